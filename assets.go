@@ -3,8 +3,12 @@ package engo
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 )
 
 // FileLoader implements support for loading and releasing file resources.
@@ -19,6 +23,13 @@ type FileLoader interface {
 	Resource(url string) (Resource, error)
 }
 
+// Reloader is implemented by FileLoaders which can reload a resource in place, such that the resources which were
+// already retrieved using `Resource` show the new data as well.
+type Reloader interface {
+	// Reload replaces the given resource, which was loaded before, by the new data.
+	Reload(url string, data io.Reader) error
+}
+
 // Resource represents a game resource, such as an image or a sound.
 type Resource interface {
 	// URL returns the uniform resource locator of the given resource.
@@ -36,6 +47,10 @@ type Formats struct {
 
 	// root is the directory which is prepended to every resource url internally.
 	root string
+
+	// roots are additional directories or URL prefixes which are searched, in order, whenever a resource could not
+	// be found within root.
+	roots []string
 }
 
 // SetRoot can be used to change the default directory from `assets` to whatever you want.
@@ -45,8 +60,11 @@ type Formats struct {
 // here: https://godoc.org/golang.org/x/mobile/asset
 //
 // You can, however, use subfolders within the `assets` folder, and set those as `root`.
+//
+// Calling SetRoot also removes any roots that were added using `AddRoot`.
 func (formats *Formats) SetRoot(root string) {
 	formats.root = root
+	formats.roots = nil
 }
 
 // GetRoot returns the folder currently set at root.
@@ -54,6 +72,28 @@ func (formats *Formats) GetRoot() string {
 	return formats.root
 }
 
+// AddRoot registers an additional root which is searched whenever a resource cannot be found within the root set by
+// `SetRoot`, or within any of the roots added before it. Roots can either be directories on the filesystem, or URL
+// prefixes such as `https://example.com/assets`. Resources behind URL prefixes are fetched using an HTTP GET request,
+// which is done by the browser on the web.
+func (formats *Formats) AddRoot(root string) {
+	formats.roots = append(formats.roots, root)
+}
+
+// GetRoots returns all roots in the order in which they are searched.
+func (formats *Formats) GetRoots() []string {
+	return append([]string{formats.root}, formats.roots...)
+}
+
+// joinRoot resolves the given url relative to root. URL prefixes are joined using forward slashes, so their scheme
+// is left intact.
+func joinRoot(root, url string) string {
+	if isURL(root) {
+		return strings.TrimSuffix(root, "/") + "/" + strings.TrimPrefix(filepath.ToSlash(url), "/")
+	}
+	return filepath.Join(root, url)
+}
+
 // Register registers a resource loader for the given file format.
 func (formats *Formats) Register(ext string, loader FileLoader) {
 	formats.formats[ext] = loader
@@ -70,13 +110,92 @@ func getExt(path string) string {
 	return ext
 }
 
+// open opens the given resource from the first root which contains it.
+func (formats *Formats) open(url string) (io.ReadCloser, error) {
+	return openPaths(formats.paths(url))
+}
+
+// paths returns the paths at which the given resource is searched, within every root in the order in which they are
+// searched. Resources which are read in the background are resolved up front, so the roots can be changed meanwhile.
+func (formats *Formats) paths(url string) []string {
+	roots := formats.GetRoots()
+	paths := make([]string, len(roots))
+	for i, root := range roots {
+		paths[i] = joinRoot(root, url)
+	}
+	return paths
+}
+
+// openPaths opens the first of the given paths which exists.
+func openPaths(paths []string) (f io.ReadCloser, err error) {
+	for _, path := range paths {
+		open := openFile
+		if isURL(path) && CurrentBackEnd != BackEndWeb {
+			open = openURL
+		}
+		if f, err = open(path); err == nil {
+			return f, nil
+		}
+	}
+	return nil, fmt.Errorf("unable to open resource: %s", err)
+}
+
+// isURL indicates whether or not the path is a URL rather than a path on the filesystem.
+func isURL(path string) bool {
+	return strings.Contains(path, "://")
+}
+
+// httpClient is used for fetching the resources behind URL roots. Its timeout keeps a server which stalls from
+// blocking the loading of a resource forever.
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// openURL fetches the resource at the given URL using HTTP. It's used on the backends whose openFile only opens
+// files, while on the web openFile already requests every path from the server.
+func openURL(url string) (io.ReadCloser, error) {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("http error: %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+// readPaths reads all data of the first of the given paths which exists. Reading stops with ErrLoadCanceled once
+// canceled is closed, unless it's nil.
+func readPaths(paths []string, canceled <-chan struct{}) ([]byte, error) {
+	f, err := openPaths(paths)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ioutil.ReadAll(cancelReader{f, canceled})
+}
+
+// cancelReader is a reader which stops reading once canceled is closed, see readPaths.
+type cancelReader struct {
+	io.Reader
+	canceled <-chan struct{}
+}
+
+func (r cancelReader) Read(p []byte) (int, error) {
+	select {
+	case <-r.canceled:
+		return 0, ErrLoadCanceled
+	default:
+		return r.Reader.Read(p)
+	}
+}
+
 // load loads the given resource into memory.
 func (formats *Formats) load(url string) error {
 	ext := getExt(url)
 	if loader, ok := Files.formats[ext]; ok {
-		f, err := openFile(filepath.Join(formats.root, url))
+		f, err := formats.open(url)
 		if err != nil {
-			return fmt.Errorf("unable to open resource: %s", err)
+			return err
 		}
 		defer f.Close()
 
@@ -105,6 +224,44 @@ func (formats *Formats) LoadReaderData(url string, f io.Reader) error {
 	return fmt.Errorf("no `FileLoader` associated with this extension: %q in url %q", ext, url)
 }
 
+// Reload reads the given resource again, which is replaced in place whenever its `FileLoader` is a `Reloader`, and
+// simply loaded again otherwise.
+func (formats *Formats) Reload(url string) error {
+	ext := getExt(url)
+	loader, ok := Files.formats[ext]
+	if !ok {
+		return fmt.Errorf("no `FileLoader` associated with this extension: %q in url %q", ext, url)
+	}
+
+	f, err := formats.open(url)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if reloader, ok := loader.(Reloader); ok {
+		return reloader.Reload(url, f)
+	}
+	return loader.Load(url, f)
+}
+
+// ModTime returns the time at which the given resource was last modified, as found in the first root which contains
+// it. Only resources within directories have a modification time, rather than those behind URL prefixes or on
+// mobile.
+func (formats *Formats) ModTime(url string) (time.Time, error) {
+	err := fmt.Errorf("resource not found in any directory: %q", url)
+	for _, root := range formats.GetRoots() {
+		if isURL(root) {
+			continue
+		}
+		var info os.FileInfo
+		if info, err = os.Stat(joinRoot(root, url)); err == nil {
+			return info.ModTime(), nil
+		}
+	}
+	return time.Time{}, err
+}
+
 // Unload releases the given resource from memory.
 func (formats *Formats) Unload(url string) error {
 	ext := getExt(url)
@@ -0,0 +1,124 @@
+package engo
+
+import (
+	"bytes"
+	"errors"
+	"time"
+)
+
+// ErrLoadCanceled is the error of an AsyncLoad which was canceled, see `AsyncLoad.Cancel`.
+var ErrLoadCanceled = errors.New("loading the resource was canceled")
+
+// asyncBudget is how long loading the assets which were read in the background may take per frame, at least one of
+// them is loaded every frame in which one was read.
+const asyncBudget = 5 * time.Millisecond
+
+// asyncLoads are the assets which are being loaded using `Formats.LoadAsync`.
+var asyncLoads []*AsyncLoad
+
+// AsyncLoad is a resource which is being loaded in the background, see `Formats.LoadAsync`.
+type AsyncLoad struct {
+	formats *Formats
+	url     string
+	read    chan assetFile
+	// canceled is closed by Cancel, which stops reading the file, and done is closed once it's no longer being read
+	canceled chan struct{}
+	done     chan struct{}
+
+	loaded bool
+	err    error
+}
+
+// LoadAsync starts loading the given resource in the background, and returns a handle to it, which reports once it's
+// loaded. Unlike `Load`, it returns immediately, so the game keeps running while assets stream in, such as for open
+// worlds. The file is read in the background, and passed to its `FileLoader` at the start of a later frame, on the
+// main thread, since loaders may upload textures to the GPU. LoadAsync itself should be called from the main thread
+// as well, such as from within a System.
+//
+// Once loaded, the resource is kept by its FileLoader like any other resource, regardless of the handle: it can be
+// retrieved using `Resource`, and has to be released using `Unload`. Dropping the handle of a resource which is still
+// being loaded doesn't stop loading it; use `AsyncLoad.Cancel` for that. Loading the same resource multiple times
+// reads it multiple times, so keep the handle around instead. The roots in which the resource is searched are those
+// of the moment LoadAsync is called, so changing them afterwards doesn't affect it.
+func (formats *Formats) LoadAsync(url string) *AsyncLoad {
+	l := &AsyncLoad{
+		formats:  formats,
+		url:      url,
+		read:     make(chan assetFile, 1),
+		canceled: make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	paths := formats.paths(url)
+	go func() {
+		defer close(l.done)
+		data, err := readPaths(paths, l.canceled)
+		l.read <- assetFile{data, err}
+	}()
+	asyncLoads = append(asyncLoads, l)
+	return l
+}
+
+// URL returns the url of the resource which is being loaded.
+func (l *AsyncLoad) URL() string {
+	return l.url
+}
+
+// Loaded returns whether or not the resource is loaded, so it can be retrieved using `Formats.Resource`.
+func (l *AsyncLoad) Loaded() bool {
+	return l.loaded
+}
+
+// Err returns the error which occurred while loading the resource, or ErrLoadCanceled if loading was canceled. It
+// returns nil while the resource is still being loaded, and once it's loaded.
+func (l *AsyncLoad) Err() error {
+	return l.err
+}
+
+// Cancel stops loading the resource, so it won't be loaded at all, unless it already is. A file which is being read
+// stops being read shortly after, see Wait. Canceling a resource which is loaded does nothing; use `Formats.Unload`
+// to release it instead.
+func (l *AsyncLoad) Cancel() {
+	if l.pending() {
+		l.err = ErrLoadCanceled
+		close(l.canceled)
+	}
+}
+
+// Wait blocks until the file of the resource is no longer being read in the background, such as before removing the
+// files it's read from after canceling it. It doesn't load the resource, which still happens at the start of a frame.
+func (l *AsyncLoad) Wait() {
+	<-l.done
+}
+
+// pending returns whether or not the resource still has to be loaded.
+func (l *AsyncLoad) pending() bool {
+	return !l.loaded && l.err == nil
+}
+
+// loadAsync loads the assets requested using `Formats.LoadAsync` which were read so far.
+func loadAsync() {
+	start := time.Now()
+	loaded := false
+	pending := asyncLoads[:0]
+	for _, l := range asyncLoads {
+		if l.pending() && (!loaded || time.Since(start) < asyncBudget) {
+			select {
+			case f := <-l.read:
+				loaded = true
+				l.err = f.err
+				if l.err == nil {
+					l.err = l.formats.LoadReaderData(l.url, bytes.NewReader(f.data))
+				}
+				l.loaded = l.err == nil
+			default:
+			}
+		}
+		if l.pending() {
+			pending = append(pending, l)
+		}
+	}
+	for i := len(pending); i < len(asyncLoads); i++ {
+		asyncLoads[i] = nil
+	}
+	asyncLoads = pending
+}
@@ -0,0 +1,70 @@
+package engo
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// countingLoader is a FileLoader which counts how often each resource is loaded.
+type countingLoader struct {
+	testLoader
+	loaded map[string]int
+}
+
+func (l *countingLoader) Load(url string, data io.Reader) error {
+	l.loaded[url]++
+	return nil
+}
+
+func TestLoadAsync(t *testing.T) {
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &assetTestScene{})
+
+	loader := &countingLoader{loaded: make(map[string]int)}
+	Files.Register(".async", loader)
+	dir, err := ioutil.TempDir(".", "testing")
+	if err != nil {
+		t.Fatalf("failed to create temp directory for testing, error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	Files.SetRoot(dir)
+	for _, name := range []string{"a.async", "b.async"} {
+		if err = ioutil.WriteFile(filepath.Join(dir, name), []byte("testing"), 0666); err != nil {
+			t.Fatalf("failed to create temp file for testing, file: %v, error: %v", name, err)
+		}
+	}
+
+	a, b, missing := Files.LoadAsync("a.async"), Files.LoadAsync("b.async"), Files.LoadAsync("missing.async")
+	b.Cancel()
+	// The resources are searched in the roots at the time they were requested
+	Files.SetRoot(filepath.Join(dir, "elsewhere"))
+	defer Files.SetRoot(dir)
+	if a.Loaded() || loader.loaded["a.async"] != 0 {
+		t.Fatal("resource was loaded before the next frame")
+	}
+
+	updateUntil(func() bool { return a.Loaded() && missing.Err() != nil })
+	b.Wait()
+	if !a.Loaded() || a.Err() != nil || loader.loaded["a.async"] != 1 {
+		t.Errorf("resource was not loaded once. Wanted: %v loads, got: %v (error: %v)", 1, loader.loaded["a.async"], a.Err())
+	}
+	if missing.Loaded() || missing.Err() == nil {
+		t.Error("missing resource did not fail to load")
+	}
+	if b.Loaded() || b.Err() != ErrLoadCanceled || loader.loaded["b.async"] != 0 {
+		t.Errorf("canceled resource was loaded, got: %v loads (error: %v)", loader.loaded["b.async"], b.Err())
+	}
+	if len(asyncLoads) != 0 {
+		t.Errorf("finished resources were still pending, got: %v", len(asyncLoads))
+	}
+
+	a.Cancel()
+	if !a.Loaded() || a.Err() != nil {
+		t.Error("canceling a loaded resource changed it")
+	}
+}
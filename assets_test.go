@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"io"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 type assetTestScene struct{}
@@ -124,6 +127,124 @@ func TestFilesMultipleLoad(t *testing.T) {
 	}
 }
 
+func TestFilesAddRoot(t *testing.T) {
+	Files.Register(".test", &testLoader{})
+
+	content := []byte("testing")
+	primary, err := ioutil.TempDir(".", "testing")
+	if err != nil {
+		t.Errorf("failed to create temp directory for testing, error: %v", err)
+	}
+	defer os.RemoveAll(primary)
+
+	secondary, err := ioutil.TempDir(".", "testing")
+	if err != nil {
+		t.Errorf("failed to create temp directory for testing, error: %v", err)
+	}
+	defer os.RemoveAll(secondary)
+
+	Files.SetRoot(primary)
+	Files.AddRoot(secondary)
+
+	tmpfn := filepath.Join(secondary, "fallback.test")
+	if err = ioutil.WriteFile(tmpfn, content, 0666); err != nil {
+		t.Errorf("failed to create temp file for testing, file: %v, error: %v", tmpfn, err)
+	}
+
+	if err = Files.Load("fallback.test"); err != nil {
+		t.Errorf("could not load test file %v from the secondary root, error: %v", "fallback.test", err)
+	}
+
+	roots := Files.GetRoots()
+	if len(roots) != 2 || roots[0] != primary || roots[1] != secondary {
+		t.Errorf("roots were not returned in search order. Wanted: %v, got: %v", []string{primary, secondary}, roots)
+	}
+
+	Files.SetRoot(primary)
+	if len(Files.GetRoots()) != 1 {
+		t.Errorf("SetRoot did not remove the added roots, got: %v", Files.GetRoots())
+	}
+}
+
+func TestJoinRoot(t *testing.T) {
+	tests := []struct {
+		root, url, expected string
+	}{
+		{"assets", "img/a.png", filepath.Join("assets", "img/a.png")},
+		{"https://example.com/assets", "img/a.png", "https://example.com/assets/img/a.png"},
+		{"https://example.com/assets/", "/img/a.png", "https://example.com/assets/img/a.png"},
+	}
+
+	for _, test := range tests {
+		if actual := joinRoot(test.root, test.url); actual != test.expected {
+			t.Errorf("joinRoot(%q, %q) did not resolve correctly. Wanted: %v, got: %v", test.root, test.url, test.expected, actual)
+		}
+	}
+}
+
+func TestFilesURLRoot(t *testing.T) {
+	Files.Register(".test", &testLoader{})
+
+	dir, err := ioutil.TempDir(".", "testing")
+	if err != nil {
+		t.Errorf("failed to create temp directory for testing, error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	if err = ioutil.WriteFile(filepath.Join(dir, "remote.test"), []byte("testing"), 0666); err != nil {
+		t.Errorf("failed to create temp file for testing, error: %v", err)
+	}
+
+	server := httptest.NewServer(http.StripPrefix("/assets/", http.FileServer(http.Dir(dir))))
+	defer server.Close()
+
+	// The resource is fetched from the server, since it's not within the primary root.
+	Files.SetRoot("notExist")
+	Files.AddRoot(server.URL + "/assets")
+	defer Files.SetRoot("assets")
+
+	data, err := readPaths(Files.paths("remote.test"), nil)
+	if err != nil {
+		t.Fatalf("could not read test file from the URL root, error: %v", err)
+	}
+	if string(data) != "testing" {
+		t.Errorf("wrong data read from the URL root. Wanted: %q, got: %q", "testing", data)
+	}
+	if err = Files.Load("remote.test"); err != nil {
+		t.Errorf("could not load test file from the URL root, error: %v", err)
+	}
+	if err = Files.Load("missing.test"); err == nil {
+		t.Error("did not report loading a resource the server does not have as an error")
+	}
+}
+
+func TestFilesURLRootTimeout(t *testing.T) {
+	Files.Register(".test", &testLoader{})
+
+	stalled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-stalled
+	}))
+	defer server.Close()
+	defer close(stalled)
+
+	defer func(timeout time.Duration) { httpClient.Timeout = timeout }(httpClient.Timeout)
+	httpClient.Timeout = 50 * time.Millisecond
+
+	Files.SetRoot(server.URL)
+	defer Files.SetRoot("assets")
+
+	done := make(chan error)
+	go func() { done <- Files.Load("stalled.test") }()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("did not report loading from a server which stalls as an error")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("loading from a server which stalls did not time out")
+	}
+}
+
 func TestFilesLoadNotExist(t *testing.T) {
 	Files.Register(".test", &testLoader{})
 
@@ -194,3 +315,74 @@ func TestFilesResourceNoFileLoader(t *testing.T) {
 		t.Errorf("wrong error returned retrieving a resource without an associated file loader. want: %v, got: %v", expected, err.Error())
 	}
 }
+
+// testReloader records the data it was loaded and reloaded with.
+type testReloader struct {
+	testLoader
+	loaded, reloaded string
+}
+
+func (l *testReloader) Load(url string, data io.Reader) error {
+	b, err := ioutil.ReadAll(data)
+	l.loaded = string(b)
+	return err
+}
+
+func (l *testReloader) Reload(url string, data io.Reader) error {
+	b, err := ioutil.ReadAll(data)
+	l.reloaded = string(b)
+	return err
+}
+
+func TestFilesReload(t *testing.T) {
+	reloader := &testReloader{}
+	Files.Register(".reload", reloader)
+	Files.Register(".test", &testLoader{})
+
+	dir, err := ioutil.TempDir(".", "testing")
+	if err != nil {
+		t.Fatalf("failed to create temp directory for testing, error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	Files.SetRoot(dir)
+
+	fn := filepath.Join(dir, "file.reload")
+	if err = ioutil.WriteFile(fn, []byte("old"), 0666); err != nil {
+		t.Fatalf("failed to create temp file for testing, file: %v, error: %v", fn, err)
+	}
+	if err = Files.Load("file.reload"); err != nil {
+		t.Fatalf("could not load test file, error: %v", err)
+	}
+	modified, err := Files.ModTime("file.reload")
+	if err != nil {
+		t.Fatalf("could not retrieve modification time, error: %v", err)
+	}
+
+	// Resources of a Reloader are reloaded in place rather than loaded again.
+	if err = ioutil.WriteFile(fn, []byte("new"), 0666); err != nil {
+		t.Fatalf("failed to update temp file for testing, file: %v, error: %v", fn, err)
+	}
+	later := modified.Add(time.Second)
+	if err = os.Chtimes(fn, later, later); err != nil {
+		t.Fatalf("failed to change the modification time, error: %v", err)
+	}
+	if err = Files.Reload("file.reload"); err != nil {
+		t.Fatalf("could not reload test file, error: %v", err)
+	}
+	if reloader.loaded != "old" || reloader.reloaded != "new" {
+		t.Errorf("file was not reloaded in place. Wanted loaded %q, reloaded %q, got: %q, %q", "old", "new", reloader.loaded, reloader.reloaded)
+	}
+	if m, err := Files.ModTime("file.reload"); err != nil || !m.Equal(later) {
+		t.Errorf("modification time was not updated. Wanted: %v, got: %v (error: %v)", later, m, err)
+	}
+
+	if _, err = Files.ModTime("missing.reload"); err == nil {
+		t.Error("retrieving the modification time of a missing file did not return an error")
+	}
+	if err = Files.Reload("missing.reload"); err == nil {
+		t.Error("reloading a missing file did not return an error")
+	}
+	if err = Files.Reload("file.unknown"); err == nil {
+		t.Error("reloading a file without a FileLoader did not return an error")
+	}
+}
@@ -10,6 +10,9 @@ type Axis struct {
 
 // Value returns the value of an Axis.
 func (a Axis) Value() float32 {
+	if Input.ignored {
+		return AxisNeutral
+	}
 	for _, pair := range a.Pairs {
 		v := pair.Value()
 		if v != AxisNeutral {
@@ -42,6 +45,25 @@ func (keys AxisKeyPair) Value() float32 {
 	return AxisNeutral
 }
 
+// An AxisPhysicalKeyPair is a set of Min/Max values used for detecting whether or not a key has been pressed. Unlike
+// AxisKeyPair, the keys refer to their physical position on a US keyboard layout, so the axis keeps working the same
+// regardless of the keyboard layout in use. See `KeyManager` for the difference.
+type AxisPhysicalKeyPair struct {
+	Min Key
+	Max Key
+}
+
+// Value returns the value of a keypress.
+func (keys AxisPhysicalKeyPair) Value() float32 {
+	if Input.keys.GetPhysical(keys.Max).Down() {
+		return AxisMax
+	} else if Input.keys.GetPhysical(keys.Min).Down() {
+		return AxisMin
+	}
+
+	return AxisNeutral
+}
+
 // AxisMouseDirection is the direction (X or Y) which the mouse is being tracked for.
 type AxisMouseDirection uint
 
@@ -511,3 +511,26 @@ func BenchmarkInputMgr_AxisFilledState(b *testing.B) {
 //		checkAxisMouseValue(b)
 //	}
 //}
+
+// Test an axis bound to both keys and the directional pad of a gamepad.
+func TestAxisGamepadButtonPair(t *testing.T) {
+	Input = NewInputManager()
+	Input.RegisterAxis("horizontal",
+		AxisKeyPair{Min: KeyA, Max: KeyD},
+		AxisGamepadButtonPair{Gamepad: 0, Min: GamepadButtonDpadLeft, Max: GamepadButtonDpadRight},
+	)
+
+	g := Input.Gamepad(0)
+	g.Connected = true
+	g.setButton(GamepadButtonDpadLeft, true)
+	if v := Input.Axis("horizontal").Value(); v != AxisMin {
+		t.Errorf("wrong value for the directional pad. Wanted: %v, got: %v", AxisMin, v)
+	}
+
+	g.setButton(GamepadButtonDpadLeft, false)
+	Input.keys.Set(KeyD, true)
+	Input.update()
+	if v := Input.Axis("horizontal").Value(); v != AxisMax {
+		t.Errorf("wrong value for the keys. Wanted: %v, got: %v", AxisMax, v)
+	}
+}
@@ -4,40 +4,78 @@ package engo
 type Button struct {
 	Triggers []Key
 	Name     string
+	// Physical indicates the Triggers refer to the physical position of keys, rather than to what is printed on them
+	// in the current keyboard layout. See `KeyManager` for the difference.
+	Physical bool
+	// GamepadTriggers are the gamepad buttons which trigger the Button, along with its Triggers. They're set using
+	// `InputManager.RegisterGamepadButton`.
+	GamepadTriggers []ButtonGamepad
+}
+
+// state returns the state of the given trigger.
+func (b Button) state(trigger Key) KeyState {
+	if b.Physical {
+		return Input.keys.GetPhysical(trigger)
+	}
+	return Input.keys.Get(trigger)
 }
 
 // JustPressed checks whether an input was pressed in the previous frame.
 func (b Button) JustPressed() bool {
+	if Input.ignored {
+		return false
+	}
 	for _, trigger := range b.Triggers {
-		v := Input.keys.Get(trigger).JustPressed()
+		v := b.state(trigger).JustPressed()
 		if v {
 			return v
 		}
 	}
+	for _, trigger := range b.GamepadTriggers {
+		if Input.Gamepad(trigger.Gamepad).ButtonJustPressed(trigger.Button) {
+			return true
+		}
+	}
 
 	return false
 }
 
 // JustReleased checks whether an input was released in the previous frame.
 func (b Button) JustReleased() bool {
+	if Input.ignored {
+		return false
+	}
 	for _, trigger := range b.Triggers {
-		v := Input.keys.Get(trigger).JustReleased()
+		v := b.state(trigger).JustReleased()
 		if v {
 			return v
 		}
 	}
+	for _, trigger := range b.GamepadTriggers {
+		if Input.Gamepad(trigger.Gamepad).ButtonJustReleased(trigger.Button) {
+			return true
+		}
+	}
 
 	return false
 }
 
 // Down checks whether the current input is being held down.
 func (b Button) Down() bool {
+	if Input.ignored {
+		return false
+	}
 	for _, trigger := range b.Triggers {
-		v := Input.keys.Get(trigger).Down()
+		v := b.state(trigger).Down()
 		if v {
 			return v
 		}
 	}
+	for _, trigger := range b.GamepadTriggers {
+		if Input.Gamepad(trigger.Gamepad).ButtonDown(trigger.Button) {
+			return true
+		}
+	}
 
 	return false
 }
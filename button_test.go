@@ -298,3 +298,36 @@ func BenchmarkInputMgr_ButtonFilledState(b *testing.B) {
 //		checkBtnConfigSubOptimal(b)
 //	}
 //}
+
+// Test buttons triggered by both keys and gamepad buttons.
+func TestButtonGamepad(t *testing.T) {
+	Input = NewInputManager()
+	Input.RegisterButton("jump", KeySpace)
+	Input.RegisterGamepadButton("jump", ButtonGamepad{Gamepad: 1, Button: GamepadButtonA})
+
+	jump := Input.Button("jump")
+	if len(jump.Triggers) != 1 || len(jump.GamepadTriggers) != 1 {
+		t.Fatalf("keys were not kept when adding gamepad buttons, got: %v", jump)
+	}
+
+	g := Input.Gamepad(1)
+	g.Connected = true
+	g.setButton(GamepadButtonA, true)
+	if !jump.JustPressed() || !jump.Down() {
+		t.Error("pressing the gamepad button did not trigger the button")
+	}
+	g.setButton(GamepadButtonA, true)
+	if jump.JustPressed() || !jump.Down() {
+		t.Error("holding the gamepad button was reported as just pressed")
+	}
+	g.setButton(GamepadButtonA, false)
+	if !jump.JustReleased() || jump.Down() {
+		t.Error("releasing the gamepad button was not reported as just released")
+	}
+
+	Input.update()
+	Input.keys.Set(KeySpace, true)
+	if !jump.JustPressed() {
+		t.Error("the key no longer triggers the button")
+	}
+}
@@ -0,0 +1,5 @@
+package engo
+
+// clipboard holds the text on the clipboard wherever the game can't use that of the system, such as while running
+// headless or on mobile, so copying and pasting still works within the game itself. See `ClipboardText`.
+var clipboard string
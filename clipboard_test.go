@@ -0,0 +1,13 @@
+package engo
+
+import "testing"
+
+func TestClipboardText(t *testing.T) {
+	Run(RunOptions{NoRun: true, HeadlessMode: true}, &testScene{})
+	defer SetClipboardText("")
+
+	SetClipboardText("héllo")
+	if text := ClipboardText(); text != "héllo" {
+		t.Errorf("clipboard did not hold the text that was set. Wanted: %q, got: %q", "héllo", text)
+	}
+}
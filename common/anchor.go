@@ -0,0 +1,218 @@
+package common
+
+import (
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+// AnchorPoint is a point on the edges or in the center of the window, to which HUD entities can be anchored.
+type AnchorPoint uint8
+
+const (
+	// AnchorTopLeft anchors entities to the top-left corner of the window
+	AnchorTopLeft AnchorPoint = iota
+	// AnchorTop anchors entities to the center of the top edge of the window
+	AnchorTop
+	// AnchorTopRight anchors entities to the top-right corner of the window
+	AnchorTopRight
+	// AnchorLeft anchors entities to the center of the left edge of the window
+	AnchorLeft
+	// AnchorCenter anchors entities to the center of the window
+	AnchorCenter
+	// AnchorRight anchors entities to the center of the right edge of the window
+	AnchorRight
+	// AnchorBottomLeft anchors entities to the bottom-left corner of the window
+	AnchorBottomLeft
+	// AnchorBottom anchors entities to the center of the bottom edge of the window
+	AnchorBottom
+	// AnchorBottomRight anchors entities to the bottom-right corner of the window
+	AnchorBottomRight
+)
+
+// Anchor positions an entity which is drawn in screen space, such as using the HUDShader (see
+// `RenderComponent.ScreenSpace`), relative to the given point of the window, and keeps it there whenever the window
+// is resized. The offset moves the entity inwards from the edges it is anchored to, so anchoring to AnchorTopRight
+// with an offset of (10, 10) places the top-right corner of the entity 10 units to the left of and below the
+// top-right corner of the window. For the center of the window or of an edge, the offset is added to the position
+// instead.
+//
+// The returned id can be passed to `engo.Mailbox.StopListen` for the "WindowResizeMessage", to stop anchoring the
+// entity.
+func Anchor(entity SpaceFace, anchor AnchorPoint, offset engo.Point) engo.MessageHandlerId {
+	space := entity.GetSpaceComponent()
+	space.Position = anchorPosition(anchor, offset, space, hudSize())
+
+	return engo.Mailbox.Listen("WindowResizeMessage", func(msg engo.Message) {
+		resize, ok := msg.(engo.WindowResizeMessage)
+		if !ok {
+			return
+		}
+		space.Position = anchorPosition(anchor, offset, space, resizedHUDSize(resize))
+	})
+}
+
+// AnchorComponent keeps an entity drawn in screen space anchored to a point of the window by the AnchorSystem, the
+// same way as Anchor does, or stretched in between two points of the window.
+type AnchorComponent struct {
+	// Anchor is the point of the window the entity is anchored to
+	Anchor AnchorPoint
+	// Offset moves the entity inwards from the edges it is anchored to, see Anchor
+	Offset engo.Point
+
+	// Stretch stretches the entity from Anchor up to StretchTo. Along the axes on which both are in a different
+	// column or row of the window, the entity covers the space in between them; along the others it's anchored as
+	// usual. Stretching from AnchorTopLeft to AnchorTopRight makes a bar along the top of the window, and stretching
+	// from AnchorTopLeft to AnchorBottomRight covers all of it.
+	Stretch bool
+	// StretchTo is the point of the window the entity is stretched up to from Anchor
+	StretchTo AnchorPoint
+	// StretchOffset moves the end at StretchTo inwards from the edges it's at, just like Offset
+	StretchOffset engo.Point
+}
+
+type anchorEntity struct {
+	*ecs.BasicEntity
+	*SpaceComponent
+	*AnchorComponent
+}
+
+// AnchorSystem positions, and stretches, the entities with an AnchorComponent within the window. Entities are placed
+// as soon as they're added, and once during every update, so they keep their place as the window is resized, and as
+// their own size or their AnchorComponent changes. The SpaceComponent of the entity is in screen coordinates, so it's
+// meant for entities on the HUD, see `RenderComponent.ScreenSpace`.
+type AnchorSystem struct {
+	entities []anchorEntity
+	ids      entityIDs
+	// size is the size of the HUD, which is updated whenever the window is resized
+	size engo.Point
+}
+
+// New subscribes the AnchorSystem to the "WindowResizeMessage".
+func (a *AnchorSystem) New(*ecs.World) {
+	a.size = hudSize()
+	engo.Mailbox.Listen("WindowResizeMessage", func(msg engo.Message) {
+		resize, ok := msg.(engo.WindowResizeMessage)
+		if !ok {
+			return
+		}
+		a.size = resizedHUDSize(resize)
+		a.Update(0)
+	})
+}
+
+// Add adds an entity to the AnchorSystem, placing it right away. The entity needs a basic, space and anchor component to
+// be added to the system.
+func (a *AnchorSystem) Add(basic *ecs.BasicEntity, space *SpaceComponent, anchor *AnchorComponent) {
+	if !a.ids.add(basic.ID()) {
+		return
+	}
+	e := anchorEntity{basic, space, anchor}
+	a.entities = append(a.entities, e)
+	a.place(e)
+}
+
+// AddByInterface Provides a simple way to add an entity to the system that satisfies Anchorable. Any entity
+// containing, BasicEntity, SpaceComponent and AnchorComponent anonymously, automatically does this.
+func (a *AnchorSystem) AddByInterface(i ecs.Identifier) {
+	o, _ := i.(Anchorable)
+	a.Add(o.GetBasicEntity(), o.GetSpaceComponent(), o.GetAnchorComponent())
+}
+
+// Remove removes an entity from the AnchorSystem, leaving it where it is.
+func (a *AnchorSystem) Remove(basic ecs.BasicEntity) {
+	a.ids.remove(basic.ID())
+	delete := -1
+	for index, e := range a.entities {
+		if e.BasicEntity.ID() == basic.ID() {
+			delete = index
+			break
+		}
+	}
+	if delete >= 0 {
+		a.entities = append(a.entities[:delete], a.entities[delete+1:]...)
+	}
+}
+
+// Update places every entity within the window.
+func (a *AnchorSystem) Update(float32) {
+	for _, e := range a.entities {
+		a.place(e)
+	}
+}
+
+// place positions, and stretches, the entity within the HUD.
+func (a *AnchorSystem) place(e anchorEntity) {
+	space, anchor := e.SpaceComponent, e.AnchorComponent
+	position := anchorPosition(anchor.Anchor, anchor.Offset, space, a.size)
+	if anchor.Stretch {
+		from := anchorPoint(anchor.Anchor, anchor.Offset, a.size)
+		to := anchorPoint(anchor.StretchTo, anchor.StretchOffset, a.size)
+		if anchor.Anchor%3 != anchor.StretchTo%3 {
+			position.X, space.Width = stretchRange(from.X, to.X)
+		}
+		if anchor.Anchor/3 != anchor.StretchTo/3 {
+			position.Y, space.Height = stretchRange(from.Y, to.Y)
+		}
+	}
+	space.Position = position
+}
+
+// stretchRange returns the start and the length of the range in between two coordinates.
+func stretchRange(a, b float32) (start, length float32) {
+	if b < a {
+		a, b = b, a
+	}
+	return a, b - a
+}
+
+// resizedHUDSize returns the size of the HUD once the window was resized.
+func resizedHUDSize(resize engo.WindowResizeMessage) engo.Point {
+	// Unless the game scales along with the window, the HUD has the same size as the window
+	if engo.ScaleOnResize() {
+		return hudSize()
+	}
+	scale := engo.GetGlobalScale()
+	return engo.Point{X: float32(resize.NewWidth) / scale.X, Y: float32(resize.NewHeight) / scale.Y}
+}
+
+// hudSize returns the size of the area covered by the HUD, in the units of a SpaceComponent.
+func hudSize() engo.Point {
+	scale := engo.GetGlobalScale()
+	if engo.ScaleOnResize() {
+		return engo.Point{X: engo.GameWidth() / scale.X, Y: engo.GameHeight() / scale.Y}
+	}
+	return engo.Point{
+		X: engo.CanvasWidth() / engo.CanvasScale() / scale.X,
+		Y: engo.CanvasHeight() / engo.CanvasScale() / scale.Y,
+	}
+}
+
+// anchorPoint returns the position of the anchor point within a HUD of the given size, moved inwards by the offset.
+func anchorPoint(anchor AnchorPoint, offset engo.Point, size engo.Point) engo.Point {
+	return anchorPosition(anchor, offset, &SpaceComponent{}, size)
+}
+
+// anchorPosition returns the position of space when anchored within a HUD of the given size.
+func anchorPosition(anchor AnchorPoint, offset engo.Point, space *SpaceComponent, size engo.Point) engo.Point {
+	var pos engo.Point
+
+	switch anchor % 3 {
+	case 0: // left column
+		pos.X = offset.X
+	case 1: // center column
+		pos.X = (size.X-space.Width)/2 + offset.X
+	case 2: // right column
+		pos.X = size.X - space.Width - offset.X
+	}
+
+	switch anchor / 3 {
+	case 0: // top row
+		pos.Y = offset.Y
+	case 1: // center row
+		pos.Y = (size.Y-space.Height)/2 + offset.Y
+	case 2: // bottom row
+		pos.Y = size.Y - space.Height - offset.Y
+	}
+
+	return pos
+}
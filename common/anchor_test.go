@@ -0,0 +1,104 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+func TestAnchorResize(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+
+	tests := []struct {
+		anchor          AnchorPoint
+		before, resized engo.Point
+	}{
+		{AnchorTopLeft, engo.Point{X: 10, Y: 5}, engo.Point{X: 10, Y: 5}},
+		{AnchorTopRight, engo.Point{X: 340, Y: 5}, engo.Point{X: 740, Y: 5}},
+		{AnchorCenter, engo.Point{X: 185, Y: 140}, engo.Point{X: 385, Y: 240}},
+		{AnchorBottom, engo.Point{X: 185, Y: 265}, engo.Point{X: 385, Y: 465}},
+		{AnchorBottomRight, engo.Point{X: 340, Y: 265}, engo.Point{X: 740, Y: 465}},
+	}
+
+	spaces := make([]*SpaceComponent, len(tests))
+	for i, test := range tests {
+		spaces[i] = &SpaceComponent{Width: 50, Height: 30}
+		Anchor(spaces[i], test.anchor, engo.Point{X: 10, Y: 5})
+		if spaces[i].Position != test.before {
+			t.Errorf("entity anchored to %v was not positioned correctly. Wanted: %v, got: %v", test.anchor, test.before, spaces[i].Position)
+		}
+	}
+
+	engo.Mailbox.Dispatch(engo.WindowResizeMessage{OldWidth: 400, OldHeight: 300, NewWidth: 800, NewHeight: 500})
+	for i, test := range tests {
+		if spaces[i].Position != test.resized {
+			t.Errorf("entity anchored to %v was not positioned correctly after resizing. Wanted: %v, got: %v", test.anchor, test.resized, spaces[i].Position)
+		}
+	}
+}
+
+func TestAnchorSystem(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+
+	type anchorTestEntity struct {
+		ecs.BasicEntity
+		SpaceComponent
+		AnchorComponent
+	}
+
+	w := &ecs.World{}
+	a := &AnchorSystem{}
+	var anchorable *Anchorable
+	w.AddSystemInterface(a, anchorable, nil)
+
+	corner := &anchorTestEntity{BasicEntity: ecs.NewBasic(), SpaceComponent: SpaceComponent{Width: 50, Height: 30}}
+	corner.AnchorComponent = AnchorComponent{Anchor: AnchorBottomRight, Offset: engo.Point{X: 10, Y: 5}}
+	bar := &anchorTestEntity{BasicEntity: ecs.NewBasic(), SpaceComponent: SpaceComponent{Height: 20}}
+	bar.AnchorComponent = AnchorComponent{
+		Anchor:        AnchorTopLeft,
+		Offset:        engo.Point{X: 10, Y: 5},
+		Stretch:       true,
+		StretchTo:     AnchorTopRight,
+		StretchOffset: engo.Point{X: 20},
+	}
+	panel := &anchorTestEntity{BasicEntity: ecs.NewBasic()}
+	panel.AnchorComponent = AnchorComponent{Anchor: AnchorTop, Offset: engo.Point{Y: 40}, Stretch: true, StretchTo: AnchorBottom}
+	w.AddEntity(corner)
+	w.AddEntity(bar)
+	w.AddEntity(panel)
+
+	check := func(when string, e *anchorTestEntity, position engo.Point, width, height float32) {
+		if e.Position != position || e.Width != width || e.Height != height {
+			t.Errorf("entity was not placed %v. Wanted: %v %vx%v, got: %v %vx%v", when, position, width, height, e.Position, e.Width, e.Height)
+		}
+	}
+	check("once added", corner, engo.Point{X: 340, Y: 265}, 50, 30)
+	check("once added", bar, engo.Point{X: 10, Y: 5}, 370, 20)
+	check("once added", panel, engo.Point{X: 200, Y: 40}, 0, 260)
+
+	engo.Mailbox.Dispatch(engo.WindowResizeMessage{OldWidth: 400, OldHeight: 300, NewWidth: 800, NewHeight: 500})
+	check("after resizing", corner, engo.Point{X: 740, Y: 465}, 50, 30)
+	check("after resizing", bar, engo.Point{X: 10, Y: 5}, 770, 20)
+	check("after resizing", panel, engo.Point{X: 400, Y: 40}, 0, 460)
+
+	// The entity is placed again as it's resized
+	corner.Width = 100
+	a.Update(0)
+	check("after growing", corner, engo.Point{X: 690, Y: 465}, 100, 30)
+
+	w.RemoveEntity(corner.BasicEntity)
+	if len(a.entities) != 2 {
+		t.Errorf("entity was not removed, %v are left", len(a.entities))
+	}
+}
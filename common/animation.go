@@ -2,15 +2,31 @@ package common
 
 import (
 	"log"
+	"sort"
 
 	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo/math"
+)
+
+// PlaybackMode is the order in which the frames of an Animation are played.
+type PlaybackMode uint8
+
+const (
+	// PlaybackForward plays the frames from first to last. This is the default.
+	PlaybackForward PlaybackMode = iota
+	// PlaybackReverse plays the frames from last to first.
+	PlaybackReverse
+	// PlaybackPingPong plays the frames from first to last, and then back to the first, without showing the last
+	// frame twice.
+	PlaybackPingPong
 )
 
 // Animation represents properties of an animation.
 type Animation struct {
-	Name   string
-	Frames []int
-	Loop   bool
+	Name     string
+	Frames   []int
+	Loop     bool
+	Playback PlaybackMode
 }
 
 // AnimationComponent tracks animations of an entity it is part of.
@@ -23,6 +39,20 @@ type AnimationComponent struct {
 	index            int                   // What frame in the is being used
 	change           float32               // The time since the last incrementation
 	def              *Animation            // The default animation to play when nothing else is playing
+	queue            []*Animation          // The animations to play once the current one is done
+	backwards        bool                  // Whether the frames are currently played from last to first
+	returning        bool                  // Whether a PlaybackPingPong animation is on its way back
+
+	// Speed is multiplied with the time passed, to speed up or slow down the animations. A Speed of 0 pauses them,
+	// and negative values play them in reverse. It's set to 1 by NewAnimationComponent.
+	Speed float32
+
+	// OnComplete is called when an animation which does not loop has shown its last frame. It's called after the
+	// animation was stopped, so it may select the next animation. Selecting another animation before the current
+	// one completed starts over, so only the animation playing at the time completes.
+	OnComplete func()
+	// OnLoop is called whenever a looping animation has shown its last frame, and starts over.
+	OnLoop func()
 }
 
 // NewAnimationComponent creates an AnimationComponent containing all given
@@ -32,21 +62,50 @@ func NewAnimationComponent(drawables []Drawable, rate float32) AnimationComponen
 		Animations: make(map[string]*Animation),
 		Drawables:  drawables,
 		Rate:       rate,
+		Speed:      1,
 	}
 }
 
 // SelectAnimationByName sets the current animation. The name must be
-// registered.
+// registered. Any queued animations are removed.
 func (ac *AnimationComponent) SelectAnimationByName(name string) {
-	ac.CurrentAnimation = ac.Animations[name]
-	ac.index = 0
+	ac.SelectAnimationByAction(ac.Animations[name])
 }
 
 // SelectAnimationByAction sets the current animation.
 // An nil action value selects the default animation.
+// Any queued animations are removed.
 func (ac *AnimationComponent) SelectAnimationByAction(action *Animation) {
+	ac.queue = nil
+	ac.play(action)
+}
+
+// QueueAnimation plays the animation with the given name once the current animation is done, after any
+// animations queued before it. Animations that loop are done when they reach their last frame while there are
+// animations queued. Nothing is queued when no animation is playing; it's played right away instead. The name must be
+// registered.
+func (ac *AnimationComponent) QueueAnimation(name string) {
+	action, ok := ac.Animations[name]
+	if !ok {
+		log.Println("Unable to queue animation", name, "since it was not added")
+		return
+	}
+	if ac.CurrentAnimation == nil {
+		ac.play(action)
+		return
+	}
+	ac.queue = append(ac.queue, action)
+}
+
+// play sets the current animation, starting at its first frame.
+func (ac *AnimationComponent) play(action *Animation) {
 	ac.CurrentAnimation = action
+	ac.backwards = action != nil && action.Playback == PlaybackReverse
+	ac.returning = false
 	ac.index = 0
+	if action != nil && len(action.Frames) > 0 && ac.backwards != (ac.Speed < 0) {
+		ac.index = len(action.Frames) - 1 // so it's played from the last frame to the first
+	}
 }
 
 // AddDefaultAnimation adds an animation which is used when no other animation is playing.
@@ -81,20 +140,59 @@ func (ac *AnimationComponent) Cell() Drawable {
 
 // NextFrame advances the current animation by one frame.
 func (ac *AnimationComponent) NextFrame() {
-	if len(ac.CurrentAnimation.Frames) == 0 {
+	ac.step(1)
+}
+
+// step moves the current animation by one frame in the order given by its PlaybackMode, or in the opposite order if
+// direction is negative.
+func (ac *AnimationComponent) step(direction int) {
+	frames := len(ac.CurrentAnimation.Frames)
+	if frames == 0 {
 		log.Println("No frame data for this animation")
 		return
 	}
 
-	ac.index++
+	delta := 1
+	if (direction > 0) == ac.backwards {
+		delta = -1
+	}
+	ac.index += delta
 	ac.change = 0
-	if ac.index >= len(ac.CurrentAnimation.Frames) {
-		ac.index = 0
+	if ac.index < 0 || ac.index >= frames {
+		// A PlaybackPingPong animation turns around at either end, and is done once it's back where it started
+		switch {
+		case ac.CurrentAnimation.Playback == PlaybackPingPong && frames > 1:
+			ac.backwards = !ac.backwards
+			ac.index -= 2 * delta
+			if ac.returning = !ac.returning; ac.returning {
+				return
+			}
+		case delta > 0:
+			ac.index = 0
+		default:
+			ac.index = frames - 1
+		}
 
+		if len(ac.queue) > 0 {
+			next := ac.queue[0]
+			ac.queue = ac.queue[1:]
+			completed := !ac.CurrentAnimation.Loop
+			ac.play(next)
+			if completed && ac.OnComplete != nil {
+				ac.OnComplete()
+			}
+			return
+		}
 		if !ac.CurrentAnimation.Loop {
 			ac.CurrentAnimation = nil
+			if ac.OnComplete != nil {
+				ac.OnComplete()
+			}
 			return
 		}
+		if ac.OnLoop != nil {
+			ac.OnLoop()
+		}
 	}
 }
 
@@ -129,6 +227,23 @@ func (a *AnimationSystem) Remove(basic ecs.BasicEntity) {
 	}
 }
 
+// Entities returns a copy of the IDs of all entities in the AnimationSystem, in ascending order.
+func (a *AnimationSystem) Entities() []uint64 {
+	ids := make([]uint64, 0, len(a.entities))
+	for id := range a.entities {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// Get returns the components with which the entity with the given ID was added to the AnimationSystem, and false if
+// it isn't in the system, such as once it was removed.
+func (a *AnimationSystem) Get(id uint64) (*AnimationComponent, *RenderComponent, bool) {
+	e, ok := a.entities[id]
+	return e.AnimationComponent, e.RenderComponent, ok
+}
+
 // Update advances the animations of all tracked entities.
 func (a *AnimationSystem) Update(dt float32) {
 	for _, e := range a.entities {
@@ -139,10 +254,23 @@ func (a *AnimationSystem) Update(dt float32) {
 			e.AnimationComponent.SelectAnimationByAction(e.AnimationComponent.def)
 		}
 
-		e.AnimationComponent.change += dt
-		if e.AnimationComponent.change >= e.AnimationComponent.Rate {
-			e.RenderComponent.Drawable = e.AnimationComponent.Cell()
-			e.AnimationComponent.NextFrame()
+		ac := e.AnimationComponent
+		if ac.Speed == 0 {
+			continue // paused
+		}
+		direction := 1
+		if ac.Speed < 0 {
+			direction = -1
+		}
+
+		// At most one frame is advanced per update, so none are skipped at high speeds. The time left over is kept
+		// towards the next frame, up to the duration of a frame.
+		ac.change += dt * math.Abs(ac.Speed)
+		if ac.change >= ac.Rate {
+			remaining := math.Min(ac.change-ac.Rate, ac.Rate)
+			e.RenderComponent.Drawable = ac.Cell()
+			ac.step(direction)
+			ac.change = remaining
 		}
 	}
 }
@@ -2,6 +2,7 @@ package common
 
 import (
 	"bytes"
+	"fmt"
 	"log"
 	"strings"
 	"testing"
@@ -186,6 +187,198 @@ func TestAnimationComponentIntegration(t *testing.T) {
 	}
 }
 
+func TestAnimationComponentCallbacks(t *testing.T) {
+	drawables := []Drawable{
+		&TestDrawable{0},
+		&TestDrawable{1},
+		&TestDrawable{2},
+	}
+	ac := NewAnimationComponent(drawables, 0.1)
+	once := &Animation{Name: "once", Frames: []int{0, 1, 2}}
+	loop := &Animation{Name: "loop", Frames: []int{2, 1}, Loop: true}
+	ac.AddAnimations([]*Animation{once, loop})
+
+	var completed, looped int
+	ac.OnComplete = func() { completed++ }
+	ac.OnLoop = func() { looped++ }
+
+	ac.SelectAnimationByAction(once)
+	ac.NextFrame()
+	ac.NextFrame()
+	if completed != 0 {
+		t.Errorf("OnComplete was called before the last frame was shown. Got: %v calls", completed)
+	}
+	ac.NextFrame()
+	if completed != 1 || ac.CurrentAnimation != nil {
+		t.Errorf("OnComplete was not called once after the last frame. Got: %v calls, with animation %v", completed, ac.CurrentAnimation)
+	}
+
+	// Changing animations mid-play starts the new animation over, so the old one never completes
+	ac.SelectAnimationByAction(once)
+	ac.NextFrame()
+	ac.NextFrame()
+	ac.SelectAnimationByAction(once)
+	ac.NextFrame()
+	if completed != 1 {
+		t.Errorf("OnComplete was called for an animation that was changed mid-play. Got: %v calls", completed)
+	}
+
+	ac.SelectAnimationByAction(loop)
+	for i := 0; i < 5; i++ {
+		ac.NextFrame()
+	}
+	if looped != 2 || completed != 1 || ac.CurrentAnimation != loop {
+		t.Errorf("OnLoop was not called on every loop boundary. Wanted: %v loops and no completions, got: %v loops and %v completions", 2, looped, completed-1)
+	}
+
+	// The next animation can be selected from OnComplete
+	ac.OnComplete = func() { ac.SelectAnimationByAction(loop) }
+	ac.SelectAnimationByAction(once)
+	for i := 0; i < 3; i++ {
+		ac.NextFrame()
+	}
+	if ac.CurrentAnimation != loop {
+		t.Errorf("animation selected by OnComplete was not played. Wanted: %v, got: %v", loop, ac.CurrentAnimation)
+	}
+}
+
+func TestAnimationComponentQueueAnimation(t *testing.T) {
+	drawables := []Drawable{
+		&TestDrawable{0},
+		&TestDrawable{1},
+		&TestDrawable{2},
+		&TestDrawable{3},
+	}
+	basic := ecs.NewBasic()
+	ac := NewAnimationComponent(drawables, 1)
+	ac.AddAnimations([]*Animation{
+		&Animation{Name: "jab", Frames: []int{0, 1}},
+		&Animation{Name: "cross", Frames: []int{2, 3, 2}},
+		&Animation{Name: "hook", Frames: []int{1}},
+		&Animation{Name: "idle", Frames: []int{3}, Loop: true},
+	})
+	render := RenderComponent{Drawable: &TestDrawable{-1}}
+	sys := &AnimationSystem{}
+	sys.Add(&basic, &ac, &render)
+
+	var completed int
+	ac.OnComplete = func() { completed++ }
+
+	ac.QueueAnimation("jab")
+	ac.QueueAnimation("cross")
+	ac.QueueAnimation("hook")
+	if ac.CurrentAnimation == nil || ac.CurrentAnimation.Name != "jab" {
+		t.Fatalf("queueing while nothing is playing did not start the animation. Wanted: %v, got: %v", "jab", ac.CurrentAnimation)
+	}
+
+	exp := []int{0, 1, 2, 3, 2, 1}
+	for i, e := range exp {
+		sys.Update(1)
+		if id := render.Drawable.(*TestDrawable).ID; id != e {
+			t.Fatalf("queued animations were not played in order. Wanted frame %v to be %v, got: %v", i, e, id)
+		}
+	}
+	if ac.CurrentAnimation != nil || completed != 3 {
+		t.Errorf("queue was not played to the end. Wanted every animation to complete, got: %v completions and animation %v playing", completed, ac.CurrentAnimation)
+	}
+
+	// Looping animations are done once something is queued, while selecting an animation clears the queue
+	ac.SelectAnimationByName("idle")
+	ac.QueueAnimation("hook")
+	sys.Update(1)
+	if ac.CurrentAnimation == nil || ac.CurrentAnimation.Name != "hook" {
+		t.Errorf("looping animation did not stop for the queued animation. Wanted: %v, got: %v", "hook", ac.CurrentAnimation)
+	}
+	ac.QueueAnimation("jab")
+	ac.SelectAnimationByName("cross")
+	for i := 0; i < 3; i++ {
+		sys.Update(1)
+	}
+	if ac.CurrentAnimation != nil {
+		t.Errorf("selecting an animation did not clear the queue. Got animation %v playing", ac.CurrentAnimation.Name)
+	}
+}
+
+func TestAnimationComponentPlayback(t *testing.T) {
+	drawables := []Drawable{
+		&TestDrawable{0},
+		&TestDrawable{1},
+		&TestDrawable{2},
+		&TestDrawable{3},
+	}
+	tests := []struct {
+		playback PlaybackMode
+		loop     bool
+		speed    float32
+		expected []int
+	}{
+		{PlaybackForward, false, 1, []int{0, 1, 2, 3}},
+		{PlaybackForward, true, 1, []int{0, 1, 2, 3, 0, 1}},
+		{PlaybackReverse, false, 1, []int{3, 2, 1, 0}},
+		{PlaybackReverse, true, 1, []int{3, 2, 1, 0, 3, 2}},
+		{PlaybackPingPong, false, 1, []int{0, 1, 2, 3, 2, 1, 0}},
+		{PlaybackPingPong, true, 1, []int{0, 1, 2, 3, 2, 1, 0, 1, 2, 3, 2}},
+		{PlaybackReverse, false, -1, []int{0, 1, 2, 3}},
+		{PlaybackPingPong, false, -1, []int{3, 2, 1, 0, 1, 2, 3}},
+	}
+
+	for _, test := range tests {
+		ac := NewAnimationComponent(drawables, 0.1)
+		ac.Speed = test.speed
+		ac.SelectAnimationByAction(&Animation{Name: "clip", Frames: []int{0, 1, 2, 3}, Loop: test.loop, Playback: test.playback})
+
+		var actual []int
+		for ac.CurrentAnimation != nil && len(actual) < len(test.expected)+1 {
+			actual = append(actual, ac.Cell().(*TestDrawable).ID)
+			ac.step(int(test.speed))
+		}
+		if test.loop {
+			actual = actual[:len(test.expected)]
+		}
+		if fmt.Sprint(actual) != fmt.Sprint(test.expected) {
+			t.Errorf("wrong frame order for playback mode %v with looping %v at speed %v. Wanted: %v, got: %v", test.playback, test.loop, test.speed, test.expected, actual)
+		}
+	}
+}
+
+func TestAnimationSystemSpeed(t *testing.T) {
+	drawables := []Drawable{
+		&TestDrawable{0},
+		&TestDrawable{1},
+		&TestDrawable{2},
+	}
+	tests := []struct {
+		speed, dt float32
+		expected  []int
+	}{
+		{1, 0.5, []int{-1, 0, 0, 1, 1}},
+		{2, 0.5, []int{0, 1, 2, 0, 1}},
+		{0, 1, []int{-1, -1, -1, -1, -1}},
+		{-1, 1, []int{2, 1, 0, 2, 1}},
+		// Even at very high speeds, every frame is shown
+		{100, 1, []int{0, 1, 2, 0, 1}},
+	}
+
+	for _, test := range tests {
+		basic := ecs.NewBasic()
+		ac := NewAnimationComponent(drawables, 1)
+		ac.Speed = test.speed
+		ac.AddAnimation(&Animation{Name: "loop", Frames: []int{0, 1, 2}, Loop: true})
+		ac.SelectAnimationByName("loop")
+		render := RenderComponent{Drawable: &TestDrawable{-1}}
+
+		sys := &AnimationSystem{}
+		sys.Add(&basic, &ac, &render)
+		for i, expected := range test.expected {
+			sys.Update(test.dt)
+			if id := render.Drawable.(*TestDrawable).ID; id != expected {
+				t.Errorf("wrong frame after %v updates of %v seconds at speed %v. Wanted: %v, got: %v", i+1, test.dt, test.speed, expected, id)
+				break
+			}
+		}
+	}
+}
+
 func TestAnimationComponentNextFrameNoData(t *testing.T) {
 	var buf bytes.Buffer
 	log.SetOutput(&buf)
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"io"
 	"log"
+	"math"
 
 	"github.com/EngoEngine/ecs"
 	"github.com/EngoEngine/engo"
@@ -58,24 +59,58 @@ func (l *stepPlayer) Step() {
 // AudioComponent is a Component which is used by the AudioSystem
 type AudioComponent struct {
 	Player *Player
+	// Group is the name of the mixer group the sound belongs to, such as "music" or "sfx". The volume of the group,
+	// as set by AudioSystem.SetGroupVolume, is multiplied with the volume of the Player.
+	Group string
+
+	// Spatial makes the sound positional: it's attenuated and panned based on where the center of the entity's
+	// SpaceComponent is relative to the listener of the AudioSystem. Entities without a SpaceComponent are never
+	// treated as spatial.
+	//
+	// The volume falls off quadratically, as (1 - distance/MaxDistance)², so the sound is at full volume at the
+	// listener and silent from MaxDistance onwards. The sound is panned linearly by the horizontal offset: at an
+	// offset of MaxDistance to the right, it's only heard in the right speaker.
+	Spatial bool
+	// MaxDistance is the distance, in game units, at which a Spatial sound can no longer be heard. Spatial sounds
+	// without a MaxDistance are never heard.
+	MaxDistance float32
 }
 
 type audioEntity struct {
 	*ecs.BasicEntity
 	*AudioComponent
+	*SpaceComponent
+}
+
+// mixedPlayer is a playing Player, along with the volume of its left and right channels.
+type mixedPlayer struct {
+	*Player
+	left, right float64
 }
 
 // AudioSystem is a System that allows for sound effects and / or music
 type AudioSystem struct {
+	// Listener is the position Spatial sounds are heard from. When it's nil, the center of the CameraSystem is used.
+	Listener *engo.Point
+
 	entities []audioEntity
+	ids      entityIDs
+	groups   map[string]float64
+
+	world  *ecs.World
+	camera *CameraSystem
+
+	music  *musicTrack
+	fading []*musicTrack
 
 	bufsize            int
 	pauseCh, restartCh chan struct{}
-	playerCh           chan []*Player
+	playerCh           chan []mixedPlayer
 }
 
 // New is called when the AudioSystem is added to the world.
 func (a *AudioSystem) New(w *ecs.World) {
+	a.world = w
 	var err error
 	switch engo.CurrentBackEnd {
 	case engo.BackEndMobile:
@@ -103,10 +138,10 @@ func (a *AudioSystem) New(w *ecs.World) {
 	closeCh = make(chan struct{}, 1)
 	a.pauseCh = make(chan struct{}, 1)
 	a.restartCh = make(chan struct{}, 1)
-	a.playerCh = make(chan []*Player, 25)
+	a.playerCh = make(chan []mixedPlayer, 25)
 	loopClosedCh = make(chan struct{})
 	go func() {
-		players := make([]*Player, 0)
+		players := make([]mixedPlayer, 0)
 	loop:
 		for {
 			select {
@@ -117,7 +152,9 @@ func (a *AudioSystem) New(w *ecs.World) {
 			case players = <-a.playerCh:
 			default:
 				buf := make([]byte, 2048)
-				a.read(buf, players)
+				if _, err := a.read(buf, players); err != nil {
+					log.Printf("error reading from audio players: %v \r\n", err)
+				}
 
 				if _, err := otoPlayer.Write(buf); err != nil {
 					log.Printf("error copying to OtoPlayer: %v \r\n", err)
@@ -126,24 +163,38 @@ func (a *AudioSystem) New(w *ecs.World) {
 		}
 		loopClosedCh <- struct{}{}
 	}()
-	masterVolume = 1
 }
 
 // Add adds an entity to the AudioSystem
 func (a *AudioSystem) Add(basic *ecs.BasicEntity, audio *AudioComponent) {
-	a.entities = append(a.entities, audioEntity{basic, audio})
+	a.AddWithSpace(basic, audio, nil)
+}
+
+// AddWithSpace adds an entity to the AudioSystem, along with the SpaceComponent used to position it whenever its
+// AudioComponent is Spatial.
+func (a *AudioSystem) AddWithSpace(basic *ecs.BasicEntity, audio *AudioComponent, space *SpaceComponent) {
+	if !a.ids.add(basic.ID()) {
+		return
+	}
+	a.entities = append(a.entities, audioEntity{basic, audio, space})
 }
 
 // AddByInterface Allows an Entity to be added directly using the Audioable interface,
 // which every entity containing the BasicEntity and AnimationComponent anonymously,
-// automatically satisfies.
+// automatically satisfies. If the entity contains a SpaceComponent as well, it is
+// added using AddWithSpace.
 func (a *AudioSystem) AddByInterface(i ecs.Identifier) {
 	o, _ := i.(Audioable)
-	a.Add(o.GetBasicEntity(), o.GetAudioComponent())
+	var space *SpaceComponent
+	if s, ok := i.(SpaceFace); ok {
+		space = s.GetSpaceComponent()
+	}
+	a.AddWithSpace(o.GetBasicEntity(), o.GetAudioComponent(), space)
 }
 
 // Remove removes an entity from the AudioSystem
 func (a *AudioSystem) Remove(basic ecs.BasicEntity) {
+	a.ids.remove(basic.ID())
 	delete := -1
 	for index, e := range a.entities {
 		if e.BasicEntity.ID() == basic.ID() {
@@ -156,23 +207,118 @@ func (a *AudioSystem) Remove(basic ecs.BasicEntity) {
 	}
 }
 
-// Update doesn't do anything since audio is run on it's own thread
+// Entities returns a copy of the IDs of all entities in the AudioSystem.
+func (a *AudioSystem) Entities() []uint64 {
+	ids := make([]uint64, len(a.entities))
+	for i, e := range a.entities {
+		ids[i] = e.ID()
+	}
+	return ids
+}
+
+// BasicEntities returns a copy of the BasicEntities of all entities in the AudioSystem.
+func (a *AudioSystem) BasicEntities() []ecs.BasicEntity {
+	basics := make([]ecs.BasicEntity, len(a.entities))
+	for i, e := range a.entities {
+		basics[i] = *e.BasicEntity
+	}
+	return basics
+}
+
+// Get returns the components with which the entity with the given ID was added to the AudioSystem, and false if it
+// isn't in the system, such as once it was removed. The SpaceComponent is nil for sounds without a position.
+func (a *AudioSystem) Get(id uint64) (*AudioComponent, *SpaceComponent, bool) {
+	for _, e := range a.entities {
+		if e.ID() == id {
+			return e.AudioComponent, e.SpaceComponent, true
+		}
+	}
+	return nil, nil, false
+}
+
+// SetGroupVolume sets the volume of the mixer group with the given name, which is multiplied with the volume of
+// every sound in the group. This affects sounds that are already playing as well. The volume is clamped to be between
+// zero and one.
+func (a *AudioSystem) SetGroupVolume(group string, volume float64) {
+	if a.groups == nil {
+		a.groups = make(map[string]float64)
+	}
+	a.groups[group] = math.Min(math.Max(volume, 0), 1)
+}
+
+// GroupVolume returns the volume of the mixer group with the given name. Groups have a volume of one until it's set
+// using SetGroupVolume.
+func (a *AudioSystem) GroupVolume(group string) float64 {
+	if volume, ok := a.groups[group]; ok {
+		return volume
+	}
+	return 1
+}
+
+// listener returns the position Spatial sounds are heard from, and false if there is none.
+func (a *AudioSystem) listener() (engo.Point, bool) {
+	if a.Listener != nil {
+		return *a.Listener, true
+	}
+	if a.camera == nil && a.world != nil {
+		for _, system := range a.world.Systems() {
+			if cam, ok := system.(*CameraSystem); ok {
+				a.camera = cam
+				break
+			}
+		}
+	}
+	if a.camera == nil {
+		return engo.Point{}, false
+	}
+	return a.camera.center(), true
+}
+
+// volume returns the volume at which the left and right channel of the entity are mixed, apart from the volume of its
+// Player.
+func (a *AudioSystem) volume(e audioEntity) (left, right float64) {
+	volume := GetMasterVolume() * a.GroupVolume(e.Group)
+	if !e.Spatial || e.SpaceComponent == nil {
+		return volume, volume
+	}
+	listener, ok := a.listener()
+	if !ok {
+		return volume, volume
+	}
+	if e.MaxDistance <= 0 {
+		return 0, 0
+	}
+
+	center := e.SpaceComponent.Center()
+	distance := float64(center.PointDistance(listener) / e.MaxDistance)
+	if distance >= 1 {
+		return 0, 0
+	}
+	volume *= (1 - distance) * (1 - distance)
+
+	pan := math.Min(math.Max(float64((center.X-listener.X)/e.MaxDistance), -1), 1)
+	return volume * math.Min(1-pan, 1), volume * math.Min(1+pan, 1)
+}
+
+// Update fades the music, and passes the playing players, and the volumes they're mixed at, on to the audio thread.
 func (a *AudioSystem) Update(dt float32) {
+	a.updateMusic(dt)
 	if len(a.playerCh) >= 25 { //if the channel is full just return so we don't block the update loop
 		return
 	}
-	players := make([]*Player, 0)
+	players := make([]mixedPlayer, 0)
 	for _, e := range a.entities {
 		if e.Player.isPlaying {
-			players = append(players, e.Player)
+			left, right := a.volume(e)
+			players = append(players, mixedPlayer{e.Player, left, right})
 		}
 	}
-	a.playerCh <- players
+	a.playerCh <- a.musicPlayers(players)
 }
 
 // Read reads from all the currently playing entities and combines them into a
 // single stream that is passed to the oto player.
-func (a *AudioSystem) read(b []byte, players []*Player) (int, error) {
+func (a *AudioSystem) read(b []byte, players []mixedPlayer) (int, error) {
 	l := len(b)
 	l &= mask
 
@@ -182,17 +328,29 @@ func (a *AudioSystem) read(b []byte, players []*Player) (int, error) {
 	}
 
 	b16s := [][]int16{}
+	mixed := make([]mixedPlayer, 0, len(players))
 	for _, player := range players {
 		buf, err := player.bufferToInt16(l)
+		if err == errPlayerClosed {
+			// The player was closed after it was passed to the audio thread, such as music which was faded out
+			continue
+		}
 		if err != nil {
 			return 0, err
 		}
 		b16s = append(b16s, buf)
+		mixed = append(mixed, player)
 	}
+	players = mixed
+	// The samples of the left and right channel are interleaved
 	for i := 0; i < l/2; i++ {
 		x := 0
-		for _, b16 := range b16s {
-			x += int(b16[i])
+		for j, b16 := range b16s {
+			if i%channelNum == 0 {
+				x += int(float64(b16[i]) * players[j].left)
+			} else {
+				x += int(float64(b16[i]) * players[j].right)
+			}
 		}
 		if x > (1<<15)-1 {
 			x = (1 << 15) - 1
@@ -0,0 +1,119 @@
+package common
+
+// MusicGroup is the mixer group of the music played using AudioSystem.CrossfadeTo. Its volume can be changed using
+// AudioSystem.SetGroupVolume, just like that of any other group.
+const MusicGroup = "music"
+
+// musicTrack is a Player started by CrossfadeTo, which is faded in or out.
+type musicTrack struct {
+	player *Player
+	// gain is the volume of the fade, between zero and one
+	gain float64
+	// rate is the change in gain per second
+	rate float64
+}
+
+// step fades the track for dt seconds.
+func (t *musicTrack) step(dt float32) {
+	t.gain += t.rate * float64(dt)
+	if t.gain >= 1 {
+		t.gain, t.rate = 1, 0
+	} else if t.gain <= 0 {
+		t.gain, t.rate = 0, 0
+	}
+}
+
+// Music returns the music track which was last passed to CrossfadeTo, or nil if there is none.
+func (a *AudioSystem) Music() *Player {
+	if a.music == nil {
+		return nil
+	}
+	return a.music.player
+}
+
+// CrossfadeTo switches the background music to the given Player, which is played on repeat. The current track is
+// faded out while the new one is faded in, both over the given duration in seconds. Once the current track is silent,
+// it's closed, so it should not be used afterwards. A duration of zero switches tracks immediately, and passing nil
+// fades out the music without starting another track.
+//
+// Requesting another crossfade while one is in progress retargets it: every track except the newest one is faded out
+// from its current volume, and the newest is faded in, again over the given duration. Passing a track which is still
+// fading out fades it back in from where it was.
+func (a *AudioSystem) CrossfadeTo(player *Player, duration float32) {
+	var next *musicTrack
+	if player != nil {
+		next = &musicTrack{player: player}
+		if a.music != nil && a.music.player == player {
+			next, a.music = a.music, nil
+		}
+		for i, track := range a.fading {
+			if track.player == player {
+				next = track
+				a.fading = append(a.fading[:i], a.fading[i+1:]...)
+				break
+			}
+		}
+	}
+	if a.music != nil {
+		a.fading = append(a.fading, a.music)
+	}
+	a.music = next
+
+	if duration <= 0 {
+		for _, track := range a.fading {
+			track.player.Close()
+		}
+		a.fading = nil
+	} else {
+		for _, track := range a.fading {
+			track.rate = -track.gain / float64(duration)
+		}
+	}
+
+	if next == nil {
+		return
+	}
+	if duration <= 0 {
+		next.gain, next.rate = 1, 0
+	} else {
+		next.rate = (1 - next.gain) / float64(duration)
+	}
+	player.Repeat = true
+	if !player.IsPlaying() {
+		player.Play()
+	}
+}
+
+// updateMusic fades the music tracks for dt seconds. Tracks which were faded out are closed.
+func (a *AudioSystem) updateMusic(dt float32) {
+	if a.music != nil {
+		a.music.step(dt)
+	}
+
+	fading := a.fading[:0]
+	for _, track := range a.fading {
+		track.step(dt)
+		if track.gain == 0 {
+			track.player.Close()
+			continue
+		}
+		fading = append(fading, track)
+	}
+	a.fading = fading
+}
+
+// musicPlayers appends the playing music tracks to players, along with the volume they're mixed at.
+func (a *AudioSystem) musicPlayers(players []mixedPlayer) []mixedPlayer {
+	volume := GetMasterVolume() * a.GroupVolume(MusicGroup)
+	tracks := a.fading
+	if a.music != nil {
+		tracks = append([]*musicTrack{a.music}, tracks...)
+	}
+	for _, track := range tracks {
+		if track.player.isPlaying {
+			gain := volume * track.gain
+			players = append(players, mixedPlayer{track.player, gain, gain})
+		}
+	}
+	return players
+}
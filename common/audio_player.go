@@ -1,7 +1,7 @@
 package common
 
 import (
-	"fmt"
+	"errors"
 	"io"
 	"log"
 	"runtime"
@@ -11,6 +11,9 @@ import (
 	"github.com/EngoEngine/engo/common/internal/decode/convert"
 )
 
+// errPlayerClosed is returned whenever a Player is used after it was closed.
+var errPlayerClosed = errors.New("audio: the player is already closed")
+
 // SampleRate is the sample rate at which the player plays audio. Any audios
 // resource that is added to the system is resampled to this sample rate. To
 // change the sample rate, you must do so BEFORE adding the audio system to the world.
@@ -97,7 +100,7 @@ func (p *Player) Close() error {
 		<-p.closedCh
 		return nil
 	case <-p.readLoopEndedCh:
-		return fmt.Errorf("audio: the player is already closed")
+		return errPlayerClosed
 	}
 }
 
@@ -107,7 +110,7 @@ func (p *Player) bufferToInt16(lengthInBytes int) ([]int16, error) {
 		r := <-p.proceededCh
 		return r.buf, r.err
 	case <-p.readLoopEndedCh:
-		return nil, fmt.Errorf("audio: the player is already closed")
+		return nil, errPlayerClosed
 	}
 }
 
@@ -257,7 +260,7 @@ func (p *Player) Seek(offset time.Duration) error {
 	case p.seekCh <- seekArgs{o, io.SeekStart}:
 		return <-p.seekedCh
 	case <-p.readLoopEndedCh:
-		return fmt.Errorf("audio: the player is already closed")
+		return errPlayerClosed
 	}
 }
 
@@ -294,14 +297,15 @@ func (p *Player) SetVolume(volume float64) {
 	}
 
 	p.sync(func() {
-		p.volume = volume * masterVolume
+		p.volume = volume
 	})
 }
 
-var masterVolume float64
+var masterVolume float64 = 1
 
 // SetMasterVolume sets the master volume. The masterVolume is multiplied by all
-// the other volumes to get the volume of each entity played.
+// the other volumes to get the volume of each entity played. This happens while
+// the sounds are mixed, so it affects sounds that are already playing as well.
 // Value must be between 0 and 1 or else it doesn't set.
 func SetMasterVolume(volume float64) {
 	if volume < 0 || volume > 1 {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"io"
 	"log"
+	"math"
 	"strings"
 	"testing"
 	"time"
@@ -374,3 +375,208 @@ func TestAudioMasterVolume(t *testing.T) {
 		t.Errorf("Logged value was not what was expected. Got: %v\n", buf.String())
 	}
 }
+
+func TestAudioSystemGroupVolume(t *testing.T) {
+	a := &AudioSystem{}
+	if v := a.GroupVolume("music"); v != 1 {
+		t.Errorf("Initial group volume was not 1, got: %v", v)
+	}
+	a.SetGroupVolume("music", 0.5)
+	if v := a.GroupVolume("music"); v != 0.5 {
+		t.Errorf("Group volume was not 0.5 after being set to it, got: %v", v)
+	}
+	if v := a.GroupVolume("sfx"); v != 1 {
+		t.Errorf("Setting the volume of one group changed another, got: %v", v)
+	}
+	a.SetGroupVolume("sfx", -1)
+	if v := a.GroupVolume("sfx"); v != 0 {
+		t.Errorf("Group volume was not clamped to 0, got: %v", v)
+	}
+	a.SetGroupVolume("sfx", 10)
+	if v := a.GroupVolume("sfx"); v != 1 {
+		t.Errorf("Group volume was not clamped to 1, got: %v", v)
+	}
+
+	// The volume is worked out whenever the sounds are mixed, so changes apply to sounds that are already playing.
+	music := audioEntity{AudioComponent: &AudioComponent{Group: "music"}}
+	ui := audioEntity{AudioComponent: &AudioComponent{}}
+	SetMasterVolume(0.5)
+	defer SetMasterVolume(1)
+	if v, _ := a.volume(music); v != 0.25 {
+		t.Errorf("Master and group volume were not combined. Wanted: 0.25, got: %v", v)
+	}
+	if v, _ := a.volume(ui); v != 0.5 {
+		t.Errorf("Sound without a group was not mixed at the master volume. Wanted: 0.5, got: %v", v)
+	}
+	a.SetGroupVolume("music", 0)
+	if v, _ := a.volume(music); v != 0 {
+		t.Errorf("Muting the group did not affect its sound, got: %v", v)
+	}
+}
+
+func TestAudioSystemSpatial(t *testing.T) {
+	a := &AudioSystem{Listener: &engo.Point{X: 100, Y: 100}}
+	// The center of the SpaceComponent is used as the position of the sound.
+	space := &SpaceComponent{Position: engo.Point{X: 90, Y: 90}, Width: 20, Height: 20}
+	e := audioEntity{AudioComponent: &AudioComponent{Spatial: true, MaxDistance: 100}, SpaceComponent: space}
+
+	tests := []struct {
+		position    engo.Point
+		left, right float64
+	}{
+		{engo.Point{X: 90, Y: 90}, 1, 1},
+		{engo.Point{X: 90, Y: 140}, 0.25, 0.25},
+		{engo.Point{X: 140, Y: 90}, 0.125, 0.25},
+		{engo.Point{X: 40, Y: 90}, 0.25, 0.125},
+		{engo.Point{X: 190, Y: 90}, 0, 0},
+		{engo.Point{X: 90, Y: 300}, 0, 0},
+	}
+	for _, test := range tests {
+		space.Position = test.position
+		left, right := a.volume(e)
+		if math.Abs(left-test.left) > 1e-6 || math.Abs(right-test.right) > 1e-6 {
+			t.Errorf("Wrong volume for sound at %v. Wanted: (%v, %v), got: (%v, %v)", test.position, test.left, test.right, left, right)
+		}
+	}
+
+	// Non-spatial sounds are not affected by their position.
+	space.Position = engo.Point{X: 500, Y: 500}
+	e.Spatial = false
+	if left, right := a.volume(e); left != 1 || right != 1 {
+		t.Errorf("Non-spatial sound was attenuated. Wanted: (1, 1), got: (%v, %v)", left, right)
+	}
+	e.Spatial = true
+	e.SpaceComponent = nil
+	if left, right := a.volume(e); left != 1 || right != 1 {
+		t.Errorf("Spatial sound without a SpaceComponent was attenuated. Wanted: (1, 1), got: (%v, %v)", left, right)
+	}
+
+	// Without a Listener, the center of the camera is used.
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &testScene{})
+	w := &ecs.World{}
+	cam := &CameraSystem{}
+	w.AddSystem(cam)
+	cam.x, cam.y = 300, 200
+	a = &AudioSystem{world: w}
+	e.SpaceComponent = &SpaceComponent{Position: engo.Point{X: 350, Y: 200}}
+	if left, right := a.volume(e); left != 0.125 || right != 0.25 {
+		t.Errorf("Camera was not used as the listener. Wanted: (0.125, 0.25), got: (%v, %v)", left, right)
+	}
+
+	// The location of the camera is multiplied by the GlobalScale, while the entity is in world units
+	engo.SetGlobalScale(engo.Point{X: 2, Y: 2})
+	defer engo.SetGlobalScale(engo.Point{X: 1, Y: 1})
+	cam.x, cam.y = 600, 400
+	if left, right := a.volume(e); left != 0.125 || right != 0.25 {
+		t.Errorf("GlobalScale was not undone for the listener. Wanted: (0.125, 0.25), got: (%v, %v)", left, right)
+	}
+}
+
+func TestAudioSystemCrossfade(t *testing.T) {
+	engo.Files.SetRoot("testdata")
+	urls := []string{"1.ogg", "TripleShot.mp3", "sfx_coin_double2.wav"}
+	players := make([]*Player, len(urls))
+	for i, url := range urls {
+		if err := engo.Files.Load(url); err != nil {
+			t.Fatalf("Could not load file. Error was: %v", err)
+		}
+		defer engo.Files.Unload(url)
+		p, err := LoadedPlayer(url)
+		if err != nil {
+			t.Fatalf("Could not get player. Error was: %v", err)
+		}
+		players[i] = p
+	}
+	first, second, third := players[0], players[1], players[2]
+
+	gains := func(a *AudioSystem) map[*Player]float64 {
+		g := make(map[*Player]float64)
+		for _, p := range a.musicPlayers(nil) {
+			g[p.Player] = p.left
+		}
+		return g
+	}
+
+	a := &AudioSystem{}
+	a.CrossfadeTo(first, 0)
+	if !first.IsPlaying() || !first.Repeat {
+		t.Error("Music was not played on repeat")
+	}
+	if g := gains(a); g[first] != 1 {
+		t.Errorf("Music without a fade was not at full volume, got: %v", g[first])
+	}
+
+	a.CrossfadeTo(second, 1)
+	a.updateMusic(0.25)
+	if g := gains(a); math.Abs(g[first]-0.75) > 1e-6 || math.Abs(g[second]-0.25) > 1e-6 {
+		t.Errorf("Tracks were not crossfaded. Wanted: 0.75 and 0.25, got: %v and %v", g[first], g[second])
+	}
+
+	// Retargeting fades out both tracks from where they were, and fades in the newest.
+	a.CrossfadeTo(third, 0.5)
+	a.updateMusic(0.25)
+	if g := gains(a); math.Abs(g[first]-0.375) > 1e-6 || math.Abs(g[second]-0.125) > 1e-6 || math.Abs(g[third]-0.5) > 1e-6 {
+		t.Errorf("Crossfade was not retargeted. Wanted: 0.375, 0.125 and 0.5, got: %v, %v and %v", g[first], g[second], g[third])
+	}
+	a.updateMusic(0.5)
+	if g := gains(a); len(g) != 1 || g[third] != 1 {
+		t.Errorf("Only the newest track should be left at full volume, got: %v", g)
+	}
+	if a.Music() != third {
+		t.Error("Music did not return the newest track")
+	}
+	if err := first.Close(); err == nil {
+		t.Error("Track which was faded out was not closed")
+	}
+	if err := second.Close(); err == nil {
+		t.Error("Track which was faded out was not closed")
+	}
+
+	// The music group is used for the volume of the music.
+	a.SetGroupVolume(MusicGroup, 0.5)
+	if g := gains(a); g[third] != 0.5 {
+		t.Errorf("Music was not mixed at the volume of the music group. Wanted: 0.5, got: %v", g[third])
+	}
+	third.Pause()
+}
+
+type failingSource struct{}
+
+func (failingSource) Read([]byte) (int, error)       { return 0, errors.New("Read Error") }
+func (failingSource) Seek(int64, int) (int64, error) { return 0, nil }
+func (failingSource) Close() error                   { return nil }
+
+func TestAudioSystemReadErrors(t *testing.T) {
+	closed, err := newPlayer(failingSource{}, "closed")
+	if err != nil {
+		t.Fatalf("Could not create player. Error was: %v", err)
+	}
+	closed.Close()
+
+	// Players which were closed after they were passed to the audio thread are left out of the mix.
+	a := &AudioSystem{}
+	buf := make([]byte, 2048)
+	if _, err := a.read(buf, []mixedPlayer{{closed, 1, 1}}); err != nil {
+		t.Errorf("Reading from a closed player returned an error: %v", err)
+	}
+
+	// Any other error is returned, once the player fails to read from its source.
+	failing, err := newPlayer(failingSource{}, "failing")
+	if err != nil {
+		t.Fatalf("Could not create player. Error was: %v", err)
+	}
+	defer failing.Close()
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, err = a.read(buf, []mixedPlayer{{failing, 1, 1}}); err != nil || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err == nil || err == errPlayerClosed {
+		t.Errorf("Reading from a failing player did not return its error, got: %v", err)
+	}
+}
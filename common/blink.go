@@ -0,0 +1,132 @@
+package common
+
+import "github.com/EngoEngine/ecs"
+
+// Blink toggles a RenderComponent between shown and hidden, such as during the invincibility frames of a character
+// which was hit. It's started using `BlinkSystem.Blink`, and used to adjust or stop the blinking.
+type Blink struct {
+	// Opacity is the opacity of the RenderComponent while it's blinked off. It's hidden instead while Opacity is
+	// zero, see `RenderComponent.Hidden`; a translucent flash is made by setting it to 0.3 or so.
+	Opacity float32
+	// OnComplete is called once the blinking ended after its duration, but not when it's stopped using Stop
+	OnComplete func()
+
+	ren                         *RenderComponent
+	interval, duration, elapsed float32
+	// hidden and opacity are the visibility of the RenderComponent before it started blinking, which is restored
+	// once it ends
+	hidden  bool
+	opacity float32
+	stopped bool
+}
+
+// Stop stops the blinking right away, restoring the visibility the RenderComponent had before it started blinking.
+func (b *Blink) Stop() {
+	if b.stopped {
+		return
+	}
+	b.stopped = true
+	b.ren.Hidden = b.hidden
+	b.ren.SetOpacity(b.opacity)
+}
+
+// Stopped returns whether the blinking was stopped, or has ended after its duration.
+func (b *Blink) Stopped() bool {
+	return b.stopped
+}
+
+// update progresses the blinking by dt seconds, and returns whether it ended after its duration.
+func (b *Blink) update(dt float32) bool {
+	b.elapsed += dt
+	if b.duration > 0 && b.elapsed >= b.duration {
+		b.Stop()
+		return true
+	}
+
+	// The RenderComponent is blinked off first, for interval seconds, and shown for the next interval
+	off := true
+	if b.interval > 0 {
+		off = int(b.elapsed/b.interval)%2 == 0
+	}
+	switch {
+	case !off:
+		b.ren.Hidden = b.hidden
+		b.ren.SetOpacity(b.opacity)
+	case b.Opacity > 0:
+		b.ren.Hidden = b.hidden
+		b.ren.SetOpacity(b.Opacity)
+	default:
+		b.ren.Hidden = true
+		b.ren.SetOpacity(b.opacity)
+	}
+	return false
+}
+
+// BlinkSystem blinks RenderComponents on and off at a steady rate, based on the time it's updated with, so they blink
+// at the same rate whatever the frame rate is. Blinks aren't linked to entities, so they have to be stopped if the
+// RenderComponent they're blinking goes away.
+type BlinkSystem struct {
+	blinks []*Blink
+}
+
+// Blink starts blinking the RenderComponent off and on, each for interval seconds, starting off during the next
+// update. It blinks for duration seconds, or until it's stopped when the duration is zero or less, after which the
+// RenderComponent is shown or hidden, and as opaque, as it was before it started blinking. Blinking a RenderComponent
+// which is blinking already stops the blinking it was doing.
+func (bs *BlinkSystem) Blink(ren *RenderComponent, interval, duration float32) *Blink {
+	for _, b := range bs.blinks {
+		if b.ren == ren {
+			b.Stop()
+		}
+	}
+	b := &Blink{ren: ren, interval: interval, duration: duration, hidden: ren.Hidden, opacity: ren.Opacity()}
+	bs.blinks = append(bs.blinks, b)
+	return b
+}
+
+// Blinking returns whether the RenderComponent is blinking.
+func (bs *BlinkSystem) Blinking(ren *RenderComponent) bool {
+	for _, b := range bs.blinks {
+		if b.ren == ren && !b.stopped {
+			return true
+		}
+	}
+	return false
+}
+
+// Stop stops every blinking RenderComponent, see `Blink.Stop`.
+func (bs *BlinkSystem) Stop() {
+	for _, b := range bs.blinks {
+		b.Stop()
+	}
+}
+
+// Remove does nothing, since blinks aren't linked to entities. It implements the ecs.System interface.
+func (*BlinkSystem) Remove(ecs.BasicEntity) {}
+
+// Update progresses every blinking RenderComponent by dt seconds. Blinks which were stopped or ended are removed.
+func (bs *BlinkSystem) Update(dt float32) {
+	var completed []*Blink
+	blinks := bs.blinks[:0]
+	for _, b := range bs.blinks {
+		if b.stopped {
+			continue
+		}
+		if b.update(dt) {
+			completed = append(completed, b)
+			continue
+		}
+		blinks = append(blinks, b)
+	}
+	for i := len(blinks); i < len(bs.blinks); i++ {
+		bs.blinks[i] = nil
+	}
+	bs.blinks = blinks
+
+	// The callbacks are called last, since they may start blinking again
+	for _, b := range completed {
+		if b.OnComplete != nil {
+			b.OnComplete()
+		}
+	}
+}
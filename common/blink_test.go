@@ -0,0 +1,64 @@
+package common
+
+import "testing"
+
+func TestBlinkSystem(t *testing.T) {
+	bs := &BlinkSystem{}
+	ren := &RenderComponent{}
+	completed := 0
+	b := bs.Blink(ren, 0.1, 0.5)
+	b.OnComplete = func() { completed++ }
+
+	// Blinking depends on the time passed rather than on the amount of frames
+	for _, step := range []struct {
+		dt     float32
+		hidden bool
+	}{
+		{0.05, true},
+		{0.1, false},
+		{0.01, false},
+		{0.1, true},
+		{0.1, false},
+	} {
+		bs.Update(step.dt)
+		if ren.Hidden != step.hidden {
+			t.Errorf("wrong visibility after %v seconds. Wanted hidden: %v, got: %v", b.elapsed, step.hidden, ren.Hidden)
+		}
+	}
+
+	bs.Update(0.2)
+	if ren.Hidden || !b.Stopped() || completed != 1 || bs.Blinking(ren) {
+		t.Error("blinking did not end after its duration, leaving the RenderComponent shown")
+	}
+}
+
+func TestBlinkSystemOpacity(t *testing.T) {
+	bs := &BlinkSystem{}
+	ren := &RenderComponent{Hidden: true}
+	ren.SetOpacity(0.8)
+
+	// Blinking without a duration keeps going until it's stopped
+	b := bs.Blink(ren, 1, 0)
+	b.Opacity = 0.25
+	bs.Update(100.5)
+	if !ren.Hidden || ren.Opacity() != 0.25 {
+		t.Errorf("RenderComponent was not blinked off to the Opacity, got opacity %v", ren.Opacity())
+	}
+	bs.Update(1)
+	if ren.Opacity() != 0.8 {
+		t.Errorf("RenderComponent was not blinked on to its own opacity, got %v", ren.Opacity())
+	}
+
+	// Blinking again stops the previous blink, so the visibility from before both is restored
+	bs.Update(1)
+	again := bs.Blink(ren, 1, 0)
+	if !b.Stopped() || ren.Opacity() != 0.8 {
+		t.Error("blinking again did not stop the previous blink")
+	}
+	bs.Update(0.5)
+	again.Stop()
+	bs.Update(0.5)
+	if !ren.Hidden || ren.Opacity() != 0.8 || bs.Blinking(ren) || len(bs.blinks) != 0 {
+		t.Errorf("stopping did not restore the visibility from before blinking, got hidden: %v, opacity: %v", ren.Hidden, ren.Opacity())
+	}
+}
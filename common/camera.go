@@ -24,6 +24,9 @@ const (
 	// KeyboardScrollerPriority is the priority for the KeyboardScrollerSystem.
 	// Priorities determine the order in which the system is updated.
 	KeyboardScrollerPriority = 130
+	// GamepadScrollerPriority is the priority for the GamepadScroller.
+	// Priorities determine the order in which the system is updated.
+	GamepadScrollerPriority = 135
 	// EntityScrollerPriority is the priority for the EntityScrollerSystem.
 	// Priorities determine the order in which the system is updated.
 	EntityScrollerPriority = 140
@@ -38,11 +41,42 @@ var (
 	// rendered surface. Larger numbers of MaxZoom allows greater
 	// perceived zooming "out".
 	MaxZoom float32 = 3
+	// IntegerZoom snaps the zoom level at which the camera is drawn, so the world is always drawn at a whole multiple
+	// of its size (or a whole fraction of it, when zoomed out), which keeps pixel art crisp along with FilterNearest.
+	// The zoom level of the camera itself is kept as is, so zooming in small steps still adds up. ScreenToWorld and
+	// WorldToScreen use the snapped zoom level as well, so the MouseSystem hits entities exactly where they're drawn.
+	// The world is only drawn at whole pixels if the game is drawn onto the window at a whole scale as well, see
+	// `engo.RunOptions.ScaleOnResize` and `engo.SetGlobalScale`.
+	IntegerZoom bool
+	// YUp flips the y-axis of the world, so it points up on screen instead of down, like in a math-style coordinate
+	// system. The world is mirrored vertically around the location of the camera, and ScreenToWorld and WorldToScreen
+	// mirror positions the same way, so the MouseSystem still hits entities exactly where they're drawn. The
+	// SpaceComponent of an entity is unchanged, so its Position is the corner of its AABB at the bottom left of the
+	// screen. Sprites and text are kept upright, while shapes are drawn as they are in the world. The HUD isn't
+	// affected.
+	YUp bool
 
 	// CameraBounds is the bounding box of the camera
 	CameraBounds engo.AABB
+
+	// MaxShakeMagnitude is the largest distance, in game units, the camera can be offset by overlapping calls to
+	// Shake.
+	MaxShakeMagnitude float32 = 50
 )
 
+// cameraShake is a random offset of the Camera, which decays over time.
+type cameraShake struct {
+	magnitude        float32
+	duration, time   float32
+	offsetX, offsetY float32
+}
+
+// cameraRotation is a rotation of the Camera by delta degrees, which is interpolated over duration seconds.
+type cameraRotation struct {
+	from, delta    float32
+	duration, time float32
+}
+
 type cameraEntity struct {
 	*ecs.BasicEntity
 	*SpaceComponent
@@ -59,6 +93,19 @@ type CameraSystem struct {
 	// angle is the angle of the camera, in degrees (not radians!)
 	angle float32
 
+	// bounds is the area the visible region of the Camera stays within, if hasBounds is set
+	bounds    engo.AABB
+	hasBounds bool
+
+	// rotation is the rotation started by RotateTo or RotateBy, if any
+	rotation cameraRotation
+
+	// shake is the current shake of the Camera, which only offsets the location at which it is drawn
+	shake cameraShake
+
+	// dispatched is the state of the Camera which was last sent in a CameraChangedMessage
+	dispatched CameraChangedMessage
+
 	longTasks map[CameraAxis]*CameraMessage
 }
 
@@ -85,6 +132,7 @@ func (cam *CameraSystem) New(w *ecs.World) {
 	cam.z = 1
 
 	cam.longTasks = make(map[CameraAxis]*CameraMessage)
+	cam.dispatched = cam.state()
 
 	engo.Mailbox.Listen("CameraMessage", func(msg engo.Message) {
 		cammsg, ok := msg.(CameraMessage)
@@ -96,6 +144,9 @@ func (cam *CameraSystem) New(w *ecs.World) {
 		if _, ok := cam.longTasks[cammsg.Axis]; ok {
 			delete(cam.longTasks, cammsg.Axis)
 		}
+		if cammsg.Axis == Angle {
+			cam.rotation = cameraRotation{}
+		}
 
 		if cammsg.Duration > time.Duration(0) {
 			cam.longTasks[cammsg.Axis] = &cammsg
@@ -118,6 +169,8 @@ func (cam *CameraSystem) Remove(ecs.BasicEntity) {}
 
 // Update updates the camera. lLong tasks are attempted to update incrementally in batches.
 func (cam *CameraSystem) Update(dt float32) {
+	defer cam.dispatchChange()
+
 	for axis, longTask := range cam.longTasks {
 		if !longTask.Incremental {
 			longTask.Incremental = true
@@ -154,6 +207,9 @@ func (cam *CameraSystem) Update(dt float32) {
 		}
 	}
 
+	cam.updateRotation(dt)
+	cam.updateShake(dt)
+
 	if cam.tracking.BasicEntity == nil {
 		return
 	}
@@ -190,16 +246,95 @@ func (cam *CameraSystem) Y() float32 {
 	return cam.y
 }
 
+// center returns the location of the Camera in world units, which is at the center of the screen. Unlike X and Y,
+// which are multiplied by the GlobalScale, it can be compared to the positions of SpaceComponents.
+func (cam *CameraSystem) center() engo.Point {
+	return engo.Point{X: cam.x / engo.GetGlobalScale().X, Y: cam.y / engo.GetGlobalScale().Y}
+}
+
 // Z returns the Z-coordinate of the location of the Camera.
 func (cam *CameraSystem) Z() float32 {
 	return cam.z
 }
 
-// Angle returns the angle (in degrees) at which the Camera is rotated.
+// Angle returns the angle (in degrees) at which the Camera is rotated. The Camera rotates around its location, so
+// whatever is at its location stays at the center of the screen.
 func (cam *CameraSystem) Angle() float32 {
 	return cam.angle
 }
 
+// ScreenToWorld translates a position on screen, such as the position of the mouse, into "game coordinates". It takes
+// the location, zoom level and rotation of the Camera into account.
+func (cam *CameraSystem) ScreenToWorld(x, y float32) (float32, float32) {
+	var wx, wy float32
+	z := cam.renderZ()
+	switch engo.CurrentBackEnd {
+	case engo.BackEndGLFW, engo.BackEndSDL, engo.BackEndVulkan, engo.BackEndHeadless:
+		// The position within the window is translated into the part of the window the game is drawn in first
+		gx, gy := engo.WindowToGame(x, y)
+		wx = (gx * z) + (cam.x-(engo.GameWidth()/2)*z)/engo.GetGlobalScale().X
+		wy = (gy * z) + (cam.y-(engo.GameHeight()/2)*z)/engo.GetGlobalScale().Y
+	case engo.BackEndMobile, engo.BackEndWeb:
+		wx = x*z + (cam.x-(engo.GameWidth()/2)*z+(engo.ResizeXOffset/2))/engo.GetGlobalScale().X
+		wy = y*z + (cam.y-(engo.GameHeight()/2)*z+(engo.ResizeYOffset/2))/engo.GetGlobalScale().Y
+	}
+
+	// Mirror and rotate around the center of the Camera if needed
+	cx, cy := cam.x/engo.GetGlobalScale().X, cam.y/engo.GetGlobalScale().Y
+	if YUp {
+		wy = 2*cy - wy
+	}
+	if cam.angle != 0 {
+		wx, wy = rotateAround(wx, wy, cx, cy, -cam.angle)
+	}
+	return wx, wy
+}
+
+// ZoomTowards zooms the Camera by zoomDelta, like a CameraMessage on the ZAxis, and moves it so the given point in the
+// world stays at the same position on screen. This zooms towards the point under the cursor, like map applications
+// do. Both the zoom level and the movement are limited as usual, by MinZoom, MaxZoom and CameraBounds.
+func (cam *CameraSystem) ZoomTowards(x, y, zoomDelta float32) {
+	sx, sy := cam.WorldToScreen(x, y)
+	cam.zoom(zoomDelta)
+
+	// Move by the distance the point moved away from its position on screen
+	wx, wy := cam.ScreenToWorld(sx, sy)
+	cam.moveX(x - wx)
+	cam.moveY(y - wy)
+}
+
+// WorldToScreen translates "game coordinates" into a position on screen. It is the inverse of ScreenToWorld.
+func (cam *CameraSystem) WorldToScreen(x, y float32) (float32, float32) {
+	// Undo the rotation and mirroring around the center of the Camera first
+	cx, cy := cam.x/engo.GetGlobalScale().X, cam.y/engo.GetGlobalScale().Y
+	if cam.angle != 0 {
+		x, y = rotateAround(x, y, cx, cy, cam.angle)
+	}
+	if YUp {
+		y = 2*cy - y
+	}
+
+	var sx, sy float32
+	z := cam.renderZ()
+	switch engo.CurrentBackEnd {
+	case engo.BackEndGLFW, engo.BackEndSDL, engo.BackEndVulkan, engo.BackEndHeadless:
+		vp := engo.GameViewport()
+		sx = (x-(cam.x-(engo.GameWidth()/2)*z)/engo.GetGlobalScale().X)*(vp.Max.X-vp.Min.X)/(z*engo.GameWidth()) + vp.Min.X/engo.GetGlobalScale().X
+		sy = (y-(cam.y-(engo.GameHeight()/2)*z)/engo.GetGlobalScale().Y)*(vp.Max.Y-vp.Min.Y)/(z*engo.GameHeight()) + vp.Min.Y/engo.GetGlobalScale().Y
+	case engo.BackEndMobile, engo.BackEndWeb:
+		sx = (x - (cam.x-(engo.GameWidth()/2)*z+(engo.ResizeXOffset/2))/engo.GetGlobalScale().X) / z
+		sy = (y - (cam.y-(engo.GameHeight()/2)*z+(engo.ResizeYOffset/2))/engo.GetGlobalScale().Y) / z
+	}
+	return sx, sy
+}
+
+// rotateAround rotates the point (x, y) counter-clockwise by the angle in degrees, around the center (cx, cy).
+func rotateAround(x, y, cx, cy, angle float32) (float32, float32) {
+	sin, cos := math.Sincos(angle * math.Pi / 180)
+	dx, dy := x-cx, y-cy
+	return cx + dx*cos - dy*sin, cy + dy*cos + dx*sin
+}
+
 func (cam *CameraSystem) moveAxis(axis CameraAxis, value float32) {
 	switch axis {
 	case XAxis:
@@ -234,6 +369,7 @@ func (cam *CameraSystem) moveX(value float32) {
 	} else {
 		cam.x += value * engo.GetGlobalScale().X
 	}
+	cam.clampToBounds()
 }
 
 func (cam *CameraSystem) moveY(value float32) {
@@ -244,6 +380,7 @@ func (cam *CameraSystem) moveY(value float32) {
 	} else {
 		cam.y += value * engo.GetGlobalScale().Y
 	}
+	cam.clampToBounds()
 }
 
 func (cam *CameraSystem) zoom(value float32) {
@@ -256,26 +393,245 @@ func (cam *CameraSystem) rotate(value float32) {
 
 func (cam *CameraSystem) moveToX(location float32) {
 	cam.x = mgl32.Clamp(location*engo.GetGlobalScale().X, CameraBounds.Min.X*engo.GetGlobalScale().X, CameraBounds.Max.X*engo.GetGlobalScale().X)
+	cam.clampToBounds()
 }
 
 func (cam *CameraSystem) moveToY(location float32) {
 	cam.y = mgl32.Clamp(location*engo.GetGlobalScale().Y, CameraBounds.Min.Y*engo.GetGlobalScale().Y, CameraBounds.Max.Y*engo.GetGlobalScale().Y)
+	cam.clampToBounds()
 }
 
 func (cam *CameraSystem) zoomTo(zoomLevel float32) {
 	cam.z = mgl32.Clamp(zoomLevel, MinZoom, MaxZoom)
+	cam.clampToBounds()
 }
 
 func (cam *CameraSystem) rotateTo(rotation float32) {
 	cam.angle = math.Mod(rotation, 360)
 }
 
+// RotateTo smoothly rotates the Camera to the given angle, in degrees, over duration seconds. It takes the shortest way
+// around, so rotating from 350 to 10 degrees turns the Camera by 20 degrees rather than by -340. A duration of zero
+// rotates the Camera instantly, much like a CameraMessage on the Angle axis.
+func (cam *CameraSystem) RotateTo(angle, duration float32) {
+	cam.RotateBy(shortestRotation(cam.angle, angle), duration)
+}
+
+// RotateBy smoothly rotates the Camera by the given amount of degrees over duration seconds. Unlike RotateTo, the
+// rotation is not shortened, so rotating by 720 degrees turns the Camera around twice.
+func (cam *CameraSystem) RotateBy(angle, duration float32) {
+	delete(cam.longTasks, Angle)
+	if duration <= 0 {
+		cam.rotation = cameraRotation{}
+		cam.rotate(angle)
+		return
+	}
+	cam.rotation = cameraRotation{from: cam.angle, delta: angle, duration: duration}
+}
+
+// updateRotation advances the rotation started by RotateTo or RotateBy by dt seconds. The rotation eases in and out,
+// and ends at exactly the requested angle.
+func (cam *CameraSystem) updateRotation(dt float32) {
+	if cam.rotation.duration == 0 {
+		return
+	}
+	cam.rotation.time += dt
+	t := cam.rotation.time / cam.rotation.duration
+	if t >= 1 {
+		cam.rotateTo(cam.rotation.from + cam.rotation.delta)
+		cam.rotation = cameraRotation{}
+		return
+	}
+	cam.rotateTo(cam.rotation.from + cam.rotation.delta*t*t*(3-2*t))
+}
+
+// shortestRotation returns the rotation, in degrees between -180 and 180, which turns from the angle from to the angle
+// to.
+func shortestRotation(from, to float32) float32 {
+	delta := math.Mod(to-from, 360)
+	if delta > 180 {
+		delta -= 360
+	} else if delta < -180 {
+		delta += 360
+	}
+	return delta
+}
+
+// state returns the current state of the Camera, as sent in a CameraChangedMessage.
+func (cam *CameraSystem) state() CameraChangedMessage {
+	return CameraChangedMessage(cam.GetState())
+}
+
+// GetState returns the location, zoom level and angle of the Camera, which can be restored later on using SetState.
+func (cam *CameraSystem) GetState() CameraState {
+	return CameraState{X: cam.x, Y: cam.y, Z: cam.z, Angle: cam.angle}
+}
+
+// SetState moves, zooms and rotates the Camera to the given state at once, such as to return to where it was before a
+// cutscene. Movements in progress, by CameraMessages with a Duration or by RotateTo and RotateBy, are stopped. The
+// state is limited as usual, by MinZoom, MaxZoom, CameraBounds and SetBounds. A followed entity is still followed,
+// so the Camera moves back to it during the next update.
+func (cam *CameraSystem) SetState(state CameraState) {
+	cam.longTasks = make(map[CameraAxis]*CameraMessage)
+	cam.rotation = cameraRotation{}
+
+	cam.zoomTo(state.Z)
+	cam.moveToX(state.X / engo.GetGlobalScale().X)
+	cam.moveToY(state.Y / engo.GetGlobalScale().Y)
+	cam.rotateTo(state.Angle)
+}
+
+// dispatchChange sends a CameraChangedMessage if the Camera changed since the previous one was sent.
+func (cam *CameraSystem) dispatchChange() {
+	if state := cam.state(); state != cam.dispatched {
+		cam.dispatched = state
+		engo.Mailbox.Dispatch(state)
+	}
+}
+
+// Shake shakes the Camera for the given amount of seconds, by offsetting the location at which it is drawn in a random
+// direction every frame. The offset is at most magnitude game units, and decays smoothly to zero. Shaking while the
+// Camera is already shaking adds to the remaining magnitude, up to MaxShakeMagnitude. The location of the Camera
+// itself, as returned by X and Y, does not change.
+func (cam *CameraSystem) Shake(duration, magnitude float32) {
+	magnitude += cam.shake.current()
+	if magnitude > MaxShakeMagnitude {
+		magnitude = MaxShakeMagnitude
+	}
+	if remaining := cam.shake.duration - cam.shake.time; remaining > duration {
+		duration = remaining
+	}
+	cam.shake = cameraShake{magnitude: magnitude, duration: duration}
+}
+
+// updateShake advances the shake of the Camera by dt seconds, and picks a new random offset using `engo.Random`.
+func (cam *CameraSystem) updateShake(dt float32) {
+	if cam.shake.duration == 0 {
+		return
+	}
+	cam.shake.time += dt
+	if cam.shake.time >= cam.shake.duration {
+		cam.shake = cameraShake{}
+		return
+	}
+	magnitude := cam.shake.current()
+	cam.shake.offsetX = engo.Random.Range(-1, 1) * magnitude
+	cam.shake.offsetY = engo.Random.Range(-1, 1) * magnitude
+}
+
+// current returns the magnitude of the shake at this point in time. It decays quadratically, so the shake ends
+// smoothly.
+func (s cameraShake) current() float32 {
+	if s.duration == 0 {
+		return 0
+	}
+	remaining := 1 - s.time/s.duration
+	return s.magnitude * remaining * remaining
+}
+
+// renderX returns the X-coordinate of the location at which the Camera is drawn, including the offset of a Shake.
+func (cam *CameraSystem) renderX() float32 {
+	return cam.x + cam.shake.offsetX
+}
+
+// renderY returns the Y-coordinate of the location at which the Camera is drawn, including the offset of a Shake.
+func (cam *CameraSystem) renderY() float32 {
+	return cam.y + cam.shake.offsetY
+}
+
+// viewOffset returns the translation of the view matrix with which the Camera is drawn, which rotates the world
+// around the location of the Camera after moving that location to the origin.
+func (cam *CameraSystem) viewOffset() (float32, float32) {
+	x, y := -cam.renderX(), -cam.renderY()
+	if cam.angle == 0 {
+		return x, y
+	}
+	return rotateAround(x, y, 0, 0, cam.angle)
+}
+
+// yAxis returns the direction of the y-axis of the world on screen, -1 while YUp is set and 1 otherwise.
+func yAxis() float32 {
+	if YUp {
+		return -1
+	}
+	return 1
+}
+
+// renderZ returns the zoom level at which the Camera is drawn, see snapZoom.
+func (cam *CameraSystem) renderZ() float32 {
+	return snapZoom(cam.z)
+}
+
+// snapZoom snaps the zoom level to a whole number, or one over a whole number when zoomed in, while IntegerZoom is set.
+func snapZoom(z float32) float32 {
+	if !IntegerZoom || z <= 0 {
+		return z
+	}
+	if z < 1 {
+		return 1 / math.Floor(1/z+0.5)
+	}
+	return math.Floor(z + 0.5)
+}
+
+// SetBounds limits the movement of the Camera, so the visible region never extends outside of the given area of the
+// world. Unlike CameraBounds, which limits the location of the Camera itself, this takes the zoom level into account.
+// Whenever the area is smaller than the visible region, the Camera is centered on it instead. The rotation of the
+// Camera is not taken into account. Passing an empty AABB removes the bounds, allowing the Camera to move as far as
+// CameraBounds allows.
+func (cam *CameraSystem) SetBounds(bounds engo.AABB) {
+	cam.bounds = bounds
+	cam.hasBounds = bounds != engo.AABB{}
+	cam.clampToBounds()
+}
+
+// clampToBounds moves the Camera so its visible region is within the bounds set using SetBounds.
+func (cam *CameraSystem) clampToBounds() {
+	if !cam.hasBounds {
+		return
+	}
+	cam.x = clampVisible(cam.x, cam.bounds.Min.X*engo.GetGlobalScale().X, cam.bounds.Max.X*engo.GetGlobalScale().X, engo.GameWidth()/2*cam.renderZ())
+	cam.y = clampVisible(cam.y, cam.bounds.Min.Y*engo.GetGlobalScale().Y, cam.bounds.Max.Y*engo.GetGlobalScale().Y, engo.GameHeight()/2*cam.renderZ())
+}
+
+// clampVisible clamps the center of a visible region of the given half size, so the region is between min and max.
+// If it does not fit, the center of min and max is returned.
+func clampVisible(center, min, max, half float32) float32 {
+	if max-min < 2*half {
+		return (min + max) / 2
+	}
+	return mgl32.Clamp(center, min+half, max-half)
+}
+
 func (cam *CameraSystem) centerCam(x, y, z float32) {
 	cam.moveToX(x)
 	cam.moveToY(y)
 	cam.zoomTo(z)
 }
 
+// CameraState is the state of the Camera, as returned by `CameraSystem.GetState` and restored by
+// `CameraSystem.SetState`. It's a plain value, so it can be stored and compared.
+type CameraState struct {
+	// X and Y are the location of the camera, see `CameraSystem.X` and `CameraSystem.Y`
+	X, Y float32
+	// Z is the zoom level of the camera, see `CameraSystem.Z`
+	Z float32
+	// Angle is the angle (in degrees) at which the camera is rotated
+	Angle float32
+}
+
+// Lerp returns the state in between s, at t = 0, and to, at t = 1. The angle turns the shortest way around, like
+// `CameraSystem.RotateTo`. Along with a tween it animates the camera from one state to another:
+//
+//	NewTweenFunc(func(t float32) { cam.SetState(from.Lerp(to, t)) }, 0, 1, duration)
+func (s CameraState) Lerp(to CameraState, t float32) CameraState {
+	return CameraState{
+		X:     s.X + (to.X-s.X)*t,
+		Y:     s.Y + (to.Y-s.Y)*t,
+		Z:     s.Z + (to.Z-s.Z)*t,
+		Angle: s.Angle + shortestRotation(s.Angle, to.Angle)*t,
+	}
+}
+
 // CameraAxis is the axis at which the Camera can/has to move.
 type CameraAxis uint8
 
@@ -305,6 +661,23 @@ func (CameraMessage) Type() string {
 	return "CameraMessage"
 }
 
+// CameraChangedMessage is a message that is sent out by the CameraSystem at the end of every update in which the
+// camera moved, zoomed or rotated, containing its new state. Multiple changes during a single frame result in a single
+// message. Unlike the CameraMessage, it is only a notification, sending it does not change the camera.
+type CameraChangedMessage struct {
+	// X and Y are the location of the camera, see `CameraSystem.X` and `CameraSystem.Y`
+	X, Y float32
+	// Z is the zoom level of the camera, see `CameraSystem.Z`
+	Z float32
+	// Angle is the angle (in degrees) at which the camera is rotated
+	Angle float32
+}
+
+// Type implements the engo.Message interface.
+func (CameraChangedMessage) Type() string {
+	return "CameraChangedMessage"
+}
+
 // NewCameraMessage is a message that is sent out whenever the camera system changes,
 // such as when a new world is created or scenes are switched.
 type NewCameraMessage struct{}
@@ -314,7 +687,15 @@ func (NewCameraMessage) Type() string {
 	return "NewCameraMessage"
 }
 
+const (
+	// keyboardScrollerHorizontalAxis and keyboardScrollerVerticalAxis are the names of the axes registered by
+	// `KeyboardScroller.BindKeys`.
+	keyboardScrollerHorizontalAxis = "keyboardScrollerHorizontal"
+	keyboardScrollerVerticalAxis   = "keyboardScrollerVertical"
+)
+
 // KeyboardScroller is a System that allows for scrolling when certain keys are pressed.
+// ScrollSpeed is in game units per second, and moving diagonally is as fast as moving along a single axis.
 type KeyboardScroller struct {
 	ScrollSpeed                  float32
 	horizontalAxis, verticalAxis string
@@ -337,9 +718,15 @@ func (c *KeyboardScroller) Update(dt float32) {
 		X: engo.Input.Axis(c.horizontalAxis).Value(),
 		Y: engo.Input.Axis(c.verticalAxis).Value(),
 	}
-	n, _ := m.Normalize()
-	engo.Mailbox.Dispatch(CameraMessage{Axis: XAxis, Value: n.X * c.ScrollSpeed * dt, Incremental: true})
-	engo.Mailbox.Dispatch(CameraMessage{Axis: YAxis, Value: n.Y * c.ScrollSpeed * dt, Incremental: true})
+	if m.X == 0 && m.Y == 0 {
+		return // so other movement of the camera is not interrupted
+	}
+	// Moving diagonally is not faster than moving along a single axis, while analog axes can still move slower
+	if n, length := m.Normalize(); length > 1 {
+		m = n
+	}
+	engo.Mailbox.Dispatch(CameraMessage{Axis: XAxis, Value: m.X * c.ScrollSpeed * dt, Incremental: true})
+	engo.Mailbox.Dispatch(CameraMessage{Axis: YAxis, Value: m.Y * c.ScrollSpeed * dt, Incremental: true})
 }
 
 // BindKeyboard sets the vertical and horizontal axes used by the KeyboardScroller.
@@ -352,6 +739,14 @@ func (c *KeyboardScroller) BindKeyboard(hori, vert string) {
 	defer c.keysMu.Unlock()
 }
 
+// BindKeys sets the keys used by the KeyboardScroller for scrolling in each direction, such as the arrow keys or a
+// layout other than QWERTY. It registers the axes it uses with engo.Input, replacing those set by BindKeyboard.
+func (c *KeyboardScroller) BindKeys(up, down, left, right engo.Key) {
+	engo.Input.RegisterAxis(keyboardScrollerHorizontalAxis, engo.AxisKeyPair{Min: left, Max: right})
+	engo.Input.RegisterAxis(keyboardScrollerVerticalAxis, engo.AxisKeyPair{Min: up, Max: down})
+	c.BindKeyboard(keyboardScrollerHorizontalAxis, keyboardScrollerVerticalAxis)
+}
+
 // NewKeyboardScroller creates a new KeyboardScroller system using the provided scrollSpeed,
 // and horizontal and vertical axes.
 func NewKeyboardScroller(scrollSpeed float32, hori, vert string) *KeyboardScroller {
@@ -364,6 +759,45 @@ func NewKeyboardScroller(scrollSpeed float32, hori, vert string) *KeyboardScroll
 	return kbs
 }
 
+// GamepadScroller is a System that allows for scrolling using a stick of a gamepad. The left stick of the gamepad
+// with the given id is used, unless RightStick is set. ScrollSpeed is in game units per second when the stick is
+// pushed all the way, and the dead-zones and response curves set on the `engo.Gamepad` are taken into account.
+type GamepadScroller struct {
+	ScrollSpeed float32
+	Gamepad     int
+	RightStick  bool
+}
+
+// Priority implements the ecs.Prioritizer interface.
+func (*GamepadScroller) Priority() int { return GamepadScrollerPriority }
+
+// Remove does nothing because the GamepadScroller system has no entities. It implements the
+// ecs.System interface.
+func (*GamepadScroller) Remove(ecs.BasicEntity) {}
+
+// Update updates the camera based on the stick of the gamepad.
+func (c *GamepadScroller) Update(dt float32) {
+	g := engo.Input.Gamepad(c.Gamepad)
+	if !g.Connected {
+		return
+	}
+
+	hori, vert := engo.GamepadAxisLeftX, engo.GamepadAxisLeftY
+	if c.RightStick {
+		hori, vert = engo.GamepadAxisRightX, engo.GamepadAxisRightY
+	}
+	m := engo.Point{X: g.Axis(hori), Y: g.Axis(vert)}
+	if m.X == 0 && m.Y == 0 {
+		return // so other movement of the camera is not interrupted
+	}
+	// The corners of square gates are not faster than pushing the stick along a single axis
+	if n, length := m.Normalize(); length > 1 {
+		m = n
+	}
+	engo.Mailbox.Dispatch(CameraMessage{Axis: XAxis, Value: m.X * c.ScrollSpeed * dt, Incremental: true})
+	engo.Mailbox.Dispatch(CameraMessage{Axis: YAxis, Value: m.Y * c.ScrollSpeed * dt, Incremental: true})
+}
+
 // EntityScroller scrolls the camera to the position of a entity using its space component.
 type EntityScroller struct {
 	*SpaceComponent
@@ -412,8 +846,13 @@ func (c *EntityScroller) Update(dt float32) {
 // EdgeScroller is a System that allows for scrolling when the cursor is near the edges of
 // the window.
 type EdgeScroller struct {
+	// ScrollSpeed is the speed at which the camera moves, in game units per second
 	ScrollSpeed float32
-	EdgeMargin  float32
+	// EdgeMargin is the distance from the edges of the window, in game units, within which the cursor scrolls
+	EdgeMargin float32
+	// Disabled temporarily stops the EdgeScroller from scrolling, e.g. while a modal dialog is open. It's a
+	// negated flag so existing EdgeScrollers keep scrolling without having to set it.
+	Disabled bool
 }
 
 // Priority implements the ecs.Prioritizer interface.
@@ -424,36 +863,37 @@ func (*EdgeScroller) Priority() int { return EdgeScrollerPriority }
 func (*EdgeScroller) Remove(ecs.BasicEntity) {}
 
 // Update moves the camera based on the position of the mouse. If the mouse is on the edge
-// of the screen, the camera moves towards that edge.
-// TODO: Warning doesn't get the cursor position
+// of the screen, the camera moves towards that edge. Nothing happens while the EdgeScroller
+// is disabled, or when the window does not have focus.
 func (c *EdgeScroller) Update(dt float32) {
-	curX, curY := engo.CursorPos()
-	maxX, maxY := engo.GameWidth(), engo.GameHeight()
-
-	if curX < c.EdgeMargin && curY < c.EdgeMargin {
-		s := math.Sqrt(2)
-		engo.Mailbox.Dispatch(CameraMessage{Axis: XAxis, Value: -c.ScrollSpeed * dt / s, Incremental: true})
-		engo.Mailbox.Dispatch(CameraMessage{Axis: YAxis, Value: -c.ScrollSpeed * dt / s, Incremental: true})
-	} else if curX < c.EdgeMargin && curY > maxY-c.EdgeMargin {
-		s := math.Sqrt(2)
-		engo.Mailbox.Dispatch(CameraMessage{Axis: XAxis, Value: -c.ScrollSpeed * dt / s, Incremental: true})
-		engo.Mailbox.Dispatch(CameraMessage{Axis: YAxis, Value: c.ScrollSpeed * dt / s, Incremental: true})
-	} else if curX > maxX-c.EdgeMargin && curY < c.EdgeMargin {
-		s := math.Sqrt(2)
-		engo.Mailbox.Dispatch(CameraMessage{Axis: XAxis, Value: c.ScrollSpeed * dt / s, Incremental: true})
-		engo.Mailbox.Dispatch(CameraMessage{Axis: YAxis, Value: -c.ScrollSpeed * dt / s, Incremental: true})
-	} else if curX > maxX-c.EdgeMargin && curY > maxY-c.EdgeMargin {
-		s := math.Sqrt(2)
-		engo.Mailbox.Dispatch(CameraMessage{Axis: XAxis, Value: c.ScrollSpeed * dt / s, Incremental: true})
-		engo.Mailbox.Dispatch(CameraMessage{Axis: YAxis, Value: c.ScrollSpeed * dt / s, Incremental: true})
-	} else if curX < c.EdgeMargin {
-		engo.Mailbox.Dispatch(CameraMessage{Axis: XAxis, Value: -c.ScrollSpeed * dt, Incremental: true})
+	if c.Disabled || !engo.WindowFocused() {
+		return
+	}
+
+	curX, curY := engo.Input.Mouse.X, engo.Input.Mouse.Y
+	maxX, maxY := engo.WindowWidth()/engo.GetGlobalScale().X, engo.WindowHeight()/engo.GetGlobalScale().Y
+
+	var dir engo.Point
+	if curX < c.EdgeMargin {
+		dir.X = -1
 	} else if curX > maxX-c.EdgeMargin {
-		engo.Mailbox.Dispatch(CameraMessage{Axis: XAxis, Value: c.ScrollSpeed * dt, Incremental: true})
-	} else if curY < c.EdgeMargin {
-		engo.Mailbox.Dispatch(CameraMessage{Axis: YAxis, Value: -c.ScrollSpeed * dt, Incremental: true})
+		dir.X = 1
+	}
+	if curY < c.EdgeMargin {
+		dir.Y = -1
 	} else if curY > maxY-c.EdgeMargin {
-		engo.Mailbox.Dispatch(CameraMessage{Axis: YAxis, Value: c.ScrollSpeed * dt, Incremental: true})
+		dir.Y = 1
+	}
+	if dir.X == 0 && dir.Y == 0 {
+		return
+	}
+	dir, _ = dir.Normalize()
+
+	if dir.X != 0 {
+		engo.Mailbox.Dispatch(CameraMessage{Axis: XAxis, Value: dir.X * c.ScrollSpeed * dt, Incremental: true})
+	}
+	if dir.Y != 0 {
+		engo.Mailbox.Dispatch(CameraMessage{Axis: YAxis, Value: dir.Y * c.ScrollSpeed * dt, Incremental: true})
 	}
 }
 
@@ -476,6 +916,41 @@ func (c *MouseZoomer) Update(float32) {
 	}
 }
 
+// CursorZoomer is a System that allows for zooming towards the point under the mouse when the scroll wheel is used,
+// rather than towards the center of the screen like the MouseZoomer does.
+type CursorZoomer struct {
+	ZoomSpeed float32
+
+	camera *CameraSystem
+}
+
+// New looks up the CameraSystem which is zoomed.
+func (c *CursorZoomer) New(w *ecs.World) {
+	for _, system := range w.Systems() {
+		if sys, ok := system.(*CameraSystem); ok {
+			c.camera = sys
+		}
+	}
+	if c.camera == nil {
+		log.Println("ERROR: CameraSystem not found - have you added the `RenderSystem` before the `CursorZoomer`?")
+	}
+}
+
+// Priority implements the ecs.Prioritizer interface.
+func (*CursorZoomer) Priority() int { return MouseZoomerPriority }
+
+// Remove does nothing because CursorZoomer has no entities. This implements the
+// ecs.System interface.
+func (*CursorZoomer) Remove(ecs.BasicEntity) {}
+
+// Update zooms the camera in and out towards the mouse, based on the movement of the scroll wheel.
+func (c *CursorZoomer) Update(float32) {
+	if engo.Input.Mouse.ScrollY != 0 && c.camera != nil {
+		x, y := c.camera.ScreenToWorld(engo.Input.Mouse.X, engo.Input.Mouse.Y)
+		c.camera.ZoomTowards(x, y, engo.Input.Mouse.ScrollY*c.ZoomSpeed)
+	}
+}
+
 // MouseRotator is a System that allows for rotating the camera based on pressing
 // down the scroll wheel.
 type MouseRotator struct {
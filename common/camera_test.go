@@ -8,6 +8,7 @@ import (
 
 	"github.com/EngoEngine/ecs"
 	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/engo/math"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -180,3 +181,320 @@ func TestCameraAddOnlyOne(t *testing.T) {
 		t.Error("adding more than one CameraSystem did not write expected output to log")
 	}
 }
+
+func TestCameraScreenToWorld(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        300,
+		Height:       300,
+	}, &mouseTestScene{})
+	initialize()
+
+	x, y := cam.ScreenToWorld(150, 150)
+	assert.Equal(t, engo.Point{X: 150, Y: 150}, engo.Point{X: x, Y: y}, "The center of the screen should be at the location of the camera")
+
+	cam.zoomTo(2)
+	x, y = cam.ScreenToWorld(160, 140)
+	assert.Equal(t, engo.Point{X: 170, Y: 130}, engo.Point{X: x, Y: y}, "Zooming out should increase the distance to the location of the camera")
+
+	cam.rotateTo(30)
+	cam.moveToX(100)
+	for _, p := range []engo.Point{{X: 0, Y: 0}, {X: 160, Y: 140}, {X: 300, Y: 20}} {
+		wx, wy := cam.ScreenToWorld(p.X, p.Y)
+		sx, sy := cam.WorldToScreen(wx, wy)
+		assert.InDelta(t, p.X, sx, 0.001, "WorldToScreen should be the inverse of ScreenToWorld")
+		assert.InDelta(t, p.Y, sy, 0.001, "WorldToScreen should be the inverse of ScreenToWorld")
+	}
+}
+
+func TestCameraZoomTowards(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        300,
+		Height:       300,
+	}, &mouseTestScene{})
+	initialize()
+
+	for _, angle := range []float32{0, 45} {
+		cam.centerCam(150, 150, 1)
+		cam.rotateTo(angle)
+		sx, sy := cam.WorldToScreen(180, 120)
+
+		cam.ZoomTowards(180, 120, 0.5)
+		assert.Equal(t, float32(1.5), cam.Z(), "ZoomTowards should zoom by the given amount")
+		x, y := cam.WorldToScreen(180, 120)
+		assert.InDelta(t, sx, x, 0.001, "The point zoomed towards should stay at the same position on screen")
+		assert.InDelta(t, sy, y, 0.001, "The point zoomed towards should stay at the same position on screen")
+	}
+
+	cam.ZoomTowards(180, 120, 10)
+	assert.Equal(t, MaxZoom, cam.Z(), "Zooming towards a point should respect the maximum zoom level")
+}
+
+func TestCameraIntegerZoom(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        300,
+		Height:       300,
+	}, &mouseTestScene{})
+	initialize()
+	IntegerZoom = true
+	defer func() { IntegerZoom = false }()
+
+	for _, zoom := range []struct{ z, snapped float32 }{{1.4, 1}, {1.6, 2}, {0.45, 0.5}, {0.3, float32(1) / 3}} {
+		cam.zoomTo(zoom.z)
+		assert.Equal(t, zoom.z, cam.Z(), "The zoom level of the camera itself should not be snapped")
+		assert.Equal(t, zoom.snapped, cam.renderZ(), "The camera should be drawn at a whole zoom level")
+	}
+
+	// The mouse is translated at the zoom level the camera is drawn at
+	cam.zoomTo(1.6)
+	x, y := cam.ScreenToWorld(160, 140)
+	assert.Equal(t, engo.Point{X: 170, Y: 130}, engo.Point{X: x, Y: y}, "ScreenToWorld should use the snapped zoom level")
+	sx, sy := cam.WorldToScreen(x, y)
+	assert.Equal(t, engo.Point{X: 160, Y: 140}, engo.Point{X: sx, Y: sy}, "WorldToScreen should use the snapped zoom level")
+}
+
+func TestCameraSetBounds(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        300,
+		Height:       300,
+	}, &mouseTestScene{})
+	initialize()
+	CameraBounds = engo.AABB{Max: engo.Point{X: 1000, Y: 1000}}
+
+	cam.SetBounds(engo.AABB{Max: engo.Point{X: 600, Y: 400}})
+
+	cam.moveToX(0)
+	cam.moveToY(1000)
+	assert.Equal(t, engo.Point{X: 150, Y: 250}, engo.Point{X: cam.X(), Y: cam.Y()}, "The visible region should stay within the bounds")
+
+	cam.zoomTo(0.5) // zoomed in, so the visible region is smaller
+	cam.moveX(-1000)
+	cam.moveY(1000)
+	assert.Equal(t, engo.Point{X: 75, Y: 325}, engo.Point{X: cam.X(), Y: cam.Y()}, "Zooming in should allow moving closer to the bounds")
+
+	cam.zoomTo(2) // zoomed out, so the visible region is larger than the bounds vertically
+	assert.Equal(t, engo.Point{X: 300, Y: 200}, engo.Point{X: cam.X(), Y: cam.Y()}, "The camera should be centered on bounds smaller than the visible region")
+
+	cam.SetBounds(engo.AABB{})
+	cam.zoomTo(1)
+	cam.moveToX(0)
+	assert.Equal(t, float32(0), cam.X(), "Removing the bounds should allow moving the camera freely")
+}
+
+func TestCameraShake(t *testing.T) {
+	initialize()
+	x, y := cam.X(), cam.Y()
+
+	cam.Shake(1, 10)
+	previous := float32(10)
+	for i := 0; i < 9; i++ {
+		cam.Update(0.1)
+		magnitude := cam.shake.current()
+		assert.True(t, magnitude < previous, "The shake should decay over time")
+		assert.True(t, cam.renderX()-x <= magnitude && x-cam.renderX() <= magnitude, "The horizontal offset should be within the magnitude")
+		assert.True(t, cam.renderY()-y <= magnitude && y-cam.renderY() <= magnitude, "The vertical offset should be within the magnitude")
+		assert.Equal(t, engo.Point{X: x, Y: y}, engo.Point{X: cam.X(), Y: cam.Y()}, "Shaking should not move the camera itself")
+		previous = magnitude
+	}
+
+	cam.Update(0.2)
+	assert.Equal(t, engo.Point{X: x, Y: y}, engo.Point{X: cam.renderX(), Y: cam.renderY()}, "The shake should end after its duration")
+
+	cam.Shake(1, 40)
+	cam.Shake(0.5, 40)
+	assert.Equal(t, MaxShakeMagnitude, cam.shake.current(), "Overlapping shakes should accumulate up to the maximum magnitude")
+	assert.Equal(t, float32(1), cam.shake.duration, "Overlapping shakes should last as long as the longest one")
+
+	shake := func() (float32, float32) {
+		engo.Random.Seed(7)
+		cam.shake = cameraShake{}
+		cam.Shake(1, 10)
+		cam.Update(0.1)
+		return cam.renderX(), cam.renderY()
+	}
+	x1, y1 := shake()
+	x2, y2 := shake()
+	assert.Equal(t, engo.Point{X: x1, Y: y1}, engo.Point{X: x2, Y: y2}, "Shaking should be reproducible by seeding engo.Random")
+}
+
+func TestCameraChangedMessage(t *testing.T) {
+	initialize()
+
+	var messages []CameraChangedMessage
+	engo.Mailbox.Listen("CameraChangedMessage", func(msg engo.Message) {
+		messages = append(messages, msg.(CameraChangedMessage))
+	})
+
+	cam.Update(0.1)
+	assert.Len(t, messages, 0, "No message should be sent while the camera does not change")
+
+	engo.Mailbox.Dispatch(CameraMessage{Axis: XAxis, Value: 10, Incremental: true})
+	engo.Mailbox.Dispatch(CameraMessage{Axis: ZAxis, Value: 2})
+	cam.Update(0.1)
+	cam.Update(0.1)
+	if assert.Len(t, messages, 1, "Changes within a single frame should be sent as one message") {
+		assert.Equal(t, CameraChangedMessage{X: 160, Y: 150, Z: 2}, messages[0], "The message should contain the new state of the camera")
+	}
+}
+
+type fixedAxisPair float32
+
+func (p fixedAxisPair) Value() float32 { return float32(p) }
+
+func TestKeyboardScroller(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        300,
+		Height:       300,
+	}, &mouseTestScene{})
+	initialize()
+
+	scroller := NewKeyboardScroller(100, "", "")
+	scroller.BindKeys(engo.KeyZ, engo.KeyS, engo.KeyQ, engo.KeyD)
+	assert.Equal(t, []engo.AxisPair{engo.AxisKeyPair{Min: engo.KeyQ, Max: engo.KeyD}}, engo.Input.Axis(keyboardScrollerHorizontalAxis).Pairs, "The left and right keys should be bound")
+	assert.Equal(t, []engo.AxisPair{engo.AxisKeyPair{Min: engo.KeyZ, Max: engo.KeyS}}, engo.Input.Axis(keyboardScrollerVerticalAxis).Pairs, "The up and down keys should be bound")
+
+	engo.Input.RegisterAxis("testHorizontal", fixedAxisPair(1))
+	engo.Input.RegisterAxis("testVertical", fixedAxisPair(-1))
+	scroller.BindKeyboard("testHorizontal", "testVertical")
+	scroller.Update(0.5)
+	s := math.Sqrt(2)
+	assert.InDelta(t, 150+50/s, cam.X(), 0.001, "Moving diagonally should not be faster than moving along a single axis")
+	assert.InDelta(t, 150-50/s, cam.Y(), 0.001, "Moving diagonally should not be faster than moving along a single axis")
+
+	engo.Input.RegisterAxis("testVertical", fixedAxisPair(0))
+	x := cam.X()
+	scroller.Update(0.5)
+	assert.InDelta(t, x+50, cam.X(), 0.001, "The camera should move by ScrollSpeed units per second")
+}
+
+func TestGamepadScroller(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        300,
+		Height:       300,
+	}, &mouseTestScene{})
+	initialize()
+
+	scroller := &GamepadScroller{ScrollSpeed: 100, Gamepad: 3}
+	scroller.Update(0.5)
+	assert.Equal(t, float32(150), cam.X(), "A gamepad which is not connected should not move the camera")
+
+	engo.Input.Gamepad(3).Connected = true
+	defer func() { engo.Input.Gamepad(3).Connected = false }()
+	scroller.RightStick = true
+	scroller.Update(0.5)
+	assert.Equal(t, float32(150), cam.X(), "A centered stick should not move the camera")
+	assert.Equal(t, float32(150), cam.Y(), "A centered stick should not move the camera")
+}
+
+func TestEdgeScroller(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        300,
+		Height:       300,
+	}, &mouseTestScene{})
+	initialize()
+
+	scroller := &EdgeScroller{ScrollSpeed: 100, EdgeMargin: 20}
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 150, 150
+	scroller.Update(0.5)
+	assert.Equal(t, float32(150), cam.X(), "The camera should not move when the cursor is away from the edges")
+	assert.Equal(t, float32(150), cam.Y(), "The camera should not move when the cursor is away from the edges")
+
+	engo.Input.Mouse.X = 10
+	scroller.Update(0.5)
+	assert.InDelta(t, 100, cam.X(), 0.001, "The camera should move towards the left edge by ScrollSpeed units per second")
+	assert.Equal(t, float32(150), cam.Y(), "The camera should only move along the axis of the edge")
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 290, 290
+	scroller.Update(0.5)
+	s := math.Sqrt(2)
+	assert.InDelta(t, 100+50/s, cam.X(), 0.001, "Scrolling diagonally should not be faster than along a single axis")
+	assert.InDelta(t, 150+50/s, cam.Y(), 0.001, "Scrolling diagonally should not be faster than along a single axis")
+
+	scroller.Disabled = true
+	x, y := cam.X(), cam.Y()
+	scroller.Update(0.5)
+	assert.Equal(t, x, cam.X(), "A disabled EdgeScroller should not move the camera")
+	assert.Equal(t, y, cam.Y(), "A disabled EdgeScroller should not move the camera")
+}
+
+func TestCameraState(t *testing.T) {
+	initialize()
+
+	cam.centerCam(100, 120, 2)
+	cam.rotateTo(350)
+	saved := cam.GetState()
+	assert.Equal(t, CameraState{X: 100, Y: 120, Z: 2, Angle: 350}, saved, "GetState should return the location, zoom level and angle")
+
+	cam.centerCam(200, 220, 1)
+	cam.rotateTo(20)
+	cam.RotateBy(90, 1)
+	cam.SetState(saved)
+	assert.Equal(t, saved, cam.GetState(), "SetState should restore the saved state")
+	cam.Update(0.5)
+	assert.Equal(t, saved, cam.GetState(), "SetState should stop the rotation in progress")
+
+	halfway := saved.Lerp(CameraState{X: 200, Y: 220, Z: 1, Angle: 10}, 0.5)
+	assert.Equal(t, CameraState{X: 150, Y: 170, Z: 1.5, Angle: 360}, halfway, "Lerp should interpolate, rotating the shortest way around")
+}
+
+func TestCameraShortestRotation(t *testing.T) {
+	tests := []struct {
+		from, to, expected float32
+	}{
+		{350, 10, 20},
+		{10, 350, -20},
+		{0, 90, 90},
+		{90, 0, -90},
+		{-10, 10, 20},
+		{170, -170, 20},
+		{0, 360, 0},
+		{45, 765, 0},
+		{0, 180, 180},
+		{0, 190, -170},
+	}
+
+	for _, test := range tests {
+		assert.InDelta(t, test.expected, shortestRotation(test.from, test.to), 0.001, "Rotating from %v to %v should take the shortest path", test.from, test.to)
+	}
+}
+
+func TestCameraRotateTo(t *testing.T) {
+	initialize()
+
+	cam.rotateTo(350)
+	cam.RotateTo(10, 1)
+	cam.Update(0.5)
+	assert.InDelta(t, 0, cam.Angle(), 0.001, "Halfway through, the camera should have rotated across 0 degrees")
+	cam.Update(0.5)
+	assert.InDelta(t, 10, cam.Angle(), 0.001, "The rotation should end at exactly the target angle")
+	cam.Update(0.5)
+	assert.InDelta(t, 10, cam.Angle(), 0.001, "The camera should not rotate after the rotation ended")
+
+	cam.RotateBy(-30, 1)
+	cam.Update(0.25)
+	assert.True(t, cam.Angle() < 10 && cam.Angle() > -5, "The rotation should ease in, got %v degrees", cam.Angle())
+	cam.Update(1)
+	assert.InDelta(t, -20, cam.Angle(), 0.001, "Rotating by an amount should be relative to the current angle")
+
+	cam.RotateTo(90, 0)
+	assert.InDelta(t, 90, cam.Angle(), 0.001, "Rotating without a duration should be instant")
+
+	cam.RotateTo(180, 1)
+	engo.Mailbox.Dispatch(CameraMessage{Axis: Angle, Value: 45})
+	cam.Update(1)
+	assert.InDelta(t, 45, cam.Angle(), 0.001, "A CameraMessage on the Angle axis should stop the rotation")
+}
@@ -39,12 +39,19 @@ type SpaceComponent struct {
 // []engo.Line, with each line defining a face of the shape. The coordinates are
 // such that (0,0) is the upper left corner of the SpaceComponent. If no shapes
 // are added, the SpaceComponent is treated as an AABB.
+//
+// Hitboxes are used by Contains only. The CollisionSystem uses the Collider of the
+// CollisionComponent instead.
 func (sc *SpaceComponent) AddShape(shape Shape) {
 	sc.hitboxes = append(sc.hitboxes, shape)
 }
 
 // SetCenter positions the space component according to its center instead of its
 // top-left point (this avoids doing the same math each time in your systems)
+//
+// The center is computed from the Width and Height, which already include the Scale of
+// a RenderComponent when they're set to the scaled size of its texture, so scaled and
+// rotated sprites are centered correctly as well.
 func (sc *SpaceComponent) SetCenter(p engo.Point) {
 	xDelta := sc.Width / 2
 	yDelta := sc.Height / 2
@@ -83,12 +90,23 @@ func (sc *SpaceComponent) Center() engo.Point {
 // This basically returns the "outer rectangle" of the plane defined by the `SpaceComponent`. Since this returns two
 // points, a minimum and a maximum, the "rectangle" resulting from this `AABB`, is not rotated in any way. However,
 // depending on the rotation of the `SpaceComponent`, this `AABB` may be larger than the original `SpaceComponent`.
+//
+// The Minimum is never larger than the Maximum, not even when the Width or Height is negative. Without a Width or
+// Height, the `AABB` has no area; it still intersects every `AABB` containing it, so such a `SpaceComponent`
+// collides like a point or a line.
 func (sc SpaceComponent) AABB() engo.AABB {
 	if sc.Rotation == 0 {
-		return engo.AABB{
+		aabb := engo.AABB{
 			Min: sc.Position,
 			Max: engo.Point{X: sc.Position.X + sc.Width, Y: sc.Position.Y + sc.Height},
 		}
+		if sc.Width < 0 {
+			aabb.Min.X, aabb.Max.X = aabb.Max.X, aabb.Min.X
+		}
+		if sc.Height < 0 {
+			aabb.Min.Y, aabb.Max.Y = aabb.Max.Y, aabb.Min.Y
+		}
+		return aabb
 	}
 
 	corners := sc.Corners()
@@ -103,7 +121,8 @@ func (sc SpaceComponent) AABB() engo.AABB {
 	for i := 0; i < 4; i++ {
 		if corners[i].X < xMin {
 			xMin = corners[i].X
-		} else if corners[i].X > xMax {
+		}
+		if corners[i].X > xMax {
 			xMax = corners[i].X
 		}
 		if corners[i].Y < yMin {
@@ -140,19 +159,16 @@ func (sc SpaceComponent) Corners() (points [4]engo.Point) {
 // Contains indicates whether or not the given point is within the shape defined by this `SpaceComponent`.
 // If it's on the border, it is considered "not within".
 // If there is no shape defined, then this uses a rectangular area defined by the
-// Width/Height of the SpaceComponent instead.
+// Width/Height of the SpaceComponent instead. A negative Width or Height extends that area from the Position to the
+// left or up, rather than to the right or down. A SpaceComponent without a Width or Height has no area, and is
+// considered a line or a point instead: the point at its Position is within it, as is every point along the line
+// between its ends.
 func (sc SpaceComponent) Contains(p engo.Point) bool {
 	if len(sc.hitboxes) == 0 {
-		points := sc.Corners()
-		halfArea := (sc.Width * sc.Height) / 2
-		for i := 0; i < 4; i++ {
-			for j := i + 1; j < 4; j++ {
-				if t := triangleArea(points[i], points[j], p); t > halfArea || engo.FloatEqual(t, halfArea) {
-					return false
-				}
-			}
-		}
-		return true
+		// the point relative to the Position, in the unrotated space of the SpaceComponent
+		sin, cos := math.Sincos(sc.Rotation * math.Pi / 180)
+		dx, dy := p.X-sc.Position.X, p.Y-sc.Position.Y
+		return withinSide(dx*cos+dy*sin, sc.Width) && withinSide(dy*cos-dx*sin, sc.Height)
 	}
 	// test line from the point to an arbitrary point far away
 	// hopefully this is big enough
@@ -205,20 +221,43 @@ func (sc SpaceComponent) Contains(p engo.Point) bool {
 	return false
 }
 
-// triangleArea computes the area of the triangle given by the three points
-func triangleArea(p1, p2, p3 engo.Point) float32 {
-	// Law of cosines states: (note a2 = math.Pow(a, 2))
-	// a2 = b2 + c2 - 2bc*cos(alpha)
-	// This ends in: alpha = arccos ((-a2 + b2 + c2)/(2bc))
-	a := p1.PointDistance(p3)
-	b := p1.PointDistance(p2)
-	c := p2.PointDistance(p3)
-	alpha := math.Acos((-math.Pow(a, 2) + math.Pow(b, 2) + math.Pow(c, 2)) / (2 * b * c))
+// hits indicates whether or not the given point hits the shape defined by this `SpaceComponent`, as tested by the
+// MouseSystem. It's the same as Contains, except that points on the minimum edges of the rectangular area defined by
+// the Width/Height are considered within, while those on the maximum edges are not, just like `engo.AABB.Contains`.
+// This way, a point on the edge shared by two adjacent areas hits exactly one of them.
+func (sc SpaceComponent) hits(p engo.Point) bool {
+	if len(sc.hitboxes) > 0 {
+		return sc.Contains(p)
+	}
+	sin, cos := math.Sincos(sc.Rotation * math.Pi / 180)
+	dx, dy := p.X-sc.Position.X, p.Y-sc.Position.Y
+	return hitsSide(dx*cos+dy*sin, sc.Width) && hitsSide(dy*cos-dx*sin, sc.Height)
+}
 
-	// Law of sines state: a / sin(alpha) = c / sin(gamma)
-	height := (c / math.Sin(math.Pi/2)) * math.Sin(alpha)
+// withinSide tells whether the offset along one of the sides of a SpaceComponent is within that side, which
+// extends from 0 to size. Offsets on either end are not within, except for a side without size, which only
+// contains the offset 0.
+func withinSide(offset, size float32) bool {
+	if size == 0 {
+		return engo.FloatEqual(offset, 0)
+	}
+	if size < 0 {
+		offset, size = -offset, -size
+	}
+	return offset > 0 && offset < size && !engo.FloatEqual(offset, 0) && !engo.FloatEqual(offset, size)
+}
 
-	return (b * height) / 2
+// hitsSide tells whether the offset along one of the sides of a SpaceComponent hits that side, which extends from 0
+// to size. The smaller end is hit while the larger one isn't, except for a side without size, which is only hit at
+// the offset 0.
+func hitsSide(offset, size float32) bool {
+	if size == 0 {
+		return engo.FloatEqual(offset, 0)
+	}
+	if size < 0 {
+		return offset >= size && offset < 0
+	}
+	return offset >= 0 && offset < size
 }
 
 // CollisionComponent keeps track of the entity's collisions.
@@ -230,19 +269,84 @@ func triangleArea(p1, p2, p3 engo.Point) float32 {
 // Extra is the allowed buffer for detecting collisions.
 //
 // Collides is all the groups this component collides with ORed together
+//
+// Layer and Mask filter which pairs are tested at all, before Main and Group are
+// considered. Two entities are only tested when the Mask of each includes the Layer of
+// the other. A Mask of 0 includes every layer.
 type CollisionComponent struct {
 	// if a.Main & (bitwise) b.Group, items can collide
 	// if a.Main == 0, it will not loop for other items
 	Main, Group CollisionGroup
 	Extra       engo.Point
 	Collides    CollisionGroup
+
+	// if a.Mask & b.Layer and b.Mask & a.Layer, items can collide
+	// if a.Mask == 0, it collides with any layer
+	Layer, Mask CollisionLayer
+
+	// Collider is the shape used to test whether entities whose AABBs intersect actually collide
+	// if Collider == nil, the AABB of the SpaceComponent is used
+	// the hitboxes added using SpaceComponent.AddShape are not used for collisions, only by Contains
+	Collider CollisionShape
+
+	// Continuous tests the movement of a Main entity since the previous update, instead of only its current
+	// position, so it cannot pass through other entities when moving fast. The movement is tested using AABBs only.
+	// A Continuous entity stops at the first solid it hits, and collisions with the entities it passed through
+	// before that are reported as well.
+	//
+	// Continuous entities are not substepped using the MaxMovePerStep of the CollisionSystem, since their whole
+	// movement is swept at once. Sweeping finds the exact point of impact, but only tests AABBs, so it suits fast
+	// entities such as bullets. Leave Continuous off and use MaxMovePerStep for entities whose Collider should be
+	// tested along the way instead.
+	Continuous bool
+	// TimeOfImpact is set for Continuous entities, to the fraction of their movement since the previous update at
+	// which they first hit another entity. It is 1 whenever they didn't hit anything along the way.
+	TimeOfImpact float32
+}
+
+// includes indicates whether the Mask of the component includes the given layer.
+func (c *CollisionComponent) includes(layer CollisionLayer) bool {
+	return c.Mask == 0 || c.Mask&layer != 0
 }
 
-// CollisionMessage is sent whenever a collision is detected by the CollisionSystem.
+// layersCollide indicates whether the layers and masks of both components allow them to collide with eachother.
+func layersCollide(c1, c2 *CollisionComponent) bool {
+	return c1.includes(c2.Layer) && c2.includes(c1.Layer)
+}
+
+// CollisionMessage is sent whenever a collision is detected by the CollisionSystem. It is sent once for every pair
+// of colliding entities: Entity is the one that collided with To. If both entities collided with eachother, Entity
+// is the one with the lowest ID.
 type CollisionMessage struct {
-	Entity collisionEntity
-	To     collisionEntity
-	Groups CollisionGroup
+	Entity  collisionEntity
+	To      collisionEntity
+	Groups  CollisionGroup
+	Contact Contact
+}
+
+// Contact describes how deep two colliding entities overlap, as computed from their AABBs before the collision
+// was resolved.
+//
+// Normal is a unit vector pointing away from To, in the direction in which Entity has to move to no longer
+// overlap To. Since the Y-axis points down, an Entity landing on top of To has a Normal of (0, -1). Depth is the
+// distance Entity has to move along Normal. Both are zero whenever the entities merely touch.
+type Contact struct {
+	Normal engo.Point
+	Depth  float32
+}
+
+// newContact returns the Contact of an entity which has to move by mtd to no longer overlap another.
+func newContact(mtd engo.Point) Contact {
+	normal, depth := mtd.Normalize()
+	if depth == 0 {
+		return Contact{}
+	}
+	return Contact{Normal: normal, Depth: depth}
+}
+
+// flip returns the Contact as seen from the other entity.
+func (c Contact) flip() Contact {
+	return Contact{Normal: engo.Point{X: -c.Normal.X, Y: -c.Normal.Y}, Depth: c.Depth}
 }
 
 // CollisionGroup is intended to be used in bitwise comparisons
@@ -250,6 +354,11 @@ type CollisionMessage struct {
 // for the different kinds of collisions they hope to use
 type CollisionGroup byte
 
+// CollisionLayer is a bitfield of the layers an entity is on, or of the layers it collides
+// with. Like CollisionGroup, it is meant to be created using const ( a = 1 << iota ... ),
+// though there's room for up to 32 layers.
+type CollisionLayer uint32
+
 // Type implements the engo.Message interface
 func (CollisionMessage) Type() string { return "CollisionMessage" }
 
@@ -257,31 +366,107 @@ type collisionEntity struct {
 	*ecs.BasicEntity
 	*CollisionComponent
 	*SpaceComponent
+
+	// previous is the position of the entity at the end of the previous update.
+	previous engo.Point
+	// physics and velocity are the PhysicsComponent and VelocityComponent of the entity, if it has them.
+	physics  *PhysicsComponent
+	velocity *VelocityComponent
+}
+
+// aabb returns the AABB of the entity, grown by its allowed buffer for detecting collisions.
+func (e collisionEntity) aabb() engo.AABB {
+	aabb := e.SpaceComponent.AABB()
+	offset := engo.Point{X: e.CollisionComponent.Extra.X / 2, Y: e.CollisionComponent.Extra.Y / 2}
+	aabb.Min.X -= offset.X
+	aabb.Min.Y -= offset.Y
+	aabb.Max.X += offset.X
+	aabb.Max.Y += offset.Y
+	return aabb
+}
+
+// respond changes the velocity of the entity, if it has a PhysicsComponent and a VelocityComponent, after it was
+// pushed out of a solid in the direction of normal.
+func (e collisionEntity) respond(normal engo.Point) {
+	if e.physics != nil && e.velocity != nil {
+		e.physics.respond(e.velocity, normal)
+	}
 }
 
 // CollisionSystem is a system that detects collisions between entities, sends a message if collisions
 // are detected, and updates their SpaceComponent so entities cannot pass through Solids.
+//
+// Entities are tested using the AABB of their SpaceComponent, see `SpaceComponent.AABB`. An entity with a negative
+// Width or Height covers the area to the left or above its Position. An entity without a Width or Height, such as
+// a bullet, collides like a point or a line: it collides with every entity it's within, but not with those it only
+// touches, nor with other entities without a size at its Position.
 type CollisionSystem struct {
 	// Solids, used to tell which collisions should be treated as solid by bitwise comparison.
 	// if a.Main & b.Group & sys.Solids{ Collisions are treated as solid.  }
 	Solids CollisionGroup
 
+	// MaxMovePerStep is the maximum distance Main entities may move per substep, as a ratio of their own width
+	// (horizontally) and height (vertically). Whenever an entity moved further than that since the previous update,
+	// its movement is split into substeps, and the entity stops at the first substep in which it hits a solid. This
+	// prevents small, fast entities from passing through thin solids, while slow entities are checked only once.
+	//
+	// Leaving this at 0 disables substepping. A value of 0.5 means an entity never moves more than half its own size
+	// per substep. Entities are not substepped along an axis in which they have no size.
+	//
+	// Substepping tests the Collider of an entity at every substep, and only stops at solids. Continuous entities are
+	// never substepped, since their movement is swept instead, see `CollisionComponent.Continuous`.
+	MaxMovePerStep float32
+
+	// CellSize is the size of the cells of the grid used to find entities which are close enough to collide. Only
+	// entities which share a cell are tested against eachother. Leaving this at 0 uses twice the average size of the
+	// entities, which works well unless their sizes vary a lot.
+	CellSize float32
+
 	entities []collisionEntity
+	ids      entityIDs
+
+	hash spatialHash
+	// hashed indicates whether the hash holds the current entities, which is no longer the case once one is added or
+	// removed
+	hashed     bool
+	candidates []int
+	hits       []sweptHit
 }
 
 // Add adds an entity to the CollisionSystem. To be added, the entity has to have a basic, collision, and space component.
 func (c *CollisionSystem) Add(basic *ecs.BasicEntity, collision *CollisionComponent, space *SpaceComponent) {
-	c.entities = append(c.entities, collisionEntity{basic, collision, space})
+	c.AddWithPhysics(basic, collision, space, nil, nil)
+}
+
+// AddWithPhysics adds an entity to the CollisionSystem, along with its PhysicsComponent and VelocityComponent.
+// Whenever the entity is pushed out of a solid, the velocity of its VelocityComponent is changed according to the
+// Restitution and Friction of the PhysicsComponent.
+func (c *CollisionSystem) AddWithPhysics(basic *ecs.BasicEntity, collision *CollisionComponent, space *SpaceComponent, physics *PhysicsComponent, velocity *VelocityComponent) {
+	if !c.ids.add(basic.ID()) {
+		return
+	}
+	c.entities = append(c.entities, collisionEntity{basic, collision, space, space.Position, physics, velocity})
+	c.hashed = false
 }
 
 // AddByInterface Provides a simple way to add an entity to the system that satisfies Collisionable. Any entity containing, BasicEntity,CollisionComponent, and SpaceComponent anonymously, automatically does this.
+// If the entity contains a PhysicsComponent and a VelocityComponent as well, it is added using AddWithPhysics.
 func (c *CollisionSystem) AddByInterface(i ecs.Identifier) {
 	o, _ := i.(Collisionable)
-	c.Add(o.GetBasicEntity(), o.GetCollisionComponent(), o.GetSpaceComponent())
+	var physics *PhysicsComponent
+	var velocity *VelocityComponent
+	if p, ok := i.(PhysicsFace); ok {
+		physics = p.GetPhysicsComponent()
+	}
+	if v, ok := i.(VelocityFace); ok {
+		velocity = v.GetVelocityComponent()
+	}
+	c.AddWithPhysics(o.GetBasicEntity(), o.GetCollisionComponent(), o.GetSpaceComponent(), physics, velocity)
 }
 
 // Remove removes an entity from the CollisionSystem.
 func (c *CollisionSystem) Remove(basic ecs.BasicEntity) {
+	c.ids.remove(basic.ID())
 	delete := -1
 	for index, e := range c.entities {
 		if e.BasicEntity.ID() == basic.ID() {
@@ -291,126 +476,394 @@ func (c *CollisionSystem) Remove(basic ecs.BasicEntity) {
 	}
 	if delete >= 0 {
 		c.entities = append(c.entities[:delete], c.entities[delete+1:]...)
+		c.hashed = false
+	}
+}
+
+// Entities returns a copy of the IDs of all entities in the CollisionSystem, in the order in which they are checked.
+func (c *CollisionSystem) Entities() []uint64 {
+	ids := make([]uint64, len(c.entities))
+	for i, e := range c.entities {
+		ids[i] = e.ID()
+	}
+	return ids
+}
+
+// BasicEntities returns a copy of the BasicEntities of all entities in the CollisionSystem.
+func (c *CollisionSystem) BasicEntities() []ecs.BasicEntity {
+	basics := make([]ecs.BasicEntity, len(c.entities))
+	for i, e := range c.entities {
+		basics[i] = *e.BasicEntity
+	}
+	return basics
+}
+
+// Get returns the components with which the entity with the given ID was added to the CollisionSystem, and false if
+// it isn't in the system, such as once it was removed. It's useful for looking up the components of the entities in
+// a CollisionMessage.
+func (c *CollisionSystem) Get(id uint64) (*CollisionComponent, *SpaceComponent, bool) {
+	for _, e := range c.entities {
+		if e.ID() == id {
+			return e.CollisionComponent, e.SpaceComponent, true
+		}
 	}
+	return nil, nil, false
 }
 
 // Update checks the entities for collision with eachother. Only Main entities are check for collision explicitly.
 // If one of the entities are solid, the SpaceComponent is adjusted so that the other entities don't pass through it.
+//
+// Every pair of entities is tested only once per update, and a single CollisionMessage is sent for every pair that
+// collides, even when both entities are Main and collide with eachother. Pairs which are too far apart to collide,
+// as determined by a grid of CellSize, are skipped without being tested.
 func (c *CollisionSystem) Update(dt float32) {
-	for i1, e1 := range c.entities {
-		if e1.CollisionComponent.Main == 0 {
-			//Main cannot pass bitwise comparison with any other items. Do not loop.
+	for i, e := range c.entities {
+		if e.CollisionComponent.Main == 0 {
+			//Main cannot pass bitwise comparison with any other items.
 			continue // with other entities
 		}
 
-		entityAABB := e1.SpaceComponent.AABB()
-		offset := engo.Point{X: e1.CollisionComponent.Extra.X / 2, Y: e1.CollisionComponent.Extra.Y / 2}
-		entityAABB.Min.X -= offset.X
-		entityAABB.Min.Y -= offset.Y
-		entityAABB.Max.X += offset.X
-		entityAABB.Max.Y += offset.Y
+		e.CollisionComponent.Collides = 0
+		if c.MaxMovePerStep > 0 && !e.CollisionComponent.Continuous {
+			c.sweep(i)
+		}
+	}
+
+	c.rehash()
 
-		var collided CollisionGroup
+	for i, e := range c.entities {
+		if e.CollisionComponent.Main != 0 && e.CollisionComponent.Continuous {
+			c.continuous(i)
+		}
+	}
 
-		for i2, e2 := range c.entities {
-			if i1 == i2 {
-				continue // with other entities, because we won't collide with ourselves
+	for i1, e1 := range c.entities {
+		candidates := c.hash.candidates(e1.aabb(), i1, c.candidates[:0])
+		for k := 0; k < len(candidates); k++ {
+			i2 := candidates[k]
+			e2 := c.entities[i2]
+			p1, p2 := e1.Position, e2.Position
+			c.collide(e1, e2)
+
+			// Entities which were pushed out of a solid are added to the cells they moved into. If e1 moved, it
+			// may now overlap entities which were not candidates before.
+			if e2.Position != p2 {
+				c.hash.insert(i2, e2.aabb())
 			}
-			cgroup := e1.CollisionComponent.Main & e2.CollisionComponent.Group
-			if cgroup == 0 {
-				continue //Items are not in a comparible group dont bother
+			if e1.Position != p1 {
+				c.hash.insert(i1, e1.aabb())
+				candidates = c.hash.candidates(e1.aabb(), i2, candidates[:0])
+				k = -1
 			}
+		}
+		c.candidates = candidates
+	}
 
-			otherAABB := e2.SpaceComponent.AABB()
-			offset = engo.Point{X: e2.CollisionComponent.Extra.X / 2, Y: e2.CollisionComponent.Extra.Y / 2}
-			otherAABB.Min.X -= offset.X
-			otherAABB.Min.Y -= offset.Y
-			otherAABB.Max.X += offset.X
-			otherAABB.Max.Y += offset.Y
-
-			if IsIntersecting(entityAABB, otherAABB) {
-				if cgroup&c.Solids > 0 {
-					mtd := MinimumTranslation(entityAABB, otherAABB)
-					if e2.CollisionComponent.Main&e1.CollisionComponent.Group&c.Solids != 0 {
-						//collision of equals (both main)
-						e1.SpaceComponent.Position.X += mtd.X / 2
-						e1.SpaceComponent.Position.Y += mtd.Y / 2
-						e2.SpaceComponent.Position.X -= mtd.X / 2
-						e2.SpaceComponent.Position.Y -= mtd.Y / 2
-						//As the entities are no longer overlapping
-						//e2 wont collide as main
-						engo.Mailbox.Dispatch(CollisionMessage{Entity: e2, To: e1, Groups: cgroup})
-					} else {
-						//collision with one main
-						e1.SpaceComponent.Position.X += mtd.X
-						e1.SpaceComponent.Position.Y += mtd.Y
-					}
-				}
+	for i := range c.entities {
+		c.entities[i].previous = c.entities[i].Position
+	}
+}
 
-				//collided can now list the types of collision
-				collided = collided | cgroup
-				engo.Mailbox.Dispatch(CollisionMessage{Entity: e1, To: e2, Groups: cgroup})
-
-				//update the position tracker of e1
-				entityAABB = e1.SpaceComponent.AABB()
-				offset := engo.Point{X: e1.CollisionComponent.Extra.X / 2, Y: e1.CollisionComponent.Extra.Y / 2}
-				entityAABB.Min.X -= offset.X
-				entityAABB.Min.Y -= offset.Y
-				entityAABB.Max.X += offset.X
-				entityAABB.Max.Y += offset.Y
-			}
+// rehash puts all entities in a new broadphase grid.
+func (c *CollisionSystem) rehash() {
+	c.hash.reset(c.cellSize(), len(c.entities))
+	for i, e := range c.entities {
+		c.hash.insert(i, e.aabb())
+	}
+	c.hashed = true
+}
+
+// refreshHash brings the broadphase grid up to date with the entities as they are now, such as before a query in
+// between updates. Entities which moved since they were put in the grid are put in the cells they moved into.
+func (c *CollisionSystem) refreshHash() {
+	if !c.hashed {
+		c.rehash()
+		return
+	}
+	for i, e := range c.entities {
+		if aabb := e.aabb(); aabb != c.hash.aabbs[i] {
+			c.hash.insert(i, aabb)
+		}
+	}
+}
+
+// cellSize returns the size of the cells of the broadphase grid: CellSize if it was set, or twice the average size
+// of the entities otherwise.
+func (c *CollisionSystem) cellSize() float32 {
+	if c.CellSize > 0 {
+		return c.CellSize
+	}
+
+	var (
+		total float32
+		n     int
+	)
+	for _, e := range c.entities {
+		if size := e.aabb().Size(); size.X >= 0 && size.Y >= 0 {
+			total += size.X + size.Y
+			n++
 		}
+	}
+	if n > 0 && total > 0 {
+		return total / float32(n)
+	}
+	return 1
+}
 
-		e1.CollisionComponent.Collides = collided
+// collide tests a single pair of entities for collision, resolves it if either of them is solid, and updates the
+// collision state of both entities.
+func (c *CollisionSystem) collide(e1, e2 collisionEntity) {
+	if !layersCollide(e1.CollisionComponent, e2.CollisionComponent) {
+		return // skip whole layers before comparing groups
+	}
+
+	groups1 := e1.CollisionComponent.Main & e2.CollisionComponent.Group // e1 collides with e2 in these groups
+	groups2 := e2.CollisionComponent.Main & e1.CollisionComponent.Group // e2 collides with e1 in these groups
+	if groups1 == 0 && groups2 == 0 {
+		return //Items are not in a comparible group dont bother
+	}
+
+	mtd, ok := overlap(e1, e2)
+	if !ok {
+		return
+	}
+
+	switch solid1, solid2 := groups1&c.Solids != 0, groups2&c.Solids != 0; {
+	case solid1 && solid2:
+		//collision of equals (both main)
+		e1.SpaceComponent.Position.X += mtd.X / 2
+		e1.SpaceComponent.Position.Y += mtd.Y / 2
+		e2.SpaceComponent.Position.X -= mtd.X / 2
+		e2.SpaceComponent.Position.Y -= mtd.Y / 2
+		e1.respond(mtd)
+		e2.respond(engo.Point{X: -mtd.X, Y: -mtd.Y})
+	case solid1:
+		//collision with one main
+		e1.SpaceComponent.Position.X += mtd.X
+		e1.SpaceComponent.Position.Y += mtd.Y
+		e1.respond(mtd)
+	case solid2:
+		e2.SpaceComponent.Position.X -= mtd.X
+		e2.SpaceComponent.Position.Y -= mtd.Y
+		e2.respond(engo.Point{X: -mtd.X, Y: -mtd.Y})
+	}
+
+	//Collides can now list the types of collision
+	e1.CollisionComponent.Collides |= groups1
+	e2.CollisionComponent.Collides |= groups2
+
+	// The entity that collided is reported as Entity. Whenever both collided with eachother, the one with the lowest
+	// ID is, and Groups holds the groups of both.
+	contact := newContact(mtd)
+	switch {
+	case groups2 == 0:
+		engo.Mailbox.Dispatch(CollisionMessage{Entity: e1, To: e2, Groups: groups1, Contact: contact})
+	case groups1 == 0:
+		engo.Mailbox.Dispatch(CollisionMessage{Entity: e2, To: e1, Groups: groups2, Contact: contact.flip()})
+	case e1.ID() < e2.ID():
+		engo.Mailbox.Dispatch(CollisionMessage{Entity: e1, To: e2, Groups: groups1 | groups2, Contact: contact})
+	default:
+		engo.Mailbox.Dispatch(CollisionMessage{Entity: e2, To: e1, Groups: groups1 | groups2, Contact: contact.flip()})
 	}
 }
 
-// IsIntersecting tells if two engo.AABBs intersect.
-func IsIntersecting(rect1 engo.AABB, rect2 engo.AABB) bool {
-	if rect1.Max.X > rect2.Min.X && rect1.Min.X < rect2.Max.X && rect1.Max.Y > rect2.Min.Y && rect1.Min.Y < rect2.Max.Y {
-		return true
+// substeps returns the number of substeps needed to move the entity by delta, without moving more than
+// MaxMovePerStep times its own size per substep.
+func (c *CollisionSystem) substeps(e collisionEntity, delta engo.Point) int {
+	steps := float32(1)
+	if maxX := c.MaxMovePerStep * math.Abs(e.SpaceComponent.Width); maxX > 0 {
+		steps = math.Max(steps, math.Ceil(math.Abs(delta.X)/maxX))
 	}
+	if maxY := c.MaxMovePerStep * math.Abs(e.SpaceComponent.Height); maxY > 0 {
+		steps = math.Max(steps, math.Ceil(math.Abs(delta.Y)/maxY))
+	}
+	return int(steps)
+}
 
-	return false
+// sweep moves the entity from its previous position to its current one in substeps. If the entity hits a solid
+// during one of those substeps, it is placed at the point where it first touches that solid, so the regular
+// collision handling pushes it back out at the side it came from.
+func (c *CollisionSystem) sweep(index int) {
+	e := c.entities[index]
+	start, end := e.previous, e.Position
+	delta := engo.Point{X: end.X - start.X, Y: end.Y - start.Y}
+	steps := c.substeps(e, delta)
+	if steps <= 1 {
+		return
+	}
+
+	at := func(t float32) engo.Point {
+		return engo.Point{X: start.X + delta.X*t, Y: start.Y + delta.Y*t}
+	}
+
+	for step := 1; step <= steps; step++ {
+		e.Position = at(float32(step) / float32(steps))
+		if !c.hitsSolid(index) {
+			continue
+		}
+
+		// Narrow down the point of impact between the last free substep and this one.
+		free, hit := float32(step-1)/float32(steps), float32(step)/float32(steps)
+		for i := 0; i < 8; i++ {
+			mid := (free + hit) / 2
+			e.Position = at(mid)
+			if c.hitsSolid(index) {
+				hit = mid
+			} else {
+				free = mid
+			}
+		}
+		e.Position = at(hit)
+		return
+	}
 }
 
-// MinimumTranslation tells how much an entity has to move to no longer overlap another entity.
-func MinimumTranslation(rect1 engo.AABB, rect2 engo.AABB) engo.Point {
-	mtd := engo.Point{}
+// continuousPenetration is how far a Continuous entity is moved into the solid it hit, so the regular collision
+// handling pushes it back out at the side it came from, and reports the collision.
+const continuousPenetration = 0.01
 
-	left := rect2.Min.X - rect1.Max.X
-	right := rect2.Max.X - rect1.Min.X
-	top := rect2.Min.Y - rect1.Max.Y
-	bottom := rect2.Max.Y - rect1.Min.Y
+// sweptHit is an entity hit by a Continuous entity while moving.
+type sweptHit struct {
+	index  int
+	time   float32
+	normal engo.Point
+	groups CollisionGroup
+}
 
-	if left > 0 || right < 0 {
-		log.Println("Box aint intercepting")
-		return mtd
-		//box doesn't intercept
+// continuous tests the movement of the Continuous entity at the given index since the previous update against the
+// AABBs of the other entities. It's moved back to the first solid it hits, and collisions with the entities it
+// passed through before that are reported, unless they still overlap, since the regular collision handling reports
+// those.
+func (c *CollisionSystem) continuous(index int) {
+	e := c.entities[index]
+	e.CollisionComponent.TimeOfImpact = 1
+	delta := engo.Point{X: e.Position.X - e.previous.X, Y: e.Position.Y - e.previous.Y}
+	if delta.X == 0 && delta.Y == 0 {
+		return
 	}
 
-	if top > 0 || bottom < 0 {
-		log.Println("Box aint intercepting")
-		return mtd
-		//box doesn't intercept
+	end := e.aabb()
+	start := engo.AABB{
+		Min: engo.Point{X: end.Min.X - delta.X, Y: end.Min.Y - delta.Y},
+		Max: engo.Point{X: end.Max.X - delta.X, Y: end.Max.Y - delta.Y},
 	}
-	if math.Abs(left) < right {
-		mtd.X = left
-	} else {
-		mtd.X = right
+
+	stop := float32(1)
+	c.hits = c.hits[:0]
+	c.candidates = c.hash.candidates(start.Union(end), -1, c.candidates[:0])
+	for _, i2 := range c.candidates {
+		e2 := c.entities[i2]
+		groups := e.CollisionComponent.Main & e2.CollisionComponent.Group
+		if i2 == index || groups == 0 || !layersCollide(e.CollisionComponent, e2.CollisionComponent) {
+			continue
+		}
+		t, normal, ok := sweepAABB(start, delta, e2.aabb())
+		if !ok {
+			continue
+		}
+		e.CollisionComponent.TimeOfImpact = math.Min(e.CollisionComponent.TimeOfImpact, t)
+		if groups&c.Solids != 0 {
+			stop = math.Min(stop, t)
+		} else {
+			c.hits = append(c.hits, sweptHit{i2, t, normal, groups})
+		}
+	}
+
+	if stop < 1 {
+		_, length := delta.Normalize()
+		t := math.Min(stop+continuousPenetration/length, 1)
+		e.Position = engo.Point{X: e.previous.X + delta.X*t, Y: e.previous.Y + delta.Y*t}
+		c.hash.insert(index, e.aabb())
+	}
+
+	aabb := e.aabb()
+	for _, hit := range c.hits {
+		e2 := c.entities[hit.index]
+		if hit.time > stop || aabb.Intersects(e2.aabb()) {
+			continue
+		}
+		e.CollisionComponent.Collides |= hit.groups
+		engo.Mailbox.Dispatch(CollisionMessage{Entity: e, To: e2, Groups: hit.groups, Contact: Contact{Normal: hit.normal}})
+	}
+}
+
+// sweepAABB returns the fraction of delta at which an AABB moving from start by delta first hits the static AABB
+// other, along with the normal of the side of other which it hits. AABBs which already overlap at the start, or
+// which merely touch, do not hit eachother.
+func sweepAABB(start engo.AABB, delta engo.Point, other engo.AABB) (float32, engo.Point, bool) {
+	enterX, exitX, ok := sweepAxis(start.Min.X, start.Max.X, delta.X, other.Min.X, other.Max.X)
+	if !ok {
+		return 0, engo.Point{}, false
+	}
+	enterY, exitY, ok := sweepAxis(start.Min.Y, start.Max.Y, delta.Y, other.Min.Y, other.Max.Y)
+	if !ok {
+		return 0, engo.Point{}, false
+	}
+
+	enter, exit := math.Max(enterX, enterY), math.Min(exitX, exitY)
+	if enter >= exit || enter < 0 || enter >= 1 {
+		return 0, engo.Point{}, false
+	}
+	if enterX > enterY {
+		return enter, engo.Point{X: -sign(delta.X)}, true
+	}
+	return enter, engo.Point{Y: -sign(delta.Y)}, true
+}
+
+// sweepAxis returns the fractions of delta at which an interval from min to max, moving by delta, starts and stops
+// overlapping the interval from otherMin to otherMax. It returns false if they never overlap.
+func sweepAxis(min, max, delta, otherMin, otherMax float32) (enter, exit float32, ok bool) {
+	if delta == 0 {
+		if max <= otherMin || min >= otherMax {
+			return 0, 0, false
+		}
+		return -math.MaxFloat32, math.MaxFloat32, true
+	}
+	enter, exit = (otherMin-max)/delta, (otherMax-min)/delta
+	if enter > exit {
+		enter, exit = exit, enter
 	}
+	return enter, exit, true
+}
 
-	if math.Abs(top) < bottom {
-		mtd.Y = top
-	} else {
-		mtd.Y = bottom
+// sign returns -1 for negative values, and 1 otherwise.
+func sign(f float32) float32 {
+	if f < 0 {
+		return -1
 	}
+	return 1
+}
 
-	if math.Abs(mtd.X) < math.Abs(mtd.Y) {
-		mtd.Y = 0
-	} else {
-		mtd.X = 0
+// hitsSolid indicates whether the entity at the given index currently overlaps with any entity it treats as solid.
+func (c *CollisionSystem) hitsSolid(index int) bool {
+	e1 := c.entities[index]
+	for i2, e2 := range c.entities {
+		if index == i2 {
+			continue
+		}
+		if e1.CollisionComponent.Main&e2.CollisionComponent.Group&c.Solids == 0 {
+			continue
+		}
+		if !layersCollide(e1.CollisionComponent, e2.CollisionComponent) {
+			continue
+		}
+		if _, ok := overlap(e1, e2); ok {
+			return true
+		}
 	}
+	return false
+}
+
+// IsIntersecting tells if two engo.AABBs intersect.
+func IsIntersecting(rect1 engo.AABB, rect2 engo.AABB) bool {
+	return rect1.Intersects(rect2)
+}
 
-	return mtd
+// MinimumTranslation tells how much an entity has to move to no longer overlap another entity.
+func MinimumTranslation(rect1 engo.AABB, rect2 engo.AABB) engo.Point {
+	if !rect1.Intersects(rect2) {
+		log.Println("Box aint intercepting")
+		return engo.Point{}
+	}
+	return rect1.Overlap(rect2)
 }
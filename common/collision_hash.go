@@ -0,0 +1,208 @@
+package common
+
+import (
+	"sort"
+
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/engo/math"
+)
+
+// maxSpatialCells is the number of cells an entity can be put in, before it is returned by every query instead.
+const maxSpatialCells = 64
+
+// spatialCell is the location of a single cell of a spatialHash, in cells rather than in game units.
+type spatialCell struct {
+	x, y int32
+}
+
+// spatialHash is a uniform grid, which is used as the broadphase of the CollisionSystem. Every entity is put in each
+// of the cells its AABB overlaps, so only entities which share a cell have to be tested against eachother.
+//
+// Entities which would span more than maxSpatialCells cells, such as the floor of a level, are kept aside and
+// returned by every query instead.
+//
+// Entities are never removed from a cell until the grid is reset, so a query may return entities which have since
+// moved away. That's fine, since the narrow phase tests those anyway.
+type spatialHash struct {
+	size  float32
+	cells map[spatialCell][]int
+	large []int
+
+	// seen holds the last query in which every entity was found, so it's returned only once per query.
+	seen  []int
+	query int
+	// aabbs holds the AABB with which every entity was last inserted.
+	aabbs []engo.AABB
+
+	// min and max are the first and last cell holding any entity, as long as filled is set
+	min, max spatialCell
+	filled   bool
+}
+
+// reset empties the grid, and prepares it for n entities in cells of the given size. Cells which were not used since
+// the previous reset are released.
+func (h *spatialHash) reset(size float32, n int) {
+	h.size = size
+	h.large = h.large[:0]
+	h.filled = false
+	if h.cells == nil {
+		h.cells = make(map[spatialCell][]int)
+	}
+	for cell, indices := range h.cells {
+		if len(indices) == 0 {
+			delete(h.cells, cell)
+		} else {
+			h.cells[cell] = indices[:0]
+		}
+	}
+
+	if cap(h.seen) < n {
+		h.seen = make([]int, n)
+		h.aabbs = make([]engo.AABB, n)
+	}
+	h.seen = h.seen[:n]
+	h.aabbs = h.aabbs[:n]
+}
+
+// bounds returns the first and last cell overlapped by the given AABB.
+func (h *spatialHash) bounds(aabb engo.AABB) (min, max spatialCell) {
+	min = spatialCell{int32(math.Floor(aabb.Min.X / h.size)), int32(math.Floor(aabb.Min.Y / h.size))}
+	max = spatialCell{int32(math.Floor(aabb.Max.X / h.size)), int32(math.Floor(aabb.Max.Y / h.size))}
+	return
+}
+
+// isLarge indicates whether an AABB spanning the given cells is too large to be put in each of them.
+func (h *spatialHash) isLarge(min, max spatialCell) bool {
+	return (int64(max.x)-int64(min.x)+1)*(int64(max.y)-int64(min.y)+1) > maxSpatialCells
+}
+
+// insert puts the entity at the given index in every cell overlapped by its AABB. Empty AABBs are left out, since
+// they never intersect anything.
+func (h *spatialHash) insert(index int, aabb engo.AABB) {
+	h.aabbs[index] = aabb
+	if aabb.Max.X < aabb.Min.X || aabb.Max.Y < aabb.Min.Y {
+		return
+	}
+	min, max := h.bounds(aabb)
+	if h.isLarge(min, max) {
+		h.large = append(h.large, index)
+		return
+	}
+	for x := min.x; x <= max.x; x++ {
+		for y := min.y; y <= max.y; y++ {
+			cell := spatialCell{x, y}
+			h.cells[cell] = append(h.cells[cell], index)
+		}
+	}
+
+	if !h.filled {
+		h.min, h.max, h.filled = min, max, true
+		return
+	}
+	h.min = spatialCell{min32(h.min.x, min.x), min32(h.min.y, min.y)}
+	h.max = spatialCell{max32(h.max.x, max.x), max32(h.max.y, max.y)}
+}
+
+// candidates appends the indices of all entities after the given index, which share a cell with the given AABB, to
+// buf. They are sorted, so pairs are tested in the same order as they would be without a broadphase.
+func (h *spatialHash) candidates(aabb engo.AABB, after int, buf []int) []int {
+	if aabb.Max.X < aabb.Min.X || aabb.Max.Y < aabb.Min.Y {
+		return buf
+	}
+	min, max := h.bounds(aabb)
+	if h.isLarge(min, max) {
+		// Like with insert, large AABBs are tested against everything rather than looked up
+		for index := after + 1; index < len(h.seen); index++ {
+			buf = append(buf, index)
+		}
+		return buf
+	}
+
+	h.query++
+	for _, index := range h.large {
+		if index > after && h.seen[index] != h.query {
+			h.seen[index] = h.query
+			buf = append(buf, index)
+		}
+	}
+	for x := min.x; x <= max.x; x++ {
+		for y := min.y; y <= max.y; y++ {
+			for _, index := range h.cells[spatialCell{x, y}] {
+				if index > after && h.seen[index] != h.query {
+					h.seen[index] = h.query
+					buf = append(buf, index)
+				}
+			}
+		}
+	}
+	sort.Ints(buf)
+	return buf
+}
+
+// nearby appends the indices of the large entities, and of the entities in the cells at the given distance in cells
+// from the center cell, to buf. The large entities are only appended for a distance of 0, which starts a new query;
+// every further call continues that query, so entities are only appended once until the next query starts.
+func (h *spatialHash) nearby(center spatialCell, distance int32, buf []int) []int {
+	if distance == 0 {
+		h.query++
+		for _, index := range h.large {
+			if h.seen[index] != h.query {
+				h.seen[index] = h.query
+				buf = append(buf, index)
+			}
+		}
+	}
+
+	visit := func(x, y int32) {
+		for _, index := range h.cells[spatialCell{x, y}] {
+			if h.seen[index] != h.query {
+				h.seen[index] = h.query
+				buf = append(buf, index)
+			}
+		}
+	}
+	min, max := spatialCell{center.x - distance, center.y - distance}, spatialCell{center.x + distance, center.y + distance}
+	for x := min.x; x <= max.x; x++ {
+		visit(x, min.y)
+		if distance > 0 {
+			visit(x, max.y)
+		}
+	}
+	for y := min.y + 1; y < max.y; y++ {
+		visit(min.x, y)
+		visit(max.x, y)
+	}
+	return buf
+}
+
+// covers indicates whether the cells up to the given distance in cells from the center cell include every cell
+// holding an entity.
+func (h *spatialHash) covers(center spatialCell, distance int32) bool {
+	return !h.filled || (center.x-distance <= h.min.x && center.y-distance <= h.min.y &&
+		center.x+distance >= h.max.x && center.y+distance >= h.max.y)
+}
+
+// clearance returns the distance from p, which is in the center cell, to the nearest point outside of the cells up
+// to the given distance in cells from the center cell. Entities which are not in any of those cells are at least
+// this far away from p.
+func (h *spatialHash) clearance(p engo.Point, center spatialCell, distance int32) float32 {
+	left := p.X - float32(center.x-distance)*h.size
+	right := float32(center.x+distance+1)*h.size - p.X
+	top := p.Y - float32(center.y-distance)*h.size
+	bottom := float32(center.y+distance+1)*h.size - p.Y
+	return math.Min(math.Min(left, right), math.Min(top, bottom))
+}
+
+func min32(a, b int32) int32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max32(a, b int32) int32 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,111 @@
+package common
+
+import (
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/engo/math"
+)
+
+// QueryAABB returns the IDs of the entities of the CollisionSystem which overlap the given AABB, in the order in
+// which they are checked, such as for damaging everything within an area. Only entities on a layer included by mask
+// are returned, so the query can be limited to enemies for example; a mask of 0 includes every layer.
+//
+// Entities are tested the same way as they collide: using their Collider if they have one, and their AABB including
+// their Extra buffer otherwise. Like with colliding, entities which merely touch the AABB do not overlap it. Only the
+// entities which are close to the AABB, as determined by the grid of CellSize, are tested.
+func (c *CollisionSystem) QueryAABB(aabb engo.AABB, mask CollisionLayer) []uint64 {
+	return c.query(aabb, aabbShape(aabb), false, mask)
+}
+
+// QueryCircle returns the IDs of the entities of the CollisionSystem which overlap the circle with the given center
+// and radius, in the order in which they are checked, such as for triggering everything within a distance. Entities
+// are filtered and tested the same way as with QueryAABB, so ones which merely touch the circle do not overlap it.
+func (c *CollisionSystem) QueryCircle(center engo.Point, radius float32, mask CollisionLayer) []uint64 {
+	aabb := engo.AABB{
+		Min: engo.Point{X: center.X - radius, Y: center.Y - radius},
+		Max: engo.Point{X: center.X + radius, Y: center.Y + radius},
+	}
+	return c.query(aabb, satShape{center: center, radius: radius}, true, mask)
+}
+
+// query returns the IDs of the entities on a layer included by mask which overlap the shape, whose AABB is given.
+// Entities without a Collider are tested against the shape itself only when exact is set, since their AABBs are tested
+// against the AABB of the shape anyway.
+func (c *CollisionSystem) query(aabb engo.AABB, shape satShape, exact bool, mask CollisionLayer) []uint64 {
+	query := CollisionComponent{Mask: mask}
+	c.refreshHash()
+
+	var ids []uint64
+	c.candidates = c.hash.candidates(aabb, -1, c.candidates[:0])
+	for _, i := range c.candidates {
+		e := c.entities[i]
+		if !query.includes(e.CollisionComponent.Layer) {
+			continue
+		}
+		entityAABB := e.aabb()
+		if !entityAABB.Intersects(aabb) {
+			continue
+		}
+		if e.CollisionComponent.Collider != nil {
+			if _, ok := separate(shape, e.shape()); !ok {
+				continue
+			}
+		} else if exact {
+			if _, ok := separate(shape, aabbShape(entityAABB)); !ok {
+				continue
+			}
+		}
+		ids = append(ids, e.ID())
+	}
+	return ids
+}
+
+// aabbShape returns the rectangle covered by the AABB.
+func aabbShape(aabb engo.AABB) satShape {
+	return satShape{points: []engo.Point{
+		aabb.Min, {X: aabb.Max.X, Y: aabb.Min.Y}, aabb.Max, {X: aabb.Min.X, Y: aabb.Max.Y},
+	}}
+}
+
+// Nearest returns the ID of the entity of the CollisionSystem which is nearest to the given point, along with its
+// distance to the point, such as for auto-targeting. Only entities on a layer included by mask are considered, like
+// with QueryAABB; it returns false if there are none.
+//
+// The distance is measured to the AABB of the entity including its Extra buffer, regardless of its Collider, so it's 0
+// for entities whose AABB contains the point. Of multiple entities at the same distance, the one with the lowest ID
+// is returned. The grid of CellSize is searched ring by ring outwards from the point, until no entity outside of the
+// searched cells can be nearer than the nearest one found, so distant entities are not tested at all.
+func (c *CollisionSystem) Nearest(p engo.Point, mask CollisionLayer) (uint64, float32, bool) {
+	query := CollisionComponent{Mask: mask}
+	c.refreshHash()
+
+	var (
+		nearest  uint64
+		distance float32
+		found    bool
+	)
+	center, _ := c.hash.bounds(engo.AABB{Min: p, Max: p})
+	for ring := int32(0); ; ring++ {
+		c.candidates = c.hash.nearby(center, ring, c.candidates[:0])
+		for _, i := range c.candidates {
+			e := c.entities[i]
+			if !query.includes(e.CollisionComponent.Layer) {
+				continue
+			}
+			d := aabbDistance(e.aabb(), p)
+			if !found || d < distance || (d == distance && e.ID() < nearest) {
+				nearest, distance, found = e.ID(), d, true
+			}
+		}
+		if (found && distance < c.hash.clearance(p, center, ring)) || c.hash.covers(center, ring) {
+			return nearest, distance, found
+		}
+	}
+}
+
+// aabbDistance returns the distance from the point to the nearest point of the AABB, which is 0 if it contains the
+// point.
+func aabbDistance(aabb engo.AABB, p engo.Point) float32 {
+	dx := math.Max(math.Max(aabb.Min.X-p.X, p.X-aabb.Max.X), 0)
+	dy := math.Max(math.Max(aabb.Min.Y-p.Y, p.Y-aabb.Max.Y), 0)
+	return math.Sqrt(dx*dx + dy*dy)
+}
@@ -0,0 +1,138 @@
+package common
+
+import (
+	"sort"
+
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/engo/math"
+)
+
+// RaycastHit is an entity hit by a ray cast using `CollisionSystem.Raycast`.
+type RaycastHit struct {
+	// ID is the ID of the entity which is hit, as used by `CollisionSystem.Get`
+	ID uint64
+	// Point is where the ray first hits the entity
+	Point engo.Point
+	// Normal is the unit normal of the side of the entity which the ray hits, pointing away from the entity
+	Normal engo.Point
+	// Distance is the distance from the origin of the ray to Point
+	Distance float32
+}
+
+// Raycast casts a ray from origin in the direction of dir, up to maxDist away from origin, and returns every entity
+// of the CollisionSystem it hits, sorted from near to far, so the first one blocks the line of sight. The length of
+// dir doesn't matter; without a direction or distance, nothing is hit.
+//
+// Entities are tested the same way as they collide: using their Collider if they have one, and their AABB including
+// their Extra buffer otherwise. Like with colliding, a ray which merely touches an entity, such as one grazing its
+// edge, doesn't hit it. Neither does a ray which starts within an entity, so a ray cast from within the shooter
+// doesn't hit the shooter itself. The groups and layers of the entities are not taken into account; check those of
+// the hit entities to ignore some of them.
+//
+// Only the entities which are close to the ray, as determined by the grid of CellSize, are tested. Entities which
+// moved since the previous update are found at their current position.
+func (c *CollisionSystem) Raycast(origin, dir engo.Point, maxDist float32) []RaycastHit {
+	dir, length := dir.Normalize()
+	if length == 0 || maxDist <= 0 {
+		return nil
+	}
+
+	c.refreshHash()
+	end := engo.Point{X: origin.X + dir.X*maxDist, Y: origin.Y + dir.Y*maxDist}
+	ray := engo.AABB{Min: origin, Max: origin}.Union(engo.AABB{Min: end, Max: end})
+
+	var hits []RaycastHit
+	c.candidates = c.hash.candidates(ray, -1, c.candidates[:0])
+	for _, i := range c.candidates {
+		e := c.entities[i]
+		var (
+			t      float32
+			normal engo.Point
+			ok     bool
+		)
+		if e.CollisionComponent.Collider == nil {
+			start := engo.AABB{Min: origin, Max: origin}
+			delta := engo.Point{X: dir.X * maxDist, Y: dir.Y * maxDist}
+			t, normal, ok = sweepAABB(start, delta, e.aabb())
+			t *= maxDist
+		} else {
+			t, normal, ok = e.shape().raycast(origin, dir, maxDist)
+		}
+		if !ok {
+			continue
+		}
+		hits = append(hits, RaycastHit{
+			ID:       e.ID(),
+			Point:    engo.Point{X: origin.X + dir.X*t, Y: origin.Y + dir.Y*t},
+			Normal:   normal,
+			Distance: t,
+		})
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Distance < hits[j].Distance })
+	return hits
+}
+
+// raycast returns the distance along the unit vector dir at which a ray from origin first hits the shape, within
+// maxDist, along with the normal of the shape where it's hit. Like with sweepAABB, rays which start within the shape,
+// or which merely touch it, do not hit it.
+func (s satShape) raycast(origin, dir engo.Point, maxDist float32) (float32, engo.Point, bool) {
+	if s.points == nil {
+		// Solve |origin + dir*t - center| = radius for the smallest t
+		offset := engo.Point{X: origin.X - s.center.X, Y: origin.Y - s.center.Y}
+		b := offset.X*dir.X + offset.Y*dir.Y
+		c := offset.X*offset.X + offset.Y*offset.Y - s.radius*s.radius
+		discriminant := b*b - c
+		if c < 0 || discriminant <= 0 {
+			return 0, engo.Point{}, false
+		}
+		t := -b - math.Sqrt(discriminant)
+		if t < 0 || t >= maxDist {
+			return 0, engo.Point{}, false
+		}
+		normal := engo.Point{X: offset.X + dir.X*t, Y: offset.Y + dir.Y*t}
+		normal, _ = normal.Normalize()
+		return t, normal, true
+	}
+	if len(s.points) < 3 {
+		return 0, engo.Point{}, false
+	}
+
+	// Clip the ray against the inside of every edge of the convex polygon
+	var centroid engo.Point
+	for _, p := range s.points {
+		centroid.X += p.X / float32(len(s.points))
+		centroid.Y += p.Y / float32(len(s.points))
+	}
+	var (
+		enter, exit float32 = -math.MaxFloat32, maxDist
+		normal      engo.Point
+	)
+	for i, p1 := range s.points {
+		p2 := s.points[(i+1)%len(s.points)]
+		n := engo.Point{X: p1.Y - p2.Y, Y: p2.X - p1.X}
+		if n.X*(centroid.X-p1.X)+n.Y*(centroid.Y-p1.Y) > 0 {
+			n = engo.Point{X: -n.X, Y: -n.Y} // make the normal point outwards
+		}
+
+		// The ray is inside of the edge wherever n·(origin + dir*t - p1) < 0
+		distance := n.X*(p1.X-origin.X) + n.Y*(p1.Y-origin.Y)
+		speed := n.X*dir.X + n.Y*dir.Y
+		switch {
+		case speed == 0:
+			if distance <= 0 {
+				return 0, engo.Point{}, false // parallel to the edge, and outside of or along it
+			}
+		case speed < 0:
+			if t := distance / speed; t > enter {
+				enter, normal = t, n
+			}
+		default:
+			exit = math.Min(exit, distance/speed)
+		}
+	}
+	if enter < 0 || enter >= exit {
+		return 0, engo.Point{}, false
+	}
+	normal, _ = normal.Normalize()
+	return enter, normal, true
+}
@@ -0,0 +1,169 @@
+package common
+
+import (
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/engo/math"
+)
+
+// CollisionShape is the shape of a CollisionComponent, used to test whether two entities whose AABBs intersect
+// actually collide. Its coordinates are relative to the Position of the SpaceComponent, such that (0,0) is its upper
+// left corner, and it's rotated along with the SpaceComponent. The shape should fit within the Width and Height of
+// the SpaceComponent, since anything outside of it is never tested.
+//
+// Available shapes are CollisionCircle and CollisionPolygon. Whenever the Collider of a CollisionComponent is nil, the
+// rectangle defined by its SpaceComponent is used.
+//
+// A CollisionShape is separate from the hitboxes added using SpaceComponent.AddShape, which are only used by
+// SpaceComponent.Contains, such as for the MouseSystem. Hitboxes may be concave, made up of several shapes and
+// elliptical, none of which can be tested efficiently for collisions. To collide along a hitbox, set a Collider
+// which approximates it, such as a CollisionPolygon made up of the corners of its Lines.
+type CollisionShape interface {
+	// satShape returns the shape in world coordinates, for the entity with the given SpaceComponent.
+	satShape(space *SpaceComponent) satShape
+}
+
+// CollisionCircle is a circular CollisionShape.
+type CollisionCircle struct {
+	Center engo.Point
+	Radius float32
+}
+
+func (c CollisionCircle) satShape(space *SpaceComponent) satShape {
+	return satShape{center: toWorld(space, c.Center), radius: c.Radius}
+}
+
+// CollisionPolygon is a convex polygonal CollisionShape, such as a slope. Its Points are the corners of the polygon,
+// in either clockwise or counter-clockwise order. Concave polygons are treated as if they were their convex hull.
+type CollisionPolygon struct {
+	Points []engo.Point
+}
+
+func (p CollisionPolygon) satShape(space *SpaceComponent) satShape {
+	points := make([]engo.Point, len(p.Points))
+	for i, point := range p.Points {
+		points[i] = toWorld(space, point)
+	}
+	return satShape{points: points}
+}
+
+// toWorld translates a point relative to the SpaceComponent into world coordinates, rotating it along with the
+// SpaceComponent.
+func toWorld(space *SpaceComponent, p engo.Point) engo.Point {
+	if space.Rotation == 0 {
+		return engo.Point{X: space.Position.X + p.X, Y: space.Position.Y + p.Y}
+	}
+	sin, cos := math.Sincos(space.Rotation * math.Pi / 180)
+	return engo.Point{
+		X: space.Position.X + p.X*cos - p.Y*sin,
+		Y: space.Position.Y + p.Y*cos + p.X*sin,
+	}
+}
+
+// satShape is a shape in world coordinates, as tested using the separating axis theorem. It's either a convex
+// polygon made up of points, or a circle whenever there are no points.
+type satShape struct {
+	points []engo.Point
+	center engo.Point
+	radius float32
+}
+
+// rectangleShape returns the rectangle defined by the SpaceComponent, taking its rotation into account.
+func rectangleShape(space *SpaceComponent) satShape {
+	corners := space.Corners()
+	return satShape{points: []engo.Point{corners[0], corners[1], corners[3], corners[2]}}
+}
+
+// project returns the interval covered by the shape on the given unit axis.
+func (s satShape) project(axis engo.Point) (min, max float32) {
+	if s.points == nil {
+		c := s.center.X*axis.X + s.center.Y*axis.Y
+		return c - s.radius, c + s.radius
+	}
+
+	min, max = math.MaxFloat32, -math.MaxFloat32
+	for _, p := range s.points {
+		d := p.X*axis.X + p.Y*axis.Y
+		min = math.Min(min, d)
+		max = math.Max(max, d)
+	}
+	return
+}
+
+// axes returns the axes along which the shape may be separated from other: the normals of the edges of a polygon,
+// or for a circle, the axis towards the closest point of other.
+func (s satShape) axes(other satShape) []engo.Point {
+	if s.points == nil {
+		closest := other.center
+		if other.points != nil {
+			var distance float32 = math.MaxFloat32
+			for _, p := range other.points {
+				if d := s.center.PointDistanceSquared(p); d < distance {
+					closest, distance = p, d
+				}
+			}
+		}
+		axis := engo.Point{X: closest.X - s.center.X, Y: closest.Y - s.center.Y}
+		if axis, length := axis.Normalize(); length > 0 {
+			return []engo.Point{axis}
+		}
+		return []engo.Point{{X: 1}} // the centers are at the same location, so any axis will do
+	}
+
+	axes := make([]engo.Point, 0, len(s.points))
+	for i, p1 := range s.points {
+		p2 := s.points[(i+1)%len(s.points)]
+		normal := engo.Point{X: p1.Y - p2.Y, Y: p2.X - p1.X}
+		if normal, length := normal.Normalize(); length > 0 {
+			axes = append(axes, normal)
+		}
+	}
+	return axes
+}
+
+// separate returns the shortest translation which moves a out of b, if they overlap. Shapes which merely touch do
+// not overlap.
+func separate(a, b satShape) (engo.Point, bool) {
+	var (
+		mtd   engo.Point
+		depth float32 = math.MaxFloat32
+	)
+	for _, axis := range append(a.axes(b), b.axes(a)...) {
+		minA, maxA := a.project(axis)
+		minB, maxB := b.project(axis)
+
+		// a can be moved out of b either forwards or backwards along the axis
+		forward, backward := maxB-minA, maxA-minB
+		if forward <= 0 || backward <= 0 {
+			return engo.Point{}, false
+		}
+		if forward < depth {
+			depth, mtd = forward, engo.Point{X: axis.X * forward, Y: axis.Y * forward}
+		}
+		if backward < depth {
+			depth, mtd = backward, engo.Point{X: -axis.X * backward, Y: -axis.Y * backward}
+		}
+	}
+	return mtd, true
+}
+
+// shape returns the shape of the entity in world coordinates.
+func (e collisionEntity) shape() satShape {
+	if e.CollisionComponent.Collider == nil {
+		return rectangleShape(e.SpaceComponent)
+	}
+	return e.CollisionComponent.Collider.satShape(e.SpaceComponent)
+}
+
+// overlap returns the shortest translation which moves e1 out of e2, and whether they overlap at all. Entities
+// without a Collider are tested using their AABBs, including the Extra buffer. As soon as either has a Collider, the
+// actual shapes of both are tested, in which case Extra only affects the AABB used to skip distant entities.
+func overlap(e1, e2 collisionEntity) (engo.Point, bool) {
+	aabb1, aabb2 := e1.aabb(), e2.aabb()
+	if !aabb1.Intersects(aabb2) {
+		return engo.Point{}, false
+	}
+	if e1.CollisionComponent.Collider == nil && e2.CollisionComponent.Collider == nil {
+		return aabb1.Overlap(aabb2), true
+	}
+	return separate(e1.shape(), e2.shape())
+}
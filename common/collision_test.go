@@ -2,10 +2,12 @@ package common
 
 import (
 	"fmt"
+	"math/rand"
 	"testing"
 
 	"github.com/EngoEngine/ecs"
 	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/engo/math"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -44,6 +46,43 @@ func TestSpaceComponent_Contains(t *testing.T) {
 	for _, f := range fail {
 		assert.False(t, space.Contains(f), fmt.Sprintf("point %v should not be within area", f))
 	}
+
+	// Rotated by 90 degrees clockwise around the Position, the area spans from (-50, 0) to (0, 100)
+	rotated := SpaceComponent{Width: 100, Height: 50, Rotation: 90}
+	for _, p := range []engo.Point{{X: -25, Y: 50}, {X: -1, Y: 99}, {X: -49, Y: 1}} {
+		assert.True(t, rotated.Contains(p), fmt.Sprintf("point %v should be within rotated area", p))
+	}
+	for _, f := range []engo.Point{{X: 25, Y: 25}, {X: 50, Y: 25}, {X: -25, Y: 110}, {X: -60, Y: 50}} {
+		assert.False(t, rotated.Contains(f), fmt.Sprintf("point %v should not be within rotated area", f))
+	}
+}
+
+func TestSpaceComponent_ContainsDegenerate(t *testing.T) {
+	point := SpaceComponent{Position: engo.Point{X: 10, Y: 10}}
+	assert.True(t, point.Contains(engo.Point{X: 10, Y: 10}), "position of a space without size should be within it")
+	assert.False(t, point.Contains(engo.Point{X: 10.5, Y: 10}), "point next to a space without size should not be within it")
+
+	rotatedPoint := SpaceComponent{Position: engo.Point{X: 10, Y: 10}, Rotation: 45}
+	assert.True(t, rotatedPoint.Contains(engo.Point{X: 10, Y: 10}), "position of a rotated space without size should be within it")
+
+	line := SpaceComponent{Position: engo.Point{X: 10, Y: 10}, Width: 20}
+	assert.True(t, line.Contains(engo.Point{X: 20, Y: 10}), "point along a space without height should be within it")
+	assert.False(t, line.Contains(engo.Point{X: 20, Y: 11}), "point next to a space without height should not be within it")
+	assert.False(t, line.Contains(engo.Point{X: 35, Y: 10}), "point beyond a space without height should not be within it")
+
+	// A negative size extends the area to the left and up, spanning from (-10, -20) to (0, 0)
+	negative := SpaceComponent{Width: -10, Height: -20}
+	assert.True(t, negative.Contains(engo.Point{X: -5, Y: -15}), "point should be within an area with a negative size")
+	assert.False(t, negative.Contains(engo.Point{X: 5, Y: 15}), "point should not be within an area with a negative size")
+	assert.False(t, negative.Contains(engo.Point{X: -10, Y: -10}), "point on the edge should not be within an area with a negative size")
+	assert.Equal(t, engo.AABB{Min: engo.Point{X: -10, Y: -20}}, negative.AABB(), "minimum of an AABB with a negative size should be smaller than its maximum")
+
+	negative.Rotation = 90
+	aabb := negative.AABB()
+	assert.True(t, aabb.Min.X < aabb.Max.X && aabb.Min.Y < aabb.Max.Y, fmt.Sprintf("minimum of a rotated AABB with a negative size should be smaller than its maximum, got: %v", aabb))
+
+	point.Rotation = 45
+	assert.Equal(t, engo.AABB{Min: point.Position, Max: point.Position}, point.AABB(), "AABB of a rotated space without size should be its position")
 }
 
 func TestSpaceComponent_Corners(t *testing.T) {
@@ -130,10 +169,596 @@ func TestSpaceComponent_Center(t *testing.T) {
 	}
 
 	for _, sc := range components {
-		for _, p := range points {
-			sc.SetCenter(p)
-			c := sc.Center()
-			assert.True(t, c.Equal(p), fmt.Sprintf("center %v should be equal to point %v", c, p))
+		for _, rotation := range []float32{0, 30, 90} {
+			sc.Rotation = rotation
+			for _, p := range points {
+				sc.SetCenter(p)
+				c := sc.Center()
+				assert.True(t, c.Equal(p), fmt.Sprintf("center %v should be equal to point %v when rotated by %v", c, p, rotation))
+			}
+		}
+	}
+}
+
+func TestCollisionSystemMaxMovePerStep(t *testing.T) {
+	engo.Mailbox = &engo.MessageManager{}
+
+	const (
+		Bullet CollisionGroup = 1 << iota
+		Wall
+	)
+
+	shoot := func(maxMovePerStep float32) *SpaceComponent {
+		bulletBasic, wallBasic := ecs.NewBasic(), ecs.NewBasic()
+		bullet := &SpaceComponent{Position: engo.Point{X: 0, Y: 10}, Width: 2, Height: 2}
+		wall := &SpaceComponent{Position: engo.Point{X: 50, Y: 0}, Width: 2, Height: 100}
+
+		sys := &CollisionSystem{Solids: Wall, MaxMovePerStep: maxMovePerStep}
+		sys.Add(&bulletBasic, &CollisionComponent{Main: Wall, Group: Bullet}, bullet)
+		sys.Add(&wallBasic, &CollisionComponent{Group: Wall}, wall)
+		sys.Update(0.01)
+
+		// Within a single frame, the bullet moves all the way past the wall.
+		bullet.Position.X = 100
+		sys.Update(0.01)
+		return bullet
+	}
+
+	if bullet := shoot(0); bullet.Position.X != 100 {
+		t.Errorf("bullet did not pass through the wall without substepping. Wanted: %v, got: %v", 100, bullet.Position.X)
+	}
+
+	bullet := shoot(0.5)
+	if bullet.Position.X > 48 || bullet.Position.X < 47 {
+		t.Errorf("bullet did not stop in front of the wall. Wanted: %v, got: %v", 48, bullet.Position.X)
+	}
+	if bullet.Position.Y != 10 {
+		t.Errorf("bullet moved vertically while stopping. Wanted: %v, got: %v", 10, bullet.Position.Y)
+	}
+}
+
+func TestCollisionSystemReportsPairsOnce(t *testing.T) {
+	engo.Mailbox = &engo.MessageManager{}
+
+	const (
+		Player CollisionGroup = 1 << iota
+		Enemy
+	)
+
+	var messages []CollisionMessage
+	engo.Mailbox.Listen("CollisionMessage", func(msg engo.Message) {
+		messages = append(messages, msg.(CollisionMessage))
+	})
+
+	for _, solids := range []CollisionGroup{0, Player | Enemy} {
+		messages = nil
+		first, second := ecs.NewBasic(), ecs.NewBasic()
+		player := &CollisionComponent{Main: Enemy, Group: Player}
+		enemy := &CollisionComponent{Main: Player, Group: Enemy}
+
+		// The entity with the highest ID is added first, to make sure the order is not taken from the system.
+		sys := &CollisionSystem{Solids: solids}
+		sys.Add(&second, enemy, &SpaceComponent{Position: engo.Point{X: 5, Y: 0}, Width: 10, Height: 10})
+		sys.Add(&first, player, &SpaceComponent{Width: 10, Height: 10})
+		sys.Update(0.01)
+
+		if len(messages) != 1 {
+			t.Fatalf("collision between two Main entities was not reported once with solids %v. Wanted: %v messages, got: %v", solids, 1, len(messages))
+		}
+		if msg := messages[0]; msg.Entity.ID() != first.ID() || msg.To.ID() != second.ID() {
+			t.Errorf("collision was not reported in ID order. Wanted: %v to %v, got: %v to %v", first.ID(), second.ID(), msg.Entity.ID(), msg.To.ID())
+		}
+		if msg := messages[0]; msg.Groups != Player|Enemy {
+			t.Errorf("collision did not report the groups of both entities. Wanted: %v, got: %v", Player|Enemy, msg.Groups)
+		}
+		if player.Collides != Enemy || enemy.Collides != Player {
+			t.Errorf("collision state was not updated for both entities. Wanted: %v and %v, got: %v and %v", Enemy, Player, player.Collides, enemy.Collides)
+		}
+	}
+}
+
+func TestCollisionSystemLayers(t *testing.T) {
+	engo.Mailbox = &engo.MessageManager{}
+
+	const (
+		Friendly CollisionLayer = 1 << iota
+		Hostile
+	)
+
+	tests := []struct {
+		layer1, mask1, layer2, mask2 CollisionLayer
+		expected                     bool
+	}{
+		{0, 0, 0, 0, true},
+		{Friendly, 0, Friendly, 0, true},
+		{Friendly, Hostile, Hostile, Friendly, true},
+		{Friendly, Hostile, Friendly, Hostile, false},
+		{Friendly, Hostile, Hostile, 0, true},
+		{Friendly, Friendly | Hostile, Hostile, Hostile, false},
+	}
+
+	for _, test := range tests {
+		var collided bool
+		engo.Mailbox = &engo.MessageManager{}
+		engo.Mailbox.Listen("CollisionMessage", func(engo.Message) {
+			collided = true
+		})
+
+		first, second := ecs.NewBasic(), ecs.NewBasic()
+		sys := &CollisionSystem{}
+		sys.Add(&first, &CollisionComponent{Main: 1, Group: 1, Layer: test.layer1, Mask: test.mask1}, &SpaceComponent{Width: 10, Height: 10})
+		sys.Add(&second, &CollisionComponent{Main: 1, Group: 1, Layer: test.layer2, Mask: test.mask2}, &SpaceComponent{Position: engo.Point{X: 5}, Width: 10, Height: 10})
+		sys.Update(0.01)
+
+		if collided != test.expected {
+			t.Errorf("wrong collision for layer %v with mask %v against layer %v with mask %v. Wanted: %v, got: %v",
+				test.layer1, test.mask1, test.layer2, test.mask2, test.expected, collided)
+		}
+	}
+}
+
+// updateAllPairs is the CollisionSystem update without a broadphase, testing every pair of entities. It's used as a
+// reference for the results of the spatial hash.
+func updateAllPairs(c *CollisionSystem) {
+	for i, e := range c.entities {
+		if e.CollisionComponent.Main == 0 {
+			continue
+		}
+		e.CollisionComponent.Collides = 0
+		if c.MaxMovePerStep > 0 {
+			c.sweep(i)
+		}
+	}
+
+	for i1 := range c.entities {
+		for i2 := i1 + 1; i2 < len(c.entities); i2++ {
+			c.collide(c.entities[i1], c.entities[i2])
+		}
+	}
+
+	for i := range c.entities {
+		c.entities[i].previous = c.entities[i].Position
+	}
+}
+
+// newCollisionBenchmark returns a CollisionSystem with n randomly placed entities of various sizes, at a density
+// which does not depend on n. A quarter of the entities are Main, and half of all collisions are solid. There's also
+// a wall spanning the whole area.
+func newCollisionBenchmark(n int, seed int64) *CollisionSystem {
+	r := rand.New(rand.NewSource(seed))
+	area := math.Sqrt(float32(n)) * 40
+	sys := &CollisionSystem{Solids: 1}
+	for i := 0; i < n; i++ {
+		basic := ecs.NewBasic()
+		collision := &CollisionComponent{Group: 1 << uint(r.Intn(2))}
+		if i%4 == 0 {
+			collision.Main = 3
+		}
+		sys.Add(&basic, collision, &SpaceComponent{
+			Position: engo.Point{X: r.Float32() * area, Y: r.Float32() * area},
+			Width:    4 + r.Float32()*16,
+			Height:   4 + r.Float32()*16,
+			Rotation: float32(r.Intn(4)) * 30,
+		})
+	}
+	wall := ecs.NewBasic()
+	sys.Add(&wall, &CollisionComponent{Group: 1}, &SpaceComponent{Position: engo.Point{Y: area / 2}, Width: area, Height: 10})
+	return sys
+}
+
+func TestCollisionSystemSpatialHash(t *testing.T) {
+	// Both systems have entities with different IDs, so collisions are recorded relative to the first entity.
+	type pair struct{ entity, to uint64 }
+	var (
+		messages []pair
+		first    uint64
+	)
+	engo.Mailbox = &engo.MessageManager{}
+	engo.Mailbox.Listen("CollisionMessage", func(msg engo.Message) {
+		m := msg.(CollisionMessage)
+		messages = append(messages, pair{m.Entity.ID() - first, m.To.ID() - first})
+	})
+
+	for _, cellSize := range []float32{0, 3, 100} {
+		expected, actual := newCollisionBenchmark(500, 1), newCollisionBenchmark(500, 1)
+		actual.CellSize = cellSize
+
+		messages, first = nil, expected.entities[0].ID()
+		for i := 0; i < 3; i++ {
+			updateAllPairs(expected)
+		}
+		expectedMessages := messages
+
+		messages, first = nil, actual.entities[0].ID()
+		for i := 0; i < 3; i++ {
+			actual.Update(0.01)
+		}
+
+		if len(messages) != len(expectedMessages) {
+			t.Fatalf("spatial hash with cell size %v did not report the same number of collisions. Wanted: %v, got: %v", cellSize, len(expectedMessages), len(messages))
+		}
+		for i := range messages {
+			if messages[i] != expectedMessages[i] {
+				t.Fatalf("spatial hash with cell size %v reported collisions in a different order. Wanted: %v, got: %v", cellSize, expectedMessages[i], messages[i])
+			}
+		}
+		for i := range actual.entities {
+			e1, e2 := expected.entities[i], actual.entities[i]
+			if e1.Position != e2.Position || e1.Collides != e2.Collides {
+				t.Fatalf("spatial hash with cell size %v did not resolve entity %v the same way. Wanted: %v colliding with %v, got: %v colliding with %v",
+					cellSize, i, e1.Position, e1.Collides, e2.Position, e2.Collides)
+			}
+		}
+	}
+}
+
+// BenchmarkCollisionSystem compares testing every pair of entities with using the spatial hash as a broadphase.
+func BenchmarkCollisionSystem(b *testing.B) {
+	engo.Mailbox = &engo.MessageManager{}
+
+	for _, n := range []int{1000, 5000} {
+		b.Run(fmt.Sprintf("AllPairs/%d", n), func(b *testing.B) {
+			sys := newCollisionBenchmark(n, 1)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				updateAllPairs(sys)
+			}
+		})
+		b.Run(fmt.Sprintf("SpatialHash/%d", n), func(b *testing.B) {
+			sys := newCollisionBenchmark(n, 1)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				sys.Update(0.01)
+			}
+		})
+	}
+}
+
+func TestCollisionSystemContact(t *testing.T) {
+	var messages []CollisionMessage
+	engo.Mailbox = &engo.MessageManager{}
+	engo.Mailbox.Listen("CollisionMessage", func(msg engo.Message) {
+		messages = append(messages, msg.(CollisionMessage))
+	})
+
+	tests := []struct {
+		main1, main2 CollisionGroup
+		normal       engo.Point
+	}{
+		{1, 0, engo.Point{X: 0, Y: -1}},
+		{0, 1, engo.Point{X: 0, Y: 1}},
+		{1, 1, engo.Point{X: 0, Y: -1}},
+	}
+
+	for _, test := range tests {
+		messages = nil
+		top, bottom := ecs.NewBasic(), ecs.NewBasic()
+		sys := &CollisionSystem{}
+		sys.Add(&top, &CollisionComponent{Main: test.main1, Group: 1}, &SpaceComponent{Position: engo.Point{X: 0, Y: 0}, Width: 10, Height: 10})
+		sys.Add(&bottom, &CollisionComponent{Main: test.main2, Group: 1}, &SpaceComponent{Position: engo.Point{X: 2, Y: 8}, Width: 10, Height: 10})
+		sys.Update(0.01)
+
+		if len(messages) != 1 {
+			t.Fatalf("collision was not reported once. Wanted: %v messages, got: %v", 1, len(messages))
+		}
+		if c := messages[0].Contact; c.Normal != test.normal || !engo.FloatEqual(c.Depth, 2) {
+			t.Errorf("wrong contact for the collision of %v with %v. Wanted: normal %v and depth %v, got: normal %v and depth %v",
+				messages[0].Entity.ID(), messages[0].To.ID(), test.normal, 2, c.Normal, c.Depth)
 		}
 	}
 }
+
+func TestCollisionShapes(t *testing.T) {
+	entity := func(x, y, w, h, rotation float32, shape CollisionShape) collisionEntity {
+		basic := ecs.NewBasic()
+		return collisionEntity{
+			BasicEntity:        &basic,
+			CollisionComponent: &CollisionComponent{Main: 1, Group: 1, Collider: shape},
+			SpaceComponent:     &SpaceComponent{Position: engo.Point{X: x, Y: y}, Width: w, Height: h, Rotation: rotation},
+		}
+	}
+	circle := CollisionCircle{Center: engo.Point{X: 5, Y: 5}, Radius: 5}
+	// A right triangle, with its slope facing the upper right
+	slope := CollisionPolygon{Points: []engo.Point{{X: 0, Y: 10}, {X: 10, Y: 0}, {X: 10, Y: 10}}}
+
+	tests := []struct {
+		name     string
+		e1, e2   collisionEntity
+		collides bool
+		mtd      engo.Point
+	}{
+		{"circle-circle", entity(0, 0, 10, 10, 0, circle), entity(8, 0, 10, 10, 0, circle), true, engo.Point{X: -2}},
+		{"circle-circle apart", entity(0, 0, 10, 10, 0, circle), entity(8, 8, 10, 10, 0, circle), false, engo.Point{}},
+		{"circle-rectangle", entity(0, 0, 10, 10, 0, circle), entity(3, 9, 10, 10, 0, nil), true, engo.Point{Y: -1}},
+		{"circle-rectangle corner", entity(0, 0, 10, 10, 0, circle), entity(9, 9, 10, 10, 0, nil), false, engo.Point{}},
+		{"rectangle-circle", entity(3, 9, 10, 10, 0, nil), entity(0, 0, 10, 10, 0, circle), true, engo.Point{Y: 1}},
+		{"polygon-rectangle below the slope", entity(0, 0, 10, 10, 0, slope), entity(7, 7, 10, 10, 0, nil), true, engo.Point{X: -3}},
+		{"polygon-rectangle above the slope", entity(0, 0, 10, 10, 0, slope), entity(1, -4, 4, 4, 0, nil), false, engo.Point{}},
+		// Rotated by 180 degrees around its Position, the slope spans from (0, 0) to (10, 10) and faces the lower right
+		{"rotated polygon", entity(10, 10, 10, 10, 180, slope), entity(6, 6, 4, 4, 0, nil), false, engo.Point{}},
+		{"rotated polygon overlapping", entity(10, 10, 10, 10, 180, slope), entity(1, 1, 4, 4, 0, nil), true, engo.Point{X: 5}},
+		{"rectangle-rectangle", entity(0, 0, 10, 10, 0, nil), entity(8, 0, 10, 10, 0, nil), true, engo.Point{X: -2}},
+	}
+
+	for _, test := range tests {
+		mtd, collides := overlap(test.e1, test.e2)
+		if collides != test.collides {
+			t.Errorf("%s: wrong collision. Wanted: %v, got: %v", test.name, test.collides, collides)
+			continue
+		}
+		if !engo.FloatEqual(mtd.X, test.mtd.X) || !engo.FloatEqual(mtd.Y, test.mtd.Y) {
+			t.Errorf("%s: wrong translation. Wanted: %v, got: %v", test.name, test.mtd, mtd)
+		}
+	}
+}
+
+func TestCollisionSystemContinuous(t *testing.T) {
+	const (
+		Bullet CollisionGroup = 1 << iota
+		Wall
+		Trigger
+	)
+
+	var messages []CollisionMessage
+	engo.Mailbox = &engo.MessageManager{}
+	engo.Mailbox.Listen("CollisionMessage", func(msg engo.Message) {
+		messages = append(messages, msg.(CollisionMessage))
+	})
+
+	var maxMovePerStep float32
+	shoot := func(continuous bool) (*SpaceComponent, *CollisionComponent) {
+		messages = nil
+		bulletBasic, wallBasic, triggerBasic := ecs.NewBasic(), ecs.NewBasic(), ecs.NewBasic()
+		bullet := &SpaceComponent{Position: engo.Point{X: 0, Y: 10}, Width: 2, Height: 2}
+		collision := &CollisionComponent{Main: Wall | Trigger, Group: Bullet, Continuous: continuous}
+
+		sys := &CollisionSystem{Solids: Wall, MaxMovePerStep: maxMovePerStep}
+		sys.Add(&bulletBasic, collision, bullet)
+		sys.Add(&triggerBasic, &CollisionComponent{Group: Trigger}, &SpaceComponent{Position: engo.Point{X: 20, Y: 0}, Width: 1, Height: 100})
+		sys.Add(&wallBasic, &CollisionComponent{Group: Wall}, &SpaceComponent{Position: engo.Point{X: 50, Y: 0}, Width: 1, Height: 100})
+		sys.Update(0.01)
+
+		// Within a single frame, the bullet moves through the trigger and all the way past the wall.
+		bullet.Position.X = 100
+		sys.Update(0.01)
+		return bullet, collision
+	}
+
+	if bullet, _ := shoot(false); bullet.Position.X != 100 || len(messages) != 0 {
+		t.Errorf("discrete bullet did not tunnel through the wall. Wanted: %v without collisions, got: %v with %v collisions", 100, bullet.Position.X, len(messages))
+	}
+
+	bullet, collision := shoot(true)
+	if !engo.FloatEqual(bullet.Position.X, 48) || bullet.Position.Y != 10 {
+		t.Errorf("continuous bullet did not stop in front of the wall. Wanted: %v, got: %v", engo.Point{X: 48, Y: 10}, bullet.Position)
+	}
+	if !engo.FloatEqual(collision.TimeOfImpact, 0.18) {
+		t.Errorf("wrong time of impact, which should be at the trigger. Wanted: %v, got: %v", 0.18, collision.TimeOfImpact)
+	}
+	if collision.Collides != Wall|Trigger {
+		t.Errorf("collisions along the way were not recorded. Wanted: %v, got: %v", Wall|Trigger, collision.Collides)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("collisions with the trigger and the wall were not both reported. Wanted: %v messages, got: %v", 2, len(messages))
+	}
+	if msg := messages[0]; msg.Groups != Trigger || msg.Contact.Normal != (engo.Point{X: -1}) {
+		t.Errorf("passing through the trigger was not reported first. Wanted: groups %v with normal %v, got: groups %v with normal %v", Trigger, engo.Point{X: -1}, msg.Groups, msg.Contact.Normal)
+	}
+	if msg := messages[1]; msg.Groups != Wall || msg.Contact.Normal != (engo.Point{X: -1}) {
+		t.Errorf("hitting the wall was not reported. Wanted: groups %v with normal %v, got: groups %v with normal %v", Wall, engo.Point{X: -1}, msg.Groups, msg.Contact.Normal)
+	}
+
+	// Continuous entities are swept rather than substepped, so the MaxMovePerStep of the system does not affect them.
+	maxMovePerStep = 0.5
+	bullet, collision = shoot(true)
+	if !engo.FloatEqual(bullet.Position.X, 48) || !engo.FloatEqual(collision.TimeOfImpact, 0.18) || len(messages) != 2 {
+		t.Errorf("continuous bullet was substepped. Wanted: %v with %v collisions, got: %v with %v collisions", 48, 2, bullet.Position.X, len(messages))
+	}
+}
+
+func TestCollisionSystemDegenerateSize(t *testing.T) {
+	engo.Mailbox = &engo.MessageManager{}
+
+	const (
+		Bullet CollisionGroup = 1 << iota
+		Wall
+	)
+
+	bulletBasic, wallBasic := ecs.NewBasic(), ecs.NewBasic()
+	bullet := &CollisionComponent{Main: Wall, Group: Bullet}
+	bulletSpace := &SpaceComponent{Position: engo.Point{X: 20, Y: 20}}
+	// The wall has a negative size, so it spans from (0, 0) to (50, 50)
+	wallSpace := &SpaceComponent{Position: engo.Point{X: 50, Y: 50}, Width: -50, Height: -50}
+
+	sys := &CollisionSystem{}
+	sys.Add(&bulletBasic, bullet, bulletSpace)
+	sys.Add(&wallBasic, &CollisionComponent{Group: Wall}, wallSpace)
+	sys.Update(0.01)
+	if bullet.Collides != Wall {
+		t.Error("bullet without a size did not collide with the wall it was within")
+	}
+
+	bulletSpace.Position = engo.Point{X: 50, Y: 20}
+	sys.Update(0.01)
+	if bullet.Collides != 0 {
+		t.Error("bullet without a size collided with the wall it only touched")
+	}
+
+	bulletSpace.Position = engo.Point{X: 70, Y: 20}
+	sys.Update(0.01)
+	if bullet.Collides != 0 {
+		t.Error("bullet without a size collided with the wall it was not within")
+	}
+}
+
+func TestCollisionSystemRaycast(t *testing.T) {
+	sys := &CollisionSystem{}
+	add := func(x, y, w, h float32, shape CollisionShape) *SpaceComponent {
+		basic := ecs.NewBasic()
+		space := &SpaceComponent{Position: engo.Point{X: x, Y: y}, Width: w, Height: h}
+		sys.Add(&basic, &CollisionComponent{Group: 1, Collider: shape}, space)
+		return space
+	}
+	far := add(50, 0, 10, 10, nil)
+	near := add(10, 0, 10, 10, nil)
+	add(30, 0, 10, 10, nil)
+	circle := add(70, 0, 10, 10, CollisionCircle{Center: engo.Point{X: 5, Y: 5}, Radius: 5})
+	// A right triangle, with its slope facing the upper left
+	slope := add(100, 0, 10, 10, CollisionPolygon{Points: []engo.Point{{X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}})
+	sys.Update(0)
+
+	hits := sys.Raycast(engo.Point{X: 0, Y: 5}, engo.Point{X: 2}, 200)
+	expected := []float32{10, 30, 50, 70, 105}
+	if len(hits) != len(expected) {
+		t.Fatalf("ray through several entities did not hit all of them. Wanted: %v hits, got: %+v", len(expected), hits)
+	}
+	for i, hit := range hits {
+		if !engo.FloatEqual(hit.Distance, expected[i]) || !engo.FloatEqual(hit.Point.X, expected[i]) || !engo.FloatEqual(hit.Point.Y, 5) {
+			t.Errorf("hit %v was not sorted near to far. Wanted: distance %v, got: %v at %v", i, expected[i], hit.Distance, hit.Point)
+		}
+	}
+	spaceOf := func(hit RaycastHit) *SpaceComponent {
+		_, space, _ := sys.Get(hit.ID)
+		return space
+	}
+	if spaceOf(hits[0]) != near || spaceOf(hits[2]) != far || spaceOf(hits[3]) != circle {
+		t.Error("hits were not reported for the entities which were hit")
+	}
+	if !engo.FloatEqual(hits[0].Normal.X, -1) || !engo.FloatEqual(hits[3].Normal.X, -1) {
+		t.Errorf("hits did not face the ray. Wanted: (-1, 0), got: %v and %v", hits[0].Normal, hits[3].Normal)
+	}
+	if n := hits[4].Normal; spaceOf(hits[4]) != slope || !engo.FloatEqual(n.X, n.Y) || n.X >= 0 {
+		t.Errorf("ray did not hit the slope of the polygon. Wanted: a normal facing the upper left, got: %v", n)
+	}
+
+	if hits := sys.Raycast(engo.Point{X: 0, Y: 5}, engo.Point{X: 1}, 35); len(hits) != 2 {
+		t.Errorf("ray hit entities beyond its maximum distance, got: %+v", hits)
+	}
+	if hits := sys.Raycast(engo.Point{X: 0, Y: 20}, engo.Point{X: 1}, 200); len(hits) != 0 {
+		t.Errorf("ray that misses all entities hit them, got: %+v", hits)
+	}
+	if hits := sys.Raycast(engo.Point{X: 0, Y: 0}, engo.Point{X: 1}, 65); len(hits) != 0 {
+		t.Errorf("ray grazing the edges of entities hit them, got: %+v", hits)
+	}
+	if hits := sys.Raycast(engo.Point{X: 15, Y: 5}, engo.Point{X: 1}, 20); len(hits) != 1 || hits[0].Distance != 15 {
+		t.Errorf("ray starting within an entity did not only hit the next one, got: %+v", hits)
+	}
+
+	// Entities which moved or were added since the update are found where they are
+	near.Position.Y = 100
+	add(10, 200, 10, 10, nil)
+	if hits := sys.Raycast(engo.Point{X: 15, Y: 50}, engo.Point{Y: 1}, 200); len(hits) != 2 || hits[0].Distance != 50 || hits[1].Distance != 150 {
+		t.Errorf("ray did not hit the entities which moved or were added since the update, got: %+v", hits)
+	}
+}
+
+func TestCollisionSystemQuery(t *testing.T) {
+	const (
+		Enemy CollisionLayer = 1 << iota
+		Friend
+	)
+	sys := &CollisionSystem{}
+	add := func(x, y float32, layer CollisionLayer, shape CollisionShape) uint64 {
+		basic := ecs.NewBasic()
+		sys.Add(&basic, &CollisionComponent{Layer: layer, Collider: shape}, &SpaceComponent{Position: engo.Point{X: x, Y: y}, Width: 10, Height: 10})
+		return basic.ID()
+	}
+	enemy := add(0, 0, Enemy, nil)
+	friend := add(20, 0, Friend, nil)
+	add(40, 0, Enemy, nil) // touches the AABBs of the queries
+	corner := add(30, 30, Enemy, nil)
+	round := add(0, 30, Enemy, CollisionCircle{Center: engo.Point{X: 5, Y: 5}, Radius: 5})
+	sys.Update(0)
+
+	tests := []struct {
+		name     string
+		actual   []uint64
+		expected []uint64
+	}{
+		{"aabb", sys.QueryAABB(engo.AABB{Min: engo.Point{X: 5, Y: 5}, Max: engo.Point{X: 40, Y: 32}}, 0), []uint64{enemy, friend, corner, round}},
+		{"aabb of enemies", sys.QueryAABB(engo.AABB{Min: engo.Point{X: 5, Y: 5}, Max: engo.Point{X: 40, Y: 32}}, Enemy), []uint64{enemy, corner, round}},
+		{"aabb missing a circle in its corner", sys.QueryAABB(engo.AABB{Min: engo.Point{X: 9, Y: 30}, Max: engo.Point{X: 20, Y: 31}}, 0), nil},
+		{"circle", sys.QueryCircle(engo.Point{X: 30, Y: 20}, 10.5, 0), []uint64{friend, corner}},
+		// The corner of the AABB of the circle, but not the circle itself, is within the circle
+		{"circle missing a circle", sys.QueryCircle(engo.Point{X: 12, Y: 28}, 3, 0), nil},
+		{"circle within a circle", sys.QueryCircle(engo.Point{X: 5, Y: 35}, 1, Enemy), []uint64{round}},
+		// The AABB of the circle, but not the circle itself, overlaps the corner of an entity
+		{"circle missing a corner", sys.QueryCircle(engo.Point{X: 43, Y: 27}, 4, 0), nil},
+	}
+	for _, test := range tests {
+		if len(test.actual) != len(test.expected) {
+			t.Errorf("%s: wrong entities. Wanted: %v, got: %v", test.name, test.expected, test.actual)
+			continue
+		}
+		for i := range test.actual {
+			if test.actual[i] != test.expected[i] {
+				t.Errorf("%s: wrong entities. Wanted: %v, got: %v", test.name, test.expected, test.actual)
+				break
+			}
+		}
+	}
+}
+
+func TestCollisionSystemNearest(t *testing.T) {
+	const (
+		Enemy CollisionLayer = 1 << iota
+		Friend
+	)
+	add := func(sys *CollisionSystem, x, y, size float32, layer CollisionLayer) uint64 {
+		basic := ecs.NewBasic()
+		sys.Add(&basic, &CollisionComponent{Layer: layer}, &SpaceComponent{Position: engo.Point{X: x, Y: y}, Width: size, Height: size})
+		return basic.ID()
+	}
+
+	// A cluster of friends around the point, with enemies around it
+	clustered := &CollisionSystem{CellSize: 10}
+	for x := float32(-20); x < 20; x += 4 {
+		for y := float32(-20); y < 20; y += 4 {
+			add(clustered, x, y, 2, Friend)
+		}
+	}
+	farEnemy := add(clustered, -60, -60, 5, Enemy)
+	enemy := add(clustered, 50, 1, 5, Enemy)
+	add(clustered, 1, 50, 5, Enemy) // as far away from the cluster as the other enemy
+
+	// Entities far apart, with a large one in between
+	sparse := &CollisionSystem{CellSize: 10}
+	add(sparse, -1000, 500, 10, Enemy)
+	lone := add(sparse, 300, -400, 10, Enemy)
+	add(sparse, -100, 0, 10, Friend)
+	floor := add(sparse, -500, 100, 1000, Friend)
+
+	tests := []struct {
+		name     string
+		sys      *CollisionSystem
+		p        engo.Point
+		mask     CollisionLayer
+		id       uint64
+		distance float32
+	}{
+		{"enemy outside of the cluster", clustered, engo.Point{X: 1, Y: 1}, Enemy, enemy, 49},
+		{"enemy across the cluster", clustered, engo.Point{X: -50, Y: -50}, Enemy, farEnemy, 5 * 1.4142135},
+		{"friend within the cluster", clustered, engo.Point{X: 1, Y: 1}, Friend, 0, 0},
+		// Both enemies are 45 away, the one with the lowest ID wins
+		{"tied enemies", clustered, engo.Point{X: 5, Y: 5}, Enemy, enemy, 45},
+		{"lone entity", sparse, engo.Point{X: 100, Y: -190}, Enemy, lone, 200 * 1.4142135},
+		{"large entity", sparse, engo.Point{X: 900, Y: 900}, Friend, floor, 400},
+	}
+	for _, test := range tests {
+		id, distance, ok := test.sys.Nearest(test.p, test.mask)
+		if !ok {
+			t.Errorf("%s: no entity was found", test.name)
+			continue
+		}
+		if (test.id != 0 && id != test.id) || !engo.FloatEqual(distance, test.distance) {
+			t.Errorf("%s: wrong entity. Wanted: %v at %v, got: %v at %v", test.name, test.id, test.distance, id, distance)
+		}
+	}
+
+	if _, _, ok := (&CollisionSystem{}).Nearest(engo.Point{}, 0); ok {
+		t.Error("entity was found without any entities")
+	}
+	if _, _, ok := sparse.Nearest(engo.Point{}, 1<<5); ok {
+		t.Error("entity was found on a layer without any entities")
+	}
+}
@@ -0,0 +1,141 @@
+package common
+
+import (
+	"github.com/EngoEngine/ecs"
+)
+
+// CooldownComponent holds any number of independent, named cooldowns of an entity, such as one for shooting and
+// another for dashing. A cooldown is started using Trigger, and counted down by the CooldownSystem until it's Ready
+// again. Cooldowns which were never triggered are ready.
+type CooldownComponent struct {
+	remaining map[string]float32
+}
+
+// Trigger starts the cooldown with the given name, which is ready again after duration seconds. A cooldown which
+// wasn't ready yet is restarted with the new duration.
+func (c *CooldownComponent) Trigger(name string, duration float32) {
+	if duration <= 0 {
+		delete(c.remaining, name)
+		return
+	}
+	if c.remaining == nil {
+		c.remaining = make(map[string]float32)
+	}
+	c.remaining[name] = duration
+}
+
+// Ready indicates whether or not the cooldown with the given name is over, or was never triggered.
+func (c *CooldownComponent) Ready(name string) bool {
+	_, ok := c.remaining[name]
+	return !ok
+}
+
+// Remaining returns the time in seconds until the cooldown with the given name is ready, or zero if it is.
+func (c *CooldownComponent) Remaining(name string) float32 {
+	return c.remaining[name]
+}
+
+// Reset makes every cooldown ready right away.
+func (c *CooldownComponent) Reset() {
+	c.remaining = nil
+}
+
+type cooldownEntity struct {
+	*ecs.BasicEntity
+	*CooldownComponent
+}
+
+// CooldownSystem counts down the cooldowns of entities every update, by the delta time, so they take the same time
+// regardless of the frame rate. A cooldown triggered during a frame starts counting down during the next update of
+// the CooldownSystem. Cooldowns are checked and triggered through the system by the ID of the entity, or on the
+// CooldownComponent itself.
+type CooldownSystem struct {
+	entities []cooldownEntity
+	ids      entityIDs
+}
+
+// Add adds an entity to the CooldownSystem. To be added, the entity has to have a basic and cooldown component.
+func (c *CooldownSystem) Add(basic *ecs.BasicEntity, cooldown *CooldownComponent) {
+	if !c.ids.add(basic.ID()) {
+		return
+	}
+	c.entities = append(c.entities, cooldownEntity{basic, cooldown})
+}
+
+// AddByInterface Provides a simple way to add an entity to the system that satisfies Cooldownable. Any entity containing, BasicEntity and CooldownComponent anonymously, automatically does this.
+func (c *CooldownSystem) AddByInterface(i ecs.Identifier) {
+	o, _ := i.(Cooldownable)
+	c.Add(o.GetBasicEntity(), o.GetCooldownComponent())
+}
+
+// Remove removes an entity from the CooldownSystem.
+func (c *CooldownSystem) Remove(basic ecs.BasicEntity) {
+	c.ids.remove(basic.ID())
+	delete := -1
+	for index, e := range c.entities {
+		if e.BasicEntity.ID() == basic.ID() {
+			delete = index
+			break
+		}
+	}
+	if delete >= 0 {
+		c.entities = append(c.entities[:delete], c.entities[delete+1:]...)
+	}
+}
+
+// Entities returns a copy of the IDs of all entities in the CooldownSystem.
+func (c *CooldownSystem) Entities() []uint64 {
+	ids := make([]uint64, len(c.entities))
+	for i, e := range c.entities {
+		ids[i] = e.ID()
+	}
+	return ids
+}
+
+// BasicEntities returns a copy of the BasicEntities of all entities in the CooldownSystem.
+func (c *CooldownSystem) BasicEntities() []ecs.BasicEntity {
+	basics := make([]ecs.BasicEntity, len(c.entities))
+	for i, e := range c.entities {
+		basics[i] = *e.BasicEntity
+	}
+	return basics
+}
+
+// Get returns the cooldown component with which the entity with the given ID was added to the CooldownSystem, and
+// false if it isn't in the system, such as once it was removed.
+func (c *CooldownSystem) Get(id uint64) (*CooldownComponent, bool) {
+	for _, e := range c.entities {
+		if e.ID() == id {
+			return e.CooldownComponent, true
+		}
+	}
+	return nil, false
+}
+
+// Ready indicates whether or not the cooldown with the given name of the entity with the given ID is ready, see
+// `CooldownComponent.Ready`. It's false for entities which aren't in the system.
+func (c *CooldownSystem) Ready(id uint64, name string) bool {
+	cooldown, ok := c.Get(id)
+	return ok && cooldown.Ready(name)
+}
+
+// Trigger starts the cooldown with the given name of the entity with the given ID, see `CooldownComponent.Trigger`.
+// Nothing happens for entities which aren't in the system.
+func (c *CooldownSystem) Trigger(id uint64, name string, duration float32) {
+	if cooldown, ok := c.Get(id); ok {
+		cooldown.Trigger(name, duration)
+	}
+}
+
+// Update counts down the cooldowns of all entities by dt seconds.
+func (c *CooldownSystem) Update(dt float32) {
+	for _, e := range c.entities {
+		for name, remaining := range e.remaining {
+			if remaining -= dt; remaining > 0 {
+				e.remaining[name] = remaining
+			} else {
+				delete(e.remaining, name)
+			}
+		}
+	}
+}
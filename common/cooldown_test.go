@@ -0,0 +1,41 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+)
+
+func TestCooldownSystem(t *testing.T) {
+	basic := ecs.NewBasic()
+	cooldown := &CooldownComponent{}
+	c := &CooldownSystem{}
+	c.Add(&basic, cooldown)
+	id := basic.ID()
+
+	if !c.Ready(id, "shoot") || c.Ready(id+1, "shoot") {
+		t.Error("cooldown which was never triggered was not ready")
+	}
+
+	c.Trigger(id, "shoot", 0.5)
+	c.Trigger(id, "dash", 2)
+	c.Update(0.25)
+	if c.Ready(id, "shoot") || c.Ready(id, "dash") {
+		t.Error("triggered cooldowns were ready before they were over")
+	}
+	if remaining := cooldown.Remaining("shoot"); remaining != 0.25 {
+		t.Errorf("wrong remaining time. Wanted: 0.25, got: %v", remaining)
+	}
+
+	c.Update(0.25)
+	if !c.Ready(id, "shoot") || c.Ready(id, "dash") {
+		t.Errorf("cooldowns were not counted down independently. Shoot ready: %v, dash ready: %v", c.Ready(id, "shoot"), c.Ready(id, "dash"))
+	}
+
+	// Triggering again restarts the cooldown
+	c.Trigger(id, "dash", 0.5)
+	c.Update(0.5)
+	if !c.Ready(id, "dash") {
+		t.Errorf("retriggered cooldown was not restarted, %v seconds remaining", cooldown.Remaining("dash"))
+	}
+}
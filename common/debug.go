@@ -0,0 +1,285 @@
+package common
+
+import (
+	"image/color"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/engo/math"
+)
+
+// DebugSystemPriority is the priority of the DebugSystem, which runs after the systems that move, hover or collide
+// entities, but before the RenderSystem draws them.
+const DebugSystemPriority = -950
+
+// debugCircleSegments is the amount of lines with which a CollisionCircle is drawn.
+const debugCircleSegments = 24
+
+// The colors used by the DebugSystem whenever its colors aren't set.
+var (
+	defaultDebugColor        = color.NRGBA{R: 0, G: 255, B: 0, A: 255}
+	defaultDebugHoverColor   = color.NRGBA{R: 255, G: 255, B: 0, A: 255}
+	defaultDebugCollideColor = color.NRGBA{R: 255, G: 0, B: 0, A: 255}
+	defaultDebugMouseColor   = color.NRGBA{R: 255, G: 0, B: 255, A: 255}
+)
+
+type debugEntity struct {
+	*ecs.BasicEntity
+	*SpaceComponent
+	*RenderComponent
+	*CollisionComponent
+	*MouseComponent
+}
+
+// debugLine is a single line drawn by the DebugSystem.
+type debugLine struct {
+	from, to engo.Point
+	color    color.Color
+	screen   bool
+}
+
+// DebugSystem draws the outline of the AABB of every entity, along with the shape of its CollisionComponent, so you
+// can see why a click or a collision misfires. The outlines are drawn in Color, HoverColor while the MouseComponent
+// of the entity is hovered, and CollideColor while its CollisionComponent collides with anything. When it's added to
+// the same world as the RenderSystem, the outlines are drawn by the RenderSystem in the "debug" pass, on top of the
+// world and the HUD.
+//
+// Since entities don't need a component of their own, every entity with a SpaceComponent is added by adding the
+// system using `World.AddSystemInterface` with Debuggable. For release builds, leave the system out of the world, and
+// nothing is drawn or updated at all.
+type DebugSystem struct {
+	// Disabled stops drawing the outlines, while keeping the entities in the system
+	Disabled bool
+	// ShowMouse draws a cross at the position of the mouse in the world
+	ShowMouse bool
+	// Thickness is the width of the lines in pixels, regardless of the zoom level of the camera. It's 1 when zero.
+	Thickness float32
+	// Color is the color of the outlines of entities which are neither hovered nor colliding, it's green when nil
+	Color color.Color
+	// HoverColor is the color of the outlines of entities which are hovered by the mouse, it's yellow when nil
+	HoverColor color.Color
+	// CollideColor is the color of the outlines of entities which collide, it's red when nil
+	CollideColor color.Color
+	// MouseColor is the color of the cross drawn at the position of the mouse, it's magenta when nil
+	MouseColor color.Color
+
+	entities []debugEntity
+	ids      entityIDs
+	world    *ecs.World
+	camera   *CameraSystem
+	lines    []debugLine
+
+	render RenderComponent
+	space  SpaceComponent
+	passes []engo.RenderPass
+}
+
+// Priority implements the ecs.Prioritizer interface.
+func (*DebugSystem) Priority() int { return DebugSystemPriority }
+
+// renderPasses returns the "debug" pass, which draws the outlines on top of the HUD, so the outlines of entities on
+// the HUD aren't covered. It implements the renderPasser interface.
+func (ds *DebugSystem) renderPasses() []engo.RenderPass {
+	if ds.passes == nil {
+		ds.passes = []engo.RenderPass{{Name: "debug", Order: engo.RenderPassOverlay, Draw: ds.draw}}
+	}
+	return ds.passes
+}
+
+// New initializes the DebugSystem.
+func (ds *DebugSystem) New(w *ecs.World) {
+	ds.world = w
+	ds.render = RenderComponent{Drawable: Rectangle{}, Scale: engo.Point{X: 1, Y: 1}}
+}
+
+// Add adds an entity to the DebugSystem.
+//   - SpaceComponent is always required, its AABB is drawn.
+//   - RenderComponent is only required for entities in screen space, such as on the HUD (see
+//     `RenderComponent.ScreenSpace`), or to skip the entity while it's hidden.
+//   - CollisionComponent is optional, and draws the shape of the entity in CollideColor while it collides.
+//   - MouseComponent is optional, and draws the entity in HoverColor while it's hovered.
+func (ds *DebugSystem) Add(basic *ecs.BasicEntity, space *SpaceComponent, render *RenderComponent, collision *CollisionComponent, mouse *MouseComponent) {
+	if !ds.ids.add(basic.ID()) {
+		return
+	}
+	ds.entities = append(ds.entities, debugEntity{basic, space, render, collision, mouse})
+}
+
+// AddByInterface Provides a simple way to add an entity to the system that satisfies Debuggable. Any entity containing, BasicEntity and SpaceComponent anonymously, automatically does this. Its RenderComponent, CollisionComponent and MouseComponent are used whenever it has them.
+func (ds *DebugSystem) AddByInterface(i ecs.Identifier) {
+	o, _ := i.(Debuggable)
+	var render *RenderComponent
+	if r, ok := i.(RenderFace); ok {
+		render = r.GetRenderComponent()
+	}
+	var collision *CollisionComponent
+	if c, ok := i.(CollisionFace); ok {
+		collision = c.GetCollisionComponent()
+	}
+	var mouse *MouseComponent
+	if m, ok := i.(MouseFace); ok {
+		mouse = m.GetMouseComponent()
+	}
+	ds.Add(o.GetBasicEntity(), o.GetSpaceComponent(), render, collision, mouse)
+}
+
+// Remove removes an entity from the DebugSystem.
+func (ds *DebugSystem) Remove(basic ecs.BasicEntity) {
+	ds.ids.remove(basic.ID())
+	delete := -1
+	for index, e := range ds.entities {
+		if e.BasicEntity.ID() == basic.ID() {
+			delete = index
+			break
+		}
+	}
+	if delete >= 0 {
+		ds.entities = append(ds.entities[:delete], ds.entities[delete+1:]...)
+	}
+}
+
+// Update collects the outlines to draw this frame, after the entities were moved, hovered and collided.
+func (ds *DebugSystem) Update(dt float32) {
+	ds.lines = ds.lines[:0]
+	if ds.Disabled {
+		return
+	}
+
+	for _, e := range ds.entities {
+		if e.RenderComponent != nil && e.RenderComponent.Hidden {
+			continue
+		}
+		screen := e.RenderComponent != nil && e.RenderComponent.ScreenSpace
+
+		c := debugColor(ds.Color, defaultDebugColor)
+		if e.MouseComponent != nil && e.MouseComponent.Hovered {
+			c = debugColor(ds.HoverColor, defaultDebugHoverColor)
+		}
+		if e.CollisionComponent != nil && e.CollisionComponent.Collides != 0 {
+			c = debugColor(ds.CollideColor, defaultDebugCollideColor)
+		}
+
+		aabb := e.SpaceComponent.AABB()
+		ds.polygon([]engo.Point{
+			aabb.Min,
+			{X: aabb.Max.X, Y: aabb.Min.Y},
+			aabb.Max,
+			{X: aabb.Min.X, Y: aabb.Max.Y},
+		}, c, screen)
+
+		// The shape of an entity without a Collider is its AABB, unless it's rotated
+		if e.CollisionComponent != nil && (e.CollisionComponent.Collider != nil || e.SpaceComponent.Rotation != 0) {
+			ds.shape(collisionEntity{BasicEntity: e.BasicEntity, CollisionComponent: e.CollisionComponent, SpaceComponent: e.SpaceComponent}.shape(), c, screen)
+		}
+	}
+
+	if ds.ShowMouse && ds.findCamera() {
+		x, y := ds.camera.ScreenToWorld(engo.Input.Mouse.X, engo.Input.Mouse.Y)
+		size := 5 * ds.camera.renderZ()
+		c := debugColor(ds.MouseColor, defaultDebugMouseColor)
+		ds.lines = append(ds.lines,
+			debugLine{engo.Point{X: x - size, Y: y}, engo.Point{X: x + size, Y: y}, c, false},
+			debugLine{engo.Point{X: x, Y: y - size}, engo.Point{X: x, Y: y + size}, c, false},
+		)
+	}
+}
+
+// findCamera looks up the CameraSystem of the world, and returns whether or not it was found.
+func (ds *DebugSystem) findCamera() bool {
+	if ds.camera != nil {
+		return true
+	}
+	if ds.world == nil {
+		return false
+	}
+	for _, system := range ds.world.Systems() {
+		if sys, ok := system.(*CameraSystem); ok {
+			ds.camera = sys
+			return true
+		}
+	}
+	return false
+}
+
+// polygon adds the outline of the polygon with the given corners.
+func (ds *DebugSystem) polygon(points []engo.Point, c color.Color, screen bool) {
+	for i, p := range points {
+		ds.lines = append(ds.lines, debugLine{p, points[(i+1)%len(points)], c, screen})
+	}
+}
+
+// shape adds the outline of a collision shape, approximating circles using debugCircleSegments lines.
+func (ds *DebugSystem) shape(s satShape, c color.Color, screen bool) {
+	if s.points != nil {
+		ds.polygon(s.points, c, screen)
+		return
+	}
+
+	points := make([]engo.Point, debugCircleSegments)
+	for i := range points {
+		sin, cos := math.Sincos(2 * math.Pi * float32(i) / debugCircleSegments)
+		points[i] = engo.Point{X: s.center.X + cos*s.radius, Y: s.center.Y + sin*s.radius}
+	}
+	ds.polygon(points, c, screen)
+}
+
+// draw draws the lines collected during the last update, as thin rectangles using the LegacyShader, so they're
+// drawn in a single batch. It's drawn by the RenderSystem as a render pass, see `RenderSystem.passes`.
+func (ds *DebugSystem) draw() {
+	if len(ds.lines) == 0 {
+		return
+	}
+	thickness := ds.Thickness
+	if thickness <= 0 {
+		thickness = 1
+	}
+	zoom := float32(1)
+	if ds.findCamera() {
+		zoom = ds.camera.renderZ()
+	}
+
+	for _, screen := range []bool{false, true} {
+		shader := LegacyShader
+		width := thickness * zoom
+		if screen {
+			shader = LegacyHUDShader
+			width = thickness
+		}
+
+		shader.Pre()
+		for _, line := range ds.lines {
+			if line.screen != screen {
+				continue
+			}
+			ds.drawLine(shader, line, width)
+		}
+		shader.Post()
+	}
+}
+
+// drawLine draws a single line of the given width, centered on the line.
+func (ds *DebugSystem) drawLine(shader Shader, line debugLine, width float32) {
+	dx, dy := line.to.X-line.from.X, line.to.Y-line.from.Y
+	length := math.Sqrt(dx*dx + dy*dy)
+	if length == 0 {
+		return
+	}
+	sin, cos := dy/length, dx/length
+
+	ds.render.Color = line.color
+	ds.space = SpaceComponent{
+		Position: engo.Point{X: line.from.X + sin*width/2, Y: line.from.Y - cos*width/2},
+		Width:    length,
+		Height:   width,
+		Rotation: math.Atan2(dy, dx) * 180 / math.Pi,
+	}
+	shader.Draw(&ds.render, &ds.space)
+}
+
+// debugColor returns c, or the default whenever c is nil.
+func debugColor(c, def color.Color) color.Color {
+	if c == nil {
+		return def
+	}
+	return c
+}
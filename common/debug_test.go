@@ -0,0 +1,92 @@
+package common
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+type debugTestEntity struct {
+	ecs.BasicEntity
+	SpaceComponent
+	CollisionComponent
+	MouseComponent
+}
+
+func TestDebugSystem(t *testing.T) {
+	w := &ecs.World{}
+	ds := &DebugSystem{CollideColor: color.Black}
+	var debuggable *Debuggable
+	var notDebuggable *NotDebuggable
+	w.AddSystemInterface(ds, debuggable, notDebuggable)
+
+	e := &debugTestEntity{BasicEntity: ecs.NewBasic()}
+	e.SpaceComponent = SpaceComponent{Position: engo.Point{X: 10, Y: 20}, Width: 30, Height: 40}
+	e.CollisionComponent.Collider = CollisionCircle{Center: engo.Point{X: 15, Y: 20}, Radius: 10}
+	w.AddEntity(e)
+
+	ds.Update(0)
+	if len(ds.lines) != 4+debugCircleSegments {
+		t.Fatalf("wrong amount of lines were drawn for the AABB and shape. Wanted: %v, got: %v", 4+debugCircleSegments, len(ds.lines))
+	}
+	if first := ds.lines[0]; first.from != e.SpaceComponent.Position || first.to != (engo.Point{X: 40, Y: 20}) {
+		t.Errorf("AABB was not outlined, first line: %v to %v", first.from, first.to)
+	}
+	if c := ds.lines[0].color; c != defaultDebugColor {
+		t.Errorf("entity was not drawn in the default color, got: %v", c)
+	}
+
+	e.MouseComponent.Hovered = true
+	ds.Update(0)
+	if c := ds.lines[0].color; c != defaultDebugHoverColor {
+		t.Errorf("hovered entity was not drawn in HoverColor, got: %v", c)
+	}
+	e.CollisionComponent.Collides = 1
+	ds.Update(0)
+	if c := ds.lines[len(ds.lines)-1].color; c != color.Black {
+		t.Errorf("colliding entity was not drawn in CollideColor, got: %v", c)
+	}
+
+	ds.Disabled = true
+	ds.Update(0)
+	if len(ds.lines) != 0 {
+		t.Errorf("disabled DebugSystem drew %v lines", len(ds.lines))
+	}
+}
+
+func TestDebugSystemScreenSpace(t *testing.T) {
+	ds := &DebugSystem{}
+	basics := []ecs.BasicEntity{ecs.NewBasic(), ecs.NewBasic()}
+	space := &SpaceComponent{Width: 10, Height: 10}
+	ds.Add(&basics[0], space, &RenderComponent{ScreenSpace: true}, nil, nil)
+	ds.Add(&basics[1], space, &RenderComponent{Hidden: true}, nil, nil)
+
+	ds.Update(0)
+	if len(ds.lines) != 4 {
+		t.Fatalf("hidden entity was drawn, drew %v lines", len(ds.lines))
+	}
+	for _, line := range ds.lines {
+		if !line.screen {
+			t.Error("entity in screen space was not outlined in screen space")
+		}
+	}
+
+	ds.Remove(basics[0])
+	ds.Update(0)
+	if len(ds.lines) != 0 {
+		t.Errorf("removed entity was drawn, drew %v lines", len(ds.lines))
+	}
+}
+
+func TestDebugSystemRenderPass(t *testing.T) {
+	w := &ecs.World{}
+	rs := &RenderSystem{world: w}
+	w.AddSystem(&DebugSystem{})
+
+	passes := rs.passes()
+	if pass := passes[len(passes)-2]; pass.Name != "debug" {
+		t.Errorf("debug pass was not drawn right before the transition, got: %v", pass.Name)
+	}
+}
@@ -0,0 +1,193 @@
+package common
+
+import (
+	"image/color"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+// DragDropComponent makes an entity Draggable, a DropTarget onto which dragged entities can be dropped, or both. See
+// DragDropSystem.
+type DragDropComponent struct {
+	// Draggable lets the entity be dragged using the DragButton of its MouseComponent
+	Draggable bool
+	// DropTarget lets dragged entities be dropped onto the entity
+	DropTarget bool
+	// Accepts indicates whether or not the DropTarget accepts the dragged entity with the given ID. A DropTarget
+	// without Accepts accepts every entity.
+	Accepts func(source uint64) bool
+
+	// Dragging is true while the Draggable entity is being dragged
+	Dragging bool
+	// Highlighted is true while the DropTarget is under the cursor dragging an entity it accepts
+	Highlighted bool
+
+	// origin is the position of the dragged entity before it was dragged, grab is the cursor relative to it
+	origin, grab engo.Point
+	// at is the position of the cursor while the entity was last dragged
+	at engo.Point
+	// color is the Color of the RenderComponent of the DropTarget before it was tinted by highlighting it
+	color  color.Color
+	tinted bool
+}
+
+// accepts indicates whether or not the DropTarget accepts the dragged entity.
+func (d *DragDropComponent) accepts(source uint64) bool {
+	return d.DropTarget && (d.Accepts == nil || d.Accepts(source))
+}
+
+type dragDropEntity struct {
+	*ecs.BasicEntity
+	*MouseComponent
+	*SpaceComponent
+	*DragDropComponent
+	*RenderComponent
+}
+
+// DragDropSystem moves Draggable entities along with the cursor while they're dragged, and drops them onto the
+// DropTarget under the cursor once they're released. Entities are dragged as determined by the MouseSystem, so the
+// world needs one, and every entity has to be in the MouseSystem as well: an entity is dragged once the DragButton of
+// its MouseComponent was pressed on it, and the cursor moved past the DragThreshold of the MouseSystem.
+//
+// While an entity is dragged, the DropTarget under the cursor which accepts it is Highlighted, and tinted using
+// HighlightColor if it has a RenderComponent. Once the entity is released onto that DropTarget, OnDrop is called with
+// both entities, and the dragged entity stays where it was dropped. When it's released anywhere else, it snaps back
+// to where it was before it was dragged. The cursor and the SpaceComponents of the DropTargets are expected to be in
+// the same coordinates as the dragged entity, which are those of the world unless it's on the HUD, see
+// `MouseComponent.MouseX`. Of multiple DropTargets under the cursor, the front-most one is used, just like with
+// `MouseSystem.TopMostOnly`.
+type DragDropSystem struct {
+	// OnDrop is called whenever a dragged entity is dropped onto a DropTarget which accepts it
+	OnDrop func(source, target ecs.BasicEntity)
+	// HighlightColor is the Color of the RenderComponent of a DropTarget while it's Highlighted. The Color is left
+	// unchanged when it's nil.
+	HighlightColor color.Color
+
+	entities []dragDropEntity
+	ids      entityIDs
+	// highlighted holds the IDs of the DropTargets to highlight during an update
+	highlighted map[uint64]bool
+}
+
+// Add adds an entity to the DragDropSystem. The BasicEntity, MouseComponent, SpaceComponent and DragDropComponent
+// are required, the RenderComponent is optional; it's tinted while the entity is Highlighted, and determines which
+// of the DropTargets is in front.
+func (d *DragDropSystem) Add(basic *ecs.BasicEntity, mouse *MouseComponent, space *SpaceComponent, dragDrop *DragDropComponent, render *RenderComponent) {
+	if !d.ids.add(basic.ID()) {
+		return
+	}
+	d.entities = append(d.entities, dragDropEntity{basic, mouse, space, dragDrop, render})
+}
+
+// AddByInterface Provides a simple way to add an entity to the system that satisfies DragDroppable. Any entity
+// containing, BasicEntity, MouseComponent, SpaceComponent and DragDropComponent anonymously, automatically does
+// this. A RenderComponent is added along with them if the entity has one.
+func (d *DragDropSystem) AddByInterface(i ecs.Identifier) {
+	o, _ := i.(DragDroppable)
+	var render *RenderComponent
+	if r, ok := i.(RenderFace); ok {
+		render = r.GetRenderComponent()
+	}
+	d.Add(o.GetBasicEntity(), o.GetMouseComponent(), o.GetSpaceComponent(), o.GetDragDropComponent(), render)
+}
+
+// Remove removes an entity from the DragDropSystem. An entity which is being dragged stays where it is.
+func (d *DragDropSystem) Remove(basic ecs.BasicEntity) {
+	d.ids.remove(basic.ID())
+	for i, e := range d.entities {
+		if e.ID() == basic.ID() {
+			d.highlight(e, false)
+			e.DragDropComponent.Dragging = false
+			d.entities = append(d.entities[:i], d.entities[i+1:]...)
+			return
+		}
+	}
+}
+
+// Update moves the dragged entities along with the cursor, highlights the DropTargets under them, and drops the
+// entities which were released.
+func (d *DragDropSystem) Update(dt float32) {
+	if d.highlighted == nil {
+		d.highlighted = make(map[uint64]bool)
+	}
+	for id := range d.highlighted {
+		delete(d.highlighted, id)
+	}
+	for _, e := range d.entities {
+		dd := e.DragDropComponent
+		if !dd.Draggable {
+			continue
+		}
+
+		// The MouseSystem has started dragging the entity once the cursor moved far enough while pressing
+		dragging := e.MouseComponent.startedDragging && e.MouseComponent.startedMoving
+		switch {
+		case dragging:
+			if !dd.Dragging {
+				dd.Dragging = true
+				dd.origin = e.SpaceComponent.Position
+				dd.grab = engo.Point{
+					X: e.MouseComponent.pressedAt.X - e.SpaceComponent.Position.X,
+					Y: e.MouseComponent.pressedAt.Y - e.SpaceComponent.Position.Y,
+				}
+			}
+			dd.at = engo.Point{X: e.MouseComponent.MouseX, Y: e.MouseComponent.MouseY}
+			e.SpaceComponent.Position = engo.Point{X: dd.at.X - dd.grab.X, Y: dd.at.Y - dd.grab.Y}
+			if target, ok := d.targetAt(e, dd.at); ok {
+				d.highlighted[target.ID()] = true
+			}
+		case dd.Dragging:
+			// The entity was released
+			dd.Dragging = false
+			target, ok := d.targetAt(e, dd.at)
+			if !ok {
+				e.SpaceComponent.Position = dd.origin
+				continue
+			}
+			if d.OnDrop != nil {
+				d.OnDrop(*e.BasicEntity, *target.BasicEntity)
+			}
+		}
+	}
+
+	for _, e := range d.entities {
+		if e.DragDropComponent.DropTarget {
+			d.highlight(e, d.highlighted[e.ID()])
+		}
+	}
+}
+
+// targetAt returns the front-most DropTarget at the given point which accepts the dragged entity, and false if there
+// is none.
+func (d *DragDropSystem) targetAt(source dragDropEntity, p engo.Point) (dragDropEntity, bool) {
+	var (
+		target dragDropEntity
+		found  bool
+	)
+	for _, e := range d.entities {
+		if e.ID() == source.ID() || !e.DragDropComponent.accepts(source.ID()) || !e.SpaceComponent.hits(p) {
+			continue
+		}
+		if !found || !(mouseEntity{RenderComponent: target.RenderComponent}).inFrontOf(mouseEntity{RenderComponent: e.RenderComponent}) {
+			target, found = e, true
+		}
+	}
+	return target, found
+}
+
+// highlight sets whether or not the DropTarget is Highlighted, and tints it accordingly.
+func (d *DragDropSystem) highlight(e dragDropEntity, on bool) {
+	dd := e.DragDropComponent
+	if dd.Highlighted == on {
+		return
+	}
+	dd.Highlighted = on
+	switch {
+	case on && e.RenderComponent != nil && d.HighlightColor != nil:
+		dd.color, dd.tinted = e.RenderComponent.Color, true
+		e.RenderComponent.Color = d.HighlightColor
+	case !on && dd.tinted:
+		e.RenderComponent.Color, dd.color, dd.tinted = dd.color, nil, false
+	}
+}
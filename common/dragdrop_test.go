@@ -0,0 +1,95 @@
+package common
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+func TestDragDropSystem(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	w.AddSystem(&CameraSystem{})
+	m := &MouseSystem{}
+	w.AddSystem(m)
+	var dropped []ecs.BasicEntity
+	d := &DragDropSystem{
+		OnDrop:         func(source, target ecs.BasicEntity) { dropped = append(dropped, source, target) },
+		HighlightColor: color.White,
+	}
+	w.AddSystem(d)
+
+	card, slot, locked := ecs.NewBasic(), ecs.NewBasic(), ecs.NewBasic()
+	cardSpace := &SpaceComponent{Position: engo.Point{X: 100, Y: 100}, Width: 50, Height: 50}
+	cardDragDrop := &DragDropComponent{Draggable: true}
+	slotDragDrop := &DragDropComponent{DropTarget: true}
+	slotRender := &RenderComponent{Color: color.Black}
+	lockedDragDrop := &DragDropComponent{DropTarget: true, Accepts: func(uint64) bool { return false }}
+	entities := []struct {
+		basic    *ecs.BasicEntity
+		space    *SpaceComponent
+		dragDrop *DragDropComponent
+		render   *RenderComponent
+	}{
+		{&card, cardSpace, cardDragDrop, nil},
+		{&slot, &SpaceComponent{Position: engo.Point{X: 300, Y: 100}, Width: 50, Height: 50}, slotDragDrop, slotRender},
+		{&locked, &SpaceComponent{Position: engo.Point{X: 300, Y: 200}, Width: 50, Height: 50}, lockedDragDrop, nil},
+	}
+	for _, e := range entities {
+		mouse := &MouseComponent{}
+		m.Add(e.basic, mouse, e.space, e.render)
+		d.Add(e.basic, mouse, e.space, e.dragDrop, e.render)
+	}
+	defer func() { engo.Input.Mouse.Action = engo.Neutral }()
+
+	mouse := func(action engo.Action, x, y float32) {
+		engo.Input.Mouse.X, engo.Input.Mouse.Y = x, y
+		engo.Input.Mouse.Button = engo.MouseButtonLeft
+		engo.Input.Mouse.Action = action
+		w.Update(0)
+	}
+
+	mouse(engo.Press, 120, 130)
+	mouse(engo.Move, 200, 130)
+	if !cardDragDrop.Dragging || cardSpace.Position != (engo.Point{X: 180, Y: 100}) {
+		t.Errorf("card did not follow the cursor where it was grabbed. Wanted: (180, 100), got: %v", cardSpace.Position)
+	}
+	mouse(engo.Move, 320, 130)
+	if !slotDragDrop.Highlighted || slotRender.Color != color.White {
+		t.Error("slot under the dragged card was not highlighted")
+	}
+	mouse(engo.Release, 320, 130)
+	if len(dropped) != 2 || dropped[0].ID() != card.ID() || dropped[1].ID() != slot.ID() {
+		t.Errorf("card was not dropped onto the slot, got: %v", dropped)
+	}
+	if cardDragDrop.Dragging || cardSpace.Position != (engo.Point{X: 300, Y: 100}) {
+		t.Errorf("card did not stay where it was dropped. Wanted: (300, 100), got: %v", cardSpace.Position)
+	}
+	if slotDragDrop.Highlighted || slotRender.Color != color.Black {
+		t.Error("slot was still highlighted once the card was dropped")
+	}
+
+	// The locked slot doesn't accept the card, so it snaps back
+	dropped = nil
+	mouse(engo.Press, 320, 130)
+	mouse(engo.Move, 320, 230)
+	if lockedDragDrop.Highlighted {
+		t.Error("slot which doesn't accept the card was highlighted")
+	}
+	mouse(engo.Release, 320, 230)
+	if len(dropped) != 0 {
+		t.Errorf("card was dropped onto a slot which doesn't accept it, got: %v", dropped)
+	}
+	if cardSpace.Position != (engo.Point{X: 300, Y: 100}) {
+		t.Errorf("card did not snap back to where it was. Wanted: (300, 100), got: %v", cardSpace.Position)
+	}
+}
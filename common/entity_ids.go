@@ -0,0 +1,22 @@
+package common
+
+// entityIDs is the set of IDs of the entities in a system. Systems use it to ignore entities which are added to them
+// again, such as by shared setup code, rather than updating them twice.
+type entityIDs map[uint64]struct{}
+
+// add adds the ID to the set, and returns false if it was already in it.
+func (ids *entityIDs) add(id uint64) bool {
+	if *ids == nil {
+		*ids = make(entityIDs)
+	}
+	if _, ok := (*ids)[id]; ok {
+		return false
+	}
+	(*ids)[id] = struct{}{}
+	return true
+}
+
+// remove removes the ID from the set.
+func (ids entityIDs) remove(id uint64) {
+	delete(ids, id)
+}
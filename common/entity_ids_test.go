@@ -0,0 +1,37 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+)
+
+func TestSystemsIgnoreDuplicateEntities(t *testing.T) {
+	basic := ecs.NewBasic()
+
+	h := &HealthSystem{}
+	h.Add(&basic, &HealthComponent{})
+	h.Add(&basic, &HealthComponent{})
+	if len(h.entities) != 1 {
+		t.Errorf("entity added twice was not ignored by the HealthSystem, got %v entities", len(h.entities))
+	}
+	h.Remove(basic)
+	h.Add(&basic, &HealthComponent{})
+	if len(h.entities) != 1 {
+		t.Errorf("entity was not added again after it was removed, got %v entities", len(h.entities))
+	}
+
+	m := &MouseSystem{}
+	m.Add(&basic, &MouseComponent{}, &SpaceComponent{}, nil)
+	m.Add(&basic, &MouseComponent{}, &SpaceComponent{}, nil)
+	if len(m.entities) != 1 || len(m.index) != 1 {
+		t.Errorf("entity added twice was not ignored by the MouseSystem, got %v entities", len(m.entities))
+	}
+
+	s := &ShapeSystem{}
+	s.Add(&basic, &ShapeComponent{})
+	s.AddWithSpace(&basic, &ShapeComponent{}, &SpaceComponent{})
+	if len(s.entities) != 1 || s.entities[0].SpaceComponent != nil {
+		t.Errorf("entity added twice was not ignored by the ShapeSystem, got %v entities", len(s.entities))
+	}
+}
@@ -5,10 +5,14 @@ import (
 	"image"
 	"image/color"
 	"image/draw"
+	"io"
 	"io/ioutil"
 	"log"
+	"strings"
+	"unicode"
 
 	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/engo/math"
 	"github.com/EngoEngine/gl"
 	"github.com/golang/freetype"
 	"github.com/golang/freetype/truetype"
@@ -18,6 +22,9 @@ import (
 
 var (
 	dpi = float64(72)
+
+	// DefaultFont is the Font used to draw a Text whenever its Font is left nil.
+	DefaultFont *Font
 )
 
 // Font keeps track of a specific Font. Fonts are explicit instances of a font file,
@@ -29,20 +36,78 @@ type Font struct {
 	BG   color.Color
 	FG   color.Color
 	TTF  *truetype.Font
-	face font.Face
+	// Fallback is the Font used to draw runes that have no glyph in this Font. Fallbacks can be chained, and are
+	// queried in order for each rune. Runes taken from a fallback are drawn using the Size and colors of this Font.
+	Fallback *Font
+	face     font.Face
 }
 
-// Create is for loading fonts from the disk, given a location
-func (f *Font) Create() error {
-	// Read and parse the font
-	ttfBytes, err := ioutil.ReadFile(f.URL)
+// HasGlyph indicates whether or not the Font itself (not its fallbacks) contains a glyph for the given rune.
+func (f *Font) HasGlyph(r rune) bool {
+	return f.TTF != nil && f.TTF.Index(r) != 0
+}
+
+// FontForRune returns the first Font of the fallback chain, starting with the Font itself, that contains a glyph for
+// the given rune. If none of them does, the Font itself is returned.
+func (f *Font) FontForRune(r rune) *Font {
+	for fnt := f; fnt != nil; fnt = fnt.Fallback {
+		if fnt.HasGlyph(r) {
+			return fnt
+		}
+	}
+	return f
+}
+
+// fontRun is a part of a string in which all runes are drawn using the same Font.
+type fontRun struct {
+	font *Font
+	text string
+}
+
+// runs splits the text into consecutive parts which can be drawn using a single Font of the fallback chain.
+func (f *Font) runs(text string) []fontRun {
+	var runs []fontRun
+	start := 0
+	var current *Font
+	for i, char := range text {
+		fnt := f.FontForRune(char)
+		if fnt != current && i > 0 {
+			runs = append(runs, fontRun{current, text[start:i]})
+			start = i
+		}
+		current = fnt
+	}
+	if start < len(text) {
+		runs = append(runs, fontRun{current, text[start:]})
+	}
+	return runs
+}
+
+// FontFromBytes parses the TrueType font in data, such as a font embedded using go:embed, into a Font of the given
+// size. It's drawn in black, unless FG is changed afterwards.
+func FontFromBytes(data []byte, size float64) (*Font, error) {
+	f := &Font{Size: size, FG: color.Black}
+	if err := f.parse(data); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// FontFromReader reads the TrueType font from r, such as a font fetched over the network, and parses it into a Font
+// of the given size, just like FontFromBytes.
+func FontFromReader(r io.Reader, size float64) (*Font, error) {
+	data, err := ioutil.ReadAll(r)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return FontFromBytes(data, size)
+}
 
-	ttf, err := freetype.ParseFont(ttfBytes)
+// parse parses the TrueType font in data, and uses it for this Font.
+func (f *Font) parse(data []byte) error {
+	ttf, err := freetype.ParseFont(data)
 	if err != nil {
-		return err
+		return fmt.Errorf("unable to parse font: %v", err)
 	}
 	f.TTF = ttf
 	f.face = truetype.NewFace(f.TTF, &truetype.Options{
@@ -50,10 +115,19 @@ func (f *Font) Create() error {
 		DPI:     dpi,
 		Hinting: font.HintingFull,
 	})
-
 	return nil
 }
 
+// Create is for loading fonts from the disk, given a location
+func (f *Font) Create() error {
+	// Read and parse the font
+	ttfBytes, err := ioutil.ReadFile(f.URL)
+	if err != nil {
+		return err
+	}
+	return f.parse(ttfBytes)
+}
+
 // CreatePreloaded is for loading fonts which have already been defined (and loaded) within Preload
 func (f *Font) CreatePreloaded() error {
 	fontres, err := engo.Files.Resource(f.URL)
@@ -78,16 +152,19 @@ func (f *Font) CreatePreloaded() error {
 // TextDimensions returns the total width, total height and total line size
 // of the input string written out in the Font.
 func (f *Font) TextDimensions(text string) (int, int, int) {
-	fnt := f.TTF
 	size := f.Size
 	var (
 		totalWidth  = fixed.Int26_6(0)
 		totalHeight = fixed.Int26_6(size)
 		maxYBearing = fixed.Int26_6(0)
 	)
-	fupe := fixed.Int26_6(fnt.FUnitsPerEm())
+	// widths keeps track of the unscaled width drawn using each font of the fallback chain, since each of them may
+	// use a different amount of units per em.
+	widths := make(map[*truetype.Font]fixed.Int26_6)
 
 	for _, char := range text {
+		fnt := f.FontForRune(char).TTF
+		fupe := fixed.Int26_6(fnt.FUnitsPerEm())
 		idx := fnt.Index(char)
 		hm := fnt.HMetric(fupe, idx)
 		vm := fnt.VMetric(fupe, idx)
@@ -97,7 +174,7 @@ func (f *Font) TextDimensions(text string) (int, int, int) {
 			log.Println(err)
 			return 0, 0, 0
 		}
-		totalWidth += hm.AdvanceWidth
+		widths[fnt] += hm.AdvanceWidth
 
 		yB := (vm.TopSideBearing * fixed.Int26_6(size)) / fupe
 		if yB > maxYBearing {
@@ -110,16 +187,24 @@ func (f *Font) TextDimensions(text string) (int, int, int) {
 	}
 
 	// Scale to actual pixel size
-	totalWidth *= fixed.Int26_6(size)
-	totalWidth /= fupe
+	for fnt, width := range widths {
+		totalWidth += width * fixed.Int26_6(size) / fixed.Int26_6(fnt.FUnitsPerEm())
+	}
 
 	return int(totalWidth), int(totalHeight), int(maxYBearing)
 }
 
+// MeasureText returns the size in pixels of the text when it's drawn as a `Text` using this Font, which is exactly
+// the size of the Drawable, without having to create one. The FontAtlas of the Font is generated if it wasn't already.
+// `TextDimensions` instead measures the text as it's drawn by `Render`.
+func (f *Font) MeasureText(text string) (width, height float32) {
+	txt := Text{Font: f, Text: text}
+	return txt.Width(), txt.Height()
+}
+
 // RenderNRGBA returns an *image.NRGBA in the Font based on the input string.
 func (f *Font) RenderNRGBA(text string) *image.NRGBA {
 	width, height, yBearing := f.TextDimensions(text)
-	font := f.TTF
 	size := f.Size
 
 	if size <= 0 {
@@ -145,18 +230,21 @@ func (f *Font) RenderNRGBA(text string) *image.NRGBA {
 	draw.Draw(nrgba, nrgba.Bounds(), bg, image.ZP, draw.Src)
 
 	c.SetDPI(dpi)
-	c.SetFont(font)
 	c.SetFontSize(size)
 	c.SetClip(nrgba.Bounds())
 	c.SetDst(nrgba)
 	c.SetSrc(fg)
 
-	// Draw the text.
+	// Draw the text, one run of runes sharing the same font at a time.
 	pt := fixed.P(0, yBearing)
-	_, err := c.DrawString(text, pt)
-	if err != nil {
-		log.Println(err)
-		return nil
+	for _, run := range f.runs(text) {
+		var err error
+		c.SetFont(run.font.TTF)
+		pt, err = c.DrawString(run.text, pt)
+		if err != nil {
+			log.Println(err)
+			return nil
+		}
 	}
 
 	return nrgba
@@ -171,13 +259,25 @@ func (f *Font) Render(text string) Texture {
 	return NewTextureSingle(imObj)
 }
 
-// generateFontAtlas generates the font atlas for this given font, using the first `c` Unicode characters.
-func (f *Font) generateFontAtlas(c int) FontAtlas {
+// generateFontAtlas generates the font atlas for this given font, using the first `c` Unicode characters along with
+// the extra runes beyond those.
+func (f *Font) generateFontAtlas(c int, extra ...rune) FontAtlas {
+	runes := make([]rune, 0, c+len(extra))
+	for i := 0; i < c; i++ {
+		runes = append(runes, rune(i))
+	}
+	for _, r := range extra {
+		if int(r) >= c {
+			runes = append(runes, r)
+		}
+	}
+
 	atlas := FontAtlas{
 		XLocation: make([]float32, c),
 		YLocation: make([]float32, c),
 		Width:     make([]float32, c),
 		Height:    make([]float32, c),
+		extra:     make(map[rune]atlasGlyph),
 	}
 
 	currentX := float32(0)
@@ -191,29 +291,43 @@ func (f *Font) generateFontAtlas(c int) FontAtlas {
 		f.BG = color.NRGBA{0, 0, 0, 0}
 	}
 
+	// faces contains a face for each font of the fallback chain, all using the size of this font.
+	faces := make(map[*Font]font.Face)
+	faceFor := func(r rune) font.Face {
+		fnt := f.FontForRune(r)
+		face, ok := faces[fnt]
+		if !ok {
+			face = truetype.NewFace(fnt.TTF, &truetype.Options{
+				Size:    f.Size,
+				DPI:     dpi,
+				Hinting: font.HintingNone,
+			})
+			faces[fnt] = face
+		}
+		return face
+	}
+
 	d := &font.Drawer{}
 	d.Src = image.NewUniform(f.FG)
-	d.Face = truetype.NewFace(f.TTF, &truetype.Options{
-		Size:    f.Size,
-		DPI:     dpi,
-		Hinting: font.HintingNone,
-	})
+	d.Face = faceFor(0)
 
 	lineHeight := d.Face.Metrics().Height
 	lineBuffer := float32(lineHeight.Ceil()) / 2
 	xBuffer := float32(10)
 
-	for i := 0; i < c; i++ {
-		_, adv, ok := d.Face.GlyphBounds(rune(i))
+	for j, r := range runes {
+		_, adv, ok := faceFor(r).GlyphBounds(r)
 		if !ok {
 			continue
 		}
 		currentX += xBuffer
 
-		atlas.Width[i] = float32(adv.Ceil())
-		atlas.Height[i] = float32(lineHeight.Ceil()) + lineBuffer
-		atlas.XLocation[i] = currentX
-		atlas.YLocation[i] = currentY
+		atlas.setGlyph(r, atlasGlyph{
+			x:      currentX,
+			y:      currentY,
+			width:  float32(adv.Ceil()),
+			height: float32(lineHeight.Ceil()) + lineBuffer,
+		})
 
 		currentX += float32(adv.Ceil()) + xBuffer
 
@@ -221,7 +335,7 @@ func (f *Font) generateFontAtlas(c int) FontAtlas {
 			atlas.TotalWidth = currentX
 		}
 
-		if currentX > 1024 || i >= c-1 {
+		if currentX > 1024 || j >= len(runes)-1 {
 			currentX = 0
 			currentY += float32(lineHeight.Ceil()) + lineBuffer
 			atlas.TotalHeight += float32(lineHeight.Ceil()) + lineBuffer
@@ -233,13 +347,15 @@ func (f *Font) generateFontAtlas(c int) FontAtlas {
 	draw.Draw(actual, actual.Bounds(), image.NewUniform(f.BG), image.ZP, draw.Src)
 	d.Dst = actual
 
-	for i := 0; i < c; i++ {
-		_, _, ok := d.Face.GlyphBounds(rune(i))
+	for _, r := range runes {
+		d.Face = faceFor(r)
+		_, _, ok := d.Face.GlyphBounds(r)
 		if !ok {
 			continue
 		}
-		d.Dot = fixed.P(int(atlas.XLocation[i]), int(atlas.YLocation[i]+float32(lineHeight.Ceil())))
-		d.DrawBytes([]byte{byte(i)})
+		g, _ := atlas.glyph(r)
+		d.Dot = fixed.P(int(g.x), int(g.y+float32(lineHeight.Ceil())))
+		d.DrawString(string(r))
 	}
 
 	imObj := NewImageObject(actual)
@@ -254,7 +370,8 @@ func (f *Font) GenerateFontAtlas(c int) FontAtlas {
 	return f.generateFontAtlas(c)
 }
 
-// A FontAtlas is a representation of some of the Font characters, as an image
+// A FontAtlas is a representation of some of the Font characters, as an image. The slices contain the first characters
+// the atlas was generated with; characters beyond those which are added for a `Text` are kept separately.
 type FontAtlas struct {
 	Texture *gl.Texture
 	// XLocation contains the X-coordinate of the starting position of all characters
@@ -271,14 +388,89 @@ type FontAtlas struct {
 	// TotalHeight is the total amount of pixels the `FontAtlas` is high; useful for determining the `Viewport`,
 	// which is relative to this value.
 	TotalHeight float32
+
+	// extra contains the characters beyond the first ones, which are only added whenever a Text uses them
+	extra map[rune]atlasGlyph
+}
+
+// atlasGlyph is the location and size in pixels of a single character within a `FontAtlas`.
+type atlasGlyph struct {
+	x, y, width, height float32
+}
+
+// glyph returns the location and size of the given character, and whether or not it is part of the atlas.
+func (a FontAtlas) glyph(r rune) (atlasGlyph, bool) {
+	if r >= 0 && int(r) < len(a.Width) {
+		return atlasGlyph{a.XLocation[r], a.YLocation[r], a.Width[r], a.Height[r]}, true
+	}
+	g, ok := a.extra[r]
+	return g, ok
+}
+
+// setGlyph sets the location and size of the given character.
+func (a *FontAtlas) setGlyph(r rune, g atlasGlyph) {
+	if int(r) < len(a.Width) {
+		a.XLocation[r], a.YLocation[r], a.Width[r], a.Height[r] = g.x, g.y, g.width, g.height
+		return
+	}
+	a.extra[r] = g
+}
+
+// Ellipsis is drawn in place of the part of a `Text` that was truncated. Whenever the Font does not contain it, three
+// periods are drawn instead.
+const Ellipsis = "…"
+
+// Truncation indicates which part of a line of `Text` is replaced by an `Ellipsis` when it does not fit within the
+// `MaxWidth` of the Text.
+type Truncation uint8
+
+const (
+	// TruncateNone does not truncate lines, even if they are wider than the `MaxWidth` of the Text.
+	TruncateNone Truncation = iota
+	// TruncateEnd keeps the start of the line, and replaces its end.
+	TruncateEnd
+	// TruncateStart keeps the end of the line, and replaces its start.
+	TruncateStart
+	// TruncateMiddle keeps both the start and the end of the line, and replaces its middle.
+	TruncateMiddle
+)
+
+// Alignment is the horizontal alignment of the lines of a `Text`.
+type Alignment uint8
+
+const (
+	// AlignLeft draws every line starting at the left.
+	AlignLeft Alignment = iota
+	// AlignCenter centers every line.
+	AlignCenter
+	// AlignRight draws every line ending at the right.
+	AlignRight
+)
+
+// TextRun is a part of a `Text` which is drawn using its own Font and color, such as a highlighted or a bold word.
+// Bold and italic text is drawn using a Font loaded from a bold or italic TTF file.
+type TextRun struct {
+	// Text is the text of the run. This may include newlines (\n).
+	Text string
+	// Font is the Font used to draw the run. If left nil, the Font of the Text is used instead.
+	Font *Font
+	// Color tints the run, along with the Color of the RenderComponent. If left nil, the run is not tinted. Just like
+	// the Color of the RenderComponent, it's multiplied with the color of the Font, so a white Font can be tinted in
+	// any color.
+	Color color.Color
 }
 
 // Text represents a string drawn onto the screen, as used by the `TextShader`.
 type Text struct {
 	// Font is the reference to the font you're using to render this. This includes the color, as well as the font size.
+	// If left nil, the DefaultFont is used instead, and nothing is drawn if there's no DefaultFont either.
 	Font *Font
 	// Text is the actual text you want to draw. This may include newlines (\n).
 	Text string
+	// Runs, when not empty, are drawn one after another instead of `Text`, each using its own Font and color. Lines
+	// are wrapped using the size of each of the runs. A line containing runs of different sizes is as high as its
+	// highest run, with all of them sharing the same baseline. Runs are never truncated, so use `Wrap` instead.
+	Runs []TextRun
 	// LineSpacing is the amount of additional spacing there is between the lines (when `Text` consists of multiple lines),
 	// relative to the `Size` of the `Font`.
 	LineSpacing float32
@@ -288,73 +480,439 @@ type Text struct {
 	// RightToLeft is an experimental variable used to indicate that subsequent characters come to the left of the
 	// previous character.
 	RightToLeft bool
+	// MaxWidth is the maximum width in pixels of each line of the Text. Lines which are wider are truncated as given
+	// by `Truncate`. It is ignored when zero.
+	MaxWidth float32
+	// Truncate indicates which part of a line that is wider than `MaxWidth` is replaced by an `Ellipsis`.
+	Truncate Truncation
+	// Wrap breaks lines that are wider than `MaxWidth` onto the next line, rather than truncating them. Lines are broken
+	// after the last word that fits, or in the middle of a word that doesn't fit on a line by itself. The spaces at a
+	// break are not drawn.
+	Wrap bool
+	// Align is the horizontal alignment of the lines. They are aligned within `MaxWidth`, or within the widest line
+	// when it is zero.
+	Align Alignment
 }
 
 // Texture returns nil because the Text is generated from a FontAtlas. This implements the common.Drawable interface.
 func (t Text) Texture() *gl.Texture { return nil }
 
-// Width returns the width of the Text generated from a FontAtlas. This implements the common.Drawable interface.
-func (t Text) Width() float32 {
-	atlas, ok := atlasCache[*t.Font]
-	if !ok {
-		// Generate texture first
-		atlas = t.Font.generateFontAtlas(200)
-		atlasCache[*t.Font] = atlas
+// font returns the Font used to draw the Text, which is the DefaultFont if no Font was set.
+func (t Text) font() *Font {
+	if t.Font == nil {
+		return DefaultFont
 	}
+	return t.Font
+}
 
-	var currentX float32
-	var greatestX float32
+// fontAtlas returns the FontAtlas of the given Font, which is generated the first time it's used. The atlas contains
+// the first `UnicodeCap` characters, and is generated again whenever the text contains runes beyond those which the
+// Font or one of its fallbacks has a glyph for, so that they're included as well.
+func fontAtlas(fnt *Font, text string) FontAtlas {
+	atlas, ok := atlasCache[*fnt]
+	var missing []rune
+	for _, r := range text {
+		// Whether or not a rune was added is looked up rather than based on its width, since glyphs such as combining
+		// accents have no width at all
+		if int(r) < UnicodeCap || containsRune(atlasRunes[*fnt], r) {
+			continue
+		}
+		if fnt.FontForRune(r).HasGlyph(r) && !containsRune(missing, r) {
+			missing = append(missing, r)
+		}
+	}
+	if ok && len(missing) == 0 {
+		return atlas
+	}
 
-	for _, char := range t.Text {
-		// TODO: this might not work for all characters
-		switch {
-		case char == '\n':
-			if currentX > greatestX {
-				greatestX = currentX
-			}
-			currentX = 0
+	if ok && !engo.Headless() {
+		engo.Gl.DeleteTexture(atlas.Texture)
+	}
+	// Generating the atlas sets the default colors of the Font, which are part of the key of both caches
+	runes := append(atlasRunes[*fnt], missing...)
+	atlas = fnt.generateFontAtlas(UnicodeCap, runes...)
+	atlasRunes[*fnt] = runes
+	atlasCache[*fnt] = atlas
+	return atlas
+}
+
+// containsRune indicates whether or not the runes contain r.
+func containsRune(runes []rune, r rune) bool {
+	for _, other := range runes {
+		if other == r {
+			return true
+		}
+	}
+	return false
+}
+
+// textStyle is how a single run of a Text is drawn.
+type textStyle struct {
+	atlas       FontAtlas
+	letterSpace float32
+	// lineHeight is the height of a line drawn using the atlas
+	lineHeight float32
+	tint       color.Color
+}
+
+// textStyles contains the style of every run of a Text.
+type textStyles []textStyle
+
+// advance returns how far the glyph advances the position of the next one.
+func (s textStyles) advance(g textGlyph) float32 {
+	glyph, ok := s[g.run].atlas.glyph(g.char)
+	if g.char < 32 || !ok {
+		return 0
+	}
+	return glyph.width + s[g.run].letterSpace
+}
+
+// styles returns the style of every run of the Text. A Text without Runs is drawn as a single run of `Text`. It
+// returns nil whenever a run has no Font and there's no DefaultFont either, in which case nothing is drawn.
+func (t Text) styles() textStyles {
+	runs := t.Runs
+	if len(runs) == 0 {
+		text := t.Text
+		if t.Truncate != TruncateNone {
+			text += Ellipsis
+		}
+		runs = []TextRun{{Text: text}}
+	}
+
+	styles := make(textStyles, len(runs))
+	for i, run := range runs {
+		fnt := run.Font
+		if fnt == nil {
+			fnt = t.font()
+		}
+		if fnt == nil {
+			return nil
+		}
+		atlas := fontAtlas(fnt, run.Text)
+		x, _ := atlas.glyph('X')
+		styles[i] = textStyle{
+			atlas:       atlas,
+			letterSpace: float32(fnt.Size) * t.LetterSpacing,
+			lineHeight:  x.height,
+			tint:        run.Color,
+		}
+	}
+	return styles
+}
+
+// textGlyph is a single character of a Text, along with the run it's part of.
+type textGlyph struct {
+	char rune
+	run  int
+}
+
+// toGlyphs returns the characters of s as glyphs of the given run.
+func toGlyphs(s string, run int) []textGlyph {
+	glyphs := make([]textGlyph, 0, len(s))
+	for _, char := range s {
+		glyphs = append(glyphs, textGlyph{char, run})
+	}
+	return glyphs
+}
+
+// glyphString returns the characters of the glyphs as a string.
+func glyphString(glyphs []textGlyph) string {
+	runes := make([]rune, len(glyphs))
+	for i, g := range glyphs {
+		runes[i] = g.char
+	}
+	return string(runes)
+}
+
+// lineWidth returns the width of a single line.
+func lineWidth(line []textGlyph, styles textStyles) (w float32) {
+	for _, g := range line {
+		w += styles.advance(g)
+	}
+	return
+}
+
+// Truncated returns the text that is drawn, which is `Text` in which every line that is wider than `MaxWidth` is
+// truncated, so that as many characters as possible fit along with an `Ellipsis`. When the Text is wrapped or drawn
+// using `Runs`, this is `Text` itself; see `Lines` instead.
+//
+// The result is cached for the Text, MaxWidth and Font, so it's only truncated again once any of them changes.
+func (t Text) Truncated() string {
+	if t.Truncate == TruncateNone || t.MaxWidth <= 0 || t.Wrap || len(t.Runs) > 0 || t.font() == nil {
+		return t.Text
+	}
+
+	key := truncation{t.Text, t.MaxWidth, t.LetterSpacing, t.Truncate, *t.font()}
+	if s, ok := truncatedCache[key]; ok {
+		return s
+	}
+	if len(truncatedCache) >= maxTruncatedCache {
+		truncatedCache = make(map[truncation]string)
+	}
+	s := t.truncate()
+	truncatedCache[key] = s
+	return s
+}
+
+// truncation is everything the result of `Text.Truncated` depends on, used to look it up in the truncatedCache.
+type truncation struct {
+	text          string
+	maxWidth      float32
+	letterSpacing float32
+	truncate      Truncation
+	font          Font
+}
+
+// maxTruncatedCache is the amount of truncated texts that are cached, after which the cache is cleared, so that
+// texts which change every frame do not keep on adding to it.
+const maxTruncatedCache = 256
+
+// truncate returns the text that is drawn, see Truncated.
+func (t Text) truncate() string {
+	styles := t.styles()
+	width := func(line []textGlyph) float32 {
+		return lineWidth(line, styles)
+	}
+
+	ellipsis := toGlyphs(Ellipsis, 0)
+	for _, g := range ellipsis {
+		if glyph, ok := styles[0].atlas.glyph(g.char); !ok || glyph.width == 0 {
+			ellipsis = toGlyphs("...", 0)
+			break
+		}
+	}
+	available := t.MaxWidth - width(ellipsis)
+
+	lines := strings.Split(t.Text, "\n")
+	for i, line := range lines {
+		glyphs := toGlyphs(line, 0)
+		if width(glyphs) <= t.MaxWidth {
 			continue
-		case char < 32: // all system stuff should be ignored
+		}
+		if available < 0 {
+			lines[i] = ""
 			continue
 		}
 
-		currentX += atlas.Width[char] + float32(t.Font.Size)*t.LetterSpacing
+		// head and tail are the amount of glyphs kept at the start and at the end of the line
+		var head, tail int
+		switch t.Truncate {
+		case TruncateEnd:
+			head = fit(glyphs, styles, available)
+		case TruncateStart:
+			tail = fitReverse(glyphs, styles, available)
+		case TruncateMiddle:
+			head = fit(glyphs, styles, available/2)
+			tail = fitReverse(glyphs[head:], styles, available-width(glyphs[:head]))
+		}
+		lines[i] = glyphString(glyphs[:head]) + glyphString(ellipsis) + glyphString(glyphs[len(glyphs)-tail:])
 	}
-	if currentX > greatestX {
-		return currentX
+	return strings.Join(lines, "\n")
+}
+
+// textLine is a single line of a Text, as it's drawn.
+type textLine struct {
+	glyphs []textGlyph
+	// run is the run the line was started by, whose height is used when the line is empty
+	run int
+	// x is the offset of the line as given by `Align`, and y is the position of its top
+	x, y          float32
+	width, height float32
+}
+
+// Lines returns the lines that are drawn, after the Text is wrapped or truncated to fit within `MaxWidth`. Every
+// newline in `Text` or `Runs` starts a new line, so empty lines are kept.
+func (t Text) Lines() []string {
+	lines := t.glyphLines(t.styles())
+	if lines == nil {
+		return nil
 	}
-	return greatestX
+
+	s := make([]string, len(lines))
+	for i, line := range lines {
+		s[i] = glyphString(line.glyphs)
+	}
+	return s
 }
 
-// Height returns the height the Text generated from a FontAtlas. This implements the common.Drawable interface.
-func (t Text) Height() float32 {
-	atlas, ok := atlasCache[*t.Font]
-	if !ok {
-		// Generate texture first
-		atlas = t.Font.generateFontAtlas(200)
-		atlasCache[*t.Font] = atlas
+// glyphLines returns the lines that are drawn, without their positions. It returns nil if nothing is drawn at all.
+func (t Text) glyphLines(styles textStyles) []textLine {
+	if styles == nil {
+		return nil
 	}
 
-	var currentY float32
-	var totalY float32
-	var tallest float32
+	var text []textGlyph
+	if len(t.Runs) == 0 {
+		text = toGlyphs(t.Truncated(), 0)
+	} else {
+		for i, run := range t.Runs {
+			text = append(text, toGlyphs(run.Text, i)...)
+		}
+	}
+	if len(text) == 0 {
+		return nil
+	}
 
-	for _, char := range t.Text {
-		// TODO: this might not work for all characters
-		switch {
-		case char == '\n':
-			totalY += tallest
-			tallest = float32(0)
-			continue
-		case char < 32: // all system stuff should be ignored
+	var lines []textLine
+	line := textLine{}
+	for _, g := range text {
+		if g.char == '\n' {
+			lines = append(lines, line)
+			line = textLine{run: g.run}
 			continue
 		}
-		currentY = atlas.Height[char] + t.LineSpacing*atlas.Height[char]
-		if currentY > tallest {
-			tallest = currentY
+		line.glyphs = append(line.glyphs, g)
+	}
+	lines = append(lines, line)
+	if !t.Wrap || t.MaxWidth <= 0 {
+		return lines
+	}
+
+	wrapped := make([]textLine, 0, len(lines))
+	for _, line := range lines {
+		wrapped = wrapLine(wrapped, line, styles, t.MaxWidth)
+	}
+	return wrapped
+}
+
+// wrapLine appends the line to lines, broken up into as many lines as needed to fit within the given width.
+func wrapLine(lines []textLine, line textLine, styles textStyles, width float32) []textLine {
+	glyphs := line.glyphs
+	for lineWidth(glyphs, styles) > width {
+		n := fit(glyphs, styles, width)
+		if n == 0 {
+			n = 1 // a single character that is too wide is put on a line by itself
+		}
+
+		// Break at the last space that fits, which may be the one just after the characters that fit
+		head, tail := glyphs[:n], glyphs[n:]
+		for i := n; i > 0; i-- {
+			if i < len(glyphs) && unicode.IsSpace(glyphs[i].char) {
+				if trimmed := trimSpaceRight(glyphs[:i]); len(trimmed) > 0 {
+					head, tail = trimmed, glyphs[i:]
+				}
+				break
+			}
+		}
+		lines = append(lines, textLine{glyphs: head, run: line.run})
+
+		glyphs = trimSpaceLeft(tail)
+		if len(glyphs) == 0 {
+			return lines // only the spaces at the break were left
+		}
+		line.run = glyphs[0].run
+	}
+	return append(lines, textLine{glyphs: glyphs, run: line.run})
+}
+
+// trimSpaceLeft returns the line without the spaces it starts with.
+func trimSpaceLeft(line []textGlyph) []textGlyph {
+	for len(line) > 0 && unicode.IsSpace(line[0].char) {
+		line = line[1:]
+	}
+	return line
+}
+
+// trimSpaceRight returns the line without the spaces it ends with.
+func trimSpaceRight(line []textGlyph) []textGlyph {
+	for len(line) > 0 && unicode.IsSpace(line[len(line)-1].char) {
+		line = line[:len(line)-1]
+	}
+	return line
+}
+
+// layout returns the lines that are drawn, positioned as given by `Align` and `LineSpacing`, along with the styles of
+// the runs they're made up of.
+func (t Text) layout() (textStyles, []textLine) {
+	styles := t.styles()
+	lines := t.glyphLines(styles)
+
+	var widest, y float32
+	for i := range lines {
+		line := &lines[i]
+		line.width = lineWidth(line.glyphs, styles)
+		widest = math.Max(widest, line.width)
+
+		line.height = styles[line.run].lineHeight
+		if len(line.glyphs) > 0 {
+			line.height = 0
+		}
+		for _, g := range line.glyphs {
+			line.height = math.Max(line.height, styles[g.run].lineHeight)
+		}
+		line.y = y
+		y += line.height + t.LineSpacing*line.height
+	}
+
+	box := widest
+	if t.MaxWidth > 0 {
+		box = t.MaxWidth
+	}
+	for i := range lines {
+		switch t.Align {
+		case AlignCenter:
+			lines[i].x = (box - lines[i].width) / 2
+		case AlignRight:
+			lines[i].x = box - lines[i].width
+		}
+	}
+	return styles, lines
+}
+
+// glyphOffset returns how far below the top of a line of the given height a glyph drawn with the given style is
+// placed, such that it shares the baseline of the highest run of the line. The baseline of a FontAtlas is at two
+// thirds of the height of its characters.
+func glyphOffset(style textStyle, lineHeight float32) float32 {
+	return (lineHeight - style.lineHeight) * 2 / 3
+}
+
+// fit returns how many glyphs from the start of the line fit within the given width.
+func fit(line []textGlyph, styles textStyles, width float32) int {
+	var w float32
+	for i, g := range line {
+		if w += styles.advance(g); w > width {
+			return i
 		}
 	}
-	return totalY + tallest
+	return len(line)
+}
+
+// fitReverse returns how many glyphs from the end of the line fit within the given width.
+func fitReverse(line []textGlyph, styles textStyles, width float32) int {
+	var w float32
+	for i := len(line) - 1; i >= 0; i-- {
+		if w += styles.advance(line[i]); w > width {
+			return len(line) - 1 - i
+		}
+	}
+	return len(line)
+}
+
+// Width returns the width of the Text generated from a FontAtlas. This implements the common.Drawable interface.
+// Lines that are aligned to the center or the right are aligned within `MaxWidth`, which is then the width of the
+// Text.
+func (t Text) Width() float32 {
+	_, lines := t.layout()
+
+	var greatestX float32
+	for _, line := range lines {
+		greatestX = math.Max(greatestX, line.x+line.width)
+	}
+	if t.Align != AlignLeft && t.MaxWidth > greatestX {
+		return t.MaxWidth
+	}
+	return greatestX
+}
+
+// Height returns the height the Text generated from a FontAtlas. This implements the common.Drawable interface.
+// It's the height of all lines, including empty ones, along with the `LineSpacing` in between them.
+func (t Text) Height() float32 {
+	_, lines := t.layout()
+	if len(lines) == 0 {
+		return 0
+	}
+	last := lines[len(lines)-1]
+	return last.y + last.height
 }
 
 // View returns 0, 0, 1, 1 because the Text is generated from a FontAtlas. This implements the common.Drawable interface.
@@ -0,0 +1,398 @@
+package common
+
+import (
+	"bytes"
+	"image/color"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/EngoEngine/engo"
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func loadTestFonts(t *testing.T) (primary, fallback *Font) {
+	regular, err := truetype.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("unable to parse goregular, error: %v", err)
+	}
+	b, err := ioutil.ReadFile(filepath.Join("testdata", "Roboto-Regular.ttf"))
+	if err != nil {
+		t.Fatalf("unable to read Roboto-Regular.ttf, error: %v", err)
+	}
+	roboto, err := truetype.Parse(b)
+	if err != nil {
+		t.Fatalf("unable to parse Roboto-Regular.ttf, error: %v", err)
+	}
+
+	fallback = &Font{Size: 32, FG: color.Black, TTF: roboto}
+	primary = &Font{Size: 32, FG: color.Black, TTF: regular, Fallback: fallback}
+	return
+}
+
+func TestFontFromBytes(t *testing.T) {
+	fnt, err := FontFromBytes(goregular.TTF, 32)
+	if err != nil {
+		t.Fatalf("unable to load font from bytes, error: %v", err)
+	}
+	if fnt.TTF == nil || fnt.Size != 32 || fnt.FG != color.Black {
+		t.Errorf("font was not created with the given size and a black color, got: %+v", fnt)
+	}
+
+	fnt, err = FontFromReader(bytes.NewReader(goregular.TTF), 16)
+	if err != nil {
+		t.Fatalf("unable to load font from a reader, error: %v", err)
+	}
+	if fnt.TTF == nil || fnt.Size != 16 {
+		t.Errorf("font was not created from the reader with the given size, got: %+v", fnt)
+	}
+
+	if _, err = FontFromBytes([]byte("not a font"), 32); err == nil {
+		t.Error("parsing an invalid font did not return an error")
+	}
+}
+
+func TestFontForRune(t *testing.T) {
+	primary, fallback := loadTestFonts(t)
+
+	// goregular has no glyph for U+01F0, which Roboto does have.
+	missing := 'ǰ'
+	if primary.HasGlyph(missing) {
+		t.Fatalf("primary font unexpectedly contains a glyph for %q", missing)
+	}
+	if fnt := primary.FontForRune(missing); fnt != fallback {
+		t.Errorf("missing rune did not fall back. Wanted: %v, got: %v", fallback, fnt)
+	}
+	if fnt := primary.FontForRune('A'); fnt != primary {
+		t.Errorf("rune present in primary font used another font. Wanted: %v, got: %v", primary, fnt)
+	}
+
+	// When no font in the chain has the rune, the primary font is used.
+	if fnt := primary.FontForRune('\uE000'); fnt != primary {
+		t.Errorf("rune missing from every font did not use the primary font. Wanted: %v, got: %v", primary, fnt)
+	}
+}
+
+func TestFontFallbackRender(t *testing.T) {
+	primary, _ := loadTestFonts(t)
+	text := string('ǰ')
+
+	withFallback, _, _ := primary.TextDimensions(text)
+	primary.Fallback = nil
+	withoutFallback, _, _ := primary.TextDimensions(text)
+	if withFallback == withoutFallback {
+		t.Errorf("fallback glyph was not used for measuring, width %v in both cases", withFallback)
+	}
+
+	primary, _ = loadTestFonts(t)
+	img := primary.RenderNRGBA("a" + text)
+	drawn := false
+	for i := 3; i < len(img.Pix); i += 4 {
+		if img.Pix[i] != 0 {
+			drawn = true
+			break
+		}
+	}
+	if !drawn {
+		t.Error("rendering text using a fallback font did not draw anything")
+	}
+}
+
+func TestFontMeasureText(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &testScene{})
+
+	fnt, _ := loadTestFonts(t)
+	for _, text := range []string{"", "Hello world !", "multiple\nlines"} {
+		txt := Text{Font: fnt, Text: text}
+		if w, h := fnt.MeasureText(text); w != txt.Width() || h != txt.Height() {
+			t.Errorf("measured size of %q did not match the Text. Wanted: %vx%v, got: %vx%v", text, txt.Width(), txt.Height(), w, h)
+		}
+	}
+
+	large := &Font{Size: 2 * fnt.Size, FG: color.Black, TTF: fnt.TTF}
+	small, _ := fnt.MeasureText("Hello")
+	if w, _ := large.MeasureText("Hello"); w <= small {
+		t.Errorf("text measured using a larger font was not wider. Wanted more than: %v, got: %v", small, w)
+	}
+}
+
+func TestTextAtlasRunes(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &testScene{})
+
+	fnt, _ := loadTestFonts(t)
+	width := func(s string) float32 { return Text{Font: fnt, Text: s}.Width() }
+
+	// Both runes are beyond the UnicodeCap, and the second one is only part of the fallback font.
+	for _, s := range []string{"é", "ǰ"} {
+		if w := width(s); w <= 0 {
+			t.Errorf("rune beyond the UnicodeCap was not added to the atlas, %q has a width of %v", s, w)
+		}
+	}
+	atlas := atlasCache[*fnt]
+	if e, _ := atlas.glyph('é'); e.width <= 0 {
+		t.Error("runes added to the atlas before were not kept when adding another one")
+	}
+	if len(atlas.Width) != UnicodeCap {
+		t.Errorf("runes beyond the UnicodeCap grew the slices of the atlas. Wanted: %d, got: %d", UnicodeCap, len(atlas.Width))
+	}
+
+	// Combining accents have a glyph without a width, which does not regenerate the atlas once it was added.
+	width("e\u0301")
+	before := atlasCache[*fnt]
+	if _, ok := before.glyph('\u0301'); !ok {
+		t.Fatal("combining accent was not added to the atlas")
+	}
+	width("e\u0301")
+	if after := atlasCache[*fnt]; after.Texture != before.Texture || len(after.extra) != len(before.extra) {
+		t.Error("atlas was generated again for a rune without a width")
+	}
+	if runes := atlasRunes[*fnt]; len(runes) != 3 {
+		t.Errorf("runes were added to the atlas more than once, got: %v", runes)
+	}
+
+	// Runes without a glyph in any of the fonts do not regenerate the atlas.
+	before = atlasCache[*fnt]
+	if w := width("\uE000"); w != 0 {
+		t.Errorf("rune without a glyph has a width of %v", w)
+	}
+	if after := atlasCache[*fnt]; len(after.extra) != len(before.extra) {
+		t.Error("atlas was generated again for a rune without a glyph")
+	}
+}
+
+func TestTextWithoutFont(t *testing.T) {
+	defer func(fnt *Font) { DefaultFont = fnt }(DefaultFont)
+	DefaultFont = nil
+
+	txt := Text{Text: "abc", MaxWidth: 10, Truncate: TruncateEnd}
+	if w, h := txt.Width(), txt.Height(); w != 0 || h != 0 {
+		t.Errorf("text without a font has a size. Wanted: 0x0, got: %vx%v", w, h)
+	}
+	if lines := txt.Lines(); lines != nil {
+		t.Errorf("text without a font has lines, got: %q", lines)
+	}
+	if s := txt.Truncated(); s != txt.Text {
+		t.Errorf("text without a font was truncated, got: %q", s)
+	}
+}
+
+func TestTextTruncated(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &testScene{})
+
+	fnt, _ := loadTestFonts(t)
+	long := "abcdefghijklmnopqrstuvwxyz"
+	maxWidth := Text{Font: fnt, Text: "abcdefghijkl"}.Width()
+
+	// The ellipsis is beyond the UnicodeCap, so it's added to the atlas of the font.
+	tests := []struct {
+		truncate Truncation
+		valid    func(s string) bool
+	}{
+		{TruncateEnd, func(s string) bool {
+			return strings.HasSuffix(s, Ellipsis) && strings.HasPrefix(long, strings.TrimSuffix(s, Ellipsis))
+		}},
+		{TruncateStart, func(s string) bool {
+			return strings.HasPrefix(s, Ellipsis) && strings.HasSuffix(long, strings.TrimPrefix(s, Ellipsis))
+		}},
+		{TruncateMiddle, func(s string) bool {
+			parts := strings.Split(s, Ellipsis)
+			return len(parts) == 2 && parts[0] != "" && parts[1] != "" &&
+				strings.HasPrefix(long, parts[0]) && strings.HasSuffix(long, parts[1])
+		}},
+	}
+
+	for _, test := range tests {
+		txt := Text{Font: fnt, Text: long, MaxWidth: maxWidth, Truncate: test.truncate}
+		actual := txt.Truncated()
+		if !test.valid(actual) {
+			t.Errorf("ellipsis was not placed correctly for truncation %v, got: %q", test.truncate, actual)
+		}
+		if w := txt.Width(); w > maxWidth {
+			t.Errorf("truncated text does not fit for truncation %v. Wanted at most: %v, got: %v", test.truncate, maxWidth, w)
+		}
+		if len(actual) < len("abcdefghi") {
+			t.Errorf("truncated text does not fit as many characters as possible for truncation %v, got: %q", test.truncate, actual)
+		}
+	}
+
+	short := Text{Font: fnt, Text: "abc\n" + long, MaxWidth: maxWidth, Truncate: TruncateEnd}
+	if lines := strings.Split(short.Truncated(), "\n"); lines[0] != "abc" || lines[1] == long {
+		t.Errorf("lines were not truncated separately, got: %q", lines)
+	}
+
+	// The result is cached, and truncated again once the MaxWidth changes.
+	txt := Text{Font: fnt, Text: long, MaxWidth: maxWidth, Truncate: TruncateEnd}
+	truncated := txt.Truncated()
+	if s, ok := truncatedCache[truncation{long, maxWidth, 0, TruncateEnd, *fnt}]; !ok || s != truncated {
+		t.Errorf("truncated text was not cached. Wanted: %q, got: %q", truncated, s)
+	}
+	txt.MaxWidth *= 2
+	if wider := txt.Truncated(); len(wider) <= len(truncated) {
+		t.Errorf("text was not truncated again for a wider MaxWidth, got: %q", wider)
+	}
+	for i := 0; i <= maxTruncatedCache; i++ {
+		txt.MaxWidth++
+		txt.Truncated()
+	}
+	if len(truncatedCache) > maxTruncatedCache {
+		t.Errorf("truncated texts kept on being cached. Wanted at most: %v, got: %v", maxTruncatedCache, len(truncatedCache))
+	}
+}
+
+func TestTextWrap(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &testScene{})
+
+	fnt, _ := loadTestFonts(t)
+	width := func(s string) float32 { return Text{Font: fnt, Text: s}.Width() }
+
+	tests := []struct {
+		name     string
+		text     string
+		maxWidth float32
+		expected []string
+	}{
+		{"words", "hello world foo", width("hello world"), []string{"hello world", "foo"}},
+		{"long words", "abcdefghij", width("abcd"), []string{"abcd", "efgh", "ij"}},
+		{"long word after a short one", "a bcdefgh", width("bcd"), []string{"a", "bcd", "efg", "h"}},
+		{"spaces at a break", "hello    world", width("hello "), []string{"hello", "world"}},
+		{"trailing spaces", "hello     ", width("hello"), []string{"hello"}},
+		{"newlines and empty lines", "ab\n\ncd", width("abcd"), []string{"ab", "", "cd"}},
+		{"too narrow for a character", "ab", 1, []string{"a", "b"}},
+	}
+	for _, test := range tests {
+		txt := Text{Font: fnt, Text: test.text, MaxWidth: test.maxWidth, Wrap: true}
+		if actual := txt.Lines(); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%s were not wrapped correctly. Wanted: %q, got: %q", test.name, test.expected, actual)
+		}
+		if w := txt.Width(); w > test.maxWidth && test.maxWidth > 1 {
+			t.Errorf("wrapped %s do not fit. Wanted at most: %v, got: %v", test.name, test.maxWidth, w)
+		}
+	}
+
+	// The height of every line, including empty ones, is used along with the spacing in between.
+	lineHeight := Text{Font: fnt}.styles()[0].lineHeight
+	txt := Text{Font: fnt, Text: "ab\n\ncd", LineSpacing: 0.5}
+	if expected := 3*lineHeight + 2*0.5*lineHeight; txt.Height() != expected {
+		t.Errorf("wrong height for text with empty lines. Wanted: %v, got: %v", expected, txt.Height())
+	}
+	if h := (Text{Font: fnt}).Height(); h != 0 {
+		t.Errorf("empty text has a height of %v", h)
+	}
+}
+
+func TestTextAlign(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &testScene{})
+
+	fnt, _ := loadTestFonts(t)
+	styles := Text{Font: fnt}.styles()
+	short, long := lineWidth(toGlyphs("ab", 0), styles), lineWidth(toGlyphs("abcd", 0), styles)
+
+	tests := []struct {
+		align    Alignment
+		maxWidth float32
+		offsets  []float32
+		width    float32
+	}{
+		{AlignLeft, 100, []float32{0, 0}, long},
+		{AlignCenter, 100, []float32{(100 - short) / 2, (100 - long) / 2}, 100},
+		{AlignRight, 100, []float32{100 - short, 100 - long}, 100},
+		// Without a MaxWidth, lines are aligned within the widest one.
+		{AlignRight, 0, []float32{long - short, 0}, long},
+	}
+	for _, test := range tests {
+		txt := Text{Font: fnt, Text: "ab\nabcd", MaxWidth: test.maxWidth, Align: test.align}
+		_, lines := txt.layout()
+		var offsets []float32
+		for _, line := range lines {
+			offsets = append(offsets, line.x)
+		}
+		if !reflect.DeepEqual(offsets, test.offsets) {
+			t.Errorf("lines were not aligned for alignment %v. Wanted: %v, got: %v", test.align, test.offsets, offsets)
+		}
+		if w := txt.Width(); w != test.width {
+			t.Errorf("wrong width for alignment %v. Wanted: %v, got: %v", test.align, test.width, w)
+		}
+	}
+}
+
+func TestTextRuns(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &testScene{})
+
+	small, _ := loadTestFonts(t)
+	large := &Font{Size: 64, FG: color.Black, TTF: small.TTF}
+	width := func(s string, fnt *Font) float32 { return Text{Font: fnt, Text: s}.Width() }
+	height := func(fnt *Font) float32 { return Text{Font: fnt}.styles()[0].lineHeight }
+
+	// Every run is wrapped using its own Font, which would have fit on a single line using the Font of the Text.
+	txt := Text{
+		Font:     small,
+		Runs:     []TextRun{{Text: "ab "}, {Text: "cd ef", Font: large}},
+		MaxWidth: width("ab ", small) + width("cd", large) - 1,
+		Wrap:     true,
+	}
+	if expected := []string{"ab", "cd", "ef"}; !reflect.DeepEqual(txt.Lines(), expected) {
+		t.Errorf("runs were not wrapped using their own font. Wanted: %q, got: %q", expected, txt.Lines())
+	}
+	if expected := height(small) + 2*height(large); txt.Height() != expected {
+		t.Errorf("lines were not as high as their runs. Wanted: %v, got: %v", expected, txt.Height())
+	}
+
+	// A line is as high as its highest run, and the other runs share its baseline.
+	txt = Text{Font: small, Runs: []TextRun{{Text: "a", Color: color.NRGBA{R: 255, A: 255}}, {Text: "b", Font: large}}}
+	if txt.Height() != height(large) {
+		t.Errorf("wrong height for a line with runs of different sizes. Wanted: %v, got: %v", height(large), txt.Height())
+	}
+	if w := width("a", small) + width("b", large); txt.Width() != w {
+		t.Errorf("wrong width for a line with runs of different sizes. Wanted: %v, got: %v", w, txt.Width())
+	}
+
+	s := &textShader{}
+	ren := &RenderComponent{Drawable: txt, Color: color.NRGBA{R: 255, G: 255, B: 255, A: 128}}
+	buffer := make([]float32, 2*20)
+	s.generateBufferContent(ren, &SpaceComponent{}, buffer)
+
+	// Quads are grouped by their texture, in the order in which they're first used.
+	a, b := buffer[:20], buffer[20:]
+	if len(s.batches) > 1 {
+		a, b = b, a
+	}
+	if expected := (height(large) - height(small)) * 2 / 3; a[1] != expected || b[1] != 0 {
+		t.Errorf("runs did not share a baseline. Wanted offsets (%v, 0), got: (%v, %v)", expected, a[1], b[1])
+	}
+	if b[0] != width("a", small) {
+		t.Errorf("run was not placed after the previous one. Wanted x: %v, got: %v", width("a", small), b[0])
+	}
+	if expected := colorToFloat32(color.NRGBA{R: 255, A: 128}); a[4] != expected {
+		t.Errorf("run was not tinted using both its own color and that of the RenderComponent. Wanted: %v, got: %v", expected, a[4])
+	}
+	if expected := colorToFloat32(ren.Color); b[4] != expected {
+		t.Errorf("run without a color was not tinted using the RenderComponent. Wanted: %v, got: %v", expected, b[4])
+	}
+
+	// The plain Text is drawn just as before whenever there are no runs.
+	plain := Text{Font: small, Text: "ab\ncd"}
+	if lines := plain.Lines(); !reflect.DeepEqual(lines, []string{"ab", "cd"}) {
+		t.Errorf("plain text was not drawn without runs, got: %q", lines)
+	}
+}
@@ -8,55 +8,114 @@ import (
 
 	"github.com/EngoEngine/ecs"
 	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/engo/math"
 
 	"golang.org/x/image/font/gofont/gomonobold"
 )
 
+// DefaultFPSSamples is the amount of frames over which the FPSSystem averages the frame time, whenever its Samples
+// is zero.
+const DefaultFPSSamples = 60
+
+// DefaultFPSGraphHeight is the height in pixels of the graph of the FPSSystem, whenever its GraphHeight is zero.
+const DefaultFPSGraphHeight = 50
+
+// fpsDisplayInterval is the amount of seconds between updates of the text displayed by the FPSSystem.
+const fpsDisplayInterval = 0.25
+
 // FPSSystem is a system for debugging that displays FPS to either the screen or
 // the terminal.
+//
+// On the screen, the FPS is shown as HUD text along with the time of the last
+// frame and the average time of the last Samples frames, optionally with a graph
+// of those frame times below it. Display can be toggled at any time; while both
+// Display and Terminal are false the system only keeps track of frame times.
 type FPSSystem struct {
 	Display, Terminal bool
-	entity            struct {
+	// Graph draws a bar for each of the last Samples frames below the text, whose
+	// height is the time the frame took. A frame at 30 FPS fills the graph, bars
+	// of frames slower than 60 FPS are yellow and those slower than 30 FPS are red.
+	Graph bool
+	// Samples is the amount of frames the frame time is averaged over, and shown
+	// in the graph. It's DefaultFPSSamples when zero.
+	Samples int
+	// Position is the position of the text on the screen
+	Position engo.Point
+	// GraphHeight is the height of the graph in pixels, it's
+	// DefaultFPSGraphHeight when zero.
+	GraphHeight float32
+	// Stats adds the RenderStats of the RenderSystem in the same world to
+	// the text, on a line of its own.
+	Stats bool
+
+	entity struct {
 		*ecs.BasicEntity
 		*RenderComponent
 		*SpaceComponent
 	}
-	elapsed, frames float32
-	fnt             *Font
+	elapsed float32
+	fnt     *Font
+	world   *ecs.World
+
+	// times contains the last frame times as a ring buffer, next is the index
+	// of the oldest one, which is replaced next
+	times   []float32
+	next    int
+	total   float32
+	display float32
+
+	render RenderComponent
+	space  SpaceComponent
+	passes []engo.RenderPass
+}
+
+// renderPasses returns the "fps" pass, which draws the graph on top of the HUD. It implements the renderPasser
+// interface.
+func (f *FPSSystem) renderPasses() []engo.RenderPass {
+	if f.passes == nil {
+		f.passes = []engo.RenderPass{{Name: "fps", Order: engo.RenderPassOverlay, Draw: f.draw}}
+	}
+	return f.passes
 }
 
 // New is called when FPSSystem is added to the world
 func (f *FPSSystem) New(w *ecs.World) {
+	f.world = w
 	if f.Display {
-		if err := engo.Files.LoadReaderData("gomonobold_fps.ttf", bytes.NewReader(gomonobold.TTF)); err != nil {
-			panic("unable to load gomonobold.ttf for the fps system! Error was: " + err.Error())
-		}
-		f.fnt = &Font{
-			URL:  "gomonobold_fps.ttf",
-			FG:   color.White,
-			BG:   color.Black,
-			Size: 32,
-		}
-		if err := f.fnt.CreatePreloaded(); err != nil {
-			panic("unable to create gomonobold.ttf for the fps system! Error was: " + err.Error())
-		}
-		txt := Text{
-			Font: f.fnt,
-			Text: "Hello world!",
-		}
-		b := ecs.NewBasic()
-		f.entity.BasicEntity = &b
-		f.entity.RenderComponent = &RenderComponent{
-			Drawable: txt,
-		}
-		f.entity.RenderComponent.SetShader(HUDShader)
-		f.entity.RenderComponent.SetZIndex(1000)
-		f.entity.SpaceComponent = &SpaceComponent{}
-		for _, system := range w.Systems() {
-			switch sys := system.(type) {
-			case *RenderSystem:
-				sys.Add(f.entity.BasicEntity, f.entity.RenderComponent, f.entity.SpaceComponent)
-			}
+		f.createEntity()
+	}
+}
+
+// createEntity creates the text displayed on the screen, and adds it to the RenderSystem.
+func (f *FPSSystem) createEntity() {
+	if err := engo.Files.LoadReaderData("gomonobold_fps.ttf", bytes.NewReader(gomonobold.TTF)); err != nil {
+		panic("unable to load gomonobold.ttf for the fps system! Error was: " + err.Error())
+	}
+	f.fnt = &Font{
+		URL:  "gomonobold_fps.ttf",
+		FG:   color.White,
+		BG:   color.Black,
+		Size: 32,
+	}
+	if err := f.fnt.CreatePreloaded(); err != nil {
+		panic("unable to create gomonobold.ttf for the fps system! Error was: " + err.Error())
+	}
+	txt := Text{
+		Font: f.fnt,
+		Text: "Hello world!",
+	}
+	b := ecs.NewBasic()
+	f.entity.BasicEntity = &b
+	f.entity.RenderComponent = &RenderComponent{
+		Drawable: txt,
+	}
+	f.entity.RenderComponent.SetShader(HUDShader)
+	f.entity.RenderComponent.SetZIndex(1000)
+	f.entity.SpaceComponent = &SpaceComponent{Position: f.Position}
+	for _, system := range f.world.Systems() {
+		switch sys := system.(type) {
+		case *RenderSystem:
+			sys.Add(f.entity.BasicEntity, f.entity.RenderComponent, f.entity.SpaceComponent)
 		}
 	}
 }
@@ -67,23 +126,153 @@ func (*FPSSystem) Add() {}
 // Remove doesn't do anything since New creates the only entity used
 func (*FPSSystem) Remove(b ecs.BasicEntity) {}
 
-// Update changes the dipslayed text and prints to the terminal every second
-// to report the FPS
+// FrameTime returns the time the last frame took, in seconds.
+func (f *FPSSystem) FrameTime() float32 {
+	if len(f.times) == 0 {
+		return 0
+	}
+	return f.times[(f.next+len(f.times)-1)%len(f.times)]
+}
+
+// AverageFrameTime returns the average time the last Samples frames took, in seconds.
+func (f *FPSSystem) AverageFrameTime() float32 {
+	if len(f.times) == 0 {
+		return 0
+	}
+	return f.total / float32(len(f.times))
+}
+
+// FPS returns the amount of frames per second, averaged over the last Samples frames.
+func (f *FPSSystem) FPS() float32 {
+	if avg := f.AverageFrameTime(); avg > 0 {
+		return 1 / avg
+	}
+	return 0
+}
+
+// sample adds the time of a frame to the frame times, replacing the oldest once there are Samples of them.
+func (f *FPSSystem) sample(dt float32) {
+	samples := f.Samples
+	if samples <= 0 {
+		samples = DefaultFPSSamples
+	}
+	if f.next != 0 && len(f.times) != samples {
+		// The window changed, so the frame times are put in order before resizing it
+		f.times = append(f.times[f.next:], f.times[:f.next]...)
+		f.next = 0
+	}
+	if len(f.times) > samples {
+		f.times = f.times[len(f.times)-samples:]
+		f.sum()
+	}
+
+	if len(f.times) < samples {
+		f.times = append(f.times, dt)
+		f.total += dt
+		return
+	}
+	f.total += dt - f.times[f.next]
+	f.times[f.next] = dt
+	if f.next = (f.next + 1) % len(f.times); f.next == 0 {
+		f.sum() // so rounding errors don't add up
+	}
+}
+
+// sum computes the total of the frame times.
+func (f *FPSSystem) sum() {
+	f.total = 0
+	for _, t := range f.times {
+		f.total += t
+	}
+}
+
+// text returns the text displayed on the screen and printed to the terminal.
+func (f *FPSSystem) text() string {
+	ms := func(t float32) string { return strconv.FormatFloat(float64(t*1000), 'f', 2, 32) }
+	text := "FPS: " + strconv.FormatFloat(float64(f.FPS()), 'f', 1, 32) +
+		" " + ms(f.FrameTime()) + "ms (avg " + ms(f.AverageFrameTime()) + "ms)"
+	if f.Stats && f.world != nil {
+		for _, system := range f.world.Systems() {
+			if rs, ok := system.(*RenderSystem); ok {
+				text += "\n" + rs.Stats().String()
+				break
+			}
+		}
+	}
+	return text
+}
+
+// Update records the time of the frame, changes the displayed text a few times
+// a second and prints to the terminal every second to report the FPS
 func (f *FPSSystem) Update(dt float32) {
-	f.elapsed += dt
-	f.frames += 1
-	text := "FPS: " + strconv.FormatFloat(float64(f.frames/f.elapsed), 'G', 5, 32)
-	if f.elapsed >= 1 {
-		if f.Display {
+	f.sample(dt)
+	if f.entity.RenderComponent != nil {
+		f.entity.Hidden = !f.Display
+	}
+	if !f.Display && !f.Terminal {
+		return
+	}
+
+	if f.Display {
+		if f.entity.BasicEntity == nil && f.world != nil {
+			f.createEntity()
+		}
+		f.entity.SpaceComponent.Position = f.Position
+		if f.display += dt; f.display >= fpsDisplayInterval {
 			f.entity.Drawable = Text{
 				Font: f.fnt,
-				Text: text,
+				Text: f.text(),
 			}
+			f.display = 0
 		}
+	}
+
+	f.elapsed += dt
+	if f.elapsed >= 1 {
 		if f.Terminal {
-			log.Println(text)
+			log.Println(f.text())
 		}
-		f.frames = 0
 		f.elapsed -= 1
 	}
 }
+
+// draw draws the graph of the frame times below the text, using the LegacyHUDShader. It's drawn by the RenderSystem
+// as a render pass, see `RenderSystem.passes`.
+func (f *FPSSystem) draw() {
+	if !f.Display || !f.Graph || f.entity.RenderComponent == nil || len(f.times) == 0 {
+		return
+	}
+
+	height := f.GraphHeight
+	if height <= 0 {
+		height = DefaultFPSGraphHeight
+	}
+	const barWidth = 2
+	top := f.Position.Y + f.entity.Drawable.Height() + 4
+
+	f.render = RenderComponent{Drawable: Rectangle{}, Scale: engo.Point{X: 1, Y: 1}, Color: color.NRGBA{A: 128}}
+	f.space = SpaceComponent{Position: engo.Point{X: f.Position.X, Y: top}, Width: float32(len(f.times)) * barWidth, Height: height}
+
+	LegacyHUDShader.Pre()
+	LegacyHUDShader.Draw(&f.render, &f.space)
+	for i := range f.times {
+		// From the oldest frame on the left to the latest on the right
+		t := f.times[(f.next+i)%len(f.times)]
+		switch {
+		case t > 1.0/30:
+			f.render.Color = color.NRGBA{R: 255, A: 255}
+		case t > 1.0/60:
+			f.render.Color = color.NRGBA{R: 255, G: 255, A: 255}
+		default:
+			f.render.Color = color.NRGBA{G: 255, A: 255}
+		}
+		h := math.Min(t*30, 1) * height
+		f.space = SpaceComponent{
+			Position: engo.Point{X: f.Position.X + float32(i)*barWidth, Y: top + height - h},
+			Width:    barWidth,
+			Height:   h,
+		}
+		LegacyHUDShader.Draw(&f.render, &f.space)
+	}
+	LegacyHUDShader.Post()
+}
@@ -0,0 +1,65 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+func TestFPSSystemSamples(t *testing.T) {
+	f := &FPSSystem{Samples: 4}
+	for _, dt := range []float32{0.5, 0.1, 0.1, 0.1, 0.1} {
+		f.Update(dt)
+	}
+	if f.FrameTime() != 0.1 {
+		t.Errorf("wrong time of the last frame. Wanted: 0.1, got: %v", f.FrameTime())
+	}
+	if avg := f.AverageFrameTime(); !tweenValuesEqual(avg, 0.1) {
+		t.Errorf("oldest frame was not left out of the average. Wanted: 0.1, got: %v", avg)
+	}
+	if fps := f.FPS(); !tweenValuesEqual(fps, 10) {
+		t.Errorf("wrong FPS. Wanted: 10, got: %v", fps)
+	}
+
+	// Shrinking the window keeps the latest frames.
+	f.Update(0.3)
+	f.Samples = 2
+	f.Update(0.5)
+	if avg := f.AverageFrameTime(); !tweenValuesEqual(avg, 0.4) {
+		t.Errorf("latest frames were not kept when the window shrunk. Wanted: 0.4, got: %v", avg)
+	}
+	if expected := "FPS: 2.5 500.00ms (avg 400.00ms)"; f.text() != expected {
+		t.Errorf("wrong text. Wanted: %q, got: %q", expected, f.text())
+	}
+}
+
+func TestFPSSystemDisplay(t *testing.T) {
+	engo.Run(engo.RunOptions{NoRun: true, HeadlessMode: true}, &testScene{})
+
+	w := &ecs.World{}
+	rs := &RenderSystem{}
+	w.AddSystem(rs)
+	f := &FPSSystem{Position: engo.Point{X: 10, Y: 20}}
+	w.AddSystem(f)
+
+	f.Update(0.5)
+	if f.entity.RenderComponent != nil {
+		t.Fatal("text was created while not displayed")
+	}
+
+	f.Display = true
+	f.Update(0.5)
+	if _, space, ok := rs.Get(f.entity.ID()); !ok || space.Position != f.Position {
+		t.Fatal("text was not added to the RenderSystem at the Position once displayed")
+	}
+	if txt := f.entity.Drawable.(Text); txt.Text != f.text() {
+		t.Errorf("displayed text was not updated, got: %q", txt.Text)
+	}
+
+	f.Display = false
+	f.Update(0.5)
+	if !f.entity.Hidden {
+		t.Error("text was not hidden once no longer displayed")
+	}
+}
@@ -0,0 +1,159 @@
+package common
+
+import (
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/engo/math"
+)
+
+// HealthComponent gives an entity hit points, which are changed using `HealthSystem.ApplyDamage` and
+// `HealthSystem.Heal`. The entity is dead once Current reaches zero.
+type HealthComponent struct {
+	// Current is the amount of hit points the entity has left, between 0 and Max
+	Current float32
+	// Max is the most hit points the entity can have
+	Max float32
+}
+
+// Dead indicates whether or not the entity has no hit points left.
+func (h *HealthComponent) Dead() bool {
+	return h.Current <= 0
+}
+
+// HealthChangedMessage is dispatched by the HealthSystem whenever the hit points of an entity changed, such as for
+// updating a health bar.
+type HealthChangedMessage struct {
+	// ID is the ID of the BasicEntity of the entity
+	ID uint64
+	// Old and New are the hit points of the entity before and after they changed
+	Old, New float32
+}
+
+// Type implements the engo.Message interface.
+func (HealthChangedMessage) Type() string {
+	return "HealthChangedMessage"
+}
+
+// EntityDiedMessage is dispatched by the HealthSystem once an entity has no hit points left. It's dispatched after
+// the HealthChangedMessage of the damage which killed it.
+type EntityDiedMessage struct {
+	// ID is the ID of the BasicEntity of the entity which died
+	ID uint64
+	// Overkill is the amount of damage beyond the hit points the entity had left
+	Overkill float32
+}
+
+// Type implements the engo.Message interface.
+func (EntityDiedMessage) Type() string {
+	return "EntityDiedMessage"
+}
+
+type healthEntity struct {
+	*ecs.BasicEntity
+	*HealthComponent
+}
+
+// HealthSystem keeps track of the hit points of entities. It doesn't draw anything; other systems, such as one
+// showing health bars, listen for the HealthChangedMessage and EntityDiedMessage it dispatches through
+// `engo.Mailbox`. Messages are dispatched while damaging or healing, so the listeners run before ApplyDamage and Heal
+// return.
+type HealthSystem struct {
+	entities []healthEntity
+	ids      entityIDs
+}
+
+// Add adds an entity to the HealthSystem. To be added, the entity has to have a basic and health component.
+func (h *HealthSystem) Add(basic *ecs.BasicEntity, health *HealthComponent) {
+	if !h.ids.add(basic.ID()) {
+		return
+	}
+	h.entities = append(h.entities, healthEntity{basic, health})
+}
+
+// AddByInterface Provides a simple way to add an entity to the system that satisfies Healthable. Any entity containing, BasicEntity and HealthComponent anonymously, automatically does this.
+func (h *HealthSystem) AddByInterface(i ecs.Identifier) {
+	o, _ := i.(Healthable)
+	h.Add(o.GetBasicEntity(), o.GetHealthComponent())
+}
+
+// Remove removes an entity from the HealthSystem.
+func (h *HealthSystem) Remove(basic ecs.BasicEntity) {
+	h.ids.remove(basic.ID())
+	delete := -1
+	for index, e := range h.entities {
+		if e.BasicEntity.ID() == basic.ID() {
+			delete = index
+			break
+		}
+	}
+	if delete >= 0 {
+		h.entities = append(h.entities[:delete], h.entities[delete+1:]...)
+	}
+}
+
+// Entities returns a copy of the IDs of all entities in the HealthSystem.
+func (h *HealthSystem) Entities() []uint64 {
+	ids := make([]uint64, len(h.entities))
+	for i, e := range h.entities {
+		ids[i] = e.ID()
+	}
+	return ids
+}
+
+// BasicEntities returns a copy of the BasicEntities of all entities in the HealthSystem.
+func (h *HealthSystem) BasicEntities() []ecs.BasicEntity {
+	basics := make([]ecs.BasicEntity, len(h.entities))
+	for i, e := range h.entities {
+		basics[i] = *e.BasicEntity
+	}
+	return basics
+}
+
+// Get returns the health component with which the entity with the given ID was added to the HealthSystem, and false
+// if it isn't in the system, such as once it was removed.
+func (h *HealthSystem) Get(id uint64) (*HealthComponent, bool) {
+	for _, e := range h.entities {
+		if e.ID() == id {
+			return e.HealthComponent, true
+		}
+	}
+	return nil, false
+}
+
+// ApplyDamage takes the given amount of hit points from the entity with the given ID, down to zero, and returns the
+// amount which was taken. Once the entity has none left, an EntityDiedMessage is dispatched, which holds the damage
+// beyond that. Entities which are already dead, or aren't in the system, take no damage, and neither does a negative
+// amount heal.
+func (h *HealthSystem) ApplyDamage(id uint64, amount float32) float32 {
+	health, ok := h.Get(id)
+	if !ok || health.Dead() || amount <= 0 {
+		return 0
+	}
+	old := health.Current
+	health.Current = math.Max(old-amount, 0)
+	engo.Mailbox.Dispatch(HealthChangedMessage{ID: id, Old: old, New: health.Current})
+	if health.Dead() {
+		engo.Mailbox.Dispatch(EntityDiedMessage{ID: id, Overkill: amount - old})
+	}
+	return old - health.Current
+}
+
+// Heal gives the given amount of hit points to the entity with the given ID, up to its Max, and returns the amount
+// which was given. Dead entities aren't healed, so they don't come back to life by accident; set Current to revive
+// them instead. Entities which aren't in the system aren't healed either, and neither does a negative amount damage.
+func (h *HealthSystem) Heal(id uint64, amount float32) float32 {
+	health, ok := h.Get(id)
+	if !ok || health.Dead() || amount <= 0 {
+		return 0
+	}
+	old := health.Current
+	health.Current = math.Min(old+amount, health.Max)
+	if health.Current == old {
+		return 0
+	}
+	engo.Mailbox.Dispatch(HealthChangedMessage{ID: id, Old: old, New: health.Current})
+	return health.Current - old
+}
+
+// Update does nothing, since hit points only change when damaging or healing. It implements the ecs.System interface.
+func (*HealthSystem) Update(float32) {}
@@ -0,0 +1,66 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+func TestHealthSystem(t *testing.T) {
+	engo.Mailbox = &engo.MessageManager{}
+	var changed []HealthChangedMessage
+	var died []EntityDiedMessage
+	engo.Mailbox.Listen("HealthChangedMessage", func(msg engo.Message) {
+		changed = append(changed, msg.(HealthChangedMessage))
+	})
+	engo.Mailbox.Listen("EntityDiedMessage", func(msg engo.Message) {
+		died = append(died, msg.(EntityDiedMessage))
+	})
+
+	basic := ecs.NewBasic()
+	health := &HealthComponent{Current: 10, Max: 20}
+	h := &HealthSystem{}
+	h.Add(&basic, health)
+
+	// Overheal only heals up to the maximum
+	if healed := h.Heal(basic.ID(), 15); healed != 10 || health.Current != 20 {
+		t.Errorf("overheal was not clamped. Wanted: 10 healed to 20, got: %v healed to %v", healed, health.Current)
+	}
+	if healed := h.Heal(basic.ID(), 5); healed != 0 || len(changed) != 1 {
+		t.Errorf("entity at full health was healed by %v", healed)
+	}
+
+	if damage := h.ApplyDamage(basic.ID(), 5); damage != 5 || health.Current != 15 || len(died) != 0 {
+		t.Errorf("wrong damage. Wanted: 5 damage to 15, got: %v damage to %v", damage, health.Current)
+	}
+
+	// Overkill only takes the hit points which were left
+	if damage := h.ApplyDamage(basic.ID(), 25); damage != 15 || health.Current != 0 || !health.Dead() {
+		t.Errorf("overkill was not clamped. Wanted: 15 damage to 0, got: %v damage to %v", damage, health.Current)
+	}
+	if len(died) != 1 || died[0].ID != basic.ID() || died[0].Overkill != 10 {
+		t.Fatalf("death was not dispatched once with an overkill of 10, got: %+v", died)
+	}
+
+	if damage := h.ApplyDamage(basic.ID(), 5); damage != 0 || len(died) != 1 {
+		t.Error("dead entity was damaged and died again")
+	}
+	if healed := h.Heal(basic.ID(), 5); healed != 0 || health.Current != 0 {
+		t.Error("dead entity was healed")
+	}
+
+	expected := []HealthChangedMessage{
+		{ID: basic.ID(), Old: 10, New: 20},
+		{ID: basic.ID(), Old: 20, New: 15},
+		{ID: basic.ID(), Old: 15, New: 0},
+	}
+	if len(changed) != len(expected) {
+		t.Fatalf("wrong number of changes. Wanted: %v, got: %+v", len(expected), changed)
+	}
+	for i, msg := range expected {
+		if changed[i] != msg {
+			t.Errorf("wrong change %v. Wanted: %+v, got: %+v", i, msg, changed[i])
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package common
+
+import (
+	"log"
+	"time"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+// HotReloadSystem reloads images whenever their file changes, so they can be edited while the game is running. Since
+// the texture of a reloaded image is replaced in place on the GPU, every RenderComponent drawing it shows the new
+// image the next frame.
+//
+// Nothing is watched unless the system is added to the world, though once it is, the modification time of every
+// loaded image is checked each Interval. It's meant for development only, so it's best added behind a command line
+// flag. Only images loaded from directories can be watched, rather than those loaded from URLs or on mobile.
+//
+// Whenever the size of an image changes, the entities added to the system which draw the entire image are updated to
+// the new size, along with the Width and Height of their SpaceComponent if ResizeSpace is set. Spritesheets and other
+// regions of the image are not updated, so their regions should be recreated if needed.
+type HotReloadSystem struct {
+	// Interval is the time in seconds between checking whether images have changed. It's one second when zero.
+	Interval float32
+	// ResizeSpace scales the SpaceComponents of entities drawing an image which was reloaded at another size, such
+	// that they keep drawing it at the same scale.
+	ResizeSpace bool
+
+	entities map[uint64]hotReloadEntity
+	modTimes map[string]time.Time
+	elapsed  float32
+}
+
+type hotReloadEntity struct {
+	*RenderComponent
+	*SpaceComponent
+}
+
+// Add starts tracking the given entity, so it's updated whenever the image it draws is reloaded at another size.
+func (h *HotReloadSystem) Add(basic *ecs.BasicEntity, render *RenderComponent, space *SpaceComponent) {
+	if h.entities == nil {
+		h.entities = make(map[uint64]hotReloadEntity)
+	}
+	h.entities[basic.ID()] = hotReloadEntity{render, space}
+}
+
+// AddByInterface allows you to add any Renderable to the HotReloadSystem.
+func (h *HotReloadSystem) AddByInterface(i ecs.Identifier) {
+	o, _ := i.(Renderable)
+	h.Add(o.GetBasicEntity(), o.GetRenderComponent(), o.GetSpaceComponent())
+}
+
+// Remove stops tracking the given entity.
+func (h *HotReloadSystem) Remove(basic ecs.BasicEntity) {
+	delete(h.entities, basic.ID())
+}
+
+// Update reloads the images which have changed since they were last checked, once every Interval.
+func (h *HotReloadSystem) Update(dt float32) {
+	interval := h.Interval
+	if interval <= 0 {
+		interval = 1
+	}
+	if h.elapsed += dt; h.elapsed < interval {
+		return
+	}
+	h.elapsed = 0
+
+	if h.modTimes == nil {
+		h.modTimes = make(map[string]time.Time)
+	}
+	for _, loader := range imageLoaders {
+		for url, old := range loader.images {
+			modified, err := engo.Files.ModTime(url)
+			if err != nil {
+				continue // not loaded from a directory
+			}
+			last, ok := h.modTimes[url]
+			h.modTimes[url] = modified
+			if !ok || !modified.After(last) {
+				continue
+			}
+
+			if err = engo.Files.Reload(url); err != nil {
+				log.Println("[WARNING] [HotReloadSystem]: Unable to reload", url, "error:", err)
+				continue
+			}
+			if res := loader.images[url]; res.Width != old.Width || res.Height != old.Height {
+				h.resize(old, res)
+			}
+		}
+	}
+}
+
+// resize updates the entities drawing the entire image, which was reloaded at another size.
+func (h *HotReloadSystem) resize(old, res TextureResource) {
+	full := engo.AABB{Max: engo.Point{X: old.Width, Y: old.Height}}
+	tex := Texture{
+		id:       res.Texture,
+		width:    res.Width,
+		height:   res.Height,
+		viewport: engo.AABB{Max: engo.Point{X: 1.0, Y: 1.0}},
+		region:   engo.AABB{Max: engo.Point{X: res.Width, Y: res.Height}},
+		pixels:   res.pixels,
+		url:      res.url,
+	}
+
+	for _, e := range h.entities {
+		switch d := e.Drawable.(type) {
+		case Texture:
+			if d.id != old.Texture || d.region != full {
+				continue
+			}
+			e.Drawable = tex
+		case *Texture:
+			if d.id != old.Texture || d.region != full {
+				continue
+			}
+			*d = tex
+		default:
+			continue
+		}
+
+		if h.ResizeSpace && old.Width > 0 && old.Height > 0 {
+			e.SpaceComponent.Width *= res.Width / old.Width
+			e.SpaceComponent.Height *= res.Height / old.Height
+		}
+	}
+}
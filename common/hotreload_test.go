@@ -0,0 +1,84 @@
+package common
+
+import (
+	"image"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+func writeTestImage(t *testing.T, fn string, w, h int) {
+	f, err := os.Create(fn)
+	if err != nil {
+		t.Fatalf("Unable to create test image. Error was: %v", err)
+	}
+	defer f.Close()
+	if err = png.Encode(f, image.NewNRGBA(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatalf("Unable to encode png from image. Error was: %v", err)
+	}
+}
+
+func TestHotReloadSystem(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &textureTestScene{})
+
+	dir, err := ioutil.TempDir("", "hotreload")
+	if err != nil {
+		t.Fatalf("Unable to create temp directory. Error was: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	root := engo.Files.GetRoot()
+	engo.Files.SetRoot(dir)
+	defer engo.Files.SetRoot(root)
+
+	fn := filepath.Join(dir, "reload.png")
+	writeTestImage(t, fn, 8, 8)
+	if err = engo.Files.Load("reload.png"); err != nil {
+		t.Fatalf("Unable to load test image. Error was: %v", err)
+	}
+	defer engo.Files.Unload("reload.png")
+
+	sprite, err := LoadedSprite("reload.png")
+	if err != nil {
+		t.Fatalf("Unable to retrieve test image. Error was: %v", err)
+	}
+	basic := ecs.NewBasic()
+	ren := &RenderComponent{Drawable: *sprite}
+	space := &SpaceComponent{Width: 16, Height: 16}
+
+	h := &HotReloadSystem{ResizeSpace: true}
+	h.Add(&basic, ren, space)
+	h.Update(0.5)
+	h.Update(0.5) // the first check only records the modification times
+
+	writeTestImage(t, fn, 16, 8)
+	later := time.Now().Add(time.Minute)
+	if err = os.Chtimes(fn, later, later); err != nil {
+		t.Fatalf("Unable to change the modification time. Error was: %v", err)
+	}
+
+	h.Update(0.5)
+	if ren.Drawable.Width() != 8 {
+		t.Errorf("image was reloaded before the Interval passed")
+	}
+	h.Update(0.5)
+	if w, hh := ren.Drawable.Width(), ren.Drawable.Height(); w != 16 || hh != 8 {
+		t.Errorf("drawable was not updated to the new size. Wanted: 16x8, got: %vx%v", w, hh)
+	}
+	if space.Width != 32 || space.Height != 16 {
+		t.Errorf("SpaceComponent was not resized along with the image. Wanted: 32x16, got: %vx%v", space.Width, space.Height)
+	}
+
+	res, _ := engo.Files.Resource("reload.png")
+	if tr := res.(TextureResource); tr.Width != 16 || tr.Texture != sprite.Texture() {
+		t.Errorf("image was not reloaded in place, got width %v", tr.Width)
+	}
+}
@@ -64,6 +64,81 @@ func (c *CollisionComponent) GetCollisionComponent() *CollisionComponent {
 	return c
 }
 
+// GetPhysicsComponent Provides container classes ability to fulfil the interface and be accessed more simply by systems, eg in AddByInterface Methods
+func (c *PhysicsComponent) GetPhysicsComponent() *PhysicsComponent {
+	return c
+}
+
+// GetParallaxComponent Provides container classes ability to fulfil the interface and be accessed more simply by systems, eg in AddByInterface Methods
+func (c *ParallaxComponent) GetParallaxComponent() *ParallaxComponent {
+	return c
+}
+
+// GetVelocityComponent Provides container classes ability to fulfil the interface and be accessed more simply by systems, eg in AddByInterface Methods
+func (c *VelocityComponent) GetVelocityComponent() *VelocityComponent {
+	return c
+}
+
+// GetStateComponent Provides container classes ability to fulfil the interface and be accessed more simply by systems, eg in AddByInterface Methods
+func (c *StateComponent) GetStateComponent() *StateComponent {
+	return c
+}
+
+// GetHealthComponent Provides container classes ability to fulfil the interface and be accessed more simply by systems, eg in AddByInterface Methods
+func (c *HealthComponent) GetHealthComponent() *HealthComponent {
+	return c
+}
+
+// GetCooldownComponent Provides container classes ability to fulfil the interface and be accessed more simply by systems, eg in AddByInterface Methods
+func (c *CooldownComponent) GetCooldownComponent() *CooldownComponent {
+	return c
+}
+
+// GetShapeComponent Provides container classes ability to fulfil the interface and be accessed more simply by systems, eg in AddByInterface Methods
+func (c *ShapeComponent) GetShapeComponent() *ShapeComponent {
+	return c
+}
+
+// GetProgressBarComponent Provides container classes ability to fulfil the interface and be accessed more simply by systems, eg in AddByInterface Methods
+func (c *ProgressBarComponent) GetProgressBarComponent() *ProgressBarComponent {
+	return c
+}
+
+// GetTextInputComponent Provides container classes ability to fulfil the interface and be accessed more simply by systems, eg in AddByInterface Methods
+func (c *TextInputComponent) GetTextInputComponent() *TextInputComponent {
+	return c
+}
+
+// GetAnchorComponent Provides container classes ability to fulfil the interface and be accessed more simply by systems, eg in AddByInterface Methods
+func (c *AnchorComponent) GetAnchorComponent() *AnchorComponent {
+	return c
+}
+
+// GetParticleComponent Provides container classes ability to fulfil the interface and be accessed more simply by systems, eg in AddByInterface Methods
+func (c *ParticleComponent) GetParticleComponent() *ParticleComponent {
+	return c
+}
+
+// GetTransformComponent Provides container classes ability to fulfil the interface and be accessed more simply by systems, eg in AddByInterface Methods
+func (c *TransformComponent) GetTransformComponent() *TransformComponent {
+	return c
+}
+
+// GetTooltipComponent Provides container classes ability to fulfil the interface and be accessed more simply by systems, eg in AddByInterface Methods
+func (c *TooltipComponent) GetTooltipComponent() *TooltipComponent {
+	return c
+}
+
+// GetDragDropComponent Provides container classes ability to fulfil the interface and be accessed more simply by systems, eg in AddByInterface Methods
+func (c *DragDropComponent) GetDragDropComponent() *DragDropComponent {
+	return c
+}
+
+// GetSelectionComponent Provides container classes ability to fulfil the interface and be accessed more simply by systems, eg in AddByInterface Methods
+func (c *SelectionComponent) GetSelectionComponent() *SelectionComponent {
+	return c
+}
+
 // Faces
 
 // BasicFace is the means of accessing the ecs.BasicEntity class , it also has the ID method, to simplify, finding an item within a system
@@ -102,6 +177,81 @@ type CollisionFace interface {
 	GetCollisionComponent() *CollisionComponent
 }
 
+// PhysicsFace allows typesafe access to an anonymous PhysicsComponent
+type PhysicsFace interface {
+	GetPhysicsComponent() *PhysicsComponent
+}
+
+// ParallaxFace allows typesafe access to an anonymous ParallaxComponent
+type ParallaxFace interface {
+	GetParallaxComponent() *ParallaxComponent
+}
+
+// VelocityFace allows typesafe access to an anonymous VelocityComponent
+type VelocityFace interface {
+	GetVelocityComponent() *VelocityComponent
+}
+
+// StateFace allows typesafe access to an anonymous StateComponent
+type StateFace interface {
+	GetStateComponent() *StateComponent
+}
+
+// HealthFace allows typesafe access to an anonymous HealthComponent
+type HealthFace interface {
+	GetHealthComponent() *HealthComponent
+}
+
+// CooldownFace allows typesafe access to an anonymous CooldownComponent
+type CooldownFace interface {
+	GetCooldownComponent() *CooldownComponent
+}
+
+// ShapeFace allows typesafe access to an anonymous ShapeComponent
+type ShapeFace interface {
+	GetShapeComponent() *ShapeComponent
+}
+
+// ProgressBarFace allows typesafe access to an anonymous ProgressBarComponent
+type ProgressBarFace interface {
+	GetProgressBarComponent() *ProgressBarComponent
+}
+
+// TextInputFace allows typesafe access to an anonymous TextInputComponent
+type TextInputFace interface {
+	GetTextInputComponent() *TextInputComponent
+}
+
+// AnchorFace allows typesafe access to an anonymous AnchorComponent
+type AnchorFace interface {
+	GetAnchorComponent() *AnchorComponent
+}
+
+// ParticleFace allows typesafe access to an anonymous ParticleComponent
+type ParticleFace interface {
+	GetParticleComponent() *ParticleComponent
+}
+
+// TransformFace allows typesafe access to an anonymous TransformComponent
+type TransformFace interface {
+	GetTransformComponent() *TransformComponent
+}
+
+// TooltipFace allows typesafe access to an anonymous TooltipComponent
+type TooltipFace interface {
+	GetTooltipComponent() *TooltipComponent
+}
+
+// DragDropFace allows typesafe access to an anonymous DragDropComponent
+type DragDropFace interface {
+	GetDragDropComponent() *DragDropComponent
+}
+
+// SelectionFace allows typesafe access to an anonymous SelectionComponent
+type SelectionFace interface {
+	GetSelectionComponent() *SelectionComponent
+}
+
 // Combined for systems
 
 // Animationable is the required interface for AnimationSystem.AddByInterface method
@@ -139,6 +289,109 @@ type Collisionable interface {
 	SpaceFace
 }
 
+// Physicsable is the required interface for the PhysicsSystem.AddByInterface method
+type Physicsable interface {
+	BasicFace
+	PhysicsFace
+	VelocityFace
+}
+
+// Movable is the required interface for the MovementSystem.AddByInterface method
+type Movable interface {
+	BasicFace
+	VelocityFace
+	SpaceFace
+}
+
+// Stateful is the required interface for the StateSystem.AddByInterface method
+type Stateful interface {
+	BasicFace
+	StateFace
+}
+
+// Healthable is the required interface for the HealthSystem.AddByInterface method
+type Healthable interface {
+	BasicFace
+	HealthFace
+}
+
+// Cooldownable is the required interface for the CooldownSystem.AddByInterface method
+type Cooldownable interface {
+	BasicFace
+	CooldownFace
+}
+
+// Shapeable is the required interface for the ShapeSystem.AddByInterface method
+type Shapeable interface {
+	BasicFace
+	ShapeFace
+}
+
+// ProgressBarable is the required interface for the ProgressBarSystem.AddByInterface method
+type ProgressBarable interface {
+	BasicFace
+	ProgressBarFace
+	SpaceFace
+}
+
+// TextInputable is the required interface for the TextInputSystem.AddByInterface method
+type TextInputable interface {
+	BasicFace
+	MouseFace
+	SpaceFace
+	RenderFace
+	TextInputFace
+}
+
+// Anchorable is the required interface for the AnchorSystem.AddByInterface method
+type Anchorable interface {
+	BasicFace
+	SpaceFace
+	AnchorFace
+}
+
+// Particleable is the required interface for the ParticleSystem.AddByInterface method
+type Particleable interface {
+	BasicFace
+	ParticleFace
+	SpaceFace
+}
+
+// Debuggable is the required interface for the DebugSystem.AddByInterface method
+type Debuggable interface {
+	BasicFace
+	SpaceFace
+}
+
+// Transformable is the required interface for the TransformSystem.AddByInterface method
+type Transformable interface {
+	BasicFace
+	SpaceFace
+	TransformFace
+}
+
+// Tooltipable is the required interface for the TooltipSystem.AddByInterface method
+type Tooltipable interface {
+	BasicFace
+	MouseFace
+	TooltipFace
+}
+
+// DragDroppable is the required interface for the DragDropSystem.AddByInterface method
+type DragDroppable interface {
+	BasicFace
+	MouseFace
+	SpaceFace
+	DragDropFace
+}
+
+// Selectable is the required interface for the SelectionSystem.AddByInterface method
+type Selectable interface {
+	BasicFace
+	SpaceFace
+	SelectionFace
+}
+
 // Not-Ables
 
 // NotAnimationComponent is used to flag an entity as not in the AnimationSystem
@@ -215,3 +468,228 @@ func (n *NotCollisionComponent) GetNotCollisionComponent() *NotCollisionComponen
 type NotCollisionable interface {
 	GetNotCollisionComponent() *NotCollisionComponent
 }
+
+// NotPhysicsComponent is used to flag an entity as not in the PhysicsSystem
+// even if it has the proper components
+type NotPhysicsComponent struct{}
+
+// GetNotPhysicsComponent implements the NotPhysicsable interface
+func (n *NotPhysicsComponent) GetNotPhysicsComponent() *NotPhysicsComponent {
+	return n
+}
+
+// NotPhysicsable is an interface used to flag an entity as not in the
+// PhysicsSystem even if it has the proper components
+type NotPhysicsable interface {
+	GetNotPhysicsComponent() *NotPhysicsComponent
+}
+
+// NotMovementComponent is used to flag an entity as not in the MovementSystem
+// even if it has the proper components
+type NotMovementComponent struct{}
+
+// GetNotMovementComponent implements the NotMovable interface
+func (n *NotMovementComponent) GetNotMovementComponent() *NotMovementComponent {
+	return n
+}
+
+// NotMovable is an interface used to flag an entity as not in the
+// MovementSystem even if it has the proper components
+type NotMovable interface {
+	GetNotMovementComponent() *NotMovementComponent
+}
+
+// NotStateComponent is used to flag an entity as not in the StateSystem
+// even if it has the proper components
+type NotStateComponent struct{}
+
+// GetNotStateComponent implements the NotStateful interface
+func (n *NotStateComponent) GetNotStateComponent() *NotStateComponent {
+	return n
+}
+
+// NotStateful is an interface used to flag an entity as not in the
+// StateSystem even if it has the proper components
+type NotStateful interface {
+	GetNotStateComponent() *NotStateComponent
+}
+
+// NotHealthComponent is used to flag an entity as not in the HealthSystem
+// even if it has the proper components
+type NotHealthComponent struct{}
+
+// GetNotHealthComponent implements the NotHealthable interface
+func (n *NotHealthComponent) GetNotHealthComponent() *NotHealthComponent {
+	return n
+}
+
+// NotHealthable is an interface used to flag an entity as not in the
+// HealthSystem even if it has the proper components
+type NotHealthable interface {
+	GetNotHealthComponent() *NotHealthComponent
+}
+
+// NotCooldownComponent is used to flag an entity as not in the CooldownSystem
+// even if it has the proper components
+type NotCooldownComponent struct{}
+
+// GetNotCooldownComponent implements the NotCooldownable interface
+func (n *NotCooldownComponent) GetNotCooldownComponent() *NotCooldownComponent {
+	return n
+}
+
+// NotCooldownable is an interface used to flag an entity as not in the
+// CooldownSystem even if it has the proper components
+type NotCooldownable interface {
+	GetNotCooldownComponent() *NotCooldownComponent
+}
+
+// NotShapeComponent is used to flag an entity as not in the ShapeSystem
+// even if it has the proper components
+type NotShapeComponent struct{}
+
+// GetNotShapeComponent implements the NotShapeable interface
+func (n *NotShapeComponent) GetNotShapeComponent() *NotShapeComponent {
+	return n
+}
+
+// NotShapeable is an interface used to flag an entity as not in the
+// ShapeSystem even if it has the proper components
+type NotShapeable interface {
+	GetNotShapeComponent() *NotShapeComponent
+}
+
+// NotProgressBarComponent is used to flag an entity as not in the ProgressBarSystem
+// even if it has the proper components
+type NotProgressBarComponent struct{}
+
+// GetNotProgressBarComponent implements the NotProgressBarable interface
+func (n *NotProgressBarComponent) GetNotProgressBarComponent() *NotProgressBarComponent {
+	return n
+}
+
+// NotProgressBarable is an interface used to flag an entity as not in the
+// ProgressBarSystem even if it has the proper components
+type NotProgressBarable interface {
+	GetNotProgressBarComponent() *NotProgressBarComponent
+}
+
+// NotTextInputComponent is used to flag an entity as not in the TextInputSystem
+// even if it has the proper components
+type NotTextInputComponent struct{}
+
+// GetNotTextInputComponent implements the NotTextInputable interface
+func (n *NotTextInputComponent) GetNotTextInputComponent() *NotTextInputComponent {
+	return n
+}
+
+// NotTextInputable is an interface used to flag an entity as not in the
+// TextInputSystem even if it has the proper components
+type NotTextInputable interface {
+	GetNotTextInputComponent() *NotTextInputComponent
+}
+
+// NotAnchorComponent is used to flag an entity as not in the AnchorSystem
+// even if it has the proper components
+type NotAnchorComponent struct{}
+
+// GetNotAnchorComponent implements the NotAnchorable interface
+func (n *NotAnchorComponent) GetNotAnchorComponent() *NotAnchorComponent {
+	return n
+}
+
+// NotAnchorable is an interface used to flag an entity as not in the
+// AnchorSystem even if it has the proper components
+type NotAnchorable interface {
+	GetNotAnchorComponent() *NotAnchorComponent
+}
+
+// NotParticleComponent is used to flag an entity as not in the ParticleSystem
+// even if it has the proper components
+type NotParticleComponent struct{}
+
+// GetNotParticleComponent implements the NotParticleable interface
+func (n *NotParticleComponent) GetNotParticleComponent() *NotParticleComponent {
+	return n
+}
+
+// NotParticleable is an interface used to flag an entity as not in the
+// ParticleSystem even if it has the proper components
+type NotParticleable interface {
+	GetNotParticleComponent() *NotParticleComponent
+}
+
+// NotDebugComponent is used to flag an entity as not in the DebugSystem
+// even if it has the proper components
+type NotDebugComponent struct{}
+
+// GetNotDebugComponent implements the NotDebuggable interface
+func (n *NotDebugComponent) GetNotDebugComponent() *NotDebugComponent {
+	return n
+}
+
+// NotDebuggable is an interface used to flag an entity as not in the
+// DebugSystem even if it has the proper components
+type NotDebuggable interface {
+	GetNotDebugComponent() *NotDebugComponent
+}
+
+// NotTransformComponent is used to flag an entity as not in the TransformSystem
+// even if it has the proper components
+type NotTransformComponent struct{}
+
+// GetNotTransformComponent implements the NotTransformable interface
+func (n *NotTransformComponent) GetNotTransformComponent() *NotTransformComponent {
+	return n
+}
+
+// NotTransformable is an interface used to flag an entity as not in the
+// TransformSystem even if it has the proper components
+type NotTransformable interface {
+	GetNotTransformComponent() *NotTransformComponent
+}
+
+// NotTooltipComponent is used to flag an entity as not in the TooltipSystem
+// even if it has the proper components
+type NotTooltipComponent struct{}
+
+// GetNotTooltipComponent implements the NotTooltipable interface
+func (n *NotTooltipComponent) GetNotTooltipComponent() *NotTooltipComponent {
+	return n
+}
+
+// NotTooltipable is an interface used to flag an entity as not in the
+// TooltipSystem even if it has the proper components
+type NotTooltipable interface {
+	GetNotTooltipComponent() *NotTooltipComponent
+}
+
+// NotDragDropComponent is used to flag an entity as not in the DragDropSystem
+// even if it has the proper components
+type NotDragDropComponent struct{}
+
+// GetNotDragDropComponent implements the NotDragDroppable interface
+func (n *NotDragDropComponent) GetNotDragDropComponent() *NotDragDropComponent {
+	return n
+}
+
+// NotDragDroppable is an interface used to flag an entity as not in the
+// DragDropSystem even if it has the proper components
+type NotDragDroppable interface {
+	GetNotDragDropComponent() *NotDragDropComponent
+}
+
+// NotSelectionComponent is used to flag an entity as not in the SelectionSystem
+// even if it has the proper components
+type NotSelectionComponent struct{}
+
+// GetNotSelectionComponent implements the NotSelectable interface
+func (n *NotSelectionComponent) GetNotSelectionComponent() *NotSelectionComponent {
+	return n
+}
+
+// NotSelectable is an interface used to flag an entity as not in the
+// SelectionSystem even if it has the proper components
+type NotSelectable interface {
+	GetNotSelectionComponent() *NotSelectionComponent
+}
@@ -15,6 +15,8 @@ type EveryComp struct {
 	SpaceComponent
 	CollisionComponent
 	AudioComponent
+	PhysicsComponent
+	VelocityComponent
 }
 
 type TestInterfaceScene struct {
@@ -54,6 +56,11 @@ func (s *TestInterfaceScene) Setup(u engo.Updater) {
 	var notaud *NotAudioable
 	w.AddSystemInterface(&audsys, aud, notaud)
 
+	psys := PhysicsSystem{}
+	var p *Physicsable
+	var notp *NotPhysicsable
+	w.AddSystemInterface(&psys, p, notp)
+
 	e := &EveryComp{BasicEntity: ecs.NewBasic()}
 	w.AddEntity(e)
 
@@ -98,6 +105,11 @@ func (s *TestInterfaceScene) Setup(u engo.Updater) {
 		s.reason = "did not add entity to collision system"
 		return
 	}
+	if csys.entities[0].physics != &e.PhysicsComponent || csys.entities[0].velocity != &e.VelocityComponent {
+		s.failed = true
+		s.reason = "did not add physics component to collision system"
+		return
+	}
 	csys.Remove(e.BasicEntity)
 	if len(csys.entities) != 0 {
 		s.failed = true
@@ -116,6 +128,18 @@ func (s *TestInterfaceScene) Setup(u engo.Updater) {
 		s.reason = "did not remove entry from audio system"
 		return
 	}
+
+	if len(psys.entities) != 1 {
+		s.failed = true
+		s.reason = "did not add entity to physics system"
+		return
+	}
+	psys.Remove(e.BasicEntity)
+	if len(psys.entities) != 0 {
+		s.failed = true
+		s.reason = "did not remove entry from physics system"
+		return
+	}
 }
 
 // TestEveryInterface Creates an Everything component and tries to add and then remove it from each system to each system using AddByInterface.
@@ -1,6 +1,9 @@
 package common
 
 import (
+	"image/color"
+
+	"github.com/EngoEngine/ecs"
 	"github.com/EngoEngine/engo"
 	"github.com/EngoEngine/engo/math"
 	"github.com/EngoEngine/gl"
@@ -11,7 +14,9 @@ const (
 	iso  = "isometric"
 )
 
-// Level is a parsed TMX level containing all layers and default Tiled attributes
+// Level is a parsed TMX level containing all layers and default Tiled attributes. Both orthogonal and isometric
+// levels are supported for tiles, though the objects of isometric levels are not: their coordinates are left as
+// given by Tiled, which does not match where they're drawn.
 type Level struct {
 	// Orientation is the parsed level orientation from the TMX XML, like orthogonal, isometric, etc.
 	Orientation string
@@ -35,6 +40,7 @@ type Level struct {
 	Properties  []Property
 	resourceMap map[uint32]Texture
 	pointMap    map[mapPoint]*Tile
+	propertyMap map[uint32][]Property
 }
 
 // Property is any custom property. The Type corresponds to the type (int,
@@ -129,6 +135,8 @@ type Object struct {
 	Width float32
 	// Height is the height of the object in pixels
 	Height float32
+	// Rotation is the rotation of the object in degrees, clockwise around (X, Y)
+	Rotation float32
 	// Properties are the custom properties of the object
 	Properties []Property
 	// Tiles are the tiles, if any, associated with the object
@@ -139,6 +147,22 @@ type Object struct {
 	Ellipses []TMXCircle
 	// Text is the text, if any, associated with the object
 	Text []TMXText
+
+	// gid is the tile drawn by the object, which is zero if it doesn't have one
+	gid uint32
+}
+
+// SpaceComponent returns the rectangle of the object as a SpaceComponent, such as for the spawn points and collision
+// zones of a level. Tiled places tile objects using their bottom left corner, which is converted to their upper left
+// one. The offset of the ObjectLayer is not included.
+func (o *Object) SpaceComponent() SpaceComponent {
+	position := engo.Point{X: o.X, Y: o.Y}
+	if o.gid != 0 {
+		sin, cos := math.Sincos(o.Rotation * math.Pi / 180)
+		position.X += o.Height * sin
+		position.Y -= o.Height * cos
+	}
+	return SpaceComponent{Position: position, Width: o.Width, Height: o.Height, Rotation: o.Rotation}
 }
 
 // TMXCircle is a circle from the tmx map
@@ -278,4 +302,71 @@ func (t *Tile) View() (float32, float32, float32, float32) {
 type Tile struct {
 	engo.Point
 	Image *Texture
+	// Properties are the custom properties given to the tile in its tileset
+	Properties []Property
+}
+
+// TileEntity is an entity drawing a single Tile of a Level, as created by TileEntities.
+type TileEntity struct {
+	ecs.BasicEntity
+	RenderComponent
+	SpaceComponent
+	// Tile is the Tile which is drawn, including its Properties
+	Tile *Tile
+}
+
+// TileEntities returns an entity for every tile of the visible tile layers and image layers of the level, positioned
+// within the world as they are in the level. Every tile layer is drawn on top of the previous one, using its index
+// within TileLayers as the z-index, while image layers are drawn behind all of them. Empty tiles are left out.
+func (l *Level) TileEntities() []*TileEntity {
+	var entities []*TileEntity
+	add := func(tile *Tile, offset engo.Point, opacity, zIndex float32) {
+		if tile.Image == nil || tile.Image.Width() == 0 {
+			return
+		}
+		e := &TileEntity{BasicEntity: ecs.NewBasic(), Tile: tile}
+		e.RenderComponent = RenderComponent{
+			Drawable: tile,
+			Scale:    engo.Point{X: 1, Y: 1},
+			Color:    color.NRGBA{R: 255, G: 255, B: 255, A: uint8(255 * math.Clamp(opacity, 0, 1))},
+		}
+		e.RenderComponent.SetZIndex(zIndex)
+		e.SpaceComponent = SpaceComponent{
+			Position: engo.Point{X: tile.X + offset.X, Y: tile.Y + offset.Y},
+			Width:    tile.Width(),
+			Height:   tile.Height(),
+		}
+		entities = append(entities, e)
+	}
+
+	for _, layer := range l.ImageLayers {
+		if !layer.Visible {
+			continue
+		}
+		for _, image := range layer.Images {
+			add(image, engo.Point{}, layer.Opacity, -1)
+		}
+	}
+	for i, layer := range l.TileLayers {
+		if !layer.Visible {
+			continue
+		}
+		for _, tile := range layer.Tiles {
+			add(tile, engo.Point{X: layer.OffSetX, Y: layer.OffSetY}, layer.Opacity, float32(i))
+		}
+	}
+	return entities
+}
+
+// AddTileEntities creates the entities of TileEntities, and adds them to every RenderSystem of the world.
+func (l *Level) AddTileEntities(w *ecs.World) []*TileEntity {
+	entities := l.TileEntities()
+	for _, system := range w.Systems() {
+		if sys, ok := system.(*RenderSystem); ok {
+			for _, e := range entities {
+				sys.Add(&e.BasicEntity, &e.RenderComponent, &e.SpaceComponent)
+			}
+		}
+	}
+	return entities
 }
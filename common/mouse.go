@@ -2,6 +2,7 @@ package common
 
 import (
 	"log"
+	"sort"
 
 	"github.com/EngoEngine/ecs"
 	"github.com/EngoEngine/engo"
@@ -28,9 +29,18 @@ const (
 	CursorVResize
 )
 
-// MouseSystemPriority is the priority of the MouseSystem
+// MouseSystemPriority is the priority of the MouseSystem, unless it has an UpdatePriority set
 const MouseSystemPriority = 100
 
+const (
+	// DefaultDoubleClickInterval is the DoubleClickInterval of a MouseSystem which has none set, in seconds.
+	DefaultDoubleClickInterval = 0.3
+	// DefaultDoubleClickMaxDistance is the DoubleClickMaxDistance of a MouseSystem which has none set, in game units.
+	DefaultDoubleClickMaxDistance = 4
+	// DefaultDragThreshold is the DragThreshold of a MouseSystem which has none set, in game units.
+	DefaultDragThreshold = 3
+)
+
 // Mouse is the representation of the physical mouse
 type Mouse struct {
 	// X is the current x position of the mouse in the game
@@ -49,6 +59,23 @@ type Mouse struct {
 	Modifer engo.Modifier
 }
 
+// MouseButtonMask is a set of mouse buttons, in which each button is represented by the bit `1 << button`.
+type MouseButtonMask uint8
+
+const (
+	// MouseMaskLeft contains the left mouse button
+	MouseMaskLeft MouseButtonMask = 1 << engo.MouseButtonLeft
+	// MouseMaskRight contains the right mouse button
+	MouseMaskRight MouseButtonMask = 1 << engo.MouseButtonRight
+	// MouseMaskMiddle contains the middle mouse button
+	MouseMaskMiddle MouseButtonMask = 1 << engo.MouseButtonMiddle
+)
+
+// Has indicates whether or not the given button is part of the mask. An empty mask contains every button.
+func (m MouseButtonMask) Has(b engo.MouseButton) bool {
+	return m == 0 || m&(1<<uint(b)) != 0
+}
+
 // MouseComponent is the location for the MouseSystem to store its results;
 // to be used / viewed by other Systems
 type MouseComponent struct {
@@ -62,12 +89,27 @@ type MouseComponent struct {
 	// the entity space in this frame. This does not necessarily imply that
 	// the mouse button was pressed down in your entity space.
 	Hovered bool
-	// Dragged is true whenever the entity space was left-clicked,
-	// and then the mouse started moving (while holding)
+	// Dragged is true whenever the entity space was clicked using the
+	// DragButton, and then the mouse started moving (while holding)
 	Dragged bool
+	// DragButton is the mouse button which drags the entity, see Dragged. It
+	// defaults to the left mouse button; the middle button is common for panning.
+	DragButton engo.MouseButton
 	// RightClicked is true whenever the entity space was right-clicked
 	// in this frame
 	RightClicked bool
+	// DoubleClicked is true whenever the entity space was left-clicked in this
+	// frame, shortly after a previous left-click on it. Clicked is true as well.
+	DoubleClicked bool
+	// RightDoubleClicked is true whenever the entity space was right-clicked in
+	// this frame, shortly after a previous right-click on it.
+	RightDoubleClicked bool
+	// MiddleClicked is true whenever the entity space was clicked using the
+	// middle mouse button in this frame
+	MiddleClicked bool
+	// MiddleReleased is true whenever the middle mouse button is released over
+	// the entity space in this frame
+	MiddleReleased bool
 	// RightDragged is true whenever the entity space was right-clicked,
 	// and then the mouse started moving (while holding)
 	RightDragged bool
@@ -75,16 +117,51 @@ type MouseComponent struct {
 	// the entity space in this frame. This does not necessarily imply that
 	// the mouse button was pressed down in your entity space.
 	RightReleased bool
+	// ButtonClicked holds every mouse button, including the extra buttons found on some mice, which was clicked over
+	// the entity space in this frame. It is nil if no button was clicked.
+	ButtonClicked map[engo.MouseButton]bool
+	// ButtonReleased holds every mouse button which was released over the entity space in this frame. It is nil if no
+	// button was released.
+	ButtonReleased map[engo.MouseButton]bool
+	// Held contains every mouse button which is being held down, wherever it was pressed, such as for detecting that
+	// both the left and right mouse button are held at once using `Holding`. Unlike the flags above, which are only
+	// set during the frame a button is pressed or released, Held lasts until the button is released again. Like
+	// MouseX and MouseY, it's only set while the entity is hovered, tracked or captured.
+	Held MouseButtonMask
 	// Enter is true whenever the Mouse entered the entity space in that frame,
 	// but wasn't in that space during the previous frame
 	Enter bool
 	// Leave is true whenever the Mouse was in the space on the previous frame,
 	// but now isn't
 	Leave bool
+	// HoverDuration is how long, in seconds, the Mouse has continuously been hovering the entity space. It's zero in
+	// the frame the Mouse enters it, and reset to zero whenever it leaves, such as for only showing a tooltip after
+	// the cursor lingers.
+	HoverDuration float32
+	// Captured is true while the entity has captured the mouse, from the frame a button it drags with (its DragButton
+	// or the right mouse button) is pressed over it until that button is released. While captured, the entity keeps
+	// being updated wherever the cursor is, so MouseX, MouseY, Dragged and RightDragged stay current even when the
+	// cursor is moved off the entity faster than it follows, and the release is seen even when it happens elsewhere.
+	Captured bool
 	// Position of the mouse at any moment this is generally used
-	// in conjunction with Track = true
+	// in conjunction with Track = true. It's rounded to `MouseSystem.Precision`.
 	MouseX float32
 	MouseY float32
+	// RawMouseX and RawMouseY are the position of the mouse like MouseX and
+	// MouseY, but never rounded, for code that needs the exact position.
+	RawMouseX float32
+	RawMouseY float32
+	// ScrollX and ScrollY are the amount the mouse wheel was scrolled in this
+	// frame while hovering the entity space (or while tracking the mouse), in
+	// wheel notches rather than pixels. Touchpads may report fractions of a notch.
+	ScrollX float32
+	ScrollY float32
+	// DeltaX and DeltaY are how far the mouse moved since the previous frame,
+	// in the same coordinates as MouseX and MouseY. Moving the camera does not
+	// count as moving the mouse, see `MouseSystem.WorldDelta`. They're zero
+	// during the first frame after the entity was added.
+	DeltaX float32
+	DeltaY float32
 	// Set manually this to true and your mouse component will track the mouse
 	// and your entity will always be able to receive an updated mouse
 	// component even if its space is not under the mouse cursor
@@ -98,6 +175,46 @@ type MouseComponent struct {
 	// Modifier is used to store the eventual modifiers that were pressed during
 	// the same time the different click events occurred
 	Modifier engo.Modifier
+	// PixelPerfect indicates whether or not the mouse only hovers the entity
+	// where its Texture is opaque, rather than anywhere within its
+	// SpaceComponent. This only works for Textures whose pixel data was kept,
+	// see `ReadableTextures`; for other drawables the SpaceComponent is used.
+	PixelPerfect bool
+	// AlphaThreshold is the alpha value pixels have to exceed to be hit by the
+	// mouse when using PixelPerfect.
+	AlphaThreshold uint8
+	// ButtonMask contains the mouse buttons the entity responds to. Pressing or
+	// releasing any other button does not click, release or start dragging the
+	// entity, although it is still hovered. If left zero, the entity responds
+	// to every button.
+	ButtonMask MouseButtonMask
+	// HitWhenHidden keeps the entity responding to the mouse while its
+	// RenderComponent is Hidden, such as an invisible button. By default
+	// hidden entities are ignored.
+	HitWhenHidden bool
+	// PassThrough lets the mouse through the entity to the entities behind it,
+	// such as a transparent overlay. Otherwise the front-most entity under the
+	// cursor covers those behind it, see `MouseSystem.TopMostOnly`.
+	PassThrough bool
+	// HitWhenCovered keeps the entity responding to the mouse while it is
+	// covered by another entity in front of it. By default covered entities are
+	// neither hovered nor clicked.
+	HitWhenCovered bool
+	// Inactive stops the entity from responding to the mouse, such as the buttons behind a modal dialog, while it
+	// stays in the MouseSystem and is still drawn. An inactive entity is neither hovered, clicked nor dragged, and
+	// doesn't cover the entities behind it. Making an entity inactive releases it, the same way the window losing focus
+	// does.
+	Inactive bool
+	// HoverCursor is the cursor shown while the mouse hovers the entity, or while it has captured the mouse, such as
+	// CursorHand for clickable entities. Of multiple such entities under the cursor the front-most one is used, see
+	// MouseSystem. The MouseSystem sets it using `engo.SetCursor`, and resets the cursor to CursorNone once no entity
+	// with a HoverCursor is hovered. If left CursorNone, the entity doesn't change the cursor.
+	HoverCursor engo.Cursor
+	// HoverMessages dispatches a MouseEnterMessage through `engo.Mailbox`
+	// whenever Enter becomes true, and a MouseLeaveMessage whenever Leave
+	// does, so systems such as tooltips can listen for hovers instead of
+	// checking every entity.
+	HoverMessages bool
 
 	// startedDragging is used internally to see if *this* is the object that is being dragged
 	startedDragging bool
@@ -109,6 +226,90 @@ type MouseComponent struct {
 	rightStartedDragging bool
 	// rightStartedMoving see startedMoving, but for the right mouse button
 	rightStartedMoving bool
+	// pressedAt is used internally to see how far the mouse moved since startedDragging became true
+	pressedAt engo.Point
+	// rightPressedAt see pressedAt, but for the right mouse button
+	rightPressedAt engo.Point
+	// lastClick is used internally to see whether the next left-click is a double-click
+	lastClick mouseClick
+	// rightLastClick see lastClick, but for the right mouse button
+	rightLastClick mouseClick
+	// updated is used internally to see whether the entity was updated before, so it has a previous mouse position
+	updated bool
+}
+
+// MouseEnterMessage is dispatched by the MouseSystem whenever the mouse starts hovering an entity whose
+// MouseComponent has HoverMessages set.
+type MouseEnterMessage struct {
+	// ID is the ID of the BasicEntity of the hovered entity
+	ID uint64
+}
+
+// Type implements the engo.Message interface.
+func (MouseEnterMessage) Type() string {
+	return "MouseEnterMessage"
+}
+
+// MouseLeaveMessage is dispatched by the MouseSystem whenever the mouse stops hovering an entity whose
+// MouseComponent has HoverMessages set.
+type MouseLeaveMessage struct {
+	// ID is the ID of the BasicEntity of the entity which is no longer hovered
+	ID uint64
+}
+
+// Type implements the engo.Message interface.
+func (MouseLeaveMessage) Type() string {
+	return "MouseLeaveMessage"
+}
+
+// Snapped returns the position of the mouse, as given by MouseX and MouseY, snapped to the grid; see `Grid.Snap`.
+func (m *MouseComponent) Snapped(g Grid) engo.Point {
+	return g.Snap(engo.Point{X: m.MouseX, Y: m.MouseY})
+}
+
+// Grid is a grid of cells of CellSize, one of which has its top-left corner at Origin, such as the tiles of a level.
+// It snaps positions, such as that of the mouse, to the cell they're in, in order to place entities on the grid.
+type Grid struct {
+	// Origin is the top-left corner of the cell in column 0 and row 0
+	Origin engo.Point
+	// CellSize is the width and height of every cell. Positions aren't snapped along an axis in which it's zero.
+	CellSize engo.Point
+}
+
+// Cell returns the column and row of the cell containing the point. The cells left of and above Origin are in the
+// negative columns and rows, so the cell just left of Origin is in column -1 rather than 0. The left and top edges
+// of a cell are within it, while the right and bottom edges are within the next one.
+func (g Grid) Cell(p engo.Point) (int, int) {
+	return gridCell(p.X, g.Origin.X, g.CellSize.X), gridCell(p.Y, g.Origin.Y, g.CellSize.Y)
+}
+
+// Snap returns the top-left corner of the cell containing the point, see Cell.
+func (g Grid) Snap(p engo.Point) engo.Point {
+	x, y := g.Cell(p)
+	if g.CellSize.X != 0 {
+		p.X = g.Origin.X + float32(x)*g.CellSize.X
+	}
+	if g.CellSize.Y != 0 {
+		p.Y = g.Origin.Y + float32(y)*g.CellSize.Y
+	}
+	return p
+}
+
+// gridCell returns the index of the cell of the given size containing p, counting from the cell starting at origin.
+// It rounds towards negative infinity, rather than towards zero.
+func gridCell(p, origin, size float32) int {
+	if size == 0 {
+		return 0
+	}
+	return int(math.Floor((p - origin) / size))
+}
+
+// mouseClick is a click on an entity which may be followed by a second one, making it a double-click.
+type mouseClick struct {
+	// valid indicates whether or not there was a click which has not been part of a double-click yet
+	valid bool
+	time  float32
+	at    engo.Point
 }
 
 type mouseEntity struct {
@@ -119,44 +320,177 @@ type mouseEntity struct {
 }
 
 // MouseSystem listens for mouse events, and changes value for MouseComponent accordingly
+//
+// On touchscreens, the first finger touching the screen is reported as the left mouse button by `engo.Input`, so
+// taps, hovering while touching and drags work the same as with a mouse. The position of the touch is scaled by the
+// GlobalScale the same way as the position of the cursor, and is then transformed to world coordinates using the
+// camera, so no extra handling is needed. Touches of other fingers are available from `engo.Input.TouchPoints`.
+//
+// With split-screen views (see `RenderSystem.AddView`), the cursor is transformed to world coordinates using the
+// view it is on, as returned by `RenderSystem.ViewAt`, so entities are hovered and clicked within the view they're
+// seen in. While the cursor isn't on any of the views, the camera is used instead. Movement is always measured
+// within the view the cursor is currently on, so moving from one view onto another doesn't jump across the world.
+//
+// While the window doesn't have focus (see `engo.WindowFocused`), such as after alt-tabbing away during a drag, no
+// entity is hovered, dragged or captured, and every button is considered released, since the backends don't report
+// what the mouse does meanwhile. Listen for the `engo.WindowFocusMessage` to pause the game as well.
+//
+// Entities are hovered while the cursor is within their SpaceComponent, see `SpaceComponent.Contains`. Unlike
+// Contains, the cursor on the left or top edge of a SpaceComponent without hitboxes is within it, while the cursor on
+// the right or bottom edge is not, so a click on the edge shared by two adjacent cells of a grid hits exactly one of
+// them. An entity with a negative Width or Height is hovered to the left or above its Position. An entity without a
+// Width or Height is a point, which is only hovered while the cursor is exactly at its Position, such as when it's
+// moved there programmatically.
+//
+// By default every entity under the cursor is hovered and clicked. With TopMostOnly, only the front-most one is, so a
+// button behind a panel doesn't fire along with the panel. Entities are in front of others in the order in which
+// they're drawn: entities on the HUD (see `RenderComponent.ScreenSpace`) are in front of those in the world, and
+// entities with a higher ZIndex are in front of those with a lower one. Entities without a RenderComponent are in the
+// world at a ZIndex of 0, and of the entities at the same depth the one returned last by Entities is in front.
+// Entities which are PassThrough let the mouse through to those behind them, while entities which are HitWhenCovered
+// are hit even when covered. Entities which Track the mouse, or have captured it by being dragged (see
+// `MouseComponent.Captured`), are always updated, and never cover others.
+//
+// No press or release of a mouse button is lost, even when several happen within a single frame, see
+// `engo.Mouse.Events`. They are queued, and handled one per update in the order in which they happened, at the position
+// of the cursor at the time. A button pressed and released again within a frame thus clicks an entity during one
+// update, and releases it during the next.
 type MouseSystem struct {
+	// DoubleClickInterval is the maximum amount of seconds between two clicks of a double-click. If it's zero,
+	// DefaultDoubleClickInterval is used.
+	DoubleClickInterval float32
+	// DoubleClickMaxDistance is the maximum distance in game units between two clicks of a double-click, so a
+	// cursor that drifted too far does not count. If it's zero, DefaultDoubleClickMaxDistance is used.
+	DoubleClickMaxDistance float32
+	// DragThreshold is the distance in game units the mouse has to move while holding a button, before an entity is
+	// dragged. Smaller movements, such as jitter on touchpads, keep it a click. If it's zero, DefaultDragThreshold is
+	// used; a negative threshold starts dragging on any movement.
+	DragThreshold float32
+	// TopMostOnly delivers hovers and clicks to only the front-most entity under the cursor, instead of to every
+	// entity under it. See MouseSystem on which entity is in front.
+	TopMostOnly bool
+	// Precision is the step in game units to which the MouseX and MouseY of every MouseComponent are rounded, such as
+	// 1 to keep UI snapped to the cursor aligned to whole units. If it's zero, the position isn't rounded. Rounding
+	// happens in the coordinates the entity is in, so for entities in the world a step is zoomed along with the camera,
+	// and covers more pixels on screen when zoomed in; entities on the HUD are not zoomed. Hit testing and the deltas
+	// always use the exact position, see `MouseComponent.RawMouseX`.
+	Precision float32
+	// UpdatePriority is the priority of the MouseSystem, for running it after systems which have to run before it,
+	// such as one moving the camera the cursor is translated with. If it's zero, MouseSystemPriority is used. It has
+	// to be set before the MouseSystem is added to the World, which orders its systems by priority as they're added.
+	UpdatePriority int
+
 	entities []mouseEntity
-	world    *ecs.World
-	camera   *CameraSystem
+	// index maps the ID of every entity to its index within entities
+	index map[uint64]int
+	// order holds the indices of the entities in the order they're checked, see checkOrder
+	order  []int
+	world  *ecs.World
+	camera *CameraSystem
+	render *RenderSystem
+	// view is the view the cursor is on, if any
+	view *View
+
+	mouseX float32
+	mouseY float32
+	// hit is whether or not the cursor hit any of the entities during the last update
+	hit bool
+	// down contains the mouse buttons which were pressed over an entity, and have not been released yet
+	down MouseButtonMask
+	// held contains the mouse buttons which were pressed anywhere, and have not been released yet
+	held MouseButtonMask
+
+	// screen is the position of the mouse on screen during the previous update
+	screen      engo.Point
+	hasScreen   bool
+	worldDelta  engo.Point
+	screenDelta engo.Point
+
+	// cursor is the HoverCursor which was set using engo.SetCursor, if any
+	cursor engo.Cursor
+
+	// lastClick is the position in the world of the last press of a mouse button, if clicked, see LastClick
+	lastClick       engo.Point
+	lastClickButton engo.MouseButton
+	clicked         bool
 
-	mouseX    float32
-	mouseY    float32
-	mouseDown bool
+	// pending are the presses and releases of mouse buttons which have not been handled yet, see nextEvent
+	pending []engo.MouseEvent
+
+	// time is the amount of seconds the MouseSystem has been updated, used to time double-clicks
+	time float32
 }
 
-// Priority returns a priority higher than most, to ensure that this System runs before all others
-func (m *MouseSystem) Priority() int { return MouseSystemPriority }
+// Priority returns a priority higher than most, to ensure that this System runs before all others, unless another
+// UpdatePriority is set.
+func (m *MouseSystem) Priority() int {
+	if m.UpdatePriority != 0 {
+		return m.UpdatePriority
+	}
+	return MouseSystemPriority
+}
 
 // New initializes the MouseSystem. It is run before any updates.
 func (m *MouseSystem) New(w *ecs.World) {
 	m.world = w
 
 	// First check if the CameraSystem is available
+	if !m.findCamera() {
+		log.Println("ERROR: CameraSystem not found - have you added the `RenderSystem` before the `MouseSystem`?")
+		return
+	}
+}
+
+// findCamera looks up the CameraSystem of the world, and returns whether or not it was found. The CameraSystem is
+// only looked up until it was found once, along with the RenderSystem holding the views, if any.
+func (m *MouseSystem) findCamera() bool {
+	if m.camera != nil {
+		return true
+	}
+	if m.world == nil {
+		return false
+	}
 	for _, system := range m.world.Systems() {
 		switch sys := system.(type) {
 		case *CameraSystem:
 			m.camera = sys
+		case *RenderSystem:
+			m.render = sys
 		}
 	}
+	return m.camera != nil
+}
 
-	if m.camera == nil {
-		log.Println("ERROR: CameraSystem not found - have you added the `RenderSystem` before the `MouseSystem`?")
-		return
+// screenToWorld translates a position within the window into "game coordinates", using the view the cursor is on, or
+// the camera when it isn't on any.
+func (m *MouseSystem) screenToWorld(x, y float32) (float32, float32) {
+	if m.view != nil {
+		return m.view.ScreenToWorld(x, y)
 	}
+	return m.camera.ScreenToWorld(x, y)
+}
+
+// View returns the view of the RenderSystem the cursor was on during the last update, or nil when it wasn't on any
+// of them, or there are no views. See `RenderSystem.AddView`.
+func (m *MouseSystem) View() *View {
+	return m.view
 }
 
 // Add adds a new entity to the MouseSystem.
-// * RenderComponent is only required if you're using the HUDShader on this Entity.
+// * RenderComponent is only required if the Entity is in screen space, such as when using the HUDShader (see
+//   `RenderComponent.ScreenSpace`), or to skip it while it's hidden.
 // * SpaceComponent is required whenever you want to know specific mouse-events on this Entity (like hover,
 //   click, etc.). If you don't need those, then you can omit the SpaceComponent.
 // * MouseComponent is always required.
 // * BasicEntity is always required.
 func (m *MouseSystem) Add(basic *ecs.BasicEntity, mouse *MouseComponent, space *SpaceComponent, render *RenderComponent) {
+	if m.index == nil {
+		m.index = make(map[uint64]int)
+	}
+	if _, ok := m.index[basic.ID()]; ok {
+		return
+	}
+	m.index[basic.ID()] = len(m.entities)
 	m.entities = append(m.entities, mouseEntity{basic, mouse, space, render})
 }
 
@@ -166,47 +500,313 @@ func (m *MouseSystem) AddByInterface(i ecs.Identifier) {
 	m.Add(o.GetBasicEntity(), o.GetMouseComponent(), o.GetSpaceComponent(), o.GetRenderComponent())
 }
 
+// AddBatch adds many entities to the MouseSystem at once, such as when spawning a wave of enemies. It's the same as
+// calling AddByInterface for every entity, except that the list of entities grows only once.
+func (m *MouseSystem) AddBatch(entities []Mouseable) {
+	if m.index == nil {
+		m.index = make(map[uint64]int, len(entities))
+	}
+	if n := len(m.entities) + len(entities); n > cap(m.entities) {
+		grown := make([]mouseEntity, len(m.entities), n)
+		copy(grown, m.entities)
+		m.entities = grown
+	}
+	for _, e := range entities {
+		m.Add(e.GetBasicEntity(), e.GetMouseComponent(), e.GetSpaceComponent(), e.GetRenderComponent())
+	}
+}
+
 // Remove removes an entity from the MouseSystem.
 func (m *MouseSystem) Remove(basic ecs.BasicEntity) {
-	var delete = -1
-	for index, entity := range m.entities {
-		if entity.ID() == basic.ID() {
-			delete = index
-			break
+	m.RemoveByID(basic.ID())
+}
+
+// RemoveByID removes the entity with the given ID from the MouseSystem. Unlike removing an entity from most systems,
+// this takes constant time, which matters for games that add and remove thousands of entities. The last entity
+// takes the place of the removed one, so the order in which entities are checked changes.
+func (m *MouseSystem) RemoveByID(id uint64) {
+	i, ok := m.index[id]
+	if !ok {
+		return
+	}
+	last := len(m.entities) - 1
+	if i != last {
+		m.entities[i] = m.entities[last]
+		m.index[m.entities[i].ID()] = i
+	}
+	m.entities[last] = mouseEntity{}
+	m.entities = m.entities[:last]
+	delete(m.index, id)
+}
+
+// Entities returns the IDs of all entities in the MouseSystem, in the order in which they are checked. The returned
+// slice is a copy, so it can be kept and iterated over while entities are added to or removed from the system.
+func (m *MouseSystem) Entities() []uint64 {
+	ids := make([]uint64, len(m.entities))
+	for i, e := range m.entities {
+		ids[i] = e.ID()
+	}
+	return ids
+}
+
+// BasicEntities returns a copy of the BasicEntities of all entities in the MouseSystem.
+func (m *MouseSystem) BasicEntities() []ecs.BasicEntity {
+	basics := make([]ecs.BasicEntity, len(m.entities))
+	for i, e := range m.entities {
+		basics[i] = *e.BasicEntity
+	}
+	return basics
+}
+
+// Get returns the components with which the entity with the given ID was added to the MouseSystem, and false if it
+// isn't in the system. Like RemoveByID, it takes constant time. Entities are looked up in the same index as they are
+// removed from, so an entity which was removed isn't found.
+func (m *MouseSystem) Get(id uint64) (*MouseComponent, *SpaceComponent, *RenderComponent, bool) {
+	i, ok := m.index[id]
+	if !ok {
+		return nil, nil, nil, false
+	}
+	e := m.entities[i]
+	return e.MouseComponent, e.SpaceComponent, e.RenderComponent, true
+}
+
+// LastClick returns the position in the world at which a mouse button was last pressed, and which button it was,
+// whether or not the click hit any entity, such as for commanding units to move there. Like MouseX and MouseY, the
+// position is translated using the view the cursor was on. It persists until the next click; clicks outside of the
+// game area don't count. It returns false if no button was pressed since the MouseSystem was added.
+func (m *MouseSystem) LastClick() (engo.Point, engo.MouseButton, bool) {
+	return m.lastClick, m.lastClickButton, m.clicked
+}
+
+// WorldDelta returns how far the cursor moved through the world since the previous update, taking the zoom level
+// and rotation of the camera into account. Moving the camera itself does not count as movement of the cursor, so
+// the camera can be moved opposite to the delta to implement "drag to pan".
+func (m *MouseSystem) WorldDelta() engo.Point {
+	return m.worldDelta
+}
+
+// doubleClicked records a click at the given position, and returns whether or not it completes a double-click
+// together with the previous click.
+func (m *MouseSystem) doubleClicked(last *mouseClick, at engo.Point) bool {
+	interval := m.DoubleClickInterval
+	if interval == 0 {
+		interval = DefaultDoubleClickInterval
+	}
+	maxDistance := m.DoubleClickMaxDistance
+	if maxDistance == 0 {
+		maxDistance = DefaultDoubleClickMaxDistance
+	}
+
+	if last.valid && m.time-last.time <= interval && last.at.PointDistance(at) <= maxDistance {
+		*last = mouseClick{}
+		return true
+	}
+	*last = mouseClick{valid: true, time: m.time, at: at}
+	return false
+}
+
+// pastDragThreshold indicates whether or not the mouse moved far enough since the given press, to start dragging.
+func (m *MouseSystem) pastDragThreshold(pressedAt, at engo.Point) bool {
+	threshold := m.DragThreshold
+	if threshold == 0 {
+		threshold = DefaultDragThreshold
+	}
+	return pressedAt.PointDistance(at) > threshold
+}
+
+// opaqueAt indicates whether or not the Texture of the entity is opaque at the given point. Drawables without readable
+// pixel data are considered opaque everywhere, so only their SpaceComponent is used.
+func opaqueAt(e mouseEntity, p engo.Point) bool {
+	if e.RenderComponent == nil {
+		return true
+	}
+	var tex Texture
+	switch d := e.RenderComponent.Drawable.(type) {
+	case Texture:
+		tex = d
+	case *Texture:
+		tex = *d
+	default:
+		return true
+	}
+
+	// Translate the point into the unrotated space of the entity, and then into pixels of the Texture
+	sin, cos := math.Sincos(e.SpaceComponent.Rotation * math.Pi / 180)
+	dx, dy := p.X-e.SpaceComponent.Position.X, p.Y-e.SpaceComponent.Position.Y
+	x, y := dx*cos+dy*sin, dy*cos-dx*sin
+	if scale := e.RenderComponent.Scale; scale.X != 0 && scale.Y != 0 {
+		x, y = x/scale.X, y/scale.Y
+	}
+
+	alpha, ok := tex.Alpha(x, y)
+	return !ok || alpha > e.MouseComponent.AlphaThreshold
+}
+
+// setPosition sets the position of the mouse, rounded to the given precision unless it's zero.
+func (m *MouseComponent) setPosition(x, y, precision float32) {
+	m.RawMouseX, m.RawMouseY = x, y
+	if precision != 0 {
+		x = math.Floor(x/precision+0.5) * precision
+		y = math.Floor(y/precision+0.5) * precision
+	}
+	m.MouseX, m.MouseY = x, y
+}
+
+// settings returns a MouseComponent with only the settings of this one, such as Track and ButtonMask, and none of the
+// results of the MouseSystem.
+func (m *MouseComponent) settings() MouseComponent {
+	return MouseComponent{
+		Track:          m.Track,
+		PixelPerfect:   m.PixelPerfect,
+		AlphaThreshold: m.AlphaThreshold,
+		ButtonMask:     m.ButtonMask,
+		DragButton:     m.DragButton,
+		HitWhenHidden:  m.HitWhenHidden,
+		PassThrough:    m.PassThrough,
+		HitWhenCovered: m.HitWhenCovered,
+		Inactive:       m.Inactive,
+		HoverMessages:  m.HoverMessages,
+		HoverCursor:    m.HoverCursor,
+	}
+}
+
+// Holding indicates whether or not every one of the given buttons is being held down, see Held.
+func (m *MouseComponent) Holding(buttons MouseButtonMask) bool {
+	return buttons != 0 && m.Held&buttons == buttons
+}
+
+// captured indicates whether or not the entity has captured the mouse, see Captured.
+func (m *MouseComponent) captured() bool {
+	return m.startedDragging || m.rightStartedDragging
+}
+
+// isDown indicates whether or not the given button was pressed over an entity, and has not been released yet.
+func (m *MouseSystem) isDown(b engo.MouseButton) bool {
+	return m.down&(1<<uint(b)) != 0
+}
+
+// checkOrder returns the indices of the entities in the order in which they're checked during an update. With
+// TopMostOnly, they're ordered from the front-most entity to the one furthest back.
+func (m *MouseSystem) checkOrder() []int {
+	m.order = m.order[:0]
+	if !m.TopMostOnly {
+		for i := range m.entities {
+			m.order = append(m.order, i)
 		}
+		return m.order
+	}
+	for i := len(m.entities) - 1; i >= 0; i-- {
+		m.order = append(m.order, i)
 	}
-	if delete >= 0 {
-		m.entities = append(m.entities[:delete], m.entities[delete+1:]...)
+	sort.SliceStable(m.order, func(a, b int) bool {
+		return m.entities[m.order[a]].inFrontOf(m.entities[m.order[b]])
+	})
+	return m.order
+}
+
+// inFrontOf indicates whether or not the entity at index i is in front of the one at index j, see MouseSystem.
+func (m *MouseSystem) inFrontOf(i, j int) bool {
+	a, b := m.entities[i], m.entities[j]
+	return a.inFrontOf(b) || (!b.inFrontOf(a) && i > j)
+}
+
+// inFrontOf indicates whether or not the entity is drawn in front of the other entity.
+func (e mouseEntity) inFrontOf(other mouseEntity) bool {
+	var hud, otherHUD bool
+	var z, otherZ float32
+	if e.RenderComponent != nil {
+		hud, z = e.RenderComponent.ScreenSpace, e.RenderComponent.ZIndex()
+	}
+	if other.RenderComponent != nil {
+		otherHUD, otherZ = other.RenderComponent.ScreenSpace, other.RenderComponent.ZIndex()
 	}
+	if hud != otherHUD {
+		return hud
+	}
+	return z > otherZ
 }
 
 // Update updates all the entities in the MouseSystem.
 func (m *MouseSystem) Update(dt float32) {
-	// Translate Mouse.X and Mouse.Y into "game coordinates"
-	switch engo.CurrentBackEnd {
-	case engo.BackEndGLFW, engo.BackEndSDL, engo.BackEndVulkan:
-		m.mouseX = ((engo.Input.Mouse.X * m.camera.Z() * engo.GameWidth() / engo.WindowWidth()) + (m.camera.X()-(engo.GameWidth()/2)*m.camera.Z())/engo.GetGlobalScale().X)
-		m.mouseY = ((engo.Input.Mouse.Y * m.camera.Z() * engo.GameHeight() / engo.WindowHeight()) + (m.camera.Y()-(engo.GameHeight()/2)*m.camera.Z())/engo.GetGlobalScale().Y)
-	case engo.BackEndMobile, engo.BackEndWeb:
-		m.mouseX = engo.Input.Mouse.X*m.camera.Z() + (m.camera.X()-(engo.GameWidth()/2)*m.camera.Z()+(engo.ResizeXOffset/2))/engo.GetGlobalScale().X
-		m.mouseY = engo.Input.Mouse.Y*m.camera.Z() + (m.camera.Y()-(engo.GameHeight()/2)*m.camera.Z()+(engo.ResizeYOffset/2))/engo.GetGlobalScale().Y
+	m.time += dt
+
+	// The CameraSystem may have been added after the MouseSystem
+	if !m.findCamera() {
+		return
 	}
 
-	// Rotate if needed
-	if m.camera.angle != 0 {
-		sin, cos := math.Sincos(m.camera.angle * math.Pi / 180)
-		m.mouseX, m.mouseY = m.mouseX*cos+m.mouseY*sin, m.mouseY*cos-m.mouseX*sin
+	if !engo.WindowFocused() {
+		m.unfocus()
+		return
 	}
 
-	for _, e := range m.entities {
-		// Reset all values except these
-		*e.MouseComponent = MouseComponent{
-			Track:                e.MouseComponent.Track,
-			Hovered:              e.MouseComponent.Hovered,
-			startedDragging:      e.MouseComponent.startedDragging,
-			startedMoving:        e.MouseComponent.startedMoving,
-			rightStartedDragging: e.MouseComponent.rightStartedDragging,
-			rightStartedMoving:   e.MouseComponent.rightStartedMoving,
+	ev := m.nextEvent()
+
+	// Translate the position of the cursor into "game coordinates", using the view the cursor is on
+	m.view = nil
+	if m.render != nil {
+		m.view = m.render.ViewAt(ev.X, ev.Y)
+	}
+	m.mouseX, m.mouseY = m.screenToWorld(ev.X, ev.Y)
+	// The position on the HUD is translated once per update rather than for every entity on the HUD, since how the
+	// game is scaled to the window doesn't change in between
+	gx, gy := engo.WindowToGame(ev.X, ev.Y)
+
+	// The previous position is translated using the current camera, so only movement of the cursor is counted
+	m.worldDelta, m.screenDelta = engo.Point{}, engo.Point{}
+	if m.hasScreen {
+		prevX, prevY := m.screenToWorld(m.screen.X, m.screen.Y)
+		m.worldDelta = engo.Point{X: m.mouseX - prevX, Y: m.mouseY - prevY}
+		prevGX, prevGY := engo.WindowToGame(m.screen.X, m.screen.Y)
+		m.screenDelta = engo.Point{X: gx - prevGX, Y: gy - prevGY}
+	}
+	m.screen = engo.Point{X: ev.X, Y: ev.Y}
+	m.hasScreen = true
+
+	// Presses and releases are only reported during a single update, so which buttons are held is kept track of
+	switch ev.Action {
+	case engo.Press:
+		m.held |= 1 << uint(ev.Button)
+		if ev.Inside {
+			m.lastClick = engo.Point{X: m.mouseX, Y: m.mouseY}
+			m.lastClickButton = ev.Button
+			m.clicked = true
+		}
+	case engo.Release:
+		m.held &^= 1 << uint(ev.Button)
+	}
+
+	// covered is set once the cursor hits an entity which covers those behind it
+	covered := false
+	// cursor is the index of the front-most hovered entity with a HoverCursor, if any
+	cursor := -1
+	m.hit = false
+	for _, i := range m.checkOrder() {
+		e := m.entities[i]
+		updated := e.MouseComponent.updated
+
+		// Reset all values except the settings and these
+		reset := e.MouseComponent.settings()
+		reset.Hovered = e.MouseComponent.Hovered
+		reset.HoverDuration = e.MouseComponent.HoverDuration
+		reset.startedDragging = e.MouseComponent.startedDragging
+		reset.startedMoving = e.MouseComponent.startedMoving
+		reset.rightStartedDragging = e.MouseComponent.rightStartedDragging
+		reset.rightStartedMoving = e.MouseComponent.rightStartedMoving
+		reset.pressedAt = e.MouseComponent.pressedAt
+		reset.rightPressedAt = e.MouseComponent.rightPressedAt
+		reset.lastClick = e.MouseComponent.lastClick
+		reset.rightLastClick = e.MouseComponent.rightLastClick
+		reset.updated = true
+		*e.MouseComponent = reset
+		if e.MouseComponent.Inactive {
+			e.release()
+			continue
+		}
+		// delta is zero during the first update of the entity
+		var delta engo.Point
+		if updated {
+			delta = m.worldDelta
 		}
 
 		if e.MouseComponent.Track {
@@ -214,8 +814,9 @@ func (m *MouseSystem) Update(dt float32) {
 			// position can do it (think an RTS when placing a new building and
 			// you get a ghost building following your mouse until you click to
 			// place it somewhere in your world.
-			e.MouseComponent.MouseX = m.mouseX
-			e.MouseComponent.MouseY = m.mouseY
+			e.MouseComponent.setPosition(m.mouseX, m.mouseY, m.Precision)
+			e.MouseComponent.DeltaX = delta.X
+			e.MouseComponent.DeltaY = delta.Y
 		}
 
 		mx := m.mouseX
@@ -225,67 +826,113 @@ func (m *MouseSystem) Update(dt float32) {
 			continue // with other entities
 		}
 
+		hidden := false
 		if e.RenderComponent != nil {
-			// Hardcoded special case for the HUD | TODO: make generic instead of hardcoding
-			if e.RenderComponent.shader == HUDShader || e.RenderComponent.shader == LegacyHUDShader {
-				mx = engo.Input.Mouse.X
-				my = engo.Input.Mouse.Y
+			// Entities in screen space, such as the HUD, aren't moved by the camera
+			if e.RenderComponent.ScreenSpace {
+				mx, my = gx, gy
+				if updated {
+					delta = m.screenDelta
+				}
 			}
 
-			if e.RenderComponent.Hidden {
+			// A captured entity which was hidden mid-drag still sees the release, but can no longer be hit
+			hidden = e.RenderComponent.Hidden && !e.MouseComponent.HitWhenHidden
+			if hidden && !e.MouseComponent.captured() {
 				continue // skip hidden components
 			}
 		}
 
-		// If the Mouse component is a tracker we always update it
-		// Check if the X-value is within range
-		// and if the Y-value is within range
-		if e.MouseComponent.Track || e.MouseComponent.startedDragging ||
-			e.SpaceComponent.Contains(engo.Point{X: mx, Y: my}) {
+		// If the Mouse component is a tracker, or has captured the mouse, we always update it
+		// Otherwise check if the cursor is within the SpaceComponent, taking its rotation into account
+		// Hit-testing is skipped whenever the cursor is outside of the game area
+		hit := !hidden && ev.Inside && e.SpaceComponent.hits(engo.Point{X: mx, Y: my}) &&
+			(!e.MouseComponent.PixelPerfect || opaqueAt(e, engo.Point{X: mx, Y: my}))
+		if hit && covered && !e.MouseComponent.HitWhenCovered {
+			hit = false // an entity in front of it got the mouse
+		}
+		m.hit = m.hit || hit
+		if hit && m.TopMostOnly && !e.MouseComponent.PassThrough {
+			covered = true
+		}
+		if e.MouseComponent.HoverCursor != engo.CursorNone && (hit || e.MouseComponent.captured()) &&
+			(cursor < 0 || m.inFrontOf(i, cursor)) {
+			cursor = i
+		}
+		if e.MouseComponent.Track || e.MouseComponent.captured() || hit {
 
 			e.MouseComponent.Enter = !e.MouseComponent.Hovered
 			e.MouseComponent.Hovered = true
+			if e.MouseComponent.Enter {
+				e.MouseComponent.HoverDuration = 0
+			} else {
+				e.MouseComponent.HoverDuration += dt
+			}
 			e.MouseComponent.Released = false
 
 			if !e.MouseComponent.Track {
 				// If we're tracking, we've already set these
-				e.MouseComponent.MouseX = mx
-				e.MouseComponent.MouseY = my
+				e.MouseComponent.setPosition(mx, my, m.Precision)
+				e.MouseComponent.DeltaX = delta.X
+				e.MouseComponent.DeltaY = delta.Y
+			}
+			e.MouseComponent.ScrollX = engo.Input.Mouse.ScrollX
+			e.MouseComponent.ScrollY = engo.Input.Mouse.ScrollY
+			e.MouseComponent.Held = m.held
+
+			action := ev.Action
+			if (action == engo.Press || action == engo.Release) && !e.MouseComponent.ButtonMask.Has(ev.Button) {
+				action = engo.Neutral // the entity ignores this button
 			}
 
-			switch engo.Input.Mouse.Action {
+			switch action {
 			case engo.Press:
-				switch engo.Input.Mouse.Button {
+				switch ev.Button {
 				case engo.MouseButtonLeft:
 					e.MouseComponent.Clicked = true
-					e.MouseComponent.startedDragging = true
+					e.MouseComponent.DoubleClicked = m.doubleClicked(&e.MouseComponent.lastClick, engo.Point{X: mx, Y: my})
 				case engo.MouseButtonRight:
 					e.MouseComponent.RightClicked = true
+					e.MouseComponent.RightDoubleClicked = m.doubleClicked(&e.MouseComponent.rightLastClick, engo.Point{X: mx, Y: my})
 					e.MouseComponent.rightStartedDragging = true
+					e.MouseComponent.rightPressedAt = engo.Point{X: mx, Y: my}
+				case engo.MouseButtonMiddle:
+					e.MouseComponent.MiddleClicked = true
 				}
+				if ev.Button == e.MouseComponent.DragButton {
+					e.MouseComponent.startedDragging = true
+					e.MouseComponent.pressedAt = engo.Point{X: mx, Y: my}
+				}
+				e.MouseComponent.ButtonClicked = map[engo.MouseButton]bool{ev.Button: true}
 
-				m.mouseDown = true
+				m.down |= 1 << uint(ev.Button)
 			case engo.Release:
-				switch engo.Input.Mouse.Button {
+				switch ev.Button {
 				case engo.MouseButtonLeft:
 					e.MouseComponent.Released = true
 				case engo.MouseButtonRight:
 					e.MouseComponent.RightReleased = true
+				case engo.MouseButtonMiddle:
+					e.MouseComponent.MiddleReleased = true
 				}
+				e.MouseComponent.ButtonReleased = map[engo.MouseButton]bool{ev.Button: true}
 			case engo.Move:
-				if m.mouseDown && e.MouseComponent.startedDragging {
+				at := engo.Point{X: mx, Y: my}
+				if m.isDown(e.MouseComponent.DragButton) && e.MouseComponent.startedDragging &&
+					(e.MouseComponent.startedMoving || m.pastDragThreshold(e.MouseComponent.pressedAt, at)) {
 					e.MouseComponent.startedMoving = true
 					e.MouseComponent.Dragged = true
 				}
-				if m.mouseDown && e.MouseComponent.rightStartedDragging {
+				if m.isDown(engo.MouseButtonRight) && e.MouseComponent.rightStartedDragging &&
+					(e.MouseComponent.rightStartedMoving || m.pastDragThreshold(e.MouseComponent.rightPressedAt, at)) {
 					e.MouseComponent.rightStartedMoving = true
 					e.MouseComponent.RightDragged = true
 				}
 			default:
-				if m.mouseDown && e.MouseComponent.startedDragging && e.MouseComponent.startedMoving {
+				if m.isDown(e.MouseComponent.DragButton) && e.MouseComponent.startedDragging && e.MouseComponent.startedMoving {
 					e.MouseComponent.Dragged = true
 				}
-				if m.mouseDown && e.MouseComponent.rightStartedDragging && e.MouseComponent.rightStartedMoving {
+				if m.isDown(engo.MouseButtonRight) && e.MouseComponent.rightStartedDragging && e.MouseComponent.rightStartedMoving {
 					e.MouseComponent.RightDragged = true
 				}
 			}
@@ -295,24 +942,99 @@ func (m *MouseSystem) Update(dt float32) {
 			}
 
 			e.MouseComponent.Hovered = false
+			e.MouseComponent.HoverDuration = 0
 		}
 
-		if engo.Input.Mouse.Action == engo.Release {
-			// dragging stops as soon as one of the currently pressed buttons
-			// is released
-			e.MouseComponent.Dragged = false
-			e.MouseComponent.startedDragging = false
-			e.MouseComponent.startedMoving = false
-			// TODO maybe separate out the release into left-button release and right-button release
-			e.MouseComponent.rightStartedDragging = false
-			e.MouseComponent.rightStartedMoving = false
-			// mouseDown goes false as soon as one of the pressed buttons is
-			// released. Effectively ending any dragging
-			m.mouseDown = false
+		if ev.Action == engo.Release {
+			// dragging stops as soon as the button it started with is
+			// released, other drags continue
+			if ev.Button == e.MouseComponent.DragButton {
+				e.MouseComponent.Dragged = false
+				e.MouseComponent.startedDragging = false
+				e.MouseComponent.startedMoving = false
+			}
+			if ev.Button == engo.MouseButtonRight {
+				e.MouseComponent.RightDragged = false
+				e.MouseComponent.rightStartedDragging = false
+				e.MouseComponent.rightStartedMoving = false
+			}
+			m.down &^= 1 << uint(ev.Button)
+		}
+		e.MouseComponent.Captured = e.MouseComponent.captured()
+
+		if e.MouseComponent.HoverMessages {
+			if e.MouseComponent.Enter {
+				engo.Mailbox.Dispatch(MouseEnterMessage{ID: e.ID()})
+			} else if e.MouseComponent.Leave {
+				engo.Mailbox.Dispatch(MouseLeaveMessage{ID: e.ID()})
+			}
 		}
 
 		// propagate the modifiers to the mouse component so that game
 		// implementers can take different decisions based on those
-		e.MouseComponent.Modifier = engo.Input.Mouse.Modifer
+		e.MouseComponent.Modifier = ev.Modifier
+	}
+
+	m.setCursor(cursor)
+}
+
+// nextEvent queues the presses and releases of mouse buttons during the current frame, and returns the first one
+// which has not been handled yet. Without any, the mouse is handled as it is, such as when it's only moved, or when
+// the Action of the mouse is set directly rather than through the backends.
+func (m *MouseSystem) nextEvent() engo.MouseEvent {
+	m.pending = append(m.pending, engo.Input.Mouse.Events()...)
+	if len(m.pending) == 0 {
+		mouse := &engo.Input.Mouse
+		return engo.MouseEvent{
+			X:        mouse.X,
+			Y:        mouse.Y,
+			Action:   mouse.Action,
+			Button:   mouse.Button,
+			Modifier: mouse.Modifer,
+			Inside:   mouse.Inside,
+		}
+	}
+	ev := m.pending[0]
+	m.pending = append(m.pending[:0], m.pending[1:]...)
+	return ev
+}
+
+// unfocus releases every entity while the window doesn't have focus, since the mouse leaving the window or a button
+// being released elsewhere is not reported to it. Entities stop being hovered, dragged and captured, and all buttons
+// are considered released, so nothing is stuck once focus returns; which entities are hovered is updated again from
+// then on, starting with Enter.
+func (m *MouseSystem) unfocus() {
+	for _, e := range m.entities {
+		e.release()
+	}
+	m.down, m.held = 0, 0
+	m.pending = m.pending[:0]
+	m.hit = false
+	m.hasScreen = false
+	m.worldDelta, m.screenDelta = engo.Point{}, engo.Point{}
+	m.setCursor(-1)
+}
+
+// release resets the results of the entity, so it's no longer hovered, dragged or captured. If it was hovered, it's
+// left.
+func (e mouseEntity) release() {
+	leave := e.MouseComponent.Hovered
+	*e.MouseComponent = e.MouseComponent.settings()
+	e.MouseComponent.Leave = leave
+	if leave && e.MouseComponent.HoverMessages {
+		engo.Mailbox.Dispatch(MouseLeaveMessage{ID: e.ID()})
+	}
+}
+
+// setCursor shows the HoverCursor of the entity at the given index, or resets the cursor if the index is negative and
+// a HoverCursor was shown before. The cursor is only set whenever it changes, so cursors set by the game are kept.
+func (m *MouseSystem) setCursor(i int) {
+	c := engo.CursorNone
+	if i >= 0 {
+		c = m.entities[i].MouseComponent.HoverCursor
+	}
+	if c != m.cursor {
+		engo.SetCursor(c)
+		m.cursor = c
 	}
 }
@@ -0,0 +1,1656 @@
+package common
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/engo/math"
+)
+
+type mouseTestScene struct{}
+
+func (*mouseTestScene) Preload() {}
+
+func (*mouseTestScene) Setup(engo.Updater) {}
+
+func (*mouseTestScene) Type() string { return "mouseTestScene" }
+
+func TestMouseSystemRenderScale(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	defer engo.SetRenderScale(1)
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	w.AddSystem(&CameraSystem{})
+	m := &MouseSystem{}
+	w.AddSystem(m)
+
+	basic := ecs.NewBasic()
+	mouse := &MouseComponent{}
+	m.Add(&basic, mouse, &SpaceComponent{Position: engo.Point{X: 100, Y: 100}, Width: 50, Height: 50}, nil)
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 120, 130
+	for _, scale := range []float32{1, 0.75, 0.5} {
+		engo.SetRenderScale(scale)
+		m.Update(0)
+
+		if !mouse.Hovered {
+			t.Errorf("entity under the cursor was not hovered at render scale %v", scale)
+		}
+		if mouse.MouseX != 120 || mouse.MouseY != 130 {
+			t.Errorf("mouse position changed at render scale %v. Wanted: (120, 130), got: (%v, %v)", scale, mouse.MouseX, mouse.MouseY)
+		}
+	}
+}
+
+func TestMouseSystemPrecision(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	w.AddSystem(&CameraSystem{})
+	m := &MouseSystem{}
+	w.AddSystem(m)
+
+	basic := ecs.NewBasic()
+	mouse := &MouseComponent{}
+	m.Add(&basic, mouse, &SpaceComponent{Position: engo.Point{X: 100, Y: 100}, Width: 50, Height: 50}, nil)
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 120.4, 130.6
+	for _, test := range []struct {
+		precision, x, y float32
+	}{
+		{0, 120.4, 130.6},
+		{1, 120, 131},
+		{0.5, 120.5, 130.5},
+		{10, 120, 130},
+	} {
+		m.Precision = test.precision
+		m.Update(0)
+
+		if mouse.MouseX != test.x || mouse.MouseY != test.y {
+			t.Errorf("wrong position at precision %v. Wanted: (%v, %v), got: (%v, %v)", test.precision, test.x, test.y, mouse.MouseX, mouse.MouseY)
+		}
+		if mouse.RawMouseX != 120.4 || mouse.RawMouseY != 130.6 {
+			t.Errorf("raw position was rounded at precision %v, got: (%v, %v)", test.precision, mouse.RawMouseX, mouse.RawMouseY)
+		}
+	}
+}
+
+func TestMouseSystemWorldDelta(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	camera := &CameraSystem{}
+	w.AddSystem(camera)
+	m := &MouseSystem{}
+	w.AddSystem(m)
+	camera.zoom(1) // zoomed out, so every pixel covers two units of the world
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 100, 100
+	m.Update(0)
+	if delta := m.WorldDelta(); delta != (engo.Point{}) {
+		t.Errorf("mouse moved before its previous position was known, got: %v", delta)
+	}
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 110, 90
+	m.Update(0)
+	if expected := (engo.Point{X: 20, Y: -20}); m.WorldDelta() != expected {
+		t.Errorf("mouse delta did not account for the zoom level. Wanted: %v, got: %v", expected, m.WorldDelta())
+	}
+
+	// Moving the camera while the cursor stays in place does not move the cursor
+	camera.moveX(15)
+	m.Update(0)
+	if delta := m.WorldDelta(); delta != (engo.Point{}) {
+		t.Errorf("moving the camera was counted as moving the mouse, got: %v", delta)
+	}
+}
+
+func TestMouseSystemEntities(t *testing.T) {
+	m := &MouseSystem{}
+	basics := []ecs.BasicEntity{ecs.NewBasic(), ecs.NewBasic(), ecs.NewBasic()}
+	for i := range basics {
+		m.Add(&basics[i], &MouseComponent{}, &SpaceComponent{}, nil)
+	}
+	m.Remove(basics[1])
+
+	ids := m.Entities()
+	expected := []uint64{basics[0].ID(), basics[2].ID()}
+	if len(ids) != len(expected) || ids[0] != expected[0] || ids[1] != expected[1] {
+		t.Fatalf("entities were not returned in order. Wanted: %v, got: %v", expected, ids)
+	}
+
+	ids[0] = 0
+	m.Remove(basics[2])
+	if actual := m.Entities(); len(actual) != 1 || actual[0] != basics[0].ID() {
+		t.Errorf("returned entities were not a copy. Wanted: %v, got: %v", []uint64{basics[0].ID()}, actual)
+	}
+}
+
+func TestMouseSystemDoubleClick(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	w.AddSystem(&CameraSystem{})
+	m := &MouseSystem{}
+	w.AddSystem(m)
+
+	basic := ecs.NewBasic()
+	mouse := &MouseComponent{}
+	m.Add(&basic, mouse, &SpaceComponent{Position: engo.Point{X: 100, Y: 100}, Width: 50, Height: 50}, nil)
+	defer func() { engo.Input.Mouse.Action = engo.Neutral }()
+
+	click := func(dt, x, y float32) {
+		engo.Input.Mouse.X, engo.Input.Mouse.Y = x, y
+		engo.Input.Mouse.Button = engo.MouseButtonLeft
+		engo.Input.Mouse.Action = engo.Press
+		m.Update(dt)
+		clicked, double := mouse.Clicked, mouse.DoubleClicked
+		engo.Input.Mouse.Action = engo.Release
+		m.Update(0)
+		mouse.Clicked, mouse.DoubleClicked = clicked, double
+	}
+
+	tests := []struct {
+		name   string
+		dt     float32
+		x, y   float32
+		double bool
+	}{
+		{"first click", 0, 120, 130, false},
+		{"second click", 0.1, 121, 130, true},
+		{"third click", 0.1, 121, 130, false},
+		{"click after the interval", 0.5, 121, 130, false},
+		{"click too far away", 0.1, 140, 130, false},
+		{"click shortly after drifting", 0.1, 141, 131, true},
+	}
+	for _, test := range tests {
+		click(test.dt, test.x, test.y)
+		if !mouse.Clicked {
+			t.Errorf("%s did not fire a click", test.name)
+		}
+		if mouse.DoubleClicked != test.double {
+			t.Errorf("%s was not detected correctly. Wanted double-click: %v, got: %v", test.name, test.double, mouse.DoubleClicked)
+		}
+	}
+}
+
+func TestMouseSystemSharedEdge(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	w.AddSystem(&CameraSystem{})
+	m := &MouseSystem{}
+	w.AddSystem(m)
+
+	// Two cells of a grid, touching at x = 150
+	basics := []ecs.BasicEntity{ecs.NewBasic(), ecs.NewBasic()}
+	left, right := &MouseComponent{}, &MouseComponent{}
+	m.Add(&basics[0], left, &SpaceComponent{Position: engo.Point{X: 100, Y: 100}, Width: 50, Height: 50}, nil)
+	m.Add(&basics[1], right, &SpaceComponent{Position: engo.Point{X: 150, Y: 100}, Width: 50, Height: 50}, nil)
+	defer func() { engo.Input.Mouse.Action = engo.Neutral }()
+
+	tests := []struct {
+		name        string
+		x, y        float32
+		left, right bool
+	}{
+		{"shared edge", 150, 120, false, true},
+		{"top edge", 120, 100, true, false},
+		{"bottom edge", 120, 150, false, false},
+		{"left edge", 100, 120, true, false},
+		{"right edge", 200, 120, false, false},
+	}
+	for _, test := range tests {
+		engo.Input.Mouse.X, engo.Input.Mouse.Y = test.x, test.y
+		engo.Input.Mouse.Button = engo.MouseButtonLeft
+		engo.Input.Mouse.Action = engo.Press
+		m.Update(0)
+		if left.Clicked != test.left || right.Clicked != test.right {
+			t.Errorf("click on the %s was not hit-tested correctly. Wanted: (%v, %v), got: (%v, %v)", test.name, test.left, test.right, left.Clicked, right.Clicked)
+		}
+		engo.Input.Mouse.Action = engo.Release
+		m.Update(0)
+	}
+}
+
+func TestMouseSystemTopMostOnly(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	w.AddSystem(&CameraSystem{})
+	m := &MouseSystem{TopMostOnly: true}
+	w.AddSystem(m)
+
+	space := SpaceComponent{Position: engo.Point{X: 100, Y: 100}, Width: 50, Height: 50}
+	basics := []ecs.BasicEntity{ecs.NewBasic(), ecs.NewBasic(), ecs.NewBasic(), ecs.NewBasic()}
+	button, panel, overlay, listener := &MouseComponent{}, &MouseComponent{}, &MouseComponent{PassThrough: true}, &MouseComponent{HitWhenCovered: true}
+	panelRender, overlayRender := &RenderComponent{}, &RenderComponent{}
+	panelRender.zIndex, overlayRender.zIndex = 1, 2
+	m.Add(&basics[0], button, &space, &RenderComponent{})
+	m.Add(&basics[1], panel, &space, panelRender)
+	m.Add(&basics[2], overlay, &space, overlayRender)
+	m.Add(&basics[3], listener, &space, nil)
+	defer func() { engo.Input.Mouse.Action = engo.Neutral }()
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 120, 130
+	engo.Input.Mouse.Button = engo.MouseButtonLeft
+	engo.Input.Mouse.Action = engo.Press
+	m.Update(0)
+	if button.Clicked || button.Hovered {
+		t.Error("button behind the panel was clicked")
+	}
+	if !panel.Clicked {
+		t.Error("panel behind the overlay which lets the mouse through was not clicked")
+	}
+	if !overlay.Clicked {
+		t.Error("overlay in front was not clicked")
+	}
+	if !listener.Clicked {
+		t.Error("entity which is hit when covered was not clicked")
+	}
+
+	// Once the panel is on the HUD, it's in front of the overlay in the world
+	panelRender.ScreenSpace = true
+	engo.Input.Mouse.Action = engo.Release
+	m.Update(0)
+	engo.Input.Mouse.Action = engo.Press
+	m.Update(0)
+	if !panel.Clicked || overlay.Clicked {
+		t.Errorf("entity on the HUD was not in front of the world. Clicked panel: %v, overlay: %v", panel.Clicked, overlay.Clicked)
+	}
+}
+
+func TestMouseSystemHoverCursor(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	w.AddSystem(&CameraSystem{})
+	m := &MouseSystem{}
+	w.AddSystem(m)
+
+	basics := []ecs.BasicEntity{ecs.NewBasic(), ecs.NewBasic(), ecs.NewBasic()}
+	button, field, plain := &MouseComponent{HoverCursor: engo.CursorHand}, &MouseComponent{HoverCursor: engo.CursorIBeam}, &MouseComponent{}
+	fieldRender, plainRender := &RenderComponent{}, &RenderComponent{}
+	fieldRender.zIndex, plainRender.zIndex = 1, 2
+	m.Add(&basics[0], button, &SpaceComponent{Position: engo.Point{X: 100, Y: 100}, Width: 100, Height: 100}, &RenderComponent{})
+	m.Add(&basics[1], field, &SpaceComponent{Position: engo.Point{X: 150, Y: 100}, Width: 100, Height: 100}, fieldRender)
+	m.Add(&basics[2], plain, &SpaceComponent{Position: engo.Point{X: 100, Y: 100}, Width: 200, Height: 100}, plainRender)
+
+	for _, test := range []struct {
+		name   string
+		x      float32
+		cursor engo.Cursor
+	}{
+		{"button", 120, engo.CursorHand},
+		{"field in front of the button", 170, engo.CursorIBeam},
+		{"field", 220, engo.CursorIBeam},
+		{"entity without a cursor", 280, engo.CursorNone},
+		{"background", 350, engo.CursorNone},
+	} {
+		engo.Input.Mouse.X, engo.Input.Mouse.Y = test.x, 150
+		m.Update(0)
+		if m.cursor != test.cursor {
+			t.Errorf("wrong cursor while hovering the %s. Wanted: %v, got: %v", test.name, test.cursor, m.cursor)
+		}
+	}
+}
+
+func TestMouseSystemHoverMessages(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+	engo.Mailbox = &engo.MessageManager{}
+
+	w := &ecs.World{}
+	w.AddSystem(&CameraSystem{})
+	m := &MouseSystem{}
+	w.AddSystem(m)
+
+	space := SpaceComponent{Position: engo.Point{X: 100, Y: 100}, Width: 50, Height: 50}
+	tooltip, silent := ecs.NewBasic(), ecs.NewBasic()
+	m.Add(&tooltip, &MouseComponent{HoverMessages: true}, &space, nil)
+	m.Add(&silent, &MouseComponent{}, &space, nil)
+
+	var messages []engo.Message
+	record := func(msg engo.Message) { messages = append(messages, msg) }
+	engo.Mailbox.Listen("MouseEnterMessage", record)
+	engo.Mailbox.Listen("MouseLeaveMessage", record)
+
+	for _, at := range []engo.Point{{X: 120, Y: 130}, {X: 125, Y: 130}, {X: 300, Y: 130}, {X: 310, Y: 130}} {
+		engo.Input.Mouse.X, engo.Input.Mouse.Y = at.X, at.Y
+		m.Update(0)
+	}
+	want := []engo.Message{MouseEnterMessage{ID: tooltip.ID()}, MouseLeaveMessage{ID: tooltip.ID()}}
+	if len(messages) != len(want) {
+		t.Fatalf("wrong number of hover messages. Wanted: %v, got: %v", want, messages)
+	}
+	for i := range want {
+		if messages[i] != want[i] {
+			t.Errorf("wrong hover message. Wanted: %v, got: %v", want[i], messages[i])
+		}
+	}
+}
+
+func TestMouseSystemHoverDuration(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	w.AddSystem(&CameraSystem{})
+	m := &MouseSystem{}
+	w.AddSystem(m)
+
+	basic := ecs.NewBasic()
+	mouse := &MouseComponent{}
+	m.Add(&basic, mouse, &SpaceComponent{Position: engo.Point{X: 100, Y: 100}, Width: 50, Height: 50}, nil)
+
+	// The cursor hovers the entity for three frames, leaves it for a single one and enters it again
+	for i, step := range []struct {
+		at   engo.Point
+		want float32
+	}{
+		{engo.Point{X: 120, Y: 130}, 0},
+		{engo.Point{X: 125, Y: 130}, 0.25},
+		{engo.Point{X: 130, Y: 130}, 0.5},
+		{engo.Point{X: 300, Y: 130}, 0},
+		{engo.Point{X: 120, Y: 130}, 0},
+		{engo.Point{X: 120, Y: 130}, 0.25},
+	} {
+		engo.Input.Mouse.X, engo.Input.Mouse.Y = step.at.X, step.at.Y
+		m.Update(0.25)
+		if mouse.HoverDuration != step.want {
+			t.Errorf("wrong hover duration in frame %v. Wanted: %v, got: %v", i, step.want, mouse.HoverDuration)
+		}
+	}
+}
+
+func TestMouseSystemDegenerateSize(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	w.AddSystem(&CameraSystem{})
+	m := &MouseSystem{}
+	w.AddSystem(m)
+
+	basics := []ecs.BasicEntity{ecs.NewBasic(), ecs.NewBasic()}
+	point, negative := &MouseComponent{}, &MouseComponent{}
+	m.Add(&basics[0], point, &SpaceComponent{Position: engo.Point{X: 120, Y: 130}}, nil)
+	// The negative size spans from (100, 100) to (150, 150)
+	m.Add(&basics[1], negative, &SpaceComponent{Position: engo.Point{X: 150, Y: 150}, Width: -50, Height: -50}, nil)
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 120, 130
+	m.Update(0)
+	if !point.Hovered {
+		t.Error("entity without a size was not hovered with the cursor exactly at its position")
+	}
+	if !negative.Hovered {
+		t.Error("entity with a negative size was not hovered")
+	}
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 121, 130
+	m.Update(0)
+	if point.Hovered {
+		t.Error("entity without a size was hovered with the cursor next to its position")
+	}
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 160, 130
+	m.Update(0)
+	if negative.Hovered {
+		t.Error("entity with a negative size was hovered with the cursor outside of it")
+	}
+}
+
+func TestMouseSystemScroll(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	w.AddSystem(&CameraSystem{})
+	m := &MouseSystem{}
+	w.AddSystem(m)
+
+	space := SpaceComponent{Position: engo.Point{X: 100, Y: 100}, Width: 50, Height: 50}
+	basics := []ecs.BasicEntity{ecs.NewBasic(), ecs.NewBasic(), ecs.NewBasic()}
+	hovered, tracking, other := &MouseComponent{}, &MouseComponent{Track: true}, &MouseComponent{}
+	m.Add(&basics[0], hovered, &space, nil)
+	m.Add(&basics[1], tracking, &SpaceComponent{Width: 10, Height: 10}, nil)
+	m.Add(&basics[2], other, &SpaceComponent{Width: 10, Height: 10}, nil)
+	defer func() { engo.Input.Mouse.ScrollX, engo.Input.Mouse.ScrollY = 0, 0 }()
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 120, 130
+	engo.Input.Mouse.ScrollX, engo.Input.Mouse.ScrollY = 0.5, -2
+	m.Update(0)
+	for _, mouse := range []*MouseComponent{hovered, tracking} {
+		if mouse.ScrollX != 0.5 || mouse.ScrollY != -2 {
+			t.Errorf("scrolling was not passed on. Wanted: (0.5, -2), got: (%v, %v)", mouse.ScrollX, mouse.ScrollY)
+		}
+	}
+	if other.ScrollX != 0 || other.ScrollY != 0 {
+		t.Errorf("scrolling was passed on to an entity that was not hovered, got: (%v, %v)", other.ScrollX, other.ScrollY)
+	}
+
+	engo.Input.Mouse.ScrollX, engo.Input.Mouse.ScrollY = 0, 0
+	m.Update(0)
+	if hovered.ScrollX != 0 || hovered.ScrollY != 0 {
+		t.Errorf("scrolling was not reset in the next frame, got: (%v, %v)", hovered.ScrollX, hovered.ScrollY)
+	}
+}
+
+func TestMouseSystemButtonMask(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	w.AddSystem(&CameraSystem{})
+	m := &MouseSystem{}
+	w.AddSystem(m)
+
+	space := SpaceComponent{Position: engo.Point{X: 100, Y: 100}, Width: 50, Height: 50}
+	basics := []ecs.BasicEntity{ecs.NewBasic(), ecs.NewBasic()}
+	masked, unmasked := &MouseComponent{ButtonMask: MouseMaskRight}, &MouseComponent{}
+	m.Add(&basics[0], masked, &space, nil)
+	m.Add(&basics[1], unmasked, &space, nil)
+	defer func() { engo.Input.Mouse.Action = engo.Neutral }()
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 120, 130
+	engo.Input.Mouse.Button = engo.MouseButtonLeft
+	engo.Input.Mouse.Action = engo.Press
+	m.Update(0)
+	if masked.Clicked || masked.ButtonClicked != nil {
+		t.Error("masked out button clicked the entity")
+	}
+	if !masked.Hovered {
+		t.Error("entity was not hovered while pressing a masked out button")
+	}
+	if !unmasked.Clicked {
+		t.Error("entity without a mask was not clicked")
+	}
+
+	engo.Input.Mouse.X = 125
+	engo.Input.Mouse.Action = engo.Move
+	m.Update(0)
+	if masked.Dragged {
+		t.Error("masked out button started dragging the entity")
+	}
+	if !unmasked.Dragged {
+		t.Error("entity without a mask was not dragged")
+	}
+
+	engo.Input.Mouse.Action = engo.Release
+	m.Update(0)
+	if masked.Released {
+		t.Error("masked out button released the entity")
+	}
+
+	engo.Input.Mouse.Button = engo.MouseButtonRight
+	engo.Input.Mouse.Action = engo.Press
+	m.Update(0)
+	if !masked.RightClicked {
+		t.Error("button within the mask did not click the entity")
+	}
+}
+
+func TestMouseSystemMiddleButton(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	w.AddSystem(&CameraSystem{})
+	m := &MouseSystem{}
+	w.AddSystem(m)
+
+	space := SpaceComponent{Position: engo.Point{X: 100, Y: 100}, Width: 50, Height: 50}
+	basics := []ecs.BasicEntity{ecs.NewBasic(), ecs.NewBasic()}
+	left, panning := &MouseComponent{}, &MouseComponent{DragButton: engo.MouseButtonMiddle}
+	m.Add(&basics[0], left, &space, nil)
+	m.Add(&basics[1], panning, &space, nil)
+	defer func() { engo.Input.Mouse.Action = engo.Neutral }()
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 120, 130
+	engo.Input.Mouse.Button = engo.MouseButtonMiddle
+	engo.Input.Mouse.Action = engo.Press
+	m.Update(0)
+	for _, mouse := range []*MouseComponent{left, panning} {
+		if !mouse.MiddleClicked {
+			t.Error("middle click was not detected")
+		}
+		if mouse.Clicked {
+			t.Error("middle click was detected as a left click")
+		}
+	}
+
+	engo.Input.Mouse.X = 125
+	engo.Input.Mouse.Action = engo.Move
+	m.Update(0)
+	if left.Dragged {
+		t.Error("middle button dragged an entity which is dragged using the left button")
+	}
+	if !panning.Dragged {
+		t.Error("middle button did not drag an entity which is dragged using the middle button")
+	}
+
+	engo.Input.Mouse.Action = engo.Release
+	m.Update(0)
+	if !left.MiddleReleased || left.Released {
+		t.Errorf("middle button release was not detected correctly. Wanted MiddleReleased, got: %v, Released: %v", left.MiddleReleased, left.Released)
+	}
+}
+
+func TestMouseSystemDelta(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	w.AddSystem(&CameraSystem{})
+	m := &MouseSystem{}
+	w.AddSystem(m)
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 100, 100
+	m.Update(0)
+
+	basics := []ecs.BasicEntity{ecs.NewBasic(), ecs.NewBasic()}
+	hovered, tracking := &MouseComponent{}, &MouseComponent{Track: true}
+	m.Add(&basics[0], hovered, &SpaceComponent{Width: 400, Height: 300}, nil)
+	m.Add(&basics[1], tracking, nil, nil)
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 150, 120
+	m.Update(0)
+	for _, mouse := range []*MouseComponent{hovered, tracking} {
+		if mouse.DeltaX != 0 || mouse.DeltaY != 0 {
+			t.Errorf("delta was not zero during the first frame of the entity, got: (%v, %v)", mouse.DeltaX, mouse.DeltaY)
+		}
+	}
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 140, 125
+	m.Update(0)
+	for _, mouse := range []*MouseComponent{hovered, tracking} {
+		if mouse.DeltaX != -10 || mouse.DeltaY != 5 {
+			t.Errorf("delta was not the movement of the mouse. Wanted: (-10, 5), got: (%v, %v)", mouse.DeltaX, mouse.DeltaY)
+		}
+	}
+}
+
+func TestMouseSystemCameraAddedLater(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	m := &MouseSystem{}
+	w.AddSystem(m)
+	m.Update(0) // without a CameraSystem, nothing is updated
+
+	camera := &CameraSystem{}
+	w.AddSystem(camera)
+	basic := ecs.NewBasic()
+	mouse := &MouseComponent{}
+	m.Add(&basic, mouse, &SpaceComponent{Width: 400, Height: 300}, nil)
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 120, 130
+	m.Update(0)
+	if m.camera != camera {
+		t.Fatal("CameraSystem added after the MouseSystem was not found")
+	}
+	if !mouse.Hovered {
+		t.Error("entity was not updated once the CameraSystem was found")
+	}
+}
+
+func TestMouseSystemRemoveByID(t *testing.T) {
+	m := &MouseSystem{}
+	basics := make([]ecs.BasicEntity, 4)
+	for i := range basics {
+		basics[i] = ecs.NewBasic()
+		m.Add(&basics[i], &MouseComponent{}, nil, nil)
+	}
+
+	m.RemoveByID(basics[1].ID())
+	m.Remove(basics[3])
+	m.RemoveByID(basics[3].ID()) // removing twice does nothing
+
+	ids := m.Entities()
+	if len(ids) != 2 || ids[0] != basics[0].ID() || ids[1] != basics[2].ID() {
+		t.Fatalf("wrong entities were removed. Wanted: %v, got: %v", []uint64{basics[0].ID(), basics[2].ID()}, ids)
+	}
+	for i, e := range m.entities {
+		if m.index[e.ID()] != i {
+			t.Errorf("index of entity %v was not kept up to date. Wanted: %v, got: %v", e.ID(), i, m.index[e.ID()])
+		}
+	}
+}
+
+func TestMouseSystemGet(t *testing.T) {
+	m := &MouseSystem{}
+	basics := []ecs.BasicEntity{ecs.NewBasic(), ecs.NewBasic()}
+	mice := []*MouseComponent{{}, {}}
+	space := &SpaceComponent{}
+	for i := range basics {
+		m.Add(&basics[i], mice[i], space, nil)
+	}
+
+	m.Remove(basics[0])
+	if _, _, _, ok := m.Get(basics[0].ID()); ok {
+		t.Error("removed entity was found")
+	}
+	// The remaining entity took the place of the removed one
+	if mouse, s, render, ok := m.Get(basics[1].ID()); !ok || mouse != mice[1] || s != space || render != nil {
+		t.Error("components of the entity were not returned")
+	}
+}
+
+// BenchmarkMouseSystemRemove measures adding and then removing 10000 entities, in the order in which they were added.
+func BenchmarkMouseSystemRemove(b *testing.B) {
+	basics := make([]ecs.BasicEntity, 10000)
+	mouse := &MouseComponent{}
+	for i := range basics {
+		basics[i] = ecs.NewBasic()
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		m := &MouseSystem{}
+		for i := range basics {
+			m.Add(&basics[i], mouse, nil, nil)
+		}
+		for _, basic := range basics {
+			m.Remove(basic)
+		}
+	}
+}
+
+func TestMouseSystemAddBatch(t *testing.T) {
+	m := &MouseSystem{}
+	entities := batchTestEntities(3)
+	batch := make([]Mouseable, len(entities))
+	for i := range entities {
+		batch[i] = &entities[i]
+	}
+	m.AddBatch(batch[:1])
+	m.AddBatch(batch[1:])
+	for i := range entities {
+		if mouse, _, _, ok := m.Get(entities[i].ID()); !ok || mouse != &entities[i].MouseComponent {
+			t.Errorf("entity %v added in a batch was not found", i)
+		}
+	}
+}
+
+// BenchmarkMouseSystemAddBatch measures spawning 5000 entities, one at a time and all at once.
+func BenchmarkMouseSystemAddBatch(b *testing.B) {
+	entities := batchTestEntities(5000)
+	batch := make([]Mouseable, len(entities))
+	for i := range entities {
+		batch[i] = &entities[i]
+	}
+
+	b.Run("Add", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			m := &MouseSystem{}
+			for _, e := range batch {
+				m.AddByInterface(e)
+			}
+		}
+	})
+	b.Run("AddBatch", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			m := &MouseSystem{}
+			m.AddBatch(batch)
+		}
+	})
+}
+
+func TestMouseSystemRightDrag(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	w.AddSystem(&CameraSystem{})
+	m := &MouseSystem{}
+	w.AddSystem(m)
+
+	basic := ecs.NewBasic()
+	mouse := &MouseComponent{}
+	m.Add(&basic, mouse, &SpaceComponent{Width: 400, Height: 300}, nil)
+	defer func() { engo.Input.Mouse.Action = engo.Neutral }()
+
+	input := func(button engo.MouseButton, action engo.Action) {
+		engo.Input.Mouse.Button = button
+		engo.Input.Mouse.Action = action
+		engo.Input.Mouse.X += 5
+		m.Update(0)
+	}
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 100, 100
+	input(engo.MouseButtonRight, engo.Press)
+	input(engo.MouseButtonLeft, engo.Press)
+	input(engo.MouseButtonLeft, engo.Move)
+	if !mouse.Dragged || !mouse.RightDragged {
+		t.Fatalf("holding both buttons did not drag using both. Dragged: %v, RightDragged: %v", mouse.Dragged, mouse.RightDragged)
+	}
+
+	input(engo.MouseButtonLeft, engo.Release)
+	input(engo.MouseButtonRight, engo.Move)
+	if mouse.Dragged {
+		t.Error("releasing the left button did not stop dragging")
+	}
+	if !mouse.RightDragged {
+		t.Error("releasing the left button stopped right-dragging")
+	}
+
+	input(engo.MouseButtonRight, engo.Release)
+	input(engo.MouseButtonRight, engo.Move)
+	if mouse.RightDragged {
+		t.Error("releasing the right button did not stop right-dragging")
+	}
+}
+
+func TestMouseSystemCapture(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	w.AddSystem(&CameraSystem{})
+	m := &MouseSystem{}
+	w.AddSystem(m)
+
+	for _, button := range []engo.MouseButton{engo.MouseButtonLeft, engo.MouseButtonRight} {
+		basic := ecs.NewBasic()
+		mouse := &MouseComponent{}
+		m.Add(&basic, mouse, &SpaceComponent{Position: engo.Point{X: 100, Y: 100}, Width: 20, Height: 20}, nil)
+
+		input := func(x float32, action engo.Action) {
+			engo.Input.Mouse.X, engo.Input.Mouse.Y = x, 110
+			engo.Input.Mouse.Button = button
+			engo.Input.Mouse.Action = action
+			m.Update(0)
+		}
+
+		input(110, engo.Press)
+		if !mouse.Captured {
+			t.Errorf("pressing button %v over the entity did not capture the mouse", button)
+		}
+
+		// The cursor moves off the entity in a single frame
+		input(250, engo.Move)
+		if !mouse.Captured || mouse.MouseX != 250 {
+			t.Errorf("captured entity was not updated with the cursor off it using button %v. Captured: %v, MouseX: %v", button, mouse.Captured, mouse.MouseX)
+		}
+		if !mouse.Dragged && !mouse.RightDragged {
+			t.Errorf("captured entity stopped being dragged using button %v", button)
+		}
+
+		input(260, engo.Release)
+		if mouse.Captured || (!mouse.Released && !mouse.RightReleased) {
+			t.Errorf("releasing button %v off the entity did not release it. Captured: %v", button, mouse.Captured)
+		}
+
+		input(270, engo.Move)
+		if mouse.MouseX != 0 {
+			t.Errorf("released entity was still updated with the cursor off it using button %v, MouseX: %v", button, mouse.MouseX)
+		}
+		m.Remove(basic)
+	}
+	engo.Input.Mouse.Action = engo.Neutral
+}
+
+func TestMouseSystemHeld(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	w.AddSystem(&CameraSystem{})
+	m := &MouseSystem{}
+	w.AddSystem(m)
+
+	basic := ecs.NewBasic()
+	mouse := &MouseComponent{}
+	m.Add(&basic, mouse, &SpaceComponent{Width: 400, Height: 300}, nil)
+	defer func() { engo.Input.Mouse.Action = engo.Neutral }()
+
+	input := func(button engo.MouseButton, action engo.Action) {
+		engo.Input.Mouse.Button = button
+		engo.Input.Mouse.Action = action
+		m.Update(0)
+	}
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 100, 100
+	input(engo.MouseButtonLeft, engo.Press)
+	input(engo.MouseButtonRight, engo.Press)
+	input(engo.MouseButtonRight, engo.Neutral)
+	if mouse.Held != MouseMaskLeft|MouseMaskRight || !mouse.Holding(MouseMaskLeft|MouseMaskRight) {
+		t.Errorf("pressing both buttons did not hold both. Wanted: %v, got: %v", MouseMaskLeft|MouseMaskRight, mouse.Held)
+	}
+	if mouse.Holding(MouseMaskLeft | MouseMaskMiddle) {
+		t.Error("holding the left and right button held the middle button")
+	}
+
+	input(engo.MouseButtonLeft, engo.Release)
+	input(engo.MouseButtonLeft, engo.Neutral)
+	if mouse.Held != MouseMaskRight || mouse.Holding(MouseMaskLeft|MouseMaskRight) {
+		t.Errorf("releasing the left button did not stop holding it. Wanted: %v, got: %v", MouseMaskRight, mouse.Held)
+	}
+}
+
+func TestMouseSystemDragThreshold(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	w.AddSystem(&CameraSystem{})
+	m := &MouseSystem{}
+	w.AddSystem(m)
+
+	basic := ecs.NewBasic()
+	mouse := &MouseComponent{}
+	m.Add(&basic, mouse, &SpaceComponent{Width: 400, Height: 300}, nil)
+	defer func() { engo.Input.Mouse.Action = engo.Neutral }()
+
+	tests := []struct {
+		name    string
+		moves   []float32
+		dragged bool
+	}{
+		{"jitter under the threshold", []float32{101, 102, 99}, false},
+		{"movement over the threshold", []float32{102, 104}, true},
+		{"movement back under the threshold", []float32{104, 101}, true},
+	}
+	for _, test := range tests {
+		engo.Input.Mouse.X, engo.Input.Mouse.Y = 100, 100
+		engo.Input.Mouse.Button = engo.MouseButtonLeft
+		engo.Input.Mouse.Action = engo.Press
+		m.Update(0)
+
+		engo.Input.Mouse.Action = engo.Move
+		for _, x := range test.moves {
+			engo.Input.Mouse.X = x
+			m.Update(0)
+		}
+		if mouse.Dragged != test.dragged {
+			t.Errorf("%s was not detected correctly. Wanted dragged: %v, got: %v", test.name, test.dragged, mouse.Dragged)
+		}
+
+		engo.Input.Mouse.Action = engo.Release
+		m.Update(0)
+		if !mouse.Released || mouse.Dragged {
+			t.Errorf("%s was not released correctly", test.name)
+		}
+	}
+}
+
+func TestMouseSystemPixelPerfect(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	w.AddSystem(&CameraSystem{})
+
+	// Only the left half of the image is opaque
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for x := 0; x < 2; x++ {
+		for y := 0; y < 4; y++ {
+			img.SetNRGBA(x, y, color.NRGBA{A: 255})
+		}
+	}
+	ReadableTextures = true
+	readable := NewTextureSingle(NewImageObject(img))
+	ReadableTextures = false
+	unreadable := NewTextureSingle(NewImageObject(img))
+
+	tests := []struct {
+		name     string
+		drawable Drawable
+		x        float32
+		hovered  bool
+	}{
+		{"opaque pixel", readable, 105, true},
+		{"transparent pixel", readable, 135, false},
+		{"texture pointer", &readable, 135, false},
+		{"unreadable texture", unreadable, 135, true},
+		{"other drawable", Rectangle{}, 135, true},
+	}
+	for _, test := range tests {
+		m := &MouseSystem{}
+		w.AddSystem(m)
+		basic := ecs.NewBasic()
+		mouse := &MouseComponent{PixelPerfect: true}
+		render := &RenderComponent{Drawable: test.drawable, Scale: engo.Point{X: 10, Y: 10}}
+		m.Add(&basic, mouse, &SpaceComponent{Position: engo.Point{X: 100, Y: 100}, Width: 40, Height: 40}, render)
+
+		engo.Input.Mouse.X, engo.Input.Mouse.Y = test.x, 120
+		m.Update(0)
+		if mouse.Hovered != test.hovered {
+			t.Errorf("%s was not hit-tested correctly. Wanted hovered: %v, got: %v", test.name, test.hovered, mouse.Hovered)
+		}
+	}
+}
+
+func TestMouseSystemScreenSpace(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	camera := &CameraSystem{}
+	w.AddSystem(camera)
+	m := &MouseSystem{}
+	w.AddSystem(m)
+	camera.zoom(1) // zoomed out, so the cursor is elsewhere in the world than on the screen
+
+	space := SpaceComponent{Position: engo.Point{X: 90, Y: 90}, Width: 20, Height: 20}
+	screen, world, hud := ecs.NewBasic(), ecs.NewBasic(), ecs.NewBasic()
+	screenMouse, worldMouse, hudMouse := &MouseComponent{}, &MouseComponent{}, &MouseComponent{}
+	screenSpace, worldSpace, hudSpace := space, space, space
+	hudRender := &RenderComponent{}
+	hudRender.SetShader(HUDShader)
+	m.Add(&screen, screenMouse, &screenSpace, &RenderComponent{ScreenSpace: true})
+	m.Add(&world, worldMouse, &worldSpace, &RenderComponent{})
+	m.Add(&hud, hudMouse, &hudSpace, hudRender)
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 100, 100
+	m.Update(0)
+	if !screenMouse.Hovered || !hudMouse.Hovered {
+		t.Errorf("entities in screen space were not hovered at the cursor on the screen, hovered: %v and %v", screenMouse.Hovered, hudMouse.Hovered)
+	}
+	if worldMouse.Hovered {
+		t.Error("entity in the world was hovered at the cursor on the screen")
+	}
+	if screenMouse.MouseX != 100 || screenMouse.MouseY != 100 {
+		t.Errorf("entity in screen space was not given the position on the screen. Wanted: (100, 100), got: (%v, %v)", screenMouse.MouseX, screenMouse.MouseY)
+	}
+}
+
+func TestMouseSystemHidden(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	w.AddSystem(&CameraSystem{})
+	m := &MouseSystem{}
+	w.AddSystem(m)
+
+	hidden, hitting := ecs.NewBasic(), ecs.NewBasic()
+	hiddenMouse, hittingMouse := &MouseComponent{}, &MouseComponent{HitWhenHidden: true}
+	m.Add(&hidden, hiddenMouse, &SpaceComponent{Width: 400, Height: 300}, &RenderComponent{Hidden: true})
+	m.Add(&hitting, hittingMouse, &SpaceComponent{Width: 400, Height: 300}, &RenderComponent{Hidden: true})
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 100, 100
+	m.Update(0)
+	if hiddenMouse.Hovered {
+		t.Error("hidden entity was hovered")
+	}
+	if !hittingMouse.Hovered {
+		t.Error("hidden entity with HitWhenHidden was not hovered")
+	}
+}
+
+func TestMouseSystemView(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	rs := &RenderSystem{}
+	w.AddSystem(rs)
+	m := &MouseSystem{}
+	w.AddSystem(m)
+
+	left, right := NewSplitScreen(false)
+	left.Position = engo.Point{X: 100, Y: 100}
+	right.Position = engo.Point{X: 1000, Y: 1000}
+	rs.AddView(left)
+	rs.AddView(right)
+
+	basic := ecs.NewBasic()
+	mouse := &MouseComponent{}
+	m.Add(&basic, mouse, &SpaceComponent{Position: engo.Point{X: 990, Y: 990}, Width: 20, Height: 20}, nil)
+
+	// The center of the right half shows the Position of the right view
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 300, 150
+	m.Update(0)
+	if m.View() != right {
+		t.Fatal("cursor was not on the right view")
+	}
+	if !mouse.Hovered || mouse.MouseX != 1000 || mouse.MouseY != 1000 {
+		t.Errorf("cursor was not translated using the right view, hovered: %v at (%v, %v)", mouse.Hovered, mouse.MouseX, mouse.MouseY)
+	}
+
+	// At the same spot of the left half, the cursor is elsewhere in the world
+	engo.Input.Mouse.X = 100
+	m.Update(0)
+	if m.View() != left || mouse.Hovered {
+		t.Errorf("cursor was not translated using the left view, hovered: %v", mouse.Hovered)
+	}
+	if expected := (engo.Point{X: -200}); m.worldDelta != expected {
+		t.Errorf("movement onto another view was not measured within it. Wanted: %v, got: %v", expected, m.worldDelta)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	g := Grid{Origin: engo.Point{X: 8, Y: 0}, CellSize: engo.Point{X: 16, Y: 16}}
+	tests := []struct {
+		p         engo.Point
+		col, row  int
+		snappedTo engo.Point
+	}{
+		{engo.Point{X: 10, Y: 5}, 0, 0, engo.Point{X: 8, Y: 0}},
+		{engo.Point{X: 24, Y: 16}, 1, 1, engo.Point{X: 24, Y: 16}},
+		{engo.Point{X: 7.5, Y: -0.5}, -1, -1, engo.Point{X: -8, Y: -16}},
+		{engo.Point{X: -8, Y: -16}, -1, -1, engo.Point{X: -8, Y: -16}},
+		{engo.Point{X: -9, Y: -17}, -2, -2, engo.Point{X: -24, Y: -32}},
+	}
+	for _, test := range tests {
+		if col, row := g.Cell(test.p); col != test.col || row != test.row {
+			t.Errorf("wrong cell for %v. Wanted: (%v, %v), got: (%v, %v)", test.p, test.col, test.row, col, row)
+		}
+		if p := g.Snap(test.p); p != test.snappedTo {
+			t.Errorf("%v was not snapped to its cell. Wanted: %v, got: %v", test.p, test.snappedTo, p)
+		}
+	}
+
+	mouse := MouseComponent{MouseX: -1, MouseY: 20}
+	if p := mouse.Snapped(Grid{CellSize: engo.Point{X: 16}}); p != (engo.Point{X: -16, Y: 20}) {
+		t.Errorf("mouse was not snapped along the axis with a cell size only. Wanted: (-16, 20), got: %v", p)
+	}
+}
+
+type mouseStepScene struct {
+	mouse *MouseComponent
+}
+
+func (*mouseStepScene) Preload() {}
+
+func (s *mouseStepScene) Setup(u engo.Updater) {
+	w := u.(*ecs.World)
+	w.AddSystem(&CameraSystem{})
+	m := &MouseSystem{}
+	w.AddSystem(m)
+	basic := ecs.NewBasic()
+	s.mouse = &MouseComponent{}
+	m.Add(&basic, s.mouse, &SpaceComponent{Position: engo.Point{X: 100, Y: 100}, Width: 50, Height: 50}, nil)
+}
+
+func (*mouseStepScene) Type() string { return "mouseStepScene" }
+
+func TestMouseSystemStep(t *testing.T) {
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+	scene := &mouseStepScene{}
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, scene)
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 120, 130
+	engo.Input.Mouse.Action = engo.Move
+	engo.Step(0)
+	if !scene.mouse.Hovered || scene.mouse.MouseX != 120 || scene.mouse.MouseY != 130 {
+		t.Errorf("mouse moved onto the entity did not hover it at (120, 130), got: %+v", *scene.mouse)
+	}
+
+	engo.Input.SetMouseButton(engo.MouseButtonLeft, true)
+	engo.Step(0)
+	if !scene.mouse.Clicked {
+		t.Error("pressing the left mouse button on the entity did not click it")
+	}
+	engo.Input.SetMouseButton(engo.MouseButtonLeft, false)
+	engo.Step(0)
+	if !scene.mouse.Released || scene.mouse.Clicked {
+		t.Error("releasing the left mouse button on the entity did not release it")
+	}
+
+	engo.Input.Mouse.X = 300
+	engo.Step(0)
+	if scene.mouse.Hovered {
+		t.Error("entity was still hovered once the mouse moved off of it")
+	}
+}
+
+func TestMouseSystemEnqueue(t *testing.T) {
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+	scene := &mouseStepScene{}
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, scene)
+	engo.Input.DisableRealInput(true)
+	defer engo.Input.DisableRealInput(false)
+
+	engo.Input.Enqueue(
+		engo.InputEvent{Type: engo.MousePressEvent, X: 120, Y: 130, Button: engo.MouseButtonLeft},
+		engo.InputEvent{Type: engo.MouseReleaseEvent, X: 120, Y: 130, Button: engo.MouseButtonLeft},
+	)
+	engo.Step(0)
+	if !scene.mouse.Clicked || scene.mouse.MouseX != 120 || scene.mouse.MouseY != 130 {
+		t.Errorf("enqueued press did not click the entity at (120, 130), got: %+v", *scene.mouse)
+	}
+	engo.Step(0)
+	if !scene.mouse.Released {
+		t.Error("enqueued release did not release the entity")
+	}
+}
+
+func TestMouseSystemEventsWithinFrame(t *testing.T) {
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+	scene := &mouseStepScene{}
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, scene)
+
+	// A fast click on the entity, released off of it, within a single frame
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 120, 130
+	engo.Input.SetMouseButton(engo.MouseButtonLeft, true)
+	engo.Input.Mouse.X = 300
+	engo.Input.SetMouseButton(engo.MouseButtonLeft, false)
+	engo.Step(0)
+	if !scene.mouse.Clicked || scene.mouse.MouseX != 120 || scene.mouse.MouseY != 130 {
+		t.Errorf("press within the frame did not click the entity where it happened, got: %+v", *scene.mouse)
+	}
+	engo.Step(0)
+	if scene.mouse.Clicked || !scene.mouse.Released || scene.mouse.Captured {
+		t.Errorf("release within the frame was not handled during the next update, got: %+v", *scene.mouse)
+	}
+	engo.Step(0)
+	if scene.mouse.Hovered || !scene.mouse.Leave {
+		t.Errorf("entity was still hovered once all events were handled, got: %+v", *scene.mouse)
+	}
+
+	// Two buttons pressed at once
+	engo.Input.Mouse.X = 120
+	engo.Input.SetMouseButton(engo.MouseButtonLeft, true)
+	engo.Input.SetMouseButton(engo.MouseButtonRight, true)
+	engo.Step(0)
+	if !scene.mouse.Clicked || scene.mouse.RightClicked {
+		t.Errorf("first of two presses was not handled first, got: %+v", *scene.mouse)
+	}
+	engo.Step(0)
+	if scene.mouse.Clicked || !scene.mouse.RightClicked || !scene.mouse.Holding(MouseMaskLeft|MouseMaskRight) {
+		t.Errorf("second of two presses was lost, got: %+v", *scene.mouse)
+	}
+}
+
+func TestMouseSystemFocusLost(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+	defer engo.SetWindowFocused(true)
+
+	w := &ecs.World{}
+	w.AddSystem(&CameraSystem{})
+	m := &MouseSystem{}
+	w.AddSystem(m)
+
+	basic := ecs.NewBasic()
+	mouse := &MouseComponent{HoverMessages: true, HoverCursor: engo.CursorHand}
+	m.Add(&basic, mouse, &SpaceComponent{Position: engo.Point{X: 100, Y: 100}, Width: 50, Height: 50}, nil)
+	left := 0
+	engo.Mailbox.Listen("MouseLeaveMessage", func(engo.Message) { left++ })
+	defer func() { engo.Input.Mouse.Action = engo.Neutral }()
+
+	input := func(x float32, action engo.Action) {
+		engo.Input.Mouse.X, engo.Input.Mouse.Y = x, 120
+		engo.Input.Mouse.Button = engo.MouseButtonLeft
+		engo.Input.Mouse.Action = action
+		m.Update(0)
+	}
+
+	input(120, engo.Press)
+	input(130, engo.Move)
+	if !mouse.Dragged || !mouse.Captured {
+		t.Fatalf("entity was not dragged. Dragged: %v, Captured: %v", mouse.Dragged, mouse.Captured)
+	}
+
+	// The player alt-tabs away mid-drag, so the release is never reported
+	engo.SetWindowFocused(false)
+	input(130, engo.Neutral)
+	if mouse.Hovered || mouse.Dragged || mouse.Captured || mouse.Held != 0 || !mouse.Leave {
+		t.Errorf("entity was still used by the mouse after focus was lost: %+v", *mouse)
+	}
+	if left != 1 || m.cursor != engo.CursorNone {
+		t.Errorf("losing focus did not leave the entity. Leave messages: %v, cursor: %v", left, m.cursor)
+	}
+	input(130, engo.Neutral)
+	if mouse.Leave || left != 1 {
+		t.Errorf("entity was left more than once while the window had no focus, messages: %v", left)
+	}
+
+	// Moving off the entity after returning doesn't drag it along
+	engo.SetWindowFocused(true)
+	input(300, engo.Move)
+	if mouse.Dragged || mouse.Captured || mouse.Hovered || mouse.MouseX != 0 {
+		t.Errorf("entity was still dragged after focus returned: %+v", *mouse)
+	}
+	input(120, engo.Move)
+	if !mouse.Hovered || !mouse.Enter {
+		t.Error("entity was not hovered again after focus returned")
+	}
+}
+
+func TestMouseSystemWindowResized(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	cam := &CameraSystem{}
+	w.AddSystem(cam)
+	m := &MouseSystem{}
+	w.AddSystem(m)
+
+	basic := ecs.NewBasic()
+	mouse := &MouseComponent{}
+	m.Add(&basic, mouse, &SpaceComponent{Position: engo.Point{X: 100, Y: 100}, Width: 50, Height: 50}, nil)
+
+	hover := func(x, y float32) {
+		engo.Input.Mouse.X, engo.Input.Mouse.Y = x, y
+		engo.Input.Mouse.Action = engo.Move
+		m.Update(0)
+	}
+	defer func() { engo.Input.Mouse.Action = engo.Neutral }()
+
+	hover(120, 120)
+	if !mouse.Hovered {
+		t.Fatal("entity was not hovered before the window was resized")
+	}
+
+	// Right after switching, the entity is picked where it's drawn within the larger window
+	for _, resize := range []func(){
+		func() { engo.SetFullscreen(true) },
+		func() { engo.SetWindowSize(1000, 500) },
+		func() { engo.SetFullscreen(false) },
+	} {
+		resize()
+		x, y := cam.WorldToScreen(125, 125)
+		hover(x, y)
+		if !mouse.Hovered || mouse.MouseX != 125 || mouse.MouseY != 125 {
+			t.Errorf("entity was not picked at %v, %v in a %vx%v window. Hovered: %v, at: %v, %v",
+				x, y, engo.WindowWidth(), engo.WindowHeight(), mouse.Hovered, mouse.MouseX, mouse.MouseY)
+		}
+		x, y = cam.WorldToScreen(200, 200)
+		hover(x, y)
+		if mouse.Hovered {
+			t.Errorf("entity was picked outside of it in a %vx%v window", engo.WindowWidth(), engo.WindowHeight())
+		}
+	}
+}
+
+func TestMouseSystemPriority(t *testing.T) {
+	if p := (&MouseSystem{}).Priority(); p != MouseSystemPriority {
+		t.Errorf("wrong default priority. Wanted: %v, got: %v", MouseSystemPriority, p)
+	}
+	if p := (&MouseSystem{UpdatePriority: 50}).Priority(); p != 50 {
+		t.Errorf("UpdatePriority was not used. Wanted: 50, got: %v", p)
+	}
+}
+
+func TestMouseSystemRotatedCamera(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 1000, Y: 1000}}
+
+	w := &ecs.World{}
+	camera := &CameraSystem{}
+	w.AddSystem(camera)
+	m := &MouseSystem{}
+	w.AddSystem(m)
+	camera.centerCam(500, 300, 1)
+	camera.rotateTo(90)
+
+	// Rotated around the center of the camera, the cursor right of the center of the screen is above its center in
+	// the world. Rotating the panned position instead picks entities far away.
+	basics := []ecs.BasicEntity{ecs.NewBasic(), ecs.NewBasic()}
+	above, right := &MouseComponent{}, &MouseComponent{}
+	m.Add(&basics[0], above, &SpaceComponent{Position: engo.Point{X: 490, Y: 190}, Width: 20, Height: 20}, nil)
+	m.Add(&basics[1], right, &SpaceComponent{Position: engo.Point{X: 590, Y: 290}, Width: 20, Height: 20}, nil)
+	defer func() { engo.Input.Mouse.Action = engo.Neutral }()
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 300, 150
+	engo.Input.Mouse.Button = engo.MouseButtonLeft
+	engo.Input.Mouse.Action = engo.Press
+	m.Update(0)
+	if !above.Clicked || right.Clicked {
+		t.Errorf("wrong entity was clicked through the rotated camera. Clicked above: %v, right: %v", above.Clicked, right.Clicked)
+	}
+
+	if x, y := camera.WorldToScreen(500, 300); x != 200 || y != 150 {
+		t.Errorf("center of the rotated camera was not drawn at the center of the screen, got (%v, %v)", x, y)
+	}
+}
+
+func TestMouseSystemYUp(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 1000, Y: 1000}}
+	YUp = true
+	defer func() { YUp = false }()
+
+	w := &ecs.World{}
+	camera := &CameraSystem{}
+	w.AddSystem(camera)
+	m := &MouseSystem{}
+	w.AddSystem(m)
+	camera.centerCam(500, 300, 1)
+
+	// With the y-axis pointing up, the cursor above the center of the screen is further along the y-axis in the world
+	basics := []ecs.BasicEntity{ecs.NewBasic(), ecs.NewBasic()}
+	up, down := &MouseComponent{}, &MouseComponent{}
+	m.Add(&basics[0], up, &SpaceComponent{Position: engo.Point{X: 490, Y: 390}, Width: 20, Height: 20}, nil)
+	m.Add(&basics[1], down, &SpaceComponent{Position: engo.Point{X: 490, Y: 190}, Width: 20, Height: 20}, nil)
+	defer func() { engo.Input.Mouse.Action = engo.Neutral }()
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 200, 50
+	engo.Input.Mouse.Button = engo.MouseButtonLeft
+	engo.Input.Mouse.Action = engo.Press
+	m.Update(0)
+	if !up.Clicked || down.Clicked {
+		t.Errorf("wrong entity was clicked with the y-axis pointing up. Clicked up: %v, down: %v", up.Clicked, down.Clicked)
+	}
+
+	// The world is drawn where the mouse picks it
+	if x, y := camera.WorldToScreen(500, 400); x != 200 || y != 50 {
+		t.Errorf("point above the center of the camera was not on the top half of the screen, got (%v, %v)", x, y)
+	}
+	var v viewCuller
+	v.prepare(camera, true)
+	if p := engo.MultiplyMatrixVector(&v.matrix, []float32{500, 400}); math.Abs(p[1]-float32(100)/150) > 0.001 {
+		t.Errorf("point above the center of the camera was not drawn on the top half of the screen, got %v", p[1])
+	}
+
+	// Mirroring and rotating are undone in the opposite order
+	camera.rotateTo(30)
+	x, y := camera.ScreenToWorld(camera.WorldToScreen(520, 410))
+	if math.Abs(x-520) > 0.01 || math.Abs(y-410) > 0.01 {
+		t.Errorf("position did not round-trip through the rotated camera, got (%v, %v)", x, y)
+	}
+}
+
+func TestMouseSystemContentScale(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+	engo.SetContentScale(2)
+	defer engo.SetContentScale(1)
+
+	w := &ecs.World{}
+	w.AddSystem(&CameraSystem{})
+	m := &MouseSystem{}
+	w.AddSystem(m)
+
+	// The cursor is in the units of the window, which the canvas, twice as large, doesn't change
+	basics := []ecs.BasicEntity{ecs.NewBasic(), ecs.NewBasic()}
+	world, hud := &MouseComponent{}, &MouseComponent{}
+	m.Add(&basics[0], world, &SpaceComponent{Position: engo.Point{X: 100, Y: 100}, Width: 50, Height: 50}, nil)
+	m.Add(&basics[1], hud, &SpaceComponent{Position: engo.Point{X: 300, Y: 200}, Width: 50, Height: 50}, &RenderComponent{ScreenSpace: true})
+	defer func() { engo.Input.Mouse.Action = engo.Neutral }()
+
+	engo.Input.Mouse.Button = engo.MouseButtonLeft
+	for _, test := range []struct {
+		at         engo.Point
+		world, hud bool
+	}{
+		{engo.Point{X: 120, Y: 130}, true, false},
+		{engo.Point{X: 320, Y: 230}, false, true},
+		{engo.Point{X: 240, Y: 260}, false, false},
+	} {
+		engo.Input.Mouse.X, engo.Input.Mouse.Y = test.at.X, test.at.Y
+		engo.Input.Mouse.Action = engo.Press
+		m.Update(0)
+		if world.Clicked != test.world || hud.Clicked != test.hud {
+			t.Errorf("wrong entity was clicked at %v with a content scale of 2. Clicked world: %v, HUD: %v", test.at, world.Clicked, hud.Clicked)
+		}
+		if test.world && (world.MouseX != test.at.X || world.MouseY != test.at.Y) {
+			t.Errorf("mouse position changed with a content scale of 2. Wanted: %v, got: (%v, %v)", test.at, world.MouseX, world.MouseY)
+		}
+		engo.Input.Mouse.Action = engo.Release
+		m.Update(0)
+	}
+}
+
+func TestMouseSystemInactive(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	w.AddSystem(&CameraSystem{})
+	m := &MouseSystem{TopMostOnly: true}
+	w.AddSystem(m)
+
+	space := SpaceComponent{Position: engo.Point{X: 100, Y: 100}, Width: 50, Height: 50}
+	basics := []ecs.BasicEntity{ecs.NewBasic(), ecs.NewBasic()}
+	button, cover := &MouseComponent{}, &MouseComponent{Inactive: true}
+	coverRender := &RenderComponent{}
+	coverRender.zIndex = 1
+	m.Add(&basics[0], button, &space, &RenderComponent{})
+	m.Add(&basics[1], cover, &space, coverRender)
+	defer func() { engo.Input.Mouse.Action = engo.Neutral }()
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 120, 130
+	m.Update(0)
+	if !button.Hovered {
+		t.Error("entity behind an inactive entity was covered by it")
+	}
+
+	// Once inactive, the hovered button is left, and isn't hovered or clicked while the cursor is over it
+	button.Inactive = true
+	m.Update(0)
+	if button.Hovered || !button.Leave {
+		t.Errorf("inactive entity was not left. Hovered: %v, Leave: %v", button.Hovered, button.Leave)
+	}
+	engo.Input.Mouse.Button = engo.MouseButtonLeft
+	engo.Input.Mouse.Action = engo.Press
+	m.Update(0)
+	if button.Hovered || button.Clicked || button.Leave || cover.Hovered || cover.Clicked {
+		t.Errorf("inactive entities responded to the mouse. Button hovered: %v, clicked: %v", button.Hovered, button.Clicked)
+	}
+	if !button.Inactive {
+		t.Error("entity was made active again by the MouseSystem")
+	}
+
+	button.Inactive = false
+	m.Update(0)
+	if !button.Hovered || !button.Enter || !button.Clicked {
+		t.Errorf("entity did not respond to the mouse once active again. Hovered: %v, clicked: %v", button.Hovered, button.Clicked)
+	}
+}
+
+func TestMouseSystemLastClick(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 1000, Y: 1000}}
+
+	w := &ecs.World{}
+	camera := &CameraSystem{}
+	w.AddSystem(camera)
+	m := &MouseSystem{}
+	w.AddSystem(m)
+	defer func() { engo.Input.Mouse.Action = engo.Neutral }()
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 120, 130
+	m.Update(0)
+	if _, _, ok := m.LastClick(); ok {
+		t.Error("last click was reported before clicking")
+	}
+
+	// The click is in the world, without any entity under it
+	camera.centerCam(300, 250, 1)
+	engo.Input.Mouse.Button = engo.MouseButtonRight
+	engo.Input.Mouse.Action = engo.Press
+	m.Update(0)
+	want := engo.Point{X: 220, Y: 230}
+	if at, button, ok := m.LastClick(); !ok || at != want || button != engo.MouseButtonRight {
+		t.Errorf("wrong last click. Wanted: %v with the right button, got: %v with %v (%v)", want, at, button, ok)
+	}
+
+	// It's kept until the next press
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 10, 10
+	engo.Input.Mouse.Action = engo.Release
+	m.Update(0)
+	engo.Input.Mouse.Action = engo.Move
+	m.Update(0)
+	if at, _, _ := m.LastClick(); at != want {
+		t.Errorf("last click did not persist until the next click, got: %v", at)
+	}
+	engo.Input.Mouse.Button = engo.MouseButtonLeft
+	engo.Input.Mouse.Action = engo.Press
+	m.Update(0)
+	if at, button, _ := m.LastClick(); at != (engo.Point{X: 110, Y: 110}) || button != engo.MouseButtonLeft {
+		t.Errorf("last click was not updated by the next click, got: %v with %v", at, button)
+	}
+}
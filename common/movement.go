@@ -0,0 +1,107 @@
+package common
+
+import (
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+// MovementSystemPriority is the priority of the MovementSystem. It is higher than that of the CollisionSystem, so
+// entities are moved before the CollisionSystem updates, and collisions are tested at
+// the positions they moved to. Their positions at the end of the previous update are where Continuous entities are
+// swept from.
+const MovementSystemPriority = 10
+
+// VelocityComponent gives an entity a velocity, which is used by the MovementSystem to move and rotate it. Give the
+// entity a PhysicsComponent as well to have its velocity respond to collisions with solids.
+type VelocityComponent struct {
+	// Velocity is the speed and direction in which the entity moves, in units per second
+	Velocity engo.Point
+	// Angular is the speed in which the entity rotates clockwise, in degrees per second
+	Angular float32
+}
+
+type movementEntity struct {
+	*ecs.BasicEntity
+	*VelocityComponent
+	*SpaceComponent
+}
+
+// MovementSystem moves and rotates entities according to their VelocityComponent every update, by their velocity
+// multiplied by the delta time, so they move at the same speed regardless of the frame rate. It has no forces or
+// mass; change the velocity of the entities to accelerate them, or use the PhysicsSystem for gravity. It is the only
+// system which moves entities by their velocity, including those bouncing off of solids using a PhysicsComponent.
+type MovementSystem struct {
+	entities []movementEntity
+	ids      entityIDs
+}
+
+// Priority implements the ecs.Prioritizer interface.
+func (*MovementSystem) Priority() int { return MovementSystemPriority }
+
+// Add adds an entity to the MovementSystem. To be added, the entity has to have a basic, velocity, and space
+// component.
+func (m *MovementSystem) Add(basic *ecs.BasicEntity, velocity *VelocityComponent, space *SpaceComponent) {
+	if !m.ids.add(basic.ID()) {
+		return
+	}
+	m.entities = append(m.entities, movementEntity{basic, velocity, space})
+}
+
+// AddByInterface Provides a simple way to add an entity to the system that satisfies Movable. Any entity containing, BasicEntity,VelocityComponent, and SpaceComponent anonymously, automatically does this.
+func (m *MovementSystem) AddByInterface(i ecs.Identifier) {
+	o, _ := i.(Movable)
+	m.Add(o.GetBasicEntity(), o.GetVelocityComponent(), o.GetSpaceComponent())
+}
+
+// Remove removes an entity from the MovementSystem.
+func (m *MovementSystem) Remove(basic ecs.BasicEntity) {
+	m.ids.remove(basic.ID())
+	delete := -1
+	for index, e := range m.entities {
+		if e.BasicEntity.ID() == basic.ID() {
+			delete = index
+			break
+		}
+	}
+	if delete >= 0 {
+		m.entities = append(m.entities[:delete], m.entities[delete+1:]...)
+	}
+}
+
+// Entities returns a copy of the IDs of all entities in the MovementSystem.
+func (m *MovementSystem) Entities() []uint64 {
+	ids := make([]uint64, len(m.entities))
+	for i, e := range m.entities {
+		ids[i] = e.ID()
+	}
+	return ids
+}
+
+// BasicEntities returns a copy of the BasicEntities of all entities in the MovementSystem.
+func (m *MovementSystem) BasicEntities() []ecs.BasicEntity {
+	basics := make([]ecs.BasicEntity, len(m.entities))
+	for i, e := range m.entities {
+		basics[i] = *e.BasicEntity
+	}
+	return basics
+}
+
+// Get returns the components with which the entity with the given ID was added to the MovementSystem, and false if
+// it isn't in the system, such as once it was removed.
+func (m *MovementSystem) Get(id uint64) (*VelocityComponent, *SpaceComponent, bool) {
+	for _, e := range m.entities {
+		if e.ID() == id {
+			return e.VelocityComponent, e.SpaceComponent, true
+		}
+	}
+	return nil, nil, false
+}
+
+// Update moves and rotates all entities by their velocity.
+func (m *MovementSystem) Update(dt float32) {
+	for _, e := range m.entities {
+		e.Position.X += e.Velocity.X * dt
+		e.Position.Y += e.Velocity.Y * dt
+		e.Rotation += e.Angular * dt
+	}
+}
@@ -0,0 +1,63 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+func TestMovementSystemUpdate(t *testing.T) {
+	basic := ecs.NewBasic()
+	space := &SpaceComponent{Position: engo.Point{X: 10, Y: 20}, Rotation: 90}
+	velocity := &VelocityComponent{Velocity: engo.Point{X: 40, Y: -20}, Angular: 180}
+
+	sys := &MovementSystem{}
+	sys.Add(&basic, velocity, space)
+	// Moving in one update takes as far as moving in several updates of the same total duration
+	sys.Update(0.25)
+	sys.Update(0.125)
+	sys.Update(0.125)
+
+	if expected := (engo.Point{X: 30, Y: 10}); space.Position != expected {
+		t.Errorf("entity was not moved by its velocity. Wanted: %v, got: %v", expected, space.Position)
+	}
+	if space.Rotation != 180 {
+		t.Errorf("entity was not rotated by its angular velocity. Wanted: %v, got: %v", 180, space.Rotation)
+	}
+
+	sys.Remove(basic)
+	sys.Update(1)
+	if expected := (engo.Point{X: 30, Y: 10}); space.Position != expected {
+		t.Errorf("removed entity was still moved. Wanted: %v, got: %v", expected, space.Position)
+	}
+}
+
+func TestMovementSystemBeforeCollisions(t *testing.T) {
+	engo.Mailbox = &engo.MessageManager{}
+
+	const (
+		Bullet CollisionGroup = 1 << iota
+		Wall
+	)
+
+	bulletBasic, wallBasic := ecs.NewBasic(), ecs.NewBasic()
+	bullet := &SpaceComponent{Position: engo.Point{X: 0, Y: 10}, Width: 2, Height: 2}
+	wall := &SpaceComponent{Position: engo.Point{X: 50, Y: 0}, Width: 1, Height: 100}
+
+	w := &ecs.World{}
+	collisions := &CollisionSystem{Solids: Wall}
+	movement := &MovementSystem{}
+	w.AddSystem(collisions)
+	w.AddSystem(movement)
+	collisions.Add(&bulletBasic, &CollisionComponent{Main: Wall, Group: Bullet, Continuous: true}, bullet)
+	collisions.Add(&wallBasic, &CollisionComponent{Group: Wall}, wall)
+	movement.Add(&bulletBasic, &VelocityComponent{Velocity: engo.Point{X: 10000}}, bullet)
+
+	// Within a single frame the bullet moves all the way past the wall, and the collisions are resolved afterwards,
+	// sweeping the bullet from where it was before moving.
+	w.Update(0.01)
+	if !engo.FloatEqual(bullet.Position.X, 48) || bullet.Position.Y != 10 {
+		t.Errorf("bullet did not stop in front of the wall. Wanted: %v, got: %v", engo.Point{X: 48, Y: 10}, bullet.Position)
+	}
+}
@@ -0,0 +1,278 @@
+package common
+
+import (
+	"image/color"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/engo/math"
+)
+
+// ParticleSystemPriority is the priority of the ParticleSystem, which is updated after the systems moving entities
+// around, so particles are emitted from where the entities ended up, and before the RenderSystem draws them.
+const ParticleSystemPriority = -900
+
+// ParticleComponent is an emitter of particles, such as for explosions, smoke, or sparks. Particles are emitted at the
+// position of the SpaceComponent of the entity plus Offset, after which they move on their own in world coordinates.
+// Every particle is drawn as Drawable, centered on its position, scaled to its size and tinted by its color. They're
+// drawn by the RenderSystem in the "particles" pass, on top of the world and below the HUD.
+type ParticleComponent struct {
+	// Drawable is drawn for every particle. It should be a Texture, such as a small white sprite, which is tinted
+	// into the color of the particle.
+	Drawable Drawable
+	// Offset is the position of the emitter relative to the position of the SpaceComponent
+	Offset engo.Point
+
+	// Burst is the amount of particles emitted at once, as soon as the emitter is first updated
+	Burst int
+	// Rate is the amount of particles emitted per second, for continuous emission
+	Rate float32
+	// Duration is how long particles are emitted at Rate, in seconds. They're emitted indefinitely when it's zero.
+	Duration float32
+	// OneShot removes the entity using `engo.RemoveDeferred` once it's done emitting, and all of its particles
+	// died. An emitter without a Rate is done after its Burst, and one with a Rate after its Duration.
+	OneShot bool
+	// Stopped pauses the emission at Rate, while the particles which were already emitted live on
+	Stopped bool
+	// MaxParticles is the maximum amount of particles alive at once, no more are emitted until some of them die.
+	// There's no maximum when it's zero.
+	MaxParticles int
+
+	// Lifetime is how long every particle lives, in seconds
+	Lifetime float32
+	// StartColor and EndColor are the color of a particle when it's emitted and when it dies, and it's interpolated
+	// in between. A nil color is white.
+	StartColor, EndColor color.Color
+	// StartSize and EndSize are the width of a particle in units when it's emitted and when it dies, and it's
+	// interpolated in between. The height follows from the aspect ratio of Drawable. Particles are drawn at the
+	// size of Drawable when both are zero.
+	StartSize, EndSize float32
+	// Velocity is the velocity of particles when they're emitted, in units per second
+	Velocity engo.Point
+	// Spread is the angle in degrees by which the direction of a particle randomly differs from Velocity, so a
+	// Spread of 360 emits particles in every direction.
+	Spread float32
+	// SpeedSpread is the part of the speed by which the speed of a particle randomly differs from Velocity, so a
+	// SpeedSpread of 0.5 emits particles at 50% to 150% of the speed of Velocity.
+	SpeedSpread float32
+	// Gravity is the acceleration of every particle, in units per second squared
+	Gravity engo.Point
+
+	// The particles are kept in flat slices rather than a slice of structs, which keeps updating them fast.
+	posX, posY, velX, velY, age []float32
+
+	queued  int     // particles to be emitted during the next update
+	pending float32 // the part of a particle not yet emitted at Rate
+	elapsed float32
+	started bool
+	removed bool
+}
+
+// Emit emits n particles at once during the next update of the ParticleSystem, in addition to those emitted at
+// Rate. It's useful for emitters without a Rate, such as for a puff of dust whenever a character lands.
+func (p *ParticleComponent) Emit(n int) {
+	p.queued += n
+}
+
+// Restart starts emitting again as if the emitter was just added. The particles which are alive live on.
+func (p *ParticleComponent) Restart() {
+	p.queued, p.pending, p.elapsed, p.started, p.removed = 0, 0, 0, false, false
+}
+
+// Count returns the amount of particles which are alive.
+func (p *ParticleComponent) Count() int {
+	return len(p.age)
+}
+
+// Done returns whether the emitter doesn't emit any more particles on its own, and all of its particles died.
+func (p *ParticleComponent) Done() bool {
+	return len(p.age) == 0 && p.started && p.queued == 0 &&
+		(p.Rate <= 0 || p.Stopped || (p.Duration > 0 && p.elapsed >= p.Duration))
+}
+
+// update moves the particles, removes those which died, and emits new ones at origin.
+func (p *ParticleComponent) update(dt float32, origin engo.Point) {
+	if !p.started {
+		p.started = true
+		p.queued += p.Burst
+	}
+
+	for i := 0; i < len(p.age); {
+		p.age[i] += dt
+		if p.age[i] >= p.Lifetime {
+			p.kill(i)
+			continue
+		}
+		p.velX[i] += p.Gravity.X * dt
+		p.velY[i] += p.Gravity.Y * dt
+		p.posX[i] += p.velX[i] * dt
+		p.posY[i] += p.velY[i] * dt
+		i++
+	}
+
+	if p.Rate > 0 && !p.Stopped && (p.Duration <= 0 || p.elapsed < p.Duration) {
+		p.pending += p.Rate * dt
+		n := int(p.pending)
+		p.pending -= float32(n)
+		p.queued += n
+	}
+	p.elapsed += dt
+
+	origin.Add(p.Offset)
+	for ; p.queued > 0; p.queued-- {
+		if p.MaxParticles > 0 && len(p.age) >= p.MaxParticles {
+			p.queued = 0
+			break
+		}
+		p.emit(origin)
+	}
+}
+
+// emit adds a single particle at origin, moving in a random direction within Spread of Velocity.
+func (p *ParticleComponent) emit(origin engo.Point) {
+	angle := engo.Random.Range(-p.Spread/2, p.Spread/2) * math.Pi / 180
+	speed := 1 + engo.Random.Range(-p.SpeedSpread, p.SpeedSpread)
+	sin, cos := math.Sincos(angle)
+
+	p.posX = append(p.posX, origin.X)
+	p.posY = append(p.posY, origin.Y)
+	p.velX = append(p.velX, (p.Velocity.X*cos-p.Velocity.Y*sin)*speed)
+	p.velY = append(p.velY, (p.Velocity.X*sin+p.Velocity.Y*cos)*speed)
+	p.age = append(p.age, 0)
+}
+
+// kill removes the particle at index i, by moving the last particle into its place.
+func (p *ParticleComponent) kill(i int) {
+	last := len(p.age) - 1
+	p.posX[i], p.posY[i], p.velX[i], p.velY[i], p.age[i] = p.posX[last], p.posY[last], p.velX[last], p.velY[last], p.age[last]
+	p.posX, p.posY, p.velX, p.velY, p.age = p.posX[:last], p.posY[:last], p.velX[:last], p.velY[:last], p.age[:last]
+}
+
+type particleEntity struct {
+	*ecs.BasicEntity
+	*ParticleComponent
+	*SpaceComponent
+}
+
+// ParticleSystem emits, moves and removes the particles of every ParticleComponent. When it's added to the same
+// world as the RenderSystem, the particles are drawn by the RenderSystem in the "particles" pass, which is drawn at
+// `engo.RenderPassParticles`. Particles of emitters sharing the same texture are drawn in a single batch.
+type ParticleSystem struct {
+	entities []particleEntity
+	ids      entityIDs
+
+	render RenderComponent
+	space  SpaceComponent
+	passes []engo.RenderPass
+}
+
+// Priority implements the ecs.Prioritizer interface.
+func (*ParticleSystem) Priority() int { return ParticleSystemPriority }
+
+// renderPasses returns the "particles" pass, which draws the particles. It implements the renderPasser interface.
+func (ps *ParticleSystem) renderPasses() []engo.RenderPass {
+	if ps.passes == nil {
+		ps.passes = []engo.RenderPass{{Name: "particles", Order: engo.RenderPassParticles, Draw: ps.draw}}
+	}
+	return ps.passes
+}
+
+// Add adds an entity to the ParticleSystem. To be added, the entity has to have a basic, particle, and space component.
+func (ps *ParticleSystem) Add(basic *ecs.BasicEntity, particle *ParticleComponent, space *SpaceComponent) {
+	if !ps.ids.add(basic.ID()) {
+		return
+	}
+	ps.entities = append(ps.entities, particleEntity{basic, particle, space})
+}
+
+// AddByInterface Provides a simple way to add an entity to the system that satisfies Particleable. Any entity containing, BasicEntity,ParticleComponent, and SpaceComponent anonymously, automatically does this.
+func (ps *ParticleSystem) AddByInterface(i ecs.Identifier) {
+	o, _ := i.(Particleable)
+	ps.Add(o.GetBasicEntity(), o.GetParticleComponent(), o.GetSpaceComponent())
+}
+
+// Remove removes an entity from the ParticleSystem.
+func (ps *ParticleSystem) Remove(basic ecs.BasicEntity) {
+	ps.ids.remove(basic.ID())
+	delete := -1
+	for index, e := range ps.entities {
+		if e.BasicEntity.ID() == basic.ID() {
+			delete = index
+			break
+		}
+	}
+	if delete >= 0 {
+		ps.entities = append(ps.entities[:delete], ps.entities[delete+1:]...)
+	}
+}
+
+// Get returns the components with which the entity with the given ID was added to the ParticleSystem, and false if
+// it isn't in the system, such as once it was removed, which happens by itself for one-shot emitters which are done.
+func (ps *ParticleSystem) Get(id uint64) (*ParticleComponent, *SpaceComponent, bool) {
+	for _, e := range ps.entities {
+		if e.ID() == id {
+			return e.ParticleComponent, e.SpaceComponent, true
+		}
+	}
+	return nil, nil, false
+}
+
+// Update emits and moves the particles of every emitter, and removes the one-shot emitters which are done.
+func (ps *ParticleSystem) Update(dt float32) {
+	for _, e := range ps.entities {
+		e.ParticleComponent.update(dt, e.SpaceComponent.Position)
+		if e.OneShot && !e.removed && e.Done() {
+			e.removed = true
+			engo.RemoveDeferred(*e.BasicEntity)
+		}
+	}
+}
+
+// draw draws the particles of every emitter using the DefaultShader. It's drawn by the RenderSystem as a render
+// pass, see `RenderSystem.passes`.
+func (ps *ParticleSystem) draw() {
+	DefaultShader.Pre()
+	for _, e := range ps.entities {
+		p := e.ParticleComponent
+		if p.Drawable == nil || len(p.age) == 0 || p.Drawable.Width() <= 0 {
+			continue
+		}
+
+		width := p.Drawable.Width()
+		aspect := p.Drawable.Height() / width
+		start, end := particleColor(p.StartColor), particleColor(p.EndColor)
+		ps.render.Drawable = p.Drawable
+		ps.render.Color = start
+
+		for i, age := range p.age {
+			t := age / p.Lifetime
+			size := width
+			if p.StartSize != 0 || p.EndSize != 0 {
+				size = p.StartSize + (p.EndSize-p.StartSize)*t
+			}
+			if start != end {
+				ps.render.Color = lerpNRGBA(start, end, t)
+			}
+			ps.render.Scale = engo.Point{X: size / width, Y: size / width}
+			ps.space.Position = engo.Point{X: p.posX[i] - size/2, Y: p.posY[i] - size*aspect/2}
+			DefaultShader.Draw(&ps.render, &ps.space)
+		}
+	}
+	DefaultShader.Post()
+}
+
+// particleColor returns c as a color.NRGBA, which is white if c is nil.
+func particleColor(c color.Color) color.NRGBA {
+	if c == nil {
+		return color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+	}
+	return color.NRGBAModel.Convert(c).(color.NRGBA)
+}
+
+// lerpNRGBA interpolates between a and b, where t is between 0 (a) and 1 (b).
+func lerpNRGBA(a, b color.NRGBA, t float32) color.NRGBA {
+	lerp := func(x, y uint8) uint8 {
+		return uint8(float32(x) + (float32(y)-float32(x))*t)
+	}
+	return color.NRGBA{R: lerp(a.R, b.R), G: lerp(a.G, b.G), B: lerp(a.B, b.B), A: lerp(a.A, b.A)}
+}
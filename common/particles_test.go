@@ -0,0 +1,146 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+type particleTestScene struct {
+	particles *ParticleSystem
+}
+
+func (*particleTestScene) Preload() {}
+
+func (s *particleTestScene) Setup(u engo.Updater) {
+	s.particles = &ParticleSystem{}
+	u.(*ecs.World).AddSystem(s.particles)
+}
+
+func (*particleTestScene) Type() string { return "particleTestScene" }
+
+func TestParticleBurst(t *testing.T) {
+	basic := ecs.NewBasic()
+	space := &SpaceComponent{Position: engo.Point{X: 10, Y: 20}}
+	p := &ParticleComponent{Burst: 10, Lifetime: 1, Velocity: engo.Point{X: 10}, Offset: engo.Point{X: 5}}
+
+	ps := &ParticleSystem{}
+	ps.Add(&basic, p, space)
+	ps.Update(0)
+	if p.Count() != 10 {
+		t.Fatalf("wrong amount of particles were emitted in a burst. Wanted: 10, got: %v", p.Count())
+	}
+	if p.posX[0] != 15 || p.posY[0] != 20 {
+		t.Errorf("particle was not emitted at the offset from the SpaceComponent, got: (%v, %v)", p.posX[0], p.posY[0])
+	}
+
+	// Once emitted, particles move on their own.
+	space.Position = engo.Point{}
+	ps.Update(0.5)
+	if p.Count() != 10 || p.posX[0] != 20 {
+		t.Errorf("particles did not move by their velocity. Wanted 10 particles at x 20, got %v at %v", p.Count(), p.posX[0])
+	}
+	ps.Update(0.6)
+	if p.Count() != 0 {
+		t.Errorf("particles outlived their Lifetime, %v are alive", p.Count())
+	}
+	if !p.Done() {
+		t.Error("emitter without a Rate is not done after its burst")
+	}
+
+	p.Emit(3)
+	ps.Update(0.1)
+	if p.Count() != 3 {
+		t.Errorf("wrong amount of particles were emitted. Wanted: 3, got: %v", p.Count())
+	}
+}
+
+func TestParticleRate(t *testing.T) {
+	basic := ecs.NewBasic()
+	p := &ParticleComponent{Rate: 10, Duration: 1.5, Lifetime: 10, MaxParticles: 8}
+
+	ps := &ParticleSystem{}
+	ps.Add(&basic, p, &SpaceComponent{})
+	ps.Update(0.25)
+	if p.Count() != 2 {
+		t.Errorf("wrong amount of particles were emitted at Rate. Wanted: 2, got: %v", p.Count())
+	}
+	ps.Update(0.25)
+	if p.Count() != 5 {
+		t.Errorf("parts of particles were not carried over to the next update. Wanted: 5, got: %v", p.Count())
+	}
+
+	p.Stopped = true
+	ps.Update(0.25)
+	if p.Count() != 5 {
+		t.Errorf("particles were emitted while the emitter was stopped, got: %v", p.Count())
+	}
+	p.Stopped = false
+	ps.Update(0.25)
+	if p.Count() != 7 {
+		t.Errorf("wrong amount of particles were emitted after restarting. Wanted: 7, got: %v", p.Count())
+	}
+
+	ps.Update(0.25)
+	if p.Count() != 8 {
+		t.Errorf("more than MaxParticles particles are alive, got: %v", p.Count())
+	}
+	p.MaxParticles = 0
+	ps.Update(0.25)
+	if p.Count() != 10 {
+		t.Errorf("wrong amount of particles were emitted without a maximum. Wanted: 10, got: %v", p.Count())
+	}
+	ps.Update(0.25)
+	if p.Count() != 10 {
+		t.Errorf("particles were emitted after the Duration, got: %v", p.Count())
+	}
+	if p.Done() {
+		t.Error("emitter is done while its particles are alive")
+	}
+}
+
+func TestParticleMovement(t *testing.T) {
+	basic := ecs.NewBasic()
+	p := &ParticleComponent{Burst: 50, Lifetime: 10, Velocity: engo.Point{X: 100}, Spread: 90, SpeedSpread: 0.5, Gravity: engo.Point{Y: 10}}
+
+	ps := &ParticleSystem{}
+	ps.Add(&basic, p, &SpaceComponent{})
+	ps.Update(0)
+	for i := range p.age {
+		velocity := engo.Point{X: p.velX[i], Y: p.velY[i]}
+		speed := velocity.PointDistance(engo.Point{})
+		if p.velX[i] < 0 || speed < 50-1e-3 || speed > 150+1e-3 {
+			t.Fatalf("particle was emitted outside of the Spread, with velocity (%v, %v)", p.velX[i], p.velY[i])
+		}
+	}
+
+	vy := p.velY[0]
+	ps.Update(0.5)
+	if actual := p.velY[0] - vy; actual < 5-1e-3 || actual > 5+1e-3 {
+		t.Errorf("particle was not accelerated by Gravity. Wanted: 5, got: %v", actual)
+	}
+}
+
+func TestParticleOneShot(t *testing.T) {
+	scene := &particleTestScene{}
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, scene)
+	engo.Time = engo.NewClock()
+
+	basic := ecs.NewBasic()
+	scene.particles.Add(&basic, &ParticleComponent{Burst: 5, Lifetime: 0.5, OneShot: true}, &SpaceComponent{})
+	scene.particles.Update(0.1)
+	engo.RunIteration()
+	if len(scene.particles.entities) != 1 {
+		t.Fatal("one-shot emitter was removed while its particles are alive")
+	}
+
+	scene.particles.Update(1)
+	engo.RunIteration()
+	if len(scene.particles.entities) != 0 {
+		t.Error("one-shot emitter was not removed after its particles died")
+	}
+}
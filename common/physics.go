@@ -0,0 +1,136 @@
+package common
+
+import (
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/engo/math"
+)
+
+// PhysicsSystemPriority is the priority of the PhysicsSystem. It is higher than that of the MovementSystem, so
+// entities are accelerated before they are moved by their new velocity.
+const PhysicsSystemPriority = MovementSystemPriority + 1
+
+// PhysicsComponent describes how an entity responds to collisions. Whenever the CollisionSystem pushes the entity out
+// of a solid, the velocity of its VelocityComponent is changed according to Restitution and Friction, while the
+// MovementSystem keeps moving the entity by that velocity. This is kept simple on purpose: entities have no mass,
+// and collisions do not make them rotate.
+type PhysicsComponent struct {
+	// Restitution is how bouncy the entity is. The part of the velocity going into a solid is reflected, and
+	// multiplied by Restitution. At 0 the entity stops moving into the solid, at 1 it bounces back at full speed.
+	Restitution float32
+	// Friction is the part of the velocity along the surface of a solid which is lost whenever the entity collides
+	// with it, between 0 (sliding without slowing down) and 1 (stopping immediately).
+	Friction float32
+}
+
+// respond changes the velocity in response to a collision with a solid, in which the entity was pushed out of the
+// solid in the direction of normal.
+func (p *PhysicsComponent) respond(velocity *VelocityComponent, normal engo.Point) {
+	length := normal.PointDistance(engo.Point{})
+	if length == 0 {
+		return
+	}
+	normal.MultiplyScalar(1 / length)
+
+	along := engo.DotProduct(velocity.Velocity, normal)
+	if along >= 0 {
+		return // already moving away from the solid
+	}
+
+	into := normal
+	into.MultiplyScalar(along)
+	tangent := velocity.Velocity
+	tangent.Subtract(into)
+
+	into.MultiplyScalar(-p.Restitution)
+	tangent.MultiplyScalar(1 - math.Clamp(p.Friction, 0, 1))
+	velocity.Velocity = into
+	velocity.Velocity.Add(tangent)
+}
+
+type physicsEntity struct {
+	*ecs.BasicEntity
+	*PhysicsComponent
+	*VelocityComponent
+}
+
+// PhysicsSystem accelerates entities by Gravity, by changing the velocity of their VelocityComponent. It doesn't move
+// them; that's left to the MovementSystem, so an entity is only ever moved once per update. Add the same entities to
+// the CollisionSystem, using `CollisionSystem.AddWithPhysics`, to have them bounce off of and slide along solids.
+type PhysicsSystem struct {
+	// Gravity is the acceleration of every entity, in units per second squared
+	Gravity engo.Point
+
+	entities []physicsEntity
+	ids      entityIDs
+}
+
+// Priority implements the ecs.Prioritizer interface.
+func (*PhysicsSystem) Priority() int { return PhysicsSystemPriority }
+
+// Add adds an entity to the PhysicsSystem. To be added, the entity has to have a basic, physics, and velocity
+// component.
+func (p *PhysicsSystem) Add(basic *ecs.BasicEntity, physics *PhysicsComponent, velocity *VelocityComponent) {
+	if !p.ids.add(basic.ID()) {
+		return
+	}
+	p.entities = append(p.entities, physicsEntity{basic, physics, velocity})
+}
+
+// AddByInterface Provides a simple way to add an entity to the system that satisfies Physicsable. Any entity containing, BasicEntity,PhysicsComponent, and VelocityComponent anonymously, automatically does this.
+func (p *PhysicsSystem) AddByInterface(i ecs.Identifier) {
+	o, _ := i.(Physicsable)
+	p.Add(o.GetBasicEntity(), o.GetPhysicsComponent(), o.GetVelocityComponent())
+}
+
+// Remove removes an entity from the PhysicsSystem.
+func (p *PhysicsSystem) Remove(basic ecs.BasicEntity) {
+	p.ids.remove(basic.ID())
+	delete := -1
+	for index, e := range p.entities {
+		if e.BasicEntity.ID() == basic.ID() {
+			delete = index
+			break
+		}
+	}
+	if delete >= 0 {
+		p.entities = append(p.entities[:delete], p.entities[delete+1:]...)
+	}
+}
+
+// Entities returns a copy of the IDs of all entities in the PhysicsSystem.
+func (p *PhysicsSystem) Entities() []uint64 {
+	ids := make([]uint64, len(p.entities))
+	for i, e := range p.entities {
+		ids[i] = e.ID()
+	}
+	return ids
+}
+
+// BasicEntities returns a copy of the BasicEntities of all entities in the PhysicsSystem.
+func (p *PhysicsSystem) BasicEntities() []ecs.BasicEntity {
+	basics := make([]ecs.BasicEntity, len(p.entities))
+	for i, e := range p.entities {
+		basics[i] = *e.BasicEntity
+	}
+	return basics
+}
+
+// Get returns the components with which the entity with the given ID was added to the PhysicsSystem, and false if
+// it isn't in the system, such as once it was removed.
+func (p *PhysicsSystem) Get(id uint64) (*PhysicsComponent, *VelocityComponent, bool) {
+	for _, e := range p.entities {
+		if e.ID() == id {
+			return e.PhysicsComponent, e.VelocityComponent, true
+		}
+	}
+	return nil, nil, false
+}
+
+// Update accelerates all entities by Gravity.
+func (p *PhysicsSystem) Update(dt float32) {
+	for _, e := range p.entities {
+		e.Velocity.X += p.Gravity.X * dt
+		e.Velocity.Y += p.Gravity.Y * dt
+	}
+}
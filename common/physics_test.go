@@ -0,0 +1,90 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+func TestPhysicsRestitution(t *testing.T) {
+	engo.Mailbox = &engo.MessageManager{}
+
+	const Floor CollisionGroup = 1
+
+	ballBasic, floorBasic := ecs.NewBasic(), ecs.NewBasic()
+	ball := &SpaceComponent{Width: 10, Height: 10}
+	ballPhysics := &PhysicsComponent{Restitution: 0.5}
+	ballVelocity := &VelocityComponent{}
+	floor := &SpaceComponent{Position: engo.Point{X: -100, Y: 100}, Width: 200, Height: 20}
+
+	physics := &PhysicsSystem{Gravity: engo.Point{Y: 200}}
+	physics.Add(&ballBasic, ballPhysics, ballVelocity)
+	movement := &MovementSystem{}
+	movement.Add(&ballBasic, ballVelocity, ball)
+	collisions := &CollisionSystem{Solids: Floor}
+	collisions.AddWithPhysics(&ballBasic, &CollisionComponent{Main: Floor}, ball, ballPhysics, ballVelocity)
+	collisions.Add(&floorBasic, &CollisionComponent{Group: Floor}, floor)
+
+	// The ball is dropped from 90 units above the floor, and is tracked until it starts falling after its first bounce.
+	dropped := floor.Position.Y - ball.Height
+	bounced := false
+	highest := dropped
+	for i := 0; i < 600; i++ {
+		physics.Update(1.0 / 60)
+		movement.Update(1.0 / 60)
+		collisions.Update(1.0 / 60)
+
+		if !bounced {
+			bounced = ballVelocity.Velocity.Y < 0
+			continue
+		}
+		if ballVelocity.Velocity.Y > 0 {
+			break
+		}
+		if ball.Position.Y < highest {
+			highest = ball.Position.Y
+		}
+	}
+
+	if !bounced {
+		t.Fatal("ball did not bounce off the floor")
+	}
+	if height := dropped - highest; height <= 0 || height >= dropped/2 {
+		t.Errorf("ball did not bounce to a reduced height. Wanted: between 0 and %v, got: %v", dropped/2, height)
+	}
+}
+
+func TestPhysicsFriction(t *testing.T) {
+	p := &PhysicsComponent{Friction: 0.25}
+	v := &VelocityComponent{Velocity: engo.Point{X: 10, Y: 5}}
+	// The entity is pushed up, out of the floor it is sliding along
+	p.respond(v, engo.Point{Y: -2})
+
+	if expected := (engo.Point{X: 7.5, Y: 0}); v.Velocity != expected {
+		t.Errorf("velocity was not reduced by friction. Wanted: %v, got: %v", expected, v.Velocity)
+	}
+}
+
+// TestPhysicsSystemDoesNotMove makes sure only the MovementSystem moves entities, so entities which are in both
+// systems are moved once per update.
+func TestPhysicsSystemDoesNotMove(t *testing.T) {
+	basic := ecs.NewBasic()
+	space := &SpaceComponent{}
+	velocity := &VelocityComponent{Velocity: engo.Point{X: 10}}
+
+	physics := &PhysicsSystem{Gravity: engo.Point{Y: 10}}
+	physics.Add(&basic, &PhysicsComponent{}, velocity)
+	movement := &MovementSystem{}
+	movement.Add(&basic, velocity, space)
+
+	w := &ecs.World{}
+	w.AddSystem(movement)
+	w.AddSystem(physics)
+	w.Update(1)
+
+	// Gravity is applied before moving, regardless of the order in which the systems were added.
+	if expected := (engo.Point{X: 10, Y: 10}); space.Position != expected {
+		t.Errorf("entity was not moved once by its accelerated velocity. Wanted: %v, got: %v", expected, space.Position)
+	}
+}
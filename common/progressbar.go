@@ -0,0 +1,275 @@
+package common
+
+import (
+	"image/color"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/engo/math"
+)
+
+// defaultProgressFillColor is the color of the fill of a ProgressBarComponent whenever its Fill isn't set.
+var defaultProgressFillColor = color.NRGBA{R: 0, G: 200, B: 0, A: 255}
+
+// ProgressDirection is the direction in which a ProgressBarComponent fills up.
+type ProgressDirection uint8
+
+const (
+	// ProgressLeftToRight fills the bar from its left edge
+	ProgressLeftToRight ProgressDirection = iota
+	// ProgressRightToLeft fills the bar from its right edge
+	ProgressRightToLeft
+	// ProgressBottomToTop fills the bar from its bottom edge
+	ProgressBottomToTop
+	// ProgressTopToBottom fills the bar from its top edge
+	ProgressTopToBottom
+)
+
+// ProgressBarComponent draws a bar showing a value between 0 and 1, such as a health bar above a character or the
+// progress of a loading screen. It's positioned relative to the SpaceComponent of the entity and follows it around,
+// without rotating along with it. It doesn't depend on what it shows: set Value every frame, such as to the hit
+// points of the HealthComponent of the entity using Set.
+type ProgressBarComponent struct {
+	// Value is how far the bar is filled, from 0 up to 1
+	Value float32
+	// Direction is the direction in which the bar fills up
+	Direction ProgressDirection
+
+	// Width and Height are the size of the bar. They're the width and height of the AABB of the SpaceComponent when
+	// zero, so a bar without a size covers the entity.
+	Width, Height float32
+	// Offset is the position of the center of the top edge of the bar, relative to the center of the top edge of the
+	// AABB of the SpaceComponent. A health bar is drawn above the entity using a negative Offset.Y.
+	Offset engo.Point
+
+	// Fill is the color of the filled part of the bar, it's green when nil
+	Fill color.Color
+	// Background is the color of the rest of the bar, which isn't drawn when nil
+	Background color.Color
+	// BorderWidth is the width of the border drawn along the inside of the edges of the bar
+	BorderWidth float32
+	// BorderColor is the color of the border, it's black when nil
+	BorderColor color.Color
+
+	// Hidden stops drawing the bar
+	Hidden bool
+	// ScreenSpace indicates that the SpaceComponent, and the bar, are in screen coordinates rather than in the world,
+	// such as for the bar of a loading screen on the HUD
+	ScreenSpace bool
+	// FaceCamera draws a bar in the world on the HUD instead, at the position of the entity on screen, so it stays
+	// upright and at the same size on screen while the camera rotates and zooms. Width, Height, Offset and
+	// BorderWidth are then in screen coordinates.
+	FaceCamera bool
+}
+
+// Set sets Value to current out of max, such as the current and maximum hit points of a HealthComponent.
+func (p *ProgressBarComponent) Set(current, max float32) {
+	if max <= 0 {
+		p.Value = 0
+		return
+	}
+	p.Value = current / max
+}
+
+type progressBarEntity struct {
+	*ecs.BasicEntity
+	*ProgressBarComponent
+	*SpaceComponent
+}
+
+// ProgressBarSystem draws the bars of ProgressBarComponents. When it's added to the same world as the RenderSystem,
+// the bars are drawn by the RenderSystem at the positions their entities ended up at during the frame, in the
+// "progressbars" pass for bars in the world, on top of the shapes of a ShapeSystem, and the "hudprogressbars" pass for
+// those on the HUD.
+type ProgressBarSystem struct {
+	entities []progressBarEntity
+	ids      entityIDs
+	world    *ecs.World
+	camera   *CameraSystem
+
+	shapes, hudShapes ShapeComponent
+	drawer            shapeDrawer
+	passes            []engo.RenderPass
+}
+
+// New initializes the ProgressBarSystem.
+func (p *ProgressBarSystem) New(w *ecs.World) {
+	p.world = w
+}
+
+// Add adds an entity to the ProgressBarSystem. To be added, the entity has to have a basic, progress bar and space
+// component.
+func (p *ProgressBarSystem) Add(basic *ecs.BasicEntity, bar *ProgressBarComponent, space *SpaceComponent) {
+	if !p.ids.add(basic.ID()) {
+		return
+	}
+	p.entities = append(p.entities, progressBarEntity{basic, bar, space})
+}
+
+// AddByInterface Provides a simple way to add an entity to the system that satisfies ProgressBarable. Any entity containing, BasicEntity, ProgressBarComponent and SpaceComponent anonymously, automatically does this.
+func (p *ProgressBarSystem) AddByInterface(i ecs.Identifier) {
+	o, _ := i.(ProgressBarable)
+	p.Add(o.GetBasicEntity(), o.GetProgressBarComponent(), o.GetSpaceComponent())
+}
+
+// Remove removes an entity from the ProgressBarSystem.
+func (p *ProgressBarSystem) Remove(basic ecs.BasicEntity) {
+	p.ids.remove(basic.ID())
+	delete := -1
+	for index, e := range p.entities {
+		if e.BasicEntity.ID() == basic.ID() {
+			delete = index
+			break
+		}
+	}
+	if delete >= 0 {
+		p.entities = append(p.entities[:delete], p.entities[delete+1:]...)
+	}
+}
+
+// Update does nothing, since the bars are drawn by the RenderSystem at the positions their entities ended up at. It
+// implements the ecs.System interface.
+func (*ProgressBarSystem) Update(float32) {}
+
+// build adds the shapes of all bars to the shapes in the world and on the HUD.
+func (p *ProgressBarSystem) build() {
+	p.shapes.Clear()
+	p.hudShapes.Clear()
+	camera := p.findCamera()
+
+	for _, e := range p.entities {
+		bar := e.ProgressBarComponent
+		if bar.Hidden {
+			continue
+		}
+		aabb := e.SpaceComponent.AABB()
+		top := engo.Point{X: (aabb.Min.X + aabb.Max.X) / 2, Y: aabb.Min.Y}
+		width, height := aabb.Max.X-aabb.Min.X, aabb.Max.Y-aabb.Min.Y
+
+		shapes := &p.shapes
+		if bar.ScreenSpace {
+			shapes = &p.hudShapes
+		} else if bar.FaceCamera && camera != nil {
+			shapes = &p.hudShapes
+			// The size of the entity on screen, for bars without a size of their own
+			left, right := p.toScreen(aabb.Min), p.toScreen(engo.Point{X: aabb.Max.X, Y: aabb.Min.Y})
+			bottom := p.toScreen(engo.Point{X: aabb.Min.X, Y: aabb.Max.Y})
+			width, height = left.PointDistance(right), left.PointDistance(bottom)
+			top = p.toScreen(top)
+		}
+		if bar.Width > 0 {
+			width = bar.Width
+		}
+		if bar.Height > 0 {
+			height = bar.Height
+		}
+
+		min := engo.Point{X: top.X + bar.Offset.X - width/2, Y: top.Y + bar.Offset.Y}
+		bar.shapes(shapes, engo.AABB{Min: min, Max: engo.Point{X: min.X + width, Y: min.Y + height}})
+	}
+}
+
+// shapes adds the shapes of the bar covering the given rectangle.
+func (p *ProgressBarComponent) shapes(shapes *ShapeComponent, rect engo.AABB) {
+	if p.Background != nil {
+		shapes.FillRect(rect, p.Background)
+	}
+
+	b := math.Max(p.BorderWidth, 0)
+	inner := engo.AABB{
+		Min: engo.Point{X: rect.Min.X + b, Y: rect.Min.Y + b},
+		Max: engo.Point{X: rect.Max.X - b, Y: rect.Max.Y - b},
+	}
+	if inner.Max.X > inner.Min.X && inner.Max.Y > inner.Min.Y {
+		if fill := p.fillRect(inner); fill.Max.X > fill.Min.X && fill.Max.Y > fill.Min.Y {
+			c := p.Fill
+			if c == nil {
+				c = defaultProgressFillColor
+			}
+			shapes.FillRect(fill, c)
+		}
+	}
+
+	if b > 0 {
+		c := p.BorderColor
+		if c == nil {
+			c = color.Black
+		}
+		// The border is centered on the edges of the rectangle which lies half its width inside of the bar
+		shapes.StrokeRect(engo.AABB{
+			Min: engo.Point{X: rect.Min.X + b/2, Y: rect.Min.Y + b/2},
+			Max: engo.Point{X: rect.Max.X - b/2, Y: rect.Max.Y - b/2},
+		}, b, c)
+	}
+}
+
+// fillRect returns the part of the given rectangle which is filled, according to the Value and Direction.
+func (p *ProgressBarComponent) fillRect(rect engo.AABB) engo.AABB {
+	value := math.Clamp(p.Value, 0, 1)
+	width, height := rect.Max.X-rect.Min.X, rect.Max.Y-rect.Min.Y
+	switch p.Direction {
+	case ProgressRightToLeft:
+		rect.Min.X = rect.Max.X - width*value
+	case ProgressBottomToTop:
+		rect.Min.Y = rect.Max.Y - height*value
+	case ProgressTopToBottom:
+		rect.Max.Y = rect.Min.Y + height*value
+	default:
+		rect.Max.X = rect.Min.X + width*value
+	}
+	return rect
+}
+
+// toScreen translates a point in the world to the same point on the HUD.
+func (p *ProgressBarSystem) toScreen(point engo.Point) engo.Point {
+	x, y := p.camera.WorldToScreen(point.X, point.Y)
+	return engo.Point{X: x, Y: y}
+}
+
+// findCamera looks up the CameraSystem of the world, and returns it, or nil if there is none.
+func (p *ProgressBarSystem) findCamera() *CameraSystem {
+	if p.camera == nil && p.world != nil {
+		for _, system := range p.world.Systems() {
+			if sys, ok := system.(*CameraSystem); ok {
+				p.camera = sys
+				break
+			}
+		}
+	}
+	return p.camera
+}
+
+// renderPasses returns the "progressbars" pass, which draws the bars in the world, and the "hudprogressbars" pass,
+// which draws those on the HUD. It implements the renderPasser interface.
+func (p *ProgressBarSystem) renderPasses() []engo.RenderPass {
+	if p.passes == nil {
+		p.passes = []engo.RenderPass{
+			{Name: "progressbars", Order: engo.RenderPassShapes, Draw: p.drawWorld},
+			{Name: "hudprogressbars", Order: engo.RenderPassHUDShapes, Draw: p.drawHUD},
+		}
+	}
+	return p.passes
+}
+
+// drawWorld draws the bars in the world. It's drawn by the RenderSystem as a render pass, see
+// `RenderSystem.passes`.
+func (p *ProgressBarSystem) drawWorld() {
+	// The bars are built once per frame, right before the first of the passes drawing them
+	p.build()
+	if len(p.shapes.batches) == 0 {
+		return
+	}
+	LegacyShader.Pre()
+	p.drawer.draw(LegacyShader, &p.shapes, engo.Point{})
+	LegacyShader.Post()
+}
+
+// drawHUD draws the bars on the HUD. It's drawn by the RenderSystem as a render pass, see `RenderSystem.passes`.
+func (p *ProgressBarSystem) drawHUD() {
+	if len(p.hudShapes.batches) == 0 {
+		return
+	}
+	LegacyHUDShader.Pre()
+	p.drawer.draw(LegacyHUDShader, &p.hudShapes, engo.Point{})
+	LegacyHUDShader.Post()
+}
@@ -0,0 +1,168 @@
+package common
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+func TestProgressBarComponentFillRect(t *testing.T) {
+	rect := engo.AABB{Max: engo.Point{X: 10, Y: 20}}
+	tests := []struct {
+		direction ProgressDirection
+		value     float32
+		want      engo.AABB
+	}{
+		{ProgressLeftToRight, 0.5, engo.AABB{Max: engo.Point{X: 5, Y: 20}}},
+		{ProgressRightToLeft, 0.5, engo.AABB{Min: engo.Point{X: 5}, Max: engo.Point{X: 10, Y: 20}}},
+		{ProgressBottomToTop, 0.25, engo.AABB{Min: engo.Point{Y: 15}, Max: engo.Point{X: 10, Y: 20}}},
+		{ProgressTopToBottom, 0.25, engo.AABB{Max: engo.Point{X: 10, Y: 5}}},
+		{ProgressLeftToRight, 2, rect},
+		{ProgressLeftToRight, -1, engo.AABB{Max: engo.Point{Y: 20}}},
+	}
+	for _, test := range tests {
+		bar := &ProgressBarComponent{Value: test.value, Direction: test.direction}
+		if got := bar.fillRect(rect); got != test.want {
+			t.Errorf("bar filled in direction %v up to %v was wrong. Wanted: %v, got: %v", test.direction, test.value, test.want, got)
+		}
+	}
+}
+
+func TestProgressBarComponentSet(t *testing.T) {
+	bar := &ProgressBarComponent{}
+	bar.Set(30, 40)
+	if bar.Value != 0.75 {
+		t.Errorf("value was not set to current out of max. Wanted: 0.75, got: %v", bar.Value)
+	}
+	bar.Set(30, 0)
+	if bar.Value != 0 {
+		t.Errorf("value was not reset without a max, got: %v", bar.Value)
+	}
+}
+
+func TestProgressBarSystem(t *testing.T) {
+	type progressBarTestEntity struct {
+		ecs.BasicEntity
+		ProgressBarComponent
+		SpaceComponent
+	}
+
+	w := &ecs.World{}
+	p := &ProgressBarSystem{}
+	var progressBarable *ProgressBarable
+	w.AddSystemInterface(p, progressBarable, nil)
+
+	red := color.NRGBA{R: 255, A: 255}
+	world := &progressBarTestEntity{
+		BasicEntity:          ecs.NewBasic(),
+		ProgressBarComponent: ProgressBarComponent{Value: 0.5, Height: 4, Offset: engo.Point{Y: -6}, Fill: red},
+		SpaceComponent:       SpaceComponent{Position: engo.Point{X: 100, Y: 100}, Width: 20, Height: 30},
+	}
+	hud := &progressBarTestEntity{
+		BasicEntity:          ecs.NewBasic(),
+		ProgressBarComponent: ProgressBarComponent{Value: 1, Background: color.White, BorderWidth: 2},
+		SpaceComponent:       SpaceComponent{Width: 100, Height: 10},
+	}
+	hud.ScreenSpace = true
+	w.AddEntity(world)
+	w.AddEntity(hud)
+	if len(p.entities) != 2 {
+		t.Fatalf("entities were not added by interface, got %v", len(p.entities))
+	}
+
+	// The bar is as wide as the entity, and above it by its offset
+	p.build()
+	if len(p.shapes.batches) != 1 || p.shapes.batches[0].color != red {
+		t.Fatalf("fill of the bar in the world was not drawn in its color, got %v batches", len(p.shapes.batches))
+	}
+	for _, point := range []engo.Point{{X: 100, Y: 94}, {X: 110, Y: 94}, {X: 110, Y: 98}, {X: 100, Y: 98}} {
+		if !hasPoint(p.shapes.batches[0].points, point) {
+			t.Errorf("corner %v of the half filled bar is missing", point)
+		}
+	}
+
+	// The background, fill and border are drawn on the HUD, with the fill inside of the border
+	if len(p.hudShapes.batches) != 3 {
+		t.Fatalf("background, fill and border of the bar on the HUD were not drawn, got %v batches", len(p.hudShapes.batches))
+	}
+	if c := p.hudShapes.batches[1].color; c != defaultProgressFillColor {
+		t.Errorf("fill was not drawn in the default color, got %v", c)
+	}
+	for _, point := range []engo.Point{{X: 2, Y: 2}, {X: 98, Y: 8}} {
+		if !hasPoint(p.hudShapes.batches[1].points, point) {
+			t.Errorf("corner %v of the fill inside of the border is missing", point)
+		}
+	}
+	for _, point := range []engo.Point{{X: 0, Y: 0}, {X: 100, Y: 10}, {X: 2, Y: 2}, {X: 98, Y: 8}} {
+		if !hasPoint(p.hudShapes.batches[2].points, point) {
+			t.Errorf("corner %v of the border along the inside of the bar is missing", point)
+		}
+	}
+
+	// Hidden bars and removed entities aren't drawn
+	world.ProgressBarComponent.Hidden = true
+	w.RemoveEntity(hud.BasicEntity)
+	p.build()
+	if len(p.entities) != 1 || len(p.shapes.batches) != 0 || len(p.hudShapes.batches) != 0 {
+		t.Errorf("hidden or removed bars were drawn")
+	}
+
+	rs := &RenderSystem{world: w}
+	var names []string
+	for _, pass := range rs.passes() {
+		names = append(names, pass.Name)
+	}
+	want := []string{"world", "progressbars", "hud", "hudprogressbars", "transition"}
+	if len(names) != len(want) {
+		t.Fatalf("progress bar passes were not drawn. Wanted: %v, got: %v", want, names)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("progress bar passes were not drawn in order. Wanted: %v, got: %v", want, names)
+			break
+		}
+	}
+}
+
+func TestProgressBarSystemFaceCamera(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &testScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	camera := &CameraSystem{}
+	w.AddSystem(camera)
+	p := &ProgressBarSystem{}
+	w.AddSystem(p)
+
+	basic := ecs.NewBasic()
+	bar := &ProgressBarComponent{Value: 1, Height: 4, FaceCamera: true}
+	p.Add(&basic, bar, &SpaceComponent{Position: engo.Point{X: 200, Y: 150}, Width: 40, Height: 20})
+
+	p.build()
+	if len(p.shapes.batches) != 0 || len(p.hudShapes.batches) != 1 {
+		t.Fatal("bar facing the camera was not drawn on the HUD")
+	}
+	points := p.hudShapes.batches[0].points
+	for _, point := range []engo.Point{{X: 200, Y: 150}, {X: 240, Y: 154}} {
+		if !hasPoint(points, point) {
+			t.Errorf("corner %v of the bar on screen is missing", point)
+		}
+	}
+
+	// Zooming out halves the width of the entity on screen, but not the height of the bar
+	camera.zoomTo(2)
+	p.build()
+	points = p.hudShapes.batches[0].points
+	for _, point := range []engo.Point{{X: 200, Y: 150}, {X: 220, Y: 154}} {
+		if !hasPoint(points, point) {
+			t.Errorf("corner %v of the bar on screen after zooming out is missing, got: %v", point, points)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/EngoEngine/ecs"
 	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/engo/math"
 	"github.com/EngoEngine/gl"
 )
 
@@ -47,6 +48,18 @@ const (
 	MirroredRepeat
 )
 
+// TextureRepeatAxes are the axes along which a texture is repeated, see RenderComponent.RepeatAxes.
+type TextureRepeatAxes uint8
+
+const (
+	// RepeatXY repeats the texture both horizontally and vertically.
+	RepeatXY TextureRepeatAxes = iota
+	// RepeatX only repeats the texture horizontally, it's drawn at its own height.
+	RepeatX
+	// RepeatY only repeats the texture vertically, it's drawn at its own width.
+	RepeatY
+)
+
 // ZoomFilter is a filter used when zooming in or out of a texture.
 type ZoomFilter uint8
 
@@ -59,40 +72,66 @@ const (
 
 // RenderComponent is the component needed to render an entity.
 type RenderComponent struct {
-	// Hidden is used to prevent drawing by OpenGL
+	// Hidden skips drawing the entity, while keeping it in the RenderSystem along with its z-index, so it's drawn in
+	// the same order once it's shown again. It's much cheaper than removing and adding the entity, for things like
+	// blinking. The MouseSystem ignores hidden entities, unless `MouseComponent.HitWhenHidden` is set.
 	Hidden bool
+	// ScreenSpace draws the entity relative to the screen rather than the world, like the HUD: its SpaceComponent is
+	// in screen coordinates, unaffected by the camera. The MouseSystem checks the cursor against such entities in
+	// screen coordinates as well. Using the HUDShader sets it, though any entity can be drawn in screen space with
+	// the built-in shaders. Entities with a custom shader are drawn along with the HUD when it's set.
+	ScreenSpace bool
 	// Scale is the scale at which to render, in the X and Y axis. Not defining Scale, will default to engo.Point{1, 1}
 	Scale engo.Point
-	// Color defines how much of the color-components of the texture get used
+	// Color defines how much of the color-components of the texture get used. It's multiplied with the color of the
+	// texture, so it can be used to tint an entity, and its alpha is multiplied with that of the texture. Leaving it
+	// nil uses white, which draws the texture as it is.
 	Color color.Color
 	// Drawable refers to the Texture that should be drawn
 	Drawable Drawable
+	// FlipX and FlipY mirror the texture horizontally and vertically, such as to have a character face the other
+	// way. Unlike a negative Scale, only the texture is flipped, so the SpaceComponent of the entity keeps the same
+	// size and position, and with it the area used for mouse and collision checks. Shapes and Text aren't flipped.
+	FlipX, FlipY bool
 	// Repeat defines how to repeat the Texture if the SpaceComponent of the entity
 	// is larger than the texture itself, after applying scale. Defaults to NoRepeat
 	// which allows the texture to draw entirely without regard to th SpaceComponent
 	// Do not set to anything other than NoRepeat for textures in a sprite sheet.
 	// This does not yet work with sprite sheets.
 	Repeat TextureRepeating
+	// RepeatAxes are the axes along which the texture tiles to fill the SpaceComponent while Repeat is set, such as
+	// for a large floor or background drawn by a single entity. Whenever the SpaceComponent isn't a multiple of the
+	// size of the texture, the last tile is cut off. Defaults to RepeatXY.
+	RepeatAxes TextureRepeatAxes
 	// Buffer represents the buffer object itself
 	// Avoid using it unless your are writing a custom shader
 	Buffer *gl.Buffer
 	// BufferContent contains the buffer data
 	// Avoid using it unless your are writing a custom shader
 	BufferContent []float32
+	// Uniforms are the uniforms of the entity, for custom shaders which draw entities with their own uniform values.
+	// The built-in shaders ignore them.
+	Uniforms Uniforms
 	// StartZIndex defines the initial Z-Index. Z-Index defines the order which the content is drawn to the
 	// screen. Higher z-indices are drawn on top of lower ones. Beware that you must use `SetZIndex` function to change
 	// the Z-Index.
 	StartZIndex float32
 
 	magFilter, minFilter ZoomFilter
+	// magFilterSet and minFilterSet indicate whether the filters were set, rather than using those of the texture
+	magFilterSet, minFilterSet bool
 
-	shader Shader
-	zIndex float32
+	shader       Shader
+	zIndex       float32
+	transparency float32 // 1 - the opacity, so the zero value is opaque
 }
 
-// SetShader sets the shader used by the RenderComponent.
+// SetShader sets the shader used by the RenderComponent. Setting one of the HUD shaders sets ScreenSpace.
 func (r *RenderComponent) SetShader(s Shader) {
 	r.shader = s
+	if isHUDShader(s) {
+		r.ScreenSpace = true
+	}
 	engo.Mailbox.Dispatch(&renderChangeMessage{})
 }
 
@@ -130,22 +169,65 @@ func (r *RenderComponent) SetZIndex(index float32) {
 	engo.Mailbox.Dispatch(&renderChangeMessage{})
 }
 
-// SetMinFilter sets the ZoomFilter used for minimizing the RenderComponent
+// ZIndex returns the order in which the RenderComponent is drawn to the screen, as set by StartZIndex or SetZIndex.
+func (r *RenderComponent) ZIndex() float32 {
+	return r.zIndex
+}
+
+// SetOpacity sets how opaque the RenderComponent is drawn, from 0 (invisible) to 1 (opaque), which is the default.
+// The opacity is multiplied with the alpha of Color and with that of the texture, rather than replacing them, so a
+// translucent sprite at half opacity is drawn even more translucent.
+//
+// Entities are blended onto what was drawn before them using their alpha (`SRC_ALPHA, ONE_MINUS_SRC_ALPHA`), so
+// overlapping translucent entities are composed in the order in which they're drawn: the one with the higher z-index
+// is blended on top of the other. Fading an entity is done by tweening its opacity, see `NewFadeTween`.
+func (r *RenderComponent) SetOpacity(opacity float32) {
+	r.transparency = 1 - math.Clamp(opacity, 0, 1)
+}
+
+// Opacity returns how opaque the RenderComponent is drawn, as set by SetOpacity.
+func (r *RenderComponent) Opacity() float32 {
+	return 1 - r.transparency
+}
+
+// SetMinFilter sets the ZoomFilter used for minimizing the RenderComponent. Until it's set, the filter of the texture is
+// used, see TextureFilter.
 func (r *RenderComponent) SetMinFilter(z ZoomFilter) {
 	r.minFilter = z
+	r.minFilterSet = true
 	engo.Mailbox.Dispatch(renderChangeMessage{})
 }
 
-// SetMagFilter sets the ZoomFilter used for magnifying the RenderComponent
+// SetMagFilter sets the ZoomFilter used for magnifying the RenderComponent. Until it's set, the filter of the texture
+// is used, see TextureFilter.
 func (r *RenderComponent) SetMagFilter(z ZoomFilter) {
 	r.magFilter = z
+	r.magFilterSet = true
 	engo.Mailbox.Dispatch(renderChangeMessage{})
 }
 
+// filters returns the ZoomFilters used for magnifying and minimizing the RenderComponent: those set using SetMagFilter
+// and SetMinFilter, or else the filter of its texture.
+func (r *RenderComponent) filters() (magFilter, minFilter ZoomFilter) {
+	magFilter, minFilter = r.magFilter, r.minFilter
+	if (r.magFilterSet && r.minFilterSet) || r.Drawable == nil {
+		return magFilter, minFilter
+	}
+	filter := textureFilter(r.Drawable.Texture())
+	if !r.magFilterSet {
+		magFilter = filter
+	}
+	if !r.minFilterSet {
+		minFilter = filter
+	}
+	return magFilter, minFilter
+}
+
 type renderEntity struct {
 	*ecs.BasicEntity
 	*RenderComponent
 	*SpaceComponent
+	*ParallaxComponent
 }
 
 type renderEntityList []renderEntity
@@ -194,7 +276,12 @@ func (r renderEntityList) Less(i, j int) bool {
 		return r[i].Position.Y < r[j].Position.Y
 	}
 
-	return r[i].Position.X < r[j].Position.X
+	if r[i].Position.X != r[j].Position.X {
+		return r[i].Position.X < r[j].Position.X
+	}
+
+	// Break ties by ID, so entities which are otherwise the same are drawn in the same order every frame
+	return r[i].BasicEntity.ID() < r[j].BasicEntity.ID()
 }
 
 func (r renderEntityList) Swap(i, j int) {
@@ -204,12 +291,44 @@ func (r renderEntityList) Swap(i, j int) {
 // RenderSystem is the system that draws entities on the OpenGL surface. It requires
 // a CameraSystem to work. If a CameraSystem is not in the World when you add RenderSystem
 // one is automatically added to the world.
+//
+// Every frame is drawn in render passes: first the world, then the HUD. Custom passes can be drawn in between those
+// using `engo.InsertRenderPass`. Before that, the world is drawn into every offscreen target added using AddTarget.
+// For split-screen games, the world can be drawn into views covering parts of the screen instead, see AddView.
 type RenderSystem struct {
+	// NoCulling draws every entity, even those which are off screen. Entities drawn by shaders which implement
+	// CullingShader are skipped when they're not within the view of the camera; this turns that off for debugging.
+	NoCulling bool
+	// Background is the color the screen is cleared to every frame, instead of the one set using SetBackground. Since
+	// every scene has its own RenderSystem, this gives each scene its own background, which applies from the first
+	// frame it's drawn, and can be changed at any time, such as for flashing the screen.
+	Background color.Color
+
 	entities renderEntityList
-	ids      map[uint64]struct{}
+	ids      entityIDs
 	world    *ecs.World
 
 	sortingNeeded, newCamera bool
+
+	scaler renderScaler
+	post   postProcessor
+
+	targets []*RenderTarget
+	target  *RenderTarget // the target being drawn into, if any
+
+	views []*View
+	// builtinPasses are the render passes of the RenderSystem itself, and passList holds all render passes of the
+	// current frame, see passes
+	builtinPasses, passList []engo.RenderPass
+
+	stats RenderStats
+}
+
+// renderPasser is implemented by the systems which draw render passes of their own, such as the ParticleSystem. The
+// RenderSystem draws the passes of every such system in the same world, see `RenderSystem.passes`.
+type renderPasser interface {
+	// renderPasses returns the render passes of the system, which it should keep around rather than create every frame
+	renderPasses() []engo.RenderPass
 }
 
 // Priority implements the ecs.Prioritizer interface.
@@ -218,7 +337,7 @@ func (*RenderSystem) Priority() int { return RenderSystemPriority }
 // New initializes the RenderSystem
 func (rs *RenderSystem) New(w *ecs.World) {
 	rs.world = w
-	rs.ids = make(map[uint64]struct{})
+	rs.ids = make(entityIDs)
 
 	engo.Mailbox.Listen("NewCameraMessage", func(engo.Message) {
 		rs.newCamera = true
@@ -230,6 +349,9 @@ func (rs *RenderSystem) New(w *ecs.World) {
 		if err := initShaders(w); err != nil {
 			panic(err)
 		}
+		if err := rs.scaler.setup(); err != nil {
+			panic(err)
+		}
 		engo.Gl.Enable(engo.Gl.MULTISAMPLE)
 	}
 
@@ -258,15 +380,22 @@ func addCameraSystemOnce(w *ecs.World) {
 
 // Add adds an entity to the RenderSystem. The entity needs a basic, render, and space component to be added to the system.
 func (rs *RenderSystem) Add(basic *ecs.BasicEntity, render *RenderComponent, space *SpaceComponent) {
+	rs.add(basic, render, space, nil)
+}
+
+// add adds an entity to the RenderSystem, which may have a parallax component.
+func (rs *RenderSystem) add(basic *ecs.BasicEntity, render *RenderComponent, space *SpaceComponent, parallax *ParallaxComponent) {
 	// Do nothing if entity already exists
-	if _, ok := rs.ids[basic.ID()]; ok {
+	if !rs.ids.add(basic.ID()) {
 		return
 	}
 
-	rs.ids[basic.ID()] = struct{}{}
-
 	render.ensureShader()
 
+	if isHUDShader(render.shader) {
+		render.ScreenSpace = true
+	}
+
 	// This is to prevent users from using the wrong one
 	if render.shader == HUDShader {
 		switch render.Drawable.(type) {
@@ -297,10 +426,30 @@ func (rs *RenderSystem) Add(basic *ecs.BasicEntity, render *RenderComponent, spa
 		render.zIndex = render.StartZIndex
 	}
 
-	rs.entities = append(rs.entities, renderEntity{basic, render, space})
+	rs.entities = append(rs.entities, renderEntity{basic, render, space, parallax})
 	rs.sortingNeeded = true
 }
 
+// AddBatch adds many entities to the RenderSystem at once, such as when spawning a wave of enemies. It's the same as
+// calling AddByInterface for every entity, except that the list of entities grows only once.
+func (rs *RenderSystem) AddBatch(entities []Renderable) {
+	if len(rs.ids) == 0 {
+		rs.ids = make(entityIDs, len(entities))
+	}
+	if n := len(rs.entities) + len(entities); n > cap(rs.entities) {
+		grown := make(renderEntityList, len(rs.entities), n)
+		copy(grown, rs.entities)
+		rs.entities = grown
+	}
+	for _, e := range entities {
+		var parallax *ParallaxComponent
+		if p, ok := e.(ParallaxFace); ok {
+			parallax = p.GetParallaxComponent()
+		}
+		rs.add(e.GetBasicEntity(), e.GetRenderComponent(), e.GetSpaceComponent(), parallax)
+	}
+}
+
 // EntityExists looks if the entity is already into the System's entities. It will return the index >= 0 of the object into de rs.entities or -1 if it could not be found.
 func (rs *RenderSystem) EntityExists(basic *ecs.BasicEntity) int {
 	for index, entity := range rs.entities {
@@ -313,9 +462,14 @@ func (rs *RenderSystem) EntityExists(basic *ecs.BasicEntity) int {
 }
 
 // AddByInterface adds any Renderable to the render system. Any Entity containing a BasicEntity,RenderComponent, and SpaceComponent anonymously does this automatically
+// If the entity contains a ParallaxComponent as well, it is added using AddWithParallax.
 func (rs *RenderSystem) AddByInterface(i ecs.Identifier) {
 	o, _ := i.(Renderable)
-	rs.Add(o.GetBasicEntity(), o.GetRenderComponent(), o.GetSpaceComponent())
+	var parallax *ParallaxComponent
+	if p, ok := i.(ParallaxFace); ok {
+		parallax = p.GetParallaxComponent()
+	}
+	rs.add(o.GetBasicEntity(), o.GetRenderComponent(), o.GetSpaceComponent(), parallax)
 }
 
 // Remove removes an entity from the RenderSystem
@@ -325,7 +479,40 @@ func (rs *RenderSystem) Remove(basic ecs.BasicEntity) {
 		rs.entities = append(rs.entities[:d], rs.entities[d+1:]...)
 		rs.sortingNeeded = true
 	}
-	delete(rs.ids, basic.ID())
+	rs.ids.remove(basic.ID())
+}
+
+// Entities returns a copy of the IDs of all entities in the RenderSystem, in drawing order as of the last update.
+func (rs *RenderSystem) Entities() []uint64 {
+	ids := make([]uint64, len(rs.entities))
+	for i, e := range rs.entities {
+		ids[i] = e.ID()
+	}
+	return ids
+}
+
+// BasicEntities returns a copy of the BasicEntities of all entities in the RenderSystem.
+func (rs *RenderSystem) BasicEntities() []ecs.BasicEntity {
+	basics := make([]ecs.BasicEntity, len(rs.entities))
+	for i, e := range rs.entities {
+		basics[i] = *e.BasicEntity
+	}
+	return basics
+}
+
+// Get returns the components with which the entity with the given ID was added to the RenderSystem, and false if it
+// isn't in the system. Since entities are only kept until they're removed, an entity which was removed isn't found,
+// even if its components are still in use elsewhere.
+func (rs *RenderSystem) Get(id uint64) (*RenderComponent, *SpaceComponent, bool) {
+	if _, ok := rs.ids[id]; !ok {
+		return nil, nil, false
+	}
+	for _, e := range rs.entities {
+		if e.ID() == id {
+			return e.RenderComponent, e.SpaceComponent, true
+		}
+	}
+	return nil, nil, false
 }
 
 // Update draws the entities in the RenderSystem to the OpenGL Surface.
@@ -334,6 +521,9 @@ func (rs *RenderSystem) Update(dt float32) {
 		return
 	}
 
+	frameStats = RenderStats{}
+	defer func() { rs.stats = frameStats }()
+
 	if rs.sortingNeeded {
 		sort.Sort(rs.entities)
 		rs.sortingNeeded = false
@@ -344,12 +534,145 @@ func (rs *RenderSystem) Update(dt float32) {
 		rs.newCamera = false
 	}
 
-	engo.Gl.Clear(engo.Gl.COLOR_BUFFER_BIT)
+	x, y, width, height, letterboxed := setViewport()
+
+	rs.drawTargets()
+
+	// Draw to an offscreen target at a lower (or higher) resolution, which is stretched over the window afterwards
+	offscreen := false
+	if scale := engo.GetRenderScale(); scale != 1 {
+		rs.scaler.begin(scale)
+		defer rs.scaler.end()
+		offscreen = true
+	}
+	// Draw to an offscreen target for the post-processing passes, which draw it onto the screen afterwards
+	if rs.post.begin() {
+		offscreen = true
+	}
+
+	// Drawing directly onto the canvas, only the game is cleared, keeping the black bars around it
+	setClearColor(rs.background())
+	if letterboxed && !offscreen {
+		engo.Gl.Enable(engo.Gl.SCISSOR_TEST)
+		engo.Gl.Scissor(x, y, width, height)
+		engo.Gl.Clear(engo.Gl.COLOR_BUFFER_BIT)
+		engo.Gl.Disable(engo.Gl.SCISSOR_TEST)
+	} else {
+		engo.Gl.Clear(engo.Gl.COLOR_BUFFER_BIT)
+	}
 
+	rs.drawPasses()
+}
+
+// passes returns the render passes of the RenderSystem, along with those inserted using `engo.InsertRenderPass`, in
+// the order in which they should be drawn. The RenderSystem draws everything that is not on the HUD in the pass
+// named "world", the HUD in the pass named "hud", and the fade of a scene transition in the pass named "transition".
+// The particles of every ParticleSystem in the same world are drawn in the pass named "particles", the shapes of a
+// ShapeSystem in the passes named "shapes" and "hudshapes", the bars of a ProgressBarSystem in the passes named
+// "progressbars" and "hudprogressbars", the outlines of a DebugSystem in the pass named "debug",
+// the graph of an FPSSystem in the pass named "fps", and the post-processing passes (see AddPostProcess) are run in
+// the pass named "postprocess". Inserting a pass with the name of any of these passes replaces it. Systems draw passes
+// of their own by implementing renderPasser. The returned slice is reused by the next call.
+func (rs *RenderSystem) passes() []engo.RenderPass {
+	if rs.builtinPasses == nil {
+		rs.builtinPasses = []engo.RenderPass{
+			{Name: "world", Order: engo.RenderPassWorld, Draw: func() { rs.draw(false) }},
+			{Name: "hud", Order: engo.RenderPassHUD, Draw: func() { rs.draw(true) }},
+			{Name: "transition", Order: engo.RenderPassTransition, Draw: rs.drawTransition},
+		}
+	}
+	inserted := engo.RenderPasses()
+	passes := appendPasses(rs.passList[:0], inserted, rs.builtinPasses...)
+	passes = append(passes, inserted...)
+	if rs.post.drawing {
+		passes = appendPasses(passes, inserted, engo.RenderPass{Name: "postprocess", Order: engo.RenderPassPost, Draw: rs.post.draw})
+	}
+	if rs.world != nil {
+		for _, system := range rs.world.Systems() {
+			if sys, ok := system.(renderPasser); ok {
+				passes = appendPasses(passes, inserted, sys.renderPasses()...)
+			}
+		}
+	}
+	sort.SliceStable(passes, func(i, j int) bool {
+		return passes[i].Order < passes[j].Order
+	})
+	rs.passList = passes
+	return passes
+}
+
+// appendPasses appends the built-in render passes to passes, except for those which are replaced by an inserted pass
+// with the same name.
+func appendPasses(passes, inserted []engo.RenderPass, builtin ...engo.RenderPass) []engo.RenderPass {
+next:
+	for _, pass := range builtin {
+		for _, replacement := range inserted {
+			if replacement.Name == pass.Name {
+				continue next
+			}
+		}
+		passes = append(passes, pass)
+	}
+	return passes
+}
+
+// drawTransition draws the fade of the scene transition in progress over the entire screen, see
+// `engo.SetSceneWithTransition`.
+func (rs *RenderSystem) drawTransition() {
+	c, fade := engo.TransitionFade()
+	if fade <= 0 {
+		return
+	}
+
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	n.A = uint8(float32(n.A) * fade)
+	ren := RenderComponent{Drawable: Rectangle{}, Color: n, Scale: engo.Point{X: 1, Y: 1}}
+	// The HUD covers either the game area or the canvas, depending on whether the game scales on resize
+	space := SpaceComponent{
+		Width:  math.Max(engo.GameWidth(), engo.CanvasWidth()/engo.CanvasScale()),
+		Height: math.Max(engo.GameHeight(), engo.CanvasHeight()/engo.CanvasScale()),
+	}
+
+	LegacyHUDShader.Pre()
+	LegacyHUDShader.Draw(&ren, &space)
+	LegacyHUDShader.Post()
+}
+
+// isHUDShader returns whether or not the given shader draws on the HUD.
+func isHUDShader(shader Shader) bool {
+	return shader == HUDShader || shader == LegacyHUDShader || shader == TextHUDShader
+}
+
+// spaceShader returns the variant of a built-in shader which draws either in screen space or in the world. Other
+// shaders are returned as they are.
+func spaceShader(shader Shader, screen bool) Shader {
+	switch shader {
+	case DefaultShader, HUDShader:
+		if screen {
+			return HUDShader
+		}
+		return DefaultShader
+	case LegacyShader, LegacyHUDShader:
+		if screen {
+			return LegacyHUDShader
+		}
+		return LegacyShader
+	case TextShader, TextHUDShader:
+		if screen {
+			return TextHUDShader
+		}
+		return TextShader
+	}
+	return shader
+}
+
+// draw draws either the entities on the HUD, or all other entities.
+func (rs *RenderSystem) draw(hud bool) {
 	preparedCullingShaders := make(map[CullingShader]struct{})
 	var cullingShader CullingShader // current culling shader
 	var prevShader Shader           // shader of the previous entity
 	var currentShader Shader        // currently "active" shader
+	var cam *CameraSystem           // camera for the parallax, looked up once needed
 
 	// TODO: it's linear for now, but that might very well be a bad idea
 	for _, e := range rs.entities {
@@ -357,8 +680,16 @@ func (rs *RenderSystem) Update(dt float32) {
 			continue // with other entities
 		}
 
+		if rs.target != nil && e.RenderComponent.Drawable != nil && e.RenderComponent.Drawable.Texture() == rs.target.texture.id {
+			continue // a target can't be drawn into itself
+		}
+
+		if e.RenderComponent.ScreenSpace != hud {
+			continue // it is drawn in the other pass
+		}
+
 		// Retrieve a shader, may be the default one -- then use it if we aren't already using it
-		shader := e.RenderComponent.shader
+		shader := spaceShader(e.RenderComponent.shader, hud)
 
 		if !compareShaders(shader, prevShader) {
 			// to increase performance avoid the type assertions when possible
@@ -374,7 +705,27 @@ func (rs *RenderSystem) Update(dt float32) {
 			}
 		}
 
-		if cullingShader != nil && !cullingShader.ShouldDraw(e.RenderComponent, e.SpaceComponent) {
+		// Setting default scale to 1, before culling uses it
+		if e.RenderComponent.Scale.X == 0 && e.RenderComponent.Scale.Y == 0 {
+			e.RenderComponent.Scale = engo.Point{X: 1, Y: 1}
+		}
+
+		// Entities with parallax are drawn offset from where they are in the world
+		space := e.SpaceComponent
+		if e.ParallaxComponent != nil && !hud {
+			if cam == nil {
+				cam = rs.camera()
+			}
+			if cam != nil {
+				offset := *space
+				offset.Position = e.ParallaxComponent.position(space.Position, cam.center())
+				space = &offset
+			}
+		}
+
+		frameStats.Submitted++
+		if cullingShader != nil && !rs.NoCulling && !cullingShader.ShouldDraw(e.RenderComponent, space) {
+			frameStats.Culled++
 			continue
 		}
 
@@ -385,11 +736,7 @@ func (rs *RenderSystem) Update(dt float32) {
 			}
 			shader.Pre()
 			currentShader = shader
-		}
-
-		// Setting default scale to 1
-		if e.RenderComponent.Scale.X == 0 && e.RenderComponent.Scale.Y == 0 {
-			e.RenderComponent.Scale = engo.Point{X: 1, Y: 1}
+			frameStats.Batches++
 		}
 
 		// Setting default to white
@@ -397,7 +744,7 @@ func (rs *RenderSystem) Update(dt float32) {
 			e.RenderComponent.Color = color.White
 		}
 
-		currentShader.Draw(e.RenderComponent, e.SpaceComponent)
+		currentShader.Draw(e.RenderComponent, space)
 	}
 
 	if currentShader != nil {
@@ -405,11 +752,34 @@ func (rs *RenderSystem) Update(dt float32) {
 	}
 }
 
-// SetBackground sets the OpenGL ClearColor to the provided color.
+// background is the color set using SetBackground
+var background color.Color
+
+// SetBackground sets the OpenGL ClearColor to the provided color, which the screen is cleared to every frame unless
+// the RenderSystem has a Background of its own.
 func SetBackground(c color.Color) {
-	if !engo.Headless() {
-		r, g, b, a := c.RGBA()
+	background = c
+	setClearColor(c)
+}
 
-		engo.Gl.ClearColor(float32(r)/0xffff, float32(g)/0xffff, float32(b)/0xffff, float32(a)/0xffff)
+// background returns the color the screen is cleared to: the Background of the RenderSystem if it's set, and the
+// one set using SetBackground otherwise.
+func (rs *RenderSystem) background() color.Color {
+	if rs.Background != nil {
+		return rs.Background
 	}
+	return background
+}
+
+// setClearColor sets the OpenGL ClearColor, which is transparent for a nil color.
+func setClearColor(c color.Color) {
+	if engo.Headless() {
+		return
+	}
+	if c == nil {
+		c = color.Transparent
+	}
+	r, g, b, a := c.RGBA()
+
+	engo.Gl.ClearColor(float32(r)/0xffff, float32(g)/0xffff, float32(b)/0xffff, float32(a)/0xffff)
 }
@@ -0,0 +1,104 @@
+package common
+
+import (
+	"image"
+
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/gl"
+)
+
+// blankTexture is the transparent placeholder of an AsyncTexture without a placeholder, which is uploaded once it's
+// first drawn.
+var blankTexture *Texture
+
+// AsyncTexture is a Drawable of an image which is loaded in the background, see `LoadSpriteAsync`. Until the image is
+// loaded, its placeholder is drawn instead; once it is loaded, the texture of the image is, so a RenderComponent can
+// refer to it right away.
+type AsyncTexture struct {
+	load        *engo.AsyncLoad
+	placeholder Drawable
+	texture     *Texture
+	err         error
+}
+
+// LoadSpriteAsync starts loading the image with the given url in the background using `engo.Files.LoadAsync`, and
+// returns an AsyncTexture which draws the placeholder until then, such as a low resolution version of the image. A nil
+// placeholder draws nothing. The placeholder is drawn using the shader of the RenderComponent, which should be
+// DefaultShader or HUDShader, so it should be a Texture or a cell of a Spritesheet rather than a shape or Text.
+//
+// Like with `engo.AsyncLoad`, the image stays loaded once it is, until it's unloaded using `engo.Files.Unload`; use
+// Close to delete its texture, or Cancel to stop loading it. The Width and Height of the AsyncTexture are those of the
+// placeholder until the image is loaded, so set those of the SpaceComponent up front.
+func LoadSpriteAsync(url string, placeholder Drawable) *AsyncTexture {
+	return &AsyncTexture{load: engo.Files.LoadAsync(url), placeholder: placeholder}
+}
+
+// Ready returns whether or not the image is loaded, and drawn instead of the placeholder.
+func (t *AsyncTexture) Ready() bool {
+	t.resolve()
+	return t.texture != nil
+}
+
+// Err returns the error that occurred while loading the image, such as `engo.ErrLoadCanceled` once it was canceled.
+// Whenever loading fails, the placeholder stays.
+func (t *AsyncTexture) Err() error {
+	if t.err != nil {
+		return t.err
+	}
+	return t.load.Err()
+}
+
+// Cancel stops loading the image, see `engo.AsyncLoad.Cancel`. Once canceled, the placeholder stays.
+func (t *AsyncTexture) Cancel() {
+	t.load.Cancel()
+}
+
+// Texture returns the OpenGL ID of the texture of the image, or of the placeholder while the image is loaded.
+func (t *AsyncTexture) Texture() *gl.Texture {
+	return t.resolve().Texture()
+}
+
+// Width returns the width of the image, or of the placeholder while the image is loaded.
+func (t *AsyncTexture) Width() float32 {
+	return t.resolve().Width()
+}
+
+// Height returns the height of the image, or of the placeholder while the image is loaded.
+func (t *AsyncTexture) Height() float32 {
+	return t.resolve().Height()
+}
+
+// View returns the viewport properties of the image, or of the placeholder while the image is loaded.
+func (t *AsyncTexture) View() (float32, float32, float32, float32) {
+	return t.resolve().View()
+}
+
+// Close removes the texture of the image from the GPU, once it's loaded. The placeholder is left alone, since it may
+// be shared.
+func (t *AsyncTexture) Close() {
+	if t.texture != nil {
+		t.texture.Close()
+	}
+}
+
+// resolve returns the Drawable to draw: the texture of the image once it's loaded, and the placeholder until then.
+func (t *AsyncTexture) resolve() Drawable {
+	if t.texture == nil && t.err == nil && t.load.Loaded() {
+		t.texture, t.err = LoadedSprite(t.load.URL())
+		if t.err == nil {
+			// The RenderSystem sorts its entities by texture
+			engo.Mailbox.Dispatch(renderChangeMessage{})
+		}
+	}
+	if t.texture != nil {
+		return t.texture
+	}
+	if t.placeholder != nil {
+		return t.placeholder
+	}
+	if blankTexture == nil {
+		blank := NewTextureSingle(NewImageObject(image.NewNRGBA(image.Rect(0, 0, 1, 1))))
+		blankTexture = &blank
+	}
+	return blankTexture
+}
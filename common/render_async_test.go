@@ -0,0 +1,61 @@
+package common
+
+import (
+	"image"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/EngoEngine/engo"
+)
+
+func TestLoadSpriteAsync(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &textureTestScene{})
+
+	dir, err := ioutil.TempDir(".", "testing")
+	if err != nil {
+		t.Fatalf("failed to create temp directory for testing, error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	engo.Files.SetRoot(dir)
+	f, err := os.Create(filepath.Join(dir, "async.png"))
+	if err != nil {
+		t.Fatalf("failed to create temp file for testing, error: %v", err)
+	}
+	err = png.Encode(f, image.NewNRGBA(image.Rect(0, 0, 37, 21)))
+	f.Close()
+	if err != nil {
+		t.Fatalf("Unable to encode png from image. Error was: %v", err)
+	}
+
+	placeholder := Texture{width: 4, height: 2}
+	tex := LoadSpriteAsync("async.png", placeholder)
+	if tex.Ready() || tex.Width() != 4 || tex.Height() != 2 {
+		t.Errorf("placeholder was not drawn while loading. Wanted: 4x2, got: %vx%v", tex.Width(), tex.Height())
+	}
+
+	for deadline := time.Now().Add(time.Second); !tex.Ready() && time.Now().Before(deadline); {
+		engo.Step(0)
+		time.Sleep(time.Millisecond)
+	}
+	if !tex.Ready() || tex.Err() != nil {
+		t.Fatalf("image was not loaded, error: %v", tex.Err())
+	}
+	if tex.Width() != 37 || tex.Height() != 21 {
+		t.Errorf("image was not drawn once loaded. Wanted: 37x21, got: %vx%v", tex.Width(), tex.Height())
+	}
+
+	canceled := LoadSpriteAsync("async.png", nil)
+	canceled.Cancel()
+	defer canceled.load.Wait()
+	engo.Step(0)
+	if canceled.Ready() || canceled.Err() != engo.ErrLoadCanceled || canceled.Width() != 1 {
+		t.Errorf("canceled image did not keep drawing nothing, got width: %v (error: %v)", canceled.Width(), canceled.Err())
+	}
+}
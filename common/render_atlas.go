@@ -0,0 +1,226 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/EngoEngine/engo"
+)
+
+// TextureAtlasResource contains the named regions of a texture atlas, which is a single image into which many
+// sprites are packed, as described by a TexturePacker descriptor. Since all regions share the texture of the image,
+// they're batched together when drawn after eachother.
+//
+// Descriptors are loaded using `engo.Files`, just like images. Both the JSON (hash and array) format and the
+// Sparrow/Starling XML format of TexturePacker are supported, which have to be named `*.atlas.json` and
+// `*.atlas.xml` to distinguish them from other files. The image of the atlas is loaded along with it, relative to
+// the descriptor.
+//
+// Rotated regions are not supported, and result in an error when loading the descriptor, so rotation should be
+// disabled in TexturePacker. Trimmed regions are drawn without the transparent border which was trimmed.
+type TextureAtlasResource struct {
+	// Image is the url of the image of the atlas
+	Image string
+	// Regions contains every region of the atlas by name
+	Regions map[string]Texture
+	url     string
+}
+
+// URL returns the file path of the TextureAtlasResource.
+func (r TextureAtlasResource) URL() string {
+	return r.url
+}
+
+// AtlasFromFile returns a Drawable for every named region of the texture atlas with the given url, which must have
+// been loaded using `engo.Files` before.
+func AtlasFromFile(url string) (map[string]Drawable, error) {
+	res, err := engo.Files.Resource(url)
+	if err != nil {
+		return nil, err
+	}
+
+	atlas, ok := res.(TextureAtlasResource)
+	if !ok {
+		return nil, fmt.Errorf("resource not of type `TextureAtlasResource`: %s", url)
+	}
+
+	drawables := make(map[string]Drawable, len(atlas.Regions))
+	for name, region := range atlas.Regions {
+		drawables[name] = region
+	}
+	return drawables, nil
+}
+
+// atlasRegion is a single named region of a texture atlas, in pixels.
+type atlasRegion struct {
+	name                string
+	x, y, width, height float32
+	rotated             bool
+}
+
+// atlasFrame is a region as given by the JSON format of TexturePacker.
+type atlasFrame struct {
+	Filename string `json:"filename"`
+	Frame    struct {
+		X float32 `json:"x"`
+		Y float32 `json:"y"`
+		W float32 `json:"w"`
+		H float32 `json:"h"`
+	} `json:"frame"`
+	Rotated bool `json:"rotated"`
+}
+
+func (f atlasFrame) region(name string) atlasRegion {
+	return atlasRegion{name: name, x: f.Frame.X, y: f.Frame.Y, width: f.Frame.W, height: f.Frame.H, rotated: f.Rotated}
+}
+
+// parseAtlasJSON parses the JSON format of TexturePacker, in which the frames are either a hash of named frames, or
+// an array of frames with a filename.
+func parseAtlasJSON(data []byte) (image string, regions []atlasRegion, err error) {
+	var descriptor struct {
+		Frames json.RawMessage `json:"frames"`
+		Meta   struct {
+			Image string `json:"image"`
+		} `json:"meta"`
+	}
+	if err = json.Unmarshal(data, &descriptor); err != nil {
+		return "", nil, err
+	}
+
+	if frames := bytes.TrimSpace(descriptor.Frames); len(frames) > 0 && frames[0] == '[' {
+		var array []atlasFrame
+		if err = json.Unmarshal(frames, &array); err != nil {
+			return "", nil, err
+		}
+		for _, frame := range array {
+			regions = append(regions, frame.region(frame.Filename))
+		}
+	} else {
+		var hash map[string]atlasFrame
+		if err = json.Unmarshal(frames, &hash); err != nil {
+			return "", nil, err
+		}
+		for name, frame := range hash {
+			regions = append(regions, frame.region(name))
+		}
+	}
+	return descriptor.Meta.Image, regions, nil
+}
+
+// parseAtlasXML parses the Sparrow/Starling XML format of TexturePacker.
+func parseAtlasXML(data []byte) (image string, regions []atlasRegion, err error) {
+	var descriptor struct {
+		ImagePath   string `xml:"imagePath,attr"`
+		SubTextures []struct {
+			Name    string  `xml:"name,attr"`
+			X       float32 `xml:"x,attr"`
+			Y       float32 `xml:"y,attr"`
+			Width   float32 `xml:"width,attr"`
+			Height  float32 `xml:"height,attr"`
+			Rotated bool    `xml:"rotated,attr"`
+		} `xml:"SubTexture"`
+	}
+	if err = xml.Unmarshal(data, &descriptor); err != nil {
+		return "", nil, err
+	}
+
+	for _, s := range descriptor.SubTextures {
+		regions = append(regions, atlasRegion{name: s.Name, x: s.X, y: s.Y, width: s.Width, height: s.Height, rotated: s.Rotated})
+	}
+	return descriptor.ImagePath, regions, nil
+}
+
+// textureAtlasLoader is responsible for managing texture atlas descriptors within `engo.Files`.
+type textureAtlasLoader struct {
+	atlases map[string]TextureAtlasResource
+}
+
+// Load parses the descriptor, and loads the image of the atlas if it wasn't loaded yet.
+func (l *textureAtlasLoader) Load(url string, data io.Reader) error {
+	b, err := ioutil.ReadAll(data)
+	if err != nil {
+		return err
+	}
+
+	var (
+		image   string
+		regions []atlasRegion
+	)
+	if strings.HasSuffix(url, ".xml") {
+		image, regions, err = parseAtlasXML(b)
+	} else {
+		image, regions, err = parseAtlasJSON(b)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to parse texture atlas %q: %v", url, err)
+	}
+	if image == "" {
+		return fmt.Errorf("texture atlas %q does not contain an image", url)
+	}
+
+	image = path.Join(path.Dir(url), image)
+	res, err := engo.Files.Resource(image)
+	if err != nil {
+		if err = engo.Files.Load(image); err != nil {
+			return err
+		}
+		res, err = engo.Files.Resource(image)
+		if err != nil {
+			return err
+		}
+	}
+	img, ok := res.(TextureResource)
+	if !ok {
+		return fmt.Errorf("resource not of type `TextureResource`: %s", image)
+	}
+
+	atlas := TextureAtlasResource{Image: image, Regions: make(map[string]Texture, len(regions)), url: url}
+	for _, r := range regions {
+		if r.rotated {
+			return fmt.Errorf("region %q of texture atlas %q is rotated, which is not supported", r.name, url)
+		}
+		atlas.Regions[r.name] = Texture{
+			id:     img.Texture,
+			width:  r.width,
+			height: r.height,
+			viewport: engo.AABB{
+				Min: engo.Point{X: r.x / img.Width, Y: r.y / img.Height},
+				Max: engo.Point{X: (r.x + r.width) / img.Width, Y: (r.y + r.height) / img.Height},
+			},
+			region: engo.AABB{
+				Min: engo.Point{X: r.x, Y: r.y},
+				Max: engo.Point{X: r.x + r.width, Y: r.y + r.height},
+			},
+			pixels: img.pixels,
+			url:    img.url,
+		}
+	}
+	l.atlases[url] = atlas
+	return nil
+}
+
+// Unload removes the texture atlas from the cache. Its image is left loaded, since it may be used elsewhere.
+func (l *textureAtlasLoader) Unload(url string) error {
+	delete(l.atlases, url)
+	return nil
+}
+
+// Resource retrieves the texture atlas, passed as a `TextureAtlasResource`
+func (l *textureAtlasLoader) Resource(url string) (engo.Resource, error) {
+	atlas, ok := l.atlases[url]
+	if !ok {
+		return nil, fmt.Errorf("resource not loaded by `FileLoader`: %q", url)
+	}
+	return atlas, nil
+}
+
+func init() {
+	engo.Files.Register(".atlas.json", &textureAtlasLoader{atlases: make(map[string]TextureAtlasResource)})
+	engo.Files.Register(".atlas.xml", &textureAtlasLoader{atlases: make(map[string]TextureAtlasResource)})
+}
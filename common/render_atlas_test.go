@@ -0,0 +1,89 @@
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/EngoEngine/engo"
+)
+
+const (
+	testAtlasHash = `{
+	"frames": {
+		"ship.png": {"frame": {"x": 0, "y": 0, "w": 32, "h": 16}, "rotated": false, "trimmed": false},
+		"rock.png": {"frame": {"x": 32, "y": 16, "w": 32, "h": 16}, "rotated": false, "trimmed": true}
+	},
+	"meta": {"image": "sheet.png", "size": {"w": 64, "h": 32}}
+}`
+	testAtlasArray = `{
+	"frames": [
+		{"filename": "ship.png", "frame": {"x": 0, "y": 0, "w": 32, "h": 16}, "rotated": false},
+		{"filename": "rock.png", "frame": {"x": 32, "y": 16, "w": 32, "h": 16}, "rotated": false}
+	],
+	"meta": {"image": "sheet.png"}
+}`
+	testAtlasXML = `<?xml version="1.0" encoding="UTF-8"?>
+<TextureAtlas imagePath="sheet.png">
+	<SubTexture name="ship.png" x="0" y="0" width="32" height="16"/>
+	<SubTexture name="rock.png" x="32" y="16" width="32" height="16"/>
+</TextureAtlas>`
+	testAtlasRotated = `{
+	"frames": {"ship.png": {"frame": {"x": 0, "y": 0, "w": 16, "h": 32}, "rotated": true}},
+	"meta": {"image": "sheet.png"}
+}`
+)
+
+func TestAtlasFromFile(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &textureTestScene{})
+
+	loadTestImage(t, "atlas/sheet.png", 64, 32)
+
+	descriptors := []struct {
+		url, data string
+	}{
+		{"atlas/hash.atlas.json", testAtlasHash},
+		{"atlas/array.atlas.json", testAtlasArray},
+		{"atlas/sparrow.atlas.xml", testAtlasXML},
+	}
+	for _, d := range descriptors {
+		if err := engo.Files.LoadReaderData(d.url, strings.NewReader(d.data)); err != nil {
+			t.Fatalf("Unable to load texture atlas %v. Error was: %v", d.url, err)
+		}
+		regions, err := AtlasFromFile(d.url)
+		if err != nil {
+			t.Fatalf("Unable to retrieve texture atlas %v. Error was: %v", d.url, err)
+		}
+		if len(regions) != 2 {
+			t.Errorf("wrong amount of regions in %v. Wanted: 2, got: %v", d.url, len(regions))
+		}
+
+		rock, ok := regions["rock.png"]
+		if !ok {
+			t.Fatalf("region was not found by name in %v", d.url)
+		}
+		if rock.Width() != 32 || rock.Height() != 16 {
+			t.Errorf("wrong size of region in %v. Wanted: 32x16, got: %vx%v", d.url, rock.Width(), rock.Height())
+		}
+		if u1, v1, u2, v2 := rock.View(); u1 != 0.5 || v1 != 0.5 || u2 != 1 || v2 != 1 {
+			t.Errorf("wrong viewport of region in %v. Wanted: (0.5, 0.5, 1, 1), got: (%v, %v, %v, %v)", d.url, u1, v1, u2, v2)
+		}
+		if rock.Texture() != regions["ship.png"].Texture() {
+			t.Errorf("regions in %v do not share the same texture", d.url)
+		}
+	}
+
+	// Rotated regions are not supported.
+	if err := engo.Files.LoadReaderData("atlas/rotated.atlas.json", strings.NewReader(testAtlasRotated)); err == nil {
+		t.Error("loading a texture atlas with rotated regions did not return an error")
+	}
+
+	if _, err := AtlasFromFile("atlas/missing.atlas.json"); err == nil {
+		t.Error("retrieving a texture atlas which was not loaded did not return an error")
+	}
+	if err := engo.Files.LoadReaderData("atlas/noimage.atlas.json", strings.NewReader(`{"frames": {}}`)); err == nil {
+		t.Error("loading a texture atlas without an image did not return an error")
+	}
+}
@@ -21,12 +21,48 @@ import (
 	"github.com/EngoEngine/gl"
 )
 
+// ReadableTextures indicates whether or not images keep their pixel data in RAM after they were sent to the GPU.
+// This is needed for reading the pixels of a Texture, such as for pixel-perfect mouse picking (see
+// `MouseComponent.PixelPerfect`), and only applies to images loaded or Textures created after setting it.
+var ReadableTextures bool
+
+// TextureFilter is the ZoomFilter with which images are sampled when they're drawn larger or smaller than their size,
+// unless the RenderComponent drawing them sets its own using SetMagFilter and SetMinFilter. FilterNearest, the
+// default, keeps the pixels of pixel art crisp, while FilterLinear smooths them, which suits photos and other detailed
+// images. Like ReadableTextures, it only applies to images loaded or Textures created after setting it, so it can be
+// set before loading a group of images. The filter of an image can also be changed afterwards using
+// `Texture.SetFilter`.
+var TextureFilter ZoomFilter
+
+// textureFilters contains the ZoomFilter of every texture on the GPU which isn't sampled using FilterNearest.
+var textureFilters = make(map[*gl.Texture]ZoomFilter)
+
+// textureFilter returns the ZoomFilter with which the texture is sampled, see TextureFilter.
+func textureFilter(id *gl.Texture) ZoomFilter {
+	return textureFilters[id]
+}
+
+// setTextureFilter sets the ZoomFilter with which the texture is sampled, see TextureFilter.
+func setTextureFilter(id *gl.Texture, filter ZoomFilter) {
+	if id == nil {
+		return // created while headless
+	}
+	if filter == FilterNearest {
+		delete(textureFilters, id)
+	} else {
+		textureFilters[id] = filter
+	}
+}
+
 // TextureResource is the resource used by the RenderSystem. It uses .jpg, .gif, and .png images
 type TextureResource struct {
 	Texture *gl.Texture
 	Width   float32
 	Height  float32
 	url     string
+
+	// pixels is the image data, which is only kept when ReadableTextures is set
+	pixels *image.NRGBA
 }
 
 // URL is the file path of the TextureResource
@@ -38,32 +74,67 @@ type imageLoader struct {
 	images map[string]TextureResource
 }
 
-func (i *imageLoader) Load(url string, data io.Reader) error {
+// imageLoaders contains the loaders of every image format, so that the HotReloadSystem can find all loaded images.
+var imageLoaders []*imageLoader
+
+// decodeImage decodes the image data, which is an svg when the url has the .svg extension.
+func decodeImage(url string, data io.Reader) (*image.NRGBA, error) {
+	var img image.Image
 	if getExt(url) == ".svg" {
 		icon, err := oksvg.ReadIconStream(data, oksvg.WarnErrorMode)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		w, h := int(icon.ViewBox.W), int(icon.ViewBox.H)
-		img := image.NewRGBA(image.Rect(0, 0, w, h))
-		gv := rasterx.NewScannerGV(w, h, img, img.Bounds())
+		rgba := image.NewRGBA(image.Rect(0, 0, w, h))
+		gv := rasterx.NewScannerGV(w, h, rgba, rgba.Bounds())
 		r := rasterx.NewDasher(w, h, gv)
 		icon.Draw(r, 1.0)
-		b := img.Bounds()
-		newm := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
-		draw.Draw(newm, newm.Bounds(), img, b.Min, draw.Src)
-		i.images[url] = NewTextureResource(&ImageObject{newm})
+		img = rgba
 	} else {
-		img, _, err := image.Decode(data)
+		var err error
+		img, _, err = image.Decode(data)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		b := img.Bounds()
-		newm := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
-		draw.Draw(newm, newm.Bounds(), img, b.Min, draw.Src)
-		i.images[url] = NewTextureResource(&ImageObject{newm})
 	}
+	b := img.Bounds()
+	newm := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(newm, newm.Bounds(), img, b.Min, draw.Src)
+	return newm, nil
+}
 
+func (i *imageLoader) Load(url string, data io.Reader) error {
+	img, err := decodeImage(url, data)
+	if err != nil {
+		return err
+	}
+	res := NewTextureResource(&ImageObject{img})
+	res.url = url
+	i.images[url] = res
+	return nil
+}
+
+// Reload replaces the image on the GPU, keeping the same texture, so that everything drawing it shows the new image.
+// Images which were not loaded before are simply loaded.
+func (i *imageLoader) Reload(url string, data io.Reader) error {
+	res, ok := i.images[url]
+	if !ok {
+		return i.Load(url, data)
+	}
+
+	img, err := decodeImage(url, data)
+	if err != nil {
+		return err
+	}
+	obj := &ImageObject{img}
+	if !engo.Headless() {
+		engo.Gl.BindTexture(engo.Gl.TEXTURE_2D, res.Texture)
+		engo.Gl.TexImage2D(engo.Gl.TEXTURE_2D, 0, engo.Gl.RGBA, engo.Gl.RGBA, engo.Gl.UNSIGNED_BYTE, obj.Data())
+	}
+	res.Width, res.Height = float32(obj.Width()), float32(obj.Height())
+	res.pixels = readablePixels(obj)
+	i.images[url] = res
 	return nil
 }
 
@@ -99,7 +170,12 @@ func UploadTexture(img Image) *gl.Texture {
 		engo.Gl.TexParameteri(engo.Gl.TEXTURE_2D, engo.Gl.TEXTURE_WRAP_S, engo.Gl.CLAMP_TO_EDGE)
 		engo.Gl.TexParameteri(engo.Gl.TEXTURE_2D, engo.Gl.TEXTURE_WRAP_T, engo.Gl.CLAMP_TO_EDGE)
 		engo.Gl.TexParameteri(engo.Gl.TEXTURE_2D, engo.Gl.TEXTURE_MIN_FILTER, engo.Gl.LINEAR)
-		engo.Gl.TexParameteri(engo.Gl.TEXTURE_2D, engo.Gl.TEXTURE_MAG_FILTER, engo.Gl.NEAREST)
+		if TextureFilter == FilterLinear {
+			engo.Gl.TexParameteri(engo.Gl.TEXTURE_2D, engo.Gl.TEXTURE_MAG_FILTER, engo.Gl.LINEAR)
+		} else {
+			engo.Gl.TexParameteri(engo.Gl.TEXTURE_2D, engo.Gl.TEXTURE_MAG_FILTER, engo.Gl.NEAREST)
+		}
+		setTextureFilter(id, TextureFilter)
 
 		if img.Data() == nil {
 			panic("Texture image data is nil.")
@@ -113,13 +189,30 @@ func UploadTexture(img Image) *gl.Texture {
 // NewTextureResource sends the image to the GPU and returns a `TextureResource` for easy access
 func NewTextureResource(img Image) TextureResource {
 	id := UploadTexture(img)
-	return TextureResource{Texture: id, Width: float32(img.Width()), Height: float32(img.Height())}
+	return TextureResource{Texture: id, Width: float32(img.Width()), Height: float32(img.Height()), pixels: readablePixels(img)}
+}
+
+// readablePixels returns the pixel data of the image if ReadableTextures is set, and nil otherwise.
+func readablePixels(img Image) *image.NRGBA {
+	if !ReadableTextures {
+		return nil
+	}
+	pixels, _ := img.Data().(*image.NRGBA)
+	return pixels
 }
 
 // NewTextureSingle sends the image to the GPU and returns a `Texture` with a viewport for single-sprite images
 func NewTextureSingle(img Image) Texture {
 	id := UploadTexture(img)
-	return Texture{id, float32(img.Width()), float32(img.Height()), engo.AABB{Max: engo.Point{X: 1.0, Y: 1.0}}}
+	width, height := float32(img.Width()), float32(img.Height())
+	return Texture{
+		id:       id,
+		width:    width,
+		height:   height,
+		viewport: engo.AABB{Max: engo.Point{X: 1.0, Y: 1.0}},
+		region:   engo.AABB{Max: engo.Point{X: width, Y: height}},
+		pixels:   readablePixels(img),
+	}
 }
 
 // ImageToNRGBA takes a given `image.Image` and converts it into an `image.NRGBA`. Especially useful when transforming
@@ -169,7 +262,15 @@ func LoadedSprite(url string) (*Texture, error) {
 		return nil, fmt.Errorf("resource not of type `TextureResource`: %s", url)
 	}
 
-	return &Texture{img.Texture, img.Width, img.Height, engo.AABB{Max: engo.Point{X: 1.0, Y: 1.0}}}, nil
+	return &Texture{
+		id:       img.Texture,
+		width:    img.Width,
+		height:   img.Height,
+		viewport: engo.AABB{Max: engo.Point{X: 1.0, Y: 1.0}},
+		region:   engo.AABB{Max: engo.Point{X: img.Width, Y: img.Height}},
+		pixels:   img.pixels,
+		url:      url,
+	}, nil
 }
 
 // Texture represents a texture loaded in the GPU RAM (by using OpenGL), which defined dimensions and viewport
@@ -178,18 +279,47 @@ type Texture struct {
 	width    float32
 	height   float32
 	viewport engo.AABB
+	region   engo.AABB
+	pixels   *image.NRGBA
+	url      string
 }
 
-// Width returns the width of the texture.
+// Width returns the width of the texture, in pixels.
 func (t Texture) Width() float32 {
 	return t.width
 }
 
-// Height returns the height of the texture.
+// Height returns the height of the texture, in pixels.
 func (t Texture) Height() float32 {
 	return t.height
 }
 
+// Region returns the rectangle, in pixels, which the Texture occupies within its source image. For single-sprite
+// images this is the entire image, for cells of a Spritesheet this is the location of the cell on the sheet.
+func (t Texture) Region() engo.AABB {
+	return t.region
+}
+
+// URL returns the url of the image the Texture is part of, if it was loaded using `engo.Files`, such as using
+// LoadedSprite, or as a cell of a Spritesheet or TextureAtlas; see Region for which part of the image it is. It's empty
+// for Textures created from an image directly, such as using NewTextureSingle.
+func (t Texture) URL() string {
+	return t.url
+}
+
+// Alpha returns the alpha value of the pixel at the given position, in pixels relative to the top-left corner of the
+// Texture. Positions outside of the Texture are fully transparent. It returns false if the pixel data of the Texture
+// is not available, which is only kept for images loaded while `ReadableTextures` is set.
+func (t Texture) Alpha(x, y float32) (uint8, bool) {
+	if t.pixels == nil {
+		return 0, false
+	}
+	if x < 0 || y < 0 || x >= t.width || y >= t.height {
+		return 0, true
+	}
+	return t.pixels.NRGBAAt(int(t.region.Min.X+x), int(t.region.Min.Y+y)).A, true
+}
+
 // Texture returns the OpenGL ID of the Texture.
 func (t Texture) Texture() *gl.Texture {
 	return t.id
@@ -200,16 +330,29 @@ func (t Texture) View() (float32, float32, float32, float32) {
 	return t.viewport.Min.X, t.viewport.Min.Y, t.viewport.Max.X, t.viewport.Max.Y
 }
 
+// Filter returns the ZoomFilter with which the image of the Texture is sampled, see TextureFilter.
+func (t Texture) Filter() ZoomFilter {
+	return textureFilter(t.id)
+}
+
+// SetFilter sets the ZoomFilter with which the image of the Texture is sampled, see TextureFilter. It applies to every
+// Texture of the same image, such as all cells of a Spritesheet.
+func (t Texture) SetFilter(filter ZoomFilter) {
+	setTextureFilter(t.id, filter)
+}
+
 // Close removes the Texture data from the GPU.
 func (t Texture) Close() {
+	setTextureFilter(t.id, FilterNearest)
 	if !engo.Headless() {
 		engo.Gl.DeleteTexture(t.id)
 	}
 }
 
 func init() {
-	engo.Files.Register(".jpg", &imageLoader{images: make(map[string]TextureResource)})
-	engo.Files.Register(".png", &imageLoader{images: make(map[string]TextureResource)})
-	engo.Files.Register(".gif", &imageLoader{images: make(map[string]TextureResource)})
-	engo.Files.Register(".svg", &imageLoader{images: make(map[string]TextureResource)})
+	for _, ext := range []string{".jpg", ".png", ".gif", ".svg"} {
+		loader := &imageLoader{images: make(map[string]TextureResource)}
+		imageLoaders = append(imageLoaders, loader)
+		engo.Files.Register(ext, loader)
+	}
 }
@@ -0,0 +1,98 @@
+package common
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/gl"
+)
+
+type textureTestScene struct{}
+
+func (*textureTestScene) Preload()           {}
+func (*textureTestScene) Setup(engo.Updater) {}
+func (*textureTestScene) Type() string       { return "textureTestScene" }
+
+func loadTestImage(t *testing.T, url string, w, h int) {
+	imgbuf := bytes.NewBuffer([]byte{})
+	if err := png.Encode(imgbuf, image.NewNRGBA(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatalf("Unable to encode png from image. Error was: %v", err)
+	}
+	if err := engo.Files.LoadReaderData(url, imgbuf); err != nil {
+		t.Fatalf("Unable to load test png. Error was: %v", err)
+	}
+}
+
+func TestLoadedSpriteDimensions(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &textureTestScene{})
+
+	loadTestImage(t, "dimensions.png", 37, 21)
+
+	tex, err := LoadedSprite("dimensions.png")
+	if err != nil {
+		t.Fatalf("Unable to retrieve loaded sprite. Error was: %v", err)
+	}
+	if tex.Width() != 37 || tex.Height() != 21 {
+		t.Errorf("Texture dimensions did not match the image. Wanted: 37x21, got: %vx%v", tex.Width(), tex.Height())
+	}
+	expected := engo.AABB{Max: engo.Point{X: 37, Y: 21}}
+	if tex.Region() != expected {
+		t.Errorf("Texture region did not span the entire image. Wanted: %v, got: %v", expected, tex.Region())
+	}
+}
+
+func TestSpritesheetCellRegion(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &textureTestScene{})
+
+	loadTestImage(t, "sheet.png", 64, 32)
+
+	sheet := NewSpritesheetWithBorderFromFile("sheet.png", 15, 15, 1, 1)
+	if sheet.SourceWidth() != 64 || sheet.SourceHeight() != 32 {
+		t.Errorf("Spritesheet source dimensions did not match the image. Wanted: 64x32, got: %vx%v", sheet.SourceWidth(), sheet.SourceHeight())
+	}
+
+	cell := sheet.Cell(5)
+	if cell.Width() != 15 || cell.Height() != 15 {
+		t.Errorf("Cell dimensions did not match the cell size. Wanted: 15x15, got: %vx%v", cell.Width(), cell.Height())
+	}
+	expected := engo.AABB{Min: engo.Point{X: 16, Y: 16}, Max: engo.Point{X: 31, Y: 31}}
+	if cell.Region() != expected {
+		t.Errorf("Cell region was not its location on the sheet. Wanted: %v, got: %v", expected, cell.Region())
+	}
+}
+
+func TestTextureFilter(t *testing.T) {
+	tex := Texture{id: &gl.Texture{}}
+	other := Texture{id: &gl.Texture{}}
+	ren := &RenderComponent{Drawable: tex}
+	if mag, min := ren.filters(); mag != FilterNearest || min != FilterNearest {
+		t.Errorf("texture was not sampled using FilterNearest by default, got: %v and %v", mag, min)
+	}
+
+	tex.SetFilter(FilterLinear)
+	if mag, min := ren.filters(); mag != FilterLinear || min != FilterLinear {
+		t.Errorf("filter of the texture was not used, got: %v and %v", mag, min)
+	}
+	if other.Filter() != FilterNearest {
+		t.Error("filter of another texture changed")
+	}
+
+	ren.SetMinFilter(FilterNearest)
+	if mag, min := ren.filters(); mag != FilterLinear || min != FilterNearest {
+		t.Errorf("filter set on the RenderComponent did not override that of the texture, got: %v and %v", mag, min)
+	}
+
+	tex.Close()
+	if tex.Filter() != FilterNearest {
+		t.Error("filter of the texture was kept after closing it")
+	}
+}
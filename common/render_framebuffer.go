@@ -14,8 +14,14 @@ type Framebuffer struct {
 	fbo    *gl.FrameBuffer
 	oldVP  [4]int32
 	isOpen bool
+	// previous is the Framebuffer which was open when this one was opened, it's drawn into again once this one is
+	// closed
+	previous *Framebuffer
 }
 
+// openFramebuffer is the Framebuffer which is drawn into, or nil when drawing onto the screen
+var openFramebuffer *Framebuffer
+
 type RenderTexture struct {
 	tex           *gl.Texture
 	width, height float32
@@ -34,6 +40,20 @@ func CreateRenderBuffer(width, height int) *RenderBuffer {
 	return rbuf
 }
 
+// createDepthBuffer returns a RenderBuffer which stores depth rather than color, to be attached to a Framebuffer at
+// DEPTH_ATTACHMENT.
+func createDepthBuffer(width, height int) *RenderBuffer {
+	rbuf := &RenderBuffer{
+		rbo:    engo.Gl.CreateRenderBuffer(),
+		width:  width,
+		height: height,
+	}
+	engo.Gl.BindRenderBuffer(rbuf.rbo)
+	engo.Gl.RenderBufferStorage(engo.Gl.DEPTH_COMPONENT16, width, height)
+	engo.Gl.BindRenderBuffer(nil)
+	return rbuf
+}
+
 func CreateRenderTexture(width, height int, depthBuffer bool) *RenderTexture {
 	texBuf := &RenderTexture{
 		width:  float32(width),
@@ -45,9 +65,9 @@ func CreateRenderTexture(width, height int, depthBuffer bool) *RenderTexture {
 	engo.Gl.BindTexture(engo.Gl.TEXTURE_2D, texBuf.tex)
 
 	if depthBuffer {
-		engo.Gl.TexImage2DEmpty(engo.Gl.TEXTURE_2D, 0, engo.Gl.DEPTH_COMPONENT, width, height, engo.Gl.DEPTH_COMPONENT, engo.Gl.UNSIGNED_BYTE)
+		engo.Gl.TexImage2DEmpty(engo.Gl.TEXTURE_2D, 0, engo.Gl.DEPTH_COMPONENT, engo.Gl.DEPTH_COMPONENT, engo.Gl.UNSIGNED_BYTE, width, height)
 	} else {
-		engo.Gl.TexImage2DEmpty(engo.Gl.TEXTURE_2D, 0, engo.Gl.RGBA, width, height, engo.Gl.RGBA, engo.Gl.UNSIGNED_BYTE)
+		engo.Gl.TexImage2DEmpty(engo.Gl.TEXTURE_2D, 0, engo.Gl.RGBA, engo.Gl.RGBA, engo.Gl.UNSIGNED_BYTE, width, height)
 	}
 	if err := engo.Gl.GetError(); err != 0 {
 		panic(err)
@@ -112,15 +132,21 @@ func (fb *Framebuffer) Open(width, height int) {
 	fb.oldVP = engo.Gl.GetViewport()
 	engo.Gl.Viewport(0, 0, width, height)
 	fb.isOpen = true
+	fb.previous, openFramebuffer = openFramebuffer, fb
 }
 
 func (fb *Framebuffer) Close() {
 	if !fb.isOpen {
 		return
 	}
-	engo.Gl.BindFrameBuffer(nil)
+	if fb.previous != nil {
+		engo.Gl.BindFrameBuffer(fb.previous.fbo)
+	} else {
+		engo.Gl.BindFrameBuffer(nil)
+	}
 	engo.Gl.Viewport(int(fb.oldVP[0]), int(fb.oldVP[1]), int(fb.oldVP[2]), int(fb.oldVP[3]))
 	fb.isOpen = false
+	openFramebuffer, fb.previous = fb.previous, nil
 }
 
 func (fb *Framebuffer) Destroy() {
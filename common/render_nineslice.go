@@ -0,0 +1,141 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/engo/math"
+	"github.com/EngoEngine/gl"
+)
+
+// NineSlice is a Drawable which stretches a Texture to fill the Width and Height of the SpaceComponent, without
+// distorting its borders. The Texture is divided into nine parts by the four insets: the corners are drawn at their
+// own size, the edges are stretched along one axis, and the center is stretched along both. This makes it useful for
+// the backgrounds of resizable panels and buttons.
+//
+// The Scale of the RenderComponent still applies to the corners and edges, so a Scale of 2 draws them twice as large
+// while the NineSlice still fills the SpaceComponent. Whenever the SpaceComponent is smaller than the corners, they
+// are shrunk to fit.
+//
+// NineSlices are drawn by the DefaultShader and HUDShader.
+type NineSlice struct {
+	// Source is the Texture which is sliced, such as a cell of a Spritesheet.
+	Source Texture
+	// Left, Top, Right and Bottom are the widths of the borders of the Source, in pixels.
+	Left, Top, Right, Bottom float32
+}
+
+// LoadedNineSlice loads the texture-reference from `engo.Files`, and wraps the given region of it in a `*NineSlice`
+// with the given insets. The region is in pixels of the image; leaving it empty uses the entire image.
+func LoadedNineSlice(url string, region engo.AABB, left, top, right, bottom float32) (*NineSlice, error) {
+	res, err := engo.Files.Resource(url)
+	if err != nil {
+		return nil, err
+	}
+
+	img, ok := res.(TextureResource)
+	if !ok {
+		return nil, fmt.Errorf("resource not of type `TextureResource`: %s", url)
+	}
+
+	if region.Max.X <= region.Min.X || region.Max.Y <= region.Min.Y {
+		region = engo.AABB{Max: engo.Point{X: img.Width, Y: img.Height}}
+	}
+	return &NineSlice{
+		Source: Texture{
+			id:     img.Texture,
+			width:  region.Max.X - region.Min.X,
+			height: region.Max.Y - region.Min.Y,
+			viewport: engo.AABB{
+				Min: engo.Point{X: region.Min.X / img.Width, Y: region.Min.Y / img.Height},
+				Max: engo.Point{X: region.Max.X / img.Width, Y: region.Max.Y / img.Height},
+			},
+			region: region,
+			pixels: img.pixels,
+		},
+		Left:   left,
+		Top:    top,
+		Right:  right,
+		Bottom: bottom,
+	}, nil
+}
+
+// Texture returns the OpenGL ID of the Source.
+func (n NineSlice) Texture() *gl.Texture {
+	return n.Source.Texture()
+}
+
+// Width returns the width of the Source, in pixels. The NineSlice itself is drawn as wide as its SpaceComponent.
+func (n NineSlice) Width() float32 {
+	return n.Source.Width()
+}
+
+// Height returns the height of the Source, in pixels. The NineSlice itself is drawn as high as its SpaceComponent.
+func (n NineSlice) Height() float32 {
+	return n.Source.Height()
+}
+
+// View returns the viewport properties of the Source. The order is Min.X, Min.Y, Max.X, Max.Y.
+func (n NineSlice) View() (float32, float32, float32, float32) {
+	return n.Source.View()
+}
+
+// Close removes the Source from the GPU.
+func (n NineSlice) Close() {
+	n.Source.Close()
+}
+
+// nineSliceQuad is one of the nine parts of a NineSlice, spanning from (x1, y1) to (x2, y2), and showing the Source
+// from (u1, v1) to (u2, v2).
+type nineSliceQuad struct {
+	x1, y1, x2, y2 float32
+	u1, v1, u2, v2 float32
+}
+
+// quads returns the nine parts of the NineSlice, when drawn at the given size.
+func (n NineSlice) quads(width, height float32) [9]nineSliceQuad {
+	u1, v1, u2, v2 := n.Source.View()
+	xs, us := sliceAxis(width, n.Source.Width(), n.Left, n.Right, u1, u2)
+	ys, vs := sliceAxis(height, n.Source.Height(), n.Top, n.Bottom, v1, v2)
+
+	var quads [9]nineSliceQuad
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 3; col++ {
+			quads[row*3+col] = nineSliceQuad{
+				x1: xs[col], y1: ys[row], x2: xs[col+1], y2: ys[row+1],
+				u1: us[col], v1: vs[row], u2: us[col+1], v2: vs[row+1],
+			}
+		}
+	}
+	return quads
+}
+
+// sliceAxis returns where the borders of a NineSlice are along a single axis, both in the size it's drawn at, and on
+// the texture running from t1 to t2.
+func sliceAxis(size, source, start, end, t1, t2 float32) (positions, coords [4]float32) {
+	start, end = math.Max(start, 0), math.Max(end, 0)
+	// Borders which don't fit are shrunk, while still showing all of the border on the texture
+	scale := float32(1)
+	if borders := start + end; borders > size && borders > 0 {
+		scale = size / borders
+	}
+
+	positions = [4]float32{0, start * scale, size - end*scale, size}
+	coords = [4]float32{t1, t1, t2, t2}
+	if source > 0 {
+		coords[1] = t1 + (t2-t1)*start/source
+		coords[2] = t2 - (t2-t1)*end/source
+	}
+	return
+}
+
+// asNineSlice returns the NineSlice the Drawable is, if it's either a NineSlice or a pointer to one.
+func asNineSlice(d Drawable) (NineSlice, bool) {
+	switch n := d.(type) {
+	case NineSlice:
+		return n, true
+	case *NineSlice:
+		return *n, true
+	}
+	return NineSlice{}, false
+}
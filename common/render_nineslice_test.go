@@ -0,0 +1,166 @@
+package common
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/engo/math"
+)
+
+func newTestNineSlice() NineSlice {
+	return NineSlice{
+		Source: Texture{width: 30, height: 30, viewport: engo.AABB{Max: engo.Point{X: 1, Y: 1}}},
+		Left:   10, Top: 10, Right: 10, Bottom: 10,
+	}
+}
+
+func nineSliceQuadsEqual(a, b nineSliceQuad) bool {
+	equal := func(x, y float32) bool { return math.Abs(x-y) < 1e-6 }
+	return equal(a.x1, b.x1) && equal(a.y1, b.y1) && equal(a.x2, b.x2) && equal(a.y2, b.y2) &&
+		equal(a.u1, b.u1) && equal(a.v1, b.v1) && equal(a.u2, b.u2) && equal(a.v2, b.v2)
+}
+
+func TestNineSliceQuads(t *testing.T) {
+	n := newTestNineSlice()
+	third := float32(1) / 3
+
+	quads := n.quads(100, 50)
+	tests := []struct {
+		name     string
+		index    int
+		expected nineSliceQuad
+	}{
+		{"top left corner", 0, nineSliceQuad{0, 0, 10, 10, 0, 0, third, third}},
+		{"top edge", 1, nineSliceQuad{10, 0, 90, 10, third, 0, 2 * third, third}},
+		{"left edge", 3, nineSliceQuad{0, 10, 10, 40, 0, third, third, 2 * third}},
+		{"center", 4, nineSliceQuad{10, 10, 90, 40, third, third, 2 * third, 2 * third}},
+		{"bottom right corner", 8, nineSliceQuad{90, 40, 100, 50, 2 * third, 2 * third, 1, 1}},
+	}
+	for _, test := range tests {
+		if actual := quads[test.index]; !nineSliceQuadsEqual(actual, test.expected) {
+			t.Errorf("wrong %s. Wanted: %v, got: %v", test.name, test.expected, actual)
+		}
+	}
+
+	// Corners which don't fit are shrunk, while still showing the entire corner of the texture.
+	quads = n.quads(10, 50)
+	if expected := (nineSliceQuad{0, 0, 5, 10, 0, 0, third, third}); !nineSliceQuadsEqual(quads[0], expected) {
+		t.Errorf("corner was not shrunk to fit. Wanted: %v, got: %v", expected, quads[0])
+	}
+	if quads[4].x1 != quads[4].x2 {
+		t.Errorf("center was not left out when the corners fill the width, got: %v", quads[4])
+	}
+}
+
+func TestBasicShaderDrawNineSlice(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &testScene{})
+
+	s := &basicShader{BatchSize: 20, modelMatrix: engo.IdentityMatrix()}
+	s.vertices = make([]float32, s.BatchSize*spriteSize)
+
+	ren := &RenderComponent{Drawable: newTestNineSlice(), Color: color.White, Scale: engo.Point{X: 1, Y: 1}}
+	s.drawNineSlice(ren, &SpaceComponent{Position: engo.Point{X: 5, Y: 5}, Width: 100, Height: 50}, newTestNineSlice())
+	if s.idx != 9*spriteSize {
+		t.Fatalf("wrong number of quads were batched. Wanted: 9, got: %v", s.idx/spriteSize)
+	}
+	// The last vertex of the bottom right corner is its bottom left one, at (95, 55).
+	if x, y := s.vertices[8*spriteSize+15], s.vertices[8*spriteSize+16]; x != 95 || y != 55 {
+		t.Errorf("corner was not stretched to fill the SpaceComponent. Wanted: (95, 55), got: (%v, %v)", x, y)
+	}
+
+	// Parts of zero size are left out, and a larger Scale draws the borders larger, while still filling the space.
+	s.idx = 0
+	ren.Scale = engo.Point{X: 2, Y: 2}
+	s.drawNineSlice(ren, &SpaceComponent{Width: 40, Height: 100}, newTestNineSlice())
+	if s.idx != 6*spriteSize {
+		t.Fatalf("parts without a size were batched. Wanted: 6 quads, got: %v", s.idx/spriteSize)
+	}
+	if x, y := s.vertices[5*spriteSize+10], s.vertices[5*spriteSize+11]; x != 40 || y != 100 {
+		t.Errorf("scaled NineSlice did not fill the SpaceComponent. Wanted: (40, 100), got: (%v, %v)", x, y)
+	}
+
+	// The SpaceComponent is used for culling rather than the size of the Source.
+	s.cameraEnabled = true
+	s.camera = &CameraSystem{x: 400, y: 300, z: 1}
+	s.projectionMatrix, s.viewMatrix, s.cullingMatrix = engo.IdentityMatrix(), engo.IdentityMatrix(), engo.IdentityMatrix()
+	s.PrepareCulling()
+	ren.Scale = engo.Point{X: 1, Y: 1}
+	if !s.ShouldDraw(ren, &SpaceComponent{Position: engo.Point{X: -950, Y: 100}, Width: 1000, Height: 50}) {
+		t.Error("NineSlice reaching onto the screen was culled")
+	}
+}
+
+func TestLoadedNineSlice(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &textureTestScene{})
+
+	loadTestImage(t, "panel.png", 64, 32)
+
+	n, err := LoadedNineSlice("panel.png", engo.AABB{Min: engo.Point{X: 32}, Max: engo.Point{X: 64, Y: 32}}, 4, 4, 4, 4)
+	if err != nil {
+		t.Fatalf("Unable to retrieve loaded nine-slice. Error was: %v", err)
+	}
+	if n.Width() != 32 || n.Height() != 32 {
+		t.Errorf("NineSlice dimensions did not match the region. Wanted: 32x32, got: %vx%v", n.Width(), n.Height())
+	}
+	if u1, v1, u2, v2 := n.View(); u1 != 0.5 || v1 != 0 || u2 != 1 || v2 != 1 {
+		t.Errorf("NineSlice viewport did not match the region. Wanted: (0.5, 0, 1, 1), got: (%v, %v, %v, %v)", u1, v1, u2, v2)
+	}
+
+	n, err = LoadedNineSlice("panel.png", engo.AABB{}, 4, 4, 4, 4)
+	if err != nil {
+		t.Fatalf("Unable to retrieve loaded nine-slice. Error was: %v", err)
+	}
+	if n.Width() != 64 || n.Height() != 32 {
+		t.Errorf("NineSlice without a region did not use the entire image. Wanted: 64x32, got: %vx%v", n.Width(), n.Height())
+	}
+}
+
+func TestBasicShaderFlip(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &testScene{})
+
+	s := &basicShader{BatchSize: 20, modelMatrix: engo.IdentityMatrix()}
+	s.vertices = make([]float32, s.BatchSize*spriteSize)
+	tex := Texture{width: 20, height: 10, viewport: engo.AABB{Min: engo.Point{X: 0.25}, Max: engo.Point{X: 0.5, Y: 1}}}
+	ren := &RenderComponent{Drawable: tex, Color: color.White, Scale: engo.Point{X: 1, Y: 1}}
+	space := &SpaceComponent{Position: engo.Point{X: 5, Y: 5}, Width: 20, Height: 10}
+	aabb := space.AABB()
+
+	buffer := make([]float32, spriteSize)
+	s.generateBufferContent(ren, space, buffer)
+	ren.FlipX = true
+	flipped := make([]float32, spriteSize)
+	s.generateBufferContent(ren, space, flipped)
+
+	// Only the texture coordinates are mirrored, every vertex stays where it was.
+	for i := 0; i < spriteSize; i += 5 {
+		if flipped[i] != buffer[i] || flipped[i+1] != buffer[i+1] {
+			t.Errorf("vertex %v moved when flipped. Wanted: (%v, %v), got: (%v, %v)", i/5, buffer[i], buffer[i+1], flipped[i], flipped[i+1])
+		}
+	}
+	if u1, u2 := flipped[2], flipped[7]; u1 != 0.5 || u2 != 0.25 {
+		t.Errorf("texture was not flipped horizontally. Wanted: u from 0.5 to 0.25, got: %v to %v", u1, u2)
+	}
+	if v1, v2 := flipped[3], flipped[13]; v1 != 0 || v2 != 1 {
+		t.Errorf("texture was flipped vertically. Wanted: v from 0 to 1, got: %v to %v", v1, v2)
+	}
+	if space.AABB() != aabb {
+		t.Errorf("AABB changed when flipped. Wanted: %v, got: %v", aabb, space.AABB())
+	}
+
+	// The parts of a NineSlice are mirrored as well, so the left corner is drawn on the right.
+	ren.Drawable = newTestNineSlice()
+	s.drawNineSlice(ren, &SpaceComponent{Width: 100, Height: 50}, newTestNineSlice())
+	if x1, x2, u2 := s.vertices[0], s.vertices[5], s.vertices[7]; x1 != 90 || x2 != 100 || u2 != 0 {
+		t.Errorf("NineSlice corner was not mirrored. Wanted: x from 90 to 100 ending at u 0, got: %v to %v ending at u %v", x1, x2, u2)
+	}
+}
@@ -0,0 +1,57 @@
+package common
+
+import (
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+// ParallaxComponent makes an entity move at a fraction of the speed of the camera, such as for the background layers
+// of a side-scroller. Multiple layers with different factors create depth. Add the entity to the RenderSystem using
+// `RenderSystem.AddWithParallax`, or AddByInterface.
+//
+// The entity is drawn where the camera would see it if the camera had only moved by Factor times its distance from
+// Origin, so it's drawn at the position of its SpaceComponent whenever the camera is at Origin. The zoom and rotation of
+// the camera apply to the entity like to any other, around the center of the screen, so a layer fixed to the screen
+// is still zoomed in and out; put the entity on the HUD instead to keep it from zooming. Within views, the offset is
+// relative to the position of every view.
+//
+// Parallax only affects where the entity is drawn: the MouseSystem, CollisionSystem and everything else see the
+// entity at the position of its SpaceComponent in the world, so hit testing a layer which is offset by its parallax
+// is off. Keep parallax layers decorative, or use the HUD for layers which have to be clicked.
+type ParallaxComponent struct {
+	// Factor is how fast the entity moves along with the camera, per axis: at 0 it's fixed to the screen, at 1 it
+	// moves with the world like entities without a ParallaxComponent do, and in between it moves slower, as if it's
+	// further away
+	Factor engo.Point
+	// Origin is the position of the camera at which the entity is drawn at the position of its SpaceComponent, which
+	// is usually where the camera starts, such as the center of the game
+	Origin engo.Point
+}
+
+// position returns the position in the world at which the entity at the given position is drawn, while the camera is
+// at the given position.
+func (p *ParallaxComponent) position(position, camera engo.Point) engo.Point {
+	return engo.Point{
+		X: position.X + (1-p.Factor.X)*(camera.X-p.Origin.X),
+		Y: position.Y + (1-p.Factor.Y)*(camera.Y-p.Origin.Y),
+	}
+}
+
+// AddWithParallax adds an entity to the RenderSystem which is drawn offset by its parallax, see ParallaxComponent.
+// Without a parallax component, it's the same as Add.
+func (rs *RenderSystem) AddWithParallax(basic *ecs.BasicEntity, render *RenderComponent, space *SpaceComponent, parallax *ParallaxComponent) {
+	rs.add(basic, render, space, parallax)
+}
+
+// camera returns the CameraSystem of the world of the RenderSystem, or nil if there is none.
+func (rs *RenderSystem) camera() *CameraSystem {
+	if rs.world == nil {
+		return nil
+	}
+	for _, system := range rs.world.Systems() {
+		if c, ok := system.(*CameraSystem); ok {
+			return c
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+type positionShader struct {
+	setupCountingShader
+	drawn []engo.Point
+}
+
+func (s *positionShader) Draw(_ *RenderComponent, space *SpaceComponent) {
+	s.drawn = append(s.drawn, space.Position)
+}
+
+type parallaxEntity struct {
+	ecs.BasicEntity
+	RenderComponent
+	SpaceComponent
+	ParallaxComponent
+}
+
+func TestRenderSystemParallax(t *testing.T) {
+	engo.Mailbox = &engo.MessageManager{}
+	engo.SetGlobalScale(engo.Point{X: 1, Y: 1})
+	w := &ecs.World{}
+	cam := &CameraSystem{}
+	w.AddSystem(cam)
+	rs := &RenderSystem{world: w, ids: make(map[uint64]struct{})}
+
+	shader := &positionShader{}
+	factors := []engo.Point{{X: 0, Y: 0}, {X: 0.5, Y: 1}, {X: 1, Y: 1}}
+	for i, factor := range factors {
+		e := &parallaxEntity{BasicEntity: ecs.NewBasic()}
+		e.RenderComponent = RenderComponent{Drawable: Rectangle{}, StartZIndex: float32(i)}
+		e.RenderComponent.SetShader(shader)
+		e.SpaceComponent = SpaceComponent{Position: engo.Point{X: 10, Y: 20}}
+		e.ParallaxComponent = ParallaxComponent{Factor: factor, Origin: engo.Point{X: 100, Y: 50}}
+		rs.AddByInterface(e)
+	}
+	plain := ecs.NewBasic()
+	ren := &RenderComponent{Drawable: Rectangle{}, StartZIndex: 3}
+	ren.SetShader(shader)
+	rs.Add(&plain, ren, &SpaceComponent{Position: engo.Point{X: 10, Y: 20}})
+	rs.sortingNeeded = false
+
+	// The camera moved 40 units to the right and 10 units down from the origin of the layers
+	cam.x, cam.y = 140, 60
+	rs.draw(false)
+	expected := []engo.Point{{X: 50, Y: 30}, {X: 30, Y: 20}, {X: 10, Y: 20}, {X: 10, Y: 20}}
+	if len(shader.drawn) != len(expected) {
+		t.Fatalf("wrong number of entities drawn. Wanted: %v, got: %v", len(expected), len(shader.drawn))
+	}
+	for i, p := range expected {
+		if shader.drawn[i] != p {
+			t.Errorf("entity %v was drawn at the wrong position. Wanted: %v, got: %v", i, p, shader.drawn[i])
+		}
+	}
+	for _, e := range rs.entities {
+		if e.Position != (engo.Point{X: 10, Y: 20}) {
+			t.Errorf("parallax moved the entity in the world to %v", e.Position)
+		}
+	}
+	// The location of the camera is multiplied by the GlobalScale, while the layers are in world units
+	engo.SetGlobalScale(engo.Point{X: 2, Y: 2})
+	defer engo.SetGlobalScale(engo.Point{X: 1, Y: 1})
+	cam.x, cam.y = 280, 120
+	shader.drawn = nil
+	rs.draw(false)
+	for i, p := range expected {
+		if i < len(shader.drawn) && shader.drawn[i] != p {
+			t.Errorf("entity %v was drawn at the wrong position with a GlobalScale. Wanted: %v, got: %v", i, p, shader.drawn[i])
+		}
+	}
+}
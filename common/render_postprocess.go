@@ -0,0 +1,197 @@
+package common
+
+import (
+	"log"
+
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/gl"
+)
+
+// PostProcessPass is a full-screen effect, such as bloom or a vignette, which is applied by the RenderSystem to
+// everything drawn before the HUD. See `RenderSystem.AddPostProcess`.
+//
+// The effect is a fragment shader, which is run for every pixel of the screen, reading the frame as drawn so far (or
+// as output by the pass before it) from the texture `uf_Texture`. The shader is given the following:
+//
+//	varying vec2 var_TexCoords;   // the position of the pixel in uf_Texture, from (0, 0) at the bottom left
+//	uniform sampler2D uf_Texture; // the frame so far
+//	uniform vec2 uf_Resolution;   // the size of the frame in pixels
+//
+// Like everything drawn offscreen, uf_Texture is stored bottom-up, so the top of the screen is at a y of 1. Any other
+// uniforms can be set using SetUniform and SetTexture.
+type PostProcessPass struct {
+	// Disabled skips the pass, without removing it from the RenderSystem
+	Disabled bool
+
+	program  *ShaderProgram
+	uniforms Uniforms
+}
+
+// NewPostProcessPass compiles the fragment shader of a post-processing pass, for which the vertex shader is provided.
+// It has to be created after the RenderSystem was added to the world, since that initializes OpenGL.
+func NewPostProcessPass(fragmentShader string) (*PostProcessPass, error) {
+	p := &PostProcessPass{}
+	if engo.Headless() {
+		return p, nil
+	}
+
+	var err error
+	if p.program, err = NewShaderProgram(scaledVertexShader, fragmentShader); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// SetUniform sets the uniform with the given name to the given values, from the next frame on. One to four values
+// set a float, vec2, vec3 or vec4 respectively. The uniform keeps its value until it's set again, so it only has to
+// be set every frame if it changes.
+func (p *PostProcessPass) SetUniform(name string, values ...float32) {
+	switch len(values) {
+	case 1:
+		p.uniforms.SetFloat(name, values[0])
+	case 2:
+		p.uniforms.SetVec2(name, values[0], values[1])
+	case 3:
+		p.uniforms.SetVec3(name, values[0], values[1], values[2])
+	case 4:
+		p.uniforms.SetVec4(name, values[0], values[1], values[2], values[3])
+	default:
+		log.Println("[WARNING] [PostProcessPass]: Unable to set uniform", name, "to", len(values), "values, it takes one to four")
+	}
+}
+
+// SetTexture sets the sampler2D uniform with the given name to the texture of the Drawable, such as a lookup table
+// for color grading. See `Uniforms.SetTexture`.
+func (p *PostProcessPass) SetTexture(name string, unit int, d Drawable) {
+	p.uniforms.SetTexture(name, unit, d)
+}
+
+// Uniform returns the values to which the uniform with the given name was set, or nil if it wasn't set.
+func (p *PostProcessPass) Uniform(name string) []float32 {
+	values, _ := p.uniforms.Float(name)
+	return values
+}
+
+// draw runs the fragment shader over the entire frame, reading from src, which is of the given size.
+func (p *PostProcessPass) draw(src *RenderTexture, width, height int, buffer *gl.Buffer) {
+	p.program.Use()
+	p.uniforms.Apply(p.program)
+	engo.Gl.BindTexture(engo.Gl.TEXTURE_2D, src.Texture())
+	engo.Gl.Uniform2f(p.program.Uniform("uf_Resolution"), float32(width), float32(height))
+
+	inPosition := p.program.Attribute("in_Position")
+	engo.Gl.BindBuffer(engo.Gl.ARRAY_BUFFER, buffer)
+	engo.Gl.EnableVertexAttribArray(inPosition)
+	engo.Gl.VertexAttribPointer(inPosition, 2, engo.Gl.FLOAT, false, 8, 0)
+
+	engo.Gl.DrawArrays(engo.Gl.TRIANGLE_STRIP, 0, 4)
+	countDrawCall(2)
+
+	engo.Gl.DisableVertexAttribArray(inPosition)
+	engo.Gl.BindBuffer(engo.Gl.ARRAY_BUFFER, nil)
+	engo.Gl.BindTexture(engo.Gl.TEXTURE_2D, nil)
+}
+
+// postProcessor draws the frame into an offscreen target whenever there are post-processing passes, and runs the
+// passes one after another at the end of the "postprocess" render pass, each reading the output of the pass before
+// it. The last pass draws onto the screen.
+type postProcessor struct {
+	passes []*PostProcessPass
+	active []*PostProcessPass // the passes which aren't disabled, this frame
+
+	buffer        *gl.Buffer
+	framebuffer   *Framebuffer
+	targets       [2]*RenderTexture
+	width, height int
+	drawing       bool
+}
+
+// begin redirects all drawing to an offscreen target of the size of the current viewport, if there are passes to
+// run. It returns whether it did.
+func (p *postProcessor) begin() bool {
+	p.active = p.active[:0]
+	for _, pass := range p.passes {
+		if !pass.Disabled {
+			p.active = append(p.active, pass)
+		}
+	}
+	if len(p.active) == 0 {
+		return false
+	}
+
+	if p.framebuffer == nil {
+		// A single quad covering the canvas, drawn as a triangle strip
+		p.buffer = engo.Gl.CreateBuffer()
+		engo.Gl.BindBuffer(engo.Gl.ARRAY_BUFFER, p.buffer)
+		engo.Gl.BufferData(engo.Gl.ARRAY_BUFFER, []float32{-1, -1, 1, -1, -1, 1, 1, 1}, engo.Gl.STATIC_DRAW)
+		engo.Gl.BindBuffer(engo.Gl.ARRAY_BUFFER, nil)
+		p.framebuffer = CreateFramebuffer()
+	}
+
+	// The viewport is that of the screen, or of the render scale (see `engo.SetRenderScale`)
+	viewport := engo.Gl.GetViewport()
+	w, h := int(viewport[2]), int(viewport[3])
+	if p.targets[0] == nil || w != p.width || h != p.height {
+		for i := range p.targets {
+			if p.targets[i] != nil {
+				p.targets[i].Close()
+			}
+			p.targets[i] = CreateRenderTexture(w, h, false)
+			engo.Gl.TexParameteri(engo.Gl.TEXTURE_2D, engo.Gl.TEXTURE_MAG_FILTER, engo.Gl.LINEAR)
+			engo.Gl.TexParameteri(engo.Gl.TEXTURE_2D, engo.Gl.TEXTURE_MIN_FILTER, engo.Gl.LINEAR)
+			engo.Gl.TexParameteri(engo.Gl.TEXTURE_2D, engo.Gl.TEXTURE_WRAP_S, engo.Gl.CLAMP_TO_EDGE)
+			engo.Gl.TexParameteri(engo.Gl.TEXTURE_2D, engo.Gl.TEXTURE_WRAP_T, engo.Gl.CLAMP_TO_EDGE)
+		}
+		engo.Gl.BindTexture(engo.Gl.TEXTURE_2D, nil)
+		p.width, p.height = w, h
+	}
+
+	p.framebuffer.Open(w, h)
+	p.targets[0].Bind()
+	p.drawing = true
+	return true
+}
+
+// draw runs every pass, and draws the output of the last one onto the screen.
+func (p *postProcessor) draw() {
+	if !p.drawing {
+		return
+	}
+	p.drawing = false
+
+	// Every pass replaces the frame, rather than being blended onto it
+	engo.Gl.Disable(engo.Gl.BLEND)
+	for i, pass := range p.active {
+		if i == len(p.active)-1 {
+			p.framebuffer.Close()
+		} else {
+			p.targets[(i+1)%2].Bind()
+		}
+		pass.draw(p.targets[i%2], p.width, p.height, p.buffer)
+	}
+	engo.Gl.Enable(engo.Gl.BLEND)
+}
+
+// AddPostProcess adds a post-processing pass, which is run after the passes which were added before it. Whenever
+// there are post-processing passes, the world is drawn into an offscreen target, after which every pass is run in
+// the "postprocess" render pass, which is drawn at `engo.RenderPassPost`. Render passes drawn afterwards, such as the
+// HUD, are drawn onto the screen as usual.
+func (rs *RenderSystem) AddPostProcess(p *PostProcessPass) {
+	for _, pass := range rs.post.passes {
+		if pass == p {
+			return
+		}
+	}
+	rs.post.passes = append(rs.post.passes, p)
+}
+
+// RemovePostProcess removes a post-processing pass. Once there are no passes left, the world is drawn directly onto
+// the screen again.
+func (rs *RenderSystem) RemovePostProcess(p *PostProcessPass) {
+	for i, pass := range rs.post.passes {
+		if pass == p {
+			rs.post.passes = append(rs.post.passes[:i], rs.post.passes[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/EngoEngine/engo"
+)
+
+func TestPostProcessPass(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &testScene{})
+
+	pass, err := NewPostProcessPass(scaledFragmentShader)
+	if err != nil {
+		t.Fatalf("Unable to create post-processing pass. Error was: %v", err)
+	}
+	pass.SetUniform("uf_Strength", 0.5)
+	pass.SetUniform("uf_Center", 0.25, 0.75)
+	pass.SetUniform("uf_Invalid", 1, 2, 3, 4, 5)
+	if v := pass.Uniform("uf_Center"); len(v) != 2 || v[0] != 0.25 || v[1] != 0.75 {
+		t.Errorf("uniform was not set. Wanted: [0.25 0.75], got: %v", v)
+	}
+	if v := pass.Uniform("uf_Invalid"); v != nil {
+		t.Errorf("uniform with more than four values was set, got: %v", v)
+	}
+
+	rs := &RenderSystem{}
+	rs.AddPostProcess(pass)
+	rs.AddPostProcess(pass)
+	if len(rs.post.passes) != 1 {
+		t.Errorf("pass was added more than once, got: %v passes", len(rs.post.passes))
+	}
+	for _, p := range rs.passes() {
+		if p.Name == "postprocess" {
+			t.Error("post-processing was drawn outside of a frame")
+		}
+	}
+
+	// Disabled passes are skipped, so the world is drawn directly onto the screen when all are disabled.
+	pass.Disabled = true
+	if rs.post.begin() {
+		t.Error("post-processing began without any enabled passes")
+	}
+	rs.RemovePostProcess(pass)
+	if len(rs.post.passes) != 0 {
+		t.Error("pass was not removed")
+	}
+}
@@ -0,0 +1,132 @@
+package common
+
+import (
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/gl"
+)
+
+const (
+	scaledVertexShader = `
+	attribute vec2 in_Position;
+
+	varying vec2 var_TexCoords;
+
+	void main() {
+	  var_TexCoords = (in_Position + 1.0) / 2.0;
+	  gl_Position = vec4(in_Position, 0, 1);
+	}
+`
+
+	scaledFragmentShader = `
+	#ifdef GL_ES
+	precision mediump float;
+	#endif
+
+	varying vec2 var_TexCoords;
+
+	uniform sampler2D uf_Texture;
+
+	void main (void) {
+	  gl_FragColor = texture2D(uf_Texture, var_TexCoords);
+	}
+`
+)
+
+// renderScaler draws the scene to an offscreen target, whose size is the size of the viewport multiplied by the
+// render scale, and stretches that target over the viewport afterwards. See `engo.SetRenderScale`.
+type renderScaler struct {
+	program    *gl.Program
+	buffer     *gl.Buffer
+	inPosition int
+
+	framebuffer   *Framebuffer
+	target        *RenderTexture
+	width, height int
+}
+
+// setup compiles the shader used for stretching the offscreen target over the canvas.
+func (r *renderScaler) setup() error {
+	var err error
+	r.program, err = LoadShader(scaledVertexShader, scaledFragmentShader)
+	if err != nil {
+		return err
+	}
+	r.inPosition = engo.Gl.GetAttribLocation(r.program, "in_Position")
+
+	// A single quad covering the canvas, drawn as a triangle strip
+	r.buffer = engo.Gl.CreateBuffer()
+	engo.Gl.BindBuffer(engo.Gl.ARRAY_BUFFER, r.buffer)
+	engo.Gl.BufferData(engo.Gl.ARRAY_BUFFER, []float32{-1, -1, 1, -1, -1, 1, 1, 1}, engo.Gl.STATIC_DRAW)
+	engo.Gl.BindBuffer(engo.Gl.ARRAY_BUFFER, nil)
+
+	r.framebuffer = CreateFramebuffer()
+	return nil
+}
+
+// scaledSize returns the size of the offscreen target for a canvas of the given size. It is at least one pixel in
+// either direction.
+func scaledSize(width, height, scale float32) (int, int) {
+	w, h := int(width*scale), int(height*scale)
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	return w, h
+}
+
+// begin redirects all drawing to the offscreen target, recreating it whenever the canvas or the render scale
+// changed size.
+func (r *renderScaler) begin(scale float32) {
+	// The viewport is that of the game within the canvas, see `engo.GameViewport`
+	viewport := engo.Gl.GetViewport()
+	w, h := scaledSize(float32(viewport[2]), float32(viewport[3]), scale)
+	if r.target == nil || w != r.width || h != r.height {
+		if r.target != nil {
+			r.target.Close()
+		}
+		r.target = CreateRenderTexture(w, h, false)
+		engo.Gl.TexParameteri(engo.Gl.TEXTURE_2D, engo.Gl.TEXTURE_MAG_FILTER, engo.Gl.LINEAR)
+		engo.Gl.TexParameteri(engo.Gl.TEXTURE_2D, engo.Gl.TEXTURE_MIN_FILTER, engo.Gl.LINEAR)
+		engo.Gl.BindTexture(engo.Gl.TEXTURE_2D, nil)
+		r.width, r.height = w, h
+	}
+
+	r.framebuffer.Open(w, h)
+	r.target.Bind()
+}
+
+// end draws the offscreen target over the viewport.
+func (r *renderScaler) end() {
+	r.framebuffer.Close()
+
+	engo.Gl.UseProgram(r.program)
+	engo.Gl.BindTexture(engo.Gl.TEXTURE_2D, r.target.Texture())
+	engo.Gl.BindBuffer(engo.Gl.ARRAY_BUFFER, r.buffer)
+	engo.Gl.EnableVertexAttribArray(r.inPosition)
+	engo.Gl.VertexAttribPointer(r.inPosition, 2, engo.Gl.FLOAT, false, 8, 0)
+
+	engo.Gl.DrawArrays(engo.Gl.TRIANGLE_STRIP, 0, 4)
+	countDrawCall(2)
+
+	engo.Gl.DisableVertexAttribArray(r.inPosition)
+	engo.Gl.BindBuffer(engo.Gl.ARRAY_BUFFER, nil)
+	engo.Gl.BindTexture(engo.Gl.TEXTURE_2D, nil)
+}
+
+// setViewport restricts drawing to the part of the canvas the game is drawn in, see `engo.GameViewport`. Whenever the
+// game doesn't cover the whole canvas, such as with the ScaleFit mode, the canvas is cleared to black, so those parts
+// become black bars, and the viewport is returned to clear the game itself.
+func setViewport() (x, y, width, height int, letterboxed bool) {
+	x, y, width, height = engo.CanvasViewport()
+	engo.Gl.Viewport(x, y, width, height)
+	if x <= 0 && y <= 0 && x+width >= int(engo.CanvasWidth()) && y+height >= int(engo.CanvasHeight()) {
+		return x, y, width, height, false
+	}
+
+	engo.Gl.ClearColor(0, 0, 0, 1)
+	engo.Gl.Clear(engo.Gl.COLOR_BUFFER_BIT)
+	setClearColor(background)
+	return x, y, width, height, true
+}
@@ -13,7 +13,8 @@ import (
 	"github.com/EngoEngine/gl"
 )
 
-// UnicodeCap is the amount of unicode characters the fonts will be able to use, starting from index 0.
+// UnicodeCap is the amount of unicode characters the fonts will be able to use, starting from index 0. Characters
+// beyond it are added to the FontAtlas of a Font whenever a `Text` uses them.
 var UnicodeCap = 200
 
 const (
@@ -72,6 +73,16 @@ const (
 // Draw is the Draw step.
 //
 // Post is called just after the Draw step.
+//
+// A custom shader is registered using AddShader, and used by an entity by passing it to `RenderComponent.SetShader`.
+// Setup is called once, when OpenGL is initialized. Every frame, the RenderSystem draws its entities in order, and
+// calls Pre before the first entity using the shader in a row, Draw for each of them, and Post after the last one,
+// so a shader batching entities has to flush its batch in Post. SetCamera is called whenever the camera changes,
+// with the camera entities should be drawn relative to. Shaders which also implement CullingShader draw the view of
+// any RenderTarget, and are culled unless `RenderSystem.NoCulling` is set.
+//
+// ShaderProgram takes care of compiling the program and setting its uniforms, using the Uniforms which can be set
+// per frame on the shader, or per entity on `RenderComponent.Uniforms`.
 type Shader interface {
 	Setup(*ecs.World) error
 	Pre()
@@ -88,6 +99,71 @@ type CullingShader interface {
 	ShouldDraw(*RenderComponent, *SpaceComponent) bool
 }
 
+// screenSize returns the size of the screen the shaders project onto, in game coordinates. While the RenderSystem
+// draws a View, it's the size of the part of the screen the view covers.
+func screenSize() (width, height float32) {
+	if engo.ScaleOnResize() {
+		width, height = engo.GameWidth(), engo.GameHeight()
+	} else {
+		width, height = engo.CanvasWidth()/engo.CanvasScale(), engo.CanvasHeight()/engo.CanvasScale()
+	}
+	if drawingView != nil {
+		size := drawingView.size()
+		width, height = width*size.X, height*size.Y
+	}
+	return width, height
+}
+
+// viewCuller tests whether entities are within the view of the camera, for shaders which don't track the combined
+// projection and view matrix themselves.
+type viewCuller struct {
+	matrix engo.Matrix
+}
+
+// prepare updates the matrix which transforms game coordinates into the [-1, 1] range of the screen, the same way
+// basicShader.PrepareCulling does.
+func (v *viewCuller) prepare(camera *CameraSystem, cameraEnabled bool) {
+	projection := engo.IdentityMatrix()
+	width, height := screenSize()
+	projection.Scale(1/(width/2), 1/(-height/2))
+	view := engo.IdentityMatrix()
+	if cameraEnabled && camera != nil {
+		view.Scale(1/camera.renderZ(), yAxis()/camera.renderZ())
+		view.Rotate(camera.angle).Translate(-camera.renderX(), -camera.renderY())
+	} else {
+		scaleX, scaleY := projection.ScaleComponent()
+		view.Translate(-1/scaleX, 1/scaleY)
+	}
+	v.matrix.Identity()
+	v.matrix.Multiply(projection).Multiply(view)
+	v.matrix.Scale(engo.GetGlobalScale().X, engo.GetGlobalScale().Y)
+}
+
+// visible returns whether an entity of at most the given size, drawn at the given position, may be on screen. The
+// square around the position which contains the entity at any rotation is tested, so entities are only culled when
+// they're off screen no matter how they're rotated.
+func (v *viewCuller) visible(position engo.Point, width, height float32) bool {
+	r := math.Sqrt(width*width + height*height)
+	c := [4]engo.Point{
+		{X: position.X - r, Y: position.Y - r},
+		{X: position.X + r, Y: position.Y - r},
+		{X: position.X - r, Y: position.Y + r},
+		{X: position.X + r, Y: position.Y + r},
+	}
+	for i := range c {
+		c[i].MultiplyMatrixVector(&v.matrix)
+	}
+
+	return !((c[0].X < -1 && c[1].X < -1 && c[2].X < -1 && c[3].X < -1) || // All points left of the "viewport"
+		(c[0].X > 1 && c[1].X > 1 && c[2].X > 1 && c[3].X > 1) || // All points right of the "viewport"
+		(c[0].Y < -1 && c[1].Y < -1 && c[2].Y < -1 && c[3].Y < -1) || // All points above of the "viewport"
+		(c[0].Y > 1 && c[1].Y > 1 && c[2].Y > 1 && c[3].Y > 1)) // All points below of the "viewport"
+}
+
+// basicShader draws sprites in batches. Consecutive sprites which share a texture, along with its filters and
+// repeating, are put into a single vertex buffer and drawn using a single draw call. Since the RenderSystem sorts
+// entities by their shader and texture within each z-index, that's one draw call per texture per z-index, for every
+// BatchSize sprites. 10000 sprites sharing a single atlas are drawn using two draw calls, rather than 10000.
 type basicShader struct {
 	BatchSize int
 
@@ -200,16 +276,13 @@ func (s *basicShader) PrepareCulling() {
 	s.projViewChange = true
 	// (Re)initialize the projection matrix.
 	s.projectionMatrix.Identity()
-	if engo.ScaleOnResize() {
-		s.projectionMatrix.Scale(1/(engo.GameWidth()/2), 1/(-engo.GameHeight()/2))
-	} else {
-		s.projectionMatrix.Scale(1/(engo.CanvasWidth()/(2*engo.CanvasScale())), 1/(-engo.CanvasHeight()/(2*engo.CanvasScale())))
-	}
+	width, height := screenSize()
+	s.projectionMatrix.Scale(1/(width/2), 1/(-height/2))
 	// (Re)initialize the view matrix
 	s.viewMatrix.Identity()
 	if s.cameraEnabled {
-		s.viewMatrix.Scale(1/s.camera.z, 1/s.camera.z)
-		s.viewMatrix.Translate(-s.camera.x, -s.camera.y).Rotate(s.camera.angle)
+		s.viewMatrix.Scale(1/s.camera.renderZ(), yAxis()/s.camera.renderZ())
+		s.viewMatrix.Rotate(s.camera.angle).Translate(-s.camera.renderX(), -s.camera.renderY())
 	} else {
 		scaleX, scaleY := s.projectionMatrix.ScaleComponent()
 		s.viewMatrix.Translate(-1/scaleX, 1/scaleY)
@@ -220,12 +293,16 @@ func (s *basicShader) PrepareCulling() {
 }
 
 func (s *basicShader) ShouldDraw(rc *RenderComponent, sc *SpaceComponent) bool {
+	w, h, _, _, _, _ := repeatView(rc, sc)
 	tsc := SpaceComponent{
 		Position: sc.Position,
-		Width:    rc.Drawable.Width() * rc.Scale.X,
-		Height:   rc.Drawable.Height() * rc.Scale.Y,
+		Width:    w * rc.Scale.X,
+		Height:   h * rc.Scale.Y,
 		Rotation: sc.Rotation,
 	}
+	if _, ok := asNineSlice(rc.Drawable); ok {
+		tsc.Width, tsc.Height = sc.Width, sc.Height
+	}
 
 	c := tsc.Corners()
 	c[0].MultiplyMatrixVector(s.cullingMatrix)
@@ -270,10 +347,11 @@ func (s *basicShader) Draw(ren *RenderComponent, space *SpaceComponent) {
 		s.lastRepeating = ren.Repeat
 	}
 
-	if s.lastMagFilter != ren.magFilter {
+	magFilter, minFilter := ren.filters()
+	if s.lastMagFilter != magFilter {
 		s.flush()
 		var val int
-		switch ren.magFilter {
+		switch magFilter {
 		case FilterNearest:
 			val = engo.Gl.NEAREST
 		case FilterLinear:
@@ -281,13 +359,13 @@ func (s *basicShader) Draw(ren *RenderComponent, space *SpaceComponent) {
 		}
 		engo.Gl.TexParameteri(engo.Gl.TEXTURE_2D, engo.Gl.TEXTURE_MAG_FILTER, val)
 
-		s.lastMagFilter = ren.magFilter
+		s.lastMagFilter = magFilter
 	}
 
-	if s.lastMinFilter != ren.minFilter {
+	if s.lastMinFilter != minFilter {
 		s.flush()
 		var val int
-		switch ren.minFilter {
+		switch minFilter {
 		case FilterNearest:
 			val = engo.Gl.NEAREST
 		case FilterLinear:
@@ -295,7 +373,12 @@ func (s *basicShader) Draw(ren *RenderComponent, space *SpaceComponent) {
 		}
 		engo.Gl.TexParameteri(engo.Gl.TEXTURE_2D, engo.Gl.TEXTURE_MIN_FILTER, val)
 
-		s.lastMinFilter = ren.minFilter
+		s.lastMinFilter = minFilter
+	}
+
+	if n, ok := asNineSlice(ren.Drawable); ok {
+		s.drawNineSlice(ren, space, n)
+		return
 	}
 
 	// Update the vertex buffer data.
@@ -332,17 +415,12 @@ func (s *basicShader) flush() {
 	if s.idx == 0 {
 		return
 	}
-	engo.Gl.BufferData(engo.Gl.ARRAY_BUFFER, s.vertices, engo.Gl.STATIC_DRAW)
-	// We only want to draw the indicies up to the number of sprites in the current batch.
+	// Only the sprites in the current batch are uploaded and drawn, so the rest of the buffer never has to be cleared.
+	engo.Gl.BufferData(engo.Gl.ARRAY_BUFFER, s.vertices[:s.idx], engo.Gl.STATIC_DRAW)
 	count := s.idx / 20 * 6
 	engo.Gl.DrawElements(engo.Gl.TRIANGLES, count, engo.Gl.UNSIGNED_SHORT, 0)
+	countDrawCall(count / 3)
 	s.idx = 0
-	// We need to reset the vertex buffer so that when we start drawing again, we don't accidentally use junk data.
-	// The "simpler" way to do this would be to just create a new slice with make(), however that would cause the
-	// previous slice to be marked for garbage collection and we'd prefer to keep the GC activity to a minimum.
-	for i := range s.vertices {
-		s.vertices[i] = 0
-	}
 }
 
 func (s *basicShader) updateBuffer(ren *RenderComponent, space *SpaceComponent) {
@@ -367,53 +445,69 @@ func (s *basicShader) makeModelMatrix(ren *RenderComponent, space *SpaceComponen
 }
 
 func (s *basicShader) generateBufferContent(ren *RenderComponent, space *SpaceComponent, buffer []float32) bool {
-	// We shouldn't use SpaceComponent to get width/height, because this usually already contains the Scale (which
-	// is being added elsewhere, so we don't want to over-do it)
-	w := ren.Drawable.Width()
-	h := ren.Drawable.Height()
+	w, h, u, v, u2, v2 := repeatView(ren, space)
+	tint := tintToFloat32(ren, ren.Color)
 
-	tint := colorToFloat32(ren.Color)
+	q := s.flipQuad(ren, nineSliceQuad{x2: w, y2: h, u1: u, v1: v, u2: u2, v2: v2}, w, h)
+	return s.setQuad(buffer, q, tint, s.makeModelMatrix(ren, space))
+}
 
-	u, v, u2, v2 := ren.Drawable.View()
+// repeatView returns the size of the sprite of ren before applying its Scale, along with its texture coordinates.
+// While the texture is repeated, the sprite covers the SpaceComponent along the RepeatAxes, and the texture
+// coordinates increase by one for every time the texture fits, so the last tile is cut off where the sprite ends.
+func repeatView(ren *RenderComponent, space *SpaceComponent) (w, h, u, v, u2, v2 float32) {
+	// We shouldn't use SpaceComponent to get width/height, because this usually already contains the Scale (which
+	// is being added elsewhere, so we don't want to over-do it)
+	w, h = ren.Drawable.Width(), ren.Drawable.Height()
+	u, v, u2, v2 = ren.Drawable.View()
+	if ren.Repeat == NoRepeat {
+		return w, h, u, v, u2, v2
+	}
 
-	if ren.Repeat != NoRepeat {
-		u2 = space.Width / (ren.Drawable.Width() * ren.Scale.X)
+	if ren.RepeatAxes != RepeatY && w != 0 && ren.Scale.X != 0 {
+		u2 = space.Width / (w * ren.Scale.X)
 		w *= u2
-		v2 = space.Width / (ren.Drawable.Height() * ren.Scale.Y)
+	}
+	if ren.RepeatAxes != RepeatX && h != 0 && ren.Scale.Y != 0 {
+		v2 = space.Height / (h * ren.Scale.Y)
 		h *= v2
 	}
+	return w, h, u, v, u2, v2
+}
 
+// setQuad sets the vertices of a single quad in the buffer, and returns whether they changed.
+func (s *basicShader) setQuad(buffer []float32, q nineSliceQuad, tint float32, modelMatrix *engo.Matrix) bool {
 	var changed bool
 
-	//setBufferValue(buffer, 0, 0, &changed)
-	//setBufferValue(buffer, 1, 0, &changed)
-	setBufferValue(buffer, 2, u, &changed)
-	setBufferValue(buffer, 3, v, &changed)
+	// The positions are transformed in place below, so every one of them has to be set, including the zeroes.
+	setBufferValue(buffer, 0, q.x1, &changed)
+	setBufferValue(buffer, 1, q.y1, &changed)
+	setBufferValue(buffer, 2, q.u1, &changed)
+	setBufferValue(buffer, 3, q.v1, &changed)
 	setBufferValue(buffer, 4, tint, &changed)
 
-	setBufferValue(buffer, 5, w, &changed)
-	//setBufferValue(buffer, 6, 0, &changed)
-	setBufferValue(buffer, 7, u2, &changed)
-	setBufferValue(buffer, 8, v, &changed)
+	setBufferValue(buffer, 5, q.x2, &changed)
+	setBufferValue(buffer, 6, q.y1, &changed)
+	setBufferValue(buffer, 7, q.u2, &changed)
+	setBufferValue(buffer, 8, q.v1, &changed)
 	setBufferValue(buffer, 9, tint, &changed)
 
-	setBufferValue(buffer, 10, w, &changed)
-	setBufferValue(buffer, 11, h, &changed)
-	setBufferValue(buffer, 12, u2, &changed)
-	setBufferValue(buffer, 13, v2, &changed)
+	setBufferValue(buffer, 10, q.x2, &changed)
+	setBufferValue(buffer, 11, q.y2, &changed)
+	setBufferValue(buffer, 12, q.u2, &changed)
+	setBufferValue(buffer, 13, q.v2, &changed)
 	setBufferValue(buffer, 14, tint, &changed)
 
-	//setBufferValue(buffer, 15, 0, &changed)
-	setBufferValue(buffer, 16, h, &changed)
-	setBufferValue(buffer, 17, u, &changed)
-	setBufferValue(buffer, 18, v2, &changed)
+	setBufferValue(buffer, 15, q.x1, &changed)
+	setBufferValue(buffer, 16, q.y2, &changed)
+	setBufferValue(buffer, 17, q.u1, &changed)
+	setBufferValue(buffer, 18, q.v2, &changed)
 	setBufferValue(buffer, 19, tint, &changed)
 
 	// Since each sprite in the batch has a different transform, we can't just send the model matrix into
 	// the shader and let the GPU take care of it. Instead, we need to multiply the current sprite's model matrix
 	// with the position component for each vertex of the current sprite on the CPU, and send the transformed
 	// positions to the shader directly.
-	modelMatrix := s.makeModelMatrix(ren, space)
 	s.multModel(modelMatrix, buffer[:2])
 	s.multModel(modelMatrix, buffer[5:7])
 	s.multModel(modelMatrix, buffer[10:12])
@@ -421,6 +515,39 @@ func (s *basicShader) generateBufferContent(ren *RenderComponent, space *SpaceCo
 	return changed
 }
 
+// drawNineSlice adds the nine parts of the NineSlice to the batch, stretched to fill the SpaceComponent.
+func (s *basicShader) drawNineSlice(ren *RenderComponent, space *SpaceComponent, n NineSlice) {
+	ren.Buffer = s.vertexBuffer
+	tint := tintToFloat32(ren, ren.Color)
+	modelMatrix := s.makeModelMatrix(ren, space)
+	w, h := space.Width/ren.Scale.X, space.Height/ren.Scale.Y
+	for _, q := range n.quads(w, h) {
+		if q.x1 == q.x2 || q.y1 == q.y2 {
+			continue // borders of zero size
+		}
+		if s.idx == len(s.vertices) {
+			s.flush()
+		}
+		s.setQuad(s.vertices[s.idx:s.idx+spriteSize], s.flipQuad(ren, q, w, h), tint, modelMatrix)
+		s.idx += spriteSize
+	}
+}
+
+// flipQuad mirrors the quad within a sprite of size w by h, according to the FlipX and FlipY of ren. Both the
+// position and texture coordinates are mirrored, so a single quad covering the sprite only has its texture flipped.
+// In the world, sprites are flipped vertically while YUp is set as well, which keeps them upright on screen.
+func (s *basicShader) flipQuad(ren *RenderComponent, q nineSliceQuad, w, h float32) nineSliceQuad {
+	if ren.FlipX {
+		q.x1, q.x2 = w-q.x2, w-q.x1
+		q.u1, q.u2 = q.u2, q.u1
+	}
+	if ren.FlipY != (YUp && s.cameraEnabled) {
+		q.y1, q.y2 = h-q.y2, h-q.y1
+		q.v1, q.v2 = q.v2, q.v1
+	}
+	return q
+}
+
 func (s *basicShader) multModel(m *engo.Matrix, v []float32) {
 	tmp := engo.MultiplyMatrixVector(m, v)
 	v[0] = tmp[0]
@@ -431,7 +558,7 @@ func (s *basicShader) SetCamera(c *CameraSystem) {
 	s.projViewChange = true
 	if s.cameraEnabled {
 		s.camera = c
-		s.viewMatrix.Identity().Translate(-s.camera.x, -s.camera.y).Rotate(s.camera.angle)
+		s.viewMatrix.Identity().Scale(1, yAxis()).Rotate(s.camera.angle).Translate(-s.camera.renderX(), -s.camera.renderY())
 	} else {
 		scaleX, scaleY := s.projectionMatrix.ScaleComponent()
 		s.viewMatrix.Translate(-1/scaleX, 1/scaleY)
@@ -464,8 +591,15 @@ type legacyShader struct {
 
 	camera        *CameraSystem
 	cameraEnabled bool
+	culler        viewCuller
 
 	lastBuffer *gl.Buffer
+
+	// batch contains the already transformed vertices of all batchable shapes drawn since the last flush, so they
+	// can be drawn using a single draw call.
+	batch            []float32
+	batchBuffer      *gl.Buffer
+	batchModelMatrix []float32
 }
 
 func (l *legacyShader) Setup(w *ecs.World) error {
@@ -532,6 +666,10 @@ void main (void) {
 	l.modelMatrix[4] = 1
 	l.modelMatrix[8] = 1
 
+	// Vertices within the batch are already transformed, so they are drawn using the identity matrix.
+	l.batchModelMatrix = []float32{1, 0, 0, 0, 1, 0, 0, 0, 1}
+	l.batchBuffer = engo.Gl.CreateBuffer()
+
 	return nil
 }
 
@@ -544,21 +682,19 @@ func (l *legacyShader) Pre() {
 	engo.Gl.EnableVertexAttribArray(l.inPosition)
 	engo.Gl.EnableVertexAttribArray(l.inColor)
 
-	if engo.ScaleOnResize() {
-		l.projectionMatrix[0] = 1 / (engo.GameWidth() / 2)
-		l.projectionMatrix[4] = 1 / (-engo.GameHeight() / 2)
-	} else {
-		l.projectionMatrix[0] = 1 / (engo.CanvasWidth() / (2 * engo.CanvasScale()))
-		l.projectionMatrix[4] = 1 / (-engo.CanvasHeight() / (2 * engo.CanvasScale()))
-	}
+	width, height := screenSize()
+	l.projectionMatrix[0] = 1 / (width / 2)
+	l.projectionMatrix[4] = 1 / (-height / 2)
 
 	if l.cameraEnabled {
 		l.viewMatrix[1], l.viewMatrix[0] = math.Sincos(l.camera.angle * math.Pi / 180)
 		l.viewMatrix[3] = -l.viewMatrix[1]
 		l.viewMatrix[4] = l.viewMatrix[0]
-		l.viewMatrix[6] = -l.camera.x
-		l.viewMatrix[7] = -l.camera.y
-		l.viewMatrix[8] = l.camera.z
+		l.viewMatrix[6], l.viewMatrix[7] = l.camera.viewOffset()
+		l.viewMatrix[8] = l.camera.renderZ()
+		if YUp {
+			l.viewMatrix[1], l.viewMatrix[4], l.viewMatrix[7] = -l.viewMatrix[1], -l.viewMatrix[4], -l.viewMatrix[7]
+		}
 	} else {
 		l.viewMatrix[6] = -1 / l.projectionMatrix[0]
 		l.viewMatrix[7] = 1 / l.projectionMatrix[4]
@@ -604,7 +740,7 @@ func (l *legacyShader) generateBufferContent(ren *RenderComponent, space *SpaceC
 
 	var changed bool
 
-	tint := colorToFloat32(ren.Color)
+	tint := tintToFloat32(ren, ren.Color)
 
 	switch shape := ren.Drawable.(type) {
 	case Triangle:
@@ -623,7 +759,7 @@ func (l *legacyShader) generateBufferContent(ren *RenderComponent, space *SpaceC
 			setBufferValue(buffer, 8, tint, &changed)
 
 			if shape.BorderWidth > 0 {
-				borderTint := colorToFloat32(shape.BorderColor)
+				borderTint := tintToFloat32(ren, shape.BorderColor)
 				b := shape.BorderWidth
 				s, c := math.Sincos(math.Atan(2 * h / w))
 
@@ -671,7 +807,7 @@ func (l *legacyShader) generateBufferContent(ren *RenderComponent, space *SpaceC
 			setBufferValue(buffer, 8, tint, &changed)
 
 			if shape.BorderWidth > 0 {
-				borderTint := colorToFloat32(shape.BorderColor)
+				borderTint := tintToFloat32(ren, shape.BorderColor)
 				b := shape.BorderWidth
 
 				pts := [][]float32{
@@ -718,7 +854,7 @@ func (l *legacyShader) generateBufferContent(ren *RenderComponent, space *SpaceC
 		var borderTint float32
 		hasBorder := shape.BorderWidth > 0
 		if hasBorder {
-			borderTint = colorToFloat32(shape.BorderColor)
+			borderTint = tintToFloat32(ren, shape.BorderColor)
 		}
 		for i := 0; i < 300; i++ {
 			setBufferValue(buffer, i*3, x+cx-bx/2, &changed)
@@ -763,7 +899,7 @@ func (l *legacyShader) generateBufferContent(ren *RenderComponent, space *SpaceC
 		setBufferValue(buffer, 17, tint, &changed)
 
 		if shape.BorderWidth > 0 {
-			borderTint := colorToFloat32(shape.BorderColor)
+			borderTint := tintToFloat32(ren, shape.BorderColor)
 			b := shape.BorderWidth
 			pts := [][]float32{
 				//Top
@@ -813,7 +949,7 @@ func (l *legacyShader) generateBufferContent(ren *RenderComponent, space *SpaceC
 		}
 
 		if shape.BorderWidth > 0 {
-			borderTint := colorToFloat32(shape.BorderColor)
+			borderTint := tintToFloat32(ren, shape.BorderColor)
 
 			for _, point := range shape.Points {
 				setBufferValue(buffer, index, point.X*w, &changed)
@@ -829,17 +965,65 @@ func (l *legacyShader) generateBufferContent(ren *RenderComponent, space *SpaceC
 	return changed
 }
 
-func (l *legacyShader) Draw(ren *RenderComponent, space *SpaceComponent) {
-	if l.lastBuffer != ren.Buffer || ren.Buffer == nil {
-		l.updateBuffer(ren, space)
+// batchVertices returns the number of vertices the shape adds to the batch, or 0 if the shape cannot be batched.
+// Only shapes that are drawn as separate triangles can be batched.
+func (l *legacyShader) batchVertices(draw Drawable) int {
+	switch shape := draw.(type) {
+	case Triangle:
+		if shape.BorderWidth > 0 {
+			return 21
+		}
+		return 3
+	case Rectangle:
+		if shape.BorderWidth > 0 {
+			return 30
+		}
+		return 6
+	case ComplexTriangles:
+		if shape.BorderWidth > 0 {
+			return 0
+		}
+		return len(shape.Points)
+	default:
+		return 0
+	}
+}
 
-		engo.Gl.BindBuffer(engo.Gl.ARRAY_BUFFER, ren.Buffer)
-		engo.Gl.VertexAttribPointer(l.inPosition, 2, engo.Gl.FLOAT, false, 12, 0)
-		engo.Gl.VertexAttribPointer(l.inColor, 4, engo.Gl.UNSIGNED_BYTE, true, 12, 8)
+// addToBatch transforms the vertices of the shape into world space and adds them to the batch.
+func (l *legacyShader) addToBatch(ren *RenderComponent, space *SpaceComponent, num int) {
+	if len(ren.BufferContent) == 0 {
+		ren.BufferContent = make([]float32, l.computeBufferSize(ren.Drawable))
+	}
+	l.generateBufferContent(ren, space, ren.BufferContent)
+	l.setModelMatrix(ren, space)
 
-		l.lastBuffer = ren.Buffer
+	m := l.modelMatrix
+	for i := 0; i < num*3; i += 3 {
+		x, y := ren.BufferContent[i], ren.BufferContent[i+1]
+		l.batch = append(l.batch, m[0]*x+m[3]*y+m[6], m[1]*x+m[4]*y+m[7], ren.BufferContent[i+2])
 	}
+}
+
+// flush draws all shapes within the batch using a single draw call.
+func (l *legacyShader) flush() {
+	if len(l.batch) == 0 {
+		return
+	}
+
+	engo.Gl.BindBuffer(engo.Gl.ARRAY_BUFFER, l.batchBuffer)
+	engo.Gl.BufferData(engo.Gl.ARRAY_BUFFER, l.batch, engo.Gl.DYNAMIC_DRAW)
+	engo.Gl.VertexAttribPointer(l.inPosition, 2, engo.Gl.FLOAT, false, 12, 0)
+	engo.Gl.VertexAttribPointer(l.inColor, 4, engo.Gl.UNSIGNED_BYTE, true, 12, 8)
+	l.lastBuffer = l.batchBuffer
+
+	engo.Gl.UniformMatrix3fv(l.matrixModel, false, l.batchModelMatrix)
+	engo.Gl.DrawArrays(engo.Gl.TRIANGLES, 0, len(l.batch)/3)
+	countDrawCall(len(l.batch) / 9)
 
+	l.batch = l.batch[:0]
+}
+
+func (l *legacyShader) setModelMatrix(ren *RenderComponent, space *SpaceComponent) {
 	if space.Rotation != 0 {
 		sin, cos := math.Sincos(space.Rotation * math.Pi / 180)
 
@@ -856,38 +1040,51 @@ func (l *legacyShader) Draw(ren *RenderComponent, space *SpaceComponent) {
 
 	l.modelMatrix[6] = space.Position.X * engo.GetGlobalScale().X
 	l.modelMatrix[7] = space.Position.Y * engo.GetGlobalScale().Y
+}
+
+func (l *legacyShader) Draw(ren *RenderComponent, space *SpaceComponent) {
+	// Shapes drawn as separate triangles are batched, and only drawn once a shape that cannot be batched is drawn, or
+	// at the end of the frame. This keeps the order in which all shapes are drawn intact.
+	if num := l.batchVertices(ren.Drawable); num > 0 {
+		l.addToBatch(ren, space, num)
+		return
+	}
+	l.flush()
+
+	if l.lastBuffer != ren.Buffer || ren.Buffer == nil {
+		l.updateBuffer(ren, space)
 
+		engo.Gl.BindBuffer(engo.Gl.ARRAY_BUFFER, ren.Buffer)
+		engo.Gl.VertexAttribPointer(l.inPosition, 2, engo.Gl.FLOAT, false, 12, 0)
+		engo.Gl.VertexAttribPointer(l.inColor, 4, engo.Gl.UNSIGNED_BYTE, true, 12, 8)
+
+		l.lastBuffer = ren.Buffer
+	}
+
+	l.setModelMatrix(ren, space)
 	engo.Gl.UniformMatrix3fv(l.matrixModel, false, l.modelMatrix)
 
 	switch shape := ren.Drawable.(type) {
-	case Triangle:
-		num := 3
-		if shape.BorderWidth > 0 {
-			num = 21
-		}
-		engo.Gl.DrawArrays(engo.Gl.TRIANGLES, 0, num)
-	case Rectangle:
-		num := 6
-		if shape.BorderWidth > 0 {
-			num = 30
-		}
-		engo.Gl.DrawArrays(engo.Gl.TRIANGLES, 0, num)
 	case Circle:
 		// Circle stuff!
 		if shape.BorderWidth > 0 {
 			engo.Gl.DrawArrays(engo.Gl.TRIANGLE_FAN, 300, 300)
+			countDrawCall(298)
 		}
 		engo.Gl.DrawArrays(engo.Gl.TRIANGLE_FAN, 0, 300)
+		countDrawCall(298)
 	case ComplexTriangles:
 		engo.Gl.DrawArrays(engo.Gl.TRIANGLES, 0, len(shape.Points))
+		countDrawCall(len(shape.Points) / 3)
 
 		if shape.BorderWidth > 0 {
 			borderWidth := shape.BorderWidth
 			if l.cameraEnabled {
-				borderWidth /= l.camera.z
+				borderWidth /= l.camera.renderZ()
 			}
 			engo.Gl.LineWidth(borderWidth)
 			engo.Gl.DrawArrays(engo.Gl.LINE_LOOP, len(shape.Points), len(shape.Points))
+			countDrawCall(0)
 		}
 	default:
 		unsupportedType(ren.Drawable)
@@ -895,6 +1092,7 @@ func (l *legacyShader) Draw(ren *RenderComponent, space *SpaceComponent) {
 }
 
 func (l *legacyShader) Post() {
+	l.flush()
 	l.lastBuffer = nil
 
 	// Cleanup
@@ -913,6 +1111,15 @@ func (l *legacyShader) SetCamera(c *CameraSystem) {
 	}
 }
 
+func (l *legacyShader) PrepareCulling() {
+	l.culler.prepare(l.camera, l.cameraEnabled)
+}
+
+// ShouldDraw culls shapes using their SpaceComponent, which is the size they're drawn at.
+func (l *legacyShader) ShouldDraw(rc *RenderComponent, sc *SpaceComponent) bool {
+	return l.culler.visible(sc.Position, sc.Width*math.Abs(rc.Scale.X), sc.Height*math.Abs(rc.Scale.Y))
+}
+
 type textShader struct {
 	program *gl.Program
 
@@ -933,9 +1140,19 @@ type textShader struct {
 
 	camera        *CameraSystem
 	cameraEnabled bool
+	culler        viewCuller
 
 	lastBuffer  *gl.Buffer
 	lastTexture *gl.Texture
+
+	// batches are the quads of the last Text whose buffer was generated, grouped by the texture they're drawn from
+	batches []textBatch
+}
+
+// textBatch is a range of the quads of a Text, which are drawn from the same FontAtlas.
+type textBatch struct {
+	texture *gl.Texture
+	quads   int
 }
 
 func (l *textShader) Setup(w *ecs.World) error {
@@ -1031,21 +1248,19 @@ func (l *textShader) Pre() {
 	engo.Gl.EnableVertexAttribArray(l.inTexCoords)
 	engo.Gl.EnableVertexAttribArray(l.inColor)
 
-	if engo.ScaleOnResize() {
-		l.projectionMatrix[0] = 1 / (engo.GameWidth() / 2)
-		l.projectionMatrix[4] = 1 / (-engo.GameHeight() / 2)
-	} else {
-		l.projectionMatrix[0] = 1 / (engo.CanvasWidth() / (2 * engo.CanvasScale()))
-		l.projectionMatrix[4] = 1 / (-engo.CanvasHeight() / (2 * engo.CanvasScale()))
-	}
+	width, height := screenSize()
+	l.projectionMatrix[0] = 1 / (width / 2)
+	l.projectionMatrix[4] = 1 / (-height / 2)
 
 	if l.cameraEnabled {
 		l.viewMatrix[1], l.viewMatrix[0] = math.Sincos(l.camera.angle * math.Pi / 180)
 		l.viewMatrix[3] = -l.viewMatrix[1]
 		l.viewMatrix[4] = l.viewMatrix[0]
-		l.viewMatrix[6] = -l.camera.x
-		l.viewMatrix[7] = -l.camera.y
-		l.viewMatrix[8] = l.camera.z
+		l.viewMatrix[6], l.viewMatrix[7] = l.camera.viewOffset()
+		l.viewMatrix[8] = l.camera.renderZ()
+		if YUp {
+			l.viewMatrix[1], l.viewMatrix[4], l.viewMatrix[7] = -l.viewMatrix[1], -l.viewMatrix[4], -l.viewMatrix[7]
+		}
 	} else {
 		l.viewMatrix[6] = -1 / l.projectionMatrix[0]
 		l.viewMatrix[7] = 1 / l.projectionMatrix[4]
@@ -1062,9 +1277,15 @@ func (l *textShader) updateBuffer(ren *RenderComponent, space *SpaceComponent) {
 		return
 	}
 
-	if len(ren.BufferContent) < 20*len(txt.Text) {
-		ren.BufferContent = make([]float32, 20*len(txt.Text)) // TODO: update this to actual value?
+	// Every character of every line gets a single quad, which is left empty for characters that aren't drawn
+	var glyphs int
+	for _, line := range txt.glyphLines(txt.styles()) {
+		glyphs += len(line.glyphs)
 	}
+	if cap(ren.BufferContent) < 20*glyphs {
+		ren.BufferContent = make([]float32, 20*glyphs)
+	}
+	ren.BufferContent = ren.BufferContent[:20*glyphs]
 	if changed := l.generateBufferContent(ren, space, ren.BufferContent); !changed {
 		return
 	}
@@ -1076,76 +1297,102 @@ func (l *textShader) updateBuffer(ren *RenderComponent, space *SpaceComponent) {
 	engo.Gl.BufferData(engo.Gl.ARRAY_BUFFER, ren.BufferContent, engo.Gl.STATIC_DRAW)
 }
 
+// generateBufferContent fills the buffer with a quad for every character of the Text. The quads are grouped by the
+// texture of the FontAtlas they're drawn from, which are kept as the batches of the shader, so that every texture is
+// drawn using a single draw call.
 func (l *textShader) generateBufferContent(ren *RenderComponent, space *SpaceComponent, buffer []float32) bool {
 	var changed bool
 
-	tint := colorToFloat32(ren.Color)
 	txt, ok := ren.Drawable.(Text)
 	if !ok {
 		unsupportedType(ren.Drawable)
 		return false
 	}
 
-	atlas, ok := atlasCache[*txt.Font]
-	if !ok {
-		// Generate texture first
-		atlas = txt.Font.generateFontAtlas(UnicodeCap)
-		atlasCache[*txt.Font] = atlas
-	}
+	styles, lines := txt.layout()
 
-	var currentX float32
-	var currentY float32
+	tints := make([]float32, len(styles))
+	l.batches = l.batches[:0]
+	for i, style := range styles {
+		tints[i] = tintToFloat32(ren, ren.Color)
+		if style.tint != nil {
+			tints[i] = tintToFloat32(ren, multiplyColors(ren.Color, style.tint))
+		}
+
+		batched := false
+		for _, batch := range l.batches {
+			batched = batched || batch.texture == style.atlas.Texture
+		}
+		if !batched {
+			l.batches = append(l.batches, textBatch{texture: style.atlas.Texture})
+		}
+	}
 
 	var modifier float32 = 1
 	if txt.RightToLeft {
 		modifier = -1
 	}
 
-	letterSpace := float32(txt.Font.Size) * txt.LetterSpacing
-	lineSpace := txt.LineSpacing * atlas.Height['X']
-
-	for index, char := range txt.Text {
-		// TODO: this might not work for all characters
-		switch {
-		case char == '\n':
-			currentX = 0
-			currentY += atlas.Height['X'] + lineSpace
-			continue
-		case char < 32: // all system stuff should be ignored
-			continue
-		}
-
-		offset := 20 * index
-
-		// These five are at 0, 0:
-		setBufferValue(buffer, 0+offset, currentX, &changed)
-		setBufferValue(buffer, 1+offset, currentY, &changed)
-		setBufferValue(buffer, 2+offset, atlas.XLocation[char]/atlas.TotalWidth, &changed)
-		setBufferValue(buffer, 3+offset, atlas.YLocation[char]/atlas.TotalHeight, &changed)
-		setBufferValue(buffer, 4+offset, tint, &changed)
-
-		// These five are at 1, 0:
-		setBufferValue(buffer, 5+offset, currentX+atlas.Width[char]+letterSpace, &changed)
-		setBufferValue(buffer, 6+offset, currentY, &changed)
-		setBufferValue(buffer, 7+offset, (atlas.XLocation[char]+atlas.Width[char])/atlas.TotalWidth, &changed)
-		setBufferValue(buffer, 8+offset, atlas.YLocation[char]/atlas.TotalHeight, &changed)
-		setBufferValue(buffer, 9+offset, tint, &changed)
-
-		// These five are at 1, 1:
-		setBufferValue(buffer, 10+offset, currentX+atlas.Width[char]+letterSpace, &changed)
-		setBufferValue(buffer, 11+offset, currentY+atlas.Height[char]+lineSpace, &changed)
-		setBufferValue(buffer, 12+offset, (atlas.XLocation[char]+atlas.Width[char])/atlas.TotalWidth, &changed)
-		setBufferValue(buffer, 13+offset, (atlas.YLocation[char]+atlas.Height[char])/atlas.TotalHeight, &changed)
-		setBufferValue(buffer, 14+offset, tint, &changed)
+	var offset int
+	for b := range l.batches {
+		batch := &l.batches[b]
+		for _, line := range lines {
+			currentX := line.x
+			for _, g := range line.glyphs {
+				style, char := styles[g.run], g.char
+				atlas, letterSpace := style.atlas, style.letterSpace
+				if atlas.Texture != batch.texture {
+					currentX += modifier * styles.advance(g)
+					continue
+				}
 
-		// These five are at 0, 1:
-		setBufferValue(buffer, 15+offset, currentX, &changed)
-		setBufferValue(buffer, 16+offset, currentY+atlas.Height[char]+lineSpace, &changed)
-		setBufferValue(buffer, 17+offset, atlas.XLocation[char]/atlas.TotalWidth, &changed)
-		setBufferValue(buffer, 18+offset, (atlas.YLocation[char]+atlas.Height[char])/atlas.TotalHeight, &changed)
-		setBufferValue(buffer, 19+offset, tint, &changed)
+				quad := buffer[offset : offset+20]
+				offset += 20
+				batch.quads++
+
+				// TODO: this might not work for all characters
+				glyph, ok := atlas.glyph(char)
+				if char < 32 || !ok { // all system stuff should be ignored, as well as anything not part of the atlas
+					for j := range quad {
+						setBufferValue(quad, j, 0, &changed)
+					}
+					continue
+				}
 
-		currentX += modifier * (atlas.Width[char] + letterSpace)
+				currentY := line.y + glyphOffset(style, line.height)
+				tint := tints[g.run]
+
+				// These five are at 0, 0:
+				setBufferValue(quad, 0, currentX, &changed)
+				setBufferValue(quad, 1, currentY, &changed)
+				setBufferValue(quad, 2, glyph.x/atlas.TotalWidth, &changed)
+				setBufferValue(quad, 3, glyph.y/atlas.TotalHeight, &changed)
+				setBufferValue(quad, 4, tint, &changed)
+
+				// These five are at 1, 0:
+				setBufferValue(quad, 5, currentX+glyph.width+letterSpace, &changed)
+				setBufferValue(quad, 6, currentY, &changed)
+				setBufferValue(quad, 7, (glyph.x+glyph.width)/atlas.TotalWidth, &changed)
+				setBufferValue(quad, 8, glyph.y/atlas.TotalHeight, &changed)
+				setBufferValue(quad, 9, tint, &changed)
+
+				// These five are at 1, 1:
+				setBufferValue(quad, 10, currentX+glyph.width+letterSpace, &changed)
+				setBufferValue(quad, 11, currentY+glyph.height, &changed)
+				setBufferValue(quad, 12, (glyph.x+glyph.width)/atlas.TotalWidth, &changed)
+				setBufferValue(quad, 13, (glyph.y+glyph.height)/atlas.TotalHeight, &changed)
+				setBufferValue(quad, 14, tint, &changed)
+
+				// These five are at 0, 1:
+				setBufferValue(quad, 15, currentX, &changed)
+				setBufferValue(quad, 16, currentY+glyph.height, &changed)
+				setBufferValue(quad, 17, glyph.x/atlas.TotalWidth, &changed)
+				setBufferValue(quad, 18, (glyph.y+glyph.height)/atlas.TotalHeight, &changed)
+				setBufferValue(quad, 19, tint, &changed)
+
+				currentX += modifier * (glyph.width + letterSpace)
+			}
+		}
 	}
 
 	return changed
@@ -1163,26 +1410,6 @@ func (l *textShader) Draw(ren *RenderComponent, space *SpaceComponent) {
 		l.lastBuffer = ren.Buffer
 	}
 
-	txt, ok := ren.Drawable.(Text)
-	if !ok {
-		unsupportedType(ren.Drawable)
-	}
-
-	atlas, ok := atlasCache[*txt.Font]
-	if !ok {
-		// Generate texture first
-		atlas = txt.Font.generateFontAtlas(UnicodeCap)
-		atlasCache[*txt.Font] = atlas
-	}
-
-	if atlas.Texture != l.lastTexture {
-		engo.Gl.BindTexture(engo.Gl.TEXTURE_2D, atlas.Texture)
-		l.lastTexture = atlas.Texture
-	}
-
-	engo.Gl.TexParameteri(engo.Gl.TEXTURE_2D, engo.Gl.TEXTURE_WRAP_S, engo.Gl.CLAMP_TO_EDGE)
-	engo.Gl.TexParameteri(engo.Gl.TEXTURE_2D, engo.Gl.TEXTURE_WRAP_T, engo.Gl.CLAMP_TO_EDGE)
-
 	if space.Rotation != 0 {
 		sin, cos := math.Sincos(space.Rotation * math.Pi / 180)
 
@@ -1200,9 +1427,31 @@ func (l *textShader) Draw(ren *RenderComponent, space *SpaceComponent) {
 	l.modelMatrix[6] = space.Position.X * engo.GetGlobalScale().X
 	l.modelMatrix[7] = space.Position.Y * engo.GetGlobalScale().Y
 
+	// The text is mirrored within its own height while YUp is set, which keeps it upright on screen
+	if YUp && l.cameraEnabled {
+		h := ren.Drawable.Height()
+		l.modelMatrix[6] += l.modelMatrix[3] * h
+		l.modelMatrix[7] += l.modelMatrix[4] * h
+		l.modelMatrix[3], l.modelMatrix[4] = -l.modelMatrix[3], -l.modelMatrix[4]
+	}
+
 	engo.Gl.UniformMatrix3fv(l.matrixModel, false, l.modelMatrix)
 
-	engo.Gl.DrawElements(engo.Gl.TRIANGLES, 6*len(txt.Text), engo.Gl.UNSIGNED_SHORT, 0)
+	// Every batch is drawn from its own FontAtlas, starting at the index of its first quad
+	var first int
+	for _, batch := range l.batches {
+		if batch.texture != l.lastTexture {
+			engo.Gl.BindTexture(engo.Gl.TEXTURE_2D, batch.texture)
+			l.lastTexture = batch.texture
+		}
+
+		engo.Gl.TexParameteri(engo.Gl.TEXTURE_2D, engo.Gl.TEXTURE_WRAP_S, engo.Gl.CLAMP_TO_EDGE)
+		engo.Gl.TexParameteri(engo.Gl.TEXTURE_2D, engo.Gl.TEXTURE_WRAP_T, engo.Gl.CLAMP_TO_EDGE)
+
+		engo.Gl.DrawElements(engo.Gl.TRIANGLES, 6*batch.quads, engo.Gl.UNSIGNED_SHORT, 6*2*first)
+		countDrawCall(2 * batch.quads)
+		first += batch.quads
+	}
 }
 
 func (l *textShader) Post() {
@@ -1227,18 +1476,47 @@ func (l *textShader) SetCamera(c *CameraSystem) {
 	}
 }
 
+func (l *textShader) PrepareCulling() {
+	l.culler.prepare(l.camera, l.cameraEnabled)
+}
+
+// ShouldDraw culls text using the size of the Text itself, since that's what's drawn regardless of the
+// SpaceComponent.
+func (l *textShader) ShouldDraw(rc *RenderComponent, sc *SpaceComponent) bool {
+	return l.culler.visible(sc.Position, rc.Drawable.Width()*math.Abs(rc.Scale.X), rc.Drawable.Height()*math.Abs(rc.Scale.Y))
+}
+
+// multiplyColors returns the color of a tint using both a and b, which is their product.
+func multiplyColors(a, b color.Color) color.Color {
+	c1 := color.NRGBAModel.Convert(a).(color.NRGBA)
+	c2 := color.NRGBAModel.Convert(b).(color.NRGBA)
+	mul := func(x, y uint8) uint8 {
+		return uint8(uint16(x) * uint16(y) / 255)
+	}
+	return color.NRGBA{R: mul(c1.R, c2.R), G: mul(c1.G, c2.G), B: mul(c1.B, c2.B), A: mul(c1.A, c2.A)}
+}
+
+// tintToFloat32 returns the float32 representation of the tint c of ren, with its alpha multiplied by the opacity
+// of ren.
+func tintToFloat32(ren *RenderComponent, c color.Color) float32 {
+	if ren.transparency == 0 {
+		return colorToFloat32(c)
+	}
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	n.A = uint8(float32(n.A) * ren.Opacity())
+	return colorToFloat32(n)
+}
+
 // colorToFloat32 returns the float32 representation of the given color
 func colorToFloat32(c color.Color) float32 {
-	colorR, colorG, colorB, colorA := c.RGBA()
-	colorR >>= 8
-	colorG >>= 8
-	colorB >>= 8
-	colorA >>= 8
+	// Colors are blended using their alpha, so they're passed on without being premultiplied by it. Otherwise a
+	// translucent tint would darken the texture as well.
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
 
-	red := colorR
-	green := colorG << 8
-	blue := colorB << 16
-	alpha := colorA << 24
+	red := uint32(n.R)
+	green := uint32(n.G) << 8
+	blue := uint32(n.B) << 16
+	alpha := uint32(n.A) << 24
 
 	return math.Float32frombits((alpha | blue | green | red) & 0xfeffffff)
 }
@@ -1260,6 +1538,8 @@ var (
 	BlendmapShader = &blendmapShader{cameraEnabled: true}
 	shadersSet     bool
 	atlasCache     = make(map[Font]FontAtlas)
+	atlasRunes     = make(map[Font][]rune)
+	truncatedCache = make(map[truncation]string)
 	shaders        = []Shader{
 		DefaultShader,
 		HUDShader,
@@ -1273,12 +1553,51 @@ var (
 
 // AddShader adds a shader to the list of shaders for initalization. They should
 // be added before the Rendersystem is added, such as in the scene's Preload.
-func AddShader(s Shader) {
+// Shaders added afterwards are set up right away, returning the error of their
+// Setup, if any.
+func AddShader(s Shader) error {
+	shaderInitMutex.Lock()
+	defer shaderInitMutex.Unlock()
+
+	for _, shader := range shaders {
+		if shader == s {
+			return nil
+		}
+	}
 	shaders = append(shaders, s)
+
+	if !shadersSet {
+		return nil
+	}
+	if err := s.Setup(shaderWorld); err != nil {
+		return err
+	}
+	if shaderCamera != nil {
+		s.SetCamera(shaderCamera)
+	}
+	return nil
 }
 
 var shaderInitMutex sync.Mutex
 
+// shaderWorld and shaderCamera are the world the shaders were set up with, and the camera they were last given.
+var (
+	shaderWorld  *ecs.World
+	shaderCamera *CameraSystem
+)
+
+// SetupShaders sets up the built-in shaders and those added using AddShader, returning the error of the first one
+// which fails, such as one which doesn't compile, along with the log of OpenGL. The RenderSystem sets them up once
+// it's added to the world, and panics with that error instead; calling SetupShaders before adding it, such as in the
+// Setup of the scene, allows handling the error, such as by showing it or by falling back to another shader. Shaders
+// are only set up once, so once they are, calling it does nothing.
+func SetupShaders(w *ecs.World) error {
+	if engo.Headless() {
+		return nil
+	}
+	return initShaders(w)
+}
+
 func initShaders(w *ecs.World) error {
 	shaderInitMutex.Lock()
 	defer shaderInitMutex.Unlock()
@@ -1289,40 +1608,47 @@ func initShaders(w *ecs.World) error {
 		for _, shader := range shaders {
 			err = shader.Setup(w)
 			if err != nil {
-				return err
+				return fmt.Errorf("unable to set up shader %T: %w", shader, err)
 			}
 		}
 
 		shadersSet = true
+		shaderWorld = w
 	}
 	return nil
 }
 
 // LoadShader takes a Vertex-shader and Fragment-shader, compiles them and attaches them to a newly created glProgram.
-// It will log possible compilation errors
+// Whenever either of them doesn't compile, or the program doesn't link, it returns an error containing the log of
+// OpenGL, see VertexShaderCompilationError, FragmentShaderCompilationError and ShaderLinkError.
 func LoadShader(vertSrc, fragSrc string) (*gl.Program, error) {
 	vertShader := engo.Gl.CreateShader(engo.Gl.VERTEX_SHADER)
+	defer engo.Gl.DeleteShader(vertShader)
 	engo.Gl.ShaderSource(vertShader, vertSrc)
 	engo.Gl.CompileShader(vertShader)
 	if !engo.Gl.GetShaderiv(vertShader, engo.Gl.COMPILE_STATUS) {
 		errorLog := engo.Gl.GetShaderInfoLog(vertShader)
 		return nil, VertexShaderCompilationError{errorLog}
 	}
-	defer engo.Gl.DeleteShader(vertShader)
 
 	fragShader := engo.Gl.CreateShader(engo.Gl.FRAGMENT_SHADER)
+	defer engo.Gl.DeleteShader(fragShader)
 	engo.Gl.ShaderSource(fragShader, fragSrc)
 	engo.Gl.CompileShader(fragShader)
 	if !engo.Gl.GetShaderiv(fragShader, engo.Gl.COMPILE_STATUS) {
 		errorLog := engo.Gl.GetShaderInfoLog(fragShader)
 		return nil, FragmentShaderCompilationError{errorLog}
 	}
-	defer engo.Gl.DeleteShader(fragShader)
 
 	program := engo.Gl.CreateProgram()
 	engo.Gl.AttachShader(program, vertShader)
 	engo.Gl.AttachShader(program, fragShader)
 	engo.Gl.LinkProgram(program)
+	if !engo.Gl.GetProgramParameterb(program, engo.Gl.LINK_STATUS) {
+		errorLog := engo.Gl.GetProgramInfoLog(program)
+		engo.Gl.DeleteProgram(program)
+		return nil, ShaderLinkError{errorLog}
+	}
 
 	return program, nil
 }
@@ -1341,6 +1667,7 @@ func newCamera(w *ecs.World) {
 		log.Println("Camera system was not found when changing scene!")
 		return
 	}
+	shaderCamera = cam
 	for _, shader := range shaders {
 		shader.SetCamera(cam)
 	}
@@ -1365,3 +1692,14 @@ type FragmentShaderCompilationError struct {
 func (f FragmentShaderCompilationError) Error() string {
 	return fmt.Sprintf("an error occurred compiling the fragment shader: %s", strings.Trim(f.OpenGLError, "\r\n"))
 }
+
+// ShaderLinkError is returned whenever the `LoadShader` method was unable to link your compiled shaders into a
+// program, such as when the outputs of the Vertex-shader don't match the inputs of the Fragment-shader
+type ShaderLinkError struct {
+	OpenGLError string
+}
+
+// Error implements the error interface.
+func (l ShaderLinkError) Error() string {
+	return fmt.Sprintf("an error occurred linking the shader program: %s", strings.Trim(l.OpenGLError, "\r\n"))
+}
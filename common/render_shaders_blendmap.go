@@ -221,16 +221,13 @@ func (s *blendmapShader) Pre() {
 func (s *blendmapShader) PrepareCulling() {
 	// (Re)initialize the projection matrix.
 	s.projectionMatrix.Identity()
-	if engo.ScaleOnResize() {
-		s.projectionMatrix.Scale(1/(engo.GameWidth()/2), 1/(-engo.GameHeight()/2))
-	} else {
-		s.projectionMatrix.Scale(1/(engo.CanvasWidth()/(2*engo.CanvasScale())), 1/(-engo.CanvasHeight()/(2*engo.CanvasScale())))
-	}
+	width, height := screenSize()
+	s.projectionMatrix.Scale(1/(width/2), 1/(-height/2))
 	// (Re)initialize the view matrix
 	s.viewMatrix.Identity()
 	if s.cameraEnabled {
-		s.viewMatrix.Scale(1/s.camera.z, 1/s.camera.z)
-		s.viewMatrix.Translate(-s.camera.x, -s.camera.y).Rotate(s.camera.angle)
+		s.viewMatrix.Scale(1/s.camera.renderZ(), yAxis()/s.camera.renderZ())
+		s.viewMatrix.Rotate(s.camera.angle).Translate(-s.camera.renderX(), -s.camera.renderY())
 	} else {
 		scaleX, scaleY := s.projectionMatrix.ScaleComponent()
 		s.viewMatrix.Translate(-1/scaleX, 1/scaleY)
@@ -341,10 +338,11 @@ func (s *blendmapShader) Draw(ren *RenderComponent, space *SpaceComponent) {
 		engo.Gl.TexParameteri(engo.Gl.TEXTURE_2D, engo.Gl.TEXTURE_WRAP_T, val)
 	}
 
-	if s.lastMagFilter != ren.magFilter {
+	magFilter, minFilter := ren.filters()
+	if s.lastMagFilter != magFilter {
 		s.flush()
 		var val int
-		switch ren.magFilter {
+		switch magFilter {
 		case FilterNearest:
 			val = engo.Gl.NEAREST
 		case FilterLinear:
@@ -353,10 +351,10 @@ func (s *blendmapShader) Draw(ren *RenderComponent, space *SpaceComponent) {
 		engo.Gl.TexParameteri(engo.Gl.TEXTURE_2D, engo.Gl.TEXTURE_MAG_FILTER, val)
 	}
 
-	if s.lastMinFilter != ren.minFilter {
+	if s.lastMinFilter != minFilter {
 		s.flush()
 		var val int
-		switch ren.minFilter {
+		switch minFilter {
 		case FilterNearest:
 			val = engo.Gl.NEAREST
 		case FilterLinear:
@@ -396,6 +394,7 @@ func (s *blendmapShader) flush() {
 	// We only want to draw the indicies up to the number of sprites in the current batch.
 	count := s.idx / 20 * 6
 	engo.Gl.DrawElements(engo.Gl.TRIANGLES, count, engo.Gl.UNSIGNED_SHORT, 0)
+	countDrawCall(count / 3)
 	s.idx = 0
 	// We need to reset the vertex buffer so that when we start drawing again, we don't accidentally use junk data.
 	// The "simpler" way to do this would be to just create a new slice with make(), however that would cause the
@@ -432,7 +431,7 @@ func (s *blendmapShader) generateBufferContent(ren *RenderComponent, space *Spac
 	w := ren.Drawable.Width()
 	h := ren.Drawable.Height()
 
-	tint := colorToFloat32(ren.Color)
+	tint := tintToFloat32(ren, ren.Color)
 
 	u, v, u2, v2 := ren.Drawable.View()
 
@@ -442,6 +441,13 @@ func (s *blendmapShader) generateBufferContent(ren *RenderComponent, space *Spac
 		v2 = space.Width / (ren.Drawable.Height() * ren.Scale.Y)
 		h *= v2
 	}
+	if ren.FlipX {
+		u, u2 = u2, u
+	}
+	// Blend maps are flipped vertically while YUp is set as well, which keeps them upright on screen
+	if ren.FlipY != (YUp && s.cameraEnabled) {
+		v, v2 = v2, v
+	}
 
 	var changed bool
 
@@ -490,7 +496,7 @@ func (s *blendmapShader) multModel(m *engo.Matrix, v []float32) {
 func (s *blendmapShader) SetCamera(c *CameraSystem) {
 	if s.cameraEnabled {
 		s.camera = c
-		s.viewMatrix.Identity().Translate(-s.camera.x, -s.camera.y).Rotate(s.camera.angle)
+		s.viewMatrix.Identity().Scale(1, yAxis()).Rotate(s.camera.angle).Translate(-s.camera.renderX(), -s.camera.renderY())
 	} else {
 		scaleX, scaleY := s.projectionMatrix.ScaleComponent()
 		s.viewMatrix.Translate(-1/scaleX, 1/scaleY)
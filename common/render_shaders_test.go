@@ -10,14 +10,6 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-type testScene struct{}
-
-func (*testScene) Preload() {}
-
-func (t *testScene) Setup(u engo.Updater) {}
-
-func (*testScene) Type() string { return "testScene" }
-
 // TestShadersInitialization tests whether all registered `Shader`s will `Setup` without any errors
 func TestShadersInitialization(t *testing.T) {
 	engo.Run(engo.RunOptions{
@@ -0,0 +1,95 @@
+package common
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/EngoEngine/engo"
+)
+
+func TestLegacyShaderBatchVertices(t *testing.T) {
+	s := &legacyShader{}
+
+	tests := []struct {
+		drawable Drawable
+		expected int
+	}{
+		{Rectangle{}, 6},
+		{Rectangle{BorderWidth: 1}, 30},
+		{Triangle{}, 3},
+		{Triangle{BorderWidth: 1}, 21},
+		{ComplexTriangles{Points: make([]engo.Point, 9)}, 9},
+		{ComplexTriangles{Points: make([]engo.Point, 9), BorderWidth: 1}, 0},
+		{Circle{}, 0},
+	}
+
+	for _, test := range tests {
+		if actual := s.batchVertices(test.drawable); actual != test.expected {
+			t.Errorf("wrong number of batched vertices for %T. Wanted: %v, got: %v", test.drawable, test.expected, actual)
+		}
+	}
+}
+
+func TestLegacyShaderAddToBatch(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &testScene{})
+
+	s := &legacyShader{modelMatrix: []float32{1, 0, 0, 0, 1, 0, 0, 0, 1}}
+
+	rects := make([]RenderComponent, 3)
+	for i := range rects {
+		rects[i] = RenderComponent{Drawable: Rectangle{}, Color: color.White, Scale: engo.Point{X: 1, Y: 1}}
+		s.addToBatch(&rects[i], &SpaceComponent{Position: engo.Point{X: float32(i) * 10, Y: 5}, Width: 10, Height: 10}, 6)
+	}
+	tri := RenderComponent{Drawable: Triangle{}, Color: color.White, Scale: engo.Point{X: 1, Y: 1}}
+	s.addToBatch(&tri, &SpaceComponent{Width: 10, Height: 10}, 3)
+
+	expected := (3*6 + 3) * 3
+	if len(s.batch) != expected {
+		t.Fatalf("shapes were not added to the batch. Wanted: %v values, got: %v", expected, len(s.batch))
+	}
+	// The second rectangle is translated by (10, 5), so its second vertex is at (20, 5).
+	if x, y := s.batch[6*3+3], s.batch[6*3+4]; x != 20 || y != 5 {
+		t.Errorf("batched vertex was not transformed into world space. Wanted: (20, 5), got: (%v, %v)", x, y)
+	}
+	// Shapes are kept in the order in which they were drawn, the triangle's first vertex is at (5, 0).
+	if x, y := s.batch[18*3], s.batch[18*3+1]; x != 5 || y != 0 {
+		t.Errorf("batch did not keep the draw order. Wanted: (5, 0), got: (%v, %v)", x, y)
+	}
+}
+
+// BenchmarkLegacyShaderRectangles measures batching 500 bordered rectangles, such as those drawn by a collision debug
+// overlay, for a single frame.
+func BenchmarkLegacyShaderRectangles(b *testing.B) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &testScene{})
+
+	s := &legacyShader{modelMatrix: []float32{1, 0, 0, 0, 1, 0, 0, 0, 1}}
+
+	rects := make([]RenderComponent, 500)
+	spaces := make([]SpaceComponent, 500)
+	for i := range rects {
+		rects[i] = RenderComponent{
+			Drawable: Rectangle{BorderWidth: 1, BorderColor: color.White},
+			Color:    color.Transparent,
+			Scale:    engo.Point{X: 1, Y: 1},
+		}
+		spaces[i] = SpaceComponent{
+			Position: engo.Point{X: float32(i%25) * 20, Y: float32(i/25) * 20},
+			Width:    16,
+			Height:   16,
+		}
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i := range rects {
+			s.addToBatch(&rects[i], &spaces[i], s.batchVertices(rects[i].Drawable))
+		}
+		s.batch = s.batch[:0]
+	}
+}
@@ -0,0 +1,44 @@
+package common
+
+import "strconv"
+
+// RenderStats are counters of what the RenderSystem drew during a frame, such as for verifying that culling and
+// batching actually help, see `RenderSystem.Stats`. Entities drawn into multiple views or render targets are counted
+// once for each of them.
+type RenderStats struct {
+	// Submitted is the number of entities the RenderSystem tried to draw, which are all entities which are not hidden
+	Submitted int
+	// Culled is the number of submitted entities which were skipped, since they were not within the view of the camera
+	Culled int
+	// Batches is the number of runs of consecutive entities drawn using the same shader, which is how often the
+	// RenderSystem switched shaders
+	Batches int
+	// DrawCalls is the number of OpenGL draw calls made by the built-in shaders, post-processing and render scaling.
+	// Those made by custom shaders are not counted.
+	DrawCalls int
+	// Triangles is the number of triangles drawn by those draw calls, not counting lines
+	Triangles int
+}
+
+// String returns the counters on a single line, such as for showing them along with the FPS.
+func (s RenderStats) String() string {
+	return "entities: " + strconv.Itoa(s.Submitted-s.Culled) + " (" + strconv.Itoa(s.Culled) + " culled)" +
+		" batches: " + strconv.Itoa(s.Batches) +
+		" draw calls: " + strconv.Itoa(s.DrawCalls) +
+		" triangles: " + strconv.Itoa(s.Triangles)
+}
+
+// frameStats accumulates the counters of the frame being drawn, into which the shaders count their draw calls.
+var frameStats RenderStats
+
+// countDrawCall counts a draw call of the given amount of triangles.
+func countDrawCall(triangles int) {
+	frameStats.DrawCalls++
+	frameStats.Triangles += triangles
+}
+
+// Stats returns the counters of the frame the RenderSystem drew last. They're only a few additions per entity and
+// draw call, so they're always kept track of. While headless nothing is drawn, so all counters are zero.
+func (rs *RenderSystem) Stats() RenderStats {
+	return rs.stats
+}
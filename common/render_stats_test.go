@@ -0,0 +1,65 @@
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+// cullingTestShader culls every entity left of the origin.
+type cullingTestShader struct {
+	setupCountingShader
+}
+
+func (*cullingTestShader) PrepareCulling() {}
+
+func (*cullingTestShader) ShouldDraw(_ *RenderComponent, space *SpaceComponent) bool {
+	return space.Position.X >= 0
+}
+
+func TestRenderStats(t *testing.T) {
+	engo.Mailbox = &engo.MessageManager{}
+	culling, other := &cullingTestShader{}, &setupCountingShader{}
+	rs := &RenderSystem{ids: make(map[uint64]struct{})}
+	for i, x := range []float32{-10, 10, 20, 30, -20} {
+		basic := ecs.NewBasic()
+		ren := &RenderComponent{Drawable: Rectangle{}, StartZIndex: float32(i)}
+		ren.SetShader(culling)
+		switch i {
+		case 2:
+			ren.SetShader(other)
+		case 3:
+			ren.Hidden = true
+		}
+		rs.Add(&basic, ren, &SpaceComponent{Position: engo.Point{X: x}})
+	}
+
+	frameStats = RenderStats{}
+	rs.draw(false)
+	// Of the four entities which aren't hidden two are culled, and the other two are drawn by different shaders
+	expected := RenderStats{Submitted: 4, Culled: 2, Batches: 2}
+	if frameStats != expected {
+		t.Errorf("wrong stats. Wanted: %+v, got: %+v", expected, frameStats)
+	}
+
+	countDrawCall(2)
+	countDrawCall(0)
+	if frameStats.DrawCalls != 2 || frameStats.Triangles != 2 {
+		t.Errorf("draw calls were not counted. Wanted: 2 calls of 2 triangles, got: %v calls of %v triangles", frameStats.DrawCalls, frameStats.Triangles)
+	}
+}
+
+func TestFPSSystemStats(t *testing.T) {
+	w := &ecs.World{}
+	rs := &RenderSystem{stats: RenderStats{Submitted: 5, Culled: 2, Batches: 1, DrawCalls: 1, Triangles: 6}}
+	w.AddSystem(rs)
+	f := &FPSSystem{Stats: true}
+	w.AddSystem(f)
+
+	expected := "\nentities: 3 (2 culled) batches: 1 draw calls: 1 triangles: 6"
+	if text := f.text(); !strings.HasSuffix(text, expected) {
+		t.Errorf("render stats were not shown. Wanted a suffix of %q, got: %q", expected, text)
+	}
+}
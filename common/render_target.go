@@ -0,0 +1,172 @@
+package common
+
+import (
+	"image/color"
+
+	"github.com/EngoEngine/engo"
+)
+
+// RenderTarget is an offscreen target into which the RenderSystem draws the world every frame, in addition to drawing
+// it onto the screen. This is useful for minimaps and mirrors: the world is drawn as seen from Position, and the
+// target can be drawn like any other texture using Drawable. Targets are drawn once they're added to the RenderSystem
+// using `RenderSystem.AddTarget`.
+//
+// The target shows the same area of the world the screen would show from Position at the given Zoom, stretched to
+// the resolution of the target, so a target with the aspect ratio of the game keeps everything in proportion. A
+// minimap is made using a small target with a large Zoom, shown on the HUD. Only the world is drawn into the target,
+// rather than the HUD, and entities drawing the target itself are left out of it.
+//
+// Shaders which implement CullingShader, which includes all built-in shaders, draw the view of the target. Other
+// shaders draw the view of the camera.
+//
+// Like everything OpenGL draws offscreen, the texture of the target is stored bottom-up. Drawable takes this into
+// account by flipping the texture coordinates, so it's drawn upright. When sampling the texture (see
+// `RenderTarget.Texture`) in a custom shader, the bottom left of the target is at (0, 0) and the top left at (0, 1),
+// unlike images, which have their top left at (0, 0).
+type RenderTarget struct {
+	// Position is the point in the world at the center of the target
+	Position engo.Point
+	// Zoom is the zoom level of the target, just like that of the camera: at 2 the target shows an area twice as
+	// wide and high as the screen at a zoom level of 1. It's 1 when zero.
+	Zoom float32
+	// Rotation is the rotation of the view of the target, in degrees, just like that of the camera
+	Rotation float32
+	// Background is the color with which the target is cleared every frame, before drawing the world. It's
+	// transparent when nil.
+	Background color.Color
+
+	width, height int
+	texture       Texture
+	color         *RenderTexture
+	depth         *RenderBuffer
+	framebuffer   *Framebuffer
+}
+
+// NewRenderTarget creates a RenderTarget with the given resolution in pixels. If depth is set, the target has a depth
+// buffer along with its color, for shaders which use depth testing. It has to be created after the RenderSystem was
+// added to the world, since that initializes OpenGL.
+func NewRenderTarget(width, height int, depth bool) *RenderTarget {
+	t := &RenderTarget{width: width, height: height}
+	t.texture = Texture{
+		width:    float32(width),
+		height:   float32(height),
+		viewport: engo.AABB{Min: engo.Point{X: 0, Y: 1}, Max: engo.Point{X: 1, Y: 0}},
+		region:   engo.AABB{Max: engo.Point{X: float32(width), Y: float32(height)}},
+	}
+	if engo.Headless() {
+		return t
+	}
+
+	t.color = CreateRenderTexture(width, height, false)
+	engo.Gl.BindTexture(engo.Gl.TEXTURE_2D, nil)
+	t.texture.id = t.color.Texture()
+	if depth {
+		t.depth = createDepthBuffer(width, height)
+	}
+	t.framebuffer = CreateFramebuffer()
+	return t
+}
+
+// Drawable returns the contents of the target as a Drawable, which is drawn upright and at the resolution of the
+// target, like an image of the same size.
+func (t *RenderTarget) Drawable() Drawable {
+	return t.texture
+}
+
+// Texture returns the color texture of the target, as it's stored by OpenGL: bottom-up.
+func (t *RenderTarget) Texture() *RenderTexture {
+	return t.color
+}
+
+// Size returns the resolution of the target, in pixels.
+func (t *RenderTarget) Size() (width, height int) {
+	return t.width, t.height
+}
+
+// Close frees the target. It has to be removed from the RenderSystem before, and can't be drawn afterwards.
+func (t *RenderTarget) Close() {
+	if t.framebuffer == nil {
+		return
+	}
+	t.color.Close()
+	if t.depth != nil {
+		t.depth.Destroy()
+	}
+	t.framebuffer.Destroy()
+	t.color, t.depth, t.framebuffer = nil, nil, nil
+}
+
+// begin redirects all drawing to the target, and clears it.
+func (t *RenderTarget) begin() {
+	t.framebuffer.Open(t.width, t.height)
+	t.color.Bind()
+	flags := engo.Gl.COLOR_BUFFER_BIT
+	if t.depth != nil {
+		t.depth.Bind(engo.Gl.DEPTH_ATTACHMENT)
+		flags |= engo.Gl.DEPTH_BUFFER_BIT
+	}
+
+	setClearColor(t.Background)
+	engo.Gl.Clear(flags)
+	setClearColor(background)
+}
+
+// end draws onto the screen again.
+func (t *RenderTarget) end() {
+	t.framebuffer.Close()
+}
+
+// AddTarget starts drawing the world into the target every frame, before drawing it onto the screen.
+func (rs *RenderSystem) AddTarget(t *RenderTarget) {
+	for _, target := range rs.targets {
+		if target == t {
+			return
+		}
+	}
+	rs.targets = append(rs.targets, t)
+}
+
+// RemoveTarget stops drawing into the target.
+func (rs *RenderSystem) RemoveTarget(t *RenderTarget) {
+	for i, target := range rs.targets {
+		if target == t {
+			rs.targets = append(rs.targets[:i], rs.targets[i+1:]...)
+			return
+		}
+	}
+}
+
+// drawTargets draws the world into every target, by moving the camera to the view of the target while drawing it.
+func (rs *RenderSystem) drawTargets() {
+	if len(rs.targets) == 0 {
+		return
+	}
+	var cam *CameraSystem
+	for _, system := range rs.world.Systems() {
+		if c, ok := system.(*CameraSystem); ok {
+			cam = c
+			break
+		}
+	}
+	if cam == nil {
+		return
+	}
+
+	x, y, z, angle, shake := cam.x, cam.y, cam.z, cam.angle, cam.shake
+	for _, t := range rs.targets {
+		if t.framebuffer == nil {
+			continue // created while headless, or closed
+		}
+		cam.x, cam.y, cam.angle, cam.shake = t.Position.X, t.Position.Y, t.Rotation, cameraShake{}
+		if cam.z = t.Zoom; cam.z <= 0 {
+			cam.z = 1
+		}
+
+		t.begin()
+		rs.target = t
+		rs.draw(false)
+		rs.target = nil
+		t.end()
+	}
+	cam.x, cam.y, cam.z, cam.angle, cam.shake = x, y, z, angle, shake
+}
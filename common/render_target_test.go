@@ -0,0 +1,40 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/EngoEngine/engo"
+)
+
+func TestRenderTarget(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &testScene{})
+
+	target := NewRenderTarget(160, 90, true)
+	if w, h := target.Size(); w != 160 || h != 90 {
+		t.Errorf("wrong size of target. Wanted: 160x90, got: %vx%v", w, h)
+	}
+
+	// The target is stored bottom-up, so its Drawable is flipped vertically to be drawn upright.
+	d := target.Drawable()
+	if d.Width() != 160 || d.Height() != 90 {
+		t.Errorf("Drawable does not have the resolution of the target. Wanted: 160x90, got: %vx%v", d.Width(), d.Height())
+	}
+	if u1, v1, u2, v2 := d.View(); u1 != 0 || v1 != 1 || u2 != 1 || v2 != 0 {
+		t.Errorf("Drawable is not flipped vertically. Wanted: (0, 1, 1, 0), got: (%v, %v, %v, %v)", u1, v1, u2, v2)
+	}
+
+	rs := &RenderSystem{}
+	rs.AddTarget(target)
+	rs.AddTarget(target)
+	if len(rs.targets) != 1 {
+		t.Errorf("target was added more than once, got: %v targets", len(rs.targets))
+	}
+	rs.RemoveTarget(target)
+	if len(rs.targets) != 0 {
+		t.Error("target was not removed")
+	}
+	target.Close()
+}
@@ -0,0 +1,483 @@
+package common
+
+import (
+	"image/color"
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/gl"
+)
+
+type testScene struct{}
+
+func (*testScene) Preload() {}
+
+func (t *testScene) Setup(u engo.Updater) {}
+
+func (*testScene) Type() string { return "testScene" }
+
+func TestRenderSystemPasses(t *testing.T) {
+	engo.InsertRenderPass("post", engo.RenderPassPost, func() {})
+	engo.InsertRenderPass("background", engo.RenderPassWorld-1, func() {})
+	defer engo.RemoveRenderPass("post")
+	defer engo.RemoveRenderPass("background")
+
+	rs := &RenderSystem{}
+	var names []string
+	for _, pass := range rs.passes() {
+		names = append(names, pass.Name)
+	}
+
+	expected := []string{"background", "world", "post", "hud", "transition"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("custom render passes were not drawn in between the built-in ones. Wanted: %v, got: %v", expected, names)
+	}
+
+	// A pass with the name of a built-in pass replaces it
+	replaced := false
+	engo.InsertRenderPass("hud", engo.RenderPassHUD, func() { replaced = true })
+	defer engo.RemoveRenderPass("hud")
+	names = names[:0]
+	for _, pass := range rs.passes() {
+		names = append(names, pass.Name)
+		if pass.Name == "hud" {
+			pass.Draw()
+		}
+	}
+	if !reflect.DeepEqual(names, expected) || !replaced {
+		t.Errorf("built-in pass was not replaced. Wanted: %v, got: %v (replaced: %v)", expected, names, replaced)
+	}
+}
+
+func TestRenderEntityListOrder(t *testing.T) {
+	basics := make([]ecs.BasicEntity, 4)
+	for i := range basics {
+		basics[i] = ecs.NewBasic()
+	}
+	zIndices := []float32{1, 0, 1, 0}
+	list := renderEntityList{}
+	for _, i := range []int{2, 3, 0, 1} {
+		render := &RenderComponent{Drawable: Rectangle{}, StartZIndex: zIndices[i]}
+		render.zIndex = render.StartZIndex
+		list = append(list, renderEntity{&basics[i], render, &SpaceComponent{}, nil})
+	}
+	sort.Sort(list)
+
+	// Entities with the same z-index, which are otherwise the same as well, are sorted by ID.
+	expected := []uint64{basics[1].ID(), basics[3].ID(), basics[0].ID(), basics[2].ID()}
+	var actual []uint64
+	for _, e := range list {
+		actual = append(actual, e.ID())
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("entities were not sorted by z-index and ID. Wanted: %v, got: %v", expected, actual)
+	}
+}
+
+type recordingShader struct {
+	setupCountingShader
+	drawn []*RenderComponent
+}
+
+func (s *recordingShader) Draw(ren *RenderComponent, _ *SpaceComponent) {
+	s.drawn = append(s.drawn, ren)
+}
+
+func TestRenderComponentHidden(t *testing.T) {
+	shader := &recordingShader{}
+	rs := &RenderSystem{ids: make(map[uint64]struct{})}
+	renders := make([]*RenderComponent, 3)
+	for i := range renders {
+		basic := ecs.NewBasic()
+		renders[i] = &RenderComponent{Drawable: Rectangle{}, StartZIndex: float32(i)}
+		renders[i].SetShader(shader)
+		rs.Add(&basic, renders[i], &SpaceComponent{})
+	}
+	sort.Sort(rs.entities)
+
+	renders[1].Hidden = true
+	rs.draw(false)
+	if len(shader.drawn) != 2 || shader.drawn[0] != renders[0] || shader.drawn[1] != renders[2] {
+		t.Errorf("hidden entity was drawn, drew %v entities", len(shader.drawn))
+	}
+	if len(rs.entities) != len(renders) {
+		t.Errorf("hidden entity was removed from the RenderSystem. Wanted: %v entities, got: %v", len(renders), len(rs.entities))
+	}
+
+	// Once it is shown again, it is drawn in between the others, as before.
+	renders[1].Hidden = false
+	shader.drawn = nil
+	rs.draw(false)
+	if !reflect.DeepEqual(shader.drawn, renders) {
+		t.Error("entity shown again was not drawn in its original order")
+	}
+}
+
+func TestRenderSystemGet(t *testing.T) {
+	rs := &RenderSystem{ids: make(map[uint64]struct{})}
+	basics := []ecs.BasicEntity{ecs.NewBasic(), ecs.NewBasic()}
+	renders := []*RenderComponent{{Drawable: Rectangle{}}, {Drawable: Rectangle{}}}
+	spaces := []*SpaceComponent{{}, {}}
+	for i := range basics {
+		rs.Add(&basics[i], renders[i], spaces[i])
+	}
+	rs.Remove(basics[0])
+
+	if _, _, ok := rs.Get(basics[0].ID()); ok {
+		t.Error("removed entity was found")
+	}
+	if render, space, ok := rs.Get(basics[1].ID()); !ok || render != renders[1] || space != spaces[1] {
+		t.Error("components of the entity were not returned")
+	}
+}
+
+func TestRenderSystemBackground(t *testing.T) {
+	defer SetBackground(nil)
+	SetBackground(color.White)
+	rs := &RenderSystem{}
+	if rs.background() != color.White {
+		t.Errorf("background set using SetBackground was not used. Wanted: %v, got: %v", color.White, rs.background())
+	}
+
+	rs.Background = color.Black
+	if rs.background() != color.Black {
+		t.Errorf("background of the RenderSystem was not used. Wanted: %v, got: %v", color.Black, rs.background())
+	}
+}
+
+func TestColorToFloat32(t *testing.T) {
+	tests := []struct {
+		c                   color.Color
+		red, green, blue, a uint32
+	}{
+		{color.White, 255, 255, 255, 254},
+		{color.NRGBA{R: 255, G: 128, A: 128}, 255, 128, 0, 128},
+		// Premultiplied colors are passed on without their alpha applied
+		{color.RGBA{R: 128, A: 128}, 255, 0, 0, 128},
+		{color.Transparent, 0, 0, 0, 0},
+	}
+
+	for _, test := range tests {
+		bits := math.Float32bits(colorToFloat32(test.c))
+		r, g, b, a := bits&0xff, bits>>8&0xff, bits>>16&0xff, bits>>24
+		if r != test.red || g != test.green || b != test.blue || a != test.a {
+			t.Errorf("wrong color for %v. Wanted: (%v, %v, %v, %v), got: (%v, %v, %v, %v)",
+				test.c, test.red, test.green, test.blue, test.a, r, g, b, a)
+		}
+	}
+}
+
+func TestRenderComponentOpacity(t *testing.T) {
+	ren := &RenderComponent{Color: color.NRGBA{R: 255, A: 200}}
+	if ren.Opacity() != 1 {
+		t.Errorf("RenderComponent is not opaque by default, got: %v", ren.Opacity())
+	}
+	if tintToFloat32(ren, ren.Color) != colorToFloat32(ren.Color) {
+		t.Error("opaque RenderComponent changed its tint")
+	}
+
+	// The opacity is multiplied with the alpha of the tint, rather than replacing it
+	ren.SetOpacity(0.5)
+	bits := math.Float32bits(tintToFloat32(ren, ren.Color))
+	if r, a := bits&0xff, bits>>24; r != 255 || a != 100 {
+		t.Errorf("opacity was not multiplied with the tint. Wanted: red 255 at alpha 100, got: red %v at alpha %v", r, a)
+	}
+
+	ren.SetOpacity(2)
+	if ren.Opacity() != 1 {
+		t.Errorf("opacity was not clamped. Wanted: 1, got: %v", ren.Opacity())
+	}
+}
+
+func TestRenderComponentScreenSpace(t *testing.T) {
+	engo.Mailbox = &engo.MessageManager{}
+
+	ren := &RenderComponent{}
+	ren.SetShader(TextHUDShader)
+	if !ren.ScreenSpace {
+		t.Error("setting a HUD shader did not put the RenderComponent in screen space")
+	}
+
+	tests := []struct {
+		shader, world, screen Shader
+	}{
+		{DefaultShader, DefaultShader, HUDShader},
+		{HUDShader, DefaultShader, HUDShader},
+		{LegacyShader, LegacyShader, LegacyHUDShader},
+		{TextHUDShader, TextShader, TextHUDShader},
+		{BlendmapShader, BlendmapShader, BlendmapShader},
+	}
+	for _, test := range tests {
+		if actual := spaceShader(test.shader, false); actual != test.world {
+			t.Errorf("wrong shader in the world for %T. Wanted: %p, got: %p", test.shader, test.world, actual)
+		}
+		if actual := spaceShader(test.shader, true); actual != test.screen {
+			t.Errorf("wrong shader in screen space for %T. Wanted: %p, got: %p", test.shader, test.screen, actual)
+		}
+	}
+}
+
+func TestViewCuller(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        800,
+		Height:       600,
+	}, &testScene{})
+
+	cam := &CameraSystem{x: 400, y: 300, z: 1}
+	tests := []struct {
+		name          string
+		zoom          float32
+		position      engo.Point
+		width, height float32
+		visible       bool
+	}{
+		{"on screen", 1, engo.Point{X: 100, Y: 100}, 10, 10, true},
+		{"far off screen", 1, engo.Point{X: 2000, Y: 100}, 10, 10, false},
+		{"partially on screen", 1, engo.Point{X: -5, Y: 100}, 10, 10, true},
+		{"just off screen", 1, engo.Point{X: -20, Y: 100}, 10, 10, false},
+		{"below the screen", 1, engo.Point{X: 100, Y: 700}, 10, 10, false},
+		// Rotated by 180 degrees, this entity is drawn to the left of its position, onto the screen.
+		{"possibly rotated onto the screen", 1, engo.Point{X: 810, Y: 100}, 20, 1, true},
+		{"zoomed out", 2, engo.Point{X: 1100, Y: 300}, 10, 10, true},
+		{"zoomed in", 0.5, engo.Point{X: 700, Y: 300}, 10, 10, false},
+	}
+
+	var v viewCuller
+	for _, test := range tests {
+		cam.z = test.zoom
+		v.prepare(cam, true)
+		if actual := v.visible(test.position, test.width, test.height); actual != test.visible {
+			t.Errorf("wrong visibility for entity %s. Wanted: %v, got: %v", test.name, test.visible, actual)
+		}
+	}
+
+	// Without a camera, such as on the HUD, the screen itself is used.
+	v.prepare(nil, false)
+	if !v.visible(engo.Point{X: 790, Y: 590}, 5, 5) || v.visible(engo.Point{X: 900, Y: 300}, 5, 5) {
+		t.Error("entities on the HUD were not culled against the screen")
+	}
+}
+
+// BenchmarkLegacyShaderCulling measures preparing 50000 rectangles for a single frame, of which only about 1% are on
+// screen, with and without culling them first.
+func BenchmarkLegacyShaderCulling(b *testing.B) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        800,
+		Height:       600,
+	}, &testScene{})
+
+	s := &legacyShader{modelMatrix: []float32{1, 0, 0, 0, 1, 0, 0, 0, 1}, cameraEnabled: true}
+	s.SetCamera(&CameraSystem{x: 400, y: 300, z: 1})
+
+	rects := make([]RenderComponent, 50000)
+	spaces := make([]SpaceComponent, 50000)
+	for i := range rects {
+		rects[i] = RenderComponent{Drawable: Rectangle{}, Color: color.White, Scale: engo.Point{X: 1, Y: 1}}
+		spaces[i] = SpaceComponent{
+			Position: engo.Point{X: float32(i%250) * 32, Y: float32(i/250) * 32},
+			Width:    16,
+			Height:   16,
+		}
+	}
+
+	b.Run("culled", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			s.PrepareCulling()
+			for i := range rects {
+				if s.ShouldDraw(&rects[i], &spaces[i]) {
+					s.addToBatch(&rects[i], &spaces[i], s.batchVertices(rects[i].Drawable))
+				}
+			}
+			s.batch = s.batch[:0]
+		}
+	})
+	b.Run("unculled", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for i := range rects {
+				s.addToBatch(&rects[i], &spaces[i], s.batchVertices(rects[i].Drawable))
+			}
+			s.batch = s.batch[:0]
+		}
+	})
+}
+
+// BenchmarkBasicShaderBatch measures filling the vertex buffer with 10000 sprites, which share a single atlas, for a
+// single frame. Since they share a texture, a new batch is only started whenever the buffer is full. The number of
+// batches, and thus draw calls, is reported as draws/op.
+func BenchmarkBasicShaderBatch(b *testing.B) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &testScene{})
+
+	s := &basicShader{BatchSize: MaxSprites, modelMatrix: engo.IdentityMatrix()}
+	s.vertices = make([]float32, s.BatchSize*spriteSize)
+
+	atlas := &gl.Texture{}
+	rects := make([]RenderComponent, 10000)
+	spaces := make([]SpaceComponent, 10000)
+	for i := range rects {
+		x := float32(i%16) / 16
+		rects[i] = RenderComponent{
+			Drawable: Texture{id: atlas, width: 16, height: 16, viewport: engo.AABB{
+				Min: engo.Point{X: x, Y: 0},
+				Max: engo.Point{X: x + 1.0/16, Y: 1},
+			}},
+			Color: color.White,
+			Scale: engo.Point{X: 1, Y: 1},
+		}
+		spaces[i] = SpaceComponent{Position: engo.Point{X: float32(i%100) * 16, Y: float32(i/100) * 16}}
+	}
+
+	var draws int
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		draws = 1
+		for i := range rects {
+			if s.idx == len(s.vertices) {
+				draws++
+				s.idx = 0
+			}
+			s.updateBuffer(&rects[i], &spaces[i])
+			s.idx += spriteSize
+		}
+		s.idx = 0
+	}
+	b.ReportMetric(float64(draws), "draws/op")
+}
+
+func TestBasicShaderBufferReuse(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &testScene{})
+
+	s := &basicShader{BatchSize: 1, modelMatrix: engo.IdentityMatrix()}
+	s.vertices = make([]float32, s.BatchSize*spriteSize)
+
+	ren := &RenderComponent{
+		Drawable: Texture{width: 10, height: 10, viewport: engo.AABB{Max: engo.Point{X: 1, Y: 1}}},
+		Color:    color.White,
+		Scale:    engo.Point{X: 1, Y: 1},
+	}
+	// The buffer is reused for every batch without being cleared, so drawing the sprite again has to give the same
+	// vertices, rather than transforming those of the previous frame once more.
+	for frame := 0; frame < 2; frame++ {
+		s.updateBuffer(ren, &SpaceComponent{Position: engo.Point{X: 5, Y: 7}})
+		expected := [][2]float32{{5, 7}, {15, 7}, {15, 17}, {5, 17}}
+		for i, p := range expected {
+			if x, y := s.vertices[i*5], s.vertices[i*5+1]; x != p[0] || y != p[1] {
+				t.Errorf("wrong position of vertex %d in frame %d. Wanted: %v, got: (%v, %v)", i, frame, p, x, y)
+			}
+		}
+	}
+}
+
+func TestBasicShaderRepeat(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &testScene{})
+
+	s := &basicShader{BatchSize: 1, modelMatrix: engo.IdentityMatrix()}
+	s.vertices = make([]float32, s.BatchSize*spriteSize)
+	ren := &RenderComponent{
+		Drawable: Texture{width: 10, height: 20, viewport: engo.AABB{Max: engo.Point{X: 1, Y: 1}}},
+		Color:    color.White,
+		Scale:    engo.Point{X: 2, Y: 2},
+	}
+	// The space is 2.5 textures wide and 1.5 textures high, so the last tiles are cut off
+	space := &SpaceComponent{Width: 50, Height: 60}
+
+	tests := []struct {
+		repeat       TextureRepeating
+		axes         TextureRepeatAxes
+		x, y, u2, v2 float32
+	}{
+		{NoRepeat, RepeatXY, 20, 40, 1, 1},
+		{Repeat, RepeatXY, 50, 60, 2.5, 1.5},
+		{Repeat, RepeatX, 50, 40, 2.5, 1},
+		{MirroredRepeat, RepeatY, 20, 60, 1, 1.5},
+	}
+	for _, test := range tests {
+		ren.Repeat, ren.RepeatAxes = test.repeat, test.axes
+		s.generateBufferContent(ren, space, s.vertices)
+		// The third vertex is the bottom right corner of the sprite
+		if x, y, u2, v2 := s.vertices[10], s.vertices[11], s.vertices[12], s.vertices[13]; x != test.x || y != test.y || u2 != test.u2 || v2 != test.v2 {
+			t.Errorf("wrong corner for repeat %v along axes %v. Wanted: (%v, %v) at (%v, %v), got: (%v, %v) at (%v, %v)",
+				test.repeat, test.axes, test.x, test.y, test.u2, test.v2, x, y, u2, v2)
+		}
+	}
+}
+
+type batchTestEntity struct {
+	ecs.BasicEntity
+	RenderComponent
+	SpaceComponent
+	MouseComponent
+}
+
+// batchTestEntities returns n entities, which can be added to both the RenderSystem and the MouseSystem.
+func batchTestEntities(n int) []batchTestEntity {
+	entities := make([]batchTestEntity, n)
+	for i := range entities {
+		entities[i].BasicEntity = ecs.NewBasic()
+		entities[i].RenderComponent.Drawable = Rectangle{}
+	}
+	return entities
+}
+
+func TestRenderSystemAddBatch(t *testing.T) {
+	rs := &RenderSystem{ids: make(map[uint64]struct{})}
+	entities := batchTestEntities(3)
+	rs.Add(&entities[0].BasicEntity, &entities[0].RenderComponent, &entities[0].SpaceComponent)
+
+	batch := make([]Renderable, len(entities))
+	for i := range entities {
+		batch[i] = &entities[i]
+	}
+	rs.AddBatch(batch)
+	if len(rs.entities) != 3 {
+		t.Fatalf("wrong amount of entities. Wanted: 3, got: %v", len(rs.entities))
+	}
+	if _, space, ok := rs.Get(entities[2].ID()); !ok || space != &entities[2].SpaceComponent {
+		t.Error("entity added in the batch was not found")
+	}
+	if entities[1].RenderComponent.Scale != (engo.Point{X: 1, Y: 1}) {
+		t.Error("entity added in the batch was not set up like one added by itself")
+	}
+}
+
+// BenchmarkRenderSystemAddBatch measures spawning 5000 entities, one at a time and all at once.
+func BenchmarkRenderSystemAddBatch(b *testing.B) {
+	entities := batchTestEntities(5000)
+	batch := make([]Renderable, len(entities))
+	for i := range entities {
+		batch[i] = &entities[i]
+	}
+
+	b.Run("Add", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			rs := &RenderSystem{ids: make(map[uint64]struct{})}
+			for _, e := range batch {
+				rs.AddByInterface(e)
+			}
+		}
+	})
+	b.Run("AddBatch", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			rs := &RenderSystem{ids: make(map[uint64]struct{})}
+			rs.AddBatch(batch)
+		}
+	})
+}
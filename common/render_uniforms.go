@@ -0,0 +1,147 @@
+package common
+
+import (
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/gl"
+)
+
+// ShaderProgram is a compiled OpenGL program, which takes care of looking up its attributes and uniforms by name. It
+// saves custom shaders from doing so themselves, see `Shader`.
+type ShaderProgram struct {
+	// Program is the compiled program
+	Program *gl.Program
+
+	attributes map[string]int
+	uniforms   map[string]*gl.UniformLocation
+}
+
+// NewShaderProgram compiles the program from the given vertex and fragment shader. It has to be called after OpenGL
+// was initialized, such as in the Setup of a Shader.
+func NewShaderProgram(vertexShader, fragmentShader string) (*ShaderProgram, error) {
+	program, err := LoadShader(vertexShader, fragmentShader)
+	if err != nil {
+		return nil, err
+	}
+	return &ShaderProgram{
+		Program:    program,
+		attributes: make(map[string]int),
+		uniforms:   make(map[string]*gl.UniformLocation),
+	}, nil
+}
+
+// Use makes the program the one used for drawing.
+func (p *ShaderProgram) Use() {
+	engo.Gl.UseProgram(p.Program)
+}
+
+// Attribute returns the location of the attribute with the given name.
+func (p *ShaderProgram) Attribute(name string) int {
+	location, ok := p.attributes[name]
+	if !ok {
+		location = engo.Gl.GetAttribLocation(p.Program, name)
+		p.attributes[name] = location
+	}
+	return location
+}
+
+// Uniform returns the location of the uniform with the given name.
+func (p *ShaderProgram) Uniform(name string) *gl.UniformLocation {
+	location, ok := p.uniforms[name]
+	if !ok {
+		location = engo.Gl.GetUniformLocation(p.Program, name)
+		p.uniforms[name] = location
+	}
+	return location
+}
+
+// uniformValue is the value of a single uniform, which is either one to four floats or a texture.
+type uniformValue struct {
+	floats  [4]float32
+	size    int
+	texture *gl.Texture
+	unit    int
+}
+
+// Uniforms contains the values of named uniforms of a ShaderProgram: floats, vectors and textures. A custom shader
+// can keep Uniforms to set per frame, and read those set per entity from `RenderComponent.Uniforms`, applying both
+// to its program using Apply. Since every entity with different uniforms has to be drawn on its own, a batching
+// shader has to flush its batch before applying the uniforms of an entity. The zero value is empty, and ready to use.
+type Uniforms struct {
+	values map[string]uniformValue
+}
+
+func (u *Uniforms) set(name string, value uniformValue) {
+	if u.values == nil {
+		u.values = make(map[string]uniformValue)
+	}
+	u.values[name] = value
+}
+
+// SetFloat sets the float uniform with the given name.
+func (u *Uniforms) SetFloat(name string, x float32) {
+	u.set(name, uniformValue{floats: [4]float32{x}, size: 1})
+}
+
+// SetVec2 sets the vec2 uniform with the given name.
+func (u *Uniforms) SetVec2(name string, x, y float32) {
+	u.set(name, uniformValue{floats: [4]float32{x, y}, size: 2})
+}
+
+// SetVec3 sets the vec3 uniform with the given name.
+func (u *Uniforms) SetVec3(name string, x, y, z float32) {
+	u.set(name, uniformValue{floats: [4]float32{x, y, z}, size: 3})
+}
+
+// SetVec4 sets the vec4 uniform with the given name.
+func (u *Uniforms) SetVec4(name string, x, y, z, w float32) {
+	u.set(name, uniformValue{floats: [4]float32{x, y, z, w}, size: 4})
+}
+
+// SetTexture sets the sampler2D uniform with the given name to the texture of the Drawable, which is bound to the
+// given texture unit. Unit 0 is used by the built-in shaders for the Drawable of the entity, so additional textures
+// should use unit 1 and up.
+func (u *Uniforms) SetTexture(name string, unit int, d Drawable) {
+	u.set(name, uniformValue{texture: d.Texture(), unit: unit})
+}
+
+// Remove removes the uniform with the given name, so it's no longer applied.
+func (u *Uniforms) Remove(name string) {
+	delete(u.values, name)
+}
+
+// Float returns the floats the uniform with the given name was set to, and false if it wasn't set or is a texture.
+func (u *Uniforms) Float(name string) ([]float32, bool) {
+	value, ok := u.values[name]
+	if !ok || value.size == 0 {
+		return nil, false
+	}
+	return value.floats[:value.size], true
+}
+
+// Len returns the amount of uniforms which were set.
+func (u *Uniforms) Len() int {
+	return len(u.values)
+}
+
+// Apply sets every uniform on the program, which has to be in use (see `ShaderProgram.Use`). Textures are bound to
+// their texture unit, after which unit 0 is active again.
+func (u *Uniforms) Apply(p *ShaderProgram) {
+	for name, value := range u.values {
+		location := p.Uniform(name)
+		switch value.size {
+		case 0:
+			engo.Gl.ActiveTexture(engo.Gl.TEXTURE0 + value.unit)
+			engo.Gl.BindTexture(engo.Gl.TEXTURE_2D, value.texture)
+			engo.Gl.Uniform1i(location, value.unit)
+			engo.Gl.ActiveTexture(engo.Gl.TEXTURE0)
+		case 1:
+			engo.Gl.Uniform1f(location, value.floats[0])
+		case 2:
+			engo.Gl.Uniform2f(location, value.floats[0], value.floats[1])
+		case 3:
+			engo.Gl.Uniform3f(location, value.floats[0], value.floats[1], value.floats[2])
+		case 4:
+			engo.Gl.Uniform4f(location, value.floats[0], value.floats[1], value.floats[2], value.floats[3])
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package common
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+)
+
+type setupCountingShader struct {
+	setups int
+	err    error
+	camera *CameraSystem
+}
+
+func (s *setupCountingShader) Setup(*ecs.World) error               { s.setups++; return s.err }
+func (*setupCountingShader) Pre()                                   {}
+func (*setupCountingShader) Draw(*RenderComponent, *SpaceComponent) {}
+func (*setupCountingShader) Post()                                  {}
+func (s *setupCountingShader) SetCamera(c *CameraSystem)            { s.camera = c }
+
+func TestUniforms(t *testing.T) {
+	var u Uniforms
+	if _, ok := u.Float("uf_Missing"); ok {
+		t.Error("uniform which was not set was found")
+	}
+
+	u.SetFloat("uf_Strength", 0.5)
+	u.SetVec3("uf_Color", 1, 0.5, 0)
+	u.SetTexture("uf_Noise", 1, Texture{})
+	if u.Len() != 3 {
+		t.Errorf("wrong amount of uniforms. Wanted: 3, got: %v", u.Len())
+	}
+	if v, ok := u.Float("uf_Color"); !ok || len(v) != 3 || v[1] != 0.5 {
+		t.Errorf("vec3 uniform was not set. Wanted: [1 0.5 0], got: %v", v)
+	}
+	if _, ok := u.Float("uf_Noise"); ok {
+		t.Error("texture uniform was returned as floats")
+	}
+
+	u.SetVec2("uf_Strength", 1, 2)
+	if v, _ := u.Float("uf_Strength"); len(v) != 2 {
+		t.Errorf("uniform was not replaced when set again, got: %v", v)
+	}
+	u.Remove("uf_Strength")
+	if _, ok := u.Float("uf_Strength"); ok || u.Len() != 2 {
+		t.Error("uniform was not removed")
+	}
+}
+
+func TestAddShader(t *testing.T) {
+	defer func(s []Shader, set bool, cam *CameraSystem) {
+		shaders, shadersSet, shaderCamera = s, set, cam
+	}(shaders, shadersSet, shaderCamera)
+
+	// Shaders added before OpenGL is initialized are set up along with the built-in ones.
+	shadersSet = false
+	before := &setupCountingShader{}
+	AddShader(before)
+	AddShader(before)
+	if before.setups != 0 || shaders[len(shaders)-1] != before || shaders[len(shaders)-2] == before {
+		t.Errorf("shader was not added once for initialization, set up %v times", before.setups)
+	}
+
+	// Shaders added afterwards are set up right away.
+	shadersSet = true
+	shaderCamera = &CameraSystem{}
+	after := &setupCountingShader{}
+	if err := AddShader(after); err != nil || after.setups != 1 {
+		t.Errorf("shader added after initialization was not set up, set up %v times with error %v", after.setups, err)
+	}
+	if after.camera != shaderCamera {
+		t.Error("shader added after initialization was not given the camera")
+	}
+
+	failing := &setupCountingShader{err: errors.New("compilation failed")}
+	if err := AddShader(failing); err != failing.err {
+		t.Errorf("error of setting up the shader was not returned, got: %v", err)
+	}
+}
+
+func TestInitShadersError(t *testing.T) {
+	defer func(s []Shader, set bool, w *ecs.World) {
+		shaders, shadersSet, shaderWorld = s, set, w
+	}(shaders, shadersSet, shaderWorld)
+
+	ok, failing := &setupCountingShader{}, &setupCountingShader{err: FragmentShaderCompilationError{"0:1: syntax error"}}
+	shaders, shadersSet = []Shader{ok, failing}, false
+	err := initShaders(&ecs.World{})
+	if !errors.Is(err, failing.err) || !strings.Contains(err.Error(), "syntax error") || !strings.Contains(err.Error(), "setupCountingShader") {
+		t.Errorf("error did not include the shader and the log of OpenGL, got: %v", err)
+	}
+	if shadersSet {
+		t.Error("shaders were set up although one of them failed")
+	}
+
+	if err := (ShaderLinkError{"error: varying not written\n"}).Error(); err != "an error occurred linking the shader program: error: varying not written" {
+		t.Errorf("wrong link error, got: %q", err)
+	}
+}
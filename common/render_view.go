@@ -0,0 +1,187 @@
+package common
+
+import "github.com/EngoEngine/engo"
+
+// View is a part of the screen into which the RenderSystem draws the world as seen from its own Position, rather than
+// from the camera. Multiple views make split-screen games: two views, each covering half of the screen, show the
+// world around each of the players. Views are drawn once they're added to the RenderSystem using
+// `RenderSystem.AddView`, in the order they were added.
+//
+// While there are views, the world is drawn into each of them instead of over the whole screen. Every render pass
+// ordered before `engo.RenderPassPost`, such as the lighting and the particles, is drawn into every view, while the
+// post-processing, the HUD and the passes after it are drawn once over the whole screen. A view doesn't follow an
+// entity by itself, so to have it follow a player its Position is updated every frame, like that of a RenderTarget.
+// The world still needs a CameraSystem, which keeps its own position while the views are drawn.
+//
+// The MouseSystem translates the cursor into the world using the view the cursor is on, see `MouseSystem`. Whenever
+// the cursor isn't on any of the views, the camera is used.
+type View struct {
+	// Viewport is the part of the screen the view covers, as fractions of the width and height of the screen: the
+	// left half is from (0, 0) to (0.5, 1), the bottom half from (0, 0.5) to (1, 1).
+	Viewport engo.AABB
+	// Position is the point in the world at the center of the view
+	Position engo.Point
+	// Zoom is the zoom level of the view, just like that of the camera: at 2 the view shows an area twice as wide and
+	// high as it would at a zoom level of 1. It's 1 when zero.
+	Zoom float32
+	// Rotation is the rotation of the view, in degrees, just like that of the camera
+	Rotation float32
+}
+
+// NewSplitScreen returns two views splitting the screen in half, side by side when vertical is false, or one above the
+// other when it's true. Both are to be added to the RenderSystem using AddView.
+func NewSplitScreen(vertical bool) (*View, *View) {
+	if vertical {
+		return &View{Viewport: engo.AABB{Max: engo.Point{X: 1, Y: 0.5}}},
+			&View{Viewport: engo.AABB{Min: engo.Point{Y: 0.5}, Max: engo.Point{X: 1, Y: 1}}}
+	}
+	return &View{Viewport: engo.AABB{Max: engo.Point{X: 0.5, Y: 1}}},
+		&View{Viewport: engo.AABB{Min: engo.Point{X: 0.5}, Max: engo.Point{X: 1, Y: 1}}}
+}
+
+// size returns the width and height of the view, as fractions of the screen.
+func (v *View) size() engo.Point {
+	return v.Viewport.Size()
+}
+
+// zoom returns the zoom level of the view, which is 1 when Zoom is not set.
+func (v *View) zoom() float32 {
+	if v.Zoom <= 0 {
+		return 1
+	}
+	return v.Zoom
+}
+
+// area returns the part of the screen the view covers, in the coordinates of the game on the screen, as used by the
+// HUD.
+func (v *View) area() engo.AABB {
+	w, h := engo.GameWidth(), engo.GameHeight()
+	return engo.AABB{
+		Min: engo.Point{X: v.Viewport.Min.X * w, Y: v.Viewport.Min.Y * h},
+		Max: engo.Point{X: v.Viewport.Max.X * w, Y: v.Viewport.Max.Y * h},
+	}
+}
+
+// Contains returns whether the position within the window, such as the position of the mouse, is on the view.
+func (v *View) Contains(x, y float32) bool {
+	gx, gy := engo.WindowToGame(x, y)
+	area := v.area()
+	return gx >= area.Min.X && gx < area.Max.X && gy >= area.Min.Y && gy < area.Max.Y
+}
+
+// ScreenToWorld translates a position within the window, such as the position of the mouse, into "game coordinates"
+// as seen through the view, just like `CameraSystem.ScreenToWorld` does for the camera. Positions outside of the view
+// are translated as if the view extended beyond its Viewport.
+func (v *View) ScreenToWorld(x, y float32) (float32, float32) {
+	gx, gy := engo.WindowToGame(x, y)
+	area, z := v.area(), snapZoom(v.zoom())
+	wx := (gx-area.Min.X)*z + (v.Position.X-(area.Max.X-area.Min.X)/2*z)/engo.GetGlobalScale().X
+	wy := (gy-area.Min.Y)*z + (v.Position.Y-(area.Max.Y-area.Min.Y)/2*z)/engo.GetGlobalScale().Y
+
+	cx, cy := v.Position.X/engo.GetGlobalScale().X, v.Position.Y/engo.GetGlobalScale().Y
+	if YUp {
+		wy = 2*cy - wy
+	}
+	if v.Rotation != 0 {
+		wx, wy = rotateAround(wx, wy, cx, cy, -v.Rotation)
+	}
+	return wx, wy
+}
+
+// viewport returns the part of the given OpenGL viewport the view covers, as passed to `Gl.Viewport`: the y-axis
+// points up from the bottom.
+func (v *View) viewport(vp [4]int32) (x, y, width, height int) {
+	w, h := float32(vp[2]), float32(vp[3])
+	x = int(vp[0]) + int(v.Viewport.Min.X*w+0.5)
+	y = int(vp[1]) + int((1-v.Viewport.Max.Y)*h+0.5)
+	width = int(v.Viewport.Max.X*w+0.5) - int(v.Viewport.Min.X*w+0.5)
+	height = int((1-v.Viewport.Min.Y)*h+0.5) - int((1-v.Viewport.Max.Y)*h+0.5)
+	return x, y, width, height
+}
+
+// drawingView is the view the RenderSystem is drawing, if any. The shaders project onto the part of the screen it
+// covers, see screenSize.
+var drawingView *View
+
+// AddView starts drawing the world into the view every frame, instead of over the whole screen.
+func (rs *RenderSystem) AddView(v *View) {
+	for _, view := range rs.views {
+		if view == v {
+			return
+		}
+	}
+	rs.views = append(rs.views, v)
+}
+
+// RemoveView stops drawing into the view. Once the last view is removed, the world is drawn over the whole screen
+// again.
+func (rs *RenderSystem) RemoveView(v *View) {
+	for i, view := range rs.views {
+		if view == v {
+			rs.views = append(rs.views[:i], rs.views[i+1:]...)
+			return
+		}
+	}
+}
+
+// Views returns the views the world is drawn into, see AddView.
+func (rs *RenderSystem) Views() []*View {
+	return rs.views
+}
+
+// ViewAt returns the view containing the position within the window, such as the position of the mouse. When views
+// overlap, the one added last is returned, since it's drawn on top. It returns nil when the position isn't on any of
+// the views.
+func (rs *RenderSystem) ViewAt(x, y float32) *View {
+	for i := len(rs.views) - 1; i >= 0; i-- {
+		if rs.views[i].Contains(x, y) {
+			return rs.views[i]
+		}
+	}
+	return nil
+}
+
+// drawPasses draws the render passes. While there are views, the passes ordered before the post-processing are drawn
+// into every view, by restricting the viewport to the view and moving the camera to its Position while drawing it, and
+// the rest over the whole screen.
+func (rs *RenderSystem) drawPasses() {
+	passes := rs.passes()
+	var cam *CameraSystem
+	if len(rs.views) > 0 {
+		for _, system := range rs.world.Systems() {
+			if c, ok := system.(*CameraSystem); ok {
+				cam = c
+				break
+			}
+		}
+	}
+	if cam == nil {
+		for _, pass := range passes {
+			pass.Draw()
+		}
+		return
+	}
+
+	world := 0
+	for world < len(passes) && passes[world].Order < engo.RenderPassPost {
+		world++
+	}
+
+	vp := engo.Gl.GetViewport()
+	x, y, z, angle, shake := cam.x, cam.y, cam.z, cam.angle, cam.shake
+	for _, v := range rs.views {
+		cam.x, cam.y, cam.z, cam.angle, cam.shake = v.Position.X, v.Position.Y, v.zoom(), v.Rotation, cameraShake{}
+		engo.Gl.Viewport(v.viewport(vp))
+		drawingView = v
+		for _, pass := range passes[:world] {
+			pass.Draw()
+		}
+	}
+	drawingView = nil
+	cam.x, cam.y, cam.z, cam.angle, cam.shake = x, y, z, angle, shake
+	engo.Gl.Viewport(int(vp[0]), int(vp[1]), int(vp[2]), int(vp[3]))
+
+	for _, pass := range passes[world:] {
+		pass.Draw()
+	}
+}
@@ -0,0 +1,64 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/EngoEngine/engo"
+)
+
+func TestView(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &testScene{})
+
+	left, right := NewSplitScreen(false)
+	right.Position, right.Zoom = engo.Point{X: 1000, Y: 1000}, 2
+
+	if x, y, w, h := right.viewport([4]int32{0, 0, 800, 600}); x != 400 || y != 0 || w != 400 || h != 600 {
+		t.Errorf("wrong viewport of the right half. Wanted: (400, 0, 400, 600), got: (%v, %v, %v, %v)", x, y, w, h)
+	}
+	top, _ := NewSplitScreen(true)
+	if x, y, w, h := top.viewport([4]int32{0, 0, 800, 600}); x != 0 || y != 300 || w != 800 || h != 300 {
+		t.Errorf("wrong viewport of the top half, whose y-axis points up. Wanted: (0, 300, 800, 300), got: (%v, %v, %v, %v)", x, y, w, h)
+	}
+
+	if x, y := right.ScreenToWorld(300, 150); x != 1000 || y != 1000 {
+		t.Errorf("center of the view was not at its Position. Wanted: (1000, 1000), got: (%v, %v)", x, y)
+	}
+	if x, y := right.ScreenToWorld(350, 150); x != 1100 || y != 1000 {
+		t.Errorf("view was not zoomed. Wanted: (1100, 1000), got: (%v, %v)", x, y)
+	}
+
+	rs := &RenderSystem{}
+	rs.AddView(left)
+	rs.AddView(right)
+	rs.AddView(right)
+	if len(rs.Views()) != 2 {
+		t.Errorf("view was added more than once, got: %v views", len(rs.Views()))
+	}
+	if v := rs.ViewAt(100, 150); v != left {
+		t.Error("cursor on the left half was not on the left view")
+	}
+	if v := rs.ViewAt(300, 150); v != right {
+		t.Error("cursor on the right half was not on the right view")
+	}
+	if v := rs.ViewAt(500, 150); v != nil {
+		t.Error("cursor outside of the window was on a view")
+	}
+
+	// The shaders project onto the half of the screen while drawing the view
+	drawingView = left
+	w, h := screenSize()
+	drawingView = nil
+	if w != 200 || h != 300 {
+		t.Errorf("shaders did not project onto the view. Wanted: 200x300, got: %vx%v", w, h)
+	}
+
+	rs.RemoveView(left)
+	if len(rs.Views()) != 1 || rs.Views()[0] != right {
+		t.Error("view was not removed")
+	}
+}
@@ -0,0 +1,332 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"io"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+// Saveable is implemented by entities which are saved using SaveEntities, such as for save games. SaveType returns
+// the name of the type of the entity, under which the function creating it is registered using RegisterSaveType, so
+// LoadEntities recreates it as the same type.
+//
+// Of the built-in components, the SpaceComponent, RenderComponent, VelocityComponent and HealthComponent are saved
+// whenever the entity contains them anonymously. Other components are saved once the entity implements
+// ComponentSaver.
+type Saveable interface {
+	BasicFace
+	SaveType() string
+}
+
+// ComponentSaver is implemented by entities whose custom components are saved along with the built-in ones, see
+// Saveable. SavedComponents returns pointers to the components by a name which is unique within the entity. When
+// saving, every component is marshaled using encoding/json, so components implementing json.Marshaler and
+// json.Unmarshaler save themselves in any format they like; when loading, the saved JSON is unmarshaled into the same
+// component of the newly created entity.
+type ComponentSaver interface {
+	SavedComponents() map[string]interface{}
+}
+
+// saveTypes contains the functions creating every type of entity, by the name of the type
+var saveTypes = make(map[string]func() Saveable)
+
+// RegisterSaveType registers the function creating an entity of the given type of Saveable entities, which is used by
+// LoadEntities to recreate every saved entity of that type. It returns a new, empty entity; its BasicEntity is set by
+// LoadEntities, and its saved components are loaded into it afterwards. Components which aren't saved, such as an
+// AnimationComponent, can be set up by the function.
+func RegisterSaveType(name string, create func() Saveable) {
+	saveTypes[name] = create
+}
+
+// The names under which the built-in components are saved
+const (
+	saveSpace    = "space"
+	saveRender   = "render"
+	saveVelocity = "velocity"
+	saveHealth   = "health"
+)
+
+type savedEntities struct {
+	Entities []savedEntity `json:"entities"`
+}
+
+type savedEntity struct {
+	Type string `json:"type"`
+	// ID is the ID the entity had when it was saved, for games which refer to other entities by their ID
+	ID         uint64                     `json:"id"`
+	Components map[string]json.RawMessage `json:"components"`
+}
+
+type savedSpace struct {
+	Position engo.Point `json:"position"`
+	Width    float32    `json:"width"`
+	Height   float32    `json:"height"`
+	Rotation float32    `json:"rotation,omitempty"`
+	Hitboxes []Shape    `json:"hitboxes,omitempty"`
+}
+
+type savedRender struct {
+	Texture     *savedTexture     `json:"texture,omitempty"`
+	Hidden      bool              `json:"hidden,omitempty"`
+	ScreenSpace bool              `json:"screenSpace,omitempty"`
+	Scale       engo.Point        `json:"scale"`
+	Color       *color.NRGBA      `json:"color,omitempty"`
+	FlipX       bool              `json:"flipX,omitempty"`
+	FlipY       bool              `json:"flipY,omitempty"`
+	Repeat      TextureRepeating  `json:"repeat,omitempty"`
+	RepeatAxes  TextureRepeatAxes `json:"repeatAxes,omitempty"`
+	ZIndex      float32           `json:"zIndex,omitempty"`
+	Opacity     float32           `json:"opacity"`
+}
+
+// savedTexture refers to a Texture by the url of its image, and the region within it, rather than by its pixels
+type savedTexture struct {
+	URL    string    `json:"url"`
+	Region engo.AABB `json:"region"`
+}
+
+// SaveEntities writes the state of the given entities to w as JSON, to be loaded using LoadEntities. The state of the
+// world itself, such as the systems and the camera, isn't saved.
+//
+// Textures are saved by the url of their image, and the region of it they're showing, so only Textures loaded using
+// `engo.Files` can be saved, such as using LoadedSprite or a Spritesheet; see `Texture.URL`. Other drawables, and the
+// shaders and zoom filters of RenderComponents, aren't saved yet, so saving an entity with a RenderComponent which
+// draws anything but a Texture returns an error; such entities can save their drawable in a custom component instead.
+func SaveEntities(w io.Writer, entities ...Saveable) error {
+	saved := savedEntities{Entities: make([]savedEntity, 0, len(entities))}
+	for _, e := range entities {
+		components, err := saveComponents(e)
+		if err != nil {
+			return fmt.Errorf("unable to save entity %v of type %q: %w", e.ID(), e.SaveType(), err)
+		}
+		saved.Entities = append(saved.Entities, savedEntity{Type: e.SaveType(), ID: e.ID(), Components: components})
+	}
+	return json.NewEncoder(w).Encode(saved)
+}
+
+// saveComponents marshals all components of the entity which are saved, by their names.
+func saveComponents(e Saveable) (map[string]json.RawMessage, error) {
+	components := make(map[string]json.RawMessage)
+	add := func(name string, component interface{}) error {
+		if _, ok := components[name]; ok {
+			return fmt.Errorf("multiple components are saved as %q", name)
+		}
+		data, err := json.Marshal(component)
+		if err != nil {
+			return fmt.Errorf("unable to save component %q: %w", name, err)
+		}
+		components[name] = data
+		return nil
+	}
+
+	if o, ok := e.(SpaceFace); ok {
+		space := o.GetSpaceComponent()
+		if err := add(saveSpace, savedSpace{
+			Position: space.Position,
+			Width:    space.Width,
+			Height:   space.Height,
+			Rotation: space.Rotation,
+			Hitboxes: space.hitboxes,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if o, ok := e.(RenderFace); ok {
+		render, err := saveRenderComponent(o.GetRenderComponent())
+		if err != nil {
+			return nil, err
+		}
+		if err = add(saveRender, render); err != nil {
+			return nil, err
+		}
+	}
+	if o, ok := e.(VelocityFace); ok {
+		if err := add(saveVelocity, o.GetVelocityComponent()); err != nil {
+			return nil, err
+		}
+	}
+	if o, ok := e.(HealthFace); ok {
+		if err := add(saveHealth, o.GetHealthComponent()); err != nil {
+			return nil, err
+		}
+	}
+	if o, ok := e.(ComponentSaver); ok {
+		for name, component := range o.SavedComponents() {
+			if err := add(name, component); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return components, nil
+}
+
+// saveRenderComponent returns the saved state of the RenderComponent.
+func saveRenderComponent(r *RenderComponent) (savedRender, error) {
+	saved := savedRender{
+		Hidden:      r.Hidden,
+		ScreenSpace: r.ScreenSpace,
+		Scale:       r.Scale,
+		FlipX:       r.FlipX,
+		FlipY:       r.FlipY,
+		Repeat:      r.Repeat,
+		RepeatAxes:  r.RepeatAxes,
+		ZIndex:      r.ZIndex(),
+		Opacity:     r.Opacity(),
+	}
+	if r.Color != nil {
+		c := color.NRGBAModel.Convert(r.Color).(color.NRGBA)
+		saved.Color = &c
+	}
+
+	var tex Texture
+	switch d := r.Drawable.(type) {
+	case nil:
+		return saved, nil
+	case Texture:
+		tex = d
+	case *Texture:
+		tex = *d
+	default:
+		return saved, fmt.Errorf("drawables of type %T can't be saved", r.Drawable)
+	}
+	if tex.url == "" {
+		return saved, fmt.Errorf("texture wasn't loaded from a file, so it can't be saved")
+	}
+	saved.Texture = &savedTexture{URL: tex.url, Region: tex.region}
+	return saved, nil
+}
+
+// LoadEntities reads the entities saved using SaveEntities from r, and adds them to the given world using
+// `ecs.World.AddEntity`, so they're added to every system which was added using `ecs.World.AddSystemInterface`. It
+// returns the entities, in the order in which they were saved.
+//
+// Every entity is created using the function registered for its type using RegisterSaveType, and gets a new ID, since
+// the saved IDs may be in use already. The images of saved Textures have to be loaded beforehand, such as in the
+// Preload of the scene; Textures are loaded as a Texture, not a *Texture. Nothing is added to the world if any of the
+// entities can't be loaded.
+func LoadEntities(w *ecs.World, r io.Reader) ([]Saveable, error) {
+	var saved savedEntities
+	if err := json.NewDecoder(r).Decode(&saved); err != nil {
+		return nil, fmt.Errorf("unable to read saved entities: %w", err)
+	}
+
+	entities := make([]Saveable, 0, len(saved.Entities))
+	for _, s := range saved.Entities {
+		create, ok := saveTypes[s.Type]
+		if !ok {
+			return nil, fmt.Errorf("unable to load entity %v, type %q was not registered", s.ID, s.Type)
+		}
+		e := create()
+		*e.GetBasicEntity() = ecs.NewBasic()
+		if err := loadComponents(e, s.Components); err != nil {
+			return nil, fmt.Errorf("unable to load entity %v of type %q: %w", s.ID, s.Type, err)
+		}
+		entities = append(entities, e)
+	}
+
+	if w != nil {
+		for _, e := range entities {
+			w.AddEntity(e)
+		}
+	}
+	return entities, nil
+}
+
+// loadComponents unmarshals the saved components into those of the entity.
+func loadComponents(e Saveable, components map[string]json.RawMessage) error {
+	targets := make(map[string]interface{})
+	if o, ok := e.(ComponentSaver); ok {
+		for name, component := range o.SavedComponents() {
+			targets[name] = component
+		}
+	}
+
+	for name, data := range components {
+		var err error
+		switch target, custom := targets[name]; {
+		case custom:
+			err = json.Unmarshal(data, target)
+		case name == saveSpace:
+			o, ok := e.(SpaceFace)
+			if !ok {
+				return fmt.Errorf("entity has no SpaceComponent")
+			}
+			var space savedSpace
+			if err = json.Unmarshal(data, &space); err == nil {
+				*o.GetSpaceComponent() = SpaceComponent{
+					Position: space.Position,
+					Width:    space.Width,
+					Height:   space.Height,
+					Rotation: space.Rotation,
+					hitboxes: space.Hitboxes,
+				}
+			}
+		case name == saveRender:
+			o, ok := e.(RenderFace)
+			if !ok {
+				return fmt.Errorf("entity has no RenderComponent")
+			}
+			var render savedRender
+			if err = json.Unmarshal(data, &render); err == nil {
+				err = loadRenderComponent(o.GetRenderComponent(), render)
+			}
+		case name == saveVelocity:
+			o, ok := e.(VelocityFace)
+			if !ok {
+				return fmt.Errorf("entity has no VelocityComponent")
+			}
+			err = json.Unmarshal(data, o.GetVelocityComponent())
+		case name == saveHealth:
+			o, ok := e.(HealthFace)
+			if !ok {
+				return fmt.Errorf("entity has no HealthComponent")
+			}
+			err = json.Unmarshal(data, o.GetHealthComponent())
+		default:
+			return fmt.Errorf("entity has no component %q", name)
+		}
+		if err != nil {
+			return fmt.Errorf("unable to load component %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// loadRenderComponent sets the RenderComponent to the saved state.
+func loadRenderComponent(r *RenderComponent, saved savedRender) error {
+	r.Hidden = saved.Hidden
+	r.ScreenSpace = saved.ScreenSpace
+	r.Scale = saved.Scale
+	r.FlipX, r.FlipY = saved.FlipX, saved.FlipY
+	r.Repeat, r.RepeatAxes = saved.Repeat, saved.RepeatAxes
+	r.StartZIndex, r.zIndex = saved.ZIndex, saved.ZIndex
+	r.SetOpacity(saved.Opacity)
+	r.Color = nil
+	if saved.Color != nil {
+		r.Color = *saved.Color
+	}
+
+	r.Drawable = nil
+	if saved.Texture == nil {
+		return nil
+	}
+	tex, err := LoadedSprite(saved.Texture.URL)
+	if err != nil {
+		return err
+	}
+	if region := saved.Texture.Region; region != tex.region {
+		// The Texture showed part of the image, such as a cell of a Spritesheet
+		tex.viewport = engo.AABB{
+			Min: engo.Point{X: region.Min.X / tex.width, Y: region.Min.Y / tex.height},
+			Max: engo.Point{X: region.Max.X / tex.width, Y: region.Max.Y / tex.height},
+		}
+		tex.width, tex.height = region.Max.X-region.Min.X, region.Max.Y-region.Min.Y
+		tex.region = region
+	}
+	r.Drawable = *tex
+	return nil
+}
@@ -0,0 +1,124 @@
+package common
+
+import (
+	"bytes"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+// saveTestScore is a custom component which is saved by savedTestEntity
+type saveTestScore struct {
+	Points int
+}
+
+type savedTestEntity struct {
+	ecs.BasicEntity
+	SpaceComponent
+	RenderComponent
+	VelocityComponent
+	Score saveTestScore
+}
+
+func (*savedTestEntity) SaveType() string { return "savedTestEntity" }
+
+func (e *savedTestEntity) SavedComponents() map[string]interface{} {
+	return map[string]interface{}{"score": &e.Score}
+}
+
+func TestSaveEntities(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &textureTestScene{})
+	loadTestImage(t, "save.png", 40, 20)
+	RegisterSaveType("savedTestEntity", func() Saveable { return &savedTestEntity{} })
+
+	sprite, err := LoadedSprite("save.png")
+	if err != nil {
+		t.Fatalf("Unable to retrieve loaded sprite. Error was: %v", err)
+	}
+	sheet := NewSpritesheetFromFile("save.png", 10, 10)
+
+	player := &savedTestEntity{BasicEntity: ecs.NewBasic(), Score: saveTestScore{Points: 42}}
+	player.SpaceComponent = SpaceComponent{Position: engo.Point{X: 10, Y: 20}, Width: 40, Height: 20, Rotation: 90}
+	player.AddShape(Shape{Ellipse: Ellipse{Cx: 20, Cy: 10, Rx: 20, Ry: 10}})
+	player.RenderComponent = RenderComponent{Drawable: sprite, Scale: engo.Point{X: 2, Y: 2}, Color: color.White, FlipX: true}
+	player.RenderComponent.zIndex = 3
+	player.SetOpacity(0.5)
+	player.VelocityComponent = VelocityComponent{Velocity: engo.Point{X: 5}, Angular: 10}
+	cell := &savedTestEntity{BasicEntity: ecs.NewBasic()}
+	cell.RenderComponent = RenderComponent{Drawable: sheet.Cell(5), Hidden: true}
+
+	buf := &bytes.Buffer{}
+	if err = SaveEntities(buf, player, cell); err != nil {
+		t.Fatalf("Unable to save entities. Error was: %v", err)
+	}
+	if strings.Contains(buf.String(), "pixels") || !strings.Contains(buf.String(), `"url":"save.png"`) {
+		t.Errorf("texture was not saved by its url, got: %s", buf.String())
+	}
+
+	w := &ecs.World{}
+	var movable *Movable
+	movement := &MovementSystem{}
+	w.AddSystemInterface(movement, movable, nil)
+	entities, err := LoadEntities(w, buf)
+	if err != nil {
+		t.Fatalf("Unable to load entities. Error was: %v", err)
+	}
+	if len(entities) != 2 {
+		t.Fatalf("wrong number of entities loaded. Wanted: 2, got: %v", len(entities))
+	}
+	if len(movement.Entities()) != 2 {
+		t.Errorf("loaded entities were not added to the systems of the world, got: %v", movement.Entities())
+	}
+
+	loaded := entities[0].(*savedTestEntity)
+	if loaded.ID() == player.ID() {
+		t.Error("loaded entity did not get a new ID")
+	}
+	if loaded.Position != player.Position || loaded.Width != 40 || loaded.Rotation != 90 || len(loaded.hitboxes) != 1 || loaded.hitboxes[0].Ellipse.Rx != 20 {
+		t.Errorf("space component was not loaded, got: %+v", loaded.SpaceComponent)
+	}
+	if loaded.VelocityComponent != player.VelocityComponent || loaded.Score.Points != 42 {
+		t.Errorf("components were not loaded. Velocity: %+v, score: %+v", loaded.VelocityComponent, loaded.Score)
+	}
+	ren := loaded.RenderComponent
+	if ren.Scale != player.Scale || !ren.FlipX || ren.ZIndex() != 3 || ren.Opacity() != 0.5 || ren.Color != (color.NRGBA{255, 255, 255, 255}) {
+		t.Errorf("render component was not loaded, got: %+v", ren)
+	}
+	if tex, ok := ren.Drawable.(Texture); !ok || tex.URL() != "save.png" || tex.Width() != 40 || tex.Height() != 20 {
+		t.Errorf("texture was not loaded, got: %+v", ren.Drawable)
+	}
+
+	loadedCell := entities[1].(*savedTestEntity).RenderComponent
+	expected := sheet.Cell(5)
+	if tex, ok := loadedCell.Drawable.(Texture); !ok || tex.Region() != expected.Region() || tex.viewport != expected.viewport || !loadedCell.Hidden {
+		t.Errorf("cell of the spritesheet was not loaded. Wanted: %+v, got: %+v", expected, loadedCell.Drawable)
+	}
+}
+
+func TestSaveEntitiesErrors(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &textureTestScene{})
+
+	e := &savedTestEntity{BasicEntity: ecs.NewBasic()}
+	e.Drawable = Rectangle{}
+	if err := SaveEntities(&bytes.Buffer{}, e); err == nil {
+		t.Error("entity drawing a drawable which can't be saved was saved")
+	}
+
+	w := &ecs.World{}
+	if _, err := LoadEntities(w, strings.NewReader(`{"entities":[{"type":"unknown","components":{}}]}`)); err == nil {
+		t.Error("entity of an unregistered type was loaded")
+	}
+	RegisterSaveType("savedTestEntity", func() Saveable { return &savedTestEntity{} })
+	if _, err := LoadEntities(w, strings.NewReader(`{"entities":[{"type":"savedTestEntity","components":{"health":{}}}]}`)); err == nil {
+		t.Error("component which the entity doesn't have was loaded")
+	}
+}
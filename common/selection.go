@@ -0,0 +1,208 @@
+package common
+
+import (
+	"image/color"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/engo/math"
+)
+
+// selectionZIndex is the z-index of the rectangle drawn while selecting, which is drawn on top of the world
+const selectionZIndex = 1000
+
+// The colors used by the SelectionSystem whenever its colors aren't set.
+var (
+	defaultSelectionColor       = color.NRGBA{R: 255, G: 255, B: 255, A: 48}
+	defaultSelectionBorderColor = color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+)
+
+// SelectionComponent marks an entity as selectable by the SelectionSystem.
+type SelectionComponent struct {
+	// Selected is true while the entity is selected
+	Selected bool
+}
+
+type selectionEntity struct {
+	*ecs.BasicEntity
+	*SpaceComponent
+	*SelectionComponent
+}
+
+// SelectionSystem lets the player select multiple entities at once by dragging a rectangle over them using the
+// left mouse button, as in strategy games and editors. A selection starts when the button is pressed while the
+// cursor isn't on any of the entities of the MouseSystem, so the world needs one, and clicking or dragging those
+// entities works as usual.
+//
+// While dragging, the rectangle is drawn in the world by the RenderSystem, and every entity whose AABB intersects it
+// is Selected. Once the button is released, OnSelect is called with the selected entities. The rectangle spans from
+// the point where the drag started to the cursor in whichever direction it was dragged, see Rect. Releasing the
+// button without dragging selects nothing, which clears the selection.
+type SelectionSystem struct {
+	// OnSelect is called with the IDs of the selected entities whenever a selection ends
+	OnSelect func(selected []uint64)
+	// Color is the color of the rectangle drawn while selecting, it's translucent white when nil
+	Color color.Color
+	// BorderColor is the color of the border of that rectangle, it's white when nil
+	BorderColor color.Color
+
+	entities []selectionEntity
+	ids      entityIDs
+	world    *ecs.World
+	mouse    *MouseSystem
+	render   *RenderSystem
+
+	selecting  bool
+	start, end engo.Point
+	selected   []uint64
+
+	rect struct {
+		ecs.BasicEntity
+		RenderComponent
+		SpaceComponent
+	}
+}
+
+// New is called when the SelectionSystem is added to the world.
+func (s *SelectionSystem) New(w *ecs.World) {
+	s.world = w
+	s.rect.BasicEntity = ecs.NewBasic()
+}
+
+// Add adds an entity to the SelectionSystem. The entity needs a basic, space and selection component to be added to
+// the system.
+func (s *SelectionSystem) Add(basic *ecs.BasicEntity, space *SpaceComponent, selection *SelectionComponent) {
+	if !s.ids.add(basic.ID()) {
+		return
+	}
+	s.entities = append(s.entities, selectionEntity{basic, space, selection})
+}
+
+// AddByInterface Provides a simple way to add an entity to the system that satisfies Selectable. Any entity
+// containing, BasicEntity, SpaceComponent and SelectionComponent anonymously, automatically does this.
+func (s *SelectionSystem) AddByInterface(i ecs.Identifier) {
+	o, _ := i.(Selectable)
+	s.Add(o.GetBasicEntity(), o.GetSpaceComponent(), o.GetSelectionComponent())
+}
+
+// Remove removes an entity from the SelectionSystem, and from the selection.
+func (s *SelectionSystem) Remove(basic ecs.BasicEntity) {
+	s.ids.remove(basic.ID())
+	for i, e := range s.entities {
+		if e.ID() == basic.ID() {
+			s.entities = append(s.entities[:i], s.entities[i+1:]...)
+			break
+		}
+	}
+	for i, id := range s.selected {
+		if id == basic.ID() {
+			s.selected = append(s.selected[:i], s.selected[i+1:]...)
+			break
+		}
+	}
+}
+
+// Selecting returns whether or not the player is dragging a selection rectangle.
+func (s *SelectionSystem) Selecting() bool {
+	return s.selecting
+}
+
+// Rect returns the selection rectangle in world coordinates, as of the last update. Its minimum is never larger than
+// its maximum, regardless of the direction in which it was dragged.
+func (s *SelectionSystem) Rect() engo.AABB {
+	return engo.AABB{
+		Min: engo.Point{X: math.Min(s.start.X, s.end.X), Y: math.Min(s.start.Y, s.end.Y)},
+		Max: engo.Point{X: math.Max(s.start.X, s.end.X), Y: math.Max(s.start.Y, s.end.Y)},
+	}
+}
+
+// Selected returns the IDs of the selected entities, as of the last update.
+func (s *SelectionSystem) Selected() []uint64 {
+	return s.selected
+}
+
+// Update starts, resizes and ends the selection, and selects the entities within its rectangle.
+func (s *SelectionSystem) Update(dt float32) {
+	if !s.findMouse() {
+		return
+	}
+	mouse := engo.Point{X: s.mouse.mouseX, Y: s.mouse.mouseY}
+	left := engo.Input.Mouse.Button == engo.MouseButtonLeft
+	switch action := engo.Input.Mouse.Action; {
+	case !s.selecting && action == engo.Press && left && engo.Input.Mouse.Inside && !s.mouse.hit:
+		s.selecting = true
+		s.start, s.end = mouse, mouse
+		s.show()
+	case s.selecting && action == engo.Release && left:
+		s.end = mouse
+		s.selecting = false
+		s.selectWithin()
+		s.hide()
+		if s.OnSelect != nil {
+			s.OnSelect(append([]uint64(nil), s.selected...))
+		}
+		return
+	case s.selecting:
+		s.end = mouse
+	default:
+		return
+	}
+
+	s.selectWithin()
+	rect := s.Rect()
+	s.rect.SpaceComponent.Position = rect.Min
+	size := rect.Size()
+	s.rect.SpaceComponent.Width, s.rect.SpaceComponent.Height = size.X, size.Y
+}
+
+// findMouse looks up the MouseSystem of the world, and returns whether or not it was found.
+func (s *SelectionSystem) findMouse() bool {
+	if s.mouse != nil {
+		return true
+	}
+	if s.world == nil {
+		return false
+	}
+	for _, system := range s.world.Systems() {
+		switch sys := system.(type) {
+		case *MouseSystem:
+			s.mouse = sys
+		case *RenderSystem:
+			s.render = sys
+		}
+	}
+	return s.mouse != nil
+}
+
+// selectWithin selects the entities whose AABB intersects the selection rectangle, and deselects the others.
+func (s *SelectionSystem) selectWithin() {
+	rect := s.Rect()
+	s.selected = s.selected[:0]
+	for _, e := range s.entities {
+		e.SelectionComponent.Selected = e.SpaceComponent.AABB().Intersects(rect)
+		if e.SelectionComponent.Selected {
+			s.selected = append(s.selected, e.ID())
+		}
+	}
+}
+
+// show starts drawing the selection rectangle, if there is a RenderSystem.
+func (s *SelectionSystem) show() {
+	if s.render == nil {
+		return
+	}
+	s.rect.RenderComponent = RenderComponent{
+		Drawable: Rectangle{BorderWidth: 1, BorderColor: debugColor(s.BorderColor, defaultSelectionBorderColor)},
+		Color:    debugColor(s.Color, defaultSelectionColor),
+	}
+	s.rect.RenderComponent.SetZIndex(selectionZIndex)
+	s.rect.SpaceComponent = SpaceComponent{Position: s.start}
+	s.render.Add(&s.rect.BasicEntity, &s.rect.RenderComponent, &s.rect.SpaceComponent)
+}
+
+// hide stops drawing the selection rectangle.
+func (s *SelectionSystem) hide() {
+	if s.render != nil {
+		s.render.Remove(s.rect.BasicEntity)
+	}
+}
@@ -0,0 +1,92 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+func TestSelectionSystem(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	rs := &RenderSystem{}
+	w.AddSystem(rs)
+	m := &MouseSystem{}
+	w.AddSystem(m)
+	var ended [][]uint64
+	s := &SelectionSystem{OnSelect: func(selected []uint64) { ended = append(ended, selected) }}
+	w.AddSystem(s)
+
+	basics := []ecs.BasicEntity{ecs.NewBasic(), ecs.NewBasic(), ecs.NewBasic()}
+	spaces := []SpaceComponent{
+		{Position: engo.Point{X: 100, Y: 100}, Width: 20, Height: 20},
+		{Position: engo.Point{X: 150, Y: 150}, Width: 20, Height: 20},
+		{Position: engo.Point{X: 300, Y: 250}, Width: 20, Height: 20},
+	}
+	components := make([]SelectionComponent, 3)
+	for i := range basics {
+		s.Add(&basics[i], &spaces[i], &components[i])
+	}
+	// A button, which is clicked rather than starting a selection
+	button := ecs.NewBasic()
+	m.Add(&button, &MouseComponent{}, &SpaceComponent{Position: engo.Point{X: 350, Y: 10}, Width: 40, Height: 20}, nil)
+	defer func() { engo.Input.Mouse.Action = engo.Neutral }()
+
+	mouse := func(action engo.Action, x, y float32) {
+		engo.Input.Mouse.X, engo.Input.Mouse.Y = x, y
+		engo.Input.Mouse.Button = engo.MouseButtonLeft
+		engo.Input.Mouse.Action = action
+		w.Update(0)
+	}
+
+	// Dragged up and to the left
+	mouse(engo.Press, 200, 200)
+	if !s.Selecting() {
+		t.Fatal("pressing on empty space did not start a selection")
+	}
+	if _, _, ok := rs.Get(s.rect.ID()); !ok {
+		t.Error("selection rectangle was not drawn")
+	}
+	mouse(engo.Move, 160, 160)
+	if !components[1].Selected || components[0].Selected {
+		t.Error("entities were not selected while dragging")
+	}
+	mouse(engo.Move, 110, 110)
+	if rect := s.Rect(); rect != (engo.AABB{Min: engo.Point{X: 110, Y: 110}, Max: engo.Point{X: 200, Y: 200}}) {
+		t.Errorf("selection rectangle was not normalized. Wanted: (110, 110) to (200, 200), got: %v", rect)
+	}
+	mouse(engo.Release, 110, 110)
+	if s.Selecting() {
+		t.Error("selection did not end once the button was released")
+	}
+	if _, _, ok := rs.Get(s.rect.ID()); ok {
+		t.Error("selection rectangle was still drawn once the selection ended")
+	}
+	if len(ended) != 1 || len(ended[0]) != 2 || ended[0][0] != basics[0].ID() || ended[0][1] != basics[1].ID() {
+		t.Errorf("wrong entities were selected, got: %v", ended)
+	}
+	if components[2].Selected {
+		t.Error("entity outside of the rectangle was selected")
+	}
+
+	mouse(engo.Press, 360, 20)
+	if s.Selecting() {
+		t.Error("pressing on an entity of the MouseSystem started a selection")
+	}
+	mouse(engo.Release, 360, 20)
+
+	// Clicking on empty space clears the selection
+	mouse(engo.Press, 50, 250)
+	mouse(engo.Release, 50, 250)
+	if len(s.Selected()) != 0 || components[0].Selected {
+		t.Errorf("clicking without dragging did not clear the selection, got: %v", s.Selected())
+	}
+}
@@ -0,0 +1,327 @@
+package common
+
+import (
+	"image/color"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/engo/math"
+)
+
+// shapeCircleSegments is the amount of segments with which circles are drawn.
+const shapeCircleSegments = 48
+
+// shapeMiterLimit is how many times half the width of a line its corners stick out at most, so sharp corners don't
+// turn into long spikes.
+const shapeMiterLimit = 4
+
+// shapeBatch holds the triangles of consecutive shapes of the same color.
+type shapeBatch struct {
+	color  color.Color
+	points []engo.Point
+}
+
+// ShapeComponent draws lines, rectangles and circles, without needing a texture or an entity for each of them, such
+// as for debug overlays, simple UI or games drawn using shapes alone. The shapes are added using its methods, and kept
+// until they're cleared, so static shapes are added once and changing ones are cleared and added again every frame.
+// They're drawn by the ShapeSystem in the order in which they were added.
+//
+// Positions are in world coordinates, or in screen coordinates for entities with ScreenSpace set, and relative to the
+// position of the SpaceComponent of the entity if it has one. Widths of lines are in the same units, so lines in the
+// world become thicker while zooming in. Edges are antialiased using multisampling, see `engo.RunOptions.MSAA`.
+type ShapeComponent struct {
+	// Hidden stops drawing the shapes, while keeping them
+	Hidden bool
+	// ScreenSpace draws the shapes on top of the HUD in screen coordinates, rather than in the world, like the
+	// ScreenSpace of a RenderComponent
+	ScreenSpace bool
+
+	batches []shapeBatch
+}
+
+// Clear removes all shapes.
+func (sc *ShapeComponent) Clear() {
+	sc.batches = sc.batches[:0]
+}
+
+// Line adds a line of the given width from one point to another.
+func (sc *ShapeComponent) Line(from, to engo.Point, width float32, c color.Color) {
+	sc.Polyline([]engo.Point{from, to}, false, width, c)
+}
+
+// Polyline adds lines of the given width through all points, joined at their corners. If closed is set, the last
+// point connects back to the first, such as for drawing the outline of a polygon.
+func (sc *ShapeComponent) Polyline(points []engo.Point, closed bool, width float32, c color.Color) {
+	b := sc.batch(c)
+	b.points = appendPolyline(b.points, points, closed, width)
+}
+
+// FillRect adds a rectangle filled with the given color.
+func (sc *ShapeComponent) FillRect(rect engo.AABB, c color.Color) {
+	b := sc.batch(c)
+	minX, minY, maxX, maxY := rect.Min.X, rect.Min.Y, rect.Max.X, rect.Max.Y
+	b.points = append(b.points,
+		engo.Point{X: minX, Y: minY}, engo.Point{X: maxX, Y: minY}, engo.Point{X: maxX, Y: maxY},
+		engo.Point{X: maxX, Y: maxY}, engo.Point{X: minX, Y: maxY}, engo.Point{X: minX, Y: minY},
+	)
+}
+
+// StrokeRect adds the outline of a rectangle, as lines of the given width centered on its edges.
+func (sc *ShapeComponent) StrokeRect(rect engo.AABB, width float32, c color.Color) {
+	sc.Polyline([]engo.Point{
+		rect.Min, {X: rect.Max.X, Y: rect.Min.Y}, rect.Max, {X: rect.Min.X, Y: rect.Max.Y},
+	}, true, width, c)
+}
+
+// FillCircle adds a circle filled with the given color.
+func (sc *ShapeComponent) FillCircle(center engo.Point, radius float32, c color.Color) {
+	b := sc.batch(c)
+	points := circlePoints(center, radius)
+	for i, p := range points {
+		b.points = append(b.points, center, p, points[(i+1)%len(points)])
+	}
+}
+
+// StrokeCircle adds the outline of a circle, as a line of the given width centered on its edge.
+func (sc *ShapeComponent) StrokeCircle(center engo.Point, radius, width float32, c color.Color) {
+	sc.Polyline(circlePoints(center, radius), true, width, c)
+}
+
+// batch returns the batch the shapes of the given color are added to, which is the last one if it has the same
+// color, so the order in which the shapes are drawn is kept.
+func (sc *ShapeComponent) batch(c color.Color) *shapeBatch {
+	if c == nil {
+		c = color.White
+	}
+	if n := len(sc.batches); n > 0 && sc.batches[n-1].color == c {
+		return &sc.batches[n-1]
+	}
+	if len(sc.batches) < cap(sc.batches) {
+		// Reuse the points of a batch which was cleared
+		sc.batches = sc.batches[:len(sc.batches)+1]
+		b := &sc.batches[len(sc.batches)-1]
+		b.color, b.points = c, b.points[:0]
+		return b
+	}
+	sc.batches = append(sc.batches, shapeBatch{color: c})
+	return &sc.batches[len(sc.batches)-1]
+}
+
+// circlePoints returns the points along the edge of a circle.
+func circlePoints(center engo.Point, radius float32) []engo.Point {
+	points := make([]engo.Point, shapeCircleSegments)
+	for i := range points {
+		sin, cos := math.Sincos(2 * math.Pi * float32(i) / shapeCircleSegments)
+		points[i] = engo.Point{X: center.X + cos*radius, Y: center.Y + sin*radius}
+	}
+	return points
+}
+
+// appendPolyline appends the triangles of lines of the given width through the points to triangles. Every line is a
+// quad, and consecutive lines share the corners of their quads, which are mitered so there are no gaps in between.
+func appendPolyline(triangles, points []engo.Point, closed bool, width float32) []engo.Point {
+	if width <= 0 {
+		width = 1
+	}
+	// Points on top of one another have no direction, so they're skipped
+	pts := make([]engo.Point, 0, len(points))
+	for _, p := range points {
+		if len(pts) == 0 || !p.Equal(pts[len(pts)-1]) {
+			pts = append(pts, p)
+		}
+	}
+	if closed && len(pts) > 1 && pts[0].Equal(pts[len(pts)-1]) {
+		pts = pts[:len(pts)-1]
+	}
+	n := len(pts)
+	if n < 2 {
+		return triangles
+	}
+	if n < 3 {
+		closed = false
+	}
+
+	half := width / 2
+	offsets := make([]engo.Point, n)
+	for i, p := range pts {
+		hasPrev, hasNext := i > 0 || closed, i < n-1 || closed
+		var prev, next engo.Point
+		if hasPrev {
+			prev = lineNormal(pts[(i+n-1)%n], p)
+		}
+		if hasNext {
+			next = lineNormal(p, pts[(i+1)%n])
+		}
+
+		switch {
+		case !hasPrev:
+			offsets[i] = engo.Point{X: next.X * half, Y: next.Y * half}
+		case !hasNext:
+			offsets[i] = engo.Point{X: prev.X * half, Y: prev.Y * half}
+		default:
+			miter := engo.Point{X: prev.X + next.X, Y: prev.Y + next.Y}
+			length := math.Sqrt(miter.X*miter.X + miter.Y*miter.Y)
+			if length < 1e-6 {
+				// The line turns back on itself
+				offsets[i] = engo.Point{X: prev.X * half, Y: prev.Y * half}
+				continue
+			}
+			miter.X, miter.Y = miter.X/length, miter.Y/length
+			scale := math.Min(half/(miter.X*prev.X+miter.Y*prev.Y), half*shapeMiterLimit)
+			offsets[i] = engo.Point{X: miter.X * scale, Y: miter.Y * scale}
+		}
+	}
+
+	lines := n - 1
+	if closed {
+		lines = n
+	}
+	for i := 0; i < lines; i++ {
+		j := (i + 1) % n
+		a, b := pts[i], pts[j]
+		leftA := engo.Point{X: a.X + offsets[i].X, Y: a.Y + offsets[i].Y}
+		rightA := engo.Point{X: a.X - offsets[i].X, Y: a.Y - offsets[i].Y}
+		leftB := engo.Point{X: b.X + offsets[j].X, Y: b.Y + offsets[j].Y}
+		rightB := engo.Point{X: b.X - offsets[j].X, Y: b.Y - offsets[j].Y}
+		triangles = append(triangles, leftA, rightA, leftB, leftB, rightA, rightB)
+	}
+	return triangles
+}
+
+// lineNormal returns the unit vector perpendicular to the line from a to b.
+func lineNormal(a, b engo.Point) engo.Point {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	length := math.Sqrt(dx*dx + dy*dy)
+	return engo.Point{X: -dy / length, Y: dx / length}
+}
+
+type shapeEntity struct {
+	*ecs.BasicEntity
+	*ShapeComponent
+	*SpaceComponent
+}
+
+// ShapeSystem draws the shapes of ShapeComponents. When it's added to the same world as the RenderSystem, the shapes
+// in the world are drawn by the RenderSystem in the "shapes" pass, on top of the world and below the particles, and
+// those in screen space in the "hudshapes" pass, on top of the HUD. Every batch of shapes of the same color is drawn
+// as triangles by the LegacyShader, so all shapes are batched together.
+type ShapeSystem struct {
+	entities []shapeEntity
+	ids      entityIDs
+	drawer   shapeDrawer
+	passes   []engo.RenderPass
+}
+
+// Add adds an entity to the ShapeSystem. To be added, the entity has to have a basic and shape component.
+func (s *ShapeSystem) Add(basic *ecs.BasicEntity, shape *ShapeComponent) {
+	s.AddWithSpace(basic, shape, nil)
+}
+
+// AddWithSpace adds an entity to the ShapeSystem, which draws its shapes relative to the position of the space
+// component. The rotation of the space component is not applied. Without a space component, it's the same as Add.
+func (s *ShapeSystem) AddWithSpace(basic *ecs.BasicEntity, shape *ShapeComponent, space *SpaceComponent) {
+	if !s.ids.add(basic.ID()) {
+		return
+	}
+	s.entities = append(s.entities, shapeEntity{basic, shape, space})
+}
+
+// AddByInterface Provides a simple way to add an entity to the system that satisfies Shapeable. Any entity containing, BasicEntity and ShapeComponent anonymously, automatically does this. Entities which also contain a SpaceComponent are added with it, see AddWithSpace.
+func (s *ShapeSystem) AddByInterface(i ecs.Identifier) {
+	o, _ := i.(Shapeable)
+	var space *SpaceComponent
+	if sp, ok := i.(SpaceFace); ok {
+		space = sp.GetSpaceComponent()
+	}
+	s.AddWithSpace(o.GetBasicEntity(), o.GetShapeComponent(), space)
+}
+
+// Remove removes an entity from the ShapeSystem.
+func (s *ShapeSystem) Remove(basic ecs.BasicEntity) {
+	s.ids.remove(basic.ID())
+	delete := -1
+	for index, e := range s.entities {
+		if e.BasicEntity.ID() == basic.ID() {
+			delete = index
+			break
+		}
+	}
+	if delete >= 0 {
+		s.entities = append(s.entities[:delete], s.entities[delete+1:]...)
+	}
+}
+
+// Update does nothing, since the shapes are drawn by the RenderSystem. It implements the ecs.System interface.
+func (*ShapeSystem) Update(float32) {}
+
+// renderPasses returns the "shapes" pass, which draws the shapes in the world, and the "hudshapes" pass, which draws
+// those on the HUD. It implements the renderPasser interface.
+func (s *ShapeSystem) renderPasses() []engo.RenderPass {
+	if s.passes == nil {
+		s.passes = []engo.RenderPass{
+			{Name: "shapes", Order: engo.RenderPassShapes, Draw: s.drawWorld},
+			{Name: "hudshapes", Order: engo.RenderPassHUDShapes, Draw: s.drawHUD},
+		}
+	}
+	return s.passes
+}
+
+// drawWorld draws the shapes in the world. It's drawn by the RenderSystem as a render pass, see
+// `RenderSystem.passes`.
+func (s *ShapeSystem) drawWorld() {
+	s.draw(LegacyShader, false)
+}
+
+// drawHUD draws the shapes in screen space. It's drawn by the RenderSystem as a render pass, see
+// `RenderSystem.passes`.
+func (s *ShapeSystem) drawHUD() {
+	s.draw(LegacyHUDShader, true)
+}
+
+// draw draws the shapes of every entity that is in screen space or not, using the given shader.
+func (s *ShapeSystem) draw(shader Shader, screen bool) {
+	drawing := false
+	for _, e := range s.entities {
+		if e.Hidden || e.ScreenSpace != screen || len(e.batches) == 0 {
+			continue
+		}
+		if !drawing {
+			shader.Pre()
+			drawing = true
+		}
+		var position engo.Point
+		if e.SpaceComponent != nil {
+			position = e.SpaceComponent.Position
+		}
+		s.drawer.draw(shader, e.ShapeComponent, position)
+	}
+	if drawing {
+		shader.Post()
+	}
+}
+
+// shapeDrawer draws the shapes of ShapeComponents as triangles, between the Pre and Post of the shader.
+type shapeDrawer struct {
+	render RenderComponent
+	space  SpaceComponent
+	buffer []float32
+}
+
+// draw draws the shapes of sc, moved by the given position.
+func (d *shapeDrawer) draw(shader Shader, sc *ShapeComponent, position engo.Point) {
+	d.render.Scale = engo.Point{X: 1, Y: 1}
+	// The points are used as they are, since they're scaled by the size of the SpaceComponent
+	d.space = SpaceComponent{Position: position, Width: 1, Height: 1}
+	for _, b := range sc.batches {
+		if len(b.points) == 0 {
+			continue
+		}
+		if n := len(b.points) * 6; cap(d.buffer) < n {
+			d.buffer = make([]float32, n)
+		}
+		d.render.BufferContent = d.buffer[:len(b.points)*6]
+		d.render.Drawable = ComplexTriangles{Points: b.points}
+		d.render.Color = b.color
+		shader.Draw(&d.render, &d.space)
+	}
+}
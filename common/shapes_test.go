@@ -0,0 +1,147 @@
+package common
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/engo/math"
+)
+
+// hasPoint indicates whether or not any of the points is at the given position.
+func hasPoint(points []engo.Point, p engo.Point) bool {
+	for _, point := range points {
+		if point.Equal(p) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestShapeComponentStrokeRect(t *testing.T) {
+	sc := &ShapeComponent{}
+	sc.StrokeRect(engo.AABB{Max: engo.Point{X: 10, Y: 10}}, 2, color.White)
+	if len(sc.batches) != 1 {
+		t.Fatalf("outline was not added as a single batch, got %v", len(sc.batches))
+	}
+
+	points := sc.batches[0].points
+	if len(points) != 4*6 {
+		t.Errorf("outline was not drawn as 4 lines, got %v points", len(points))
+	}
+	// The lines are centered on the edges and mitered, so the corners are square
+	for _, p := range []engo.Point{{X: -1, Y: -1}, {X: 11, Y: -1}, {X: 11, Y: 11}, {X: -1, Y: 11}, {X: 1, Y: 1}, {X: 9, Y: 9}} {
+		if !hasPoint(points, p) {
+			t.Errorf("corner %v of the outline is missing", p)
+		}
+	}
+}
+
+func TestShapeComponentPolyline(t *testing.T) {
+	sc := &ShapeComponent{}
+	sc.Line(engo.Point{X: 0, Y: 0}, engo.Point{X: 10, Y: 0}, 4, color.White)
+	points := sc.batches[0].points
+	if len(points) != 6 {
+		t.Fatalf("line was not drawn as a single quad, got %v points", len(points))
+	}
+	for _, p := range []engo.Point{{X: 0, Y: -2}, {X: 0, Y: 2}, {X: 10, Y: -2}, {X: 10, Y: 2}} {
+		if !hasPoint(points, p) {
+			t.Errorf("corner %v of the line is missing", p)
+		}
+	}
+
+	// Points on top of one another are skipped, and so is a line without a length
+	sc.Clear()
+	sc.Polyline([]engo.Point{{X: 0, Y: 0}, {X: 0, Y: 0}, {X: 5, Y: 0}, {X: 5, Y: 5}}, false, 1, color.White)
+	sc.Line(engo.Point{X: 3, Y: 3}, engo.Point{X: 3, Y: 3}, 1, color.White)
+	if n := len(sc.batches[0].points); n != 2*6 {
+		t.Errorf("polyline was not drawn as 2 lines, got %v points", n)
+	}
+
+	// Sharp corners don't stick out further than the miter limit
+	sc.Clear()
+	sc.Polyline([]engo.Point{{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 0, Y: 1}}, false, 2, color.White)
+	for _, p := range sc.batches[0].points {
+		dx, dy := p.X-100, p.Y
+		if d := math.Sqrt(dx*dx + dy*dy); p.X > 50 && d > shapeMiterLimit+0.01 {
+			t.Errorf("sharp corner stuck out %v units, more than the miter limit", d)
+		}
+	}
+}
+
+func TestShapeComponentFill(t *testing.T) {
+	sc := &ShapeComponent{}
+	red := color.NRGBA{R: 255, A: 255}
+	sc.FillRect(engo.AABB{Max: engo.Point{X: 10, Y: 5}}, red)
+	sc.FillCircle(engo.Point{X: 20, Y: 20}, 5, red)
+	sc.StrokeCircle(engo.Point{X: 20, Y: 20}, 5, 1, color.White)
+	sc.FillRect(engo.AABB{Max: engo.Point{X: 1, Y: 1}}, red)
+
+	if len(sc.batches) != 3 {
+		t.Fatalf("shapes were not batched by consecutive colors, got %v batches", len(sc.batches))
+	}
+	if n := len(sc.batches[0].points); n != 6+shapeCircleSegments*3 {
+		t.Errorf("filled rectangle and circle were not drawn as triangles, got %v points", n)
+	}
+	if n := len(sc.batches[1].points); n != shapeCircleSegments*6 {
+		t.Errorf("outline of the circle was not drawn as %v lines, got %v points", shapeCircleSegments, n)
+	}
+	for _, p := range sc.batches[0].points[6:] {
+		dx, dy := p.X-20, p.Y-20
+		if d := math.Sqrt(dx*dx + dy*dy); d > 5.01 {
+			t.Errorf("point of the filled circle was outside of it, %v units from its center", d)
+		}
+	}
+
+	sc.Clear()
+	sc.FillRect(engo.AABB{Max: engo.Point{X: 1, Y: 1}}, nil)
+	if len(sc.batches) != 1 || len(sc.batches[0].points) != 6 || sc.batches[0].color != color.White {
+		t.Errorf("shapes were not cleared, or added in white without a color. Batches: %v", len(sc.batches))
+	}
+}
+
+func TestShapeSystem(t *testing.T) {
+	type shapeEntityWithSpace struct {
+		ecs.BasicEntity
+		ShapeComponent
+		SpaceComponent
+	}
+	type shapeEntityWithoutSpace struct {
+		ecs.BasicEntity
+		ShapeComponent
+	}
+
+	w := &ecs.World{}
+	s := &ShapeSystem{}
+	var shapeable *Shapeable
+	w.AddSystemInterface(s, shapeable, nil)
+
+	with := &shapeEntityWithSpace{BasicEntity: ecs.NewBasic()}
+	without := &shapeEntityWithoutSpace{BasicEntity: ecs.NewBasic()}
+	w.AddEntity(with)
+	w.AddEntity(without)
+	if len(s.entities) != 2 || s.entities[0].SpaceComponent != &with.SpaceComponent || s.entities[1].SpaceComponent != nil {
+		t.Fatal("entities were not added with their SpaceComponent")
+	}
+	w.RemoveEntity(with.BasicEntity)
+	if len(s.entities) != 1 {
+		t.Errorf("entity was not removed, %v are left", len(s.entities))
+	}
+
+	rs := &RenderSystem{world: w}
+	var names []string
+	for _, pass := range rs.passes() {
+		names = append(names, pass.Name)
+	}
+	want := []string{"world", "shapes", "hud", "hudshapes", "transition"}
+	if len(names) != len(want) {
+		t.Fatalf("shape passes were not drawn. Wanted: %v, got: %v", want, names)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("shape passes were not drawn in order. Wanted: %v, got: %v", want, names)
+			break
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package common
 
 import (
+	"image"
 	"log"
 
 	"github.com/EngoEngine/engo"
@@ -14,6 +15,8 @@ type Spritesheet struct {
 	width, height float32         // The dimensions of the total texture
 	cells         []SpriteRegion  // The dimensions of each sprite
 	cache         map[int]Texture // The cell cache cells
+	pixels        *image.NRGBA    // The image data, if it is readable
+	url           string          // The url of the original texture, if it was loaded from a file
 }
 
 // SpriteRegion holds the position data for each sprite on the sheet
@@ -31,6 +34,8 @@ func NewAsymmetricSpritesheetFromTexture(tr *TextureResource, spriteRegions []Sp
 		height:  tr.Height,
 		cells:   spriteRegions,
 		cache:   make(map[int]Texture),
+		pixels:  tr.pixels,
+		url:     tr.url,
 	}
 }
 
@@ -124,6 +129,15 @@ func (s *Spritesheet) Cell(index int) Texture {
 				Y: (cell.Position.Y + float32(cell.Height)) / s.height,
 			},
 		},
+		region: engo.AABB{
+			Min: cell.Position,
+			Max: engo.Point{
+				X: cell.Position.X + float32(cell.Width),
+				Y: cell.Position.Y + float32(cell.Height),
+			},
+		},
+		pixels: s.pixels,
+		url:    s.url,
 	}
 
 	return s.cache[index]
@@ -161,6 +175,16 @@ func (s *Spritesheet) Cells() []Texture {
 	return cells
 }
 
+// SourceWidth returns the width, in pixels, of the image the spritesheet was created from.
+func (s *Spritesheet) SourceWidth() float32 {
+	return s.width
+}
+
+// SourceHeight returns the height, in pixels, of the image the spritesheet was created from.
+func (s *Spritesheet) SourceHeight() float32 {
+	return s.height
+}
+
 // Width is the amount of tiles on the x-axis of the spritesheet
 // only if the sprite sheet is symmetric with no border.
 func (s Spritesheet) Width() float32 {
@@ -0,0 +1,244 @@
+package common
+
+import (
+	"log"
+
+	"github.com/EngoEngine/ecs"
+)
+
+// State is a state of a StateMachine, such as idle, walking or attacking. All of its functions are optional. They're
+// called with the ID of the entity and its StateComponent, so a single State is shared by all entities using it.
+type State struct {
+	// Name is the name of the state, which is used to transition to it
+	Name string
+	// Enter is called once the entity transitioned into the state
+	Enter func(id uint64, sc *StateComponent)
+	// Update is called during every update of the StateSystem while the entity is in the state, with the delta time
+	Update func(id uint64, sc *StateComponent, dt float32)
+	// Exit is called once the entity transitions out of the state, before the next state is entered
+	Exit func(id uint64, sc *StateComponent)
+	// To contains the names of the states the entity may transition to from this state. If it's nil, the entity may
+	// transition to any state, and if it's empty but not nil to none, such as for a state the entity never leaves.
+	To []string
+	// Animation is the name of the animation which is selected once the state is entered, for entities which were
+	// added to the StateSystem with an AnimationComponent. If it's empty the animation is left as is.
+	Animation string
+}
+
+// allows indicates whether or not the state allows transitioning to the state with the given name.
+func (s *State) allows(name string) bool {
+	if s.To == nil {
+		return true
+	}
+	for _, to := range s.To {
+		if to == name {
+			return true
+		}
+	}
+	return false
+}
+
+// StateMachine defines the states of entities, and the state they start in. It holds no state of its own, so the
+// same StateMachine is used by all entities with the same behavior, each with its own StateComponent.
+type StateMachine struct {
+	// Initial is the name of the state entities start in
+	Initial string
+	// States are all states of the machine
+	States []*State
+}
+
+// State returns the state with the given name, or nil if there is none.
+func (sm *StateMachine) State(name string) *State {
+	for _, s := range sm.States {
+		if s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// StateComponent keeps track of the state of an entity, which the StateSystem drives using the StateMachine of the
+// entity. The entity enters the Initial state of its machine during its first update.
+type StateComponent struct {
+	// Machine defines the states of the entity
+	Machine *StateMachine
+
+	current *State
+	next    *State
+	elapsed float32
+}
+
+// Current returns the name of the state the entity is in, or an empty string before it entered its initial state.
+func (sc *StateComponent) Current() string {
+	if sc.current == nil {
+		return ""
+	}
+	return sc.current.Name
+}
+
+// Pending returns the name of the state the entity is going to transition to, see Transition, or an empty string if
+// no transition was requested.
+func (sc *StateComponent) Pending() string {
+	if sc.next == nil {
+		return ""
+	}
+	return sc.next.Name
+}
+
+// Elapsed returns the time in seconds the entity has been in its current state, including the current update.
+func (sc *StateComponent) Elapsed() float32 {
+	return sc.elapsed
+}
+
+// Can indicates whether or not the entity may transition from its current state to the state with the given name,
+// see `State.To`. Before the entity entered its initial state, it may transition to any state.
+func (sc *StateComponent) Can(name string) bool {
+	if sc.Machine == nil || sc.Machine.State(name) == nil {
+		return false
+	}
+	return sc.current == nil || sc.current.allows(name)
+}
+
+// Transition requests the entity to transition to the state with the given name, and returns whether or not it may,
+// see Can. The transition is queued rather than made right away, so the state never changes while the functions of
+// a state are being called: it's made during the next update of the entity by the StateSystem, before the Update of
+// the state. Transitions requested by other systems updating before the StateSystem are therefore made during the
+// same frame, while those requested from within Enter, Update or Exit are made during the next frame. Of multiple
+// requests before the transition is made, the last one is used.
+func (sc *StateComponent) Transition(name string) bool {
+	if !sc.Can(name) {
+		log.Println("Unable to transition from state", sc.Current(), "to", name)
+		return false
+	}
+	sc.next = sc.Machine.State(name)
+	return true
+}
+
+type stateEntity struct {
+	*ecs.BasicEntity
+	*StateComponent
+	*AnimationComponent
+}
+
+// StateSystem drives the StateComponents of entities every update, making the transitions they requested and
+// calling the functions of their states. It doesn't depend on rendering; entities which were added with an
+// AnimationComponent select the Animation of every state they enter, which the AnimationSystem then plays.
+type StateSystem struct {
+	entities []stateEntity
+	ids      entityIDs
+}
+
+// Add adds an entity to the StateSystem. To be added, the entity has to have a basic and state component.
+func (s *StateSystem) Add(basic *ecs.BasicEntity, state *StateComponent) {
+	s.AddWithAnimation(basic, state, nil)
+}
+
+// AddWithAnimation adds an entity to the StateSystem, which selects the Animation of every state the entity enters.
+// Without an animation component, it's the same as Add.
+func (s *StateSystem) AddWithAnimation(basic *ecs.BasicEntity, state *StateComponent, animation *AnimationComponent) {
+	if !s.ids.add(basic.ID()) {
+		return
+	}
+	s.entities = append(s.entities, stateEntity{basic, state, animation})
+}
+
+// AddByInterface Provides a simple way to add an entity to the system that satisfies Stateful. Any entity containing, BasicEntity and StateComponent anonymously, automatically does this. Entities which also contain an AnimationComponent are added with it, see AddWithAnimation.
+func (s *StateSystem) AddByInterface(i ecs.Identifier) {
+	o, _ := i.(Stateful)
+	var animation *AnimationComponent
+	if a, ok := i.(AnimationFace); ok {
+		animation = a.GetAnimationComponent()
+	}
+	s.AddWithAnimation(o.GetBasicEntity(), o.GetStateComponent(), animation)
+}
+
+// Remove removes an entity from the StateSystem. The Exit of its current state is not called.
+func (s *StateSystem) Remove(basic ecs.BasicEntity) {
+	s.ids.remove(basic.ID())
+	delete := -1
+	for index, e := range s.entities {
+		if e.BasicEntity.ID() == basic.ID() {
+			delete = index
+			break
+		}
+	}
+	if delete >= 0 {
+		s.entities = append(s.entities[:delete], s.entities[delete+1:]...)
+	}
+}
+
+// Entities returns a copy of the IDs of all entities in the StateSystem.
+func (s *StateSystem) Entities() []uint64 {
+	ids := make([]uint64, len(s.entities))
+	for i, e := range s.entities {
+		ids[i] = e.ID()
+	}
+	return ids
+}
+
+// BasicEntities returns a copy of the BasicEntities of all entities in the StateSystem.
+func (s *StateSystem) BasicEntities() []ecs.BasicEntity {
+	basics := make([]ecs.BasicEntity, len(s.entities))
+	for i, e := range s.entities {
+		basics[i] = *e.BasicEntity
+	}
+	return basics
+}
+
+// Get returns the components with which the entity with the given ID was added to the StateSystem, and false if it
+// isn't in the system, such as once it was removed. The animation component is nil if it was added without one.
+func (s *StateSystem) Get(id uint64) (*StateComponent, *AnimationComponent, bool) {
+	for _, e := range s.entities {
+		if e.ID() == id {
+			return e.StateComponent, e.AnimationComponent, true
+		}
+	}
+	return nil, nil, false
+}
+
+// Update makes the transitions the entities requested, entering the initial state of those which weren't in any
+// state yet, and then calls the Update of the state of every entity.
+func (s *StateSystem) Update(dt float32) {
+	for _, e := range s.entities {
+		sc := e.StateComponent
+		if sc.Machine == nil {
+			continue
+		}
+		if sc.current == nil && sc.next == nil {
+			sc.next = sc.Machine.State(sc.Machine.Initial)
+		}
+		if sc.next != nil {
+			s.enter(e, sc.next)
+		}
+
+		if sc.current == nil {
+			continue // the initial state doesn't exist
+		}
+		sc.elapsed += dt
+		if sc.current.Update != nil {
+			sc.current.Update(e.ID(), sc, dt)
+		}
+	}
+}
+
+// enter transitions the entity out of its current state, if any, into the given state.
+func (s *StateSystem) enter(e stateEntity, state *State) {
+	sc := e.StateComponent
+	sc.next = nil
+	if sc.current != nil && sc.current.Exit != nil {
+		sc.current.Exit(e.ID(), sc)
+	}
+	sc.current = state
+	sc.elapsed = 0
+
+	if e.AnimationComponent != nil && state.Animation != "" {
+		if animation, ok := e.AnimationComponent.Animations[state.Animation]; ok {
+			e.AnimationComponent.SelectAnimationByAction(animation)
+		} else {
+			log.Println("Unable to select animation", state.Animation, "of state", state.Name, "since it was not added")
+		}
+	}
+	if state.Enter != nil {
+		state.Enter(e.ID(), sc)
+	}
+}
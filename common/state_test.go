@@ -0,0 +1,74 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+)
+
+type stateEntityTest struct {
+	ecs.BasicEntity
+	StateComponent
+	AnimationComponent
+}
+
+func TestStateSystem(t *testing.T) {
+	var calls []string
+	record := func(name string) (func(uint64, *StateComponent), func(uint64, *StateComponent)) {
+		return func(uint64, *StateComponent) { calls = append(calls, "enter "+name) },
+			func(uint64, *StateComponent) { calls = append(calls, "exit "+name) }
+	}
+	idle := &State{Name: "idle", To: []string{"walk"}, Animation: "idle"}
+	idle.Enter, idle.Exit = record("idle")
+	walk := &State{Name: "walk", Animation: "walk"}
+	walk.Enter, walk.Exit = record("walk")
+	walk.Update = func(_ uint64, sc *StateComponent, dt float32) {
+		calls = append(calls, "update walk")
+		if sc.Elapsed() >= 1 {
+			sc.Transition("attack")
+		}
+	}
+	attack := &State{Name: "attack", To: []string{}}
+	attack.Enter, attack.Exit = record("attack")
+	machine := &StateMachine{Initial: "idle", States: []*State{idle, walk, attack}}
+
+	e := &stateEntityTest{BasicEntity: ecs.NewBasic()}
+	e.StateComponent = StateComponent{Machine: machine}
+	e.AnimationComponent = NewAnimationComponent(nil, 0.1)
+	e.AnimationComponent.AddAnimations([]*Animation{{Name: "idle"}, {Name: "walk"}})
+	s := &StateSystem{}
+	s.AddByInterface(e)
+
+	s.Update(0.5)
+	if e.Current() != "idle" || e.CurrentAnimation.Name != "idle" {
+		t.Fatalf("entity did not enter its initial state, got: %q", e.Current())
+	}
+	if e.Transition("attack") {
+		t.Error("entity was allowed to transition to a state which its state doesn't allow")
+	}
+	if !e.Transition("walk") || e.Current() != "idle" || e.Pending() != "walk" {
+		t.Errorf("transition was not queued. Current: %q, pending: %q", e.Current(), e.Pending())
+	}
+
+	s.Update(0.5)
+	if e.Current() != "walk" || e.CurrentAnimation.Name != "walk" {
+		t.Errorf("queued transition was not made, got: %q", e.Current())
+	}
+	s.Update(0.5)
+	if e.Current() != "walk" || e.Pending() != "attack" {
+		t.Errorf("transition requested during an update was not queued until the next one. Current: %q, pending: %q", e.Current(), e.Pending())
+	}
+	s.Update(0.5)
+	if e.Current() != "attack" || e.CurrentAnimation.Name != "walk" {
+		t.Errorf("entity did not transition to a state without an animation, got: %q", e.Current())
+	}
+	if e.Can("idle") || e.Can("walk") {
+		t.Error("entity was allowed to leave a state without transitions")
+	}
+
+	expected := []string{"enter idle", "exit idle", "enter walk", "update walk", "update walk", "exit walk", "enter attack"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("wrong calls. Wanted: %v, got: %v", expected, calls)
+	}
+}
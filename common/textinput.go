@@ -0,0 +1,632 @@
+package common
+
+import (
+	"image/color"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+const (
+	// DefaultCaretBlink is the CaretBlink of a TextInputSystem which has none set, in seconds.
+	DefaultCaretBlink = 0.5
+	// DefaultCaretWidth is the CaretWidth of a TextInputSystem which has none set.
+	DefaultCaretWidth = 2
+	// textInputRepeatDelay is the amount of seconds an editing key is held down before it starts repeating, and
+	// textInputRepeatRate the amount of seconds in between its repeats
+	textInputRepeatDelay = 0.5
+	textInputRepeatRate  = 0.05
+)
+
+// defaultTextSelectionColor is the color of the selected text of a TextInputSystem which has no SelectionColor set.
+var defaultTextSelectionColor = color.NRGBA{R: 0, G: 120, B: 215, A: 96}
+
+// TextInputComponent is a field which text is typed into using the TextInputSystem, such as for the name of the
+// player or a chat message. Its Text is drawn by the Text drawable of the RenderComponent of the entity.
+type TextInputComponent struct {
+	// Text is the text in the field. It can be changed at any time, the caret and the selection are kept within it.
+	Text string
+	// MaxLength is the maximum amount of characters of the Text, which is unlimited when zero. Characters typed
+	// beyond it are dropped.
+	MaxLength int
+	// Disabled keeps the field from being focused. A focused field loses focus once it's disabled.
+	Disabled bool
+	// OnChange is called whenever the Text was changed by typing into the field
+	OnChange func(text string)
+	// OnSubmit is called whenever enter is pressed while the field is focused
+	OnSubmit func(text string)
+
+	// caret is the position of the caret, and anchor the other end of the selection, which is the caret itself when
+	// nothing is selected. Both count characters rather than bytes.
+	caret, anchor int
+	focused       bool
+}
+
+// Focused indicates whether or not the field is focused, and receives everything that's typed.
+func (t *TextInputComponent) Focused() bool {
+	return t.focused
+}
+
+// Caret returns the position of the caret, which is the amount of characters in front of it.
+func (t *TextInputComponent) Caret() int {
+	t.clamp()
+	return t.caret
+}
+
+// SetCaret moves the caret to the given position, deselecting the selection.
+func (t *TextInputComponent) SetCaret(position int) {
+	t.Select(position, position)
+}
+
+// Selection returns the positions of the start and the end of the selection, which are the same when nothing is
+// selected.
+func (t *TextInputComponent) Selection() (start, end int) {
+	t.clamp()
+	if t.anchor < t.caret {
+		return t.anchor, t.caret
+	}
+	return t.caret, t.anchor
+}
+
+// Select selects the characters from start up to end, and moves the caret to end.
+func (t *TextInputComponent) Select(start, end int) {
+	t.anchor, t.caret = start, end
+	t.clamp()
+}
+
+// SelectedText returns the selected part of the Text.
+func (t *TextInputComponent) SelectedText() string {
+	start, end := t.Selection()
+	return string([]rune(t.Text)[start:end])
+}
+
+// clamp keeps the caret and the anchor within the Text, which may have been changed since they were placed.
+func (t *TextInputComponent) clamp() {
+	n := utf8.RuneCountInString(t.Text)
+	t.caret, t.anchor = clampIndex(t.caret, n), clampIndex(t.anchor, n)
+}
+
+// clampIndex returns the index clamped to [0, n].
+func clampIndex(i, n int) int {
+	if i < 0 {
+		return 0
+	}
+	if i > n {
+		return n
+	}
+	return i
+}
+
+// move moves the caret to the given position, and extends the selection up to it if extend is set.
+func (t *TextInputComponent) move(position int, extend bool) {
+	t.caret = position
+	if !extend {
+		t.anchor = position
+	}
+	t.clamp()
+}
+
+// insert replaces the selection by the given characters, skipping control characters and those beyond the
+// MaxLength. It returns whether or not the Text changed.
+func (t *TextInputComponent) insert(chars []rune) bool {
+	start, end := t.Selection()
+	room := -1
+	if t.MaxLength > 0 {
+		room = t.MaxLength - (utf8.RuneCountInString(t.Text) - (end - start))
+	}
+
+	inserted := make([]rune, 0, len(chars))
+	for _, char := range chars {
+		if unicode.IsControl(char) || (room >= 0 && len(inserted) >= room) {
+			continue
+		}
+		inserted = append(inserted, char)
+	}
+	if len(inserted) == 0 {
+		return false
+	}
+	t.replace(start, end, inserted)
+	return true
+}
+
+// erase deletes the selection or, when nothing is selected, the character in front of the caret for a direction of
+// -1 (backspace), or behind it for a direction of 1 (delete). It returns whether or not the Text changed.
+func (t *TextInputComponent) erase(direction int) bool {
+	start, end := t.Selection()
+	if start == end {
+		if direction < 0 {
+			start--
+		} else {
+			end++
+		}
+		if start < 0 || end > utf8.RuneCountInString(t.Text) {
+			return false
+		}
+	}
+	t.replace(start, end, nil)
+	return true
+}
+
+// replace replaces the characters from start up to end by the given ones, and places the caret behind them.
+func (t *TextInputComponent) replace(start, end int, chars []rune) {
+	text := []rune(t.Text)
+	replaced := make([]rune, 0, len(text)-(end-start)+len(chars))
+	replaced = append(replaced, text[:start]...)
+	replaced = append(replaced, chars...)
+	replaced = append(replaced, text[end:]...)
+	t.Text = string(replaced)
+	t.caret = start + len(chars)
+	t.anchor = t.caret
+}
+
+type textInputEntity struct {
+	*ecs.BasicEntity
+	*MouseComponent
+	*SpaceComponent
+	*RenderComponent
+	*TextInputComponent
+}
+
+// TextInputSystem lets text be typed into the TextInputComponents of entities. Clicking a field focuses it and places
+// the caret under the cursor, and double-clicking it selects its Text; clicking anywhere else, or pressing escape,
+// removes the focus. Fields are clicked as determined by the MouseSystem, so the world needs one, and every entity
+// has to be in the MouseSystem as well. Only the focused field receives what is typed.
+//
+// Characters are entered as they're reported by the TextMessages of the window, so shift, caps lock, dead keys and
+// the keyboard layout are taken into account, and any unicode character can be typed. While a field is focused:
+//   - backspace and delete erase the selection, or the character in front of or behind the caret
+//   - the left and right arrow keys move the caret, and home and end move it to the start or end of the Text; holding
+//     shift selects the characters the caret moves across
+//   - control+A selects the whole Text
+//   - control+C and control+X copy or cut the selection to the clipboard, and control+V pastes the text on the
+//     clipboard, see `engo.ClipboardText`
+//   - enter calls the OnSubmit of the field
+//
+// Backspace, delete and the arrow keys repeat while they're held down.
+//
+// The Text of the field is drawn on a single line by the Text drawable of its RenderComponent, which has to be a Text
+// before the entity is added to the RenderSystem, so it's drawn using the TextShader; its Font and LetterSpacing are
+// kept, and used to place the caret. The caret and the selection of the focused field are drawn on top of it by the
+// RenderSystem, so the world needs one as well.
+type TextInputSystem struct {
+	// CaretColor is the color of the caret. It's the FG of the Font of the focused field when nil, or black if that
+	// has none.
+	CaretColor color.Color
+	// CaretWidth is the width of the caret. It's DefaultCaretWidth when zero.
+	CaretWidth float32
+	// CaretBlink is the amount of seconds the caret is shown, and then hidden, while it blinks. It's
+	// DefaultCaretBlink when zero; a negative CaretBlink doesn't blink. Typing or moving the caret shows it again.
+	CaretBlink float32
+	// SelectionColor is the color of the selection, which is drawn on top of the Text. It's a translucent blue when
+	// nil.
+	SelectionColor color.Color
+
+	entities []textInputEntity
+	ids      entityIDs
+	world    *ecs.World
+
+	// focused is the index of the focused entity, or -1 if there is none
+	focused int
+	// typed are the characters typed since the previous update
+	typed []rune
+	// blink is the amount of seconds since the caret last moved
+	blink float32
+	// repeatKey is the editing key which was pressed last, and repeatTime the amount of seconds it's held down
+	repeatKey  engo.Key
+	repeatTime float32
+
+	caret, selection struct {
+		ecs.BasicEntity
+		RenderComponent
+		SpaceComponent
+	}
+	// render is the RenderSystem drawing the caret while a field is focused, and nil otherwise
+	render *RenderSystem
+}
+
+// New is called when the TextInputSystem is added to the world.
+func (t *TextInputSystem) New(w *ecs.World) {
+	t.world = w
+	t.focused = -1
+	t.caret.BasicEntity = ecs.NewBasic()
+	t.selection.BasicEntity = ecs.NewBasic()
+
+	engo.Mailbox.Listen("TextMessage", func(msg engo.Message) {
+		m, ok := msg.(engo.TextMessage)
+		if !ok || t.focused < 0 {
+			return
+		}
+		t.typed = append(t.typed, m.Char)
+	})
+}
+
+// Add adds an entity to the TextInputSystem. The entity needs a basic, mouse, space, render and text input component
+// to be added to the system. The MouseComponent has to be in the MouseSystem as well.
+func (t *TextInputSystem) Add(basic *ecs.BasicEntity, mouse *MouseComponent, space *SpaceComponent, render *RenderComponent, input *TextInputComponent) {
+	if !t.ids.add(basic.ID()) {
+		return
+	}
+	t.entities = append(t.entities, textInputEntity{basic, mouse, space, render, input})
+}
+
+// AddByInterface Provides a simple way to add an entity to the system that satisfies TextInputable. Any entity
+// containing, BasicEntity, MouseComponent, SpaceComponent, RenderComponent and TextInputComponent anonymously,
+// automatically does this.
+func (t *TextInputSystem) AddByInterface(i ecs.Identifier) {
+	o, _ := i.(TextInputable)
+	t.Add(o.GetBasicEntity(), o.GetMouseComponent(), o.GetSpaceComponent(), o.GetRenderComponent(), o.GetTextInputComponent())
+}
+
+// Remove removes an entity from the TextInputSystem, removing its focus if it's focused.
+func (t *TextInputSystem) Remove(basic ecs.BasicEntity) {
+	t.ids.remove(basic.ID())
+	for i, e := range t.entities {
+		if e.ID() != basic.ID() {
+			continue
+		}
+		if t.focused == i {
+			t.Blur()
+		} else if t.focused > i {
+			t.focused--
+		}
+		t.entities = append(t.entities[:i], t.entities[i+1:]...)
+		return
+	}
+}
+
+// Focus focuses the field of the given entity, with the caret behind its Text, and removes the focus from any other
+// field. Disabled fields aren't focused.
+func (t *TextInputSystem) Focus(basic ecs.BasicEntity) {
+	for i, e := range t.entities {
+		if e.ID() != basic.ID() {
+			continue
+		}
+		if !e.Disabled {
+			t.focus(i)
+			e.SetCaret(utf8.RuneCountInString(e.Text))
+		}
+		return
+	}
+}
+
+// Blur removes the focus from the focused field, if any.
+func (t *TextInputSystem) Blur() {
+	t.focus(-1)
+}
+
+// Focused returns the ID of the entity whose field is focused, and false while none is.
+func (t *TextInputSystem) Focused() (uint64, bool) {
+	if t.focused < 0 {
+		return 0, false
+	}
+	return t.entities[t.focused].ID(), true
+}
+
+// focus focuses the entity with the given index, or none for -1.
+func (t *TextInputSystem) focus(i int) {
+	if i == t.focused {
+		return
+	}
+	if t.focused >= 0 {
+		t.entities[t.focused].focused = false
+	}
+	// The caret is added again for the new field, which may be drawn on the HUD rather than in the world
+	t.hide()
+	t.focused = i
+	t.typed = t.typed[:0]
+	t.blink = 0
+	if i >= 0 {
+		t.entities[i].focused = true
+	}
+}
+
+// Update focuses the clicked field, enters what was typed into the focused one, and draws the Text of every field.
+func (t *TextInputSystem) Update(dt float32) {
+	t.click()
+	if t.focused >= 0 {
+		if e := t.entities[t.focused]; e.Disabled {
+			t.Blur()
+		} else {
+			t.edit(e, dt)
+		}
+	}
+	t.typed = t.typed[:0]
+
+	for _, e := range t.entities {
+		drawTextInput(e)
+	}
+	t.place(dt)
+}
+
+// click focuses the field which was clicked, if any, and removes the focus when anything else was clicked.
+func (t *TextInputSystem) click() {
+	clicked := -1
+	for i, e := range t.entities {
+		if e.MouseComponent.Clicked && !e.Disabled {
+			clicked = i
+		}
+	}
+	if clicked < 0 {
+		if engo.Input.Mouse.ButtonJustPressed(engo.MouseButtonLeft) {
+			t.Blur()
+		}
+		return
+	}
+
+	e := t.entities[clicked]
+	// Shift-clicking the focused field selects up to the cursor
+	extend := clicked == t.focused && shiftHeld()
+	t.focus(clicked)
+	t.blink = 0
+	if e.DoubleClicked {
+		e.Select(0, utf8.RuneCountInString(e.Text))
+		return
+	}
+	e.move(caretAt(e, e.MouseComponent.MouseX), extend)
+}
+
+// edit enters the typed characters and the editing keys into the focused field.
+func (t *TextInputSystem) edit(e textInputEntity, dt float32) {
+	text, caret, anchor := e.Text, e.caret, e.anchor
+	shift := shiftHeld()
+
+	e.insert(t.typed)
+	for i := t.repeats(engo.KeyBackspace, dt); i > 0; i-- {
+		e.erase(-1)
+	}
+	for i := t.repeats(engo.KeyDelete, dt); i > 0; i-- {
+		e.erase(1)
+	}
+	for i := t.repeats(engo.KeyArrowLeft, dt); i > 0; i-- {
+		// Without shift, the caret moves to the start of the selection first
+		if start, end := e.Selection(); start != end && !shift {
+			e.move(start, false)
+		} else {
+			e.move(e.caret-1, shift)
+		}
+	}
+	for i := t.repeats(engo.KeyArrowRight, dt); i > 0; i-- {
+		if start, end := e.Selection(); start != end && !shift {
+			e.move(end, false)
+		} else {
+			e.move(e.caret+1, shift)
+		}
+	}
+	if engo.Input.Key(engo.KeyHome).JustPressed() {
+		e.move(0, shift)
+	}
+	if engo.Input.Key(engo.KeyEnd).JustPressed() {
+		e.move(utf8.RuneCountInString(e.Text), shift)
+	}
+	if controlHeld() {
+		t.shortcuts(e)
+	}
+
+	if e.caret != caret || e.anchor != anchor || e.Text != text {
+		t.blink = 0
+	}
+	if e.Text != text && e.OnChange != nil {
+		e.OnChange(e.Text)
+	}
+	if engo.Input.Key(engo.KeyEnter).JustPressed() && e.OnSubmit != nil {
+		e.OnSubmit(e.Text)
+	}
+	if engo.Input.Key(engo.KeyEscape).JustPressed() {
+		t.Blur()
+	}
+}
+
+// shortcuts handles the shortcuts pressed along with control, for selecting everything and using the clipboard.
+func (t *TextInputSystem) shortcuts(e textInputEntity) {
+	switch {
+	case engo.Input.Key(engo.KeyA).JustPressed():
+		e.Select(0, utf8.RuneCountInString(e.Text))
+	case engo.Input.Key(engo.KeyC).JustPressed(), engo.Input.Key(engo.KeyX).JustPressed():
+		start, end := e.Selection()
+		if start == end {
+			return
+		}
+		engo.SetClipboardText(e.SelectedText())
+		if engo.Input.Key(engo.KeyX).JustPressed() {
+			e.erase(0)
+		}
+	case engo.Input.Key(engo.KeyV).JustPressed():
+		// Pasted text is inserted like typed text, so newlines are dropped and so is text beyond the MaxLength
+		e.insert([]rune(engo.ClipboardText()))
+	}
+}
+
+// repeats returns how many times the given key was pressed during this update, counting the repeats while it's
+// held down.
+func (t *TextInputSystem) repeats(k engo.Key, dt float32) int {
+	state := engo.Input.Key(k)
+	if state.JustPressed() {
+		t.repeatKey, t.repeatTime = k, 0
+		return 1
+	}
+	if k != t.repeatKey || !state.Down() {
+		return 0
+	}
+	before := repeatCount(t.repeatTime)
+	t.repeatTime += dt
+	return repeatCount(t.repeatTime) - before
+}
+
+// repeatCount returns how many times a key repeated once it's held down for the given amount of seconds.
+func repeatCount(held float32) int {
+	if held < textInputRepeatDelay {
+		return 0
+	}
+	return int((held-textInputRepeatDelay)/textInputRepeatRate) + 1
+}
+
+// keyHeld indicates whether or not any of the given keys is held down.
+func keyHeld(keys ...engo.Key) bool {
+	for _, k := range keys {
+		if state := engo.Input.Key(k); state.Down() || state.JustPressed() {
+			return true
+		}
+	}
+	return false
+}
+
+// shiftHeld indicates whether or not either shift key is held down.
+func shiftHeld() bool {
+	return keyHeld(engo.KeyLeftShift, engo.KeyRightShift)
+}
+
+// controlHeld indicates whether or not either control key is held down, or either command key on macOS.
+func controlHeld() bool {
+	return keyHeld(engo.KeyLeftControl, engo.KeyRightControl, engo.KeyLeftSuper, engo.KeyRightSuper)
+}
+
+// drawTextInput sets the Text drawn by the RenderComponent to that of the field, keeping the other settings of the
+// Text.
+func drawTextInput(e textInputEntity) {
+	text, ok := e.RenderComponent.Drawable.(Text)
+	if ok && text.Text == e.TextInputComponent.Text && len(text.Runs) == 0 {
+		return
+	}
+	text.Text, text.Runs = e.TextInputComponent.Text, nil
+	e.RenderComponent.Drawable = text
+}
+
+// drawnText returns a Text drawn the same way as that of the field, containing the given text.
+func drawnText(e textInputEntity, s string) Text {
+	text, _ := e.RenderComponent.Drawable.(Text)
+	return Text{Font: text.Font, LetterSpacing: text.LetterSpacing, Text: s}
+}
+
+// textWidth returns the width of the given part of the Text of the field, in the coordinates of its SpaceComponent.
+func textWidth(e textInputEntity, s string) float32 {
+	if s == "" || drawnText(e, s).font() == nil {
+		return 0
+	}
+	return drawnText(e, s).Width() * renderScale(e.RenderComponent).X
+}
+
+// renderScale returns the scale at which the RenderComponent is drawn, which is 1 until it's added to the
+// RenderSystem.
+func renderScale(r *RenderComponent) engo.Point {
+	scale := r.Scale
+	if scale.X == 0 {
+		scale.X = 1
+	}
+	if scale.Y == 0 {
+		scale.Y = 1
+	}
+	return scale
+}
+
+// caretAt returns the position of the caret closest to the given x coordinate.
+func caretAt(e textInputEntity, x float32) int {
+	x -= e.SpaceComponent.Position.X
+	runes := []rune(e.Text)
+	closest, distance := 0, x
+	if distance < 0 {
+		distance = -distance
+	}
+	for i := 1; i <= len(runes); i++ {
+		d := textWidth(e, string(runes[:i])) - x
+		if d < 0 {
+			d = -d
+		}
+		if d < distance {
+			closest, distance = i, d
+		}
+	}
+	return closest
+}
+
+// place places the caret and the selection of the focused field, and blinks the caret.
+func (t *TextInputSystem) place(dt float32) {
+	if t.focused < 0 {
+		t.hide()
+		return
+	}
+	e := t.entities[t.focused]
+	if t.render == nil && !t.show(e) {
+		return
+	}
+
+	runes := []rune(e.Text)
+	start, end := e.Selection()
+	x := e.SpaceComponent.Position.X
+	startX, endX, caretX := x+textWidth(e, string(runes[:start])), x+textWidth(e, string(runes[:end])), x+textWidth(e, string(runes[:e.caret]))
+	var height float32
+	if drawnText(e, "").font() != nil {
+		height = drawnText(e, "X").Height() * renderScale(e.RenderComponent).Y
+	}
+
+	width := t.CaretWidth
+	if width <= 0 {
+		width = DefaultCaretWidth
+	}
+	t.caret.SpaceComponent = SpaceComponent{
+		Position: engo.Point{X: caretX - width/2, Y: e.SpaceComponent.Position.Y},
+		Width:    width,
+		Height:   height,
+	}
+	t.selection.SpaceComponent = SpaceComponent{
+		Position: engo.Point{X: startX, Y: e.SpaceComponent.Position.Y},
+		Width:    endX - startX,
+		Height:   height,
+	}
+	t.selection.RenderComponent.Hidden = start == end
+
+	blink := t.CaretBlink
+	if blink == 0 {
+		blink = DefaultCaretBlink
+	}
+	t.caret.RenderComponent.Hidden = blink > 0 && int(t.blink/blink)%2 == 1
+	t.blink += dt
+}
+
+// show starts drawing the caret and the selection of the field, and returns whether or not there is a RenderSystem
+// to draw them.
+func (t *TextInputSystem) show(e textInputEntity) bool {
+	if t.world == nil {
+		return false
+	}
+	for _, system := range t.world.Systems() {
+		if rs, ok := system.(*RenderSystem); ok {
+			t.render = rs
+			break
+		}
+	}
+	if t.render == nil {
+		return false
+	}
+
+	caretColor := t.CaretColor
+	if fnt := drawnText(e, "").font(); caretColor == nil && fnt != nil {
+		caretColor = fnt.FG
+	}
+	if caretColor == nil {
+		caretColor = color.Black
+	}
+	selectionColor := t.SelectionColor
+	if selectionColor == nil {
+		selectionColor = defaultTextSelectionColor
+	}
+
+	// Both are drawn on top of the Text, in the world or on the HUD along with it
+	z := e.RenderComponent.ZIndex() + 1
+	t.selection.RenderComponent = RenderComponent{Drawable: Rectangle{}, Color: selectionColor, ScreenSpace: e.RenderComponent.ScreenSpace, StartZIndex: z}
+	t.caret.RenderComponent = RenderComponent{Drawable: Rectangle{}, Color: caretColor, ScreenSpace: e.RenderComponent.ScreenSpace, StartZIndex: z}
+	t.render.Add(&t.selection.BasicEntity, &t.selection.RenderComponent, &t.selection.SpaceComponent)
+	t.render.Add(&t.caret.BasicEntity, &t.caret.RenderComponent, &t.caret.SpaceComponent)
+	return true
+}
+
+// hide stops drawing the caret and the selection, if they're shown.
+func (t *TextInputSystem) hide() {
+	if t.render == nil {
+		return
+	}
+	t.render.Remove(t.caret.BasicEntity)
+	t.render.Remove(t.selection.BasicEntity)
+	t.render = nil
+}
@@ -0,0 +1,281 @@
+package common
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestTextInputComponentEditing(t *testing.T) {
+	input := &TextInputComponent{Text: "héllo", MaxLength: 8}
+
+	input.SetCaret(2)
+	if !input.insert([]rune("ab\ncd")) || input.Text != "héabcllo" || input.Caret() != 5 {
+		t.Errorf("control characters, or those beyond the MaxLength, were inserted. Got: %q with the caret at %v", input.Text, input.Caret())
+	}
+	if input.insert([]rune("x")) {
+		t.Error("character was inserted into a full field")
+	}
+
+	input.Select(4, 1)
+	if s := input.SelectedText(); s != "éab" {
+		t.Errorf("wrong selection. Wanted: %q, got: %q", "éab", s)
+	}
+	if !input.insert([]rune("e")) || input.Text != "hecllo" {
+		t.Errorf("selection was not replaced by the inserted characters, got: %q", input.Text)
+	}
+
+	input.SetCaret(0)
+	if input.erase(-1) {
+		t.Error("character in front of the start was erased")
+	}
+	if !input.erase(1) || input.Text != "ecllo" {
+		t.Errorf("character behind the caret was not deleted, got: %q", input.Text)
+	}
+	input.Select(1, 3)
+	if !input.erase(-1) || input.Text != "elo" || input.Caret() != 1 {
+		t.Errorf("selection was not erased, got: %q with the caret at %v", input.Text, input.Caret())
+	}
+
+	// The caret is kept within a Text which was changed
+	input.SetCaret(3)
+	input.Text = "a"
+	if start, end := input.Selection(); input.Caret() != 1 || start != 1 || end != 1 {
+		t.Errorf("caret was not kept within the changed Text, got: %v", input.Caret())
+	}
+}
+
+func TestRepeatCount(t *testing.T) {
+	tests := []struct {
+		held     float32
+		expected int
+	}{
+		{0, 0},
+		{textInputRepeatDelay - 0.01, 0},
+		{textInputRepeatDelay, 1},
+		{textInputRepeatDelay + textInputRepeatRate*2.5, 3},
+	}
+	for _, test := range tests {
+		if n := repeatCount(test.held); n != test.expected {
+			t.Errorf("wrong amount of repeats after holding a key for %v seconds. Wanted: %v, got: %v", test.held, test.expected, n)
+		}
+	}
+}
+
+func TestTextInputSystem(t *testing.T) {
+	engo.Run(engo.RunOptions{NoRun: true, HeadlessMode: true}, &testScene{})
+	fnt, err := FontFromBytes(goregular.TTF, 20)
+	if err != nil {
+		t.Fatalf("unable to load font, error: %v", err)
+	}
+
+	type textInputTestEntity struct {
+		ecs.BasicEntity
+		MouseComponent
+		SpaceComponent
+		RenderComponent
+		TextInputComponent
+	}
+
+	w := &ecs.World{}
+	rs := &RenderSystem{}
+	w.AddSystem(rs)
+	ts := &TextInputSystem{}
+	var textInputable *TextInputable
+	w.AddSystemInterface(ts, textInputable, nil)
+
+	var submitted, changed string
+	name := &textInputTestEntity{BasicEntity: ecs.NewBasic()}
+	name.SpaceComponent = SpaceComponent{Position: engo.Point{X: 10, Y: 20}, Width: 200, Height: 30}
+	name.RenderComponent = RenderComponent{Drawable: Text{Font: fnt}}
+	name.TextInputComponent = TextInputComponent{
+		OnChange: func(text string) { changed = text },
+		OnSubmit: func(text string) { submitted = text },
+	}
+	chat := &textInputTestEntity{BasicEntity: ecs.NewBasic()}
+	chat.RenderComponent = RenderComponent{Drawable: Text{Font: fnt}}
+	w.AddEntity(name)
+	w.AddEntity(chat)
+	rs.Add(&name.BasicEntity, &name.RenderComponent, &name.SpaceComponent)
+
+	// Nothing is typed while no field is focused
+	engo.Mailbox.Dispatch(engo.TextMessage{Char: 'x'})
+	ts.Update(0.1)
+	if name.TextInputComponent.Text != "" || chat.TextInputComponent.Text != "" {
+		t.Fatal("characters were typed into a field without focus")
+	}
+
+	name.MouseComponent.Clicked = true
+	ts.Update(0.1)
+	name.MouseComponent.Clicked = false
+	if id, ok := ts.Focused(); !ok || id != name.ID() || !name.Focused() {
+		t.Fatal("clicked field was not focused")
+	}
+	if _, _, ok := rs.Get(ts.caret.ID()); !ok {
+		t.Fatal("caret was not drawn once the field was focused")
+	}
+
+	for _, char := range "Hi" {
+		engo.Mailbox.Dispatch(engo.TextMessage{Char: char})
+	}
+	ts.Update(0.1)
+	if name.TextInputComponent.Text != "Hi" || changed != "Hi" || chat.TextInputComponent.Text != "" {
+		t.Fatalf("characters were not typed into the focused field only, got: %q", name.TextInputComponent.Text)
+	}
+	if txt := name.Drawable.(Text); txt.Text != "Hi" || txt.Font != fnt {
+		t.Errorf("typed text was not drawn using the Font of the Text, got: %q", txt.Text)
+	}
+	width := Text{Font: fnt, Text: "Hi"}.Width()
+	if x := ts.caret.Position.X + DefaultCaretWidth/2; x != 10+width || ts.caret.Position.Y != 20 {
+		t.Errorf("caret was not placed behind the text. Wanted x: %v, got: %v", 10+width, x)
+	}
+
+	// Holding backspace erases a character, and repeats after a delay
+	engo.Input.SetKey(engo.KeyBackspace, true)
+	ts.Update(0.1)
+	engo.Step(0)
+	if name.TextInputComponent.Text != "H" {
+		t.Errorf("backspace did not erase a character, got: %q", name.TextInputComponent.Text)
+	}
+	ts.Update(textInputRepeatDelay)
+	if name.TextInputComponent.Text != "" {
+		t.Errorf("backspace did not repeat, got: %q", name.TextInputComponent.Text)
+	}
+	engo.Input.SetKey(engo.KeyBackspace, false)
+	engo.Step(0)
+
+	// Clicking places the caret under the cursor, and shift-clicking selects up to it
+	name.TextInputComponent.Text = "Hello"
+	name.MouseComponent.Clicked, name.MouseComponent.MouseX = true, 10+Text{Font: fnt, Text: "H"}.Width()+1
+	ts.Update(0.1)
+	if c := name.Caret(); c != 1 {
+		t.Errorf("caret was not placed under the cursor, got: %v", c)
+	}
+	engo.Input.SetKey(engo.KeyLeftShift, true)
+	name.MouseComponent.MouseX = 1000
+	ts.Update(0.1)
+	engo.Input.SetKey(engo.KeyLeftShift, false)
+	name.MouseComponent.Clicked = false
+	engo.Step(0)
+	if s := name.SelectedText(); s != "ello" {
+		t.Errorf("shift-clicking did not select up to the cursor, got: %q", s)
+	}
+	if ts.selection.Hidden || ts.selection.Width <= 0 {
+		t.Error("selection was not drawn")
+	}
+
+	engo.Input.SetKey(engo.KeyEnter, true)
+	ts.Update(0.1)
+	engo.Input.SetKey(engo.KeyEnter, false)
+	engo.Step(0)
+	if submitted != "Hello" {
+		t.Errorf("enter did not submit the text, got: %q", submitted)
+	}
+
+	// The caret blinks
+	ts.blink = 0
+	ts.Update(DefaultCaretBlink)
+	ts.Update(0)
+	if !ts.caret.Hidden {
+		t.Error("caret did not blink")
+	}
+
+	// Clicking anywhere else removes the focus, and the caret
+	engo.Input.SetMouseButton(engo.MouseButtonLeft, true)
+	ts.Update(0.1)
+	engo.Input.SetMouseButton(engo.MouseButtonLeft, false)
+	engo.Step(0)
+	if _, ok := ts.Focused(); ok || name.Focused() {
+		t.Error("focus was not removed by clicking elsewhere")
+	}
+	if _, _, ok := rs.Get(ts.caret.ID()); ok {
+		t.Error("caret was still drawn without a focused field")
+	}
+
+	ts.Focus(chat.BasicEntity)
+	w.RemoveEntity(chat.BasicEntity)
+	if _, ok := ts.Focused(); ok || len(ts.entities) != 1 {
+		t.Error("removed field kept the focus")
+	}
+	name.Disabled = true
+	ts.Focus(name.BasicEntity)
+	if _, ok := ts.Focused(); ok {
+		t.Error("disabled field was focused")
+	}
+}
+
+func TestTextInputSystemCaretColor(t *testing.T) {
+	engo.Run(engo.RunOptions{NoRun: true, HeadlessMode: true}, &testScene{})
+	fnt, err := FontFromBytes(goregular.TTF, 20)
+	if err != nil {
+		t.Fatalf("unable to load font, error: %v", err)
+	}
+	fnt.FG = color.White
+
+	w := &ecs.World{}
+	w.AddSystem(&RenderSystem{})
+	ts := &TextInputSystem{}
+	w.AddSystem(ts)
+	basic := ecs.NewBasic()
+	render := &RenderComponent{Drawable: Text{Font: fnt}, ScreenSpace: true}
+	ts.Add(&basic, &MouseComponent{}, &SpaceComponent{}, render, &TextInputComponent{})
+
+	ts.Focus(basic)
+	ts.Update(0)
+	if c := ts.caret.Color; c != color.White {
+		t.Errorf("caret was not drawn in the color of the Font, got: %v", c)
+	}
+	if !ts.caret.ScreenSpace || !ts.selection.ScreenSpace {
+		t.Error("caret of a field on the HUD was not drawn on the HUD")
+	}
+}
+
+func TestTextInputSystemClipboard(t *testing.T) {
+	engo.Run(engo.RunOptions{NoRun: true, HeadlessMode: true}, &testScene{})
+	defer engo.SetClipboardText("")
+
+	w := &ecs.World{}
+	ts := &TextInputSystem{}
+	w.AddSystem(ts)
+	basic := ecs.NewBasic()
+	input := &TextInputComponent{Text: "copy me", MaxLength: 10}
+	ts.Add(&basic, &MouseComponent{}, &SpaceComponent{}, &RenderComponent{}, input)
+	ts.Focus(basic)
+
+	press := func(k engo.Key) {
+		engo.Input.SetKey(engo.KeyLeftControl, true)
+		engo.Input.SetKey(k, true)
+		ts.Update(0)
+		engo.Input.SetKey(engo.KeyLeftControl, false)
+		engo.Input.SetKey(k, false)
+		engo.Step(0)
+	}
+
+	// Copying without a selection leaves the clipboard as it is
+	engo.SetClipboardText("before")
+	press(engo.KeyC)
+	if text := engo.ClipboardText(); text != "before" {
+		t.Errorf("clipboard changed without a selection, got: %q", text)
+	}
+
+	input.Select(0, 4)
+	press(engo.KeyC)
+	if text := engo.ClipboardText(); text != "copy" || input.Text != "copy me" {
+		t.Errorf("selection was not copied, got: %q", text)
+	}
+	input.Select(4, 7)
+	press(engo.KeyX)
+	if text := engo.ClipboardText(); text != " me" || input.Text != "copy" {
+		t.Errorf("selection was not cut, got %q on the clipboard and %q in the field", text, input.Text)
+	}
+
+	// Pasting drops newlines, and what doesn't fit
+	engo.SetClipboardText("ca\nt food")
+	press(engo.KeyV)
+	if input.Text != "copycat fo" || input.Caret() != 10 {
+		t.Errorf("clipboard was not pasted at the caret, got: %q", input.Text)
+	}
+}
@@ -0,0 +1,129 @@
+package common
+
+import (
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo/math"
+)
+
+// Timer is a callback scheduled using `TimerSystem.After` or `TimerSystem.Every`. It's used to stop the callback
+// before it fires.
+type Timer struct {
+	fn        func()
+	interval  float32
+	repeat    bool
+	remaining float32
+	stopped   bool
+}
+
+// Stop stops the timer, so its callback isn't called anymore. A repeating timer may stop itself from within its own
+// callback.
+func (t *Timer) Stop() {
+	t.stopped = true
+}
+
+// Stopped returns whether the timer was stopped, or has fired if it doesn't repeat.
+func (t *Timer) Stopped() bool {
+	return t.stopped
+}
+
+// Remaining returns the time in seconds until the timer fires next.
+func (t *Timer) Remaining() float32 {
+	return math.Max(t.remaining, 0)
+}
+
+// update progresses the timer by dt seconds, calling its callback whenever it fires. If skip is set, a repeating
+// timer fires only once even if more than one interval passed.
+func (t *Timer) update(dt float32, skip bool) {
+	t.remaining -= dt
+	for t.remaining <= 0 && !t.stopped {
+		switch {
+		case !t.repeat:
+			t.stopped = true
+		case t.interval <= 0:
+			// Fires every frame, once
+			t.fn()
+			return
+		case skip:
+			// The missed intervals are skipped, keeping the timer in step with its interval
+			t.remaining += t.interval * math.Floor(-t.remaining/t.interval+1)
+		default:
+			t.remaining += t.interval
+		}
+		t.fn()
+	}
+}
+
+// TimerSystem calls callbacks after a delay, or repeatedly at an interval, based on the time it's updated with,
+// such as for spawning an enemy every half a second. Timers aren't linked to entities, so they have to be stopped
+// if whatever their callback uses goes away. Like any other system, the TimerSystem doesn't fire while it's paused,
+// see `engo.SetSystemPaused`.
+type TimerSystem struct {
+	// SkipMissed fires a repeating timer only once when more than one of its intervals passed during a single frame,
+	// such as after a long frame while loading, and skips the missed ones. By default, the timer catches up by
+	// firing once for every interval which passed.
+	SkipMissed bool
+
+	timers []*Timer
+}
+
+// After calls fn once, after the given amount of seconds. A delay of zero or less fires during the next update.
+func (ts *TimerSystem) After(delay float32, fn func()) *Timer {
+	return ts.add(&Timer{fn: fn, remaining: delay})
+}
+
+// Every calls fn repeatedly, every interval seconds, starting interval seconds from now, until the timer is
+// stopped. An interval of zero or less fires once every update.
+func (ts *TimerSystem) Every(interval float32, fn func()) *Timer {
+	return ts.add(&Timer{fn: fn, interval: interval, repeat: true, remaining: interval})
+}
+
+// add schedules the timer.
+func (ts *TimerSystem) add(t *Timer) *Timer {
+	ts.timers = append(ts.timers, t)
+	return t
+}
+
+// Stop stops every timer, see `Timer.Stop`.
+func (ts *TimerSystem) Stop() {
+	for _, t := range ts.timers {
+		t.stopped = true
+	}
+}
+
+// Len returns the number of timers which haven't fired or been stopped yet.
+func (ts *TimerSystem) Len() int {
+	n := 0
+	for _, t := range ts.timers {
+		if !t.stopped {
+			n++
+		}
+	}
+	return n
+}
+
+// Remove does nothing, since timers aren't linked to entities. It implements the ecs.System interface.
+func (*TimerSystem) Remove(ecs.BasicEntity) {}
+
+// Update progresses every timer by dt seconds, firing those which are due in the order in which they were added.
+// Timers added by a callback start being progressed during the next update, and timers which were stopped or fired
+// for the last time are removed.
+func (ts *TimerSystem) Update(dt float32) {
+	// The callbacks may add timers, which aren't updated until the next frame
+	n := len(ts.timers)
+	for i := 0; i < n; i++ {
+		if t := ts.timers[i]; !t.stopped {
+			t.update(dt, ts.SkipMissed)
+		}
+	}
+
+	timers := ts.timers[:0]
+	for _, t := range ts.timers {
+		if !t.stopped {
+			timers = append(timers, t)
+		}
+	}
+	for i := len(timers); i < len(ts.timers); i++ {
+		ts.timers[i] = nil
+	}
+	ts.timers = timers
+}
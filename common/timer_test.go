@@ -0,0 +1,83 @@
+package common
+
+import "testing"
+
+func TestTimerSystem(t *testing.T) {
+	ts := &TimerSystem{}
+	var after, every int
+	once := ts.After(1, func() { after++ })
+	repeating := ts.Every(0.5, func() { every++ })
+
+	ts.Update(0.75)
+	if after != 0 || every != 1 {
+		t.Errorf("wrong timers fired after 0.75 seconds. Wanted: 0 and 1, got: %v and %v", after, every)
+	}
+	if remaining := once.Remaining(); remaining != 0.25 {
+		t.Errorf("wrong remaining time. Wanted: 0.25, got: %v", remaining)
+	}
+
+	ts.Update(0.25)
+	if after != 1 || every != 2 || !once.Stopped() {
+		t.Errorf("wrong timers fired after 1 second. Wanted: 1 and 2, got: %v and %v", after, every)
+	}
+
+	ts.Update(1)
+	if after != 1 || every != 4 {
+		t.Errorf("wrong timers fired after 2 seconds. Wanted: 1 and 4, got: %v and %v", after, every)
+	}
+	if ts.Len() != 1 {
+		t.Errorf("fired timer was not removed. Wanted: 1 timer, got: %v", ts.Len())
+	}
+
+	repeating.Stop()
+	ts.Update(1)
+	if every != 4 || ts.Len() != 0 {
+		t.Errorf("stopped timer kept firing, fired %v times", every)
+	}
+}
+
+func TestTimerSystemSkipMissed(t *testing.T) {
+	for _, test := range []struct {
+		skip  bool
+		fired int
+	}{
+		{false, 5},
+		{true, 1},
+	} {
+		ts := &TimerSystem{SkipMissed: test.skip}
+		fired := 0
+		timer := ts.Every(0.5, func() { fired++ })
+
+		// A long frame of 2.75 seconds, during which 5 intervals passed
+		ts.Update(2.75)
+		if fired != test.fired {
+			t.Errorf("wrong number of intervals fired while skipping missed ones is %v. Wanted: %v, got: %v", test.skip, test.fired, fired)
+		}
+		if remaining := timer.Remaining(); remaining != 0.25 {
+			t.Errorf("timer was not kept in step with its interval while skipping missed ones is %v. Wanted: 0.25, got: %v", test.skip, remaining)
+		}
+	}
+}
+
+func TestTimerSystemCallbacks(t *testing.T) {
+	ts := &TimerSystem{}
+	var added, fired int
+	var self *Timer
+	self = ts.Every(0, func() {
+		fired++
+		if fired == 2 {
+			self.Stop()
+		}
+		ts.After(0, func() { added++ })
+	})
+
+	ts.Update(1)
+	if fired != 1 || added != 0 {
+		t.Errorf("timer added during an update was fired during it. Wanted: 1 and 0, got: %v and %v", fired, added)
+	}
+	ts.Update(1)
+	ts.Update(1)
+	if fired != 2 || added != 2 {
+		t.Errorf("timer which stopped itself kept firing. Wanted: 2 and 2, got: %v and %v", fired, added)
+	}
+}
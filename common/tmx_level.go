@@ -21,6 +21,7 @@ func createLevelFromTmx(r io.Reader, tmxURL string) (*Level, error) {
 	level.Orientation = orth
 	level.resourceMap = make(map[uint32]Texture)
 	level.pointMap = make(map[mapPoint]*Tile)
+	level.propertyMap = make(map[uint32][]Property)
 
 	// get a map of the gids to textures from the tilesets
 	for _, ts := range tmxLevel.Tilesets {
@@ -28,6 +29,9 @@ func createLevelFromTmx(r io.Reader, tmxURL string) (*Level, error) {
 			level.Orientation = g.Orientation
 		}
 		for _, t := range ts.Tiles {
+			if len(t.Properties) > 0 {
+				level.propertyMap[ts.FirstGID+t.ID] = getProperties(t.Properties)
+			}
 			for _, i := range t.Image {
 				if i.Source != "" {
 					tex, err := LoadedSprite(path.Join(path.Dir(tmxURL), i.Source))
@@ -138,6 +142,8 @@ func createLevelFromTmx(r io.Reader, tmxURL string) (*Level, error) {
 			object.Y = float32(tmxobj.Y)
 			object.Width = float32(tmxobj.Width)
 			object.Height = float32(tmxobj.Height)
+			object.Rotation = float32(tmxobj.Rotation)
+			object.gid = tmxobj.GID
 			object.Properties = getProperties(tmxobj.Properties)
 			object.Tiles = append(object.Tiles, level.tileFromGID(tmxobj.GID, engo.Point{
 				X: object.X,
@@ -361,6 +367,7 @@ func (l *Level) tileFromGID(gid uint32, pt engo.Point) *Tile {
 	tex := l.resourceMap[gid]
 	ret.Image = &tex
 	ret.Point = pt
+	ret.Properties = l.propertyMap[gid]
 	return ret
 }
 
@@ -3,6 +3,7 @@ package common
 import (
 	"bytes"
 	"image"
+	"image/color"
 	"image/png"
 	"io/ioutil"
 	"os"
@@ -10,7 +11,9 @@ import (
 	"testing"
 	"text/template"
 
+	"github.com/EngoEngine/ecs"
 	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/engo/math"
 )
 
 var testTMXtmpl = `
@@ -91,6 +94,43 @@ var testTMXtmpl = `
 </map>
 `
 
+// testTMXEntities is a level whose layers and objects are turned into entities, see `Level.TileEntities`.
+var testTMXEntities = `
+<?xml version="1.0" encoding="UTF-8"?>
+<map version="1.0" tiledversion="1.1.5" orientation="orthogonal" renderorder="right-down" width="3" height="3" tilewidth="16" tileheight="16" infinite="0" nextobjectid="1">
+ <tileset firstgid="1" name="test" tilewidth="16" tileheight="16" spacing="1" tilecount="468" columns="26">
+  <image source="test.png" width="457" height="305"/>
+  <tile id="234">
+   <properties>
+    <property name="solid" type="bool" value="true"/>
+   </properties>
+  </tile>
+ </tileset>
+ <layer name="Tile Layer 1" width="3" height="3">
+  <data encoding="base64" compression="zlib">
+   eJx7zcDA8AaI3wLxdyBOYWRgkAJiZyB2AWJBIAYAfvME1w==
+  </data>
+ </layer>
+ <layer name="Tile Layer 2" opacity="0.5" offsetx="4" offsety="8">
+	<data encoding="base64" compression="zlib">
+	 eJx7zcDA8AaI3wLxdyBOYWRgkAJiZyB2AWJBIAYAfvME1w==
+	</data>
+ </layer>
+ <layer name="Hidden Layer" visible="0">
+	<data encoding="base64" compression="zlib">
+	 eJx7zcDA8AaI3wLxdyBOYWRgkAJiZyB2AWJBIAYAfvME1w==
+	</data>
+ </layer>
+ <objectgroup name="Object Layer 1">
+	<object id="1" name="Rectangle" x="10" y="13" width="25" height="23"/>
+	<object id="3" name="Tile" gid="1" x="0" y="32" width="16" height="16" rotation="90"/>
+ </objectgroup>
+ <imagelayer name="Image Layer 1">
+	<image source="test.png"/>
+ </imagelayer>
+</map>
+`
+
 var badTMX = `
 <?xml version="1.0" encoding="UTF-8"?>
 <map version="1.0" tiledversion="1.1.5" orientation="orthogonal" renderorder="right-down" width="3" height="3" tilewidth="16" tileheight="16" infinite="0" nextobjectid="1">
@@ -721,3 +761,79 @@ func TestTMXLevelIsometric(t *testing.T) {
 		t.Errorf("Tile was not returned correctly\nWanted: %v\nGot: %v", expTile, tile.Point)
 	}
 }
+
+func TestTMXLevelEntities(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &tmxTestScene{})
+
+	imgbuf := bytes.NewBuffer([]byte{})
+	img := image.NewRGBA(image.Rect(0, 0, 457, 305))
+	err := png.Encode(imgbuf, img)
+	if err != nil {
+		t.Errorf("Unable to encode png from image")
+	}
+	err = engo.Files.LoadReaderData("test.png", imgbuf)
+	if err != nil {
+		t.Errorf("Unable to load test png. Error was: %v", err)
+	}
+
+	err = engo.Files.LoadReaderData("test.tmx", bytes.NewBufferString(testTMXEntities))
+	if err != nil {
+		t.Fatalf("Unable to load tmx file for testing. Error was: %v", err)
+	}
+	resource, err := engo.Files.Resource("test.tmx")
+	if err != nil {
+		t.Fatalf("Unable to retrieve resource. Error was: %v", err)
+	}
+	level := resource.(TMXResource).Level
+
+	// Only the visible layers are drawn, using the opacity and offset of their layer.
+	entities := level.TileEntities()
+	if len(entities) != 1+2*9 {
+		t.Fatalf("wrong amount of tile entities. Wanted: %v, got: %v", 1+2*9, len(entities))
+	}
+	if e := entities[0]; e.RenderComponent.ZIndex() != -1 || e.Tile != level.ImageLayers[0].Images[0] {
+		t.Errorf("image layer was not drawn behind the tile layers, got z-index: %v", e.RenderComponent.ZIndex())
+	}
+	first, second := entities[1], entities[10]
+	if first.RenderComponent.ZIndex() != 0 || second.RenderComponent.ZIndex() != 1 {
+		t.Errorf("tile layers were not drawn in order. Wanted z-index (0, 1), got: (%v, %v)",
+			first.RenderComponent.ZIndex(), second.RenderComponent.ZIndex())
+	}
+	if p := second.SpaceComponent.Position; p.X != 4 || p.Y != 8 {
+		t.Errorf("tile was not moved by the offset of its layer. Wanted: (4, 8), got: %v", p)
+	}
+	if s := second.SpaceComponent; s.Width != 16 || s.Height != 16 {
+		t.Errorf("tile entity was not as large as its tile. Wanted: 16x16, got: %vx%v", s.Width, s.Height)
+	}
+	if c := second.RenderComponent.Color.(color.NRGBA); c.A != 127 {
+		t.Errorf("tile was not drawn using the opacity of its layer. Wanted alpha: 127, got: %v", c.A)
+	}
+
+	// The tile properties of the tileset are kept for each tile using them.
+	if p := first.Tile.Properties; len(p) != 1 || p[0].Name != "solid" || p[0].Value != "true" {
+		t.Errorf("tile properties were not kept for the tile. Got: %v", p)
+	}
+	if p := entities[2].Tile.Properties; len(p) != 0 {
+		t.Errorf("tile without properties got properties: %v", p)
+	}
+
+	// Objects are converted to SpaceComponents, where tile objects are placed using their bottom left corner.
+	objects := level.ObjectLayers[0].Objects
+	if s := objects[0].SpaceComponent(); s.Position.X != 10 || s.Position.Y != 13 || s.Width != 25 || s.Height != 23 {
+		t.Errorf("rectangle was not converted correctly, got: %+v", s)
+	}
+	if s := objects[1].SpaceComponent(); math.Abs(s.Position.X-16) > 1e-4 || math.Abs(s.Position.Y-32) > 1e-4 || s.Rotation != 90 {
+		t.Errorf("rotated tile object was not placed at its upper left corner. Wanted: (16, 32), got: %+v", s)
+	}
+
+	w := &ecs.World{}
+	rs := &RenderSystem{}
+	w.AddSystem(rs)
+	level.AddTileEntities(w)
+	if n := len(rs.entities); n != len(entities) {
+		t.Errorf("tile entities were not added to the RenderSystem. Wanted: %v, got: %v", len(entities), n)
+	}
+}
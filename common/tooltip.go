@@ -0,0 +1,224 @@
+package common
+
+import (
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+const (
+	// DefaultTooltipDelay is the Delay of a TooltipSystem which has none set, in seconds.
+	DefaultTooltipDelay = 0.5
+	// DefaultTooltipOffset is the distance from the cursor to the tooltip along both axes, for a TooltipSystem
+	// which has no Offset set.
+	DefaultTooltipOffset = 16
+	// tooltipZIndex is the z-index of the tooltip, which is drawn on top of the rest of the HUD
+	tooltipZIndex = 1000
+)
+
+// TooltipComponent holds the tooltip shown by the TooltipSystem while the mouse hovers the entity.
+type TooltipComponent struct {
+	// Text is the text of the tooltip, which is drawn using the Font of the TooltipSystem
+	Text string
+	// Drawable is drawn as the tooltip instead of Text, if set, such as a NineSlice holding an icon
+	Drawable Drawable
+}
+
+// drawable returns what is to be drawn as the tooltip, or nil if there is nothing to draw.
+func (t *TooltipComponent) drawable(fnt *Font) Drawable {
+	if t.Drawable != nil {
+		return t.Drawable
+	}
+	if t.Text == "" || fnt == nil {
+		return nil
+	}
+	return Text{Font: fnt, Text: t.Text}
+}
+
+type tooltipEntity struct {
+	*ecs.BasicEntity
+	*MouseComponent
+	*TooltipComponent
+}
+
+// TooltipSystem shows the tooltip of an entity near the cursor, once the mouse hovered the entity for Delay seconds
+// without leaving it, and hides it as soon as the mouse leaves the entity. Entities are hovered as determined by the
+// MouseSystem, so the world needs one, and the tooltip is drawn on the HUD by the RenderSystem.
+//
+// The tooltip follows the cursor at Offset from it. Whenever that would put part of the tooltip outside of the screen,
+// it's moved to the other side of the cursor, and against the top or left edge of the screen if it doesn't fit there
+// either.
+type TooltipSystem struct {
+	// Delay is the amount of seconds the mouse has to hover an entity before its tooltip is shown. It's
+	// DefaultTooltipDelay when zero; a negative delay shows the tooltip right away.
+	Delay float32
+	// Offset is the position of the top-left corner of the tooltip relative to the cursor, in the coordinates of the
+	// HUD. It's DefaultTooltipOffset along both axes when zero.
+	Offset engo.Point
+	// Font is the font in which the Text of the tooltips is drawn. Tooltips with a Text but without a Drawable are
+	// not shown while it's nil.
+	Font *Font
+
+	entities []tooltipEntity
+	ids      entityIDs
+	world    *ecs.World
+
+	// hovered is the index of the entity hovered during the previous update, or -1 if there was none
+	hovered int
+	// hoverTime is the amount of seconds the mouse has been hovering the hovered entity
+	hoverTime float32
+
+	tooltip struct {
+		ecs.BasicEntity
+		RenderComponent
+		SpaceComponent
+	}
+	// render is the RenderSystem drawing the tooltip while it's shown, and nil while it's hidden
+	render *RenderSystem
+}
+
+// New is called when the TooltipSystem is added to the world.
+func (t *TooltipSystem) New(w *ecs.World) {
+	t.world = w
+	t.hovered = -1
+	t.tooltip.BasicEntity = ecs.NewBasic()
+}
+
+// Add adds an entity to the TooltipSystem. The entity needs a basic, mouse and tooltip component to be added to the
+// system. The MouseComponent has to be in the MouseSystem as well.
+func (t *TooltipSystem) Add(basic *ecs.BasicEntity, mouse *MouseComponent, tooltip *TooltipComponent) {
+	if !t.ids.add(basic.ID()) {
+		return
+	}
+	t.entities = append(t.entities, tooltipEntity{basic, mouse, tooltip})
+}
+
+// AddByInterface Provides a simple way to add an entity to the system that satisfies Tooltipable. Any entity
+// containing, BasicEntity, MouseComponent and TooltipComponent anonymously, automatically does this.
+func (t *TooltipSystem) AddByInterface(i ecs.Identifier) {
+	o, _ := i.(Tooltipable)
+	t.Add(o.GetBasicEntity(), o.GetMouseComponent(), o.GetTooltipComponent())
+}
+
+// Remove removes an entity from the TooltipSystem, hiding its tooltip if it's shown.
+func (t *TooltipSystem) Remove(basic ecs.BasicEntity) {
+	t.ids.remove(basic.ID())
+	for i, e := range t.entities {
+		if e.ID() != basic.ID() {
+			continue
+		}
+		if t.hovered == i {
+			t.hide()
+			t.hovered, t.hoverTime = -1, 0
+		} else if t.hovered > i {
+			t.hovered--
+		}
+		t.entities = append(t.entities[:i], t.entities[i+1:]...)
+		return
+	}
+}
+
+// Shown returns the ID of the entity whose tooltip is shown, and false while none is.
+func (t *TooltipSystem) Shown() (uint64, bool) {
+	if t.render == nil || t.hovered < 0 {
+		return 0, false
+	}
+	return t.entities[t.hovered].ID(), true
+}
+
+// Tooltip returns the SpaceComponent of the tooltip, which is in the coordinates of the HUD. It's only drawn while
+// Shown returns true.
+func (t *TooltipSystem) Tooltip() *SpaceComponent {
+	return &t.tooltip.SpaceComponent
+}
+
+// Update shows the tooltip of the hovered entity once it has been hovered for Delay seconds, and moves it along with
+// the cursor.
+func (t *TooltipSystem) Update(dt float32) {
+	hovered := -1
+	for i, e := range t.entities {
+		if e.MouseComponent.Hovered {
+			hovered = i
+		}
+	}
+	if hovered != t.hovered {
+		t.hide()
+		t.hovered, t.hoverTime = hovered, 0
+	}
+	if hovered < 0 {
+		return
+	}
+
+	delay := t.Delay
+	if delay == 0 {
+		delay = DefaultTooltipDelay
+	}
+	t.hoverTime += dt
+	if t.hoverTime < delay {
+		return
+	}
+	if t.render == nil && !t.show(t.entities[hovered]) {
+		return
+	}
+	t.place()
+}
+
+// show starts drawing the tooltip of the entity, and returns whether or not it has a tooltip to draw.
+func (t *TooltipSystem) show(e tooltipEntity) bool {
+	drawable := e.TooltipComponent.drawable(t.Font)
+	if drawable == nil || t.world == nil {
+		return false
+	}
+	for _, system := range t.world.Systems() {
+		if rs, ok := system.(*RenderSystem); ok {
+			t.render = rs
+			break
+		}
+	}
+	if t.render == nil {
+		return false
+	}
+
+	// The RenderComponent is replaced, so the RenderSystem picks the HUD shader for the new Drawable
+	t.tooltip.RenderComponent = RenderComponent{Drawable: drawable}
+	t.tooltip.RenderComponent.SetShader(HUDShader)
+	t.tooltip.RenderComponent.SetZIndex(tooltipZIndex)
+	t.tooltip.SpaceComponent = SpaceComponent{Width: drawable.Width(), Height: drawable.Height()}
+	t.render.Add(&t.tooltip.BasicEntity, &t.tooltip.RenderComponent, &t.tooltip.SpaceComponent)
+	return true
+}
+
+// hide stops drawing the tooltip, if it's shown.
+func (t *TooltipSystem) hide() {
+	if t.render == nil {
+		return
+	}
+	t.render.Remove(t.tooltip.BasicEntity)
+	t.render = nil
+}
+
+// place moves the tooltip next to the cursor, keeping it within the screen.
+func (t *TooltipSystem) place() {
+	offset := t.Offset
+	if offset.X == 0 && offset.Y == 0 {
+		offset = engo.Point{X: DefaultTooltipOffset, Y: DefaultTooltipOffset}
+	}
+	x, y := engo.WindowToGame(engo.Input.Mouse.X, engo.Input.Mouse.Y)
+	width, height := screenSize()
+	space := &t.tooltip.SpaceComponent
+	space.Position.X = placeTooltip(x, offset.X, space.Width, width)
+	space.Position.Y = placeTooltip(y, offset.Y, space.Height, height)
+}
+
+// placeTooltip returns the position of the tooltip along one axis: at offset from the cursor, on the other side of
+// the cursor if it doesn't fit within the screen there, and against the top or left edge of the screen if it fits on
+// neither side.
+func placeTooltip(cursor, offset, size, screen float32) float32 {
+	p := cursor + offset
+	if p+size > screen {
+		p = cursor - offset - size
+	}
+	if p < 0 {
+		p = 0
+	}
+	return p
+}
@@ -0,0 +1,88 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+func TestTooltipSystem(t *testing.T) {
+	engo.Run(engo.RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &mouseTestScene{})
+	CameraBounds = engo.AABB{Max: engo.Point{X: 400, Y: 300}}
+
+	w := &ecs.World{}
+	rs := &RenderSystem{}
+	w.AddSystem(rs)
+	m := &MouseSystem{}
+	w.AddSystem(m)
+	ts := &TooltipSystem{Delay: 1}
+	w.AddSystem(ts)
+
+	basic := ecs.NewBasic()
+	mouse := &MouseComponent{}
+	m.Add(&basic, mouse, &SpaceComponent{Position: engo.Point{X: 100, Y: 100}, Width: 300, Height: 200}, nil)
+	ts.Add(&basic, mouse, &TooltipComponent{Drawable: Texture{width: 40, height: 20}})
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 120, 130
+	w.Update(0.5)
+	if _, ok := ts.Shown(); ok {
+		t.Error("tooltip was shown before the delay passed")
+	}
+	w.Update(0.6)
+	if id, ok := ts.Shown(); !ok || id != basic.ID() {
+		t.Fatal("tooltip was not shown once the delay passed")
+	}
+	if _, _, ok := rs.Get(ts.tooltip.ID()); !ok {
+		t.Error("shown tooltip was not drawn")
+	}
+	if p := ts.Tooltip().Position; p != (engo.Point{X: 136, Y: 146}) {
+		t.Errorf("tooltip was not placed at the offset from the cursor. Wanted: (136, 146), got: %v", p)
+	}
+
+	// Near the bottom-right corner, the tooltip is moved to the other side of the cursor
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 390, 290
+	w.Update(0.1)
+	if p := ts.Tooltip().Position; p != (engo.Point{X: 334, Y: 254}) {
+		t.Errorf("tooltip was not kept within the screen. Wanted: (334, 254), got: %v", p)
+	}
+
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 50, 50
+	w.Update(0.1)
+	if _, ok := ts.Shown(); ok {
+		t.Error("tooltip was still shown once the mouse left the entity")
+	}
+	if _, _, ok := rs.Get(ts.tooltip.ID()); ok {
+		t.Error("hidden tooltip was still drawn")
+	}
+
+	// Hovering the entity again starts the delay over
+	engo.Input.Mouse.X, engo.Input.Mouse.Y = 120, 130
+	w.Update(0.5)
+	if _, ok := ts.Shown(); ok {
+		t.Error("tooltip was shown right away when hovering the entity again")
+	}
+}
+
+func TestPlaceTooltip(t *testing.T) {
+	tests := []struct {
+		name                         string
+		cursor, offset, size, screen float32
+		expected                     float32
+	}{
+		{"fits", 100, 16, 40, 400, 116},
+		{"other side", 390, 16, 40, 400, 334},
+		{"fits on neither side", 30, 16, 40, 60, 0},
+		{"larger than the screen", 30, 16, 80, 60, 0},
+	}
+	for _, test := range tests {
+		if p := placeTooltip(test.cursor, test.offset, test.size, test.screen); p != test.expected {
+			t.Errorf("tooltip which %s was placed wrong. Wanted: %v, got: %v", test.name, test.expected, p)
+		}
+	}
+}
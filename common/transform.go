@@ -0,0 +1,281 @@
+package common
+
+import (
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/engo/math"
+)
+
+// TransformSystemPriority is the priority of the TransformSystem. It's lower than that of every other system except
+// for those drawing, so children are moved along with their parents after everything else moved, right before they're
+// drawn.
+const TransformSystemPriority = -800
+
+// TransformComponent makes the SpaceComponent of an entity relative to that of a parent entity, so it moves, rotates
+// and scales along with the parent. The parent is set using `TransformSystem.SetParent`; entities without a parent
+// are positioned by their SpaceComponent as usual, and only use their Scale.
+type TransformComponent struct {
+	// Position is the position of the entity relative to the Position of its parent, in the unrotated and unscaled
+	// space of the parent: at (10, 0) the entity is 10 units to the right of its parent, or below it once the
+	// parent is rotated by 90 degrees.
+	Position engo.Point
+	// Rotation is the rotation of the entity relative to that of its parent, in degrees
+	Rotation float32
+	// Scale is the scale of the entity relative to that of its parent, which scales the Position of its children.
+	// It's 1 when zero.
+	Scale engo.Point
+
+	parent     uint64
+	hasParent  bool
+	worldScale engo.Point
+}
+
+// scale returns the Scale, which is 1 when not set.
+func (t *TransformComponent) scale() engo.Point {
+	if t.Scale.X == 0 && t.Scale.Y == 0 {
+		return engo.Point{X: 1, Y: 1}
+	}
+	return t.Scale
+}
+
+// Parent returns the ID of the parent of the entity, and false if it has none.
+func (t *TransformComponent) Parent() (uint64, bool) {
+	return t.parent, t.hasParent
+}
+
+// WorldScale returns the scale of the entity relative to the world, which is its Scale multiplied by the scale of
+// its parents, as of the last update of the TransformSystem.
+func (t *TransformComponent) WorldScale() engo.Point {
+	if t.worldScale.X == 0 && t.worldScale.Y == 0 {
+		return t.scale()
+	}
+	return t.worldScale
+}
+
+type transformEntity struct {
+	*ecs.BasicEntity
+	*SpaceComponent
+	*TransformComponent
+	*RenderComponent
+
+	// resolved is the resolution during which the SpaceComponent was last moved to its place
+	resolved uint
+}
+
+// TransformSystem moves the SpaceComponent of every entity with a parent to its place relative to the parent, so
+// moving the parent moves its children. A turret on a tank is added to the TransformSystem along with the tank, and
+// attached to it using SetParent; from then on the Position and Rotation of the TransformComponent of the turret are
+// relative to the tank, and its SpaceComponent is in the world, like that of every other entity.
+//
+// The world transforms are resolved once per update, in which parents are always resolved before their children. The
+// TransformSystem updates after all other systems except for those drawing (see TransformSystemPriority), so
+// parents can be moved by any system, and the children are drawn at the place of their parents during the same frame.
+// Systems updating before it, such as the MouseSystem, see the SpaceComponents of the children as they were drawn
+// during the previous frame, so the MouseSystem hits children exactly where the player saw them. The position of
+// the mouse is in the world (see `MouseComponent.MouseX`); use WorldToLocal to translate it into the space of
+// an entity, such as to aim a turret at the cursor.
+//
+// An entity added along with a RenderComponent is drawn at its scale: its RenderComponent.Scale is set to its scale
+// relative to the world, and the Width and Height of its SpaceComponent to those of its Drawable at that scale, so
+// it's hit where it's drawn.
+type TransformSystem struct {
+	entities []transformEntity
+	// index maps the ID of every entity to its index within entities
+	index map[uint64]int
+	// resolution counts the resolutions of the world transforms, see transformEntity.resolved
+	resolution uint
+}
+
+// Priority implements the ecs.Prioritizer interface.
+func (*TransformSystem) Priority() int { return TransformSystemPriority }
+
+// Add adds an entity to the TransformSystem. The BasicEntity, SpaceComponent and TransformComponent are required,
+// the RenderComponent is optional; see TransformSystem on how it's scaled.
+func (ts *TransformSystem) Add(basic *ecs.BasicEntity, space *SpaceComponent, transform *TransformComponent, render *RenderComponent) {
+	if ts.index == nil {
+		ts.index = make(map[uint64]int)
+	}
+	if _, ok := ts.index[basic.ID()]; ok {
+		return
+	}
+	ts.index[basic.ID()] = len(ts.entities)
+	ts.entities = append(ts.entities, transformEntity{basic, space, transform, render, 0})
+}
+
+// AddByInterface Provides a simple way to add an entity to the system that satisfies Transformable. Any entity
+// containing, BasicEntity, SpaceComponent and TransformComponent anonymously, automatically does this. A
+// RenderComponent is added along with them if the entity has one.
+func (ts *TransformSystem) AddByInterface(i ecs.Identifier) {
+	o, _ := i.(Transformable)
+	var render *RenderComponent
+	if r, ok := i.(RenderFace); ok {
+		render = r.GetRenderComponent()
+	}
+	ts.Add(o.GetBasicEntity(), o.GetSpaceComponent(), o.GetTransformComponent(), render)
+}
+
+// Remove removes an entity from the TransformSystem. Its children are detached, keeping their place in the world.
+func (ts *TransformSystem) Remove(basic ecs.BasicEntity) {
+	i, ok := ts.index[basic.ID()]
+	if !ok {
+		return
+	}
+	ts.resolve()
+	for _, e := range ts.entities {
+		if e.hasParent && e.parent == basic.ID() {
+			ts.detach(e)
+		}
+	}
+
+	ts.entities = append(ts.entities[:i], ts.entities[i+1:]...)
+	delete(ts.index, basic.ID())
+	for j := i; j < len(ts.entities); j++ {
+		ts.index[ts.entities[j].ID()] = j
+	}
+}
+
+// Entities returns a copy of the IDs of all entities in the TransformSystem.
+func (ts *TransformSystem) Entities() []uint64 {
+	ids := make([]uint64, len(ts.entities))
+	for i, e := range ts.entities {
+		ids[i] = e.ID()
+	}
+	return ids
+}
+
+// BasicEntities returns a copy of the BasicEntities of all entities in the TransformSystem.
+func (ts *TransformSystem) BasicEntities() []ecs.BasicEntity {
+	basics := make([]ecs.BasicEntity, len(ts.entities))
+	for i, e := range ts.entities {
+		basics[i] = *e.BasicEntity
+	}
+	return basics
+}
+
+// Get returns the components with which the entity with the given ID was added to the TransformSystem, and false
+// if it isn't in the system, such as once it was removed.
+func (ts *TransformSystem) Get(id uint64) (*SpaceComponent, *TransformComponent, bool) {
+	i, ok := ts.index[id]
+	if !ok {
+		return nil, nil, false
+	}
+	return ts.entities[i].SpaceComponent, ts.entities[i].TransformComponent, true
+}
+
+// SetParent attaches the child to the parent, which both have to be in the TransformSystem. The child keeps its
+// place in the world: the Position, Rotation and Scale of its TransformComponent are set relative to the parent.
+// It returns false if either entity isn't in the system, or if the parent is the child or one of its children.
+func (ts *TransformSystem) SetParent(child, parent ecs.BasicEntity) bool {
+	c, ok := ts.index[child.ID()]
+	if !ok {
+		return false
+	}
+	p, ok := ts.index[parent.ID()]
+	if !ok {
+		return false
+	}
+	for ancestor := p; ; {
+		if ancestor == c {
+			return false
+		}
+		a := ts.entities[ancestor]
+		if !a.hasParent {
+			break
+		}
+		ancestor = ts.index[a.parent]
+	}
+
+	ts.resolve()
+	e, pe := ts.entities[c], ts.entities[p]
+	if e.hasParent {
+		ts.detach(e)
+	}
+	ps := pe.WorldScale()
+	ws := e.WorldScale()
+	sin, cos := math.Sincos(pe.SpaceComponent.Rotation * math.Pi / 180)
+	dx, dy := e.SpaceComponent.Position.X-pe.SpaceComponent.Position.X, e.SpaceComponent.Position.Y-pe.SpaceComponent.Position.Y
+	e.TransformComponent.Position = engo.Point{X: (dx*cos + dy*sin) / ps.X, Y: (dy*cos - dx*sin) / ps.Y}
+	e.TransformComponent.Rotation = e.SpaceComponent.Rotation - pe.SpaceComponent.Rotation
+	e.TransformComponent.Scale = engo.Point{X: ws.X / ps.X, Y: ws.Y / ps.Y}
+	e.parent, e.hasParent = parent.ID(), true
+	return true
+}
+
+// Detach detaches the child from its parent, if it has one. The child keeps its place in the world, and its scale
+// relative to the world becomes its Scale.
+func (ts *TransformSystem) Detach(child ecs.BasicEntity) {
+	i, ok := ts.index[child.ID()]
+	if !ok || !ts.entities[i].hasParent {
+		return
+	}
+	ts.resolve()
+	ts.detach(ts.entities[i])
+}
+
+// detach detaches the resolved entity from its parent.
+func (ts *TransformSystem) detach(e transformEntity) {
+	e.TransformComponent.Scale = e.WorldScale()
+	e.TransformComponent.Position, e.TransformComponent.Rotation = engo.Point{}, 0
+	e.parent, e.hasParent = 0, false
+}
+
+// WorldToLocal translates a point in the world into the unrotated and unscaled space of the entity with the given
+// ID, relative to its Position: the space in which the Position of its children is. It returns false if the entity
+// isn't in the TransformSystem.
+func (ts *TransformSystem) WorldToLocal(id uint64, p engo.Point) (engo.Point, bool) {
+	i, ok := ts.index[id]
+	if !ok {
+		return engo.Point{}, false
+	}
+	e := ts.entities[i]
+	scale := e.WorldScale()
+	sin, cos := math.Sincos(e.SpaceComponent.Rotation * math.Pi / 180)
+	dx, dy := p.X-e.SpaceComponent.Position.X, p.Y-e.SpaceComponent.Position.Y
+	return engo.Point{X: (dx*cos + dy*sin) / scale.X, Y: (dy*cos - dx*sin) / scale.Y}, true
+}
+
+// Update moves every entity with a parent to its place relative to the parent.
+func (ts *TransformSystem) Update(dt float32) {
+	ts.resolve()
+}
+
+// resolve moves the entities to their place in the world, parents before their children.
+func (ts *TransformSystem) resolve() {
+	ts.resolution++
+	for i := range ts.entities {
+		ts.resolveEntity(i)
+	}
+}
+
+// resolveEntity moves the entity at index i to its place in the world, after resolving its parent.
+func (ts *TransformSystem) resolveEntity(i int) {
+	e := &ts.entities[i]
+	if e.resolved == ts.resolution {
+		return
+	}
+	e.resolved = ts.resolution
+
+	scale := e.TransformComponent.scale()
+	if p, ok := ts.index[e.parent]; e.hasParent && ok {
+		ts.resolveEntity(p)
+		parent := ts.entities[p]
+		ps := parent.WorldScale()
+		x, y := e.TransformComponent.Position.X*ps.X, e.TransformComponent.Position.Y*ps.Y
+		sin, cos := math.Sincos(parent.SpaceComponent.Rotation * math.Pi / 180)
+		e.SpaceComponent.Position = engo.Point{
+			X: parent.SpaceComponent.Position.X + x*cos - y*sin,
+			Y: parent.SpaceComponent.Position.Y + x*sin + y*cos,
+		}
+		e.SpaceComponent.Rotation = parent.SpaceComponent.Rotation + e.TransformComponent.Rotation
+		scale = engo.Point{X: scale.X * ps.X, Y: scale.Y * ps.Y}
+	}
+	e.worldScale = scale
+
+	if e.RenderComponent != nil {
+		e.RenderComponent.Scale = scale
+		if e.RenderComponent.Drawable != nil {
+			e.SpaceComponent.Width = e.RenderComponent.Drawable.Width() * scale.X
+			e.SpaceComponent.Height = e.RenderComponent.Drawable.Height() * scale.Y
+		}
+	}
+}
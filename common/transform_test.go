@@ -0,0 +1,104 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+)
+
+type transformTestEntity struct {
+	ecs.BasicEntity
+	SpaceComponent
+	TransformComponent
+	RenderComponent
+}
+
+func transformPointsEqual(a, b engo.Point) bool {
+	return tweenValuesEqual(a.X, b.X) && tweenValuesEqual(a.Y, b.Y)
+}
+
+func TestTransformSystem(t *testing.T) {
+	w := &ecs.World{}
+	ts := &TransformSystem{}
+	var transformable *Transformable
+	var notTransformable *NotTransformable
+	w.AddSystemInterface(ts, transformable, notTransformable)
+
+	tank := &transformTestEntity{BasicEntity: ecs.NewBasic()}
+	tank.SpaceComponent.Position = engo.Point{X: 100, Y: 100}
+	turret := &transformTestEntity{BasicEntity: ecs.NewBasic()}
+	turret.SpaceComponent.Position = engo.Point{X: 110, Y: 100}
+	turret.RenderComponent.Drawable = Texture{width: 4, height: 2}
+	w.AddEntity(tank)
+	w.AddEntity(turret)
+
+	if !ts.SetParent(turret.BasicEntity, tank.BasicEntity) {
+		t.Fatal("turret was not attached to the tank")
+	}
+	if turret.TransformComponent.Position != (engo.Point{X: 10}) {
+		t.Errorf("turret did not keep its place when attached. Wanted: (10, 0), got: %v", turret.TransformComponent.Position)
+	}
+	if ts.SetParent(tank.BasicEntity, turret.BasicEntity) {
+		t.Error("tank was attached to its own child")
+	}
+
+	tank.SpaceComponent.Position = engo.Point{X: 200, Y: 100}
+	tank.SpaceComponent.Rotation = 90
+	tank.TransformComponent.Scale = engo.Point{X: 2, Y: 2}
+	w.Update(0)
+	if !transformPointsEqual(turret.SpaceComponent.Position, engo.Point{X: 200, Y: 120}) || turret.SpaceComponent.Rotation != 90 {
+		t.Errorf("turret did not move along with the tank, got: %v at %v degrees", turret.SpaceComponent.Position, turret.SpaceComponent.Rotation)
+	}
+	if turret.RenderComponent.Scale != (engo.Point{X: 2, Y: 2}) || turret.SpaceComponent.Width != 8 || turret.SpaceComponent.Height != 4 {
+		t.Errorf("turret was not scaled along with the tank, got scale %v and size %vx%v", turret.RenderComponent.Scale, turret.SpaceComponent.Width, turret.SpaceComponent.Height)
+	}
+	if p, _ := ts.WorldToLocal(tank.ID(), turret.SpaceComponent.Position); !transformPointsEqual(p, turret.TransformComponent.Position) {
+		t.Errorf("position of the turret was not translated into the space of the tank. Wanted: %v, got: %v", turret.TransformComponent.Position, p)
+	}
+
+	ts.Detach(turret.BasicEntity)
+	tank.SpaceComponent.Position = engo.Point{}
+	w.Update(0)
+	if !transformPointsEqual(turret.SpaceComponent.Position, engo.Point{X: 200, Y: 120}) {
+		t.Errorf("detached turret moved along with the tank, got: %v", turret.SpaceComponent.Position)
+	}
+	if turret.TransformComponent.Scale != (engo.Point{X: 2, Y: 2}) || turret.RenderComponent.Scale != (engo.Point{X: 2, Y: 2}) {
+		t.Errorf("detached turret did not keep its scale, got: %v", turret.TransformComponent.Scale)
+	}
+}
+
+func TestTransformSystemRemove(t *testing.T) {
+	ts := &TransformSystem{}
+	basics := []ecs.BasicEntity{ecs.NewBasic(), ecs.NewBasic(), ecs.NewBasic()}
+	spaces := []SpaceComponent{{}, {Position: engo.Point{X: 5}}, {Position: engo.Point{X: 5, Y: 5}}}
+	transforms := make([]TransformComponent, 3)
+	for i := range basics {
+		ts.Add(&basics[i], &spaces[i], &transforms[i], nil)
+	}
+	// A chain of three entities, in which the child was added before its parent
+	ts.SetParent(basics[1], basics[2])
+	ts.SetParent(basics[2], basics[0])
+
+	spaces[0].Position = engo.Point{X: 10}
+	ts.Update(0)
+	if spaces[1].Position != (engo.Point{X: 15}) {
+		t.Errorf("grandchild did not move along with the root, got: %v", spaces[1].Position)
+	}
+
+	ts.Remove(basics[2])
+	if _, ok := transforms[1].Parent(); ok {
+		t.Error("child of the removed entity was not detached")
+	}
+	if _, _, ok := ts.Get(basics[2].ID()); ok {
+		t.Error("removed entity was still in the system")
+	}
+	spaces[0].Position = engo.Point{}
+	ts.Update(0)
+	if spaces[1].Position != (engo.Point{X: 15}) {
+		t.Errorf("child of the removed entity did not keep its place, got: %v", spaces[1].Position)
+	}
+	if space, _, ok := ts.Get(basics[1].ID()); !ok || space != &spaces[1] {
+		t.Error("entity after the removed one was not found")
+	}
+}
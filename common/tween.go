@@ -0,0 +1,261 @@
+package common
+
+import (
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo/math"
+)
+
+// EaseFunc changes the rate at which a Tween progresses. Given the progress t of the tween between 0 and 1, it
+// returns the eased progress, which starts at 0 and ends at 1, but may go beyond those in between.
+type EaseFunc func(t float32) float32
+
+// EaseLinear progresses at a constant rate.
+func EaseLinear(t float32) float32 { return t }
+
+// EaseInQuad starts slow and speeds up.
+func EaseInQuad(t float32) float32 { return t * t }
+
+// EaseOutQuad starts fast and slows down.
+func EaseOutQuad(t float32) float32 { return t * (2 - t) }
+
+// EaseInOutQuad starts slow, speeds up, and slows down again.
+func EaseInOutQuad(t float32) float32 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return -1 + (4-2*t)*t
+}
+
+// EaseInCubic starts slow and speeds up, more so than EaseInQuad.
+func EaseInCubic(t float32) float32 { return t * t * t }
+
+// EaseOutCubic starts fast and slows down, more so than EaseOutQuad.
+func EaseOutCubic(t float32) float32 {
+	t--
+	return t*t*t + 1
+}
+
+// EaseInOutCubic starts slow, speeds up, and slows down again, more so than EaseInOutQuad.
+func EaseInOutCubic(t float32) float32 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	t = 2*t - 2
+	return t*t*t/2 + 1
+}
+
+// EaseInElastic winds up like a spring before shooting towards the end.
+func EaseInElastic(t float32) float32 {
+	return 1 - EaseOutElastic(1-t)
+}
+
+// EaseOutElastic shoots past the end, and springs back and forth until it settles.
+func EaseOutElastic(t float32) float32 {
+	if t <= 0 || t >= 1 {
+		return math.Clamp(t, 0, 1)
+	}
+	return math.Pow(2, -10*t)*math.Sin((t-0.075)*(2*math.Pi)/0.3) + 1
+}
+
+// EaseInBounce bounces off of the start a few times before heading towards the end.
+func EaseInBounce(t float32) float32 {
+	return 1 - EaseOutBounce(1-t)
+}
+
+// EaseOutBounce falls towards the end, and bounces off of it a few times until it settles.
+func EaseOutBounce(t float32) float32 {
+	const n, d = 7.5625, 2.75
+	switch {
+	case t < 1/d:
+		return n * t * t
+	case t < 2/d:
+		t -= 1.5 / d
+		return n*t*t + 0.75
+	case t < 2.5/d:
+		t -= 2.25 / d
+		return n*t*t + 0.9375
+	default:
+		t -= 2.625 / d
+		return n*t*t + 0.984375
+	}
+}
+
+// Tween animates a single float from one value to another over time, such as the position of a menu sliding in, or
+// the opacity of a fading sprite. It's created using NewTween, NewTweenFunc or NewFadeTween, and animated once added
+// to a TweenSystem.
+type Tween struct {
+	// Ease changes the rate at which the tween progresses, it's EaseLinear when nil
+	Ease EaseFunc
+	// Delay is the time in seconds before the tween starts, once it's added or the tween before it completed
+	Delay float32
+	// Loops is the amount of times the tween is repeated after it first ended. It loops forever when negative, in
+	// which case it never completes.
+	Loops int
+	// Yoyo plays every other loop in reverse, from the target value back to the starting value
+	Yoyo bool
+	// OnComplete is called once the tween completed, after all of its Loops
+	OnComplete func()
+
+	value       *float32
+	get         func() float32
+	set         func(float32)
+	from, to    float32
+	fromCurrent bool
+	duration    float32
+
+	next    *Tween
+	started bool
+	elapsed float32
+	loop    int
+}
+
+// NewTween returns a tween which animates value from the value it has when the tween starts to the given target
+// value, over duration seconds.
+func NewTween(value *float32, to, duration float32) *Tween {
+	return &Tween{value: value, to: to, fromCurrent: true, duration: duration}
+}
+
+// NewTweenFunc returns a tween which animates from one value to another over duration seconds, calling set with
+// the value every frame. It's useful for values which can't be pointed to, such as the zoom of a camera.
+func NewTweenFunc(set func(float32), from, to, duration float32) *Tween {
+	return &Tween{set: set, from: from, to: to, duration: duration}
+}
+
+// NewFadeTween returns a tween which fades the RenderComponent from the opacity it has when the tween starts to the
+// given opacity, over duration seconds. See `RenderComponent.SetOpacity`.
+func NewFadeTween(ren *RenderComponent, to, duration float32) *Tween {
+	return &Tween{get: ren.Opacity, set: ren.SetOpacity, to: to, fromCurrent: true, duration: duration}
+}
+
+// Then starts the next tween once t completed, and returns next, so a sequence of tweens is written as
+// `a.Then(b).Then(c)`. Any tween which was set to follow t before is replaced. Since a tween which loops forever
+// never completes, the tween following it never starts.
+func (t *Tween) Then(next *Tween) *Tween {
+	t.next = next
+	return next
+}
+
+// start resets the tween to its start, taking the value it's animating as the starting value if needed.
+func (t *Tween) start() {
+	t.started = true
+	t.elapsed = -t.Delay
+	t.loop = 0
+	if t.fromCurrent && t.get != nil {
+		t.from = t.get()
+	} else if t.fromCurrent && t.value != nil {
+		t.from = *t.value
+	}
+}
+
+// update progresses the tween by dt seconds, and returns whether it's still running.
+func (t *Tween) update(dt float32) bool {
+	if !t.started {
+		t.start()
+	}
+	if t.elapsed += dt; t.elapsed < 0 {
+		return true
+	}
+
+	looping := func() bool { return t.Loops < 0 || t.loop < t.Loops }
+	if t.duration > 0 {
+		for t.elapsed >= t.duration && looping() {
+			t.elapsed -= t.duration
+			t.loop++
+		}
+	}
+
+	progress := float32(1)
+	if t.duration > 0 {
+		progress = math.Min(t.elapsed/t.duration, 1)
+	}
+	if t.Yoyo && t.loop%2 == 1 {
+		progress = 1 - progress
+	}
+	t.apply(progress)
+
+	return t.elapsed < t.duration || looping()
+}
+
+// apply sets the value at the given progress, before easing.
+func (t *Tween) apply(progress float32) {
+	ease := t.Ease
+	if ease == nil {
+		ease = EaseLinear
+	}
+	v := t.from + (t.to-t.from)*ease(progress)
+	if t.set != nil {
+		t.set(v)
+	} else if t.value != nil {
+		*t.value = v
+	}
+}
+
+// TweenSystem animates all tweens added to it every frame, until they complete. Tweens aren't linked to entities,
+// so they have to be stopped using Stop if the value they animate goes away.
+type TweenSystem struct {
+	tweens []*Tween
+}
+
+// Add starts animating the tween, from its start. Once it completes, the tweens following it (see `Tween.Then`)
+// are animated one after another.
+func (ts *TweenSystem) Add(t *Tween) {
+	t.started = false
+	ts.tweens = append(ts.tweens, t)
+}
+
+// Stop stops animating the tween where it is, without completing it, so its OnComplete isn't called and the tweens
+// following it don't start. A tween which is waiting for the tween before it to complete is taken out of the
+// sequence, along with the tweens following it.
+func (ts *TweenSystem) Stop(t *Tween) {
+	for i, tween := range ts.tweens {
+		if tween == t {
+			ts.tweens = append(ts.tweens[:i], ts.tweens[i+1:]...)
+			return
+		}
+		for ; tween.next != nil; tween = tween.next {
+			if tween.next == t {
+				tween.next = nil
+				return
+			}
+		}
+	}
+}
+
+// Running returns whether the tween is being animated, or waiting for the tween before it to complete.
+func (ts *TweenSystem) Running(t *Tween) bool {
+	for _, tween := range ts.tweens {
+		for ; tween != nil; tween = tween.next {
+			if tween == t {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Remove does nothing, since tweens aren't linked to entities. It implements the ecs.System interface.
+func (*TweenSystem) Remove(ecs.BasicEntity) {}
+
+// Update progresses every tween by dt seconds. Tweens which completed are removed, and replaced by the tween
+// following them.
+func (ts *TweenSystem) Update(dt float32) {
+	for i := 0; i < len(ts.tweens); {
+		t := ts.tweens[i]
+		if t.update(dt) {
+			i++
+			continue
+		}
+
+		if t.next != nil {
+			ts.tweens[i] = t.next
+			t.next.started = false
+			i++
+		} else {
+			ts.tweens = append(ts.tweens[:i], ts.tweens[i+1:]...)
+		}
+		// The callback is called last, since it may add or stop tweens
+		if t.OnComplete != nil {
+			t.OnComplete()
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/EngoEngine/engo/math"
+)
+
+func tweenValuesEqual(a, b float32) bool {
+	return math.Abs(a-b) < 1e-4
+}
+
+func TestEaseFuncs(t *testing.T) {
+	funcs := map[string]EaseFunc{
+		"EaseLinear":     EaseLinear,
+		"EaseInQuad":     EaseInQuad,
+		"EaseOutQuad":    EaseOutQuad,
+		"EaseInOutQuad":  EaseInOutQuad,
+		"EaseInCubic":    EaseInCubic,
+		"EaseOutCubic":   EaseOutCubic,
+		"EaseInOutCubic": EaseInOutCubic,
+		"EaseInElastic":  EaseInElastic,
+		"EaseOutElastic": EaseOutElastic,
+		"EaseInBounce":   EaseInBounce,
+		"EaseOutBounce":  EaseOutBounce,
+	}
+	for name, ease := range funcs {
+		if start, end := ease(0), ease(1); !tweenValuesEqual(start, 0) || !tweenValuesEqual(end, 1) {
+			t.Errorf("%s does not go from 0 to 1, got: %v to %v", name, start, end)
+		}
+	}
+
+	if actual := EaseInQuad(0.5); actual != 0.25 {
+		t.Errorf("EaseInQuad is not quadratic. Wanted: 0.25, got: %v", actual)
+	}
+	if actual := EaseInOutCubic(0.5); actual != 0.5 {
+		t.Errorf("EaseInOutCubic is not halfway at half of the tween. Wanted: 0.5, got: %v", actual)
+	}
+	if EaseOutElastic(0.1) <= 1 {
+		t.Error("EaseOutElastic does not shoot past the end")
+	}
+}
+
+func TestTweenSystem(t *testing.T) {
+	ts := &TweenSystem{}
+	value := float32(10)
+	completed := 0
+
+	tween := NewTween(&value, 20, 1)
+	tween.OnComplete = func() { completed++ }
+	ts.Add(tween)
+	ts.Update(0.25)
+	if value != 12.5 {
+		t.Errorf("value was not tweened linearly. Wanted: 12.5, got: %v", value)
+	}
+	ts.Update(1)
+	if value != 20 || completed != 1 {
+		t.Errorf("tween did not complete at its target. Wanted: 20 completed once, got: %v completed %v times", value, completed)
+	}
+	if ts.Running(tween) {
+		t.Error("completed tween is still running")
+	}
+
+	// Tweens in a sequence start from the value at which the tween before them ended.
+	var set float32
+	first := NewTween(&value, 0, 1)
+	first.Ease = EaseInQuad
+	second := NewTweenFunc(func(v float32) { set = v }, 5, 15, 1)
+	second.Delay = 0.5
+	ts.Add(first)
+	first.Then(second)
+	ts.Update(0.5)
+	if value != 15 {
+		t.Errorf("tween was not eased. Wanted: 15, got: %v", value)
+	}
+	ts.Update(0.5)
+	if value != 0 || !ts.Running(second) {
+		t.Fatalf("sequence did not continue after the first tween, got: %v", value)
+	}
+	ts.Update(0.25)
+	if set != 0 {
+		t.Errorf("tween started before its Delay, set: %v", set)
+	}
+	ts.Update(0.75)
+	if set != 10 {
+		t.Errorf("setter was not called with the tweened value. Wanted: 10, got: %v", set)
+	}
+
+	ts.Stop(second)
+	ts.Update(1)
+	if set != 10 || ts.Running(second) {
+		t.Errorf("stopped tween kept running, set: %v", set)
+	}
+}
+
+func TestTweenLoops(t *testing.T) {
+	ts := &TweenSystem{}
+	value := float32(0)
+	completed := false
+
+	tween := NewTween(&value, 10, 1)
+	tween.Loops = 2
+	tween.Yoyo = true
+	tween.OnComplete = func() { completed = true }
+	ts.Add(tween)
+
+	ts.Update(1.25)
+	if !tweenValuesEqual(value, 7.5) {
+		t.Errorf("second loop was not played in reverse. Wanted: 7.5, got: %v", value)
+	}
+	ts.Update(1)
+	if !tweenValuesEqual(value, 2.5) || completed {
+		t.Errorf("tween did not loop again. Wanted: 2.5, got: %v completed: %v", value, completed)
+	}
+	ts.Update(1)
+	if value != 10 || !completed {
+		t.Errorf("tween did not complete after its loops. Wanted: 10, got: %v completed: %v", value, completed)
+	}
+
+	forever := NewTween(&value, 0, 1)
+	forever.Loops = -1
+	ts.Add(forever)
+	forever.Then(NewTween(&value, 100, 1))
+	for i := 0; i < 10; i++ {
+		ts.Update(0.75)
+	}
+	if !ts.Running(forever) || value > 10 {
+		t.Errorf("tween looping forever completed, value: %v", value)
+	}
+}
+
+func TestFadeTween(t *testing.T) {
+	ts := &TweenSystem{}
+	ren := &RenderComponent{}
+	ren.SetOpacity(0.5)
+
+	fade := NewFadeTween(ren, 1, 1)
+	fade.Then(NewFadeTween(ren, 0, 1))
+	ts.Add(fade)
+	ts.Update(0.5)
+	if !tweenValuesEqual(ren.Opacity(), 0.75) {
+		t.Errorf("fade did not start at the current opacity. Wanted: 0.75, got: %v", ren.Opacity())
+	}
+	ts.Update(0.5)
+	ts.Update(0.25)
+	if !tweenValuesEqual(ren.Opacity(), 0.75) {
+		t.Errorf("second fade did not start at the opacity the first ended. Wanted: 0.75, got: %v", ren.Opacity())
+	}
+}
@@ -1,7 +1,12 @@
 package engo
 
+import (
+	"image"
+	"math"
+)
+
 // Cursor is a reference to standard cursors, to be used in conjunction with `SetCursor`. What they look like, is
-// different for each platform.
+// different for each platform. Custom cursors are created from an image using `CreateCursor`.
 type Cursor uint8
 
 const (
@@ -20,3 +25,60 @@ const (
 	// CursorVResize represents a VResize cursor
 	CursorVResize
 )
+
+// cursorCustom is the first Cursor returned by CreateCursor.
+const cursorCustom = CursorVResize + 1
+
+// customCursorCount is the amount of custom cursors created so far.
+var customCursorCount int
+
+// newCustomCursor is used by the backends to check the image and hotspot passed to CreateCursor, and to retrieve the
+// Cursor which refers to the new custom cursor. It returns false if no cursor can be created, in which case
+// CreateCursor falls back to CursorArrow.
+func newCustomCursor(img image.Image, hotX, hotY int) (Cursor, bool) {
+	if img == nil {
+		return CursorArrow, false
+	}
+	b := img.Bounds()
+	if b.Empty() || hotX < 0 || hotY < 0 || hotX >= b.Dx() || hotY >= b.Dy() {
+		warning("the hotspot of a cursor has to be within its image")
+		return CursorArrow, false
+	}
+	if int(cursorCustom)+customCursorCount > math.MaxUint8 {
+		warning("too many custom cursors were created")
+		return CursorArrow, false
+	}
+	c := cursorCustom + Cursor(customCursorCount)
+	customCursorCount++
+	return c, true
+}
+
+var (
+	// cursorGrabbed indicates whether the cursor is grabbed using SetCursorGrabbed
+	cursorGrabbed bool
+	// grabbedAt is the position of the mouse when the cursor was grabbed, to which it's returned once released
+	grabbedAt Point
+)
+
+// CursorGrabbed returns whether the cursor is currently grabbed using SetCursorGrabbed.
+func CursorGrabbed() bool {
+	return cursorGrabbed
+}
+
+// grabCursor is used by the backends in SetCursorGrabbed to keep track of whether the cursor is grabbed, and returns
+// false if it already was in the requested mode. While grabbed, the backends only report the movement of the mouse
+// in Mouse.DeltaX and Mouse.DeltaY, so the position of the mouse stays where it was grabbed. It's restored to that
+// position once released, for when it was changed in the meantime.
+func grabCursor(grabbed bool) bool {
+	if grabbed == cursorGrabbed {
+		return false
+	}
+	cursorGrabbed = grabbed
+	if grabbed {
+		grabbedAt = Point{X: Input.Mouse.X, Y: Input.Mouse.Y}
+	} else {
+		Input.Mouse.X, Input.Mouse.Y = grabbedAt.X, grabbedAt.Y
+		Input.updateMouse()
+	}
+	return true
+}
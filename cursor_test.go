@@ -0,0 +1,62 @@
+package engo
+
+import (
+	"image"
+	"testing"
+)
+
+func TestCreateCursor(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 16, 16))
+
+	crosshair := CreateCursor(img, 8, 8)
+	if crosshair <= CursorVResize {
+		t.Errorf("custom cursor overlaps the standard cursors, got: %v", crosshair)
+	}
+	if pointer := CreateCursor(img, 0, 0); pointer == crosshair || pointer <= CursorVResize {
+		t.Errorf("custom cursors are not distinct. Got %v and %v", crosshair, pointer)
+	}
+
+	if c := CreateCursor(img, 16, 8); c != CursorArrow {
+		t.Errorf("cursor with its hotspot outside of the image did not fall back to the arrow cursor, got: %v", c)
+	}
+	if c := CreateCursor(image.NewNRGBA(image.Rect(0, 0, 0, 0)), 0, 0); c != CursorArrow {
+		t.Errorf("cursor without an image did not fall back to the arrow cursor, got: %v", c)
+	}
+}
+
+func TestSetCursorGrabbed(t *testing.T) {
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        100,
+		Height:       50,
+	}, &inputTestScene{})
+	defer SetCursorGrabbed(false)
+
+	Input.Mouse.X, Input.Mouse.Y = 40, 20
+	SetCursorGrabbed(true)
+	if !CursorGrabbed() {
+		t.Fatal("cursor was not grabbed")
+	}
+
+	// Grabbing it again does not change where it was grabbed.
+	Input.Mouse.X, Input.Mouse.Y = 90, 45
+	SetCursorGrabbed(true)
+	Input.Mouse.X, Input.Mouse.Y = -10, 80
+	Input.updateMouse()
+
+	SetCursorGrabbed(false)
+	if CursorGrabbed() {
+		t.Error("cursor was not released")
+	}
+	if Input.Mouse.X != 40 || Input.Mouse.Y != 20 || !Input.Mouse.Inside {
+		t.Errorf("releasing the cursor did not return the mouse to where it was grabbed. Wanted: (40, 20), got: (%v, %v)", Input.Mouse.X, Input.Mouse.Y)
+	}
+
+	// Releasing it again does nothing.
+	Input.Mouse.X = 60
+	SetCursorGrabbed(false)
+	if Input.Mouse.X != 60 {
+		t.Errorf("releasing a cursor which was not grabbed moved the mouse to %v", Input.Mouse.X)
+	}
+}
@@ -31,7 +31,7 @@ func (*DefaultScene) Setup(u engo.Updater) {
 	w.AddSystem(&common.RenderSystem{})
 
 	// The most important line in this whole demo:
-	w.AddSystem(&common.EdgeScroller{scrollSpeed, edgeMargin})
+	w.AddSystem(&common.EdgeScroller{ScrollSpeed: scrollSpeed, EdgeMargin: edgeMargin})
 
 	// Create the background; this way we'll see when we actually scroll
 	demoutils.NewBackground(w, worldWidth, worldHeight, color.RGBA{102, 153, 0, 255}, color.RGBA{102, 173, 0, 255})
@@ -69,11 +69,48 @@ func (*DefaultScene) Setup(u engo.Updater) {
 		LetterSpacing: 0.15,
 	}
 
+	small := &common.Font{
+		URL:  "Roboto-Regular.ttf",
+		FG:   color.Black,
+		Size: 32,
+	}
+	err = small.CreatePreloaded()
+	if err != nil {
+		panic(err)
+	}
+
+	// The tint of a run is multiplied with the color of its font, so runs drawn in a color use a white font
+	white := &common.Font{
+		URL:  "Roboto-Regular.ttf",
+		FG:   color.White,
+		Size: 64,
+	}
+	err = white.CreatePreloaded()
+	if err != nil {
+		panic(err)
+	}
+
+	// Runs draw parts of a single Text using their own font and color
+	label3 := MyLabel{BasicEntity: ecs.NewBasic()}
+	label3.RenderComponent.Drawable = common.Text{
+		Font: fnt,
+		Runs: []common.TextRun{
+			{Text: "Runs can be "},
+			{Text: "red", Font: white, Color: color.RGBA{255, 0, 0, 255}},
+			{Text: " or ", Font: small},
+			{Text: "blue", Font: white, Color: color.RGBA{0, 0, 255, 255}},
+		},
+		MaxWidth: 500,
+		Wrap:     true,
+	}
+	label3.SpaceComponent.Position = engo.Point{X: 0, Y: 300}
+
 	for _, system := range w.Systems() {
 		switch sys := system.(type) {
 		case *common.RenderSystem:
 			sys.Add(&label1.BasicEntity, &label1.RenderComponent, &label1.SpaceComponent)
 			sys.Add(&label2.BasicEntity, &label2.RenderComponent, &label2.SpaceComponent)
+			sys.Add(&label3.BasicEntity, &label3.RenderComponent, &label3.SpaceComponent)
 		}
 	}
 }
@@ -0,0 +1,132 @@
+//+build demo
+
+package main
+
+import (
+	"image/color"
+	"log"
+
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo"
+	"github.com/EngoEngine/engo/common"
+)
+
+// flashDuration is the time, in seconds, it takes for a flash to fade back to white
+const flashDuration = 0.5
+
+type DefaultScene struct{}
+
+type Rock struct {
+	ecs.BasicEntity
+	common.RenderComponent
+	common.SpaceComponent
+}
+
+func (*DefaultScene) Preload() {
+	err := engo.Files.Load("rock.png")
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (*DefaultScene) Setup(u engo.Updater) {
+	w, _ := u.(*ecs.World)
+
+	common.SetBackground(color.White)
+	engo.Input.RegisterButton("flash", engo.KeySpace)
+
+	w.AddSystem(&common.RenderSystem{})
+	w.AddSystem(&FlashSystem{})
+
+	// Retrieve a texture
+	texture, err := common.LoadedSprite("rock.png")
+	if err != nil {
+		log.Println(err)
+	}
+
+	// Create an entity
+	rock := Rock{BasicEntity: ecs.NewBasic()}
+
+	// Initialize the components, set scale to 8x. A white Color draws the texture as it is.
+	rock.RenderComponent = common.RenderComponent{
+		Drawable: texture,
+		Scale:    engo.Point{X: 8, Y: 8},
+		Color:    color.White,
+	}
+	rock.SpaceComponent = common.SpaceComponent{
+		Position: engo.Point{X: 0, Y: 0},
+		Width:    texture.Width() * rock.RenderComponent.Scale.X,
+		Height:   texture.Height() * rock.RenderComponent.Scale.Y,
+	}
+
+	// Add it to appropriate systems
+	for _, system := range w.Systems() {
+		switch sys := system.(type) {
+		case *common.RenderSystem:
+			sys.Add(&rock.BasicEntity, &rock.RenderComponent, &rock.SpaceComponent)
+		case *FlashSystem:
+			sys.Add(&rock.BasicEntity, &rock.RenderComponent)
+		}
+	}
+}
+
+func (*DefaultScene) Type() string { return "GameWorld" }
+
+type flashEntity struct {
+	*ecs.BasicEntity
+	*common.RenderComponent
+	// remaining is the time left before the flash has faded
+	remaining float32
+}
+
+// FlashSystem tints its entities red whenever space is pressed, and then fades them back to white.
+type FlashSystem struct {
+	entities []flashEntity
+}
+
+func (f *FlashSystem) Add(basic *ecs.BasicEntity, render *common.RenderComponent) {
+	f.entities = append(f.entities, flashEntity{basic, render, 0})
+}
+
+func (f *FlashSystem) Remove(basic ecs.BasicEntity) {
+	delete := -1
+	for index, e := range f.entities {
+		if e.BasicEntity.ID() == basic.ID() {
+			delete = index
+			break
+		}
+	}
+	if delete >= 0 {
+		f.entities = append(f.entities[:delete], f.entities[delete+1:]...)
+	}
+}
+
+func (f *FlashSystem) Update(dt float32) {
+	flash := engo.Input.Button("flash").JustPressed()
+	for i := range f.entities {
+		e := &f.entities[i]
+		if flash {
+			e.remaining = flashDuration
+		}
+		if e.remaining <= 0 {
+			continue
+		}
+
+		e.remaining -= dt
+		if e.remaining < 0 {
+			e.remaining = 0
+		}
+		// Only the green and blue components are faded, from none back to full
+		gb := uint8(255 * (1 - e.remaining/flashDuration))
+		e.RenderComponent.Color = color.NRGBA{R: 255, G: gb, B: gb, A: 255}
+	}
+}
+
+func main() {
+	opts := engo.RunOptions{
+		Title:  "Tint Demo",
+		Width:  1024,
+		Height: 640,
+	}
+	engo.Run(opts, &DefaultScene{})
+}
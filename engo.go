@@ -81,7 +81,9 @@ type RunOptions struct {
 	// Title is the Window title
 	Title string
 
-	// HeadlessMode indicates whether or not OpenGL calls should be made
+	// HeadlessMode indicates whether or not OpenGL calls should be made. Systems can be tested headless without a window
+	// by setting NoRun as well, and updating the scene frame by frame using `Step`, which also lists the parts of the
+	// engine which are unavailable headless.
 	HeadlessMode bool
 
 	// Fullscreen indicates the game should run in fullscreen mode if run on a desktop
@@ -96,7 +98,14 @@ type RunOptions struct {
 	// However, custom systems should be aware of this if this is set.
 	GlobalScale Point
 
-	// VSync indicates whether or not OpenGL should wait for the monitor to swp the buffers
+	// RenderScale is the resolution at which the RenderSystem draws, relative to the size of the window. Values below
+	// 1 trade sharpness for performance on slower GPUs, since the scene is drawn at a lower resolution and then
+	// stretched over the window. Leaving it at zero defaults to 1.
+	RenderScale float32
+
+	// VSync indicates whether or not OpenGL should wait for the monitor to swap the buffers, which prevents tearing.
+	// Frames are then drawn at the lower of the refresh rate of the monitor and FPSLimit. It can be changed later on
+	// using `SetVSync`.
 	VSync bool
 
 	// Resizable indicates whether or not the Window should be resizable.  Defaults to `true`.
@@ -105,13 +114,26 @@ type RunOptions struct {
 	// ScaleOnResize indicates whether or not engo should make things larger/smaller whenever the screen resizes
 	ScaleOnResize bool
 
-	// FPSLimit indicates the maximum number of frames per second
+	// ScaleMode is how the game is scaled to the window while ScaleOnResize is set, whenever the aspect ratio of the
+	// window differs from that of the game. It defaults to ScaleStretch; use ScaleFit for letterboxing.
+	ScaleMode ScaleMode
+
+	// FPSLimit indicates the maximum number of frames per second, so the game doesn't use more of the CPU than it
+	// needs. The game loop waits until the next frame is due, on a steady ticker, so the delta time stays stable even
+	// without VSync. It defaults to 60, and can be changed later on using `SetFPSLimit`.
+	//
+	// Systems on a fixed timestep (see `SetSystemTimestep`) are updated at their own rate regardless of the frame rate,
+	// as many times per frame as needed, up to their maximum amount of steps.
 	FPSLimit int
 
 	// OverrideCloseAction indicates that (when true) engo will never close whenever the gamer wants to close the
 	// game - that will be your responsibility
 	OverrideCloseAction bool
 
+	// ClampMouse indicates whether or not the position of the mouse should be clamped to the game area, whenever
+	// the cursor is outside of it (such as over the window decorations on some platforms).
+	ClampMouse bool
+
 	// StandardInputs is an easy way to map common inputs to actions, such as "jump" being <SPACE>, and "action" being
 	// <ENTER>.
 	StandardInputs bool
@@ -181,18 +203,27 @@ func Run(o RunOptions, defaultScene Scene) {
 		o.GlobalScale = Point{X: 1, Y: 1}
 	}
 
+	if o.RenderScale <= 0 {
+		o.RenderScale = 1
+	}
+
 	opts = o
 
 	// Create input
 	Input = NewInputManager()
+	focused, headlessFocused = true, true
+	contentScale = 1
+	ResizeXOffset, ResizeYOffset = 0, 0
+	timeScale = 1
 	if opts.StandardInputs {
 		log.Println("Using standard inputs")
 
 		Input.RegisterButton("jump", KeySpace)
 		Input.RegisterButton("action", KeyEnter)
 
-		Input.RegisterAxis(DefaultHorizontalAxis, AxisKeyPair{KeyA, KeyD}, AxisKeyPair{KeyArrowLeft, KeyArrowRight})
-		Input.RegisterAxis(DefaultVerticalAxis, AxisKeyPair{KeyW, KeyS}, AxisKeyPair{KeyArrowUp, KeyArrowDown})
+		// WASD is bound by position, so it works the same regardless of the keyboard layout
+		Input.RegisterAxis(DefaultHorizontalAxis, AxisPhysicalKeyPair{KeyA, KeyD}, AxisKeyPair{KeyArrowLeft, KeyArrowRight})
+		Input.RegisterAxis(DefaultVerticalAxis, AxisPhysicalKeyPair{KeyW, KeyS}, AxisKeyPair{KeyArrowUp, KeyArrowDown})
 
 		Input.RegisterAxis(DefaultMouseXAxis, NewAxisMouse(AxisMouseHori))
 		Input.RegisterAxis(DefaultMouseYAxis, NewAxisMouse(AxisMouseVert))
@@ -241,13 +272,22 @@ func SetOverrideCloseAction(value bool) {
 	opts.OverrideCloseAction = value
 }
 
+// SetClampMouse can be used to change the value in the given `RunOpts` after already having called `engo.Run`.
+func SetClampMouse(clamp bool) {
+	opts.ClampMouse = clamp
+}
+
 // SetFPSLimit can be used to change the value in the given `RunOpts` after already having called `engo.Run`.
 func SetFPSLimit(limit int) error {
 	if limit <= 0 {
 		return fmt.Errorf("FPS Limit out of bounds. Requires > 0")
 	}
 	opts.FPSLimit = limit
-	resetLoopTicker <- true
+	select {
+	case resetLoopTicker <- true:
+	default:
+		// The game loop is going to reset its ticker already
+	}
 	return nil
 }
 
@@ -268,6 +308,7 @@ func Exit() {
 	closeGameOnce.Do(func() {
 		close(closeGame)
 	})
+	teardownScenes()
 }
 
 // GameWidth returns the current game width
@@ -316,6 +357,22 @@ func SetGlobalScale(p Point) {
 	opts.GlobalScale = p
 }
 
+// GetRenderScale returns the RenderScale set in the RunOptions or via SetRenderScale()
+func GetRenderScale() float32 {
+	return opts.RenderScale
+}
+
+// SetRenderScale sets the resolution at which the RenderSystem draws, relative to the size of the window. It does
+// not affect the coordinates of the mouse, which are always relative to the window. If scale is less than or equal
+// to zero, the RenderScale is set to 1.
+func SetRenderScale(scale float32) {
+	if scale <= 0 {
+		opts.RenderScale = 1
+		return
+	}
+	opts.RenderScale = scale
+}
+
 // GetTitle returns the title of the game.
 func GetTitle() string {
 	return opts.Title
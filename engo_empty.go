@@ -3,12 +3,12 @@
 package engo
 
 import (
+	"image"
 	"io"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"github.com/EngoEngine/gl"
 )
@@ -17,7 +17,6 @@ var (
 	// Gl is the current OpenGL context
 	Gl *gl.Context
 
-	scale = float32(1)
 )
 
 // CreateWindow sets up the GLFW window and prepares the OpenGL surface for rendering
@@ -30,7 +29,7 @@ func CreateWindow(title string, width, height int, fullscreen bool, msaa int) {
 	Gl = gl.NewContext()
 
 	windowWidth, windowHeight = float32(width), float32(height)
-	canvasWidth, canvasHeight = float32(width), float32(height)
+	canvasWidth, canvasHeight = float32(width)*contentScale, float32(height)*contentScale
 }
 
 // DestroyWindow handles the termination of windows
@@ -41,10 +40,22 @@ func SetTitle(title string) {
 	log.Println("Title set to:", title)
 }
 
+// ClipboardText returns the text on the clipboard. Since there's no system clipboard while running headless, it only
+// holds the text set using SetClipboardText.
+func ClipboardText() string {
+	return clipboard
+}
+
+// SetClipboardText puts the given text on the clipboard, see ClipboardText.
+func SetClipboardText(text string) {
+	clipboard = text
+}
+
 // RunIteration runs one iteration per frame
 func RunIteration() {
 	Time.Tick()
-	currentUpdater.Update(Time.Delta())
+	Input.consumeEvents()
+	updateScene(Time.Delta())
 }
 
 // RunPreparation is called automatically when calling Open. It should only be called once.
@@ -63,7 +74,7 @@ func runLoop(defaultScene Scene, headless bool) {
 	}()
 
 	RunPreparation(defaultScene)
-	ticker := time.NewTicker(time.Duration(int(time.Second) / opts.FPSLimit))
+	ticker := newFrameTicker()
 
 	// Start tick, minimize the delta
 	Time.Tick()
@@ -74,9 +85,11 @@ func runLoop(defaultScene Scene, headless bool) {
 			RunIteration()
 		case <-resetLoopTicker:
 			ticker.Stop()
-			ticker = time.NewTicker(time.Duration(int(time.Second) / opts.FPSLimit))
+			ticker = newFrameTicker()
 		case <-c:
-			fallthrough
+			ticker.Stop()
+			closeEvent()
+			return
 		case <-closeGame:
 			ticker.Stop()
 			closeEvent()
@@ -90,6 +103,11 @@ func CursorPos() (x, y float32) {
 	return float32(0), float32(0)
 }
 
+// WindowFocused returns true, unless the headless window lost focus using SetWindowFocused
+func WindowFocused() bool {
+	return headlessFocused
+}
+
 // WindowSize gets the current window size
 func WindowSize() (w, h int) {
 	return int(windowWidth), int(windowHeight)
@@ -117,7 +135,12 @@ func CanvasHeight() float32 {
 
 // CanvasScale gets the ratio of the canvas to the window sizes
 func CanvasScale() float32 {
-	return scale
+	return contentScale
+}
+
+// ContentScale returns the content scale of the headless display, which is 1 unless it's set using SetContentScale.
+func ContentScale() float32 {
+	return contentScale
 }
 
 // SetCursor does nothing since there's no headless cursor
@@ -126,14 +149,37 @@ func SetCursor(c Cursor) {}
 // SetVSync does nothing since there's no monitor to synchronize with
 func SetVSync(enabled bool) {}
 
+// SetFullscreen switches the headless window to or from fullscreen, resizing it to the default headless size and
+// back, the same way a real window is resized to its monitor
+func SetFullscreen(fullscreen bool) {
+	setHeadlessFullscreen(fullscreen)
+}
+
+// SetWindowSize resizes the headless window, the same way a real window is resized
+func SetWindowSize(width, height int) {
+	setHeadlessWindowSize(width, height)
+}
+
 //SetCursorVisibility does nothing since there's no headless cursor
 func SetCursorVisibility(visible bool) {}
 
+// SetCursorGrabbed only keeps track of whether the cursor is grabbed, since there's no headless cursor
+func SetCursorGrabbed(grabbed bool) {
+	grabCursor(grabbed)
+}
+
 // openFile is the desktop-specific way of opening a file
 func openFile(url string) (io.ReadCloser, error) {
 	return os.Open(url)
 }
 
+// CreateCursor returns a Cursor for the given image without creating anything, since there's no headless cursor. As
+// on the other platforms, CursorArrow is returned if the hotspot is not within the image.
+func CreateCursor(img image.Image, hotX, hotY int) Cursor {
+	c, _ := newCustomCursor(img, hotX, hotY)
+	return c
+}
+
 // IsAndroidChrome tells if the browser is Chrome for android
 func IsAndroidChrome() bool {
 	return false
@@ -4,13 +4,13 @@
 package engo
 
 import (
+	"image"
 	"io"
 	"log"
 	"os"
 	"os/signal"
 	"runtime"
 	"syscall"
-	"time"
 
 	"github.com/EngoEngine/gl"
 	"github.com/go-gl/glfw/v3.3/glfw"
@@ -28,6 +28,10 @@ var (
 	cursorHand      *glfw.Cursor
 	cursorHResize   *glfw.Cursor
 	cursorVResize   *glfw.Cursor
+	// customCursors contains the cursors created using CreateCursor
+	customCursors = make(map[Cursor]*glfw.Cursor)
+	// cursorX and cursorY are the position of the cursor reported last, used for the movement of the mouse
+	cursorX, cursorY float64
 
 	scale = float32(1)
 )
@@ -70,6 +74,10 @@ func CreateWindow(title string, width, height int, fullscreen bool, msaa int) {
 		}
 	}
 
+	// The window returns to this size and position once it leaves fullscreen, see SetFullscreen
+	windowedWidth, windowedHeight = width, height
+	windowedX, windowedY = (mode.Width-width)/2, (mode.Height-height)/2
+
 	if fullscreen {
 		width = mode.Width
 		height = mode.Height
@@ -125,81 +133,84 @@ func CreateWindow(title string, width, height int, fullscreen bool, msaa int) {
 
 	Window.SetFramebufferSizeCallback(func(Window *glfw.Window, w, h int) {
 		Gl.Viewport(0, 0, w, h)
-		width, height = Window.GetSize()
-		windowWidth, windowHeight = float32(width), float32(width)
-
-		oldCanvasW, oldCanvasH := canvasWidth, canvasHeight
-
-		canvasWidth, canvasHeight = float32(w), float32(h)
-
-		ResizeXOffset += oldCanvasW - canvasWidth
-		ResizeYOffset += oldCanvasH - canvasHeight
-
-		if windowWidth <= canvasWidth && windowHeight <= canvasHeight {
-			scale = canvasWidth / windowWidth
-		}
+		windowResized()
 	})
 
+	cursorX, cursorY = Window.GetCursorPos()
 	Window.SetCursorPosCallback(func(Window *glfw.Window, x, y float64) {
+		if Input.realInputDisabled {
+			cursorX, cursorY = x, y
+			return
+		}
+		Input.Mouse.DeltaX += float32(x-cursorX) / opts.GlobalScale.X
+		Input.Mouse.DeltaY += float32(y-cursorY) / opts.GlobalScale.Y
+		cursorX, cursorY = x, y
+		if cursorGrabbed {
+			return // the position of a grabbed cursor is meaningless
+		}
+
 		Input.Mouse.X, Input.Mouse.Y = float32(x)/opts.GlobalScale.X, float32(y)/opts.GlobalScale.Y
+		Input.updateMouse()
 		if Input.Mouse.Action != Release && Input.Mouse.Action != Press {
 			Input.Mouse.Action = Move
 		}
 	})
 
 	Window.SetMouseButtonCallback(func(Window *glfw.Window, b glfw.MouseButton, a glfw.Action, m glfw.ModifierKey) {
-		x, y := Window.GetCursorPos()
-		Input.Mouse.X, Input.Mouse.Y = float32(x)/(opts.GlobalScale.X), float32(y)/(opts.GlobalScale.Y)
+		if Input.realInputDisabled {
+			return
+		}
+		if !cursorGrabbed {
+			x, y := Window.GetCursorPos()
+			Input.Mouse.X, Input.Mouse.Y = float32(x)/(opts.GlobalScale.X), float32(y)/(opts.GlobalScale.Y)
+			Input.updateMouse()
+		}
 
 		// this is only valid because we use an internal structure that is
 		// 100% compatible with glfw3.h
 		Input.Mouse.Button = MouseButton(b)
 		Input.Mouse.Modifer = Modifier(m)
+		Input.Mouse.setButton(MouseButton(b), a == glfw.Press)
 
 		if a == glfw.Press {
 			Input.Mouse.Action = Press
 		} else {
 			Input.Mouse.Action = Release
 		}
+		Input.Mouse.addEvent()
 	})
 
 	Window.SetScrollCallback(func(Window *glfw.Window, xoff, yoff float64) {
+		if Input.realInputDisabled {
+			return
+		}
 		Input.Mouse.ScrollX = float32(xoff)
 		Input.Mouse.ScrollY = float32(yoff)
 	})
 
 	Window.SetKeyCallback(func(Window *glfw.Window, k glfw.Key, s int, a glfw.Action, m glfw.ModifierKey) {
-		key := Key(k)
+		if Input.realInputDisabled {
+			return
+		}
+		// GLFW names its keys after their position on a US keyboard layout, which makes them physical keys
+		key, physical := layoutKey(k, s), Key(k)
 		if a == glfw.Press {
 			Input.keys.Set(key, true)
+			Input.keys.SetPhysical(physical, true)
 		} else if a == glfw.Release {
 			Input.keys.Set(key, false)
+			Input.keys.SetPhysical(physical, false)
 		}
 	})
 
 	Window.SetSizeCallback(func(w *glfw.Window, widthInt int, heightInt int) {
-		message := WindowResizeMessage{
-			OldWidth:  int(windowWidth),
-			OldHeight: int(windowHeight),
-			NewWidth:  widthInt,
-			NewHeight: heightInt,
-		}
-
-		windowWidth = float32(widthInt)
-		windowHeight = float32(heightInt)
-
-		// TODO: verify these for retina displays & verify if needed here
-		fw, fh := Window.GetFramebufferSize()
-		canvasWidth, canvasHeight = float32(fw), float32(fh)
-
-		if !opts.ScaleOnResize {
-			gameWidth, gameHeight = float32(widthInt), float32(heightInt)
-		}
-
-		Mailbox.Dispatch(message)
+		windowResized()
 	})
 
 	Window.SetCharCallback(func(Window *glfw.Window, char rune) {
+		if Input.realInputDisabled || Input.ignored {
+			return
+		}
 		Mailbox.Dispatch(TextMessage{char})
 	})
 
@@ -208,6 +219,140 @@ func CreateWindow(title string, width, height int, fullscreen bool, msaa int) {
 	})
 }
 
+// windowResized updates the sizes of the window, canvas and game after either the window or its framebuffer was
+// resized, and dispatches a WindowResizeMessage whenever any of them changed. GLFW usually reports both right after
+// one another, in which case the message is only dispatched once.
+func windowResized() {
+	width, height := Window.GetSize()
+	fw, fh := Window.GetFramebufferSize()
+	message := WindowResizeMessage{
+		OldWidth:        int(windowWidth),
+		OldHeight:       int(windowHeight),
+		NewWidth:        width,
+		NewHeight:       height,
+		OldCanvasWidth:  int(canvasWidth),
+		OldCanvasHeight: int(canvasHeight),
+		NewCanvasWidth:  fw,
+		NewCanvasHeight: fh,
+	}
+	if message.OldWidth == width && message.OldHeight == height && message.OldCanvasWidth == fw && message.OldCanvasHeight == fh {
+		return
+	}
+
+	windowWidth, windowHeight = float32(width), float32(height)
+
+	ResizeXOffset += canvasWidth - float32(fw)
+	ResizeYOffset += canvasHeight - float32(fh)
+	canvasWidth, canvasHeight = float32(fw), float32(fh)
+
+	if windowWidth <= canvasWidth && windowHeight <= canvasHeight {
+		scale = canvasWidth / windowWidth
+	}
+
+	if !opts.ScaleOnResize {
+		gameWidth, gameHeight = float32(width), float32(height)
+	}
+
+	// The cursor didn't move, but whether it's inside the window, and where it's clamped to, may have changed
+	if !cursorGrabbed {
+		Input.updateMouse()
+	}
+
+	Mailbox.Dispatch(message)
+}
+
+// windowedX and windowedY are the position the window is restored to once it leaves fullscreen.
+var windowedX, windowedY int
+
+// SetFullscreen switches the window to or from fullscreen mode while the game is running, such as from a settings
+// menu. The window goes fullscreen on the monitor it's mostly on, at the current video mode of that monitor, and
+// returns to the size and position it had before once it leaves fullscreen. The sizes of the window, canvas and game
+// are updated and a WindowResizeMessage is dispatched right away, so the camera and the MouseSystem are accurate from
+// the next frame on.
+func SetFullscreen(fullscreen bool) {
+	if opts.HeadlessMode {
+		setHeadlessFullscreen(fullscreen)
+		return
+	}
+	if fullscreen == opts.Fullscreen {
+		return
+	}
+
+	if fullscreen {
+		monitor := windowMonitor()
+		if monitor == nil {
+			warning("unable to switch to fullscreen, no monitor was found")
+			return
+		}
+		windowedX, windowedY = Window.GetPos()
+		windowedWidth, windowedHeight = Window.GetSize()
+		mode := monitor.GetVideoMode()
+		Window.SetMonitor(monitor, 0, 0, mode.Width, mode.Height, mode.RefreshRate)
+	} else {
+		if windowedWidth <= 0 || windowedHeight <= 0 {
+			windowedWidth, windowedHeight = headlessWidth, headlessHeight
+		}
+		Window.SetMonitor(nil, windowedX, windowedY, windowedWidth, windowedHeight, glfw.DontCare)
+		// A window which was created in fullscreen mode was created without decorations
+		Window.SetAttrib(glfw.Decorated, glfw.True)
+	}
+	opts.Fullscreen = fullscreen
+
+	// Switching monitors may reset the swap interval
+	SetVSync(opts.VSync)
+	resizeViewport()
+}
+
+// SetWindowSize resizes the window while the game is running, to the given size in screen coordinates. The sizes of
+// the window, canvas and game are updated and a WindowResizeMessage is dispatched right away, although some window
+// managers take another moment to resize the window, in which case this happens once they did. While the window is
+// fullscreen, it's resized once it leaves fullscreen instead.
+func SetWindowSize(width, height int) {
+	if opts.HeadlessMode {
+		setHeadlessWindowSize(width, height)
+		return
+	}
+	if !validWindowSize(width, height) {
+		return
+	}
+	if opts.Fullscreen {
+		windowedWidth, windowedHeight = width, height
+		return
+	}
+	Window.SetSize(width, height)
+	resizeViewport()
+}
+
+// resizeViewport sets the OpenGL viewport to the framebuffer after the window was resized by the game itself, rather
+// than waiting for the callbacks during the next frame. The callbacks then find the sizes unchanged, so the
+// WindowResizeMessage is only dispatched once.
+func resizeViewport() {
+	fw, fh := Window.GetFramebufferSize()
+	Gl.Viewport(0, 0, fw, fh)
+	windowResized()
+}
+
+// windowMonitor returns the monitor which the largest part of the window is on, or the primary monitor if the window
+// isn't on any of them.
+func windowMonitor() *glfw.Monitor {
+	x, y := Window.GetPos()
+	w, h := Window.GetSize()
+
+	best, bestArea := glfw.GetPrimaryMonitor(), 0
+	for _, monitor := range glfw.GetMonitors() {
+		mode := monitor.GetVideoMode()
+		if mode == nil {
+			continue
+		}
+		mx, my := monitor.GetPos()
+		area := overlap(x, x+w, mx, mx+mode.Width) * overlap(y, y+h, my, my+mode.Height)
+		if area > bestArea {
+			best, bestArea = monitor, area
+		}
+	}
+	return best
+}
+
 // DestroyWindow handles the termination of windows
 func DestroyWindow() {
 	glfw.Terminate()
@@ -222,6 +367,37 @@ func SetTitle(title string) {
 	}
 }
 
+// ClipboardText returns the text on the clipboard of the system, or an empty string when it holds something else,
+// such as an image. While running headless, the clipboard only holds the text set using SetClipboardText.
+func ClipboardText() string {
+	if opts.HeadlessMode {
+		return clipboard
+	}
+	text := Window.GetClipboardString()
+	if text == "" {
+		discardClipboardError()
+	}
+	return text
+}
+
+// discardClipboardError discards the error GLFW reports when the clipboard holds no text. It's left pending by
+// GetClipboardString, and would otherwise panic whichever GLFW function checks for errors next.
+func discardClipboardError() {
+	defer func() {
+		recover()
+	}()
+	Window.GetPos()
+}
+
+// SetClipboardText puts the given text on the clipboard of the system.
+func SetClipboardText(text string) {
+	if opts.HeadlessMode {
+		clipboard = text
+		return
+	}
+	Window.SetClipboardString(text)
+}
+
 // RunIteration runs one iteration per frame
 func RunIteration() {
 	Time.Tick()
@@ -230,21 +406,48 @@ func RunIteration() {
 	if !opts.HeadlessMode {
 		Input.update()
 		glfw.PollEvents()
+		pollGamepads()
 	}
 
+	Input.consumeEvents()
+
 	// Then update the world and all Systems
-	currentUpdater.Update(Time.Delta())
+	updateScene(Time.Delta())
 
 	// Lastly, forget keypresses and swap buffers
 	if !opts.HeadlessMode {
 		// reset values to avoid catching the same "signal" twice
 		Input.Mouse.ScrollX, Input.Mouse.ScrollY = 0, 0
-		Input.Mouse.Action = Neutral
+		Input.Mouse.DeltaX, Input.Mouse.DeltaY = 0, 0
+		Input.Mouse.endFrame()
 
 		Window.SwapBuffers()
 	}
 }
 
+// pollGamepads updates the state of every gamepad that has been retrieved using `InputManager.Gamepad`.
+func pollGamepads() {
+	for id, g := range Input.gamepads {
+		joy := glfw.Joystick1 + glfw.Joystick(id)
+		var state *glfw.GamepadState
+		if joy <= glfw.JoystickLast && joy.IsGamepad() {
+			state = joy.GetGamepadState()
+		}
+		if state == nil {
+			g.disconnect()
+			continue
+		}
+
+		g.Connected = true
+		for axis, value := range state.Axes {
+			g.setAxis(GamepadAxis(axis), value)
+		}
+		for button, action := range state.Buttons {
+			g.setButton(GamepadButton(button), action == glfw.Press)
+		}
+	}
+}
+
 // RunPreparation is called automatically when calling Open. It should only be called once.
 func RunPreparation(defaultScene Scene) {
 	Time = NewClock()
@@ -261,7 +464,7 @@ func runLoop(defaultScene Scene, headless bool) {
 	}()
 
 	RunPreparation(defaultScene)
-	ticker := time.NewTicker(time.Duration(int(time.Second) / opts.FPSLimit))
+	ticker := newFrameTicker()
 
 	// Start tick, minimize the delta
 	Time.Tick()
@@ -272,7 +475,7 @@ func runLoop(defaultScene Scene, headless bool) {
 			RunIteration()
 		case <-resetLoopTicker:
 			ticker.Stop()
-			ticker = time.NewTicker(time.Duration(int(time.Second) / opts.FPSLimit))
+			ticker = newFrameTicker()
 		case <-closeGame:
 			ticker.Stop()
 			closeEvent()
@@ -292,6 +495,14 @@ func WindowSize() (w, h int) {
 	return Window.GetSize()
 }
 
+// WindowFocused indicates whether or not the window has input focus
+func WindowFocused() bool {
+	if opts.HeadlessMode {
+		return headlessFocused
+	}
+	return Window.GetAttrib(glfw.Focused) == glfw.True
+}
+
 // WindowWidth gets the current window width
 func WindowWidth() float32 {
 	return windowWidth
@@ -314,9 +525,25 @@ func CanvasHeight() float32 {
 
 // CanvasScale gets the ratio of the canvas to the window sizes
 func CanvasScale() float32 {
+	if opts.HeadlessMode {
+		return contentScale
+	}
 	return scale
 }
 
+// ContentScale returns the ratio between the DPI of the monitor the window is on and the default DPI of the platform,
+// such as 2 on a Retina or other high-DPI display, for scaling text and the HUD so they keep their size on such
+// displays. On macOS the window, the mouse and the game are measured in points, and only the canvas is larger, see
+// CanvasScale, so the game keeps its size on its own. On other platforms they're all measured in pixels, so the game
+// is drawn smaller unless it's scaled by the content scale.
+func ContentScale() float32 {
+	if opts.HeadlessMode {
+		return contentScale
+	}
+	x, _ := Window.GetContentScale()
+	return x
+}
+
 // SetCursor sets the pointer of the mouse to the defined standard cursor
 func SetCursor(c Cursor) {
 	var cur *glfw.Cursor
@@ -335,10 +562,30 @@ func SetCursor(c Cursor) {
 		cur = cursorHResize
 	case CursorVResize:
 		cur = cursorVResize
+	default:
+		cur = customCursors[c]
 	}
 	Window.SetCursor(cur)
 }
 
+// CreateCursor creates a custom cursor from the given image, which can be passed to SetCursor. The hotspot at hotX,
+// hotY is the pixel of the image which points at the position of the mouse, such as the center of a crosshair. If
+// the cursor can't be created, CursorArrow is returned instead.
+func CreateCursor(img image.Image, hotX, hotY int) (c Cursor) {
+	c, ok := newCustomCursor(img, hotX, hotY)
+	if !ok {
+		return c
+	}
+	defer func() {
+		if err := recover(); err != nil {
+			log.Println("[WARNING] Unable to create cursor:", err)
+			c = CursorArrow
+		}
+	}()
+	customCursors[c] = glfw.CreateCursor(img, hotX, hotY)
+	return c
+}
+
 // SetVSync sets whether or not to use VSync
 func SetVSync(enabled bool) {
 	opts.VSync = enabled
@@ -359,6 +606,33 @@ func SetCursorVisibility(visible bool) {
 	}
 }
 
+// SetCursorGrabbed hides the cursor and locks it to the window when grabbed is true, such as for controlling the camera
+// of a first-person game. While grabbed, Mouse.X and Mouse.Y stay where the cursor was grabbed, and the movement of
+// the mouse is only reported in Mouse.DeltaX and Mouse.DeltaY, without it being limited by the edges of the
+// screen. Raw mouse motion is used where supported, so the movement is not affected by the acceleration set by the
+// operating system. Releasing the cursor shows it again at the position where it was grabbed.
+func SetCursorGrabbed(grabbed bool) {
+	if !grabCursor(grabbed) {
+		return
+	}
+
+	if grabbed {
+		Window.SetInputMode(glfw.CursorMode, glfw.CursorDisabled)
+		if glfw.RawMouseMotionSupported() {
+			Window.SetInputMode(glfw.RawMouseMotion, glfw.True)
+		}
+		cursorX, cursorY = Window.GetCursorPos()
+		return
+	}
+
+	if glfw.RawMouseMotionSupported() {
+		Window.SetInputMode(glfw.RawMouseMotion, glfw.False)
+	}
+	Window.SetInputMode(glfw.CursorMode, glfw.CursorNormal)
+	cursorX, cursorY = float64(Input.Mouse.X*opts.GlobalScale.X), float64(Input.Mouse.Y*opts.GlobalScale.Y)
+	Window.SetCursorPos(cursorX, cursorY)
+}
+
 // openFile is the desktop-specific way of opening a file
 func openFile(url string) (io.ReadCloser, error) {
 	return os.Open(url)
@@ -4,8 +4,11 @@ package engo
 
 import (
 	"bytes"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"image"
+	"image/png"
 	"io"
 	"log"
 	"math"
@@ -24,12 +27,21 @@ var (
 
 	devicePixelRatio float64
 
-	poll     = make(map[int]bool)
-	pollLock sync.Mutex
+	// poll and layoutPoll contain the physical and the layout-dependent keys which were pressed or released since
+	// the previous frame, see jsPollKeys
+	poll       = make(map[int]bool)
+	layoutPoll = make(map[int]bool)
+	// pressedLayout contains the layout-dependent key each physical key was reported as when it was pressed, so it's
+	// released even when modifiers change what's printed on it in the meantime
+	pressedLayout = make(map[Key]Key)
+	pollLock      sync.Mutex
 
 	document = js.Global().Get("document")
 	window   = js.Global().Get("window")
 	canvas   js.Value
+
+	// customCursors contains the CSS value of the cursors created using CreateCursor
+	customCursors = make(map[Cursor]string)
 )
 
 // CreateWindow creates a window with the specified parameters
@@ -83,6 +95,7 @@ func CreateWindow(title string, width, height int, fullscreen bool, msaa int) {
 			go func(i int) {
 				pollLock.Lock()
 				poll[i] = true
+				layoutPoll[i] = true
 				pollLock.Unlock()
 			}(kc)
 			k := Key(kc)
@@ -92,21 +105,33 @@ func CreateWindow(title string, width, height int, fullscreen bool, msaa int) {
 			return nil
 		}
 		k := jsStrToKey[ke.String()]
-		go func(i int) {
+		go func(physical, layout Key) {
 			pollLock.Lock()
-			poll[i] = true
+			poll[int(physical)] = true
+			layoutPoll[int(layout)] = true
+			pressedLayout[physical] = layout
 			pollLock.Unlock()
-		}(int(k))
+		}(k, jsLayoutKey(ke.String(), event.Get("key").String(), k))
 		if k == KeyArrowUp || k == KeyArrowDown || k == KeyArrowLeft || k == KeyArrowRight || k == KeyTab || k == KeyBackspace || k == KeySpace {
 			event.Call("preventDefault")
 		}
 		char := event.Get("key").String()
-		if len(char) == 1 {
+		// Shortcuts such as control+V don't type a character, though AltGr, reported as control and alt, does
+		shortcut := (event.Get("ctrlKey").Bool() && !event.Get("altKey").Bool()) || event.Get("metaKey").Bool()
+		if len(char) == 1 && !shortcut && !Input.realInputDisabled && !Input.ignored {
 			Mailbox.Dispatch(TextMessage{[]rune(char)[0]})
 		}
 		return nil
 	}))
 
+	document.Call("addEventListener", "paste", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		// Pasting is the only moment the clipboard of the system can be read synchronously, see ClipboardText
+		if data := args[0].Get("clipboardData"); data.Truthy() {
+			clipboard = data.Call("getData", "text").String()
+		}
+		return nil
+	}))
+
 	canvas.Call("addEventListener", "keyup", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
 		event := args[0]
 		ke := event.Get("code")
@@ -115,6 +140,7 @@ func CreateWindow(title string, width, height int, fullscreen bool, msaa int) {
 			go func(i int) {
 				pollLock.Lock()
 				poll[i] = false
+				layoutPoll[i] = false
 				pollLock.Unlock()
 			}(kc)
 			k := Key(kc)
@@ -124,11 +150,17 @@ func CreateWindow(title string, width, height int, fullscreen bool, msaa int) {
 			return nil
 		}
 		k := jsStrToKey[ke.String()]
-		go func(i int) {
+		go func(physical Key) {
 			pollLock.Lock()
-			poll[i] = false
+			poll[int(physical)] = false
+			layout, ok := pressedLayout[physical]
+			if !ok {
+				layout = physical
+			}
+			layoutPoll[int(layout)] = false
+			delete(pressedLayout, physical)
 			pollLock.Unlock()
-		}(int(k))
+		}(k)
 		if k == KeyArrowUp || k == KeyArrowDown || k == KeyArrowLeft || k == KeyArrowRight || k == KeyTab || k == KeyBackspace || k == KeySpace {
 			event.Call("preventDefault")
 		}
@@ -136,32 +168,94 @@ func CreateWindow(title string, width, height int, fullscreen bool, msaa int) {
 	}))
 
 	canvas.Call("addEventListener", "mousemove", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if Input.realInputDisabled {
+			return nil
+		}
 		event := args[0]
+		Input.Mouse.DeltaX += float32(event.Get("movementX").Float()) / opts.GlobalScale.X
+		Input.Mouse.DeltaY += float32(event.Get("movementY").Float()) / opts.GlobalScale.Y
+		if cursorGrabbed {
+			return nil // the position of a grabbed cursor is meaningless
+		}
+
 		mmX, mmY := event.Get("clientX").Int(), event.Get("clientY").Int()
 		Input.Mouse.X = float32(mmX) / opts.GlobalScale.X
 		Input.Mouse.Y = float32(mmY) / opts.GlobalScale.Y
+		Input.updateMouse()
 		return nil
 	}))
 
 	canvas.Call("addEventListener", "mousedown", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if Input.realInputDisabled {
+			return nil
+		}
 		event := args[0]
 		mmX, mmY := event.Get("clientX").Int(), event.Get("clientY").Int()
 		Input.Mouse.X = float32(mmX) / opts.GlobalScale.X
 		Input.Mouse.Y = float32(mmY) / opts.GlobalScale.Y
+		Input.updateMouse()
+		Input.Mouse.Button = jsMouseButton(event.Get("button").Int())
+		Input.Mouse.setButton(Input.Mouse.Button, true)
 		Input.Mouse.Action = Press
+		Input.Mouse.addEvent()
 		return nil
 	}))
 
 	canvas.Call("addEventListener", "mouseup", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if Input.realInputDisabled {
+			return nil
+		}
 		event := args[0]
 		mmX, mmY := event.Get("clientX").Int(), event.Get("clientY").Int()
 		Input.Mouse.X = float32(mmX) / opts.GlobalScale.X
 		Input.Mouse.Y = float32(mmY) / opts.GlobalScale.Y
+		Input.updateMouse()
+		Input.Mouse.Button = jsMouseButton(event.Get("button").Int())
+		Input.Mouse.setButton(Input.Mouse.Button, false)
 		Input.Mouse.Action = Release
+		Input.Mouse.addEvent()
+		return nil
+	}))
+
+	touchListener := func(action Action) js.Func {
+		return js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			event := args[0]
+			touches := event.Get("changedTouches")
+			for i := 0; i < touches.Get("length").Int(); i++ {
+				t := touches.Call("item", i)
+				Input.touchEvent(t.Get("identifier").Int(), float32(t.Get("clientX").Float()), float32(t.Get("clientY").Float()), action)
+			}
+			// so the browser does not scroll the page, nor send mouse events for the same touch
+			event.Call("preventDefault")
+			return nil
+		})
+	}
+	canvas.Call("addEventListener", "touchstart", touchListener(Press))
+	canvas.Call("addEventListener", "touchmove", touchListener(Move))
+	canvas.Call("addEventListener", "touchend", touchListener(Release))
+	canvas.Call("addEventListener", "touchcancel", touchListener(Release))
+
+	// The browser releases the cursor by itself when escape is pressed
+	document.Call("addEventListener", "pointerlockchange", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if !document.Get("pointerLockElement").Equal(canvas) {
+			grabCursor(false)
+		}
 		return nil
 	}))
 }
 
+// jsMouseButton converts the button of a browser mouse event to a MouseButton. Browsers number the middle button
+// before the right button, every other button is in the same order.
+func jsMouseButton(b int) MouseButton {
+	switch b {
+	case 1:
+		return MouseButtonMiddle
+	case 2:
+		return MouseButtonRight
+	}
+	return MouseButton(b)
+}
+
 // DestroyWindow handles destroying the window when done
 func DestroyWindow() {}
 
@@ -170,6 +264,49 @@ func CursorPos() (x, y float32) {
 	return Input.Mouse.X * opts.GlobalScale.X, Input.Mouse.Y * opts.GlobalScale.Y
 }
 
+// WindowFocused indicates whether or not the page has input focus
+func WindowFocused() bool {
+	if opts.HeadlessMode {
+		return headlessFocused
+	}
+	return document.Call("hasFocus").Bool()
+}
+
+// ClipboardText returns the text on the clipboard.
+//
+// Browsers only let a page read the clipboard of the system asynchronously, and after asking the player for
+// permission, so it can't be read while the game is running. Instead, it returns the text which was last set using
+// SetClipboardText, or pasted into the page by the player, such as using control+V while the canvas has focus; the
+// paste event of the page gives access to the clipboard for a moment. Reading the clipboard any other way, such as
+// from a menu item, only returns text copied within the game.
+func ClipboardText() string {
+	return clipboard
+}
+
+// SetClipboardText puts the given text on the clipboard.
+//
+// In the browser, the text is written to the clipboard of the system using the asynchronous Clipboard API, which is
+// only available to pages served over HTTPS (or from localhost), and which most browsers only allow in response to
+// input of the player, such as while handling a key press. Wherever it's unavailable or refused, the text is only put
+// on the clipboard within the game, see ClipboardText.
+func SetClipboardText(text string) {
+	clipboard = text
+	if opts.HeadlessMode {
+		return
+	}
+	api := js.Global().Get("navigator").Get("clipboard")
+	if !api.Truthy() {
+		return
+	}
+	// The promise is rejected whenever writing is refused, which would be logged as an uncaught error otherwise
+	api.Call("writeText", text).Call("catch", ignoreRejection)
+}
+
+// ignoreRejection handles a rejected promise by doing nothing.
+var ignoreRejection = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+	return nil
+})
+
 // SetTitle changes the title of the page to the given string
 func SetTitle(title string) {
 	if opts.HeadlessMode {
@@ -207,9 +344,22 @@ func CanvasHeight() float32 {
 }
 
 func CanvasScale() float32 {
+	if opts.HeadlessMode {
+		return contentScale
+	}
 	return 1
 }
 
+// ContentScale returns the device pixel ratio of the browser, such as 2 on a Retina display or while zoomed in, for
+// scaling text and the HUD. The window, the mouse and the canvas are all measured in CSS pixels, so the game keeps its
+// size regardless, although it's drawn at a lower resolution than the display has.
+func ContentScale() float32 {
+	if opts.HeadlessMode {
+		return contentScale
+	}
+	return float32(window.Get("devicePixelRatio").Float())
+}
+
 func rafPolyfill() {
 	vendors := []string{"ms", "moz", "webkit", "o"}
 	if window.Get("requestAnimationFrame").Type() == js.TypeUndefined {
@@ -250,8 +400,10 @@ func RunIteration() {
 	Time.Tick()
 	Input.update()
 	jsPollKeys()
-	currentUpdater.Update(Time.Delta())
-	Input.Mouse.Action = Neutral
+	Input.consumeEvents()
+	updateScene(Time.Delta())
+	Input.Mouse.DeltaX, Input.Mouse.DeltaY = 0, 0
+	Input.Mouse.endFrame()
 	// TODO: this may not work, and sky-rocket the FPS
 	//  requestAnimationFrame(func(dt float32) {
 	// 	currentWorld.Update(Time.Delta())
@@ -273,10 +425,37 @@ func jsPollKeys() {
 	pollLock.Lock()
 	defer pollLock.Unlock()
 
+	// The code of a key event is the position of the key, and its key is what's printed on it in the current layout
 	for key, state := range poll {
-		Input.keys.Set(Key(key), state)
+		if !Input.realInputDisabled {
+			Input.keys.SetPhysical(Key(key), state)
+		}
 		delete(poll, key)
 	}
+	for key, state := range layoutPoll {
+		if !Input.realInputDisabled {
+			Input.keys.Set(Key(key), state)
+		}
+		delete(layoutPoll, key)
+	}
+}
+
+// jsLayoutKey returns the Key printed on the physical key in the current keyboard layout, given the code and the key
+// of its event. Only letters and digits differ between layouts; other keys, and those on the numpad, are the same as
+// the physical key.
+func jsLayoutKey(code, key string, physical Key) Key {
+	if len(key) != 1 || strings.HasPrefix(code, "Numpad") {
+		return physical
+	}
+	switch c := key[0]; {
+	case c >= 'a' && c <= 'z':
+		return KeyA + Key(c-'a')
+	case c >= 'A' && c <= 'Z':
+		return KeyA + Key(c-'A')
+	case c >= '0' && c <= '9':
+		return KeyZero + Key(c-'0')
+	}
+	return physical
 }
 
 func requestAnimationFrame(callback func(float32)) int {
@@ -303,7 +482,7 @@ func RunPreparation() {
 func runLoop(defaultScene Scene, headless bool) {
 	SetScene(defaultScene, false)
 	RunPreparation()
-	ticker := time.NewTicker(time.Duration(int(time.Second) / opts.FPSLimit))
+	ticker := newFrameTicker()
 
 	// Start tick, minimize the delta
 	Time.Tick()
@@ -314,7 +493,7 @@ func runLoop(defaultScene Scene, headless bool) {
 			RunIteration()
 		case <-resetLoopTicker:
 			ticker.Stop()
-			ticker = time.NewTicker(time.Duration(int(time.Second) / opts.FPSLimit))
+			ticker = newFrameTicker()
 		case <-closeGame:
 			ticker.Stop()
 			closeEvent()
@@ -384,9 +563,32 @@ func SetCursor(c Cursor) {
 		document.Get("body").Get("style").Set("cursor", "default")
 	case CursorHand:
 		document.Get("body").Get("style").Set("cursor", "hand")
+	default:
+		if css, ok := customCursors[c]; ok {
+			document.Get("body").Get("style").Set("cursor", css)
+		}
 	}
 }
 
+// CreateCursor creates a custom cursor from the given image, which can be passed to SetCursor. The hotspot at hotX,
+// hotY is the pixel of the image which points at the position of the mouse, such as the center of a crosshair. If
+// the cursor can't be created, CursorArrow is returned instead. Browsers may refuse to show large cursors, usually
+// those over 128x128 pixels.
+func CreateCursor(img image.Image, hotX, hotY int) Cursor {
+	c, ok := newCustomCursor(img, hotX, hotY)
+	if !ok {
+		return c
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		log.Println("[WARNING] Unable to create cursor:", err)
+		return CursorArrow
+	}
+	customCursors[c] = fmt.Sprintf("url(data:image/png;base64,%s) %d %d, auto", base64.StdEncoding.EncodeToString(buf.Bytes()), hotX, hotY)
+	return c
+}
+
 //SetCursorVisibility sets the visibility of the cursor.
 //If true the cursor is visible, if false the cursor is not.
 func SetCursorVisibility(visible bool) {
@@ -397,6 +599,39 @@ func SetCursorVisibility(visible bool) {
 	}
 }
 
+// SetFullscreen switches the game to or from fullscreen - not yet implemented
+func SetFullscreen(fullscreen bool) {
+	notImplemented("SetFullscreen")
+}
+
+// SetWindowSize resizes the window - not yet implemented, since the size of the browser window is up to the gamer
+func SetWindowSize(width, height int) {
+	notImplemented("SetWindowSize")
+}
+
+// SetVSync has no effect in the browser, since frames are always drawn in sync with the display using
+// requestAnimationFrame
+func SetVSync(enabled bool) {
+	opts.VSync = enabled
+}
+
+// SetCursorGrabbed hides the cursor and locks it to the canvas when grabbed is true, such as for controlling the camera
+// of a first-person game. While grabbed, Mouse.X and Mouse.Y stay where the cursor was grabbed, and the movement of
+// the mouse is only reported in Mouse.DeltaX and Mouse.DeltaY. Browsers only grab the cursor in response to a click or
+// key press, and release it by themselves when escape is pressed. The cursor can't be moved by the browser, so it's
+// shown again where it was moved to while grabbed, although Mouse.X and Mouse.Y are reset to where it was grabbed.
+func SetCursorGrabbed(grabbed bool) {
+	if !grabCursor(grabbed) {
+		return
+	}
+
+	if grabbed {
+		canvas.Call("requestPointerLock")
+	} else {
+		document.Call("exitPointerLock")
+	}
+}
+
 // IsAndroidChrome tells if the browser is Chrome for android
 func IsAndroidChrome() bool {
 	ua := js.Global().Get("navigator").Get("userAgent").String()
@@ -4,6 +4,7 @@
 package engo
 
 import (
+	"image"
 	"io"
 	"os"
 	"os/signal"
@@ -47,6 +48,11 @@ func CursorPos() (x, y float32) {
 	return 0, 0
 }
 
+// WindowFocused always returns true, since the game only runs while it is in the foreground
+func WindowFocused() bool {
+	return true
+}
+
 // WindowWidth returns the current window width
 func WindowWidth() float32 {
 	return windowWidth
@@ -72,6 +78,16 @@ func CanvasScale() float32 {
 	return CanvasWidth() / WindowWidth()
 }
 
+// ContentScale returns the number of pixels per point of the screen, as reported by the operating system, for
+// scaling text and the HUD so they keep their size on screens with a higher density. The window, the touches and the
+// game are measured in pixels.
+func ContentScale() float32 {
+	if sz.PixelsPerPt <= 0 {
+		return 1
+	}
+	return sz.PixelsPerPt
+}
+
 // DestroyWindow handles destroying the window
 func DestroyWindow() { /* nothing to do here? */ }
 
@@ -97,7 +113,7 @@ func runLoop(defaultScene Scene, headless bool) {
 					Gl = gl.NewContext(e.DrawContext)
 					RunPreparation(defaultScene)
 
-					ticker = time.NewTicker(time.Duration(int(time.Second) / opts.FPSLimit))
+					ticker = newFrameTicker()
 					// Start tick, minimize the delta
 					Time.Tick()
 
@@ -111,6 +127,16 @@ func runLoop(defaultScene Scene, headless bool) {
 
 			case size.Event:
 				sz = e
+				message := WindowResizeMessage{
+					OldWidth:        int(windowWidth),
+					OldHeight:       int(windowHeight),
+					NewWidth:        sz.WidthPx,
+					NewHeight:       sz.HeightPx,
+					OldCanvasWidth:  int(canvasWidth),
+					OldCanvasHeight: int(canvasHeight),
+					NewCanvasWidth:  sz.WidthPx,
+					NewCanvasHeight: sz.HeightPx,
+				}
 				windowWidth = float32(sz.WidthPx)
 				windowHeight = float32(sz.HeightPx)
 				canvasWidth = float32(sz.WidthPx)
@@ -118,6 +144,10 @@ func runLoop(defaultScene Scene, headless bool) {
 				Gl.Viewport(0, 0, sz.WidthPx, sz.HeightPx)
 				ResizeXOffset = (gameWidth - canvasWidth)
 				ResizeYOffset = (gameHeight - canvasHeight)
+				// The first size event arrives when the app starts, which may be before the scene is set up
+				if Mailbox != nil && (message.OldWidth != message.NewWidth || message.OldHeight != message.NewHeight) {
+					Mailbox.Dispatch(message)
+				}
 			case paint.Event:
 				if e.External {
 					// As we are actively painting as fast as
@@ -131,35 +161,24 @@ func runLoop(defaultScene Scene, headless bool) {
 					RunIteration()
 				case <-resetLoopTicker:
 					ticker.Stop()
-					ticker = time.NewTicker(time.Duration(int(time.Second) / opts.FPSLimit))
+					ticker = newFrameTicker()
 				}
 
-				Input.Mouse.Action = Neutral
+				Input.Mouse.endFrame()
 				a.Publish() // same as SwapBuffers
 
 				// Drive the animation by preparing to paint the next frame
 				// after this one is shown. - FPS is ignored here!
 				a.Send(paint.Event{})
 			case touch.Event:
-				Input.Mouse.X = e.X / opts.GlobalScale.X
-				Input.Mouse.Y = e.Y / opts.GlobalScale.Y
 				id := int(e.Sequence)
 				switch e.Type {
 				case touch.TypeBegin:
-					Input.Mouse.Action = Press
-					Input.Touches[id] = Point{
-						X: float32(e.X) / opts.GlobalScale.X,
-						Y: float32(e.Y) / opts.GlobalScale.Y,
-					}
+					Input.touchEvent(id, e.X, e.Y, Press)
 				case touch.TypeMove:
-					Input.Mouse.Action = Move
-					Input.Touches[id] = Point{
-						X: float32(e.X) / opts.GlobalScale.X,
-						Y: float32(e.Y) / opts.GlobalScale.Y,
-					}
+					Input.touchEvent(id, e.X, e.Y, Move)
 				case touch.TypeEnd:
-					Input.Mouse.Action = Release
-					delete(Input.Touches, id)
+					Input.touchEvent(id, e.X, e.Y, Release)
 				}
 			}
 		}
@@ -180,9 +199,10 @@ func RunIteration() {
 	if !opts.HeadlessMode {
 		Input.update()
 	}
+	Input.consumeEvents()
 
 	// Then update the world and all Systems
-	currentUpdater.Update(Time.Delta())
+	updateScene(Time.Delta())
 }
 
 // SetCursor changes the cursor - not yet implemented
@@ -190,14 +210,47 @@ func SetCursor(Cursor) {
 	notImplemented("SetCursor")
 }
 
+// CreateCursor creates a custom cursor - not yet implemented
+func CreateCursor(image.Image, int, int) Cursor {
+	notImplemented("CreateCursor")
+	return CursorArrow
+}
+
 //SetCursorVisibility sets the visibility of the cursor.
 //If true the cursor is visible, if false the cursor is not.
 //Does nothing in mobile since there's no visible cursor to begin with
 func SetCursorVisibility(visible bool) {}
 
+// SetCursorGrabbed grabs the cursor - not yet implemented
+func SetCursorGrabbed(bool) {
+	notImplemented("SetCursorGrabbed")
+}
+
 // SetTitle has no effect on mobile
 func SetTitle(title string) {}
 
+// ClipboardText returns the text on the clipboard. The clipboard of the system isn't available on mobile, so it only
+// holds the text set using SetClipboardText.
+func ClipboardText() string {
+	return clipboard
+}
+
+// SetClipboardText puts the given text on the clipboard, which is kept within the game on mobile, see ClipboardText.
+func SetClipboardText(text string) {
+	clipboard = text
+}
+
+// SetFullscreen has no effect on mobile, since the game always fills the screen
+func SetFullscreen(fullscreen bool) {}
+
+// SetWindowSize has no effect on mobile, since the game always fills the screen
+func SetWindowSize(width, height int) {}
+
+// SetVSync has no effect on mobile, since the buffers are always swapped in sync with the display
+func SetVSync(enabled bool) {
+	opts.VSync = enabled
+}
+
 // openFile is the mobile-specific way of opening a file
 func openFile(url string) (io.ReadCloser, error) {
 	usedUrl := url
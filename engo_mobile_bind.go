@@ -4,6 +4,7 @@ package engo
 
 import (
 	"errors"
+	"image"
 	"io"
 	"runtime"
 	"time"
@@ -45,6 +46,11 @@ func CursorPos() (x, y float32) {
 	return 0, 0
 }
 
+// WindowFocused always returns true, since the game only runs while it is in the foreground
+func WindowFocused() bool {
+	return true
+}
+
 // WindowWidth returns the current window width
 func WindowWidth() float32 {
 	return windowWidth
@@ -70,6 +76,12 @@ func CanvasScale() float32 {
 	return CanvasWidth() / WindowWidth()
 }
 
+// ContentScale returns 1, since the app embedding the game doesn't report the density of the screen. The window, the
+// touches and the game are measured in pixels.
+func ContentScale() float32 {
+	return 1
+}
+
 // DestroyWindow destroies the window.
 func DestroyWindow() { /* nothing to do here? */ }
 
@@ -122,14 +134,47 @@ func SetCursor(Cursor) {
 	notImplemented("SetCursor")
 }
 
+// CreateCursor creates a custom cursor - not yet implemented
+func CreateCursor(image.Image, int, int) Cursor {
+	notImplemented("CreateCursor")
+	return CursorArrow
+}
+
 //SetCursorVisibility sets the visibility of the cursor.
 //If true the cursor is visible, if false the cursor is not.
 //Does nothing in mobile since there's no visible cursor to begin with
 func SetCursorVisibility(visible bool) {}
 
+// SetCursorGrabbed grabs the cursor - not yet implemented
+func SetCursorGrabbed(bool) {
+	notImplemented("SetCursorGrabbed")
+}
+
 // SetTitle has no effect on mobile
 func SetTitle(title string) {}
 
+// ClipboardText returns the text on the clipboard. The clipboard of the system isn't available on mobile, so it only
+// holds the text set using SetClipboardText.
+func ClipboardText() string {
+	return clipboard
+}
+
+// SetClipboardText puts the given text on the clipboard, which is kept within the game on mobile, see ClipboardText.
+func SetClipboardText(text string) {
+	clipboard = text
+}
+
+// SetFullscreen has no effect on mobile, since the game always fills the screen
+func SetFullscreen(fullscreen bool) {}
+
+// SetWindowSize has no effect on mobile, since the game always fills the screen
+func SetWindowSize(width, height int) {}
+
+// SetVSync has no effect on mobile, since the buffers are always swapped in sync with the display
+func SetVSync(enabled bool) {
+	opts.VSync = enabled
+}
+
 // openFile is the mobile-specific way of opening a file
 func openFile(url string) (io.ReadCloser, error) {
 	return nil, errors.New("binding does not open files this way. utilize go-bindata instead")
@@ -149,7 +194,7 @@ func mobileDraw(defaultScene Scene) {
 
 	if !initalized {
 		RunPreparation(defaultScene)
-		ticker = time.NewTicker(time.Duration(int(time.Second) / opts.FPSLimit))
+		ticker = newFrameTicker()
 		initalized = true
 	}
 
@@ -157,15 +202,15 @@ func mobileDraw(defaultScene Scene) {
 	case <-ticker.C:
 	case <-resetLoopTicker:
 		ticker.Stop()
-		ticker = time.NewTicker(time.Duration(int(time.Second) / opts.FPSLimit))
+		ticker = newFrameTicker()
 	}
 	Time.Tick()
 	if !opts.HeadlessMode {
 		Input.update()
 	}
 	// Then update the world and all Systems
-	currentUpdater.Update(Time.Delta())
-	Input.Mouse.Action = Neutral
+	updateScene(Time.Delta())
+	Input.Mouse.endFrame()
 }
 
 //MobileStop handles when the game is closed
@@ -4,13 +4,15 @@ package engo
 
 import (
 	"bytes"
+	"image"
+	"image/draw"
 	"io"
 	"log"
 	"os"
 	"os/signal"
 	"runtime"
 	"syscall"
-	"time"
+	"unsafe"
 
 	"github.com/EngoEngine/gl"
 
@@ -29,6 +31,8 @@ var (
 	cursorHand      *sdl.Cursor
 	cursorHResize   *sdl.Cursor
 	cursorVResize   *sdl.Cursor
+	// customCursors contains the cursors created using CreateCursor
+	customCursors = make(map[Cursor]*sdl.Cursor)
 
 	Gl           *gl.Context
 	sdlGLContext sdl.GLContext
@@ -83,6 +87,8 @@ func CreateWindow(title string, width, height int, fullscreen bool, msaa int) {
 
 	Gl = gl.NewContext()
 
+	// The window returns to this size once it leaves fullscreen, see SetFullscreen
+	windowedWidth, windowedHeight = width, height
 	if fullscreen {
 		Window.SetFullscreen(sdl.WINDOW_FULLSCREEN)
 	}
@@ -105,6 +111,102 @@ func CreateWindow(title string, width, height int, fullscreen bool, msaa int) {
 	}
 }
 
+// windowResized updates the sizes of the window, canvas and game after the window was resized, and dispatches a
+// WindowResizeMessage if any of them changed.
+func windowResized() {
+	w, h := Window.GetSize()
+	fw, fh := Window.GLGetDrawableSize()
+
+	message := WindowResizeMessage{
+		OldWidth:        int(windowWidth),
+		OldHeight:       int(windowHeight),
+		NewWidth:        int(w),
+		NewHeight:       int(h),
+		OldCanvasWidth:  int(canvasWidth),
+		OldCanvasHeight: int(canvasHeight),
+		NewCanvasWidth:  int(fw),
+		NewCanvasHeight: int(fh),
+	}
+	if message.OldWidth == message.NewWidth && message.OldHeight == message.NewHeight &&
+		message.OldCanvasWidth == message.NewCanvasWidth && message.OldCanvasHeight == message.NewCanvasHeight {
+		return
+	}
+
+	Gl.Viewport(0, 0, int(fw), int(fh))
+	windowWidth, windowHeight = float32(w), float32(h)
+
+	oldCanvasW, oldCanvasH := canvasWidth, canvasHeight
+
+	canvasWidth, canvasHeight = float32(fw), float32(fh)
+
+	ResizeXOffset += oldCanvasW - canvasWidth
+	ResizeYOffset += oldCanvasH - canvasHeight
+
+	if !opts.ScaleOnResize {
+		gameWidth, gameHeight = float32(w), float32(h)
+	}
+
+	if windowWidth <= canvasWidth && windowHeight <= canvasHeight {
+		scale = canvasWidth / windowWidth
+	}
+
+	// The cursor didn't move, but whether it's inside the window may have changed
+	if !cursorGrabbed {
+		Input.updateMouse()
+	}
+
+	Mailbox.Dispatch(message)
+}
+
+// SetFullscreen switches the window to or from fullscreen mode while the game is running, such as from a settings
+// menu. The window goes fullscreen on the display it's on, at the resolution of that display, and returns to its
+// previous size once it leaves fullscreen. The sizes of the window, canvas and game are updated and a
+// WindowResizeMessage is dispatched right away, so the camera and the MouseSystem are accurate from the next frame on.
+func SetFullscreen(fullscreen bool) {
+	if opts.HeadlessMode {
+		setHeadlessFullscreen(fullscreen)
+		return
+	}
+	if fullscreen == opts.Fullscreen {
+		return
+	}
+
+	var flags uint32
+	if fullscreen {
+		w, h := Window.GetSize()
+		windowedWidth, windowedHeight = int(w), int(h)
+		flags = sdl.WINDOW_FULLSCREEN_DESKTOP
+	}
+	if err := Window.SetFullscreen(flags); err != nil {
+		warning("unable to switch fullscreen mode: " + err.Error())
+		return
+	}
+	if !fullscreen && windowedWidth > 0 && windowedHeight > 0 {
+		Window.SetSize(int32(windowedWidth), int32(windowedHeight))
+	}
+	opts.Fullscreen = fullscreen
+	windowResized()
+}
+
+// SetWindowSize resizes the window while the game is running, to the given size in screen coordinates. The sizes of
+// the window, canvas and game are updated and a WindowResizeMessage is dispatched right away. While the window is
+// fullscreen, it's resized once it leaves fullscreen instead.
+func SetWindowSize(width, height int) {
+	if opts.HeadlessMode {
+		setHeadlessWindowSize(width, height)
+		return
+	}
+	if !validWindowSize(width, height) {
+		return
+	}
+	if opts.Fullscreen {
+		windowedWidth, windowedHeight = width, height
+		return
+	}
+	Window.SetSize(int32(width), int32(height))
+	windowResized()
+}
+
 // DestroyWindow handles the termination of windows
 func DestroyWindow() {
 	sdl.GLDeleteContext(sdlGLContext)
@@ -121,6 +223,40 @@ func SetTitle(title string) {
 	}
 }
 
+// ClipboardText returns the text on the clipboard of the system, or an empty string when it holds something else,
+// such as an image. While running headless, the clipboard only holds the text set using SetClipboardText.
+func ClipboardText() string {
+	if opts.HeadlessMode {
+		return clipboard
+	}
+	text, err := sdl.GetClipboardText()
+	if err != nil {
+		return ""
+	}
+	return text
+}
+
+// SetClipboardText puts the given text on the clipboard of the system.
+func SetClipboardText(text string) {
+	if opts.HeadlessMode {
+		clipboard = text
+		return
+	}
+	if err := sdl.SetClipboardText(text); err != nil {
+		warning("unable to set the clipboard: " + err.Error())
+	}
+}
+
+// isInputEvent returns whether or not the event reports input of the keyboard or mouse, which is ignored while real
+// input is disabled, see `InputManager.DisableRealInput`.
+func isInputEvent(event sdl.Event) bool {
+	switch event.(type) {
+	case *sdl.KeyboardEvent, *sdl.MouseWheelEvent, *sdl.MouseButtonEvent, *sdl.MouseMotionEvent, *sdl.TextInputEvent:
+		return true
+	}
+	return false
+}
+
 // RunIteration runs one iteration per frame
 func RunIteration() {
 	Time.Tick()
@@ -129,21 +265,29 @@ func RunIteration() {
 	if !opts.HeadlessMode {
 		Input.update()
 		for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
+			if Input.realInputDisabled && isInputEvent(event) {
+				continue
+			}
 			switch e := event.(type) {
 			case *sdl.QuitEvent:
 				Exit()
 			case *sdl.KeyboardEvent:
-				key := Key(e.Keysym.Sym)
+				key, physical := Key(e.Keysym.Sym), physicalKey(e.Keysym.Scancode, e.Keysym.Sym)
 				if e.GetType() == sdl.KEYUP {
 					Input.keys.Set(key, false)
+					Input.keys.SetPhysical(physical, false)
 				} else if e.GetType() == sdl.KEYDOWN {
 					Input.keys.Set(key, true)
+					Input.keys.SetPhysical(physical, true)
 				}
 			case *sdl.MouseWheelEvent:
 				Input.Mouse.ScrollX = float32(e.X)
 				Input.Mouse.ScrollY = float32(e.Y)
 			case *sdl.MouseButtonEvent:
-				Input.Mouse.X, Input.Mouse.Y = float32(e.X)/(opts.GlobalScale.X), float32(e.Y)/(opts.GlobalScale.Y)
+				if !cursorGrabbed {
+					Input.Mouse.X, Input.Mouse.Y = float32(e.X)/(opts.GlobalScale.X), float32(e.Y)/(opts.GlobalScale.Y)
+					Input.updateMouse()
+				}
 
 				switch e.Button {
 				case sdl.BUTTON_LEFT:
@@ -156,7 +300,11 @@ func RunIteration() {
 					Input.Mouse.Button = MouseButton4
 				case sdl.BUTTON_X2:
 					Input.Mouse.Button = MouseButton5
+				default:
+					// SDL numbers any further buttons in the same order, starting at 1
+					Input.Mouse.Button = MouseButton(e.Button - 1)
 				}
+				Input.Mouse.setButton(Input.Mouse.Button, e.State == sdl.PRESSED)
 
 				// Is this possible in SDL?
 				// Input.Mouse.Modifer = Modifier(m)
@@ -166,62 +314,44 @@ func RunIteration() {
 				} else {
 					Input.Mouse.Action = Release
 				}
+				Input.Mouse.addEvent()
 			case *sdl.MouseMotionEvent:
+				Input.Mouse.DeltaX += float32(e.XRel) / opts.GlobalScale.X
+				Input.Mouse.DeltaY += float32(e.YRel) / opts.GlobalScale.Y
+				if cursorGrabbed {
+					break // the position of a grabbed cursor is meaningless
+				}
+
 				Input.Mouse.X, Input.Mouse.Y = float32(e.X)/opts.GlobalScale.X, float32(e.Y)/opts.GlobalScale.Y
+				Input.updateMouse()
 				if Input.Mouse.Action != Release && Input.Mouse.Action != Press {
 					Input.Mouse.Action = Move
 				}
 			case *sdl.WindowEvent:
 				if e.Event == sdl.WINDOWEVENT_RESIZED {
-
-					w, h := Window.GetSize()
-					fw, fh := Window.GLGetDrawableSize()
-
-					message := WindowResizeMessage{
-						OldWidth:  int(windowWidth),
-						OldHeight: int(windowHeight),
-						NewWidth:  int(w),
-						NewHeight: int(h),
-					}
-
-					Gl.Viewport(0, 0, int(fw), int(fh))
-					windowWidth, windowHeight = float32(w), float32(h)
-
-					oldCanvasW, oldCanvasH := canvasWidth, canvasHeight
-
-					canvasWidth, canvasHeight = float32(fw), float32(fh)
-
-					ResizeXOffset += oldCanvasW - canvasWidth
-					ResizeYOffset += oldCanvasH - canvasHeight
-
-					if !opts.ScaleOnResize {
-						gameWidth, gameHeight = float32(w), float32(h)
-					}
-
-					if windowWidth <= canvasWidth && windowHeight <= canvasHeight {
-						scale = canvasWidth / windowWidth
-					}
-
-					Mailbox.Dispatch(message)
+					windowResized()
 				}
 			case *sdl.TextInputEvent:
 				n := bytes.IndexByte(e.Text[:], 0)
 				s := string(e.Text[:n])
-				if len(s) == 1 {
+				if len(s) == 1 && !Input.ignored {
 					Mailbox.Dispatch(TextMessage{[]rune(s)[0]})
 				}
 			}
 		}
 	}
 
+	Input.consumeEvents()
+
 	// Then update the world and all Systems
-	currentUpdater.Update(Time.Delta())
+	updateScene(Time.Delta())
 
 	// Lastly, forget keypresses and swap buffers
 	if !opts.HeadlessMode {
 		// reset values to avoid catching the same "signal" twice
 		Input.Mouse.ScrollX, Input.Mouse.ScrollY = 0, 0
-		Input.Mouse.Action = Neutral
+		Input.Mouse.DeltaX, Input.Mouse.DeltaY = 0, 0
+		Input.Mouse.endFrame()
 		sdlMojaveFix.UpdateNSGLContext(sdlGLContext)
 		Window.GLSwap()
 	}
@@ -243,7 +373,7 @@ func runLoop(defaultScene Scene, headless bool) {
 	}()
 
 	RunPreparation(defaultScene)
-	ticker := time.NewTicker(time.Duration(int(time.Second) / opts.FPSLimit))
+	ticker := newFrameTicker()
 
 	// Start tick, minimize the delta
 	Time.Tick()
@@ -254,7 +384,7 @@ func runLoop(defaultScene Scene, headless bool) {
 			RunIteration()
 		case <-resetLoopTicker:
 			ticker.Stop()
-			ticker = time.NewTicker(time.Duration(int(time.Second) / opts.FPSLimit))
+			ticker = newFrameTicker()
 		case <-closeGame:
 			ticker.Stop()
 			closeEvent()
@@ -269,6 +399,14 @@ func CursorPos() (x, y float32) {
 	return float32(w), float32(h)
 }
 
+// WindowFocused indicates whether or not the window has input focus
+func WindowFocused() bool {
+	if opts.HeadlessMode {
+		return headlessFocused
+	}
+	return Window.GetFlags()&sdl.WINDOW_INPUT_FOCUS != 0
+}
+
 // WindowSize gets the current window size
 func WindowSize() (w, h int) {
 	width, height := Window.GetSize()
@@ -297,9 +435,20 @@ func CanvasHeight() float32 {
 
 // CanvasScale gets the ratio of the canvas to the window sizes
 func CanvasScale() float32 {
+	if opts.HeadlessMode {
+		return contentScale
+	}
 	return scale
 }
 
+// ContentScale returns the ratio between the DPI of the display the window is on and the default DPI of the platform,
+// such as 2 on a Retina display, for scaling text and the HUD so they keep their size on such displays. SDL doesn't
+// report the DPI of the monitor, so it's the CanvasScale: the ratio by which the canvas is larger than the window on
+// high-DPI displays where the window is measured in points, such as on macOS.
+func ContentScale() float32 {
+	return CanvasScale()
+}
+
 // SetCursor sets the pointer of the mouse to the defined standard cursor
 func SetCursor(c Cursor) {
 	var cur *sdl.Cursor
@@ -318,10 +467,40 @@ func SetCursor(c Cursor) {
 		cur = cursorHResize
 	case CursorVResize:
 		cur = cursorVResize
+	default:
+		cur = customCursors[c]
 	}
 	sdl.SetCursor(cur)
 }
 
+// CreateCursor creates a custom cursor from the given image, which can be passed to SetCursor. The hotspot at hotX,
+// hotY is the pixel of the image which points at the position of the mouse, such as the center of a crosshair. If
+// the cursor can't be created, CursorArrow is returned instead.
+func CreateCursor(img image.Image, hotX, hotY int) Cursor {
+	c, ok := newCustomCursor(img, hotX, hotY)
+	if !ok {
+		return c
+	}
+
+	b := img.Bounds()
+	m := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(m, m.Bounds(), img, b.Min, draw.Src)
+	surface, err := sdl.CreateRGBSurfaceWithFormatFrom(unsafe.Pointer(&m.Pix[0]), int32(b.Dx()), int32(b.Dy()), 32, int32(m.Stride), sdl.PIXELFORMAT_ABGR8888)
+	if err != nil {
+		log.Println("[WARNING] Unable to create cursor:", err)
+		return CursorArrow
+	}
+	defer surface.Free()
+
+	cur := sdl.CreateColorCursor(surface, int32(hotX), int32(hotY))
+	if cur == nil {
+		log.Println("[WARNING] Unable to create cursor:", sdl.GetError())
+		return CursorArrow
+	}
+	customCursors[c] = cur
+	return c
+}
+
 // SetVSync sets whether or not to use VSync
 func SetVSync(enabled bool) {
 	opts.VSync = enabled
@@ -345,6 +524,24 @@ func SetCursorVisibility(visible bool) {
 	}
 }
 
+// SetCursorGrabbed hides the cursor and locks it to the window when grabbed is true, such as for controlling the camera
+// of a first-person game. While grabbed, Mouse.X and Mouse.Y stay where the cursor was grabbed, and the movement of
+// the mouse is only reported in Mouse.DeltaX and Mouse.DeltaY, without it being limited by the edges of the
+// screen. Releasing the cursor shows it again at the position where it was grabbed.
+func SetCursorGrabbed(grabbed bool) {
+	if !grabCursor(grabbed) {
+		return
+	}
+
+	if grabbed {
+		sdl.SetRelativeMouseMode(true)
+		return
+	}
+
+	sdl.SetRelativeMouseMode(false)
+	Window.WarpMouseInWindow(int32(Input.Mouse.X*opts.GlobalScale.X), int32(Input.Mouse.Y*opts.GlobalScale.Y))
+}
+
 // openFile is the desktop-specific way of opening a file
 func openFile(url string) (io.ReadCloser, error) {
 	return os.Open(url)
@@ -144,6 +144,27 @@ func TestSetFPSLimit(t *testing.T) {
 	}
 }
 
+func TestSetFPSLimitRepeatedly(t *testing.T) {
+	Run(RunOptions{
+		HeadlessMode: true,
+		NoRun:        true,
+	}, &testScene{})
+	// Without a game loop resetting its ticker, the second call must not block
+	SetFPSLimit(30)
+	SetFPSLimit(120)
+	if opts.FPSLimit != 120 {
+		t.Error("SetFPSLimit didn't set properly.")
+	}
+	select {
+	case <-resetLoopTicker:
+	default:
+		t.Error("SetFPSLimit didn't reset the ticker of the game loop.")
+	}
+	if ticker := newFrameTicker(); ticker != nil {
+		ticker.Stop()
+	}
+}
+
 func TestRunNegativeMSAAPanic(t *testing.T) {
 	defer func() {
 		r := recover()
@@ -297,6 +318,114 @@ func TestSceneSwitching(t *testing.T) {
 	}
 }
 
+type countingSystem struct {
+	entities []ecs.BasicEntity
+	updates  int
+}
+
+func (c *countingSystem) BasicEntities() []ecs.BasicEntity {
+	return append([]ecs.BasicEntity(nil), c.entities...)
+}
+
+func (c *countingSystem) Remove(basic ecs.BasicEntity) {
+	for i, e := range c.entities {
+		if e.ID() == basic.ID() {
+			c.entities = append(c.entities[:i], c.entities[i+1:]...)
+			return
+		}
+	}
+}
+
+func (c *countingSystem) Update(float32) { c.updates++ }
+
+type testScopedScene struct {
+	sys           *countingSystem
+	setups, exits int
+	persist       bool
+	name          string
+}
+
+func (*testScopedScene) Preload() {}
+
+func (s *testScopedScene) Setup(u Updater) {
+	s.setups++
+	s.sys = &countingSystem{entities: ecs.NewBasics(2)}
+	u.(*ecs.World).AddSystem(s.sys)
+}
+
+func (s *testScopedScene) ExitScene() { s.exits++ }
+
+func (s *testScopedScene) Persist() bool { return s.persist }
+
+func (s *testScopedScene) Type() string { return s.name }
+
+func TestSceneTeardown(t *testing.T) {
+	first := &testScopedScene{name: "testScopedScene"}
+	second := &testScopedScene{name: "testScopedScene2"}
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, first)
+
+	updateScene(1)
+	sys := first.sys
+	SetScene(second, false)
+	updateScene(1)
+	updateScene(1)
+
+	if sys.updates != 1 {
+		t.Errorf("systems of the previous scene kept running after switching away. Wanted: %v updates, got: %v", 1, sys.updates)
+	}
+	if first.exits != 1 {
+		t.Errorf("previous scene was not exited after switching away. Wanted: %v, got: %v", 1, first.exits)
+	}
+	if len(sys.entities) != 0 {
+		t.Errorf("entities of the previous scene were not removed after switching away. Wanted: %v, got: %v", 0, len(sys.entities))
+	}
+	if scenes[first.Type()].update != nil {
+		t.Error("previous scene still holds on to its Updater after switching away")
+	}
+
+	SetScene(first, false)
+	if first.setups != 2 || first.sys == sys {
+		t.Errorf("scene was not set up again after switching back. Wanted: %v setups, got: %v", 2, first.setups)
+	}
+
+	// Persisting scenes are kept, but are exited every time, and only torn down when a new Updater is forced.
+	first.persist = true
+	sys = first.sys
+	SetScene(second, false)
+	if first.exits != 2 {
+		t.Errorf("persisting scene was not exited after switching away. Wanted: %v exits, got: %v", 2, first.exits)
+	}
+	if len(sys.entities) != 2 || scenes[first.Type()].update == nil {
+		t.Error("persisting scene was torn down after switching away")
+	}
+	SetScene(first, true)
+	if len(sys.entities) != 0 {
+		t.Errorf("entities of the persisting scene were not removed when forcing a new Updater. Wanted: %v, got: %v", 0, len(sys.entities))
+	}
+
+	// The active scene is exited when the game gets closed, and isn't exited again by later calls to Exit.
+	teardownScenes()
+	teardownScenes()
+	if first.exits != 3 {
+		t.Errorf("active scene was not exited when closing the game. Wanted: %v exits, got: %v", 3, first.exits)
+	}
+}
+
+func TestScenePersistsByDefault(t *testing.T) {
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &testScene{})
+	SetScene(&testScene2{}, false)
+	SetScene(&testScene{}, false)
+	if scenes["testScene2"].update == nil {
+		t.Error("scene without Persister was torn down after switching away")
+	}
+}
+
 func TestUtils(t *testing.T) {
 	var buf bytes.Buffer
 	log.SetOutput(&buf)
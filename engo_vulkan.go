@@ -3,13 +3,13 @@
 package engo
 
 import (
+	"image"
 	"io"
 	"log"
 	"os"
 	"os/signal"
 	"runtime"
 	"syscall"
-	"time"
 
 	"github.com/vulkan-go/glfw/v3.3/glfw"
 	vk "github.com/vulkan-go/vulkan"
@@ -25,6 +25,10 @@ var (
 	cursorHand      *glfw.Cursor
 	cursorHResize   *glfw.Cursor
 	cursorVResize   *glfw.Cursor
+	// customCursors contains the cursors created using CreateCursor
+	customCursors = make(map[Cursor]*glfw.Cursor)
+	// cursorX and cursorY are the position of the cursor reported last, used for the movement of the mouse
+	cursorX, cursorY float64
 
 	scale = float32(1)
 )
@@ -78,6 +82,10 @@ func CreateWindow(title string, width, height int, fullscreen bool, msaa int) {
 	gameWidth = float32(width)
 	gameHeight = float32(height)
 
+	// The window returns to this size and position once it leaves fullscreen, see SetFullscreen
+	windowedWidth, windowedHeight = width, height
+	windowedX, windowedY = (mode.Width-width)/2, (mode.Height-height)/2
+
 	if fullscreen {
 		width = mode.Width
 		height = mode.Height
@@ -132,66 +140,107 @@ func CreateWindow(title string, width, height int, fullscreen bool, msaa int) {
 		}
 	})
 
+	cursorX, cursorY = Window.GetCursorPos()
 	Window.SetCursorPosCallback(func(Window *glfw.Window, x, y float64) {
+		if Input.realInputDisabled {
+			cursorX, cursorY = x, y
+			return
+		}
+		Input.Mouse.DeltaX += float32(x-cursorX) / opts.GlobalScale.X
+		Input.Mouse.DeltaY += float32(y-cursorY) / opts.GlobalScale.Y
+		cursorX, cursorY = x, y
+		if cursorGrabbed {
+			return // the position of a grabbed cursor is meaningless
+		}
+
 		Input.Mouse.X, Input.Mouse.Y = float32(x)/opts.GlobalScale.X, float32(y)/opts.GlobalScale.Y
+		Input.updateMouse()
 		if Input.Mouse.Action != Release && Input.Mouse.Action != Press {
 			Input.Mouse.Action = Move
 		}
 	})
 
 	Window.SetMouseButtonCallback(func(Window *glfw.Window, b glfw.MouseButton, a glfw.Action, m glfw.ModifierKey) {
-		x, y := Window.GetCursorPos()
-		Input.Mouse.X, Input.Mouse.Y = float32(x)/(opts.GlobalScale.X), float32(y)/(opts.GlobalScale.Y)
+		if Input.realInputDisabled {
+			return
+		}
+		if !cursorGrabbed {
+			x, y := Window.GetCursorPos()
+			Input.Mouse.X, Input.Mouse.Y = float32(x)/(opts.GlobalScale.X), float32(y)/(opts.GlobalScale.Y)
+			Input.updateMouse()
+		}
 
 		// this is only valid because we use an internal structure that is
 		// 100% compatible with glfw3.h
 		Input.Mouse.Button = MouseButton(b)
 		Input.Mouse.Modifer = Modifier(m)
+		Input.Mouse.setButton(MouseButton(b), a == glfw.Press)
 
 		if a == glfw.Press {
 			Input.Mouse.Action = Press
 		} else {
 			Input.Mouse.Action = Release
 		}
+		Input.Mouse.addEvent()
 	})
 
 	Window.SetScrollCallback(func(Window *glfw.Window, xoff, yoff float64) {
+		if Input.realInputDisabled {
+			return
+		}
 		Input.Mouse.ScrollX = float32(xoff)
 		Input.Mouse.ScrollY = float32(yoff)
 	})
 
 	Window.SetKeyCallback(func(Window *glfw.Window, k glfw.Key, s int, a glfw.Action, m glfw.ModifierKey) {
-		key := Key(k)
+		if Input.realInputDisabled {
+			return
+		}
+		// GLFW names its keys after their position on a US keyboard layout, which makes them physical keys
+		key, physical := layoutKey(k, s), Key(k)
 		if a == glfw.Press {
 			Input.keys.Set(key, true)
+			Input.keys.SetPhysical(physical, true)
 		} else if a == glfw.Release {
 			Input.keys.Set(key, false)
+			Input.keys.SetPhysical(physical, false)
 		}
 	})
 
 	Window.SetSizeCallback(func(w *glfw.Window, widthInt int, heightInt int) {
+		// TODO: verify these for retina displays & verify if needed here
+		fw, fh := Window.GetFramebufferSize()
 		message := WindowResizeMessage{
-			OldWidth:  int(windowWidth),
-			OldHeight: int(windowHeight),
-			NewWidth:  widthInt,
-			NewHeight: heightInt,
+			OldWidth:        int(windowWidth),
+			OldHeight:       int(windowHeight),
+			NewWidth:        widthInt,
+			NewHeight:       heightInt,
+			OldCanvasWidth:  int(canvasWidth),
+			OldCanvasHeight: int(canvasHeight),
+			NewCanvasWidth:  fw,
+			NewCanvasHeight: fh,
 		}
 
 		windowWidth = float32(widthInt)
 		windowHeight = float32(heightInt)
-
-		// TODO: verify these for retina displays & verify if needed here
-		fw, fh := Window.GetFramebufferSize()
 		canvasWidth, canvasHeight = float32(fw), float32(fh)
 
 		if !opts.ScaleOnResize {
 			gameWidth, gameHeight = float32(widthInt), float32(heightInt)
 		}
 
+		// The cursor didn't move, but whether it's inside the window may have changed
+		if !cursorGrabbed {
+			Input.updateMouse()
+		}
+
 		Mailbox.Dispatch(message)
 	})
 
 	Window.SetCharCallback(func(Window *glfw.Window, char rune) {
+		if Input.realInputDisabled || Input.ignored {
+			return
+		}
 		Mailbox.Dispatch(TextMessage{char})
 	})
 
@@ -200,6 +249,80 @@ func CreateWindow(title string, width, height int, fullscreen bool, msaa int) {
 	})
 }
 
+// windowedX and windowedY are the position the window is restored to once it leaves fullscreen.
+var windowedX, windowedY int
+
+// SetFullscreen switches the window to or from fullscreen mode while the game is running, such as from a settings
+// menu. The window goes fullscreen on the monitor it's mostly on, at the current video mode of that monitor, and
+// returns to the size and position it had before once it leaves fullscreen. The sizes of the window, canvas and game
+// are updated, and a WindowResizeMessage is dispatched, once GLFW reports the new size during the next frame.
+func SetFullscreen(fullscreen bool) {
+	if opts.HeadlessMode {
+		setHeadlessFullscreen(fullscreen)
+		return
+	}
+	if fullscreen == opts.Fullscreen {
+		return
+	}
+
+	if fullscreen {
+		monitor := windowMonitor()
+		if monitor == nil {
+			warning("unable to switch to fullscreen, no monitor was found")
+			return
+		}
+		windowedX, windowedY = Window.GetPos()
+		windowedWidth, windowedHeight = Window.GetSize()
+		mode := monitor.GetVideoMode()
+		Window.SetMonitor(monitor, 0, 0, mode.Width, mode.Height, mode.RefreshRate)
+	} else {
+		if windowedWidth <= 0 || windowedHeight <= 0 {
+			windowedWidth, windowedHeight = headlessWidth, headlessHeight
+		}
+		Window.SetMonitor(nil, windowedX, windowedY, windowedWidth, windowedHeight, glfw.DontCare)
+	}
+	opts.Fullscreen = fullscreen
+}
+
+// SetWindowSize resizes the window while the game is running, to the given size in screen coordinates. The sizes of
+// the window, canvas and game are updated, and a WindowResizeMessage is dispatched, once GLFW reports the new size
+// during the next frame. While the window is fullscreen, it's resized once it leaves fullscreen instead.
+func SetWindowSize(width, height int) {
+	if opts.HeadlessMode {
+		setHeadlessWindowSize(width, height)
+		return
+	}
+	if !validWindowSize(width, height) {
+		return
+	}
+	if opts.Fullscreen {
+		windowedWidth, windowedHeight = width, height
+		return
+	}
+	Window.SetSize(width, height)
+}
+
+// windowMonitor returns the monitor which the largest part of the window is on, or the primary monitor if the window
+// isn't on any of them.
+func windowMonitor() *glfw.Monitor {
+	x, y := Window.GetPos()
+	w, h := Window.GetSize()
+
+	best, bestArea := glfw.GetPrimaryMonitor(), 0
+	for _, monitor := range glfw.GetMonitors() {
+		mode := monitor.GetVideoMode()
+		if mode == nil {
+			continue
+		}
+		mx, my := monitor.GetPos()
+		area := overlap(x, x+w, mx, mx+mode.Width) * overlap(y, y+h, my, my+mode.Height)
+		if area > bestArea {
+			best, bestArea = monitor, area
+		}
+	}
+	return best
+}
+
 // DestroyWindow handles the termination of windows
 func DestroyWindow() {
 	glfw.Terminate()
@@ -214,6 +337,28 @@ func SetTitle(title string) {
 	}
 }
 
+// ClipboardText returns the text on the clipboard of the system, or an empty string when it holds something else,
+// such as an image. While running headless, the clipboard only holds the text set using SetClipboardText.
+func ClipboardText() string {
+	if opts.HeadlessMode {
+		return clipboard
+	}
+	text, err := Window.GetClipboardString()
+	if err != nil {
+		return ""
+	}
+	return text
+}
+
+// SetClipboardText puts the given text on the clipboard of the system.
+func SetClipboardText(text string) {
+	if opts.HeadlessMode {
+		clipboard = text
+		return
+	}
+	Window.SetClipboardString(text)
+}
+
 // RunIteration runs one iteration per frame
 func RunIteration() {
 	Time.Tick()
@@ -224,14 +369,17 @@ func RunIteration() {
 		glfw.PollEvents()
 	}
 
+	Input.consumeEvents()
+
 	// Then update the world and all Systems
-	currentUpdater.Update(Time.Delta())
+	updateScene(Time.Delta())
 
 	// Lastly, forget keypresses and swap buffers
 	if !opts.HeadlessMode {
 		// reset values to avoid catching the same "signal" twice
 		Input.Mouse.ScrollX, Input.Mouse.ScrollY = 0, 0
-		Input.Mouse.Action = Neutral
+		Input.Mouse.DeltaX, Input.Mouse.DeltaY = 0, 0
+		Input.Mouse.endFrame()
 	}
 }
 
@@ -251,7 +399,7 @@ func runLoop(defaultScene Scene, headless bool) {
 	}()
 
 	RunPreparation(defaultScene)
-	ticker := time.NewTicker(time.Duration(int(time.Second) / opts.FPSLimit))
+	ticker := newFrameTicker()
 
 	// Start tick, minimize the delta
 	Time.Tick()
@@ -262,7 +410,7 @@ func runLoop(defaultScene Scene, headless bool) {
 			RunIteration()
 		case <-resetLoopTicker:
 			ticker.Stop()
-			ticker = time.NewTicker(time.Duration(int(time.Second) / opts.FPSLimit))
+			ticker = newFrameTicker()
 		case <-closeGame:
 			ticker.Stop()
 			closeEvent()
@@ -282,6 +430,14 @@ func WindowSize() (w, h int) {
 	return Window.GetSize()
 }
 
+// WindowFocused indicates whether or not the window has input focus
+func WindowFocused() bool {
+	if opts.HeadlessMode {
+		return headlessFocused
+	}
+	return Window.GetAttrib(glfw.Focused) == glfw.True
+}
+
 // WindowWidth gets the current window width
 func WindowWidth() float32 {
 	return windowWidth
@@ -304,9 +460,20 @@ func CanvasHeight() float32 {
 
 // CanvasScale gets the ratio of the canvas to the window sizes
 func CanvasScale() float32 {
+	if opts.HeadlessMode {
+		return contentScale
+	}
 	return scale
 }
 
+// ContentScale returns the ratio between the DPI of the display the window is on and the default DPI of the platform,
+// such as 2 on a Retina display, for scaling text and the HUD so they keep their size on such displays. GLFW for Vulkan doesn't
+// report the DPI of the monitor, so it's the CanvasScale: the ratio by which the canvas is larger than the window on
+// high-DPI displays where the window is measured in points, such as on macOS.
+func ContentScale() float32 {
+	return CanvasScale()
+}
+
 // SetCursor sets the pointer of the mouse to the defined standard cursor
 func SetCursor(c Cursor) {
 	var cur *glfw.Cursor
@@ -325,10 +492,36 @@ func SetCursor(c Cursor) {
 		cur = cursorHResize
 	case CursorVResize:
 		cur = cursorVResize
+	default:
+		cur = customCursors[c]
 	}
 	Window.SetCursor(cur)
 }
 
+// CreateCursor creates a custom cursor from the given image, which can be passed to SetCursor. The hotspot at hotX,
+// hotY is the pixel of the image which points at the position of the mouse, such as the center of a crosshair. If
+// the cursor can't be created, CursorArrow is returned instead.
+func CreateCursor(img image.Image, hotX, hotY int) (c Cursor) {
+	c, ok := newCustomCursor(img, hotX, hotY)
+	if !ok {
+		return c
+	}
+	defer func() {
+		if err := recover(); err != nil {
+			log.Println("[WARNING] Unable to create cursor:", err)
+			c = CursorArrow
+		}
+	}()
+	customCursors[c] = glfw.CreateCursor(img, hotX, hotY)
+	return c
+}
+
+// SetVSync has no effect yet with vulkan, since there's no OpenGL context to swap the buffers of; use
+// `SetFPSLimit` instead
+func SetVSync(enabled bool) {
+	opts.VSync = enabled
+}
+
 //SetCursorVisibility sets the visibility of the cursor.
 //If true the cursor is visible, if false the cursor is not.
 func SetCursorVisibility(visible bool) {
@@ -339,6 +532,26 @@ func SetCursorVisibility(visible bool) {
 	}
 }
 
+// SetCursorGrabbed hides the cursor and locks it to the window when grabbed is true, such as for controlling the camera
+// of a first-person game. While grabbed, Mouse.X and Mouse.Y stay where the cursor was grabbed, and the movement of
+// the mouse is only reported in Mouse.DeltaX and Mouse.DeltaY, without it being limited by the edges of the
+// screen. Releasing the cursor shows it again at the position where it was grabbed.
+func SetCursorGrabbed(grabbed bool) {
+	if !grabCursor(grabbed) {
+		return
+	}
+
+	if grabbed {
+		Window.SetInputMode(glfw.CursorMode, glfw.CursorDisabled)
+		cursorX, cursorY = Window.GetCursorPos()
+		return
+	}
+
+	Window.SetInputMode(glfw.CursorMode, glfw.CursorNormal)
+	cursorX, cursorY = float64(Input.Mouse.X*opts.GlobalScale.X), float64(Input.Mouse.Y*opts.GlobalScale.Y)
+	Window.SetCursorPos(cursorX, cursorY)
+}
+
 // openFile is the desktop-specific way of opening a file
 func openFile(url string) (io.ReadCloser, error) {
 	return os.Open(url)
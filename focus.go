@@ -0,0 +1,20 @@
+package engo
+
+var (
+	// focused indicates whether or not the window had focus during the previous frame
+	focused = true
+	// headlessFocused indicates whether or not the window has focus while running headless, see `SetWindowFocused`
+	headlessFocused = true
+)
+
+// updateFocus dispatches a WindowFocusMessage whenever the window gained or lost focus since the previous frame.
+func updateFocus() {
+	f := WindowFocused()
+	if f == focused {
+		return
+	}
+	focused = f
+	if Mailbox != nil {
+		Mailbox.Dispatch(WindowFocusMessage{Focused: f})
+	}
+}
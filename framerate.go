@@ -0,0 +1,10 @@
+package engo
+
+import "time"
+
+// newFrameTicker returns a ticker which ticks whenever the game loop should draw the next frame, at the rate of
+// `RunOptions.FPSLimit`. Since the ticker keeps a steady rate regardless of how long a frame took, the delta time
+// stays stable even without VSync.
+func newFrameTicker() *time.Ticker {
+	return time.NewTicker(time.Second / time.Duration(opts.FPSLimit))
+}
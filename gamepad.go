@@ -0,0 +1,279 @@
+package engo
+
+import "math"
+
+// GamepadAxis identifies one of the analog axes of a gamepad. The axes are numbered the same as the standard
+// gamepad mapping of GLFW.
+type GamepadAxis int
+
+const (
+	// GamepadAxisLeftX is the horizontal axis of the left stick
+	GamepadAxisLeftX GamepadAxis = iota
+	// GamepadAxisLeftY is the vertical axis of the left stick
+	GamepadAxisLeftY
+	// GamepadAxisRightX is the horizontal axis of the right stick
+	GamepadAxisRightX
+	// GamepadAxisRightY is the vertical axis of the right stick
+	GamepadAxisRightY
+	// GamepadAxisLeftTrigger is the axis of the left trigger
+	GamepadAxisLeftTrigger
+	// GamepadAxisRightTrigger is the axis of the right trigger
+	GamepadAxisRightTrigger
+
+	gamepadAxisCount
+)
+
+// GamepadButton identifies one of the buttons of a gamepad. The buttons are numbered the same as the standard
+// gamepad mapping of GLFW.
+type GamepadButton int
+
+const (
+	// GamepadButtonA is the bottom face button
+	GamepadButtonA GamepadButton = iota
+	// GamepadButtonB is the right face button
+	GamepadButtonB
+	// GamepadButtonX is the left face button
+	GamepadButtonX
+	// GamepadButtonY is the top face button
+	GamepadButtonY
+	// GamepadButtonLeftBumper is the left shoulder button
+	GamepadButtonLeftBumper
+	// GamepadButtonRightBumper is the right shoulder button
+	GamepadButtonRightBumper
+	// GamepadButtonBack is the back or select button
+	GamepadButtonBack
+	// GamepadButtonStart is the start button
+	GamepadButtonStart
+	// GamepadButtonGuide is the button in the middle of the gamepad, such as its logo
+	GamepadButtonGuide
+	// GamepadButtonLeftThumb is the button of the left stick, pressed by pushing it in
+	GamepadButtonLeftThumb
+	// GamepadButtonRightThumb is the button of the right stick, pressed by pushing it in
+	GamepadButtonRightThumb
+	// GamepadButtonDpadUp is the up button of the directional pad
+	GamepadButtonDpadUp
+	// GamepadButtonDpadRight is the right button of the directional pad
+	GamepadButtonDpadRight
+	// GamepadButtonDpadDown is the down button of the directional pad
+	GamepadButtonDpadDown
+	// GamepadButtonDpadLeft is the left button of the directional pad
+	GamepadButtonDpadLeft
+
+	gamepadButtonCount
+)
+
+// Gamepad holds the state of the analog axes and the buttons of a single gamepad, along with the dead-zones and
+// response curves used for cleaning up the values of its axes. Gamepads are retrieved using `InputManager.Gamepad`,
+// and are currently only updated by the GLFW backend. A gamepad which is not connected has all of its axes centered
+// and none of its buttons pressed.
+type Gamepad struct {
+	// Connected indicates whether or not the gamepad is currently connected.
+	Connected bool
+
+	buttons, previous [gamepadButtonCount]bool
+
+	raw       [gamepadAxisCount]float32
+	deadzones [gamepadAxisCount]float32
+	curves    [gamepadAxisCount]responseCurve
+	radial    []radialDeadzone
+
+	// ignored indicates the axes and buttons are not reported during this frame, see `InputManager.ignore`
+	ignored bool
+}
+
+// responseCurve maps the value of an axis outside of its dead-zone to sensitivity * value^exponent.
+type responseCurve struct {
+	sensitivity, exponent float32
+}
+
+// radialDeadzone is a dead-zone applied to the distance a stick moved from its center, rather than to each of the
+// axes of that stick separately.
+type radialDeadzone struct {
+	x, y  GamepadAxis
+	value float32
+}
+
+// SetDeadzone sets the dead-zone of the given axis. Whenever the axis is moved less than value (between 0 and 1)
+// from its center, it is reported as AxisNeutral. Values outside of the dead-zone are rescaled, so the axis still
+// covers the full range from AxisMin to AxisMax.
+func (g *Gamepad) SetDeadzone(axis GamepadAxis, value float32) {
+	if axis < 0 || axis >= gamepadAxisCount {
+		return
+	}
+	g.deadzones[axis] = value
+}
+
+// SetRadialDeadzone sets a dead-zone for the stick made up of the axes x and y. Whenever the stick is moved less
+// than value (between 0 and 1) from its center in any direction, both axes are reported as AxisNeutral. Unlike the
+// dead-zone of SetDeadzone, this does not make it harder to move the stick along a diagonal. The radial dead-zone
+// replaces the dead-zones of both axes.
+func (g *Gamepad) SetRadialDeadzone(x, y GamepadAxis, value float32) {
+	for i, r := range g.radial {
+		if r.x == x && r.y == y {
+			g.radial[i].value = value
+			return
+		}
+	}
+	g.radial = append(g.radial, radialDeadzone{x: x, y: y, value: value})
+}
+
+// SetResponseCurve sets how the value of the given axis is reported, after applying its dead-zone. The value is
+// raised to the power of exponent, and then multiplied by sensitivity. An exponent above 1 allows for more precise
+// movements near the center of the stick. Values of 0 are treated as 1.
+func (g *Gamepad) SetResponseCurve(axis GamepadAxis, sensitivity, exponent float32) {
+	if axis < 0 || axis >= gamepadAxisCount {
+		return
+	}
+	g.curves[axis] = responseCurve{sensitivity: sensitivity, exponent: exponent}
+}
+
+// RawAxis returns the value of the given axis as reported by the gamepad.
+func (g *Gamepad) RawAxis(axis GamepadAxis) float32 {
+	if g.ignored || axis < 0 || axis >= gamepadAxisCount {
+		return AxisNeutral
+	}
+	return g.raw[axis]
+}
+
+// Axis returns the value of the given axis, after applying its dead-zone and response curve.
+func (g *Gamepad) Axis(axis GamepadAxis) float32 {
+	if g.ignored || axis < 0 || axis >= gamepadAxisCount {
+		return AxisNeutral
+	}
+
+	v := g.raw[axis]
+	if r, ok := g.radialDeadzone(axis); ok {
+		x, y := float64(g.raw[r.x]), float64(g.raw[r.y])
+		length := math.Hypot(x, y)
+		if length <= float64(r.value) {
+			return AxisNeutral
+		}
+		v = float32(float64(v) / length * rescale(length, float64(r.value)))
+	} else {
+		length := math.Abs(float64(v))
+		if length <= float64(g.deadzones[axis]) {
+			return AxisNeutral
+		}
+		v = float32(math.Copysign(rescale(length, float64(g.deadzones[axis])), float64(v)))
+	}
+
+	return g.curves[axis].apply(v)
+}
+
+// ButtonDown indicates whether the given button is currently pressed.
+func (g *Gamepad) ButtonDown(button GamepadButton) bool {
+	if g.ignored || button < 0 || button >= gamepadButtonCount {
+		return false
+	}
+	return g.buttons[button]
+}
+
+// ButtonJustPressed indicates whether the given button was pressed since the previous frame.
+func (g *Gamepad) ButtonJustPressed(button GamepadButton) bool {
+	if g.ignored || button < 0 || button >= gamepadButtonCount {
+		return false
+	}
+	return g.buttons[button] && !g.previous[button]
+}
+
+// ButtonJustReleased indicates whether the given button was released since the previous frame.
+func (g *Gamepad) ButtonJustReleased(button GamepadButton) bool {
+	if g.ignored || button < 0 || button >= gamepadButtonCount {
+		return false
+	}
+	return !g.buttons[button] && g.previous[button]
+}
+
+// radialDeadzone returns the radial dead-zone the given axis is part of, if any.
+func (g *Gamepad) radialDeadzone(axis GamepadAxis) (radialDeadzone, bool) {
+	for _, r := range g.radial {
+		if r.x == axis || r.y == axis {
+			return r, true
+		}
+	}
+	return radialDeadzone{}, false
+}
+
+// setAxis is used by the backends to update the raw value of an axis.
+func (g *Gamepad) setAxis(axis GamepadAxis, value float32) {
+	g.raw[axis] = value
+}
+
+// setButton is used by the backends to update the state of a button, once every frame.
+func (g *Gamepad) setButton(button GamepadButton, down bool) {
+	g.previous[button] = g.buttons[button]
+	g.buttons[button] = down
+}
+
+// disconnect is used by the backends whenever the gamepad is no longer connected, which centers all of its axes and
+// releases all of its buttons.
+func (g *Gamepad) disconnect() {
+	g.Connected = false
+	g.raw = [gamepadAxisCount]float32{}
+	g.buttons = [gamepadButtonCount]bool{}
+	g.previous = [gamepadButtonCount]bool{}
+}
+
+// rescale maps length from the range [deadzone, 1] to [0, 1].
+func rescale(length, deadzone float64) float64 {
+	if deadzone >= 1 {
+		return 0
+	}
+	return math.Min((length-deadzone)/(1-deadzone), 1)
+}
+
+// apply applies the response curve to v.
+func (c responseCurve) apply(v float32) float32 {
+	sensitivity, exponent := c.sensitivity, c.exponent
+	if sensitivity == 0 {
+		sensitivity = 1
+	}
+	if exponent == 0 {
+		exponent = 1
+	}
+	curved := float64(sensitivity) * math.Pow(math.Abs(float64(v)), float64(exponent))
+	return float32(math.Copysign(math.Min(curved, 1), float64(v)))
+}
+
+// An AxisGamepad is an axis of a gamepad, which can be used by an Axis. Its value has the dead-zone and response
+// curve of that axis applied.
+type AxisGamepad struct {
+	// Gamepad is the id of the gamepad, starting at 0 for the first one
+	Gamepad int
+	// Axis is the axis of that gamepad
+	Axis GamepadAxis
+}
+
+// Value returns the value of the gamepad axis.
+func (a AxisGamepad) Value() float32 {
+	return Input.Gamepad(a.Gamepad).Axis(a.Axis)
+}
+
+// An AxisGamepadButtonPair is a set of Min/Max buttons of a gamepad which can be used by an Axis, such as the left and
+// right buttons of the directional pad.
+type AxisGamepadButtonPair struct {
+	// Gamepad is the id of the gamepad, starting at 0 for the first one
+	Gamepad int
+	Min     GamepadButton
+	Max     GamepadButton
+}
+
+// Value returns the value of the pressed button.
+func (pair AxisGamepadButtonPair) Value() float32 {
+	g := Input.Gamepad(pair.Gamepad)
+	if g.ButtonDown(pair.Max) {
+		return AxisMax
+	} else if g.ButtonDown(pair.Min) {
+		return AxisMin
+	}
+
+	return AxisNeutral
+}
+
+// A ButtonGamepad is a button of a gamepad, which can trigger a Button. See `InputManager.RegisterGamepadButton`.
+type ButtonGamepad struct {
+	// Gamepad is the id of the gamepad, starting at 0 for the first one
+	Gamepad int
+	// Button is the button of that gamepad
+	Button GamepadButton
+}
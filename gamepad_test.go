@@ -0,0 +1,97 @@
+package engo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGamepadDeadzone(t *testing.T) {
+	g := &Gamepad{}
+	g.SetDeadzone(GamepadAxisLeftX, 0.2)
+
+	for _, noise := range []float32{0.01, -0.05, 0.15, -0.19, 0.2} {
+		g.setAxis(GamepadAxisLeftX, noise)
+		if v := g.Axis(GamepadAxisLeftX); v != AxisNeutral {
+			t.Errorf("noise inside the dead-zone was not ignored. Wanted: %v for %v, got: %v", AxisNeutral, noise, v)
+		}
+	}
+	if v := g.RawAxis(GamepadAxisLeftX); v != 0.2 {
+		t.Errorf("raw value was changed by the dead-zone. Wanted: %v, got: %v", 0.2, v)
+	}
+
+	tests := []struct {
+		raw, expected float32
+	}{
+		{0.6, 0.5},
+		{-0.6, -0.5},
+		{1, AxisMax},
+		{-1, AxisMin},
+	}
+	for _, test := range tests {
+		g.setAxis(GamepadAxisLeftX, test.raw)
+		if v := g.Axis(GamepadAxisLeftX); math.Abs(float64(v-test.expected)) > 1e-6 {
+			t.Errorf("value outside the dead-zone was not rescaled. Wanted: %v for %v, got: %v", test.expected, test.raw, v)
+		}
+	}
+}
+
+func TestGamepadRadialDeadzone(t *testing.T) {
+	g := &Gamepad{}
+	g.SetRadialDeadzone(GamepadAxisRightX, GamepadAxisRightY, 0.25)
+
+	// Each axis is within the dead-zone, but not the stick as a whole.
+	g.setAxis(GamepadAxisRightX, 0.2)
+	g.setAxis(GamepadAxisRightY, 0.2)
+	if x, y := g.Axis(GamepadAxisRightX), g.Axis(GamepadAxisRightY); x <= 0 || x != y {
+		t.Errorf("diagonal movement outside the radial dead-zone was ignored, got: (%v, %v)", x, y)
+	}
+
+	g.setAxis(GamepadAxisRightX, 0.15)
+	g.setAxis(GamepadAxisRightY, -0.1)
+	if x, y := g.Axis(GamepadAxisRightX), g.Axis(GamepadAxisRightY); x != AxisNeutral || y != AxisNeutral {
+		t.Errorf("movement inside the radial dead-zone was not ignored, got: (%v, %v)", x, y)
+	}
+}
+
+func TestGamepadResponseCurve(t *testing.T) {
+	g := &Gamepad{}
+	g.SetResponseCurve(GamepadAxisLeftY, 2, 2)
+
+	g.setAxis(GamepadAxisLeftY, -0.5)
+	if v := g.Axis(GamepadAxisLeftY); v != -0.5 {
+		t.Errorf("response curve was not applied. Wanted: %v, got: %v", -0.5, v)
+	}
+	g.setAxis(GamepadAxisLeftY, 0.9)
+	if v := g.Axis(GamepadAxisLeftY); v != AxisMax {
+		t.Errorf("curved value was not clamped. Wanted: %v, got: %v", AxisMax, v)
+	}
+}
+
+func TestGamepadButtons(t *testing.T) {
+	g := &Gamepad{Connected: true}
+
+	g.setButton(GamepadButtonA, true)
+	if !g.ButtonDown(GamepadButtonA) || !g.ButtonJustPressed(GamepadButtonA) || g.ButtonJustReleased(GamepadButtonA) {
+		t.Error("pressed button was not reported as just pressed")
+	}
+	g.setButton(GamepadButtonA, true)
+	if !g.ButtonDown(GamepadButtonA) || g.ButtonJustPressed(GamepadButtonA) {
+		t.Error("held button was reported as just pressed")
+	}
+	g.setButton(GamepadButtonA, false)
+	if g.ButtonDown(GamepadButtonA) || !g.ButtonJustReleased(GamepadButtonA) {
+		t.Error("released button was not reported as just released")
+	}
+
+	// A disconnected gamepad has none of its buttons pressed, rather than keeping their last state.
+	g.setButton(GamepadButtonStart, true)
+	g.setAxis(GamepadAxisLeftX, 1)
+	g.disconnect()
+	if g.ButtonDown(GamepadButtonStart) || g.ButtonJustReleased(GamepadButtonStart) || g.Axis(GamepadAxisLeftX) != AxisNeutral {
+		t.Error("disconnected gamepad still reported its buttons or axes")
+	}
+
+	if g.ButtonDown(-1) || g.ButtonDown(gamepadButtonCount) || g.ButtonJustPressed(gamepadButtonCount) {
+		t.Error("buttons out of range were reported as pressed")
+	}
+}
@@ -0,0 +1,70 @@
+package engo
+
+// Step runs a single frame of the current scene, updating all of its systems with the given delta time (scaled by the
+// time scale, see `SetTimeScale`), the same way the run loop does for every frame. It's meant for testing systems deterministically without a window: call `Run`
+// with NoRun and HeadlessMode set to set up the scene, set the input of the frame using `InputManager.SetKey`,
+// `InputManager.SetMouseButton` and the fields of `Input.Mouse`, and call Step to update the world with it.
+//
+// Like the run loop, Step consumes the events enqueued using `InputManager.Enqueue`, and updates whether or not the
+// mouse is Inside the game area (clamping it if ClampMouse is set) before updating the scene. Afterwards, it resets
+// the Action, scroll and delta of the mouse, and carries the keys and mouse buttons over to the next frame. A key set
+// before one Step is JustPressed during that frame, and Down during the next.
+//
+// While running headless, the following parts of the engine are unavailable:
+//   - there is no window and no cursor; `CursorPos` returns (0, 0) and `SetCursor` has no effect
+//   - nothing is drawn: the common.RenderSystem keeps track of its entities without drawing them, textures are not
+//     uploaded, and post-processing passes and render targets are skipped
+//   - audio is advanced without being played
+//   - gamepads are not polled, and all input has to be set or enqueued by the test itself
+//   - `Time` measures real time, so pass the delta time to Step explicitly rather than relying on the clock
+func Step(dt float32) {
+	Input.consumeEvents()
+	Input.updateMouse()
+	updateScene(dt)
+
+	Input.update()
+	Input.Mouse.ScrollX, Input.Mouse.ScrollY = 0, 0
+	Input.Mouse.DeltaX, Input.Mouse.DeltaY = 0, 0
+	Input.Mouse.endFrame()
+}
+
+// SetKey sets whether or not the given key is held down, as both a regular and a physical key, the same way the
+// backends do whenever a key is pressed or released. It's meant for testing, see `Step`.
+func (im *InputManager) SetKey(k Key, down bool) {
+	im.keys.Set(k, down)
+	im.keys.SetPhysical(k, down)
+}
+
+// SetWindowFocused sets whether or not the window has input focus while running headless, the same way switching to
+// another window and back does: `WindowFocused` returns it, and the next Step dispatches a WindowFocusMessage if it
+// changed. It's meant for testing, see `Step`, and has no effect on mobile or unless running headless.
+func SetWindowFocused(focused bool) {
+	headlessFocused = focused
+}
+
+// SetContentScale sets the content scale of the display while running headless, the same way moving the window onto a
+// high-DPI display such as a Retina display does on macOS: `ContentScale` and `CanvasScale` return it, the canvas
+// becomes that many times as large as the window, and a WindowResizeMessage is dispatched. The window, the mouse and
+// the game keep their size. It's meant for testing, see `Step`, and has no effect unless running headless, or if the
+// scale is zero or less.
+func SetContentScale(scale float32) {
+	if !opts.HeadlessMode || scale <= 0 {
+		return
+	}
+	contentScale = scale
+	resizeHeadlessWindow(int(windowWidth), int(windowHeight))
+}
+
+// SetMouseButton sets whether or not the given mouse button is held down, the same way the backends do whenever a
+// mouse button is pressed or released: Mouse.Button becomes the given button, and Mouse.Action becomes Press or
+// Release. It's meant for testing, see `Step`.
+func (im *InputManager) SetMouseButton(b MouseButton, down bool) {
+	im.Mouse.Button = b
+	im.Mouse.setButton(b, down)
+	if down {
+		im.Mouse.Action = Press
+	} else {
+		im.Mouse.Action = Release
+	}
+	im.Mouse.addEvent()
+}
@@ -0,0 +1,102 @@
+package engo
+
+import (
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+)
+
+// inputRecordingSystem records the input it sees during every update.
+type inputRecordingSystem struct {
+	jumpPressed, jumpDown []bool
+	actions               []Action
+	leftDown              []bool
+}
+
+func (*inputRecordingSystem) Remove(ecs.BasicEntity) {}
+
+func (s *inputRecordingSystem) Update(dt float32) {
+	s.jumpPressed = append(s.jumpPressed, Input.Button("jump").JustPressed())
+	s.jumpDown = append(s.jumpDown, Input.Button("jump").Down())
+	s.actions = append(s.actions, Input.Mouse.Action)
+	s.leftDown = append(s.leftDown, Input.Mouse.ButtonDown(MouseButtonLeft))
+}
+
+type stepTestScene struct {
+	sys *inputRecordingSystem
+}
+
+func (*stepTestScene) Preload() {}
+
+func (s *stepTestScene) Setup(u Updater) {
+	s.sys = &inputRecordingSystem{}
+	u.(*ecs.World).AddSystem(s.sys)
+}
+
+func (*stepTestScene) Type() string { return "stepTestScene" }
+
+func TestStep(t *testing.T) {
+	scene := &stepTestScene{}
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        100,
+		Height:       50,
+	}, scene)
+	Input.RegisterButton("jump", KeySpace)
+
+	Input.SetKey(KeySpace, true)
+	Input.Mouse.X, Input.Mouse.Y = 150, 20
+	Input.SetMouseButton(MouseButtonLeft, true)
+	Step(1)
+	if Input.Mouse.Inside {
+		t.Error("mouse outside of the game area was considered inside")
+	}
+	Step(1)
+	Input.SetKey(KeySpace, false)
+	Input.SetMouseButton(MouseButtonLeft, false)
+	Step(1)
+
+	sys := scene.sys
+	if len(sys.actions) != 3 {
+		t.Fatalf("scene was not updated once per step. Wanted: 3 updates, got: %v", len(sys.actions))
+	}
+	if !sys.jumpPressed[0] || sys.jumpPressed[1] || sys.jumpPressed[2] {
+		t.Errorf("key was not just pressed during the first step only, got: %v", sys.jumpPressed)
+	}
+	if sys.jumpDown[0] || !sys.jumpDown[1] || sys.jumpDown[2] {
+		t.Errorf("key was not down from the frame after it was pressed until it was released, got: %v", sys.jumpDown)
+	}
+	if sys.actions[0] != Press || sys.actions[1] != Neutral || sys.actions[2] != Release {
+		t.Errorf("mouse actions were not reset after every step, got: %v", sys.actions)
+	}
+	if sys.leftDown[0] || !sys.leftDown[1] || sys.leftDown[2] {
+		t.Errorf("mouse button was not down from the frame after it was pressed until it was released, got: %v", sys.leftDown)
+	}
+}
+
+func TestSetWindowFocused(t *testing.T) {
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &stepTestScene{})
+	defer SetWindowFocused(true)
+
+	var messages []bool
+	Mailbox.Listen("WindowFocusMessage", func(msg Message) {
+		messages = append(messages, msg.(WindowFocusMessage).Focused)
+	})
+
+	Step(1)
+	SetWindowFocused(false)
+	if WindowFocused() {
+		t.Error("window still had focus after it was lost")
+	}
+	Step(1)
+	Step(1)
+	SetWindowFocused(true)
+	Step(1)
+	if len(messages) != 2 || messages[0] || !messages[1] {
+		t.Errorf("focus changes were not dispatched once each. Wanted: [false true], got: %v", messages)
+	}
+}
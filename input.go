@@ -1,5 +1,11 @@
 package engo
 
+import (
+	"sort"
+
+	"github.com/EngoEngine/engo/math"
+)
+
 const (
 	// AxisMax is the maximum value a joystick or keypress axis will reach
 	AxisMax float32 = 1
@@ -12,10 +18,13 @@ const (
 // NewInputManager holds onto anything input related for engo
 func NewInputManager() *InputManager {
 	return &InputManager{
+		Mouse:   Mouse{Inside: true},
 		Touches: make(map[int]Point),
 		axes:    make(map[string]Axis),
 		buttons: make(map[string]Button),
 		keys:    NewKeyManager(),
+
+		gamepads: make(map[int]*Gamepad),
 	}
 }
 
@@ -25,18 +34,52 @@ type InputManager struct {
 	// Axis and Button system if at all possible.
 	Mouse Mouse
 
-	// Touches is the touches on the screen. There can be up to 5 recorded in Android,
-	// and up to 4 on iOS. GLFW can also keep track of the touches. The latest touch is also
-	// recorded in the Mouse so that touches readily work with the common.MouseSystem
+	// Touches is the touches on the screen, by their id. There can be up to 5 recorded in Android,
+	// and up to 4 on iOS. The first finger touching the screen is also recorded in the Mouse as the
+	// left mouse button, so that touches readily work with the common.MouseSystem. See `TouchPoints`
+	// for the touches ordered by their id.
 	Touches map[int]Point
 
 	axes    map[string]Axis
 	buttons map[string]Button
 	keys    *KeyManager
+
+	// primaryTouch is the id of the touch which is recorded in the Mouse, as long as touching is set
+	primaryTouch int
+	touching     bool
+
+	// ignored indicates all input is ignored during this frame, such as during a scene transition
+	ignored bool
+
+	// events are the enqueued synthetic input events which have yet to be consumed, see `Enqueue`
+	events []InputEvent
+	// realInputDisabled indicates the input reported by the backends is ignored, see `DisableRealInput`
+	realInputDisabled bool
+	// recorder records the input of every frame, and replay replays it, see `StartRecording` and `StartReplay`
+	recorder *inputRecorder
+	replay   *inputReplay
+
+	gamepads map[int]*Gamepad
 }
 
 func (im *InputManager) update() {
 	im.keys.update()
+	im.Mouse.updateButtons()
+}
+
+// ignore ignores all input during the current frame when ignored is true. Buttons and keys are not pressed, axes
+// are neutral, the mouse has no action and no buttons down, gamepads report neither, and no TextMessage is dispatched.
+func (im *InputManager) ignore(ignored bool) {
+	im.ignored = ignored
+	im.Mouse.ignored = ignored
+	for _, g := range im.gamepads {
+		g.ignored = ignored
+	}
+	if ignored {
+		im.Mouse.Action = Neutral
+		im.Mouse.ScrollX, im.Mouse.ScrollY = 0, 0
+		im.Mouse.DeltaX, im.Mouse.DeltaY = 0, 0
+	}
 }
 
 // RegisterAxis registers a new axis which can be used to retrieve inputs which are spectrums.
@@ -47,7 +90,10 @@ func (im *InputManager) RegisterAxis(name string, pairs ...AxisPair) {
 	}
 }
 
-// RegisterButton registers a new button input.
+// RegisterButton registers a new button input, triggered by the keys as printed on them in the current keyboard
+// layout. Up until physical keys were tracked separately, desktop bindings matched the position of keys instead, so
+// WASD bindings registered using RegisterButton now move along with the letters on an AZERTY keyboard. Use
+// RegisterPhysicalButton for bindings which should stay in place, see `KeyManager`.
 func (im *InputManager) RegisterButton(name string, keys ...Key) {
 	im.buttons[name] = Button{
 		Triggers: keys,
@@ -55,6 +101,37 @@ func (im *InputManager) RegisterButton(name string, keys ...Key) {
 	}
 }
 
+// RegisterPhysicalButton registers a new button input, triggered by the keys at the given positions on a US keyboard
+// layout, regardless of the keyboard layout in use. See `KeyManager` for the difference with RegisterButton.
+func (im *InputManager) RegisterPhysicalButton(name string, keys ...Key) {
+	im.buttons[name] = Button{
+		Triggers: keys,
+		Name:     name,
+		Physical: true,
+	}
+}
+
+// RegisterGamepadButton adds the given gamepad buttons as triggers of the button with the given name, so the same
+// action can be performed using either the keyboard or a gamepad. The keys registered using RegisterButton or
+// RegisterPhysicalButton are kept, so this is to be called after those.
+func (im *InputManager) RegisterGamepadButton(name string, buttons ...ButtonGamepad) {
+	b := im.buttons[name]
+	b.Name = name
+	b.GamepadTriggers = append(b.GamepadTriggers, buttons...)
+	im.buttons[name] = b
+}
+
+// Gamepad retrieves the gamepad with the given id, starting at 0 for the first gamepad. The gamepad is returned even
+// when it is not connected, so its dead-zones can be configured before it is.
+func (im *InputManager) Gamepad(id int) *Gamepad {
+	g, ok := im.gamepads[id]
+	if !ok {
+		g = &Gamepad{ignored: im.ignored}
+		im.gamepads[id] = g
+	}
+	return g
+}
+
 // Axis retrieves an Axis with a specified name.
 func (im *InputManager) Axis(name string) Axis {
 	return im.axes[name]
@@ -65,11 +142,198 @@ func (im *InputManager) Button(name string) Button {
 	return im.buttons[name]
 }
 
+// Key retrieves the state of the given key, as printed on it in the current keyboard layout, for systems handling
+// keys directly rather than through a registered Button, such as text input. Like a Button, no key is pressed while
+// the input is ignored.
+func (im *InputManager) Key(k Key) KeyState {
+	if im.ignored {
+		return KeyState{}
+	}
+	return im.keys.Get(k)
+}
+
 // Mouse represents the mouse
 type Mouse struct {
-	X, Y             float32
+	X, Y float32
+	// DeltaX and DeltaY are the movement of the mouse during the previous frame, in the same units as X and Y. Unlike
+	// X and Y, they are not limited by the edges of the window, and keep being updated while the cursor is grabbed
+	// using `SetCursorGrabbed`, such as for controlling a first-person camera.
+	DeltaX, DeltaY   float32
 	ScrollX, ScrollY float32
-	Action           Action
-	Button           MouseButton
-	Modifer          Modifier
+	// Action and Button are the latest action of the mouse during the current frame, and the button it was done with.
+	// See Events for every button pressed or released during the frame.
+	Action  Action
+	Button  MouseButton
+	Modifer Modifier
+	// Inside indicates whether or not the cursor is currently within the game area. This is always true for backends
+	// which do not report the position of the cursor.
+	Inside bool
+
+	// buttons tracks the state of every mouse button that has been pressed, including the extra buttons found on
+	// some mice.
+	buttons map[MouseButton]KeyState
+	// events are the presses and releases of the buttons during the current frame, see Events
+	events []MouseEvent
+	// ignored indicates the buttons are not reported during this frame, see `InputManager.ignore`
+	ignored bool
+}
+
+// MouseEvent is a press or release of a mouse button, see `Mouse.Events`.
+type MouseEvent struct {
+	// X and Y are the position of the cursor at the time of the event, in the same units as Mouse.X and Mouse.Y
+	X, Y float32
+	// Action is either Press or Release
+	Action   Action
+	Button   MouseButton
+	Modifier Modifier
+	// Inside indicates whether or not the cursor was within the game area at the time of the event
+	Inside bool
+}
+
+// Events returns every press and release of a mouse button during the current frame, in the order in which they
+// happened. Action and Button only hold the latest of them, so a button pressed and released again within a single
+// frame, or two buttons pressed at once, are only fully seen through the events. No events are returned while the
+// input is ignored. The slice is reused, and is only valid until the end of the frame.
+func (m *Mouse) Events() []MouseEvent {
+	if m.ignored {
+		return nil
+	}
+	return m.events
+}
+
+// addEvent is used by the backends to record the press or release of Button at the current position of the cursor,
+// once Action and Modifer are set.
+func (m *Mouse) addEvent() {
+	m.events = append(m.events, MouseEvent{
+		X:        m.X,
+		Y:        m.Y,
+		Action:   m.Action,
+		Button:   m.Button,
+		Modifier: m.Modifer,
+		Inside:   m.Inside,
+	})
+}
+
+// endFrame forgets the action and events of the mouse at the end of a frame, to avoid catching the same "signal"
+// twice.
+func (m *Mouse) endFrame() {
+	m.Action = Neutral
+	m.events = m.events[:0]
+}
+
+// ButtonDown returns whether or not the given mouse button is being held down. Any button the backend reports can
+// be checked, such as MouseButton4 and MouseButton5 for the back and forward buttons found on many mice.
+func (m *Mouse) ButtonDown(b MouseButton) bool {
+	return !m.ignored && m.buttons[b].Down()
+}
+
+// ButtonJustPressed returns whether or not the given mouse button was pressed during the previous frame.
+func (m *Mouse) ButtonJustPressed(b MouseButton) bool {
+	return !m.ignored && m.buttons[b].JustPressed()
+}
+
+// ButtonJustReleased returns whether or not the given mouse button was released during the previous frame.
+func (m *Mouse) ButtonJustReleased(b MouseButton) bool {
+	return !m.ignored && m.buttons[b].JustReleased()
+}
+
+// setButton is used by the backends to update whether or not the given mouse button is held down.
+func (m *Mouse) setButton(b MouseButton, state bool) {
+	if m.buttons == nil {
+		m.buttons = make(map[MouseButton]KeyState)
+	}
+	ks := m.buttons[b]
+	ks.set(state)
+	m.buttons[b] = ks
+}
+
+// updateButtons carries the state of the mouse buttons over to the next frame.
+func (m *Mouse) updateButtons() {
+	for b, ks := range m.buttons {
+		ks.set(ks.currentState)
+		m.buttons[b] = ks
+	}
+}
+
+// updateMouse updates whether or not the mouse is within the game area, and clamps Mouse.X and Mouse.Y to that area
+// if the `ClampMouse` option was set. It should be called by the backends whenever they update the mouse position.
+func (im *InputManager) updateMouse() {
+	// The game area is the part of the window the game is drawn in, so the black bars of ScaleFit are outside of it
+	vp := GameViewport()
+	minX, minY := math.Max(vp.Min.X, 0)/opts.GlobalScale.X, math.Max(vp.Min.Y, 0)/opts.GlobalScale.Y
+	maxX, maxY := math.Min(vp.Max.X, WindowWidth())/opts.GlobalScale.X, math.Min(vp.Max.Y, WindowHeight())/opts.GlobalScale.Y
+	im.Mouse.Inside = AABB{Min: Point{X: minX, Y: minY}, Max: Point{X: maxX, Y: maxY}}.Contains(Point{X: im.Mouse.X, Y: im.Mouse.Y})
+	if !opts.ClampMouse {
+		return
+	}
+
+	if im.Mouse.X < minX {
+		im.Mouse.X = minX
+	} else if im.Mouse.X > maxX {
+		im.Mouse.X = maxX
+	}
+	if im.Mouse.Y < minY {
+		im.Mouse.Y = minY
+	} else if im.Mouse.Y > maxY {
+		im.Mouse.Y = maxY
+	}
+}
+
+// Touch is a finger touching the screen.
+type Touch struct {
+	// ID identifies the touch for as long as the finger touches the screen. The ids of touches that ended may be
+	// reused by the backend.
+	ID int
+	// X and Y are the position of the touch, in the same units as Mouse.X and Mouse.Y.
+	X, Y float32
+}
+
+// TouchPoints returns the touches currently on the screen ordered by their id, such as for recognizing pinch-zoom
+// gestures.
+func (im *InputManager) TouchPoints() []Touch {
+	touches := make([]Touch, 0, len(im.Touches))
+	for id, p := range im.Touches {
+		touches = append(touches, Touch{ID: id, X: p.X, Y: p.Y})
+	}
+	sort.Slice(touches, func(i, j int) bool { return touches[i].ID < touches[j].ID })
+	return touches
+}
+
+// touchEvent is used by the backends to report that the touch with the given id was pressed, moved or released at
+// the given position in pixels of the window. The position is scaled by the GlobalScale, the same way the backends
+// scale the position of the mouse, so the common.MouseSystem transforms it to world coordinates just like the
+// cursor. The first finger touching the screen is also reported as the left mouse button until it's released, so
+// taps and drags work like clicks; the other fingers are only recorded in Touches.
+func (im *InputManager) touchEvent(id int, x, y float32, action Action) {
+	if im.realInputDisabled {
+		return
+	}
+	p := Point{X: x / opts.GlobalScale.X, Y: y / opts.GlobalScale.Y}
+	if action == Release {
+		delete(im.Touches, id)
+	} else {
+		im.Touches[id] = p
+	}
+
+	if action == Press && !im.touching {
+		im.touching = true
+		im.primaryTouch = id
+	} else if !im.touching || id != im.primaryTouch {
+		return
+	}
+
+	im.Mouse.X, im.Mouse.Y = p.X, p.Y
+	im.updateMouse()
+	im.Mouse.Action = action
+	switch action {
+	case Press:
+		im.Mouse.Button = MouseButtonLeft
+		im.Mouse.setButton(MouseButtonLeft, true)
+		im.Mouse.addEvent()
+	case Release:
+		im.Mouse.Button = MouseButtonLeft
+		im.Mouse.setButton(MouseButtonLeft, false)
+		im.Mouse.addEvent()
+		im.touching = false
+	}
 }
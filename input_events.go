@@ -0,0 +1,121 @@
+package engo
+
+// InputEventType is the kind of an InputEvent.
+type InputEventType uint8
+
+const (
+	// MouseMoveEvent moves the cursor to the position of the event
+	MouseMoveEvent InputEventType = iota
+	// MousePressEvent moves the cursor to the position of the event, and presses the Button of the event there
+	MousePressEvent
+	// MouseReleaseEvent moves the cursor to the position of the event, and releases the Button of the event there
+	MouseReleaseEvent
+	// KeyPressEvent presses the Key of the event
+	KeyPressEvent
+	// KeyReleaseEvent releases the Key of the event
+	KeyReleaseEvent
+)
+
+// InputEvent is a synthetic input event, which is enqueued using `InputManager.Enqueue`.
+type InputEvent struct {
+	Type InputEventType
+	// X and Y are the position of the cursor for mouse events, in pixels of the window. Like the position reported by
+	// the backends, they are divided by the GlobalScale, and then transformed to the world by the common.MouseSystem.
+	X, Y float32
+	// Button is the mouse button of a MousePressEvent or MouseReleaseEvent
+	Button MouseButton
+	// Key is the key of a KeyPressEvent or KeyReleaseEvent, which is pressed as both a regular and a physical key
+	Key Key
+}
+
+// Enqueue enqueues synthetic input events, such as for scripted tests or playing back a recorded demo. The events go
+// through the same pipeline as the input reported by the backends: they're consumed at the start of the next frame,
+// before the scene is updated, in the order they were enqueued.
+//
+// Every frame consumes as many of the events as possible without losing any of them, the same way real input hitting
+// between two frames would be: moving the cursor multiple times within a frame only reports its last position, but
+// pressing or releasing a mouse button ends the mouse events of that frame, and a key is pressed or released at most
+// once per frame. Clicking by enqueueing a MousePressEvent and a MouseReleaseEvent thus takes two frames, so the
+// common.MouseSystem sees both. See `DisableRealInput` to keep the real input from interfering.
+func (im *InputManager) Enqueue(events ...InputEvent) {
+	im.events = append(im.events, events...)
+}
+
+// PendingEvents returns the number of enqueued events which have not been consumed yet.
+func (im *InputManager) PendingEvents() int {
+	return len(im.events)
+}
+
+// DisableRealInput sets whether or not the input reported by the backends is ignored, so only the enqueued events
+// move the mouse and press keys, see `Enqueue`. Gamepads are not affected.
+func (im *InputManager) DisableRealInput(disabled bool) {
+	im.realInputDisabled = disabled
+}
+
+// RealInputDisabled returns whether or not the input reported by the backends is ignored, see `DisableRealInput`.
+func (im *InputManager) RealInputDisabled() bool {
+	return im.realInputDisabled
+}
+
+// consumeEvents applies the replayed input and the enqueued events of the current frame, and records the resulting
+// input, see `StartReplay`, `Enqueue` and `StartRecording`. It should be called by the backends after reporting the
+// real input of the frame, right before the scene is updated.
+func (im *InputManager) consumeEvents() {
+	if im.replay != nil && !im.replay.replay(im) {
+		im.StopReplay()
+	}
+	im.consumeEnqueued()
+	if im.recorder != nil {
+		im.recorder.record(im)
+	}
+}
+
+// consumeEnqueued applies the enqueued events of the current frame, see `Enqueue`.
+func (im *InputManager) consumeEnqueued() {
+	var (
+		clicked bool
+		keys    []Key
+		n       int
+	)
+consume:
+	for ; n < len(im.events); n++ {
+		e := im.events[n]
+		switch e.Type {
+		case MouseMoveEvent, MousePressEvent, MouseReleaseEvent:
+			if clicked {
+				break consume
+			}
+			im.moveMouse(e.X, e.Y)
+			if e.Type != MouseMoveEvent {
+				im.SetMouseButton(e.Button, e.Type == MousePressEvent)
+				clicked = true
+			}
+		case KeyPressEvent, KeyReleaseEvent:
+			for _, k := range keys {
+				if k == e.Key {
+					break consume
+				}
+			}
+			keys = append(keys, e.Key)
+			im.SetKey(e.Key, e.Type == KeyPressEvent)
+		}
+	}
+
+	if n == len(im.events) {
+		im.events = nil
+	} else {
+		im.events = im.events[n:]
+	}
+}
+
+// moveMouse moves the cursor to the given position in pixels of the window, the same way the backends do.
+func (im *InputManager) moveMouse(x, y float32) {
+	x, y = x/opts.GlobalScale.X, y/opts.GlobalScale.Y
+	im.Mouse.DeltaX += x - im.Mouse.X
+	im.Mouse.DeltaY += y - im.Mouse.Y
+	im.Mouse.X, im.Mouse.Y = x, y
+	im.updateMouse()
+	if im.Mouse.Action != Release && im.Mouse.Action != Press {
+		im.Mouse.Action = Move
+	}
+}
@@ -0,0 +1,74 @@
+package engo
+
+import "testing"
+
+func TestEnqueue(t *testing.T) {
+	scene := &stepTestScene{}
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        100,
+		Height:       50,
+		GlobalScale:  Point{X: 2, Y: 2},
+	}, scene)
+	Input.RegisterButton("jump", KeySpace)
+
+	Input.Enqueue(
+		InputEvent{Type: MouseMoveEvent, X: 10, Y: 10},
+		InputEvent{Type: MouseMoveEvent, X: 40, Y: 60},
+		InputEvent{Type: KeyPressEvent, Key: KeySpace},
+		InputEvent{Type: MousePressEvent, X: 40, Y: 60, Button: MouseButtonLeft},
+		InputEvent{Type: MouseReleaseEvent, X: 40, Y: 60, Button: MouseButtonLeft},
+		InputEvent{Type: KeyReleaseEvent, Key: KeySpace},
+	)
+	Step(1)
+	if Input.Mouse.X != 20 || Input.Mouse.Y != 30 {
+		t.Errorf("position of the events was not scaled by the GlobalScale. Wanted: (20, 30), got: (%v, %v)", Input.Mouse.X, Input.Mouse.Y)
+	}
+	if n := Input.PendingEvents(); n != 2 {
+		t.Errorf("events after the mouse press were consumed during the same frame. Wanted: 2 pending, got: %v", n)
+	}
+	Step(1)
+	Step(1)
+
+	sys := scene.sys
+	if sys.actions[0] != Press || sys.actions[1] != Release || sys.actions[2] != Neutral {
+		t.Errorf("mouse events were not consumed one click per frame, got: %v", sys.actions)
+	}
+	if !sys.jumpPressed[0] || sys.jumpDown[2] {
+		t.Errorf("key was not pressed during the first frame and released during the second, got: %v and %v", sys.jumpPressed, sys.jumpDown)
+	}
+	if Input.PendingEvents() != 0 {
+		t.Errorf("events were left pending, got: %v", Input.PendingEvents())
+	}
+
+	// A key pressed and released right away takes two frames as well
+	Input.Enqueue(InputEvent{Type: KeyPressEvent, Key: KeySpace}, InputEvent{Type: KeyReleaseEvent, Key: KeySpace})
+	Step(1)
+	if !sys.jumpPressed[3] || Input.PendingEvents() != 1 {
+		t.Error("key pressed and released within one frame was not pressed during that frame")
+	}
+	Step(1)
+}
+
+func TestDisableRealInput(t *testing.T) {
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &inputTestScene{})
+	Input.DisableRealInput(true)
+	defer Input.DisableRealInput(false)
+
+	Input.touchEvent(0, 10, 20, Press)
+	if len(Input.Touches) != 0 || Input.Mouse.ButtonDown(MouseButtonLeft) || Input.Mouse.ButtonJustPressed(MouseButtonLeft) {
+		t.Error("touch was reported while real input was disabled")
+	}
+
+	Input.Enqueue(InputEvent{Type: MousePressEvent, X: 10, Y: 20, Button: MouseButtonLeft})
+	Step(0)
+	Input.Enqueue(InputEvent{Type: MouseReleaseEvent, X: 10, Y: 20, Button: MouseButtonLeft})
+	Step(0)
+	if Input.Mouse.X != 10 || Input.Mouse.Y != 20 {
+		t.Errorf("enqueued events were ignored while real input was disabled. Wanted: (10, 20), got: (%v, %v)", Input.Mouse.X, Input.Mouse.Y)
+	}
+}
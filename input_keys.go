@@ -319,3 +319,15 @@ var jsStrToKey = map[string]Key{
 	"OSRight":        KeyRightSuper,
 	"MetaRight":      KeyRightSuper,
 }
+
+// KeyByScancode returns the physical Key for the given scancode. On this platform keys are only reported by what is
+// printed on them, so the scancode is the Key itself.
+func KeyByScancode(scancode int) Key {
+	return Key(scancode)
+}
+
+// ScancodeByKey returns the scancode of the physical Key k. On this platform keys are only reported by what is
+// printed on them, so the scancode is the Key itself.
+func ScancodeByKey(k Key) int {
+	return int(k)
+}
@@ -216,3 +216,57 @@ const (
 	// KeyNumEnter represents the NumEnter keyboard key on the numpad
 	KeyNumEnter Key = Key(glfw.KeyKPEnter)
 )
+
+// layoutPunctuation maps the printable punctuation characters to the key which produces them on a US keyboard layout.
+var layoutPunctuation = map[byte]glfw.Key{
+	'`':  glfw.KeyGraveAccent,
+	'-':  glfw.KeyMinus,
+	'\'': glfw.KeyApostrophe,
+	';':  glfw.KeySemicolon,
+	'=':  glfw.KeyEqual,
+	',':  glfw.KeyComma,
+	'.':  glfw.KeyPeriod,
+	'/':  glfw.KeySlash,
+	'\\': glfw.KeyBackslash,
+	'[':  glfw.KeyLeftBracket,
+	']':  glfw.KeyRightBracket,
+}
+
+// layoutKey returns the Key printed on the physical key k in the current keyboard layout. Keys which are not
+// printable, or which are on the numpad, are the same in every layout.
+func layoutKey(k glfw.Key, scancode int) Key {
+	if k >= glfw.KeyKP0 && k <= glfw.KeyKPEqual {
+		return Key(k)
+	}
+	name := glfw.GetKeyName(k, scancode)
+	if len(name) != 1 {
+		return Key(k)
+	}
+	switch c := name[0]; {
+	case c >= 'a' && c <= 'z':
+		return Key(glfw.KeyA + glfw.Key(c-'a'))
+	case c >= '0' && c <= '9':
+		return Key(glfw.Key0 + glfw.Key(c-'0'))
+	default:
+		if key, ok := layoutPunctuation[c]; ok {
+			return Key(key)
+		}
+	}
+	return Key(k)
+}
+
+// KeyByScancode returns the physical Key for the given platform-specific scancode, which identifies a key by its
+// position on the keyboard. It returns -1 if no key has that scancode.
+func KeyByScancode(scancode int) Key {
+	for k := glfw.KeySpace; k <= glfw.KeyLast; k++ {
+		if glfw.GetKeyScancode(k) == scancode {
+			return Key(k)
+		}
+	}
+	return Key(glfw.KeyUnknown)
+}
+
+// ScancodeByKey returns the platform-specific scancode of the physical Key k, or -1 if it has none.
+func ScancodeByKey(k Key) int {
+	return glfw.GetKeyScancode(glfw.Key(k))
+}
@@ -215,3 +215,37 @@ const (
 	// KeyNumEnter represents the NumEnter keyboard key on the numpad
 	KeyNumEnter Key = Key(sdl.K_KP_ENTER)
 )
+
+// physicalKey returns the Key at the position of the given scancode on a US keyboard layout. Only letter and digit
+// keys differ between layouts, every other key is returned as reported by SDL.
+func physicalKey(scancode sdl.Scancode, sym sdl.Keycode) Key {
+	switch {
+	case scancode >= sdl.SCANCODE_A && scancode <= sdl.SCANCODE_Z:
+		return Key(sdl.K_a + sdl.Keycode(scancode-sdl.SCANCODE_A))
+	case scancode >= sdl.SCANCODE_1 && scancode <= sdl.SCANCODE_9:
+		return Key(sdl.K_1 + sdl.Keycode(scancode-sdl.SCANCODE_1))
+	case scancode == sdl.SCANCODE_0:
+		return Key(sdl.K_0)
+	}
+	return Key(sym)
+}
+
+// KeyByScancode returns the physical Key for the given SDL scancode, which identifies a key by its position on the
+// keyboard.
+func KeyByScancode(scancode int) Key {
+	sc := sdl.Scancode(scancode)
+	return physicalKey(sc, sdl.GetKeyFromScancode(sc))
+}
+
+// ScancodeByKey returns the SDL scancode of the physical Key k.
+func ScancodeByKey(k Key) int {
+	switch {
+	case k >= KeyA && k <= KeyZ:
+		return int(sdl.SCANCODE_A) + int(k-KeyA)
+	case k >= KeyOne && k <= KeyNine:
+		return int(sdl.SCANCODE_1) + int(k-KeyOne)
+	case k == KeyZero:
+		return int(sdl.SCANCODE_0)
+	}
+	return int(sdl.GetScancodeFromKey(sdl.Keycode(k)))
+}
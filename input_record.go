@@ -0,0 +1,274 @@
+package engo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// inputRecordHeader is the first line of every recorded input stream, followed by the version of the format.
+const inputRecordHeader = "engo-input 1"
+
+// StartRecording starts writing the input of every frame to w, until `StopRecording` is called. The recording can be
+// replayed later using `StartReplay`, such as for reproducing a bug or as a regression test. Seeding `Random` with
+// the same seed when recording and replaying makes the whole session reproducible, as long as the scene is in the same
+// state when both start, such as right after setting it.
+//
+// The input is recorded as seen by the scene: after the backends reported the real input of the frame, and the
+// events enqueued using `Enqueue` were consumed. The recording is a text stream, starting with the line
+// "engo-input 1". Every other line holds the input of a frame, as fields separated by spaces: the frame, counting
+// from 0 at the start of the recording, the amount of seconds since the recording started, the kind of input, and
+// its values:
+//
+//	<frame> <seconds> mouse <x> <y> <deltaX> <deltaY> <scrollX> <scrollY> <action> <button> <modifier>
+//	<frame> <seconds> button <button> <state>
+//	<frame> <seconds> key <key> <state>
+//	<frame> <seconds> physical <key> <state>
+//
+// A mouse line holds the fields of `Input.Mouse` during the frame, in the same units. It's written for the first
+// frame and whenever any of them changed since the mouse was last written. A button line holds the state of a mouse
+// button that was pressed or released during the frame, and key and physical lines hold the state of a regular or
+// physical key that was set during the frame. A state is one of KeyStateUp, KeyStateDown, KeyStateJustDown and
+// KeyStateJustUp. Touches are recorded as far as the backends report them as the mouse, and gamepads are not
+// recorded at all.
+func (im *InputManager) StartRecording(w io.Writer) error {
+	if im.recorder != nil {
+		if err := im.StopRecording(); err != nil {
+			return err
+		}
+	}
+	r := &inputRecorder{w: bufio.NewWriter(w), start: time.Now()}
+	if _, err := fmt.Fprintln(r.w, inputRecordHeader); err != nil {
+		return err
+	}
+	im.recorder = r
+	return nil
+}
+
+// StopRecording stops recording the input, see `StartRecording`. It flushes the recording, and returns the first
+// error that occurred while writing it.
+func (im *InputManager) StopRecording() error {
+	r := im.recorder
+	if r == nil {
+		return nil
+	}
+	im.recorder = nil
+	if err := r.w.Flush(); r.err == nil {
+		r.err = err
+	}
+	return r.err
+}
+
+// Recording returns whether or not the input is being recorded, see `StartRecording`.
+func (im *InputManager) Recording() bool {
+	return im.recorder != nil
+}
+
+// StartReplay starts replaying the input recorded using `StartRecording` from r. While replaying, the input of each
+// recorded frame replaces the input of the next frame, and the real input is ignored, see `DisableRealInput`. The
+// replay stops once r is exhausted, or when `StopReplay` is called. It returns an error if r doesn't start with a
+// recording; a recording which turns out to be malformed later on stops the replay with a warning.
+//
+// The replay is frame-based: the input of the n-th recorded frame is replayed during the n-th frame of the replay,
+// regardless of how much time passed in between, so a replay running at a different frame rate than the recording
+// takes more or less time, but its frames see exactly the same input. The recorded seconds are for reference only.
+// The delta time of the frames however still depends on the frame rate, so to reproduce a session exactly, its
+// systems have to advance by the same amount of time every frame while recording and replaying, such as by updating
+// the scene using `Step` with a fixed delta time.
+func (im *InputManager) StartReplay(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+		return errors.New("input recording is empty")
+	}
+	if header := scanner.Text(); header != inputRecordHeader {
+		return fmt.Errorf("input recording has an unknown header %q", header)
+	}
+
+	im.StopReplay()
+	im.replay = &inputReplay{scanner: scanner, line: 1, disabled: im.realInputDisabled}
+	im.realInputDisabled = true
+	return nil
+}
+
+// StopReplay stops replaying the input, and stops ignoring the real input unless it was disabled before the replay
+// started, see `StartReplay`.
+func (im *InputManager) StopReplay() {
+	if im.replay == nil {
+		return
+	}
+	im.realInputDisabled = im.replay.disabled
+	im.replay = nil
+}
+
+// Replaying returns whether or not recorded input is being replayed, see `StartReplay`.
+func (im *InputManager) Replaying() bool {
+	return im.replay != nil
+}
+
+// mouseRecord holds the fields of the Mouse which are recorded.
+type mouseRecord struct {
+	x, y, deltaX, deltaY, scrollX, scrollY float32
+	action                                 Action
+	button                                 MouseButton
+	modifier                               Modifier
+}
+
+// inputRecorder writes the input of every frame, see `InputManager.StartRecording`.
+type inputRecorder struct {
+	w     *bufio.Writer
+	start time.Time
+	frame int
+	// mouse is the mouse as it was last written, which is only written again once it changed
+	mouse   mouseRecord
+	written bool
+	// err is the first error that occurred while writing
+	err error
+}
+
+// record writes the input of the current frame.
+func (r *inputRecorder) record(im *InputManager) {
+	prefix := fmt.Sprintf("%d %.3f", r.frame, time.Since(r.start).Seconds())
+	r.frame++
+
+	m := &im.Mouse
+	mouse := mouseRecord{m.X, m.Y, m.DeltaX, m.DeltaY, m.ScrollX, m.ScrollY, m.Action, m.Button, m.Modifer}
+	if !r.written || mouse != r.mouse {
+		r.mouse, r.written = mouse, true
+		r.writef("%s mouse %v %v %v %v %v %v %d %d %d\n", prefix, mouse.x, mouse.y, mouse.deltaX, mouse.deltaY,
+			mouse.scrollX, mouse.scrollY, mouse.action, mouse.button, mouse.modifier)
+	}
+
+	buttons := make([]MouseButton, 0, len(m.buttons))
+	for b, ks := range m.buttons {
+		if ks.lastState != ks.currentState {
+			buttons = append(buttons, b)
+		}
+	}
+	sort.Slice(buttons, func(i, j int) bool { return buttons[i] < buttons[j] })
+	for _, b := range buttons {
+		ks := m.buttons[b]
+		r.writef("%s button %d %d\n", prefix, b, ks.State())
+	}
+
+	for _, k := range im.keys.dirtyKeys(false) {
+		ks := im.keys.Get(k)
+		r.writef("%s key %d %d\n", prefix, k, ks.State())
+	}
+	for _, k := range im.keys.dirtyKeys(true) {
+		ks := im.keys.GetPhysical(k)
+		r.writef("%s physical %d %d\n", prefix, k, ks.State())
+	}
+}
+
+// writef writes a line of the recording, unless writing it failed before.
+func (r *inputRecorder) writef(format string, a ...interface{}) {
+	if r.err == nil {
+		_, r.err = fmt.Fprintf(r.w, format, a...)
+	}
+}
+
+// inputReplay reads the recorded input of every frame, see `InputManager.StartReplay`.
+type inputReplay struct {
+	scanner *bufio.Scanner
+	frame   int
+	line    int
+	// next holds the fields of the line which was read, but belongs to a later frame
+	next []string
+	// disabled indicates whether or not the real input was disabled before the replay started
+	disabled bool
+}
+
+// replay applies the recorded input of the current frame, and returns false once the recording is exhausted.
+func (r *inputReplay) replay(im *InputManager) bool {
+	frame := r.frame
+	r.frame++
+	for {
+		if r.next == nil {
+			if !r.scanner.Scan() {
+				if err := r.scanner.Err(); err != nil {
+					warning("unable to read the input recording: " + err.Error())
+				}
+				return false
+			}
+			r.line++
+			r.next = strings.Fields(r.scanner.Text())
+			if len(r.next) == 0 {
+				r.next = nil
+				continue
+			}
+		}
+
+		f, err := strconv.Atoi(r.next[0])
+		if err != nil {
+			return r.malformed()
+		}
+		if f > frame {
+			return true
+		}
+		if !r.apply(im, r.next) {
+			return r.malformed()
+		}
+		r.next = nil
+	}
+}
+
+// malformed warns about the line which was last read, and returns false to stop the replay.
+func (r *inputReplay) malformed() bool {
+	warning(fmt.Sprintf("input recording is malformed at line %d, stopping the replay", r.line))
+	return false
+}
+
+// apply applies a line of the recording, and returns false if it's malformed.
+func (r *inputReplay) apply(im *InputManager, fields []string) bool {
+	if len(fields) < 3 {
+		return false
+	}
+	values := make([]float64, len(fields)-3)
+	for i, field := range fields[3:] {
+		v, err := strconv.ParseFloat(field, 32)
+		if err != nil {
+			return false
+		}
+		values[i] = v
+	}
+
+	switch kind := fields[2]; {
+	case kind == "mouse" && len(values) == 9:
+		m := &im.Mouse
+		m.X, m.Y, m.DeltaX, m.DeltaY = float32(values[0]), float32(values[1]), float32(values[2]), float32(values[3])
+		m.ScrollX, m.ScrollY = float32(values[4]), float32(values[5])
+		m.Action, m.Button, m.Modifer = Action(values[6]), MouseButton(values[7]), Modifier(values[8])
+		im.updateMouse()
+		// The recording holds the latest action of every frame, which is its only press or release
+		m.events = m.events[:0]
+		if m.Action == Press || m.Action == Release {
+			m.addEvent()
+		}
+	case kind == "button" && len(values) == 2:
+		if im.Mouse.buttons == nil {
+			im.Mouse.buttons = make(map[MouseButton]KeyState)
+		}
+		im.Mouse.buttons[MouseButton(values[0])] = keyStateOf(int(values[1]))
+	case (kind == "key" || kind == "physical") && len(values) == 2:
+		im.keys.setState(Key(values[0]), kind == "physical", keyStateOf(int(values[1])))
+	default:
+		return false
+	}
+	return true
+}
+
+// keyStateOf returns the KeyState of which State returns the given state.
+func keyStateOf(state int) KeyState {
+	return KeyState{
+		lastState:    state == KeyStateDown || state == KeyStateJustUp,
+		currentState: state == KeyStateDown || state == KeyStateJustDown,
+	}
+}
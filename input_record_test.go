@@ -0,0 +1,100 @@
+package engo
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRecordReplay(t *testing.T) {
+	play := func() *inputRecordingSystem {
+		scene := &stepTestScene{}
+		Run(RunOptions{
+			NoRun:        true,
+			HeadlessMode: true,
+			Width:        100,
+			Height:       50,
+		}, scene)
+		Input.RegisterButton("jump", KeySpace)
+		return scene.sys
+	}
+
+	var buf bytes.Buffer
+	recorded := play()
+	if err := Input.StartRecording(&buf); err != nil {
+		t.Fatalf("unable to start recording: %v", err)
+	}
+	Input.Enqueue(
+		InputEvent{Type: MouseMoveEvent, X: 10, Y: 20},
+		InputEvent{Type: MousePressEvent, X: 30, Y: 40, Button: MouseButtonLeft},
+		InputEvent{Type: MouseReleaseEvent, X: 30, Y: 40, Button: MouseButtonLeft},
+	)
+	Input.SetKey(KeySpace, true)
+	Step(1)
+	Input.keys.SetPhysical(KeyW, true)
+	Step(1)
+	Step(1)
+	Input.SetKey(KeySpace, false)
+	Step(1)
+	if err := Input.StopRecording(); err != nil {
+		t.Fatalf("unable to stop recording: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), inputRecordHeader+"\n0 ") {
+		t.Errorf("recording did not start with its header and the first frame, got: %q", buf.String())
+	}
+
+	replayed := play()
+	if err := Input.StartReplay(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("unable to start replaying: %v", err)
+	}
+	if !Input.RealInputDisabled() {
+		t.Error("real input was not disabled while replaying")
+	}
+	var physical []bool
+	for i := 0; i < 4; i++ {
+		Step(1)
+		physical = append(physical, Input.keys.GetPhysical(KeyW).Down())
+	}
+	if !reflect.DeepEqual(recorded, replayed) {
+		t.Errorf("replay did not see the recorded input. Wanted: %+v, got: %+v", *recorded, *replayed)
+	}
+	if Input.Mouse.X != 30 || Input.Mouse.Y != 40 {
+		t.Errorf("mouse was not replayed to where it was recorded. Wanted: (30, 40), got: (%v, %v)", Input.Mouse.X, Input.Mouse.Y)
+	}
+	if physical[0] || !physical[1] {
+		t.Errorf("physical key was not replayed, got: %v", physical)
+	}
+
+	Step(1)
+	if Input.Replaying() || Input.RealInputDisabled() {
+		t.Error("replay did not stop once the recording was exhausted")
+	}
+}
+
+func TestStartReplayMalformed(t *testing.T) {
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &inputTestScene{})
+
+	if err := Input.StartReplay(strings.NewReader("")); err == nil {
+		t.Error("empty recording was replayed")
+	}
+	if err := Input.StartReplay(strings.NewReader("something else\n")); err == nil {
+		t.Error("recording with an unknown header was replayed")
+	}
+	if Input.Replaying() {
+		t.Error("invalid recordings started replaying")
+	}
+
+	Input.DisableRealInput(true)
+	defer Input.DisableRealInput(false)
+	if err := Input.StartReplay(strings.NewReader(inputRecordHeader + "\n0 0.000 key 32\n")); err != nil {
+		t.Fatalf("unable to start replaying: %v", err)
+	}
+	Step(0)
+	if Input.Replaying() || !Input.RealInputDisabled() {
+		t.Error("malformed recording did not stop the replay, or real input was enabled although it was disabled before")
+	}
+}
@@ -0,0 +1,236 @@
+package engo
+
+import "testing"
+
+type inputTestScene struct{}
+
+func (*inputTestScene) Preload() {}
+
+func (*inputTestScene) Setup(Updater) {}
+
+func (*inputTestScene) Type() string { return "inputTestScene" }
+
+func TestMouseInside(t *testing.T) {
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        100,
+		Height:       50,
+	}, &inputTestScene{})
+
+	if !Input.Mouse.Inside {
+		t.Error("mouse was not considered inside the game area before its position was reported")
+	}
+
+	Input.Mouse.X, Input.Mouse.Y = 20, 30
+	Input.updateMouse()
+	if !Input.Mouse.Inside {
+		t.Errorf("mouse at (%v, %v) was not considered inside the game area", Input.Mouse.X, Input.Mouse.Y)
+	}
+
+	Input.Mouse.X, Input.Mouse.Y = -20, 80
+	Input.updateMouse()
+	if Input.Mouse.Inside {
+		t.Errorf("mouse at (%v, %v) was considered inside the game area", Input.Mouse.X, Input.Mouse.Y)
+	}
+	if Input.Mouse.X != -20 || Input.Mouse.Y != 80 {
+		t.Errorf("mouse was clamped without ClampMouse being set. Wanted: (-20, 80), got: (%v, %v)", Input.Mouse.X, Input.Mouse.Y)
+	}
+}
+
+func TestMouseClamp(t *testing.T) {
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        100,
+		Height:       50,
+		ClampMouse:   true,
+	}, &inputTestScene{})
+
+	tests := []struct {
+		x, y, expectedX, expectedY float32
+		inside                     bool
+	}{
+		{20, 30, 20, 30, true},
+		{-20, 30, 0, 30, false},
+		{120, 30, 100, 30, false},
+		{20, -5, 20, 0, false},
+		{150, 80, 100, 50, false},
+	}
+
+	for _, test := range tests {
+		Input.Mouse.X, Input.Mouse.Y = test.x, test.y
+		Input.updateMouse()
+		if Input.Mouse.X != test.expectedX || Input.Mouse.Y != test.expectedY {
+			t.Errorf("mouse at (%v, %v) was not clamped correctly. Wanted: (%v, %v), got: (%v, %v)", test.x, test.y, test.expectedX, test.expectedY, Input.Mouse.X, Input.Mouse.Y)
+		}
+		if Input.Mouse.Inside != test.inside {
+			t.Errorf("mouse at (%v, %v) reported the wrong Inside value. Wanted: %v, got: %v", test.x, test.y, test.inside, Input.Mouse.Inside)
+		}
+	}
+
+	SetClampMouse(false)
+	Input.Mouse.X, Input.Mouse.Y = -20, 30
+	Input.updateMouse()
+	if Input.Mouse.X != -20 {
+		t.Errorf("mouse was clamped after SetClampMouse(false). Wanted: %v, got: %v", -20, Input.Mouse.X)
+	}
+}
+
+func TestPhysicalBindings(t *testing.T) {
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &inputTestScene{})
+
+	Input.RegisterButton("logical", KeyW)
+	Input.RegisterPhysicalButton("physical", KeyW)
+	Input.RegisterAxis("axis", AxisPhysicalKeyPair{KeyS, KeyW})
+
+	// The key at the position of W on an AZERTY keyboard is labeled Z.
+	Input.keys.Set(KeyZ, true)
+	Input.keys.SetPhysical(KeyW, true)
+	Input.update()
+
+	if Input.Button("logical").Down() {
+		t.Error("button bound to the key printed as W was triggered by the key printed as Z")
+	}
+	if !Input.Button("physical").Down() {
+		t.Error("button bound to the physical W key was not triggered")
+	}
+	if !Input.Key(KeyZ).Down() || Input.Key(KeyW).Down() {
+		t.Error("key state was not that of the key printed as Z")
+	}
+	if v := Input.Axis("axis").Value(); v != AxisMax {
+		t.Errorf("axis bound to the physical W key did not report its value. Wanted: %v, got: %v", AxisMax, v)
+	}
+
+	Input.keys.Set(KeyZ, false)
+	Input.keys.SetPhysical(KeyW, false)
+	Input.update()
+}
+
+func TestMouseButtons(t *testing.T) {
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &inputTestScene{})
+
+	Input.Mouse.setButton(MouseButton4, true)
+	if !Input.Mouse.ButtonJustPressed(MouseButton4) {
+		t.Error("pressing button 4 was not reported as just pressed")
+	}
+
+	Input.update()
+	if !Input.Mouse.ButtonDown(MouseButton4) {
+		t.Error("button 4 was not reported as being held down")
+	}
+	if Input.Mouse.ButtonDown(MouseButton5) {
+		t.Error("button 5 was reported as being held down without being pressed")
+	}
+
+	Input.Mouse.setButton(MouseButton4, false)
+	if !Input.Mouse.ButtonJustReleased(MouseButton4) {
+		t.Error("releasing button 4 was not reported as just released")
+	}
+
+	Input.update()
+	if Input.Mouse.ButtonDown(MouseButton4) || Input.Mouse.ButtonJustReleased(MouseButton4) {
+		t.Error("button 4 was still reported after it had been released")
+	}
+}
+
+func TestMouseEvents(t *testing.T) {
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        100,
+		Height:       50,
+	}, &inputTestScene{})
+
+	// Every press and release during the frame is kept, while Action and Button only hold the latest
+	Input.Mouse.X, Input.Mouse.Y = 10, 20
+	Input.SetMouseButton(MouseButtonLeft, true)
+	Input.Mouse.X, Input.Mouse.Y = 30, 40
+	Input.SetMouseButton(MouseButtonRight, true)
+	Input.SetMouseButton(MouseButtonLeft, false)
+	want := []MouseEvent{
+		{X: 10, Y: 20, Action: Press, Button: MouseButtonLeft, Inside: true},
+		{X: 30, Y: 40, Action: Press, Button: MouseButtonRight, Inside: true},
+		{X: 30, Y: 40, Action: Release, Button: MouseButtonLeft, Inside: true},
+	}
+	events := Input.Mouse.Events()
+	if len(events) != len(want) {
+		t.Fatalf("presses and releases during the frame were not all kept. Wanted: %v, got: %v", want, events)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Errorf("wrong event %d. Wanted: %+v, got: %+v", i, want[i], events[i])
+		}
+	}
+	if Input.Mouse.Action != Release || Input.Mouse.Button != MouseButtonLeft {
+		t.Errorf("latest action was not kept, got: %v of button %v", Input.Mouse.Action, Input.Mouse.Button)
+	}
+
+	Input.ignore(true)
+	if events := Input.Mouse.Events(); len(events) != 0 {
+		t.Errorf("events were reported while the input was ignored, got: %v", events)
+	}
+	Input.ignore(false)
+
+	Step(0)
+	if events := Input.Mouse.Events(); len(events) != 0 {
+		t.Errorf("events were kept after the frame ended, got: %v", events)
+	}
+}
+
+func TestTouchEvents(t *testing.T) {
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        100,
+		Height:       50,
+		GlobalScale:  Point{X: 2, Y: 2},
+	}, &inputTestScene{})
+
+	Input.touchEvent(7, 40, 60, Press)
+	if !Input.Mouse.ButtonJustPressed(MouseButtonLeft) || Input.Mouse.Action != Press || Input.Mouse.Button != MouseButtonLeft {
+		t.Error("the first touch was not reported as pressing the left mouse button")
+	}
+	if Input.Mouse.X != 20 || Input.Mouse.Y != 30 {
+		t.Errorf("touch was not scaled like the mouse. Wanted: (20, 30), got: (%v, %v)", Input.Mouse.X, Input.Mouse.Y)
+	}
+
+	// A second finger is recorded, but does not move the mouse nor release its button.
+	Input.update()
+	Input.touchEvent(3, 10, 10, Press)
+	Input.touchEvent(3, 12, 14, Move)
+	if Input.Mouse.X != 20 || Input.Mouse.Y != 30 {
+		t.Errorf("second touch moved the mouse to (%v, %v)", Input.Mouse.X, Input.Mouse.Y)
+	}
+	touches := Input.TouchPoints()
+	if len(touches) != 2 || touches[0] != (Touch{ID: 3, X: 6, Y: 7}) || touches[1] != (Touch{ID: 7, X: 20, Y: 30}) {
+		t.Errorf("wrong touch points. Wanted: [{3 6 7} {7 20 30}], got: %v", touches)
+	}
+	Input.touchEvent(3, 12, 14, Release)
+	if !Input.Mouse.ButtonDown(MouseButtonLeft) {
+		t.Error("releasing the second touch released the left mouse button")
+	}
+
+	Input.touchEvent(7, 50, 60, Move)
+	if Input.Mouse.X != 25 || Input.Mouse.Action != Move {
+		t.Errorf("moving the first touch did not move the mouse, got: (%v, %v)", Input.Mouse.X, Input.Mouse.Y)
+	}
+	Input.touchEvent(7, 50, 60, Release)
+	if !Input.Mouse.ButtonJustReleased(MouseButtonLeft) || len(Input.TouchPoints()) != 0 {
+		t.Error("releasing the first touch did not release the left mouse button")
+	}
+
+	// Once all fingers are lifted, the next touch is mapped onto the mouse.
+	Input.update()
+	Input.touchEvent(3, 80, 20, Press)
+	if Input.Mouse.X != 40 || !Input.Mouse.ButtonJustPressed(MouseButtonLeft) {
+		t.Error("a new touch was not mapped onto the mouse")
+	}
+	Input.touchEvent(3, 80, 20, Release)
+}
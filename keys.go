@@ -1,6 +1,7 @@
 package engo
 
 import (
+	"sort"
 	"sync"
 )
 
@@ -18,16 +19,28 @@ const (
 // NewKeyManager creates a new KeyManager.
 func NewKeyManager() *KeyManager {
 	return &KeyManager{
-		dirtmap: make(map[Key]Key),
-		mapper:  make(map[Key]KeyState),
+		dirtmap:         make(map[Key]Key),
+		mapper:          make(map[Key]KeyState),
+		physicalDirtmap: make(map[Key]Key),
+		physicalMapper:  make(map[Key]KeyState),
 	}
 }
 
 // KeyManager tracks which keys are pressed and released at the current point of time.
+//
+// Keys are tracked in two ways. Regular keys are layout-dependent: they are identified by what is printed on them in
+// the current keyboard layout, so pressing the key labeled 'Z' on an AZERTY keyboard sets KeyZ. Physical keys are
+// layout-independent: they are identified by the key at the same position on a US (QWERTY) keyboard, so that same key
+// sets the physical KeyW. Physical keys are most useful for bindings which depend on where keys are located, such as
+// WASD movement.
 type KeyManager struct {
 	dirtmap map[Key]Key
 	mapper  map[Key]KeyState
-	mutex   sync.RWMutex
+
+	physicalDirtmap map[Key]Key
+	physicalMapper  map[Key]KeyState
+
+	mutex sync.RWMutex
 }
 
 // Set is used for updating whether or not a key is held down, or not held down.
@@ -51,19 +64,79 @@ func (km *KeyManager) Get(k Key) KeyState {
 	return ks
 }
 
+// SetPhysical is used for updating whether or not the key at the position of k on a US keyboard layout is held down,
+// or not held down.
+func (km *KeyManager) SetPhysical(k Key, state bool) {
+	km.mutex.Lock()
+
+	ks := km.physicalMapper[k]
+	ks.set(state)
+	km.physicalMapper[k] = ks
+	km.physicalDirtmap[k] = k
+
+	km.mutex.Unlock()
+}
+
+// GetPhysical retrieves the state of the key at the position of k on a US keyboard layout, regardless of the
+// keyboard layout currently in use.
+func (km *KeyManager) GetPhysical(k Key) KeyState {
+	km.mutex.RLock()
+	ks := km.physicalMapper[k]
+	km.mutex.RUnlock()
+
+	return ks
+}
+
+// dirtyKeys returns the keys which were set since the last update ordered by key, as either regular or physical keys.
+func (km *KeyManager) dirtyKeys(physical bool) []Key {
+	km.mutex.RLock()
+	dirtmap := km.dirtmap
+	if physical {
+		dirtmap = km.physicalDirtmap
+	}
+	keys := make([]Key, 0, len(dirtmap))
+	for k := range dirtmap {
+		keys = append(keys, k)
+	}
+	km.mutex.RUnlock()
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// setState sets the state of the regular or physical key as a whole, such as when replaying recorded input.
+func (km *KeyManager) setState(k Key, physical bool, ks KeyState) {
+	km.mutex.Lock()
+
+	if physical {
+		km.physicalMapper[k] = ks
+		km.physicalDirtmap[k] = k
+	} else {
+		km.mapper[k] = ks
+		km.dirtmap[k] = k
+	}
+
+	km.mutex.Unlock()
+}
+
 func (km *KeyManager) update() {
 	km.mutex.Lock()
 
 	// Update the state on all the dirty keys
-	for _, key := range km.dirtmap {
-		delete(km.dirtmap, key)
+	updateDirtyKeys(km.dirtmap, km.mapper)
+	updateDirtyKeys(km.physicalDirtmap, km.physicalMapper)
+
+	km.mutex.Unlock()
+}
+
+func updateDirtyKeys(dirtmap map[Key]Key, mapper map[Key]KeyState) {
+	for _, key := range dirtmap {
+		delete(dirtmap, key)
 
-		state := km.mapper[key]
+		state := mapper[key]
 		state.set(state.currentState)
-		km.mapper[key] = state
+		mapper[key] = state
 	}
-
-	km.mutex.Unlock()
 }
 
 // KeyState is used for detecting the state of a key press.
@@ -233,6 +233,33 @@ func TestKeyManager(t *testing.T) {
 	runKeyChecks("Pass (6.2)", t, mgr, initPass6)
 }
 
+// TestKeyManagerPhysical makes sure physical keys are tracked separately from the keys printed on them, as happens
+// when pressing the key labeled 'Z' on an AZERTY keyboard.
+func TestKeyManagerPhysical(t *testing.T) {
+	mgr := NewKeyManager()
+	mgr.Set(KeyZ, true)
+	mgr.SetPhysical(KeyW, true)
+
+	if !mgr.Get(KeyZ).JustPressed() || !mgr.GetPhysical(KeyW).JustPressed() {
+		t.Error("pressed key was not reported as just pressed")
+	}
+	if mgr.Get(KeyW).Down() || mgr.GetPhysical(KeyZ).Down() {
+		t.Error("physical key position was mixed up with the key printed on it")
+	}
+
+	mgr.update()
+	if !mgr.Get(KeyZ).Down() || !mgr.GetPhysical(KeyW).Down() {
+		t.Error("pressed key was not kept down after an update")
+	}
+
+	mgr.Set(KeyZ, false)
+	mgr.SetPhysical(KeyW, false)
+	mgr.update()
+	if !mgr.Get(KeyZ).Up() || !mgr.GetPhysical(KeyW).Up() {
+		t.Error("released key was not reported as up")
+	}
+}
+
 // Used to store results when benchmarking.
 var keyResult [12]keyState
 
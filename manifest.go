@@ -0,0 +1,191 @@
+package engo
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+)
+
+// Manifester is an optional interface a Scene can implement, to declare all assets it needs in one place. Those
+// assets are loaded before the Scene is set up, spread out over multiple frames so the game keeps running in the
+// meantime. While loading, the `LoadingScene` set using `SetLoadingScene` is shown, and `LoadingProgress` reports how
+// far along loading is. Preload is still called once all assets are loaded, right before Setup.
+//
+// The files of the assets are read in the background, in the order of the manifest, so reading large files or
+// fetching them from a URL root doesn't stall the game. They are passed to their `FileLoader` on the main thread
+// though, since loaders may upload textures to the GPU. The assets are searched in the roots of `Files` at the moment
+// the Scene is set, so changing them while loading doesn't affect it.
+type Manifester interface {
+	// Manifest returns the URLs of all assets the Scene needs, as they would be passed to `Files.Load`
+	Manifest() []string
+}
+
+// LoadingScene is a Scene which is shown while the assets in the manifest of another Scene are being loaded.
+type LoadingScene interface {
+	Scene
+
+	// Progress is called after loading each asset, with the fraction of the assets that is loaded so far
+	Progress(progress float32)
+
+	// Failed is called whenever one of the assets could not be loaded. The Scene it belongs to does not get set up,
+	// and the LoadingScene stays active.
+	Failed(err error)
+}
+
+// manifestBudget is how long loading the assets which were read may take per frame, at least one of them is loaded
+// every frame in which one was read.
+const manifestBudget = 10 * time.Millisecond
+
+var (
+	loadingScene    LoadingScene
+	pendingManifest *manifestLoad
+)
+
+// manifestLoad keeps track of the assets of a Scene which are being loaded.
+type manifestLoad struct {
+	scene         Scene
+	wrapper       *sceneWrapper
+	forceNewWorld bool
+	urls          []string
+	// paths are the paths at which each of the urls is searched, see `Formats.paths`
+	paths  [][]string
+	loaded int
+
+	// read receives the files of the assets in the order of urls, as they're read in the background
+	read chan assetFile
+	// done is closed when loading is canceled, which stops reading the files
+	done chan struct{}
+}
+
+// assetFile is the data of an asset which was read in the background, or the error that occurred while reading it.
+type assetFile struct {
+	data []byte
+	err  error
+}
+
+// SetLoadingScene sets the Scene which is shown while the assets of a `Manifester` are being loaded. If it is nil,
+// the previous Scene stays active until all assets are loaded, and failing to load any of them panics.
+func SetLoadingScene(s LoadingScene) {
+	loadingScene = s
+}
+
+// LoadingProgress returns the fraction of the assets in the manifest of the pending Scene which is loaded so far, and
+// whether or not such assets are being loaded at all, see `Manifester`. It can be polled by the `LoadingScene`, such
+// as to draw a loading bar every frame, instead of keeping track of the fraction passed to its Progress method.
+func LoadingProgress() (float32, bool) {
+	p := pendingManifest
+	if p == nil {
+		return 0, false
+	}
+	return float32(p.loaded) / float32(len(p.urls)), true
+}
+
+// cancelManifest stops loading the assets of the pending manifest, if any.
+func cancelManifest() {
+	if pendingManifest != nil {
+		close(pendingManifest.done)
+		pendingManifest = nil
+	}
+}
+
+// startManifest starts loading the assets of the given Scene, if it has a manifest and still has to be set up. It
+// returns whether or not setting the Scene has to wait until those are loaded.
+func startManifest(s Scene, forceNewWorld bool) bool {
+	m, ok := s.(Manifester)
+	if !ok {
+		return false
+	}
+
+	RegisterScene(s)
+	sceneMutex.RLock()
+	wrapper := scenes[s.Type()]
+	sceneMutex.RUnlock()
+
+	if wrapper.manifestLoaded || (wrapper.update != nil && !forceNewWorld) {
+		return false
+	}
+	urls := m.Manifest()
+	if len(urls) == 0 {
+		return false
+	}
+
+	p := &manifestLoad{
+		scene:         s,
+		wrapper:       wrapper,
+		forceNewWorld: forceNewWorld,
+		urls:          urls,
+		paths:         make([][]string, len(urls)),
+		read:          make(chan assetFile, len(urls)),
+		done:          make(chan struct{}),
+	}
+	for i, url := range urls {
+		p.paths[i] = Files.paths(url)
+	}
+	go p.readFiles()
+	pendingManifest = p
+	if loadingScene != nil {
+		SetScene(loadingScene, false)
+	}
+	return true
+}
+
+// readFiles reads the files of all assets in the background, until one of them can't be read or loading is
+// canceled.
+func (p *manifestLoad) readFiles() {
+	for _, paths := range p.paths {
+		data, err := readPaths(paths, p.done)
+		select {
+		case p.read <- assetFile{data, err}:
+		case <-p.done:
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// loadManifest loads the assets of the pending manifest which were read so far, and sets its Scene once all of them
+// are loaded.
+func loadManifest() {
+	p := pendingManifest
+	if p == nil {
+		return
+	}
+
+	start := time.Now()
+	for p.loaded < len(p.urls) {
+		var f assetFile
+		select {
+		case f = <-p.read:
+		default:
+			return // the next file is still being read
+		}
+
+		err := f.err
+		if err == nil {
+			err = Files.LoadReaderData(p.urls[p.loaded], bytes.NewReader(f.data))
+		}
+		if err != nil {
+			cancelManifest()
+			err = fmt.Errorf("unable to load the assets of scene %q: %v", p.scene.Type(), err)
+			if loadingScene == nil {
+				panic(err)
+			}
+			loadingScene.Failed(err)
+			return
+		}
+
+		p.loaded++
+		if loadingScene != nil {
+			loadingScene.Progress(float32(p.loaded) / float32(len(p.urls)))
+		}
+		if p.loaded < len(p.urls) && time.Since(start) >= manifestBudget {
+			return // continue during the next frame
+		}
+	}
+
+	pendingManifest = nil
+	p.wrapper.manifestLoaded = true
+	SetScene(p.scene, p.forceNewWorld)
+}
@@ -0,0 +1,120 @@
+package engo
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type manifestTestScene struct {
+	name   string
+	urls   []string
+	setups int
+}
+
+func (*manifestTestScene) Preload() {}
+
+func (s *manifestTestScene) Setup(Updater) { s.setups++ }
+
+func (s *manifestTestScene) Type() string { return s.name }
+
+func (s *manifestTestScene) Manifest() []string { return s.urls }
+
+type loadingTestScene struct {
+	progress []float32
+	err      error
+}
+
+func (*loadingTestScene) Preload() {}
+
+func (*loadingTestScene) Setup(Updater) {}
+
+func (*loadingTestScene) Type() string { return "loadingTestScene" }
+
+func (s *loadingTestScene) Progress(progress float32) { s.progress = append(s.progress, progress) }
+
+func (s *loadingTestScene) Failed(err error) { s.err = err }
+
+// updateUntil updates the scene until done returns true, giving the assets of a pending manifest up to a second to be
+// read in the background.
+func updateUntil(done func() bool) {
+	for deadline := time.Now().Add(time.Second); !done() && time.Now().Before(deadline); {
+		updateScene(0)
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSceneManifest(t *testing.T) {
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &assetTestScene{})
+
+	Files.Register(".test", &testLoader{})
+	dir, err := ioutil.TempDir(".", "testing")
+	if err != nil {
+		t.Fatalf("failed to create temp directory for testing, error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	Files.SetRoot(dir)
+	for _, name := range []string{"a.test", "b.test"} {
+		if err = ioutil.WriteFile(filepath.Join(dir, name), []byte("testing"), 0666); err != nil {
+			t.Fatalf("failed to create temp file for testing, file: %v, error: %v", name, err)
+		}
+	}
+
+	loading := &loadingTestScene{}
+	SetLoadingScene(loading)
+	defer SetLoadingScene(nil)
+
+	scene := &manifestTestScene{name: "manifestTestScene", urls: []string{"a.test", "b.test"}}
+	SetScene(scene, false)
+	if CurrentScene() != loading || scene.setups != 0 {
+		t.Fatal("scene was set up before its assets were loaded")
+	}
+	if progress, ok := LoadingProgress(); !ok || progress != 0 {
+		t.Errorf("loading progress was not reported before loading. Wanted: %v, got: %v (loading: %v)", 0, progress, ok)
+	}
+
+	updateUntil(func() bool { return CurrentScene() == scene })
+	if CurrentScene() != scene || scene.setups != 1 {
+		t.Fatalf("scene was not set up once its assets were loaded. Wanted: %v setups, got: %v", 1, scene.setups)
+	}
+	if n := len(loading.progress); n != 2 || loading.progress[n-1] != 1 {
+		t.Errorf("loading scene did not receive the progress of every asset, got: %v", loading.progress)
+	}
+	if _, ok := LoadingProgress(); ok {
+		t.Error("loading progress was still reported once the assets were loaded")
+	}
+
+	missing := &manifestTestScene{name: "missingManifestTestScene", urls: []string{"a.test", "missing.test"}}
+	SetScene(missing, false)
+	updateUntil(func() bool { return loading.err != nil })
+	if loading.err == nil {
+		t.Error("missing asset was not reported to the loading scene")
+	}
+	if missing.setups != 0 || CurrentScene() != loading {
+		t.Error("scene with a missing asset was set up")
+	}
+	if _, ok := LoadingProgress(); ok {
+		t.Error("loading progress was still reported once loading failed")
+	}
+
+	// Setting another scene while loading cancels reading the assets
+	canceled := &manifestTestScene{name: "canceledManifestTestScene", urls: []string{"a.test", "b.test"}}
+	other := &assetTestScene{}
+	SetScene(canceled, false)
+	SetScene(other, false)
+	if _, ok := LoadingProgress(); ok || CurrentScene() != other {
+		t.Error("loading was not canceled by setting another scene")
+	}
+	for i := 0; i < 10; i++ {
+		updateScene(0)
+		time.Sleep(time.Millisecond)
+	}
+	if canceled.setups != 0 {
+		t.Error("scene was set up after loading its assets was canceled")
+	}
+}
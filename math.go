@@ -33,6 +33,72 @@ type AABB struct {
 	Min, Max Point
 }
 
+// Intersects indicates whether or not the two AABBs overlap. AABBs that only share an edge or a corner do not
+// intersect.
+func (a AABB) Intersects(other AABB) bool {
+	return a.Max.X > other.Min.X && a.Min.X < other.Max.X && a.Max.Y > other.Min.Y && a.Min.Y < other.Max.Y
+}
+
+// Contains indicates whether or not the point is within the AABB. Points on the minimum edges are considered within,
+// while points on the maximum edges are not. This way, a point on an edge shared by two adjacent AABBs is contained
+// by exactly one of them.
+func (a AABB) Contains(p Point) bool {
+	return p.X >= a.Min.X && p.X < a.Max.X && p.Y >= a.Min.Y && p.Y < a.Max.Y
+}
+
+// Union returns the smallest AABB containing both AABBs.
+func (a AABB) Union(other AABB) AABB {
+	return AABB{
+		Min: Point{X: math.Min(a.Min.X, other.Min.X), Y: math.Min(a.Min.Y, other.Min.Y)},
+		Max: Point{X: math.Max(a.Max.X, other.Max.X), Y: math.Max(a.Max.Y, other.Max.Y)},
+	}
+}
+
+// Center returns the point in the middle of the AABB.
+func (a AABB) Center() Point {
+	return Point{X: (a.Min.X + a.Max.X) / 2, Y: (a.Min.Y + a.Max.Y) / 2}
+}
+
+// Size returns the width and height of the AABB, as the X and Y of a Point.
+func (a AABB) Size() Point {
+	return Point{X: a.Max.X - a.Min.X, Y: a.Max.Y - a.Min.Y}
+}
+
+// Overlap returns the penetration vector of the two AABBs: the shortest translation along a single axis which moves
+// a out of other. If the AABBs do not intersect, the zero Point is returned.
+func (a AABB) Overlap(other AABB) Point {
+	if !a.Intersects(other) {
+		return Point{}
+	}
+
+	var mtd Point
+
+	left := other.Min.X - a.Max.X
+	right := other.Max.X - a.Min.X
+	top := other.Min.Y - a.Max.Y
+	bottom := other.Max.Y - a.Min.Y
+
+	if math.Abs(left) < right {
+		mtd.X = left
+	} else {
+		mtd.X = right
+	}
+
+	if math.Abs(top) < bottom {
+		mtd.Y = top
+	} else {
+		mtd.Y = bottom
+	}
+
+	if math.Abs(mtd.X) < math.Abs(mtd.Y) {
+		mtd.Y = 0
+	} else {
+		mtd.X = 0
+	}
+
+	return mtd
+}
+
 // AABBer is an interface for everything that provides information about its axis aligned bounding box.
 type AABBer interface {
 	// AABB returns the axis aligned bounding box.
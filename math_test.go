@@ -1587,3 +1587,122 @@ func TestMultipleMatrixVector(t *testing.T) {
 		}
 	}
 }
+
+func TestAABBIntersects(t *testing.T) {
+	box := AABB{Min: Point{X: 0, Y: 0}, Max: Point{X: 10, Y: 10}}
+	data := []struct {
+		other AABB
+		exp   bool
+	}{
+		{other: AABB{Min: Point{X: 5, Y: 5}, Max: Point{X: 15, Y: 15}}, exp: true},
+		{other: AABB{Min: Point{X: 2, Y: 2}, Max: Point{X: 8, Y: 8}}, exp: true},
+		{other: AABB{Min: Point{X: -5, Y: -5}, Max: Point{X: 15, Y: 15}}, exp: true},
+		{other: AABB{Min: Point{X: 10, Y: 0}, Max: Point{X: 20, Y: 10}}, exp: false},  // shared edge
+		{other: AABB{Min: Point{X: 0, Y: -10}, Max: Point{X: 10, Y: 0}}, exp: false},  // shared edge
+		{other: AABB{Min: Point{X: 10, Y: 10}, Max: Point{X: 20, Y: 20}}, exp: false}, // shared corner
+		{other: AABB{Min: Point{X: 20, Y: 20}, Max: Point{X: 30, Y: 30}}, exp: false},
+	}
+	for _, d := range data {
+		if actual := box.Intersects(d.other); actual != d.exp {
+			t.Errorf("Test AABB.Intersects failed. a: %v, b: %v, wanted: %v, got: %v", box, d.other, d.exp, actual)
+		}
+		if actual := d.other.Intersects(box); actual != d.exp {
+			t.Errorf("Test AABB.Intersects is not symmetric. a: %v, b: %v, wanted: %v, got: %v", d.other, box, d.exp, actual)
+		}
+	}
+}
+
+func TestAABBContains(t *testing.T) {
+	box := AABB{Min: Point{X: 0, Y: 0}, Max: Point{X: 10, Y: 10}}
+	data := []struct {
+		p   Point
+		exp bool
+	}{
+		{p: Point{X: 5, Y: 5}, exp: true},
+		{p: Point{X: 0, Y: 0}, exp: true},
+		{p: Point{X: 0, Y: 5}, exp: true},
+		{p: Point{X: 10, Y: 5}, exp: false},
+		{p: Point{X: 5, Y: 10}, exp: false},
+		{p: Point{X: 10, Y: 10}, exp: false},
+		{p: Point{X: -1, Y: 5}, exp: false},
+	}
+	for _, d := range data {
+		if actual := box.Contains(d.p); actual != d.exp {
+			t.Errorf("Test AABB.Contains failed. aabb: %v, p: %v, wanted: %v, got: %v", box, d.p, d.exp, actual)
+		}
+	}
+
+	// A point on an edge shared by two adjacent AABBs is contained by exactly one of them.
+	left := AABB{Min: Point{X: 0, Y: 0}, Max: Point{X: 10, Y: 10}}
+	right := AABB{Min: Point{X: 10, Y: 0}, Max: Point{X: 20, Y: 10}}
+	p := Point{X: 10, Y: 5}
+	if left.Contains(p) == right.Contains(p) {
+		t.Errorf("Test AABB.Contains failed. point %v on a shared edge should be contained by exactly one AABB", p)
+	}
+}
+
+func TestAABBUnion(t *testing.T) {
+	data := []struct {
+		a, b, exp AABB
+	}{
+		{
+			a:   AABB{Min: Point{X: 0, Y: 0}, Max: Point{X: 10, Y: 10}},
+			b:   AABB{Min: Point{X: 5, Y: -5}, Max: Point{X: 20, Y: 5}},
+			exp: AABB{Min: Point{X: 0, Y: -5}, Max: Point{X: 20, Y: 10}},
+		},
+		{
+			a:   AABB{Min: Point{X: 0, Y: 0}, Max: Point{X: 10, Y: 10}},
+			b:   AABB{Min: Point{X: 2, Y: 2}, Max: Point{X: 8, Y: 8}},
+			exp: AABB{Min: Point{X: 0, Y: 0}, Max: Point{X: 10, Y: 10}},
+		},
+		{
+			a:   AABB{Min: Point{X: 0, Y: 0}, Max: Point{X: 1, Y: 1}},
+			b:   AABB{Min: Point{X: 5, Y: 5}, Max: Point{X: 6, Y: 6}},
+			exp: AABB{Min: Point{X: 0, Y: 0}, Max: Point{X: 6, Y: 6}},
+		},
+	}
+	for _, d := range data {
+		if actual := d.a.Union(d.b); actual != d.exp {
+			t.Errorf("Test AABB.Union failed. a: %v, b: %v, wanted: %v, got: %v", d.a, d.b, d.exp, actual)
+		}
+	}
+}
+
+func TestAABBCenterSize(t *testing.T) {
+	data := []struct {
+		aabb         AABB
+		center, size Point
+	}{
+		{AABB{Max: Point{X: 10, Y: 20}}, Point{X: 5, Y: 10}, Point{X: 10, Y: 20}},
+		{AABB{Min: Point{X: -10, Y: 5}, Max: Point{X: 0, Y: 6}}, Point{X: -5, Y: 5.5}, Point{X: 10, Y: 1}},
+		{AABB{Min: Point{X: 3, Y: 3}, Max: Point{X: 3, Y: 3}}, Point{X: 3, Y: 3}, Point{}},
+	}
+	for _, d := range data {
+		if actual := d.aabb.Center(); actual != d.center {
+			t.Errorf("Test AABB.Center failed. aabb: %v, wanted: %v, got: %v", d.aabb, d.center, actual)
+		}
+		if actual := d.aabb.Size(); actual != d.size {
+			t.Errorf("Test AABB.Size failed. aabb: %v, wanted: %v, got: %v", d.aabb, d.size, actual)
+		}
+	}
+}
+
+func TestAABBOverlap(t *testing.T) {
+	box := AABB{Min: Point{X: 0, Y: 0}, Max: Point{X: 10, Y: 10}}
+	data := []struct {
+		other AABB
+		exp   Point
+	}{
+		{other: AABB{Min: Point{X: 8, Y: 0}, Max: Point{X: 20, Y: 10}}, exp: Point{X: -2, Y: 0}},
+		{other: AABB{Min: Point{X: -12, Y: 0}, Max: Point{X: 1, Y: 10}}, exp: Point{X: 1, Y: 0}},
+		{other: AABB{Min: Point{X: 0, Y: 7}, Max: Point{X: 10, Y: 20}}, exp: Point{X: 0, Y: -3}},
+		{other: AABB{Min: Point{X: 0, Y: -12}, Max: Point{X: 10, Y: 4}}, exp: Point{X: 0, Y: 4}},
+		{other: AABB{Min: Point{X: 10, Y: 0}, Max: Point{X: 20, Y: 10}}, exp: Point{X: 0, Y: 0}}, // shared edge
+		{other: AABB{Min: Point{X: 20, Y: 20}, Max: Point{X: 30, Y: 30}}, exp: Point{X: 0, Y: 0}},
+	}
+	for _, d := range data {
+		if actual := box.Overlap(d.other); !actual.Equal(d.exp) {
+			t.Errorf("Test AABB.Overlap failed. a: %v, b: %v, wanted: %v, got: %v", box, d.other, d.exp, actual)
+		}
+	}
+}
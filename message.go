@@ -24,8 +24,15 @@ func getNewHandlerID() MessageHandlerId {
 type HandlerIDPair struct {
 	MessageHandlerId
 	MessageHandler
+	// consume is called instead of the MessageHandler for handlers added using ListenConsume
+	consume MessageConsumer
 }
 
+// A MessageConsumer handles a message like a MessageHandler, and returns whether or not it consumed the message. A
+// consumed message isn't dispatched to the handlers which were added after it, such as a click handled by a menu
+// which shouldn't reach the game behind it.
+type MessageConsumer func(msg Message) (consumed bool)
+
 // A Message is used to send messages within the MessageManager
 type Message interface {
 	Type() string
@@ -37,72 +44,107 @@ type MessageManager struct {
 	// conditions on listeners and
 	// sync its state across the game
 	sync.RWMutex
-	listeners        map[string][]HandlerIDPair
-	handlersToRemove map[string][]MessageHandlerId
+	listeners map[string][]HandlerIDPair
+	// listening contains the IDs of all handlers which haven't been stopped
+	listening map[MessageHandlerId]bool
 }
 
-// Dispatch sends a message to all subscribed handlers of the message's type
+// Dispatch sends a message to all subscribed handlers of the message's type, in the order in which they were added,
+// until one of them consumes it (see ListenConsume). Handlers which are added by a handler receive the next message,
+// while handlers which are stopped by a handler don't receive the current one anymore.
 // To prevent any data races, be aware that these listeners occur as callbacks and can be
 // executed at any time. If variables are altered in the handler, utilize channels, locks,
 // semaphores, or any other method necessary to ensure the memory is not altered by multiple
 // functions simultaneously.
 func (mm *MessageManager) Dispatch(message Message) {
 	mm.RLock()
-	mm.clearRemovedHandlers()
-	handlers := make([]MessageHandler, len(mm.listeners[message.Type()]))
-	pairs := mm.listeners[message.Type()]
-	for i := range pairs {
-		handlers[i] = pairs[i].MessageHandler
-	}
+	pairs := make([]HandlerIDPair, len(mm.listeners[message.Type()]))
+	copy(pairs, mm.listeners[message.Type()])
 	mm.RUnlock()
 
-	for _, handler := range handlers {
-		handler(message)
+	for _, pair := range pairs {
+		if !mm.isListening(pair.MessageHandlerId) {
+			continue
+		}
+		if pair.consume != nil {
+			if pair.consume(message) {
+				return
+			}
+			continue
+		}
+		pair.MessageHandler(message)
 	}
+}
 
+// isListening indicates whether or not the handler with the given ID hasn't been stopped.
+func (mm *MessageManager) isListening(handlerID MessageHandlerId) bool {
+	mm.RLock()
+	defer mm.RUnlock()
+	return mm.listening[handlerID]
 }
 
 // Listen subscribes to the specified message type and calls the specified handler when fired
 func (mm *MessageManager) Listen(messageType string, handler MessageHandler) MessageHandlerId {
-	mm.Lock()
-	defer mm.Unlock()
-	if mm.listeners == nil {
-		mm.listeners = make(map[string][]HandlerIDPair)
-	}
-	handlerID := getNewHandlerID()
-	newHandlerIDPair := HandlerIDPair{MessageHandlerId: handlerID, MessageHandler: handler}
-	mm.listeners[messageType] = append(mm.listeners[messageType], newHandlerIDPair)
-	return handlerID
+	return mm.listen(messageType, HandlerIDPair{MessageHandler: handler})
 }
 
-// ListenOnce is a convenience wrapper around StopListen() to only listen to a specified message once
-func (mm *MessageManager) ListenOnce(messageType string, handler MessageHandler) {
+// ListenOnce is a convenience wrapper around StopListen() to only listen to a specified message once. The returned
+// ID can be passed to StopListen to stop listening before the message was received.
+func (mm *MessageManager) ListenOnce(messageType string, handler MessageHandler) MessageHandlerId {
+	var once sync.Once
 	handlerID := MessageHandlerId(0)
 	handlerID = mm.Listen(messageType, func(msg Message) {
-		handler(msg)
-		mm.StopListen(messageType, handlerID)
+		// The handler is stopped before it's called, so it isn't called again when it dispatches the message itself
+		once.Do(func() {
+			mm.StopListen(messageType, handlerID)
+			handler(msg)
+		})
 	})
+	return handlerID
 }
 
-// StopListen removes a previously added handler from the listener queue
-func (mm *MessageManager) StopListen(messageType string, handlerID MessageHandlerId) {
-	if mm.handlersToRemove == nil {
-		mm.handlersToRemove = make(map[string][]MessageHandlerId)
-	}
-	mm.handlersToRemove[messageType] = append(mm.handlersToRemove[messageType], handlerID)
+// ListenFilter subscribes to the specified message type like Listen, but only calls the handler for the messages for
+// which filter returns true, such as for the collisions of a single entity.
+func (mm *MessageManager) ListenFilter(messageType string, filter func(msg Message) bool, handler MessageHandler) MessageHandlerId {
+	return mm.Listen(messageType, func(msg Message) {
+		if filter(msg) {
+			handler(msg)
+		}
+	})
 }
 
-// Will deleted all queued handlers that are scheduled for removal due to StopListen()
-func (mm *MessageManager) clearRemovedHandlers() {
-	for messageType, handlerList := range mm.handlersToRemove {
-		for _, handlerID := range handlerList {
-			mm.removeHandler(messageType, handlerID)
-		}
+// ListenConsume subscribes to the specified message type like Listen, with a handler which can consume the message.
+// A consumed message isn't dispatched to the handlers which were added after this one, see MessageConsumer.
+func (mm *MessageManager) ListenConsume(messageType string, handler MessageConsumer) MessageHandlerId {
+	return mm.listen(messageType, HandlerIDPair{consume: handler})
+}
+
+// listen adds the handler to the listeners of the message type, and returns its new ID.
+func (mm *MessageManager) listen(messageType string, pair HandlerIDPair) MessageHandlerId {
+	mm.Lock()
+	defer mm.Unlock()
+	if mm.listeners == nil {
+		mm.listeners = make(map[string][]HandlerIDPair)
 	}
-	mm.handlersToRemove = make(map[string][]MessageHandlerId)
+	if mm.listening == nil {
+		mm.listening = make(map[MessageHandlerId]bool)
+	}
+	pair.MessageHandlerId = getNewHandlerID()
+	mm.listeners[messageType] = append(mm.listeners[messageType], pair)
+	mm.listening[pair.MessageHandlerId] = true
+	return pair.MessageHandlerId
+}
+
+// StopListen removes a previously added handler from the listener queue. It takes effect right away, so a handler
+// stopped while a message is being dispatched doesn't receive that message anymore, unless it already did.
+func (mm *MessageManager) StopListen(messageType string, handlerID MessageHandlerId) {
+	mm.Lock()
+	defer mm.Unlock()
+	delete(mm.listening, handlerID)
+	mm.removeHandler(messageType, handlerID)
 }
 
-// Removes a single handler from the handler queue, called during cleanup of all handlers scheduled for removal
+// Removes a single handler from the handler queue
 func (mm *MessageManager) removeHandler(messageType string, handlerID MessageHandlerId) {
 	indexOfHandler := -1
 	for i, activeHandler := range mm.listeners[messageType] {
@@ -111,22 +153,47 @@ func (mm *MessageManager) removeHandler(messageType string, handlerID MessageHan
 			break
 		}
 	}
-	// A handler might have already been removed during a previous Dispatch(), no action necessary
+	// A handler might have already been removed, no action necessary
 	if indexOfHandler == -1 {
 		return
 	}
 	mm.listeners[messageType] = append(mm.listeners[messageType][:indexOfHandler], mm.listeners[messageType][indexOfHandler+1:]...)
 }
 
-// WindowResizeMessage is a message that's being dispatched whenever the game window is being resized by the gamer
+// reset removes every handler.
+func (mm *MessageManager) reset() {
+	mm.Lock()
+	defer mm.Unlock()
+	mm.listeners = make(map[string][]HandlerIDPair)
+	mm.listening = make(map[MessageHandlerId]bool)
+}
+
+// WindowResizeMessage is a message that's being dispatched whenever the game window is being resized by the gamer,
+// or its canvas (the framebuffer, in pixels) changes size, such as when the window is moved to a screen with another
+// pixel density. By the time it's dispatched, WindowWidth, CanvasWidth and GameWidth (and their heights) already
+// return the new sizes, so the camera and the MouseSystem use them from the next frame on.
 type WindowResizeMessage struct {
 	OldWidth, OldHeight int
 	NewWidth, NewHeight int
+	// OldCanvasWidth, OldCanvasHeight, NewCanvasWidth and NewCanvasHeight are the sizes of the canvas, in pixels
+	OldCanvasWidth, OldCanvasHeight int
+	NewCanvasWidth, NewCanvasHeight int
 }
 
 // Type returns the type of the current object "WindowResizeMessage"
 func (WindowResizeMessage) Type() string { return "WindowResizeMessage" }
 
+// WindowFocusMessage is a message that's being dispatched whenever the game window gains or loses input focus, such
+// as when the gamer switches to another window and back, so the game can pause itself while it's in the background.
+// It's dispatched at the start of the first frame during which `WindowFocused` returns the new state.
+type WindowFocusMessage struct {
+	// Focused indicates whether or not the window has focus now
+	Focused bool
+}
+
+// Type returns the type of the current object "WindowFocusMessage"
+func (WindowFocusMessage) Type() string { return "WindowFocusMessage" }
+
 // TextMessage is a message that is dispatched whenever a character is typed on the
 // keyboard. This is not the same as a keypress, as it returns the rune of the
 // character typed by the user, which could be a combination of keypresses.
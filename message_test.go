@@ -109,3 +109,81 @@ func TestMessageRemoveNotExistAndMultipleMessageTypes(t *testing.T) {
 		t.Error("Message counter should be 1. Only one message was dispatched to it")
 	}
 }
+
+func TestMessageListenOnceStopped(t *testing.T) {
+	mailbox := &MessageManager{}
+	msg := testMessageCounter{}
+	handlerID := mailbox.ListenOnce("testMessageCounter", func(message Message) {
+		message.(*testMessageCounter).counter++
+	})
+	mailbox.StopListen("testMessageCounter", handlerID)
+	mailbox.Dispatch(&msg)
+	if msg.counter != 0 {
+		t.Error("Message should not have been received since its ListenOnce() handler was stopped")
+	}
+
+	// A handler dispatching the message itself still only receives it once
+	mailbox.ListenOnce("testMessageCounter", func(message Message) {
+		message.(*testMessageCounter).counter++
+		mailbox.Dispatch(message)
+	})
+	mailbox.Dispatch(&msg)
+	if msg.counter != 1 {
+		t.Errorf("Message should have been received exactly 1 times by the ListenOnce() handler, got %v", msg.counter)
+	}
+}
+
+func TestMessageStopListenDuringDispatch(t *testing.T) {
+	mailbox := &MessageManager{}
+	msg := testMessageCounter{}
+	var secondID MessageHandlerId
+	mailbox.Listen("testMessageCounter", func(message Message) {
+		message.(*testMessageCounter).counter++
+		mailbox.StopListen("testMessageCounter", secondID)
+	})
+	secondID = mailbox.Listen("testMessageCounter", func(message Message) {
+		message.(*testMessageCounter).counter2++
+	})
+	mailbox.Dispatch(&msg)
+	if msg.counter != 1 || msg.counter2 != 0 {
+		t.Errorf("Message should not have been received by the handler stopped during dispatch, got %v and %v", msg.counter, msg.counter2)
+	}
+}
+
+func TestMessageListenConsume(t *testing.T) {
+	mailbox := &MessageManager{}
+	msg := testMessageCounter{}
+	consume := true
+	mailbox.ListenConsume("testMessageCounter", func(message Message) bool {
+		message.(*testMessageCounter).counter++
+		return consume
+	})
+	mailbox.Listen("testMessageCounter", func(message Message) {
+		message.(*testMessageCounter).counter2++
+	})
+	mailbox.Dispatch(&msg)
+	if msg.counter != 1 || msg.counter2 != 0 {
+		t.Errorf("Message should not have been received after it was consumed, got %v and %v", msg.counter, msg.counter2)
+	}
+	consume = false
+	mailbox.Dispatch(&msg)
+	if msg.counter != 2 || msg.counter2 != 1 {
+		t.Errorf("Message should have been received by every handler when not consumed, got %v and %v", msg.counter, msg.counter2)
+	}
+}
+
+func TestMessageListenFilter(t *testing.T) {
+	mailbox := &MessageManager{}
+	msg := testMessageCounter{}
+	mailbox.ListenFilter("testMessageCounter", func(message Message) bool {
+		return message.(*testMessageCounter).counter2 > 0
+	}, func(message Message) {
+		message.(*testMessageCounter).counter++
+	})
+	mailbox.Dispatch(&msg)
+	msg.counter2 = 1
+	mailbox.Dispatch(&msg)
+	if msg.counter != 1 {
+		t.Errorf("Message should have been received only when it passed the filter, got %v", msg.counter)
+	}
+}
@@ -0,0 +1,98 @@
+package engo
+
+import (
+	"reflect"
+
+	"github.com/EngoEngine/ecs"
+)
+
+// pausedSystems contains the systems paused using SetSystemPaused, by their systemKey.
+var pausedSystems = make(map[interface{}]bool)
+
+// referenceSystem identifies a system of a type which can't be compared, but does refer to its data, by the type and
+// the pointer to that data.
+type referenceSystem struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+// systemKey returns the key the given system is identified by in pausedSystems, fixedSteps and unscaledSystems.
+// Systems are usually pointers, which are keys themselves, but values of types which can't be compared, such as a
+// slice or a struct containing one, can't be used as the key of a map. Slices, maps and funcs are identified by the
+// pointer to their data instead, and other values which can't be compared aren't identified at all, so nil is
+// returned for those.
+func systemKey(system ecs.System) interface{} {
+	t := reflect.TypeOf(system)
+	if t == nil || t.Comparable() {
+		return system
+	}
+	switch v := reflect.ValueOf(system); v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Func:
+		return referenceSystem{typ: t, ptr: v.Pointer()}
+	}
+	return nil
+}
+
+// SetSystemPaused pauses or resumes the given system, which has to be the same (pointer) value that was added to the
+// *ecs.World of a Scene. Systems which are values of a type that can't be compared, such as a struct containing a
+// slice, can't be told apart from one another and are never paused. While paused, the world skips the Update of the system, so gameplay such as physics and
+// animation can be frozen while the RenderSystem and the systems of a pause menu keep running. Resuming the system
+// does not make up for the time it was paused.
+//
+// Paused systems still take part in removal: entities removed from the world using `RemoveDeferred` or
+// `ecs.World.RemoveEntity` are removed from them as well, so no entities linger in a paused system. A system is no
+// longer paused once the Scene it belongs to is torn down, so it's not paused when the Scene is set up again.
+//
+// Pausing only applies to the systems of an *ecs.World, as other Updaters are updated as a whole.
+func SetSystemPaused(system ecs.System, paused bool) {
+	key := systemKey(system)
+	if key == nil {
+		return
+	}
+	if paused {
+		pausedSystems[key] = true
+	} else {
+		delete(pausedSystems, key)
+	}
+}
+
+// SystemPaused returns whether or not the given system is paused using SetSystemPaused.
+func SystemPaused(system ecs.System) bool {
+	return pausedSystems[systemKey(system)]
+}
+
+// updateUpdater updates the current Updater with the real delta time scaled by the time scale (see `SetTimeScale`),
+// skipping the systems which are paused, and updating those on a fixed timestep (see `SetSystemTimestep`) as many
+// times as needed.
+func updateUpdater(dt float32) {
+	w, ok := currentUpdater.(*ecs.World)
+	if !ok || (len(pausedSystems) == 0 && len(fixedSteps) == 0 && len(unscaledSystems) == 0) {
+		currentUpdater.Update(dt * timeScale)
+		return
+	}
+
+	for _, system := range w.Systems() {
+		key := systemKey(system)
+		if pausedSystems[key] {
+			continue
+		}
+		if step, ok := fixedSteps[key]; ok {
+			step.update(system, scaledDelta(system, dt))
+		} else {
+			system.Update(scaledDelta(system, dt))
+		}
+	}
+}
+
+// forgetSystems resumes all paused systems of the given Updater, and forgets their timesteps and whether or not they
+// ignore the time scale, when it's torn down.
+func forgetSystems(u Updater) {
+	if w, ok := u.(*ecs.World); ok {
+		for _, system := range w.Systems() {
+			key := systemKey(system)
+			delete(pausedSystems, key)
+			delete(fixedSteps, key)
+			delete(unscaledSystems, key)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package engo
+
+import (
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+)
+
+type removalCountingSystem struct {
+	countingSystem
+	removals int
+}
+
+func (r *removalCountingSystem) Remove(ecs.BasicEntity) { r.removals++ }
+
+// valueSystem is added to a world as a value of a type that can't be compared, since it contains a slice.
+type valueSystem struct {
+	names   []string
+	updates *int
+}
+
+func (v valueSystem) Update(float32)         { *v.updates++ }
+func (v valueSystem) Remove(ecs.BasicEntity) {}
+
+func TestSetSystemPaused(t *testing.T) {
+	scene := &testScopedScene{name: "testPausedScene"}
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, scene)
+	w := currentUpdater.(*ecs.World)
+	other := &removalCountingSystem{}
+	w.AddSystem(other)
+
+	SetSystemPaused(other, true)
+	if !SystemPaused(other) || SystemPaused(scene.sys) {
+		t.Fatal("only the paused system should be reported as paused")
+	}
+	updateScene(1)
+	if other.updates != 0 || scene.sys.updates != 1 {
+		t.Errorf("paused system was updated, or the other system was not. Got %v and %v updates", other.updates, scene.sys.updates)
+	}
+
+	// Removing entities still reaches paused systems.
+	RemoveDeferred(ecs.NewBasic())
+	updateScene(1)
+	if other.removals != 1 {
+		t.Errorf("entity was not removed from the paused system. Wanted: 1 removal, got: %v", other.removals)
+	}
+
+	SetSystemPaused(other, false)
+	updateScene(1)
+	if other.updates != 1 {
+		t.Errorf("resumed system was not updated. Wanted: 1 update, got: %v", other.updates)
+	}
+
+	// Tearing down the scene resumes its systems.
+	SetSystemPaused(other, true)
+	SetScene(&testScopedScene{name: "testPausedScene2"}, false)
+	if SystemPaused(other) {
+		t.Error("system of a torn down scene was still paused")
+	}
+}
+
+func TestSetSystemPausedValueSystems(t *testing.T) {
+	scene := &testScopedScene{name: "testPausedValueScene"}
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, scene)
+	w := currentUpdater.(*ecs.World)
+	value := valueSystem{names: []string{"value"}, updates: new(int)}
+	w.AddSystem(value)
+
+	// Other systems being paused doesn't require the systems of the world to be comparable.
+	SetSystemPaused(scene.sys, true)
+	SetSystemPaused(value, true)
+	SetSystemTimestep(value, 0.1, 0)
+	SetSystemUnscaled(value, true)
+	if SystemPaused(value) || SystemUnscaled(value) || TimestepAlpha(value) != 0 {
+		t.Error("system which can't be compared was paused, unscaled or put on a fixed timestep")
+	}
+	updateScene(1)
+	if *value.updates != 1 || scene.sys.updates != 0 {
+		t.Errorf("wrong systems were updated. Got %v and %v updates", *value.updates, scene.sys.updates)
+	}
+	SetSystemPaused(scene.sys, false)
+}
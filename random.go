@@ -0,0 +1,55 @@
+package engo
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Random is the source of randomness used by engo itself, such as for shaking the camera. Games can draw from it as
+// well, so that all randomness comes from a single source. Seeding it using `Rand.Seed` then makes the game behave the
+// same every time it's run with the same seed and the same input, such as for replays or networked lockstep. It's
+// seeded with the current time by default.
+var Random = NewRand(time.Now().UnixNano())
+
+// Rand is a seedable source of random numbers. Unlike the global functions of math/rand, it's not safe for
+// concurrent use, so it should only be used from within the game loop, just like the systems.
+type Rand struct {
+	rnd  *rand.Rand
+	seed int64
+}
+
+// NewRand creates a new Rand using the given seed.
+func NewRand(seed int64) *Rand {
+	return &Rand{rnd: rand.New(rand.NewSource(seed)), seed: seed}
+}
+
+// Seed restarts the Rand using the given seed, so it produces the same numbers as any other Rand using that seed.
+func (r *Rand) Seed(seed int64) {
+	r.rnd.Seed(seed)
+	r.seed = seed
+}
+
+// CurrentSeed returns the seed the Rand was last seeded with, such as for storing it along with a replay.
+func (r *Rand) CurrentSeed() int64 {
+	return r.seed
+}
+
+// Float32 returns a random number in [0, 1).
+func (r *Rand) Float32() float32 {
+	return r.rnd.Float32()
+}
+
+// Range returns a random number in [min, max).
+func (r *Rand) Range(min, max float32) float32 {
+	return min + r.rnd.Float32()*(max-min)
+}
+
+// Intn returns a random number in [0, n). It panics if n <= 0.
+func (r *Rand) Intn(n int) int {
+	return r.rnd.Intn(n)
+}
+
+// Int63 returns a random non-negative 63-bit integer.
+func (r *Rand) Int63() int64 {
+	return r.rnd.Int63()
+}
@@ -0,0 +1,23 @@
+package engo
+
+import "testing"
+
+func TestRand(t *testing.T) {
+	a, b := NewRand(42), NewRand(7)
+	b.Seed(42)
+	if b.CurrentSeed() != 42 {
+		t.Errorf("wrong seed. Wanted: 42, got: %v", b.CurrentSeed())
+	}
+	for i := 0; i < 100; i++ {
+		if x, y := a.Float32(), b.Float32(); x != y {
+			t.Fatalf("Rands with the same seed produced different numbers: %v and %v", x, y)
+		}
+		if x, y := a.Intn(10), b.Intn(10); x != y || x < 0 || x >= 10 {
+			t.Fatalf("Rands with the same seed produced different or invalid integers: %v and %v", x, y)
+		}
+		if x := a.Range(-2, 3); x < -2 || x >= 3 {
+			t.Fatalf("number out of range. Wanted: [-2, 3), got: %v", x)
+		}
+		b.Range(-2, 3)
+	}
+}
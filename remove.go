@@ -0,0 +1,57 @@
+package engo
+
+import "github.com/EngoEngine/ecs"
+
+// EntityRemover is implemented by any Updater which is able to remove entities from all of its systems, such as the
+// *ecs.World.
+type EntityRemover interface {
+	RemoveEntity(ecs.BasicEntity)
+}
+
+// deferredRemoval is an entity marked for removal, along with the Updater it should be removed from.
+type deferredRemoval struct {
+	updater Updater
+	entity  ecs.BasicEntity
+}
+
+var deferredRemovals []deferredRemoval
+
+// RemoveDeferred marks the entity for removal from the current Updater. The entity is removed from all systems at the
+// end of the current frame, after every system has been updated. This makes it safe to remove entities (including
+// the one currently being updated) from within a System's Update, without altering the entities that system is
+// iterating over.
+func RemoveDeferred(e ecs.BasicEntity) {
+	deferredRemovals = append(deferredRemovals, deferredRemoval{currentUpdater, e})
+}
+
+// updateScene dispatches a WindowFocusMessage if the window gained or lost focus, advances the scene transition in
+// progress (see `SetSceneWithTransition`), continues loading the assets of a pending manifest (see `Manifester`) and
+// those requested using `Formats.LoadAsync`, updates the current Updater except for its paused systems (see
+// `SetSystemPaused`) and with the fixed timesteps of its systems (see `SetSystemTimestep`), and afterwards removes all
+// entities that were marked for removal using `RemoveDeferred`. It is called once per frame by the run loop.
+func updateScene(dt float32) {
+	updateFocus()
+	updateTransition(dt)
+	Input.ignore(transition != nil)
+	loadManifest()
+	loadAsync()
+	updateUpdater(dt)
+	removeDeferred()
+}
+
+// removeDeferred removes all entities that were marked for removal using `RemoveDeferred`.
+func removeDeferred() {
+	// Removing an entity may result in new entities being marked for removal, those are removed as well.
+	for len(deferredRemovals) > 0 {
+		removals := deferredRemovals
+		deferredRemovals = nil
+		for _, removal := range removals {
+			remover, ok := removal.updater.(EntityRemover)
+			if !ok {
+				warning("unable to remove entity, the Updater does not implement EntityRemover")
+				continue
+			}
+			remover.RemoveEntity(removal.entity)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package engo
+
+import (
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+)
+
+type removeTestSystem struct {
+	entities []*ecs.BasicEntity
+	updated  []uint64
+	// victims maps an entity to the entity it removes during Update.
+	victims map[uint64]*ecs.BasicEntity
+}
+
+func (s *removeTestSystem) Update(float32) {
+	s.updated = s.updated[:0]
+	for _, e := range s.entities {
+		s.updated = append(s.updated, e.ID())
+		if victim, ok := s.victims[e.ID()]; ok {
+			RemoveDeferred(*victim)
+		}
+	}
+}
+
+func (s *removeTestSystem) Remove(basic ecs.BasicEntity) {
+	delete := -1
+	for index, e := range s.entities {
+		if e.ID() == basic.ID() {
+			delete = index
+			break
+		}
+	}
+	if delete >= 0 {
+		s.entities = append(s.entities[:delete], s.entities[delete+1:]...)
+	}
+}
+
+type removeTestScene struct {
+	sys     *removeTestSystem
+	players []ecs.BasicEntity
+}
+
+func (*removeTestScene) Preload() {}
+
+func (s *removeTestScene) Setup(u Updater) {
+	w := u.(*ecs.World)
+	s.players = ecs.NewBasics(3)
+	s.sys = &removeTestSystem{victims: make(map[uint64]*ecs.BasicEntity)}
+	for i := range s.players {
+		s.sys.entities = append(s.sys.entities, &s.players[i])
+	}
+	// During the update, the first entity removes the second one, and the last entity removes the first one.
+	s.sys.victims[s.players[0].ID()] = &s.players[1]
+	s.sys.victims[s.players[2].ID()] = &s.players[0]
+	w.AddSystem(s.sys)
+}
+
+func (*removeTestScene) Type() string { return "removeTestScene" }
+
+func TestRemoveDeferred(t *testing.T) {
+	scene := &removeTestScene{}
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, scene)
+
+	updateScene(1)
+	if len(scene.sys.updated) != 3 {
+		t.Errorf("not all entities were updated in the frame they were removed in. Wanted: %v, got: %v", 3, len(scene.sys.updated))
+	}
+	if len(scene.sys.entities) != 1 || scene.sys.entities[0].ID() != scene.players[2].ID() {
+		t.Errorf("entities were not removed at the end of the frame. Wanted: %v, got: %v", []uint64{scene.players[2].ID()}, idsOf(scene.sys.entities))
+	}
+	if len(deferredRemovals) != 0 {
+		t.Errorf("removals were not cleared at the end of the frame, got: %v", len(deferredRemovals))
+	}
+
+	updateScene(1)
+	if len(scene.sys.updated) != 1 || scene.sys.updated[0] != scene.players[2].ID() {
+		t.Errorf("removed entities were updated. Wanted: %v, got: %v", []uint64{scene.players[2].ID()}, scene.sys.updated)
+	}
+}
+
+func idsOf(entities []*ecs.BasicEntity) []uint64 {
+	ids := make([]uint64, len(entities))
+	for i, e := range entities {
+		ids[i] = e.ID()
+	}
+	return ids
+}
@@ -0,0 +1,89 @@
+package engo
+
+import (
+	"sort"
+	"sync"
+)
+
+// The order of the render passes drawn by the `RenderSystem`, and of the passes commonly inserted in between them.
+// Passes are drawn from the lowest order to the highest, so a pass with order RenderPassPost+1 is drawn right after
+// post-processing, but before the HUD.
+const (
+	// RenderPassWorld is the order of the pass in which the RenderSystem draws everything that is not part of the HUD
+	RenderPassWorld = 0
+	// RenderPassShapes is the order for passes which draw shapes on top of the world, such as those of the
+	// common.ShapeSystem and the common.ProgressBarSystem
+	RenderPassShapes = RenderPassWorld + 50
+	// RenderPassLighting is the order for passes which light the world
+	RenderPassLighting = 100
+	// RenderPassParticles is the order for passes which draw particles on top of the world
+	RenderPassParticles = 200
+	// RenderPassPost is the order for post-processing passes
+	RenderPassPost = 300
+	// RenderPassHUD is the order of the pass in which the RenderSystem draws the HUD
+	RenderPassHUD = 400
+	// RenderPassHUDShapes is the order for passes which draw shapes on top of the HUD, such as those of the
+	// common.ShapeSystem and the common.ProgressBarSystem
+	RenderPassHUDShapes = RenderPassHUD + 25
+	// RenderPassOverlay is the order for passes which draw on top of the HUD, such as the outlines of the
+	// common.DebugSystem and the graph of the common.FPSSystem
+	RenderPassOverlay = RenderPassHUD + 50
+	// RenderPassTransition is the order of the pass in which the RenderSystem draws the fade of a scene transition,
+	// see `SetSceneWithTransition`
+	RenderPassTransition = 500
+)
+
+// RenderPass is a single named step in drawing a frame.
+type RenderPass struct {
+	// Name identifies the pass, inserting another pass with the same name replaces it.
+	Name string
+	// Order determines when the pass is drawn, passes with a lower order are drawn first. Passes with the same order
+	// are drawn in the order in which they were inserted.
+	Order int
+	// Draw is called once per frame to draw the pass.
+	Draw func()
+}
+
+var (
+	renderPasses    []RenderPass
+	renderPassMutex sync.RWMutex
+)
+
+// InsertRenderPass registers a custom render pass, which the `RenderSystem` draws every frame in between its own
+// passes, according to order. See RenderPassWorld and the other orders for where the built-in passes are drawn.
+// Inserting a pass with the name of an existing pass replaces it, including the built-in passes of the RenderSystem
+// such as "world" and "hud".
+func InsertRenderPass(name string, order int, fn func()) {
+	renderPassMutex.Lock()
+	defer renderPassMutex.Unlock()
+
+	removeRenderPass(name)
+	renderPasses = append(renderPasses, RenderPass{Name: name, Order: order, Draw: fn})
+	sort.SliceStable(renderPasses, func(i, j int) bool {
+		return renderPasses[i].Order < renderPasses[j].Order
+	})
+}
+
+// RemoveRenderPass removes the render pass with the given name, if it was inserted.
+func RemoveRenderPass(name string) {
+	renderPassMutex.Lock()
+	removeRenderPass(name)
+	renderPassMutex.Unlock()
+}
+
+func removeRenderPass(name string) {
+	for i, pass := range renderPasses {
+		if pass.Name == name {
+			renderPasses = append(renderPasses[:i], renderPasses[i+1:]...)
+			return
+		}
+	}
+}
+
+// RenderPasses returns all inserted render passes in the order in which they are drawn.
+func RenderPasses() []RenderPass {
+	renderPassMutex.RLock()
+	defer renderPassMutex.RUnlock()
+
+	return append([]RenderPass(nil), renderPasses...)
+}
@@ -0,0 +1,40 @@
+package engo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRenderPassOrder(t *testing.T) {
+	var drawn []string
+	pass := func(name string) func() {
+		return func() { drawn = append(drawn, name) }
+	}
+
+	InsertRenderPass("post", RenderPassPost, pass("post"))
+	InsertRenderPass("lighting", RenderPassLighting, pass("lighting"))
+	InsertRenderPass("particles", RenderPassParticles, pass("particles"))
+	InsertRenderPass("sparks", RenderPassParticles, pass("sparks"))
+	defer func() {
+		for _, name := range []string{"post", "lighting", "particles", "sparks"} {
+			RemoveRenderPass(name)
+		}
+	}()
+
+	// Replacing a pass moves it to its new order
+	InsertRenderPass("post", RenderPassWorld, pass("post"))
+
+	for _, p := range RenderPasses() {
+		p.Draw()
+	}
+
+	expected := []string{"post", "lighting", "particles", "sparks"}
+	if !reflect.DeepEqual(drawn, expected) {
+		t.Errorf("render passes were not drawn in order. Wanted: %v, got: %v", expected, drawn)
+	}
+
+	RemoveRenderPass("lighting")
+	if n := len(RenderPasses()); n != 3 {
+		t.Errorf("render pass was not removed. Wanted: %v passes, got: %v", 3, n)
+	}
+}
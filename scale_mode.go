@@ -0,0 +1,89 @@
+package engo
+
+// ScaleMode is how the game is scaled to the window while ScaleOnResize is set, whenever the aspect ratio of the
+// window differs from that of the game. See `RunOptions.ScaleMode`.
+type ScaleMode uint8
+
+const (
+	// ScaleStretch stretches the game over the whole window, distorting it whenever the aspect ratio differs
+	ScaleStretch ScaleMode = iota
+	// ScaleFit scales the game to fit within the window while keeping its aspect ratio, drawing black bars along the
+	// sides that are left over (letterboxing)
+	ScaleFit
+	// ScaleFill scales the game to fill the whole window while keeping its aspect ratio, cutting off the edges of the
+	// game that don't fit
+	ScaleFill
+)
+
+// GetScaleMode returns the ScaleMode set in the RunOptions or via SetScaleMode()
+func GetScaleMode() ScaleMode {
+	return opts.ScaleMode
+}
+
+// SetScaleMode sets how the game is scaled to the window while ScaleOnResize is set. It takes effect from the next
+// frame on.
+func SetScaleMode(mode ScaleMode) {
+	opts.ScaleMode = mode
+}
+
+// GameViewport returns the area of the window in which the game is drawn, in window coordinates. It's the whole
+// window unless ScaleOnResize is set along with the ScaleFit or ScaleFill mode, in which case it's the largest
+// (or smallest) area with the aspect ratio of the game, centered within the window. With ScaleFill it extends beyond
+// the window.
+//
+// The mobile and web backends always draw the game over the whole window.
+func GameViewport() AABB {
+	ww, wh := WindowWidth(), WindowHeight()
+	window := AABB{Max: Point{X: ww, Y: wh}}
+	if !opts.ScaleOnResize || opts.ScaleMode == ScaleStretch || CurrentBackEnd == BackEndMobile || CurrentBackEnd == BackEndWeb {
+		return window
+	}
+	gw, gh := GameWidth(), GameHeight()
+	if gw <= 0 || gh <= 0 || ww <= 0 || wh <= 0 {
+		return window
+	}
+
+	scale := ww / gw
+	if (opts.ScaleMode == ScaleFit) == (wh/gh < scale) {
+		scale = wh / gh
+	}
+	w, h := gw*scale, gh*scale
+	x, y := (ww-w)/2, (wh-h)/2
+	return AABB{Min: Point{X: x, Y: y}, Max: Point{X: x + w, Y: y + h}}
+}
+
+// CanvasViewport returns the GameViewport in pixels of the canvas, as passed to `Gl.Viewport`: the y-axis points up
+// from the bottom of the canvas.
+func CanvasViewport() (x, y, width, height int) {
+	vp := GameViewport()
+	sx, sy := float32(1), float32(1)
+	if ww, wh := WindowWidth(), WindowHeight(); ww > 0 && wh > 0 {
+		sx, sy = CanvasWidth()/ww, CanvasHeight()/wh
+	}
+	round := func(f float32) int {
+		if f < 0 {
+			return int(f - 0.5)
+		}
+		return int(f + 0.5)
+	}
+	x, width = round(vp.Min.X*sx), round((vp.Max.X-vp.Min.X)*sx)
+	height = round((vp.Max.Y - vp.Min.Y) * sy)
+	y = round(CanvasHeight() - vp.Max.Y*sy)
+	return
+}
+
+// WindowToGame translates a position within the window, in the coordinates of the mouse (see `Input.Mouse`), into
+// the coordinates of the game on the screen, as used by the HUD. It takes the GameViewport into account, so with
+// the ScaleFit mode positions on the black bars are outside of the game. The mobile and web backends already report
+// the mouse in the coordinates of the game, so it returns the position as is.
+func WindowToGame(x, y float32) (float32, float32) {
+	if CurrentBackEnd == BackEndMobile || CurrentBackEnd == BackEndWeb {
+		return x, y
+	}
+	vp := GameViewport()
+	size := vp.Size()
+	if size.X <= 0 || size.Y <= 0 {
+		return x, y
+	}
+	return (x - vp.Min.X/opts.GlobalScale.X) * GameWidth() / size.X, (y - vp.Min.Y/opts.GlobalScale.Y) * GameHeight() / size.Y
+}
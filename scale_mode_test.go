@@ -0,0 +1,53 @@
+package engo
+
+import "testing"
+
+func TestGameViewport(t *testing.T) {
+	Run(RunOptions{
+		NoRun:         true,
+		HeadlessMode:  true,
+		Width:         100,
+		Height:        50,
+		ScaleOnResize: true,
+	}, &inputTestScene{})
+	// The window was resized to be twice as high as the game, and its canvas has twice as many pixels
+	windowWidth, windowHeight = 200, 200
+	canvasWidth, canvasHeight = 400, 400
+	defer func() {
+		windowWidth, windowHeight = 100, 50
+		canvasWidth, canvasHeight = 100, 50
+	}()
+
+	tests := []struct {
+		mode       ScaleMode
+		viewport   AABB
+		canvas     [4]int
+		gameX      float32
+		gameY      float32
+		mouseAtTop bool
+	}{
+		{ScaleStretch, AABB{Max: Point{X: 200, Y: 200}}, [4]int{0, 0, 400, 400}, 50, 12.5, true},
+		{ScaleFit, AABB{Min: Point{Y: 50}, Max: Point{X: 200, Y: 150}}, [4]int{0, 100, 400, 200}, 50, 0, false},
+		{ScaleFill, AABB{Min: Point{X: -100}, Max: Point{X: 300, Y: 200}}, [4]int{-200, 0, 800, 400}, 50, 12.5, true},
+	}
+	for _, test := range tests {
+		SetScaleMode(test.mode)
+		if vp := GameViewport(); vp != test.viewport {
+			t.Errorf("wrong viewport for mode %v. Wanted: %v, got: %v", test.mode, test.viewport, vp)
+		}
+		if x, y, w, h := CanvasViewport(); [4]int{x, y, w, h} != test.canvas {
+			t.Errorf("wrong viewport of the canvas for mode %v. Wanted: %v, got: %v", test.mode, test.canvas, [4]int{x, y, w, h})
+		}
+		if x, y := WindowToGame(100, 50); x != test.gameX || y != test.gameY {
+			t.Errorf("mouse was not translated into the game for mode %v. Wanted: (%v, %v), got: (%v, %v)", test.mode, test.gameX, test.gameY, x, y)
+		}
+
+		// The black bars at the top and bottom are outside of the game
+		Input.Mouse.X, Input.Mouse.Y = 100, 20
+		Input.updateMouse()
+		if Input.Mouse.Inside != test.mouseAtTop {
+			t.Errorf("mouse at the top of the window was not inside the game area for mode %v. Wanted: %v, got: %v", test.mode, test.mouseAtTop, Input.Mouse.Inside)
+		}
+	}
+	SetScaleMode(ScaleStretch)
+}
@@ -3,6 +3,8 @@ package engo
 import (
 	"fmt"
 	"reflect"
+
+	"github.com/EngoEngine/ecs"
 )
 
 var scenes = make(map[string]*sceneWrapper)
@@ -21,7 +23,8 @@ type Scene interface {
 }
 
 // Shower is an optional interface a Scene can implement, indicating it'll have custom behavior
-// whenever the Scene gets shown again after being hidden (due to switching to other Scenes)
+// whenever the Scene gets shown again after being hidden (due to switching to other Scenes).
+// This only happens for Scenes that persist, which they do by default, see `Persister`.
 type Shower interface {
 	// Show is called whenever the other Scene becomes inactive, and this one becomes the active one
 	Show()
@@ -34,6 +37,34 @@ type Hider interface {
 	Hide()
 }
 
+// Persister is an optional interface a Scene can implement, indicating whether or not its Updater (including all
+// Systems and entities) should be kept whenever another Scene becomes active. Scenes persist unless they implement
+// Persister and return false. Scenes that do not persist are torn down as soon as another Scene becomes active: every
+// entity is removed from the Systems listing them (see `EntityLister`), after which the Updater is released. They are
+// set up again from scratch whenever they become active again.
+type Persister interface {
+	// Persist indicates whether or not the Scene should be kept when switching to other Scenes
+	Persist() bool
+}
+
+// SceneExiter is an optional interface a Scene can implement, indicating it'll have custom behavior
+// whenever the Scene stops being the active one. This happens every time another Scene becomes active,
+// whether or not the Scene persists, and when the game gets closed while the Scene is active.
+type SceneExiter interface {
+	// ExitScene is called right after `Hider.Hide`, and before the Scene is torn down if it does not persist. It
+	// should be used to release anything the Scene holds on to while it's active, such as open files or playing
+	// audio. Unlike `Exiter`, it cannot prevent anything from happening and should not call `engo.Exit`.
+	ExitScene()
+}
+
+// EntityLister is an optional interface a System can implement, listing the entities it holds. Whenever the Scene the
+// System belongs to is torn down, each of these entities is removed from the System using its Remove, so it can
+// release anything it keeps for them.
+type EntityLister interface {
+	// BasicEntities returns a copy of the BasicEntities of all entities in the System
+	BasicEntities() []ecs.BasicEntity
+}
+
 // Exiter is an optional interface a Scene can implement, indicating it'll have custom behavior
 // whenever the game get closed.
 type Exiter interface {
@@ -56,6 +87,9 @@ type sceneWrapper struct {
 	scene   Scene
 	update  Updater
 	mailbox *MessageManager
+
+	// manifestLoaded indicates the assets in the manifest of the Scene are loaded, see `Manifester`
+	manifestLoaded bool
 }
 
 // CurrentScene returns the SceneWorld that is currently active
@@ -65,12 +99,32 @@ func CurrentScene() Scene {
 
 // SetScene sets the currentScene to the given Scene, and
 // optionally forcing to create a new ecs.World that goes with it.
+// The previous Scene is exited (see `SceneExiter`), and torn down if it does not persist (see `Persister`).
+// If the Scene has a manifest (see `Manifester`), it is set once all of its assets are loaded.
 func SetScene(s Scene, forceNewWorld bool) {
+	// Setting another Scene cancels loading the assets of the pending one
+	if loadingScene == nil || s.Type() != loadingScene.Type() {
+		cancelManifest()
+	}
+	if startManifest(s, forceNewWorld) {
+		return
+	}
+
 	// Break down currentScene
 	if currentScene != nil {
 		if hider, ok := currentScene.(Hider); ok {
 			hider.Hide()
 		}
+		if exiter, ok := currentScene.(SceneExiter); ok {
+			exiter.ExitScene()
+		}
+
+		if persister, ok := currentScene.(Persister); ok && !persister.Persist() {
+			sceneMutex.RLock()
+			current := scenes[currentScene.Type()]
+			sceneMutex.RUnlock()
+			current.teardown()
+		}
 	}
 
 	// Register Scene if needed
@@ -90,10 +144,13 @@ func SetScene(s Scene, forceNewWorld bool) {
 	var doSetup bool
 
 	if wrapper.update == nil || forceNewWorld {
+		wrapper.teardown()
+
 		t := reflect.Indirect(reflect.ValueOf(currentUpdater)).Type()
 		v := reflect.New(t)
 		wrapper.update = v.Interface().(Updater)
 		wrapper.mailbox = &MessageManager{}
+		wrapper.scene = s
 
 		doSetup = true
 	}
@@ -107,7 +164,7 @@ func SetScene(s Scene, forceNewWorld bool) {
 	if doSetup {
 		s.Preload()
 
-		wrapper.mailbox.listeners = make(map[string][]HandlerIDPair)
+		wrapper.mailbox.reset()
 
 		s.Setup(wrapper.update)
 	} else {
@@ -117,6 +174,59 @@ func SetScene(s Scene, forceNewWorld bool) {
 	}
 }
 
+// teardown removes all entities from the Systems of the Scene, and releases its Updater and MessageManager.
+func (w *sceneWrapper) teardown() {
+	if w == nil || w.update == nil {
+		return
+	}
+	removeEntities(w.update)
+	forgetSystems(w.update)
+	w.update = nil
+	w.mailbox = nil
+	w.manifestLoaded = false
+}
+
+// removeEntities removes the entities listed by the Systems of the Updater (see `EntityLister`) from those Systems.
+func removeEntities(u Updater) {
+	w, ok := u.(*ecs.World)
+	if !ok {
+		return
+	}
+	for _, system := range w.Systems() {
+		lister, ok := system.(EntityLister)
+		if !ok {
+			continue
+		}
+		for _, e := range lister.BasicEntities() {
+			system.Remove(e)
+		}
+	}
+}
+
+// teardownScenes exits the current Scene, and tears down all Scenes that are currently set up.
+func teardownScenes() {
+	sceneMutex.RLock()
+	wrappers := make([]*sceneWrapper, 0, len(scenes))
+	for _, wrapper := range scenes {
+		wrappers = append(wrappers, wrapper)
+	}
+	var current *sceneWrapper
+	if currentScene != nil {
+		current = scenes[currentScene.Type()]
+	}
+	sceneMutex.RUnlock()
+
+	if current != nil && current.update != nil {
+		if exiter, ok := currentScene.(SceneExiter); ok {
+			exiter.ExitScene()
+		}
+	}
+
+	for _, wrapper := range wrappers {
+		wrapper.teardown()
+	}
+}
+
 // RegisterScene registers the `Scene`, so it can later be used by `SetSceneByName`
 func RegisterScene(s Scene) {
 	sceneMutex.RLock()
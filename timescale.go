@@ -0,0 +1,61 @@
+package engo
+
+import "github.com/EngoEngine/ecs"
+
+// timeScale is the scale set using SetTimeScale.
+var timeScale = float32(1)
+
+// unscaledSystems contains the systems which ignore the time scale by their systemKey, see SetSystemUnscaled.
+var unscaledSystems = make(map[interface{}]bool)
+
+// SetTimeScale scales the time that passes in the game, such as for slow-motion effects: every frame, the delta time
+// the systems are updated with is the real time the frame took, multiplied by the scale. A scale of 0.5 runs the game
+// at half speed, 2 at double speed, and 0 stops time altogether. Unlike with SetSystemPaused, the systems are still
+// updated at a scale of 0, just with a delta time of 0, so the RenderSystem keeps drawing and input keeps being
+// handled. If scale is less than zero, the TimeScale is set to 1.
+//
+// The time scale applies to the systems of an *ecs.World, and to other Updaters as a whole. Systems such as those of
+// a pause menu or UI animations can ignore it using SetSystemUnscaled. Systems on a fixed timestep (see
+// `SetSystemTimestep`) are still updated with their own dt, just less or more often, as the scaled time accumulates.
+// The time scale doesn't apply to scene transitions, nor to `Time`, which always measures real time.
+func SetTimeScale(scale float32) {
+	if scale < 0 {
+		timeScale = 1
+		return
+	}
+	timeScale = scale
+}
+
+// GetTimeScale returns the TimeScale set via SetTimeScale.
+func GetTimeScale() float32 {
+	return timeScale
+}
+
+// SetSystemUnscaled sets whether or not the given system ignores the time scale set using SetTimeScale, and is always
+// updated with the real delta time of the frame instead. The system has to be the same (pointer) value that was added
+// to the *ecs.World of a Scene, and just like with pausing, values of a type that can't be compared always use the
+// time scale. Just like pausing, this is forgotten once the Scene of the system is torn down.
+func SetSystemUnscaled(system ecs.System, unscaled bool) {
+	key := systemKey(system)
+	if key == nil {
+		return
+	}
+	if unscaled {
+		unscaledSystems[key] = true
+	} else {
+		delete(unscaledSystems, key)
+	}
+}
+
+// SystemUnscaled returns whether or not the given system ignores the time scale, see SetSystemUnscaled.
+func SystemUnscaled(system ecs.System) bool {
+	return unscaledSystems[systemKey(system)]
+}
+
+// scaledDelta returns the delta time the given system is updated with, given the real delta time of the frame.
+func scaledDelta(system ecs.System, dt float32) float32 {
+	if unscaledSystems[systemKey(system)] {
+		return dt
+	}
+	return dt * timeScale
+}
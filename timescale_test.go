@@ -0,0 +1,54 @@
+package engo
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+)
+
+func TestSetTimeScale(t *testing.T) {
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, &testScopedScene{name: "testTimeScaleScene"})
+	defer SetTimeScale(1)
+	w := currentUpdater.(*ecs.World)
+	scaled, unscaled, fixed := &timestepSystem{}, &timestepSystem{}, &timestepSystem{}
+	w.AddSystem(scaled)
+	w.AddSystem(unscaled)
+	w.AddSystem(fixed)
+
+	SetTimeScale(0.5)
+	updateScene(1)
+	SetSystemUnscaled(unscaled, true)
+	SetSystemTimestep(fixed, 0.25, 0)
+	if !SystemUnscaled(unscaled) || SystemUnscaled(scaled) {
+		t.Fatal("only the unscaled system should be reported as unscaled")
+	}
+	updateScene(1)
+	SetTimeScale(0)
+	updateScene(1)
+
+	if want := []float32{0.5, 0.5, 0}; !reflect.DeepEqual(scaled.dts, want) {
+		t.Errorf("system was not updated with the scaled delta time. Wanted: %v, got: %v", want, scaled.dts)
+	}
+	if want := []float32{0.5, 1, 1}; !reflect.DeepEqual(unscaled.dts, want) {
+		t.Errorf("unscaled system was not updated with the real delta time. Wanted: %v, got: %v", want, unscaled.dts)
+	}
+	// Half a second of scaled time fits two fixed steps, and none pass while time is stopped
+	if want := []float32{0.5, 0.25, 0.25}; !reflect.DeepEqual(fixed.dts, want) {
+		t.Errorf("system on a fixed timestep was not stepped by the scaled time. Wanted: %v, got: %v", want, fixed.dts)
+	}
+
+	SetTimeScale(-1)
+	if GetTimeScale() != 1 {
+		t.Errorf("negative time scale was not reset to 1, got: %v", GetTimeScale())
+	}
+
+	// Tearing down the scene forgets which of its systems are unscaled.
+	SetScene(&testScopedScene{name: "testTimeScaleScene2"}, false)
+	if SystemUnscaled(unscaled) {
+		t.Error("system of a torn down scene was still unscaled")
+	}
+}
@@ -0,0 +1,70 @@
+package engo
+
+import (
+	"github.com/EngoEngine/ecs"
+	"github.com/EngoEngine/engo/math"
+)
+
+// DefaultMaxSteps is the maximum amount of times per frame a system on a fixed timestep is updated, when no maximum
+// is passed to SetSystemTimestep.
+const DefaultMaxSteps = 5
+
+// fixedSteps contains the systems set to a fixed timestep using SetSystemTimestep, by their systemKey.
+var fixedSteps = make(map[interface{}]*fixedStep)
+
+// fixedStep accumulates the time a system on a fixed timestep still has to be updated for.
+type fixedStep struct {
+	dt          float32
+	maxSteps    int
+	accumulated float32
+}
+
+// SetSystemTimestep updates the given system on a fixed timestep, which is useful for physics that should not depend
+// on the framerate. The system has to be the same (pointer) value that was added to the *ecs.World of a Scene, and
+// just like with pausing, values of a type that can't be compared are never put on a fixed timestep.
+// Instead of once per frame, the system is updated as many times as fit into the time that passed, always with the
+// given dt in seconds, carrying the remainder over to the next frame. Since the other systems, such as the
+// RenderSystem, are still updated once per frame, `TimestepAlpha` can be used to interpolate between steps.
+//
+// To prevent a slow frame from needing ever more steps to catch up, the system is updated at most maxSteps times per
+// frame, after which the time that's left is dropped. DefaultMaxSteps is used if maxSteps is zero. A dt of zero
+// updates the system once per frame again. Just like pausing, the timestep is forgotten once the Scene of the system
+// is torn down.
+func SetSystemTimestep(system ecs.System, dt float32, maxSteps int) {
+	key := systemKey(system)
+	if key == nil {
+		return
+	}
+	if dt <= 0 {
+		delete(fixedSteps, key)
+		return
+	}
+	if maxSteps <= 0 {
+		maxSteps = DefaultMaxSteps
+	}
+	fixedSteps[key] = &fixedStep{dt: dt, maxSteps: maxSteps}
+}
+
+// TimestepAlpha returns how far the time carried over to the next frame is towards the next step of a system on a
+// fixed timestep, from 0 up to 1. Drawing the state of the previous step interpolated by this fraction towards the
+// current one keeps the movement smooth. It's zero for systems which are not on a fixed timestep.
+func TimestepAlpha(system ecs.System) float32 {
+	step, ok := fixedSteps[systemKey(system)]
+	if !ok {
+		return 0
+	}
+	return step.accumulated / step.dt
+}
+
+// update updates the system for as many steps as fit into the accumulated time.
+func (f *fixedStep) update(system ecs.System, dt float32) {
+	f.accumulated += dt
+	for steps := 0; f.accumulated >= f.dt; steps++ {
+		if steps == f.maxSteps {
+			f.accumulated = math.Mod(f.accumulated, f.dt)
+			return
+		}
+		system.Update(f.dt)
+		f.accumulated -= f.dt
+	}
+}
@@ -0,0 +1,68 @@
+package engo
+
+import (
+	"testing"
+
+	"github.com/EngoEngine/ecs"
+)
+
+type timestepSystem struct {
+	dts []float32
+}
+
+func (*timestepSystem) Remove(ecs.BasicEntity) {}
+
+func (s *timestepSystem) Update(dt float32) { s.dts = append(s.dts, dt) }
+
+func TestSetSystemTimestep(t *testing.T) {
+	scene := &testScopedScene{name: "testTimestepScene"}
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, scene)
+	fixed := &timestepSystem{}
+	currentUpdater.(*ecs.World).AddSystem(fixed)
+	SetSystemTimestep(fixed, 0.25, 3)
+
+	updateScene(0.6)
+	if len(fixed.dts) != 2 || fixed.dts[0] != 0.25 || fixed.dts[1] != 0.25 {
+		t.Errorf("system was not updated a whole number of times with the fixed dt. Wanted: [0.25 0.25], got: %v", fixed.dts)
+	}
+	if scene.sys.updates != 1 {
+		t.Errorf("other systems were not updated once per frame. Wanted: 1, got: %v", scene.sys.updates)
+	}
+	if alpha := TimestepAlpha(fixed); alpha < 0.39 || alpha > 0.41 {
+		t.Errorf("remainder was not carried over. Wanted an alpha of 0.4, got: %v", alpha)
+	}
+
+	updateScene(0.15)
+	if len(fixed.dts) != 3 {
+		t.Errorf("carried over remainder did not add up to another step. Wanted: 3 steps, got: %v", len(fixed.dts))
+	}
+
+	// A very long frame is clamped to the maximum amount of steps, dropping the rest.
+	fixed.dts = nil
+	updateScene(10)
+	if len(fixed.dts) != 3 {
+		t.Errorf("steps were not clamped. Wanted: 3, got: %v", len(fixed.dts))
+	}
+	if alpha := TimestepAlpha(fixed); alpha >= 1 {
+		t.Errorf("time which did not fit into the steps was carried over, got an alpha of %v", alpha)
+	}
+
+	SetSystemTimestep(fixed, 0, 0)
+	fixed.dts = nil
+	updateScene(0.1)
+	if len(fixed.dts) != 1 || fixed.dts[0] != 0.1 {
+		t.Errorf("system was not updated once per frame after removing its timestep, got: %v", fixed.dts)
+	}
+
+	SetSystemTimestep(fixed, 0.1, 0)
+	if fixedSteps[fixed].maxSteps != DefaultMaxSteps {
+		t.Errorf("DefaultMaxSteps was not used without a maximum, got: %v", fixedSteps[fixed].maxSteps)
+	}
+	SetScene(&testScopedScene{name: "testTimestepScene2"}, false)
+	if _, ok := fixedSteps[fixed]; ok {
+		t.Error("timestep of a system of a torn down scene was kept")
+	}
+}
@@ -0,0 +1,85 @@
+package engo
+
+import "image/color"
+
+// Transition describes how to switch to another Scene using `SetSceneWithTransition`. The current Scene fades to
+// Color during the first half of the Duration, after which the other Scene is set and fades in from Color during
+// the second half. Both Scenes keep being updated while fading, but `Input` is ignored until the transition is done,
+// so clicks and key presses meant for one Scene don't end up in the other one. This includes keys retrieved using
+// `InputManager.Key`, gamepads and typed TextMessages. Only `InputManager.Touches` keeps being reported.
+type Transition struct {
+	// Duration is the time in seconds the entire transition takes
+	Duration float32
+	// Color is the color which is faded to in between the Scenes. It's black when nil.
+	Color color.Color
+}
+
+var transition *sceneTransition
+
+// sceneTransition keeps track of the transition which is in progress.
+type sceneTransition struct {
+	Transition
+	scene         Scene
+	forceNewWorld bool
+	elapsed       float32
+	switched      bool
+}
+
+// SetSceneWithTransition sets the given Scene just like `SetScene`, after fading out the current Scene as described
+// by the Transition. The common.RenderSystem draws the fade over everything else. If a transition is already in
+// progress, it's replaced. A Transition without a Duration sets the Scene right away.
+func SetSceneWithTransition(s Scene, forceNewWorld bool, t Transition) {
+	if t.Duration <= 0 {
+		transition = nil
+		SetScene(s, forceNewWorld)
+		return
+	}
+	if t.Color == nil {
+		t.Color = color.Black
+	}
+	transition = &sceneTransition{Transition: t, scene: s, forceNewWorld: forceNewWorld}
+}
+
+// Transitioning returns whether or not a transition started by `SetSceneWithTransition` is in progress.
+func Transitioning() bool {
+	return transition != nil
+}
+
+// TransitionFade returns the color the screen is faded to by the transition in progress, along with how far it is
+// faded, from 0 for not at all to 1 for entirely covering the screen. It's used by the common.RenderSystem to draw
+// the transition.
+func TransitionFade() (color.Color, float32) {
+	t := transition
+	if t == nil {
+		return nil, 0
+	}
+
+	half := t.Duration / 2
+	fade := t.elapsed / half
+	if t.switched {
+		fade = 1 - (t.elapsed-half)/half
+	}
+	if fade < 0 {
+		fade = 0
+	} else if fade > 1 {
+		fade = 1
+	}
+	return t.Color, fade
+}
+
+// updateTransition advances the transition in progress, and sets its Scene once the current Scene has faded out.
+func updateTransition(dt float32) {
+	t := transition
+	if t == nil {
+		return
+	}
+
+	t.elapsed += dt
+	if !t.switched && t.elapsed >= t.Duration/2 {
+		t.switched = true
+		SetScene(t.scene, t.forceNewWorld)
+	}
+	if t.elapsed >= t.Duration && transition == t {
+		transition = nil
+	}
+}
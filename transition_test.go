@@ -0,0 +1,75 @@
+package engo
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestSetSceneWithTransition(t *testing.T) {
+	first := &testScopedScene{name: "testTransitionScene"}
+	second := &testScopedScene{name: "testTransitionScene2"}
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+	}, first)
+	Input.RegisterButton("transitionClick", KeyEnter)
+	Input.keys.Set(KeyEnter, true)
+	Input.Gamepad(0).setButton(GamepadButtonA, true)
+
+	SetSceneWithTransition(second, false, Transition{Duration: 1, Color: color.White})
+	if CurrentScene() != first || !Transitioning() {
+		t.Fatal("scene was set before fading out")
+	}
+
+	updateScene(0.25)
+	if c, fade := TransitionFade(); c != color.White || fade != 0.5 {
+		t.Errorf("wrong fade while fading out. Wanted: 0.5, got: %v", fade)
+	}
+	if Input.Button("transitionClick").JustPressed() {
+		t.Error("input was not ignored during the transition")
+	}
+	if Input.Key(KeyEnter).JustPressed() {
+		t.Error("key was not ignored during the transition")
+	}
+	if Input.Gamepad(0).ButtonDown(GamepadButtonA) || Input.Gamepad(1).ButtonDown(GamepadButtonA) {
+		t.Error("gamepad was not ignored during the transition")
+	}
+
+	updateScene(0.25)
+	if CurrentScene() != second || second.setups != 1 {
+		t.Fatal("scene was not set halfway through the transition")
+	}
+	if _, fade := TransitionFade(); fade != 1 {
+		t.Errorf("screen was not entirely faded halfway through the transition, got: %v", fade)
+	}
+
+	updateScene(0.25)
+	if _, fade := TransitionFade(); fade != 0.5 {
+		t.Errorf("wrong fade while fading in. Wanted: 0.5, got: %v", fade)
+	}
+	updateScene(0.25)
+	if Transitioning() {
+		t.Error("transition did not end after its duration")
+	}
+	if _, fade := TransitionFade(); fade != 0 {
+		t.Errorf("screen was still faded after the transition, got: %v", fade)
+	}
+	if !Input.Button("transitionClick").JustPressed() || !Input.Key(KeyEnter).JustPressed() {
+		t.Error("input was still ignored after the transition")
+	}
+	if !Input.Gamepad(0).ButtonDown(GamepadButtonA) {
+		t.Error("gamepad was still ignored after the transition")
+	}
+	Input.Gamepad(0).setButton(GamepadButtonA, false)
+
+	// Transitions without a duration, and without a color, fall back to switching right away and to black.
+	SetSceneWithTransition(first, false, Transition{})
+	if CurrentScene() != first || Transitioning() {
+		t.Error("scene was not set right away without a duration")
+	}
+	SetSceneWithTransition(second, false, Transition{Duration: 2})
+	if c, _ := TransitionFade(); c != color.Black {
+		t.Errorf("transition without a color did not fade to black, got: %v", c)
+	}
+	transition = nil
+}
@@ -0,0 +1,99 @@
+package engo
+
+// windowedWidth and windowedHeight are the size the window is restored to once it leaves fullscreen.
+var windowedWidth, windowedHeight int
+
+// contentScale is the content scale of the display while running headless, see `SetContentScale`.
+var contentScale = float32(1)
+
+// IsFullscreen indicates whether or not the game is running in fullscreen mode, either because it was started with
+// `RunOptions.Fullscreen` or because of `SetFullscreen`.
+func IsFullscreen() bool {
+	return opts.Fullscreen
+}
+
+// validWindowSize indicates whether or not a window can be resized to the given size, warning if it can't.
+func validWindowSize(width, height int) bool {
+	if width <= 0 || height <= 0 {
+		warning("window size out of bounds, requires > 0")
+		return false
+	}
+	return true
+}
+
+// overlap returns the length of the overlap of the ranges [aMin, aMax) and [bMin, bMax), or zero if they don't.
+func overlap(aMin, aMax, bMin, bMax int) int {
+	if bMin > aMin {
+		aMin = bMin
+	}
+	if bMax < aMax {
+		aMax = bMax
+	}
+	if aMax < aMin {
+		return 0
+	}
+	return aMax - aMin
+}
+
+// setHeadlessWindowSize resizes the window while running headless, see `SetWindowSize`. The canvas is as large as the
+// window, times the content scale.
+func setHeadlessWindowSize(width, height int) {
+	if !validWindowSize(width, height) {
+		return
+	}
+	if opts.Fullscreen {
+		windowedWidth, windowedHeight = width, height
+		return
+	}
+	resizeHeadlessWindow(width, height)
+}
+
+// setHeadlessFullscreen switches to or from fullscreen while running headless, see `SetFullscreen`. The
+// headless monitor is as large as the default headless window.
+func setHeadlessFullscreen(fullscreen bool) {
+	if fullscreen == opts.Fullscreen {
+		return
+	}
+	opts.Fullscreen = fullscreen
+	if fullscreen {
+		windowedWidth, windowedHeight = int(windowWidth), int(windowHeight)
+		resizeHeadlessWindow(headlessWidth, headlessHeight)
+	} else {
+		resizeHeadlessWindow(windowedWidth, windowedHeight)
+	}
+}
+
+// resizeHeadlessWindow updates the sizes of the window, canvas and game the same way resizing a real window does,
+// and dispatches a WindowResizeMessage if they changed. Like on a high-DPI display, the canvas is larger than the
+// window by the content scale.
+func resizeHeadlessWindow(width, height int) {
+	fw, fh := int(float32(width)*contentScale+0.5), int(float32(height)*contentScale+0.5)
+	message := WindowResizeMessage{
+		OldWidth:        int(windowWidth),
+		OldHeight:       int(windowHeight),
+		NewWidth:        width,
+		NewHeight:       height,
+		OldCanvasWidth:  int(canvasWidth),
+		OldCanvasHeight: int(canvasHeight),
+		NewCanvasWidth:  fw,
+		NewCanvasHeight: fh,
+	}
+	if message.OldWidth == width && message.OldHeight == height && message.OldCanvasWidth == fw && message.OldCanvasHeight == fh {
+		return
+	}
+
+	windowWidth, windowHeight = float32(width), float32(height)
+	ResizeXOffset += canvasWidth - float32(fw)
+	ResizeYOffset += canvasHeight - float32(fh)
+	canvasWidth, canvasHeight = float32(fw), float32(fh)
+
+	if !opts.ScaleOnResize {
+		gameWidth, gameHeight = float32(width), float32(height)
+	}
+	if !cursorGrabbed {
+		Input.updateMouse()
+	}
+	if Mailbox != nil {
+		Mailbox.Dispatch(message)
+	}
+}
@@ -0,0 +1,128 @@
+package engo
+
+import "testing"
+
+func TestSetWindowSize(t *testing.T) {
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &stepTestScene{})
+
+	var messages []WindowResizeMessage
+	Mailbox.Listen("WindowResizeMessage", func(msg Message) {
+		messages = append(messages, msg.(WindowResizeMessage))
+	})
+
+	SetWindowSize(640, 480)
+	if WindowWidth() != 640 || WindowHeight() != 480 || CanvasWidth() != 640 || CanvasHeight() != 480 {
+		t.Errorf("window was not resized. Wanted: 640x480, got: %vx%v", WindowWidth(), WindowHeight())
+	}
+	if GameWidth() != 640 || GameHeight() != 480 {
+		t.Errorf("game was not resized along with the window, got: %vx%v", GameWidth(), GameHeight())
+	}
+	want := WindowResizeMessage{
+		OldWidth: 400, OldHeight: 300, NewWidth: 640, NewHeight: 480,
+		OldCanvasWidth: 400, OldCanvasHeight: 300, NewCanvasWidth: 640, NewCanvasHeight: 480,
+	}
+	if len(messages) != 1 || messages[0] != want {
+		t.Errorf("resize was not dispatched once. Wanted: [%+v], got: %+v", want, messages)
+	}
+
+	SetWindowSize(640, 480)
+	SetWindowSize(0, 100)
+	if len(messages) != 1 || WindowWidth() != 640 {
+		t.Errorf("window was resized without a new valid size, messages: %v, width: %v", len(messages), WindowWidth())
+	}
+}
+
+func TestSetWindowSizeScaleOnResize(t *testing.T) {
+	Run(RunOptions{
+		NoRun:         true,
+		HeadlessMode:  true,
+		Width:         400,
+		Height:        300,
+		ScaleOnResize: true,
+	}, &stepTestScene{})
+
+	SetWindowSize(800, 600)
+	if WindowWidth() != 800 || GameWidth() != 400 || GameHeight() != 300 {
+		t.Errorf("game was resized while ScaleOnResize was set. Window: %v, game: %vx%v", WindowWidth(), GameWidth(), GameHeight())
+	}
+}
+
+func TestSetFullscreen(t *testing.T) {
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &stepTestScene{})
+
+	resized := 0
+	Mailbox.Listen("WindowResizeMessage", func(Message) { resized++ })
+
+	SetFullscreen(true)
+	if !IsFullscreen() {
+		t.Error("game was not fullscreen after switching to fullscreen")
+	}
+	if WindowWidth() != float32(headlessWidth) || WindowHeight() != float32(headlessHeight) || GameWidth() != float32(headlessWidth) {
+		t.Errorf("window was not resized to the monitor, got: %vx%v", WindowWidth(), WindowHeight())
+	}
+	SetFullscreen(true)
+
+	// Resizing while fullscreen only changes the size the window is restored to
+	SetWindowSize(500, 400)
+	if WindowWidth() != float32(headlessWidth) {
+		t.Errorf("fullscreen window was resized to %v", WindowWidth())
+	}
+
+	SetFullscreen(false)
+	if IsFullscreen() || WindowWidth() != 500 || WindowHeight() != 400 {
+		t.Errorf("window was not restored after leaving fullscreen. Fullscreen: %v, size: %vx%v", IsFullscreen(), WindowWidth(), WindowHeight())
+	}
+	if resized != 2 {
+		t.Errorf("every switch was not dispatched once, got %v messages", resized)
+	}
+}
+
+func TestSetContentScale(t *testing.T) {
+	Run(RunOptions{
+		NoRun:        true,
+		HeadlessMode: true,
+		Width:        400,
+		Height:       300,
+	}, &stepTestScene{})
+
+	var messages []WindowResizeMessage
+	Mailbox.Listen("WindowResizeMessage", func(msg Message) {
+		messages = append(messages, msg.(WindowResizeMessage))
+	})
+
+	SetContentScale(2)
+	if ContentScale() != 2 || CanvasScale() != 2 {
+		t.Errorf("content scale was not set. Wanted: 2, got: %v, canvas scale: %v", ContentScale(), CanvasScale())
+	}
+	if WindowWidth() != 400 || GameWidth() != 400 || CanvasWidth() != 800 || CanvasHeight() != 600 {
+		t.Errorf("only the canvas should have been scaled. Window: %v, game: %v, canvas: %vx%v", WindowWidth(), GameWidth(), CanvasWidth(), CanvasHeight())
+	}
+	want := WindowResizeMessage{
+		OldWidth: 400, OldHeight: 300, NewWidth: 400, NewHeight: 300,
+		OldCanvasWidth: 400, OldCanvasHeight: 300, NewCanvasWidth: 800, NewCanvasHeight: 600,
+	}
+	if len(messages) != 1 || messages[0] != want {
+		t.Errorf("resize was not dispatched once. Wanted: [%+v], got: %+v", want, messages)
+	}
+
+	// The canvas keeps its scale as the window is resized
+	SetWindowSize(200, 100)
+	if CanvasWidth() != 400 || CanvasHeight() != 200 {
+		t.Errorf("canvas did not keep its scale, got %vx%v", CanvasWidth(), CanvasHeight())
+	}
+
+	SetContentScale(0)
+	if ContentScale() != 2 {
+		t.Errorf("content scale of zero was not ignored, got %v", ContentScale())
+	}
+}